@@ -97,6 +97,8 @@ export function normalizeThresholdRanges(raw) {
       const result = { color };
       if (min !== null) result.min = min;
       if (max !== null) result.max = max;
+      const label = String(entry.label || "").trim();
+      if (label) result.label = label;
       return result;
     })
     .filter(Boolean);
@@ -116,7 +118,32 @@ export function normalizeThresholdGroups(raw) {
       const ranges = normalizeThresholdRanges(entry.ranges);
       if (ranges.length === 0) return null;
       used.add(name);
-      return { name, monitors, ranges };
+      const result = { name, monitors, ranges };
+      const hysteresis = normalizeFiniteNumber(entry.hysteresis);
+      if (hysteresis !== null && hysteresis > 0) result.hysteresis = hysteresis;
+      if (String(entry.direction || "").trim().toLowerCase() === "lower_is_worse") result.direction = "lower_is_worse";
+      const unit = String(entry.unit || "").trim();
+      if (unit) result.unit = unit;
+      return result;
+    })
+    .filter(Boolean);
+}
+
+export function normalizeItemGroups(raw) {
+  if (!Array.isArray(raw)) return [];
+  const used = new Set();
+  return raw
+    .map((entry) => {
+      if (!entry || typeof entry !== "object" || Array.isArray(entry)) return null;
+      const name = String(entry.name || "").trim();
+      if (!name || used.has(name)) return null;
+      used.add(name);
+      const result = { name };
+      const x = Math.round(Number(entry.x) || 0);
+      const y = Math.round(Number(entry.y) || 0);
+      if (x !== 0) result.x = x;
+      if (y !== 0) result.y = y;
+      return result;
     })
     .filter(Boolean);
 }
@@ -144,8 +171,40 @@ export function normalizeItemRenderAttrs(itemType, raw, styleKeySet) {
   }
   delete result.rows;
   delete result.columns;
-  delete result.col_count;
   delete result.row_count;
+  if (normalizedType === "full_heatmap") {
+    const monitors = Array.isArray(result.monitors)
+      ? [...new Set(result.monitors.map((name) => normalizeMonitorName(name)).filter(Boolean))]
+      : [];
+    const colCount = normalizePositiveInt(result.col_count, 0);
+    if (monitors.length > 0) {
+      result.monitors = monitors;
+    } else {
+      delete result.monitors;
+    }
+    if (colCount > 0) {
+      result.col_count = colCount;
+    } else {
+      delete result.col_count;
+    }
+    return result;
+  }
+  delete result.col_count;
+  if (normalizedType === "full_vu_meter") {
+    const monitors = Array.isArray(result.monitors)
+      ? [...new Set(result.monitors.map((name) => normalizeMonitorName(name)).filter(Boolean))]
+      : [];
+    if (monitors.length > 0) {
+      result.monitors = monitors;
+    } else {
+      delete result.monitors;
+    }
+    return result;
+  }
+  delete result.monitors;
+  if (normalizedType !== "simple_line_chart" && normalizedType !== "full_chart") {
+    delete result.stack_monitors;
+  }
   return result;
 }
 
@@ -199,10 +258,13 @@ export function normalizeConfigModel(
   config.name = String(config.name || "web");
   config.width = Math.max(10, Number(config.width || 480));
   config.height = Math.max(10, Number(config.height || 320));
+  config.auto_resolution = config.auto_resolution === true;
   config.layout_padding = Math.max(0, Number(config.layout_padding || 0));
   config.refresh_interval = Math.max(100, Number(config.refresh_interval || 1000));
+  config.max_fps = Math.min(60, Math.max(0, Math.round(Number(config.max_fps || 0))));
   config.collect_warn_ms = Math.max(10, Number(config.collect_warn_ms || 100));
   config.render_wait_max_ms = Math.max(0, Number(config.render_wait_max_ms || 300));
+  config.scale = Math.min(4, Math.max(1, Math.round(Number(config.scale || 1))));
   config.history_size = Math.max(10, Number(config.history_size || 180));
   config.default_history_points = Math.max(10, Number(config.default_history_points || 150));
   config.default_font = String(config.default_font || "");
@@ -215,6 +277,7 @@ export function normalizeConfigModel(
   config.type_defaults = normalizeTypeDefaults(config.type_defaults, styleKeySet, itemTypesRaw);
   delete config.default_thresholds;
   config.threshold_groups = normalizeThresholdGroups(config.threshold_groups);
+  config.groups = normalizeItemGroups(config.groups);
   config.items = Array.isArray(config.items) ? config.items : [];
   const itemIdSet = new Set();
   config.items = config.items.map((item) => {
@@ -226,6 +289,14 @@ export function normalizeConfigModel(
     itemIdSet.add(next.id);
     next.custom_style = config.allow_custom_style ? next.custom_style === true : false;
     next.monitor = normalizeMonitorName(next.monitor);
+    const z = Math.round(Number(next.z) || 0);
+    if (z !== 0) {
+      next.z = z;
+    } else {
+      delete next.z;
+    }
+    next.group = String(next.group || "").trim();
+    if (!next.group) delete next.group;
     next.style = normalizeStyleMap(next.style, styleKeySet);
     next.render_attrs_map = normalizeItemRenderAttrs(next.type, next.render_attrs_map, styleKeySet);
     normalizeItemRangeFields(next);
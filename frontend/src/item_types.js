@@ -12,6 +12,9 @@ export const DEFAULT_ITEM_TYPES = [
   "full_progress_h",
   "full_progress_v",
   "full_gauge",
+  "full_heatmap",
+  "full_vu_meter",
+  "full_timer",
 ];
 
 export const ITEM_TYPE_LABELS = {
@@ -28,6 +31,9 @@ export const ITEM_TYPE_LABELS = {
   full_progress_h: "复杂进度条(横向)",
   full_progress_v: "复杂进度条(竖向)",
   full_gauge: "复杂仪表盘",
+  full_heatmap: "复杂热力图",
+  full_vu_meter: "复杂电平表",
+  full_timer: "番茄钟",
 };
 
 const MONITOR_REQUIRED_TYPE_SET = new Set([
@@ -48,6 +54,8 @@ const RANGE_TYPE_SET = new Set([
   "full_progress_h",
   "full_progress_v",
   "full_gauge",
+  "full_heatmap",
+  "full_vu_meter",
 ]);
 
 export function getItemTypeLabel(type) {
@@ -2,18 +2,76 @@ const MONITOR_ALIASES = {
   disk_default_read_speed: "go_native.disk.total_read",
   disk_default_write_speed: "go_native.disk.total_write",
   disk_default_temp: "go_native.disk.max_temp",
+  disks_total_size: "go_native.disk.total_size",
+  disks_total_used: "go_native.disk.total_used",
+  disks_total_free: "go_native.disk.total_free",
+  net_default_upload: "go_native.net.default.upload",
+  net_default_download: "go_native.net.default.download",
+  net_default_ip: "go_native.net.default.ip",
+  net_default_ip6: "go_native.net.default.ip6",
+  net_default_interface: "go_native.net.default.interface",
 };
 
 const MONITOR_ALIAS_LABELS = {
   disk_default_read_speed: "Disk total read speed",
   disk_default_write_speed: "Disk total write speed",
   disk_default_temp: "Disk max temperature",
+  disks_total_size: "Disks total size",
+  disks_total_used: "Disks total used",
+  disks_total_free: "Disks total free",
+  net_default_upload: "Default net upload",
+  net_default_download: "Default net download",
+  net_default_ip: "Default net ip",
+  net_default_ip6: "Default net ipv6",
+  net_default_interface: "Default net interface",
 };
 
+// Per-slot names such as disk1_temp, mirroring monitorSlotAliases in Go.
+const MONITOR_SLOT_ALIASES = [
+  {
+    pattern: /^disk([1-9][0-9]*)_([a-z_]+)$/,
+    prefix: "go_native.disk",
+    metrics: {
+      name: "name",
+      size: "size",
+      used: "used",
+      available: "available",
+      usage: "usage",
+      temp: "temp",
+      health: "health",
+      busy: "busy",
+      read_speed: "read",
+      write_speed: "write",
+    },
+  },
+  {
+    pattern: /^net([1-9][0-9]*)_([a-z0-9_]+)$/,
+    prefix: "go_native.net",
+    metrics: {
+      upload: "upload",
+      download: "download",
+      ip: "ip",
+      ip6: "ip6",
+      interface: "interface",
+    },
+  },
+];
+
+function resolveSlotAlias(name) {
+  if (name.includes(".")) return "";
+  for (const alias of MONITOR_SLOT_ALIASES) {
+    const match = alias.pattern.exec(name);
+    if (!match) continue;
+    const metric = alias.metrics[match[2]];
+    if (metric) return `${alias.prefix}.${match[1]}.${metric}`;
+  }
+  return "";
+}
+
 export function normalizeMonitorName(raw) {
   const name = String(raw || "").trim();
   if (!name || name === "-") return "";
-  return MONITOR_ALIASES[name] || name;
+  return MONITOR_ALIASES[name] || resolveSlotAlias(name) || name;
 }
 
 export function monitorAliasLabel(raw, labels = null) {
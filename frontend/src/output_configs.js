@@ -2,12 +2,30 @@ export const OUTPUT_TYPE_MEMIMG = "memimg";
 export const OUTPUT_TYPE_AX206USB = "ax206usb";
 export const OUTPUT_TYPE_HTTPPUSH = "httppush";
 export const OUTPUT_TYPE_TCPPUSH = "tcppush";
+export const OUTPUT_TYPE_FILE = "file";
+export const OUTPUT_TYPE_HTTP = "http";
+export const OUTPUT_TYPE_SSD1306 = "ssd1306";
+export const OUTPUT_TYPE_ST7789 = "st7789";
+export const OUTPUT_TYPE_TURING = "turing";
+export const OUTPUT_TYPE_FRAMEBUFFER = "framebuffer";
+export const OUTPUT_TYPE_MQTT = "mqtt";
 
-export const CONFIGURABLE_OUTPUT_TYPES = [OUTPUT_TYPE_AX206USB, OUTPUT_TYPE_HTTPPUSH, OUTPUT_TYPE_TCPPUSH];
+export const CONFIGURABLE_OUTPUT_TYPES = [
+  OUTPUT_TYPE_AX206USB,
+  OUTPUT_TYPE_HTTPPUSH,
+  OUTPUT_TYPE_TCPPUSH,
+  OUTPUT_TYPE_FILE,
+  OUTPUT_TYPE_HTTP,
+  OUTPUT_TYPE_SSD1306,
+  OUTPUT_TYPE_ST7789,
+  OUTPUT_TYPE_TURING,
+  OUTPUT_TYPE_FRAMEBUFFER,
+  OUTPUT_TYPE_MQTT,
+];
 export const DEFAULT_OUTPUT_TYPES = [...CONFIGURABLE_OUTPUT_TYPES];
 const DEFAULT_OUTPUTS = [];
-const OUTPUT_SINGLETON_TYPES = new Set([OUTPUT_TYPE_MEMIMG, OUTPUT_TYPE_AX206USB, OUTPUT_TYPE_HTTPPUSH, OUTPUT_TYPE_TCPPUSH]);
-const OUTPUT_ALLOWED_TYPES = new Set([OUTPUT_TYPE_MEMIMG, OUTPUT_TYPE_AX206USB, OUTPUT_TYPE_HTTPPUSH, OUTPUT_TYPE_TCPPUSH]);
+const OUTPUT_SINGLETON_TYPES = new Set([OUTPUT_TYPE_MEMIMG, ...CONFIGURABLE_OUTPUT_TYPES]);
+const OUTPUT_ALLOWED_TYPES = new Set([OUTPUT_TYPE_MEMIMG, ...CONFIGURABLE_OUTPUT_TYPES]);
 
 export const OUTPUT_FORMAT_OPTIONS = [
   { label: "jpeg", value: "jpeg" },
@@ -15,6 +33,11 @@ export const OUTPUT_FORMAT_OPTIONS = [
   { label: "png", value: "png" },
 ];
 
+export const OUTPUT_FILE_FORMAT_OPTIONS = [
+  { label: "png", value: "png" },
+  { label: "jpeg", value: "jpeg" },
+];
+
 export const OUTPUT_TCP_FORMAT_OPTIONS = [
   { label: "jpeg", value: "jpeg" },
   { label: "rgb565le", value: "rgb565le" },
@@ -22,6 +45,31 @@ export const OUTPUT_TCP_FORMAT_OPTIONS = [
   { label: "index8_rle", value: "index8_rle" },
 ];
 
+export const OUTPUT_AX206_FIT_OPTIONS = [
+  { label: "原样", value: "none" },
+  { label: "拉伸", value: "stretch" },
+  { label: "等比", value: "contain" },
+];
+
+export const OUTPUT_EINK_OPTIONS = [
+  { label: "关闭", value: "" },
+  { label: "黑白", value: "mono" },
+  { label: "4 灰阶", value: "gray4" },
+];
+
+export const OUTPUT_TURING_ORIENTATION_OPTIONS = [
+  { label: "横屏", value: "landscape" },
+  { label: "横屏（翻转）", value: "reverse_landscape" },
+  { label: "竖屏", value: "portrait" },
+  { label: "竖屏（翻转）", value: "reverse_portrait" },
+];
+
+export const OUTPUT_MQTT_QOS_OPTIONS = [
+  { label: "QoS 0", value: 0 },
+  { label: "QoS 1", value: 1 },
+  { label: "QoS 2", value: 2 },
+];
+
 export const OUTPUT_HTTP_METHOD_OPTIONS = [
   { label: "POST", value: "POST" },
   { label: "PUT", value: "PUT" },
@@ -51,6 +99,35 @@ export function isTcpPushType(type) {
   return normalizeOutputType(type) === OUTPUT_TYPE_TCPPUSH;
 }
 
+export function isFileType(type) {
+  return normalizeOutputType(type) === OUTPUT_TYPE_FILE;
+}
+
+export function isHttpServeType(type) {
+  return normalizeOutputType(type) === OUTPUT_TYPE_HTTP;
+}
+
+export function isMiniPanelType(type) {
+  const normalized = normalizeOutputType(type);
+  return normalized === OUTPUT_TYPE_SSD1306 || normalized === OUTPUT_TYPE_ST7789;
+}
+
+export function isST7789Type(type) {
+  return normalizeOutputType(type) === OUTPUT_TYPE_ST7789;
+}
+
+export function isTuringType(type) {
+  return normalizeOutputType(type) === OUTPUT_TYPE_TURING;
+}
+
+export function isFramebufferType(type) {
+  return normalizeOutputType(type) === OUTPUT_TYPE_FRAMEBUFFER;
+}
+
+export function isMQTTType(type) {
+  return normalizeOutputType(type) === OUTPUT_TYPE_MQTT;
+}
+
 export function isAX206Type(type) {
   return normalizeOutputType(type) === OUTPUT_TYPE_AX206USB;
 }
@@ -61,6 +138,43 @@ export function normalizeAX206ReconnectMS(value) {
   return Math.max(100, Math.min(60000, Math.round(reconnectMS)));
 }
 
+export function normalizeAX206WatchdogMS(value) {
+  const watchdogMS = Number(value || 30000);
+  if (!Number.isFinite(watchdogMS)) return 30000;
+  return Math.max(5000, Math.min(600000, Math.round(watchdogMS)));
+}
+
+export function normalizeAX206LatencyBudgetMS(value) {
+  const latencyMS = Number(value || 2000);
+  if (!Number.isFinite(latencyMS)) return 2000;
+  return Math.max(100, Math.min(30000, Math.round(latencyMS)));
+}
+
+export function normalizeAX206TransferTimeoutMS(value) {
+  const timeoutMS = Number(value || 2000);
+  if (!Number.isFinite(timeoutMS)) return 2000;
+  return Math.max(100, Math.min(30000, Math.round(timeoutMS)));
+}
+
+export function normalizeAX206Retries(value) {
+  const retries = Math.round(Number(value || 2));
+  if (!Number.isFinite(retries)) return 2;
+  if (retries < 0) return -1;
+  return Math.min(5, retries);
+}
+
+export function normalizeAX206USBIDs(value) {
+  const items = Array.isArray(value) ? value : String(value || "").split(/[\s,;]+/);
+  const ids = [];
+  items.forEach((item) => {
+    const match = /^(?:0x)?([0-9a-f]{1,4}):(?:0x)?([0-9a-f]{1,4})$/.exec(String(item || "").trim().toLowerCase());
+    if (!match) return;
+    const id = `${match[1].padStart(4, "0")}:${match[2].padStart(4, "0")}`;
+    if (!ids.includes(id)) ids.push(id);
+  });
+  return ids;
+}
+
 export function normalizeHTTPMethod(value) {
   const method = String(value || "POST").trim().toUpperCase();
   return method || "POST";
@@ -122,6 +236,18 @@ export function normalizeHTTPSuccessCodes(raw) {
     });
 }
 
+// E-ink options shared by httppush, tcppush and file outputs.
+function applyEInkFields(entry, item) {
+  const eink = String(item.eink || "").trim().toLowerCase();
+  if (eink !== "mono" && eink !== "gray4") return;
+  entry.eink = eink;
+  entry.invert = item.invert === true;
+  const intervalMS = Number(item.min_interval_ms || 60000);
+  entry.min_interval_ms = Math.max(1, Math.min(3600000, Number.isFinite(intervalMS) ? Math.round(intervalMS) : 60000));
+  const fullRefreshEvery = Number(item.full_refresh_every || 10);
+  entry.full_refresh_every = Math.max(1, Math.min(1000, Number.isFinite(fullRefreshEvery) ? Math.round(fullRefreshEvery) : 10));
+}
+
 export function normalizeOutputEntry(raw) {
   const item = raw && typeof raw === "object" ? raw : { type: raw };
   const type = normalizeOutputType(item.type);
@@ -129,6 +255,20 @@ export function normalizeOutputEntry(raw) {
   const entry = { type, enabled: item.enabled !== false };
   if (type === OUTPUT_TYPE_AX206USB) {
     entry.reconnect_ms = normalizeAX206ReconnectMS(item.reconnect_ms);
+    entry.watchdog_ms = normalizeAX206WatchdogMS(item.watchdog_ms);
+    entry.latency_budget_ms = normalizeAX206LatencyBudgetMS(item.latency_budget_ms);
+    entry.timeout_ms = normalizeAX206TransferTimeoutMS(item.timeout_ms);
+    entry.retries = normalizeAX206Retries(item.retries);
+    entry.device_profile = String(item.device_profile || "ax206").trim().toLowerCase() || "ax206";
+    const usbIDs = normalizeAX206USBIDs(item.usb_ids);
+    if (usbIDs.length > 0) entry.usb_ids = usbIDs;
+    const usbPath = String(item.usb_path || "").trim();
+    if (usbPath) entry.usb_path = usbPath;
+    const usbSerial = String(item.usb_serial || "").trim();
+    if (usbSerial) entry.usb_serial = usbSerial;
+    const fit = String(item.fit || "none").trim().toLowerCase();
+    entry.fit = fit === "stretch" || fit === "contain" ? fit : "none";
+    entry.skip_unchanged = item.skip_unchanged !== false;
   }
   if (type === OUTPUT_TYPE_HTTPPUSH) {
     entry.url = String(item.url || "").trim();
@@ -152,6 +292,10 @@ export function normalizeOutputEntry(raw) {
     entry.file_name = String(item.file_name || "").trim();
     entry.form_fields = normalizeHTTPKeyValueList(item.form_fields);
     entry.success_codes = normalizeHTTPSuccessCodes(item.success_codes);
+    const minIntervalMS = Number(item.min_interval_ms || 0);
+    if (Number.isFinite(minIntervalMS) && minIntervalMS > 0) entry.min_interval_ms = Math.min(3600000, Math.round(minIntervalMS));
+    if (item.skip_unchanged === true) entry.skip_unchanged = true;
+    applyEInkFields(entry, item);
   }
   if (type === OUTPUT_TYPE_TCPPUSH) {
     entry.url = String(item.url || "").trim();
@@ -170,6 +314,97 @@ export function normalizeOutputEntry(raw) {
     entry.busy_check_ms = normalizeTCPBusyCheckMS(item.busy_check_ms);
     entry.file_name = String(item.file_name || "").trim();
     entry.success_codes = normalizeHTTPSuccessCodes(item.success_codes);
+    if (item.skip_unchanged === true) entry.skip_unchanged = true;
+    applyEInkFields(entry, item);
+  }
+  if (type === OUTPUT_TYPE_FILE) {
+    entry.path = String(item.path || "").trim();
+    let format = String(item.format || "").trim().toLowerCase();
+    if (format === "jpg") format = "jpeg";
+    if (format !== "png" && format !== "jpeg") format = /\.jpe?g$/i.test(entry.path) ? "jpeg" : "png";
+    entry.format = format;
+    const qualityRaw = Number(item.quality || 80);
+    entry.quality = Math.max(1, Math.min(100, Number.isFinite(qualityRaw) ? Math.round(qualityRaw) : 80));
+    const fileMode = String(item.file_mode || "").trim();
+    entry.file_mode = /^0?[0-7]{3}$/.test(fileMode) ? fileMode : "0644";
+    entry.atomic = item.atomic !== false;
+    entry.tmpfs = item.tmpfs === true;
+    entry.skip_unchanged = item.skip_unchanged !== false;
+    applyEInkFields(entry, item);
+  }
+  if (type === OUTPUT_TYPE_SSD1306 || type === OUTPUT_TYPE_ST7789) {
+    const st7789 = type === OUTPUT_TYPE_ST7789;
+    entry.device = String(item.device || "").trim() || (st7789 ? "/dev/spidev0.0" : "/dev/i2c-1");
+    const maxSize = st7789 ? 320 : 128;
+    const width = Math.round(Number(item.width || 0));
+    entry.width = width > 0 && width <= maxSize ? width : st7789 ? 240 : 128;
+    const height = Math.round(Number(item.height || 0));
+    if (st7789) entry.height = height > 0 && height <= maxSize ? height : 240;
+    else entry.height = height === 32 || height === 48 ? height : 64;
+    const fit = String(item.fit || "contain").trim().toLowerCase();
+    entry.fit = fit === "stretch" || fit === "none" ? fit : "contain";
+    entry.invert = item.invert === true;
+    entry.skip_unchanged = item.skip_unchanged !== false;
+    if (!st7789) {
+      const address = Math.round(Number(item.i2c_address || 0x3c));
+      entry.i2c_address = address > 0 && address <= 0x7f ? address : 0x3c;
+    } else {
+      const spiMode = Math.round(Number(item.spi_mode || 0));
+      entry.spi_mode = spiMode >= 0 && spiMode <= 3 ? spiMode : 0;
+      const speed = Math.round(Number(item.spi_speed_hz || 40000000));
+      entry.spi_speed_hz = speed > 0 && speed <= 80000000 ? speed : 40000000;
+      entry.gpio_chip = String(item.gpio_chip || "").trim() || "/dev/gpiochip0";
+      ["dc_line", "reset_line"].forEach((key) => {
+        const line = Number(item[key]);
+        if (item[key] !== undefined && item[key] !== null && item[key] !== "" && Number.isFinite(line) && line >= 0) entry[key] = Math.round(line);
+      });
+      entry.offset_x = Math.round(Number(item.offset_x || 0)) || 0;
+      entry.offset_y = Math.round(Number(item.offset_y || 0)) || 0;
+    }
+  }
+  if (type === OUTPUT_TYPE_TURING) {
+    // An empty device uses the platform default, /dev/ttyACM0 or COM3.
+    entry.device = String(item.device || "").trim();
+    const width = Math.round(Number(item.width || 0));
+    entry.width = width > 0 && width <= 1024 ? width : 320;
+    const height = Math.round(Number(item.height || 0));
+    entry.height = height > 0 && height <= 1024 ? height : 480;
+    const baud = Math.round(Number(item.baud || 0));
+    entry.baud = baud > 0 ? baud : 115200;
+    const brightness = Math.round(Number(item.brightness || 0));
+    entry.brightness = brightness > 0 && brightness <= 100 ? brightness : 100;
+    const orientation = String(item.orientation || "").trim().toLowerCase();
+    entry.orientation = OUTPUT_TURING_ORIENTATION_OPTIONS.some((option) => option.value === orientation) ? orientation : "landscape";
+    const fit = String(item.fit || "contain").trim().toLowerCase();
+    entry.fit = fit === "stretch" || fit === "none" ? fit : "contain";
+    entry.skip_unchanged = item.skip_unchanged !== false;
+  }
+  if (type === OUTPUT_TYPE_FRAMEBUFFER) {
+    entry.device = String(item.device || "").trim() || "/dev/fb0";
+    const fit = String(item.fit || "contain").trim().toLowerCase();
+    entry.fit = fit === "stretch" || fit === "none" ? fit : "contain";
+    entry.skip_unchanged = item.skip_unchanged !== false;
+  }
+  if (type === OUTPUT_TYPE_MQTT) {
+    entry.url = String(item.url || "").trim();
+    const topic = String(item.topic || "").trim();
+    entry.topic = topic && !/[+#]/.test(topic) ? topic : "ax206monitor/frame";
+    const valuesTopic = String(item.values_topic || "").trim();
+    entry.values_topic = valuesTopic && !/[+#]/.test(valuesTopic) ? valuesTopic : "ax206monitor/values";
+    const qos = Math.round(Number(item.qos || 0));
+    entry.qos = qos === 1 || qos === 2 ? qos : 0;
+    entry.retain = item.retain === true;
+    entry.client_id = String(item.client_id || "").trim();
+    entry.auth_username = String(item.auth_username || "").trim();
+    entry.auth_password = String(item.auth_password || "").trim();
+    entry.timeout_ms = normalizeHTTPTimeoutMS(item.timeout_ms);
+    const intervalMS = Number(item.min_interval_ms || 5000);
+    entry.min_interval_ms = Math.max(200, Math.min(3600000, Number.isFinite(intervalMS) ? Math.round(intervalMS) : 5000));
+  }
+  if (type === OUTPUT_TYPE_HTTP) {
+    entry.listen = String(item.listen || "").trim() || "127.0.0.1:18087";
+    const qualityRaw = Number(item.quality || 80);
+    entry.quality = Math.max(1, Math.min(100, Number.isFinite(qualityRaw) ? Math.round(qualityRaw) : 80));
   }
   return entry;
 }
@@ -187,8 +422,17 @@ export function normalizeOutputs(rawOutputs, rawTypes) {
     if (!entry) return;
     if (entry.type === OUTPUT_TYPE_MEMIMG) return;
     if (OUTPUT_SINGLETON_TYPES.has(entry.type)) {
-      if (singleton.has(entry.type)) return;
-      singleton.add(entry.type);
+      // Several AX206 frames may coexist when each is bound to its own USB path or serial,
+      // and several files when each has its own path.
+      let key = entry.type;
+      if (entry.type === OUTPUT_TYPE_AX206USB) key = `${entry.type}|${entry.usb_path || ""}|${entry.usb_serial || ""}`;
+      if (entry.type === OUTPUT_TYPE_FILE) key = `${entry.type}|${entry.path}`;
+      if (entry.type === OUTPUT_TYPE_HTTP) key = `${entry.type}|${entry.listen}`;
+      if (entry.type === OUTPUT_TYPE_SSD1306 || entry.type === OUTPUT_TYPE_ST7789) key = `${entry.type}|${entry.device}|${entry.i2c_address || 0}`;
+      if (entry.type === OUTPUT_TYPE_TURING || entry.type === OUTPUT_TYPE_FRAMEBUFFER) key = `${entry.type}|${entry.device}`;
+      if (entry.type === OUTPUT_TYPE_MQTT) key = `${entry.type}|${entry.url}|${entry.topic}|${entry.values_topic}`;
+      if (singleton.has(key)) return;
+      singleton.add(key);
     }
     list.push(entry);
   });
@@ -251,6 +495,37 @@ export function createDefaultOutputEntry(type = OUTPUT_TYPE_AX206USB) {
       success_codes: [],
     };
   }
+  if (normalized === OUTPUT_TYPE_FILE) {
+    return {
+      type: OUTPUT_TYPE_FILE,
+      enabled: true,
+      path: "ax206monitor.png",
+      format: "png",
+      quality: 80,
+      file_mode: "0644",
+      atomic: true,
+      tmpfs: true,
+      skip_unchanged: true,
+    };
+  }
+  if (normalized === OUTPUT_TYPE_SSD1306) {
+    return normalizeOutputEntry({ type: OUTPUT_TYPE_SSD1306 });
+  }
+  if (normalized === OUTPUT_TYPE_ST7789) {
+    return normalizeOutputEntry({ type: OUTPUT_TYPE_ST7789, dc_line: 25, reset_line: 27 });
+  }
+  if (normalized === OUTPUT_TYPE_TURING) {
+    return normalizeOutputEntry({ type: OUTPUT_TYPE_TURING });
+  }
+  if (normalized === OUTPUT_TYPE_FRAMEBUFFER) {
+    return normalizeOutputEntry({ type: OUTPUT_TYPE_FRAMEBUFFER });
+  }
+  if (normalized === OUTPUT_TYPE_MQTT) {
+    return normalizeOutputEntry({ type: OUTPUT_TYPE_MQTT, url: "mqtt://127.0.0.1:1883" });
+  }
+  if (normalized === OUTPUT_TYPE_HTTP) {
+    return { type: OUTPUT_TYPE_HTTP, enabled: true, listen: "127.0.0.1:18087", quality: 80 };
+  }
   if (normalized === OUTPUT_TYPE_AX206USB) {
     return { type: OUTPUT_TYPE_AX206USB, enabled: true, reconnect_ms: 3000 };
   }
@@ -2,6 +2,28 @@ export const STYLE_SCOPE_BASE = "base";
 export const STYLE_SCOPE_TYPE = "type";
 export const STYLE_SCOPE_ITEM = "item";
 
+const TABULAR_NUMBER_TYPES = [
+  "simple_value",
+  "simple_progress",
+  "label_text",
+  "full_chart",
+  "full_table",
+  "full_progress_h",
+  "full_progress_v",
+  "full_gauge",
+];
+
+const ALERT_EFFECT_TYPES = [
+  "simple_value",
+  "simple_progress",
+  "simple_line_chart",
+  "label_text",
+  "full_chart",
+  "full_progress_h",
+  "full_progress_v",
+  "full_gauge",
+];
+
 export const DEFAULT_STYLE_KEYS = [
   { key: "font_family", label: "字体", kind: "select", scopes: [STYLE_SCOPE_BASE] },
   { key: "text_font_size", label: "文本字号", kind: "int", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM] },
@@ -13,19 +35,69 @@ export const DEFAULT_STYLE_KEYS = [
   { key: "border_width", label: "边框宽度", kind: "float", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM] },
   { key: "border_color", label: "边框颜色", kind: "color", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM] },
   { key: "radius", label: "圆角", kind: "int", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM] },
+  { key: "shadow_color", label: "阴影颜色", kind: "color", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM] },
+  { key: "shadow_offset_x", label: "阴影偏移X", kind: "float", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM] },
+  { key: "shadow_offset_y", label: "阴影偏移Y", kind: "float", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM] },
+  { key: "shadow_blur", label: "阴影模糊", kind: "float", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM] },
   { key: "history_points", label: "历史点数", kind: "int", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart"] },
-  { key: "content_padding_x", label: "左右边距", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["label_text", "full_chart", "full_table", "full_progress_h", "full_progress_v", "full_gauge"] },
-  { key: "content_padding_y", label: "上下边距", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["label_text", "full_chart", "full_table", "full_progress_h", "full_progress_v", "full_gauge"] },
-  { key: "body_gap", label: "标题间距", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_progress_h"] },
-  { key: "header_height", label: "标题栏高度", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_progress_h"] },
-  { key: "header_divider", label: "标题分隔线", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_progress_h"] },
-  { key: "header_divider_width", label: "分隔线宽", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_progress_h"] },
-  { key: "header_divider_offset", label: "分隔线偏移", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_progress_h"] },
-  { key: "header_divider_color", label: "分隔线颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_progress_h"] },
+  { key: "history_stride", label: "采样步长", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart"] },
+  {
+    key: "text_align",
+    label: "水平对齐",
+    kind: "select",
+    scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM],
+    types: ["simple_label", "simple_value", "label_text"],
+    options: [
+      { label: "左对齐", value: "left" },
+      { label: "居中", value: "center" },
+      { label: "右对齐", value: "right" },
+      { label: "两端对齐", value: "justify" },
+    ],
+  },
+  {
+    key: "text_valign",
+    label: "垂直对齐",
+    kind: "select",
+    scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM],
+    types: ["simple_label", "simple_value", "label_text"],
+    options: [
+      { label: "顶部", value: "top" },
+      { label: "居中", value: "middle" },
+      { label: "底部", value: "bottom" },
+    ],
+  },
+  { key: "text_wrap", label: "自动换行", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_label"] },
+  { key: "text_line_spacing", label: "行距倍数", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_label"] },
+  { key: "text_ellipsis", label: "超长省略", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_label", "simple_value", "label_text"] },
+  {
+    key: "label_position",
+    label: "标签位置",
+    kind: "select",
+    scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM],
+    types: ["label_text"],
+    options: [
+      { label: "左侧", value: "left" },
+      { label: "上方", value: "above" },
+      { label: "下方", value: "below" },
+      { label: "同行", value: "inline" },
+    ],
+  },
+  { key: "label_gap", label: "标签间距", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["label_text"] },
+  { key: "content_padding_x", label: "左右边距", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_progress", "simple_line_chart", "label_text", "full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_timer", "full_progress_h", "full_progress_v", "full_gauge"] },
+  { key: "content_padding_y", label: "上下边距", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_progress", "simple_line_chart", "label_text", "full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_timer", "full_progress_h", "full_progress_v", "full_gauge"] },
+  { key: "body_gap", label: "标题间距", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_progress_h"] },
+  { key: "header_height", label: "标题栏高度", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_progress_h"] },
+  { key: "header_divider", label: "标题分隔线", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_progress_h"] },
+  { key: "header_divider_width", label: "分隔线宽", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_progress_h"] },
+  { key: "header_divider_offset", label: "分隔线偏移", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_progress_h"] },
+  { key: "header_divider_color", label: "分隔线颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_progress_h"] },
   { key: "show_segment_lines", label: "分段线", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
-  { key: "show_grid_lines", label: "网格线", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
-  { key: "grid_lines", label: "网格线数量", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
-  { key: "enable_threshold_colors", label: "阈值分段颜色", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart"] },
+  { key: "show_grid_lines", label: "网格线", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart"] },
+  { key: "grid_lines", label: "网格线数量", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart"] },
+  { key: "show_axis_labels", label: "最值标签", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart"] },
+  { key: "show_threshold_lines", label: "阈值参考线", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart"] },
+  { key: "show_time_span", label: "时间跨度", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart"] },
+  { key: "enable_threshold_colors", label: "阈值分段颜色", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart", "full_heatmap"] },
   { key: "line_width", label: "线宽", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "simple_line", "full_chart"] },
   {
     key: "line_orientation",
@@ -36,19 +108,37 @@ export const DEFAULT_STYLE_KEYS = [
     options: [
       { label: "横向", value: "horizontal" },
       { label: "竖向", value: "vertical" },
+      { label: "左上到右下", value: "diagonal_down" },
+      { label: "左下到右上", value: "diagonal_up" },
     ],
   },
+  {
+    key: "line_dash",
+    label: "线型",
+    kind: "select",
+    scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM],
+    types: ["simple_line"],
+    options: [
+      { label: "实线", value: "solid" },
+      { label: "虚线", value: "dashed" },
+      { label: "点线", value: "dotted" },
+    ],
+  },
+  { key: "line_dash_length", label: "虚线段长", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line"] },
+  { key: "log_scale", label: "对数刻度", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart"] },
   { key: "show_avg_line", label: "均线", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
   { key: "chart_color", label: "折线颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
-  { key: "chart_fill_color", label: "折线区域颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
+  { key: "chart_fill_color", label: "折线区域颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart"] },
   { key: "chart_area_bg", label: "图表区背景", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
   { key: "chart_area_border_color", label: "图表区边框", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
+  { key: "chart_area_radius", label: "图表区圆角", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart"] },
+  { key: "grid_line_color", label: "网格线颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_line_chart", "full_chart"] },
   {
     key: "progress_style",
     label: "进度样式",
     kind: "select",
     scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM],
-    types: ["full_progress_h", "full_progress_v"],
+    types: ["simple_progress", "full_progress_h", "full_progress_v"],
     options: [
       { label: "gradient", value: "gradient" },
       { label: "solid", value: "solid" },
@@ -56,12 +146,38 @@ export const DEFAULT_STYLE_KEYS = [
       { label: "stripes", value: "stripes" },
     ],
   },
-  { key: "bar_height", label: "条高度", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_progress_h", "full_progress_v"] },
+  { key: "bar_height", label: "条高度", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_progress_h", "full_progress_v", "full_timer"] },
   { key: "bar_radius", label: "条圆角", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_progress_h", "full_progress_v"] },
-  { key: "track_color", label: "轨道颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_progress_h", "full_progress_v", "full_gauge"] },
-  { key: "segments", label: "分段数量", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_progress_h", "full_progress_v"] },
-  { key: "segment_gap", label: "分段间隔", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_progress_h", "full_progress_v"] },
-  { key: "card_radius", label: "外框圆角", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_progress_h", "full_progress_v", "full_gauge"] },
+  { key: "track_color", label: "轨道颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_progress", "full_progress_h", "full_progress_v", "full_gauge", "full_vu_meter", "full_timer"] },
+  { key: "segments", label: "分段数量", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_progress", "full_progress_h", "full_progress_v", "full_vu_meter"] },
+  { key: "segment_gap", label: "分段间隔", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_progress", "full_progress_h", "full_progress_v", "full_vu_meter"] },
+  {
+    key: "progress_orientation",
+    label: "进度方向",
+    kind: "select",
+    scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM],
+    types: ["simple_progress", "full_vu_meter"],
+    options: [
+      { label: "横向", value: "horizontal" },
+      { label: "竖向", value: "vertical" },
+    ],
+  },
+  { key: "round_caps", label: "圆头", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_progress"] },
+  { key: "tick_count", label: "刻度数量", kind: "int", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_progress"] },
+  { key: "tick_color", label: "刻度颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["simple_progress"] },
+  {
+    key: "value_position",
+    label: "数值位置",
+    kind: "select",
+    scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM],
+    types: ["simple_progress"],
+    options: [
+      { label: "居中", value: "center" },
+      { label: "条内", value: "inside" },
+      { label: "隐藏", value: "none" },
+    ],
+  },
+  { key: "card_radius", label: "外框圆角", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_chart", "full_table", "full_heatmap", "full_vu_meter", "full_timer", "full_progress_h", "full_progress_v", "full_gauge"] },
   { key: "table_row_gap", label: "行间距", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_table"] },
   { key: "table_row_radius", label: "行圆角", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_table"] },
   { key: "table_row_bg", label: "行背景", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_table"] },
@@ -69,9 +185,38 @@ export const DEFAULT_STYLE_KEYS = [
   { key: "table_column_gap", label: "列间距", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_table"] },
   { key: "table_label_width_ratio", label: "标签列比例", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_table"] },
   { key: "table_show_units", label: "显示单位", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_table"] },
+  { key: "heatmap_low_color", label: "低值颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_heatmap"] },
+  { key: "heatmap_high_color", label: "高值颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_heatmap"] },
+  { key: "heatmap_cell_gap", label: "单元间距", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_heatmap"] },
+  { key: "heatmap_cell_radius", label: "单元圆角", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_heatmap"] },
+  { key: "heatmap_show_values", label: "单元数值", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_heatmap"] },
+  { key: "vu_low_color", label: "低电平颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_vu_meter"] },
+  { key: "vu_mid_color", label: "中电平颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_vu_meter"] },
+  { key: "vu_high_color", label: "高电平颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_vu_meter"] },
+  { key: "vu_mid_at", label: "中电平起点(%)", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_vu_meter"] },
+  { key: "vu_high_at", label: "高电平起点(%)", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_vu_meter"] },
+  { key: "timer_work_color", label: "专注颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_timer"] },
+  { key: "timer_break_color", label: "短休息颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_timer"] },
+  { key: "timer_long_break_color", label: "长休息颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_timer"] },
+  { key: "timer_idle_color", label: "暂停颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_timer"] },
   { key: "gauge_thickness", label: "仪表盘厚度", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_gauge"] },
   { key: "gauge_gap_degrees", label: "底部缺口角度", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_gauge"] },
   { key: "gauge_text_gap", label: "文字间距", kind: "float", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ["full_gauge"] },
+  { key: "show_level_label", label: "显示阈值标签", kind: "bool", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ALERT_EFFECT_TYPES },
+  { key: "tabular_numbers", label: "等宽数字", kind: "bool", scopes: [STYLE_SCOPE_BASE, STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: TABULAR_NUMBER_TYPES },
+  {
+    key: "alert_effect",
+    label: "越限动画",
+    kind: "select",
+    scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM],
+    types: ALERT_EFFECT_TYPES,
+    options: [
+      { label: "无", value: "none" },
+      { label: "闪烁", value: "blink" },
+      { label: "呼吸", value: "pulse" },
+    ],
+  },
+  { key: "alert_color", label: "越限动画颜色", kind: "color", scopes: [STYLE_SCOPE_TYPE, STYLE_SCOPE_ITEM], types: ALERT_EFFECT_TYPES },
 ];
 
 export function normalizeStyleKeys(raw) {
@@ -93,18 +93,21 @@ function frequencyUnitScale(unit) {
   }
 }
 
-function buildNamedRangeSpec(family, min, max) {
-  return {
+function buildNamedRangeSpec(family, min, max, unit = "") {
+  const spec = {
     name: `${family}_${formatNumberToken(min)}_${formatNumberToken(max)}`,
     min,
     max,
   };
+  if (unit) spec.unit = unit;
+  return spec;
 }
 
+// Throughput and frequency groups are written in base units; the backend
+// converts each monitor's value, so KiB/s and MiB/s monitors share a group.
 function inferThroughputSpec(unit) {
-  const scale = throughputUnitScale(unit);
-  if (!scale) return null;
-  return buildNamedRangeSpec("throughput", 0, THROUGHPUT_MAX_BYTES_PER_SEC / scale);
+  if (!throughputUnitScale(unit)) return null;
+  return buildNamedRangeSpec("throughput", 0, THROUGHPUT_MAX_BYTES_PER_SEC, "B/s");
 }
 
 function inferRPMSpec(unit, name, label) {
@@ -123,19 +126,19 @@ function inferRPMSpec(unit, name, label) {
 }
 
 function inferFrequencySpec(unit) {
-  const scale = frequencyUnitScale(unit);
-  if (!scale) return null;
-  return buildNamedRangeSpec("frequency", 0, FREQUENCY_MAX_HZ / scale);
+  if (!frequencyUnitScale(unit)) return null;
+  return buildNamedRangeSpec("frequency", 0, FREQUENCY_MAX_HZ, "Hz");
 }
 
-function createStandardRanges(min, max) {
+function createStandardRanges(min, max, direction = "") {
   const start = Number(min);
   const end = Number(max);
   if (!Number.isFinite(start) || !Number.isFinite(end) || end <= start) {
     return [];
   }
   const step = (end - start) / 4;
-  return AUTO_RANGE_COLORS.map((color, index) => {
+  const colors = direction === "lower_is_worse" ? [...AUTO_RANGE_COLORS].reverse() : AUTO_RANGE_COLORS;
+  return colors.map((color, index) => {
     const entry = { color };
     if (index > 0) {
       entry.min = start + step * index;
@@ -187,7 +190,20 @@ function collectMonitorMeta({ config, snapshot, monitorOptions }) {
   return [...candidates.values()];
 }
 
+function isLowerWorseCandidate(name, label) {
+  const text = `${normalizeSearchText(name)} ${normalizeSearchText(label)}`;
+  return /\bfps\b|\bbattery\b|\bfree\b|\bavailable\b|电量|剩余|可用/.test(text);
+}
+
 function inferThresholdSpec(candidate) {
+  const spec = inferBaseThresholdSpec(candidate);
+  if (!spec || !isLowerWorseCandidate(candidate.name, candidate.label)) {
+    return spec;
+  }
+  return { ...spec, name: `${spec.name}_low`, direction: "lower_is_worse" };
+}
+
+function inferBaseThresholdSpec(candidate) {
   if (!candidate || !candidate.name) return null;
   const { name, label, unit, min, max } = candidate;
   const unitProfile = inferUnitRangeProfile(unit);
@@ -226,7 +242,9 @@ function buildAutoGroups({ config, snapshot, monitorOptions }) {
     const entry = grouped.get(spec.name) || {
       name: spec.name,
       monitors: [],
-      ranges: createStandardRanges(spec.min, spec.max),
+      ranges: createStandardRanges(spec.min, spec.max, spec.direction),
+      direction: spec.direction,
+      unit: spec.unit,
     };
     entry.monitors.push(candidate.name);
     grouped.set(spec.name, entry);
@@ -238,6 +256,7 @@ export function buildMergedAutoThresholdGroups({ existingGroups = [], config, sn
   const merged = new Map();
   normalizeThresholdGroups(existingGroups).forEach((group) => {
     merged.set(group.name, {
+      ...group,
       name: group.name,
       monitors: Array.isArray(group.monitors) ? [...group.monitors] : [],
       ranges: Array.isArray(group.ranges) ? group.ranges.map((item) => ({ ...(item || {}) })) : [],
@@ -251,6 +270,7 @@ export function buildMergedAutoThresholdGroups({ existingGroups = [], config, sn
     }
     previous.monitors = [...new Set([...(previous.monitors || []), ...(group.monitors || [])])];
     previous.ranges = group.ranges;
+    previous.unit = group.unit;
     merged.set(group.name, previous);
   });
   return normalizeThresholdGroups([...merged.values()]);
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	parecRate     = 44100
+	parecChannels = 2
+	// parecBlockFrames is 20 ms of audio, one meter update.
+	parecBlockFrames = parecRate / 50
+)
+
+// runParec records device as raw 16-bit stereo until stop is closed and
+// emits the peak of each 20 ms block.
+func runParec(command, device string, stop <-chan struct{}, emit func(audioSample)) error {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return fmt.Errorf("%s not found: %w", command, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path,
+		"--raw",
+		"--format=s16le",
+		"--rate="+strconv.Itoa(parecRate),
+		"--channels="+strconv.Itoa(parecChannels),
+		"--latency-msec=20",
+		"--client-name=ax206monitor",
+		"--device="+device,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-done:
+		}
+	}()
+	readErr := readPCMPeaks(stdout, parecChannels, parecBlockFrames, time.Now, emit)
+	waitErr := cmd.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if waitErr != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%w: %s", waitErr, message)
+		}
+		return waitErr
+	}
+	if readErr != nil {
+		return readErr
+	}
+	return errors.New("parec exited")
+}
+
+// readPCMPeaks reads interleaved signed 16-bit little-endian samples and
+// emits the peak of each channel over every block of blockFrames frames.
+func readPCMPeaks(r io.Reader, channels, blockFrames int, now func() time.Time, emit func(audioSample)) error {
+	buf := make([]byte, channels*blockFrames*2)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		peaks := make([]float64, channels)
+		for offset := 0; offset+1 < len(buf); offset += 2 {
+			value := float64(int16(binary.LittleEndian.Uint16(buf[offset:]))) / 32768
+			if value < 0 {
+				value = -value
+			}
+			channel := (offset / 2) % channels
+			if value > peaks[channel] {
+				peaks[channel] = value
+			}
+		}
+		emit(audioSample{at: now(), peaks: peaks})
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package main
+
+import "errors"
+
+func runWASAPIPeakMeter(stop <-chan struct{}, emit func(audioSample)) error {
+	return errors.New("wasapi is only supported on windows")
+}
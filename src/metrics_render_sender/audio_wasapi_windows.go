@@ -0,0 +1,145 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	wasapiPollInterval = 20 * time.Millisecond
+	// wasapiEndpointRefresh is how often the default endpoint is looked up
+	// again, so the meter follows a switch to headphones or a headset.
+	wasapiEndpointRefresh = 5 * time.Second
+
+	clsctxAll   = 0x17
+	eRender     = 0
+	eMultimedia = 1
+	comRelease  = 2
+	sFalse      = 1
+
+	// Method indexes in the vtables, after the three IUnknown methods.
+	mmDeviceEnumeratorGetDefaultAudioEndpoint = 4
+	mmDeviceActivate                          = 3
+	audioMeterGetMeteringChannelCount         = 4
+	audioMeterGetChannelsPeakValues           = 5
+)
+
+var (
+	ole32DLL             = windows.NewLazySystemDLL("ole32.dll")
+	procCoCreateInstance = ole32DLL.NewProc("CoCreateInstance")
+
+	clsidMMDeviceEnumerator   = windows.GUID{Data1: 0xbcde0395, Data2: 0xe52f, Data3: 0x467c, Data4: [8]byte{0x8e, 0x3d, 0xc4, 0x57, 0x92, 0x91, 0x69, 0x2e}}
+	iidIMMDeviceEnumerator    = windows.GUID{Data1: 0xa95664d2, Data2: 0x9614, Data3: 0x4f35, Data4: [8]byte{0xa7, 0x46, 0xde, 0x8d, 0xb6, 0x36, 0x17, 0xe6}}
+	iidIAudioMeterInformation = windows.GUID{Data1: 0xc02216f6, Data2: 0x8c67, Data3: 0x4b5b, Data4: [8]byte{0x9d, 0x00, 0xd0, 0x08, 0xe7, 0x3e, 0x00, 0x64}}
+)
+
+// comObject is the start of any COM interface: a pointer to its vtable.
+type comObject struct {
+	vtbl *[8]uintptr
+}
+
+func (o *comObject) call(method int, args ...uintptr) error {
+	hr, _, _ := syscall.SyscallN(o.vtbl[method], append([]uintptr{uintptr(unsafe.Pointer(o))}, args...)...)
+	if int32(hr) < 0 {
+		return fmt.Errorf("hresult 0x%08x", uint32(hr))
+	}
+	return nil
+}
+
+func (o *comObject) release() {
+	if o != nil {
+		_, _, _ = syscall.SyscallN(o.vtbl[comRelease], uintptr(unsafe.Pointer(o)))
+	}
+}
+
+// runWASAPIPeakMeter polls the peak meter of the default render endpoint,
+// the level Windows shows in the volume mixer, until stop is closed. It
+// reads what is being played without opening a loopback capture stream.
+func runWASAPIPeakMeter(stop <-chan struct{}, emit func(audioSample)) error {
+	// COM objects belong to the thread that created them.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if err := windows.CoInitializeEx(0, windows.COINIT_MULTITHREADED); err != nil {
+		// S_FALSE only says COM was already initialized on this thread.
+		if errno, ok := err.(syscall.Errno); !ok || errno != sFalse {
+			return fmt.Errorf("CoInitializeEx: %w", err)
+		}
+	}
+	defer windows.CoUninitialize()
+
+	var enumerator *comObject
+	hr, _, _ := procCoCreateInstance.Call(
+		uintptr(unsafe.Pointer(&clsidMMDeviceEnumerator)),
+		0,
+		clsctxAll,
+		uintptr(unsafe.Pointer(&iidIMMDeviceEnumerator)),
+		uintptr(unsafe.Pointer(&enumerator)),
+	)
+	if int32(hr) < 0 || enumerator == nil {
+		return fmt.Errorf("create device enumerator: hresult 0x%08x", uint32(hr))
+	}
+	defer enumerator.release()
+
+	ticker := time.NewTicker(wasapiPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := pollWASAPIEndpoint(enumerator, stop, ticker.C, emit); err != nil {
+			return err
+		}
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+	}
+}
+
+// pollWASAPIEndpoint reads the current default endpoint for
+// wasapiEndpointRefresh, or until stop is closed.
+func pollWASAPIEndpoint(enumerator *comObject, stop <-chan struct{}, tick <-chan time.Time, emit func(audioSample)) error {
+	var device *comObject
+	if err := enumerator.call(mmDeviceEnumeratorGetDefaultAudioEndpoint, eRender, eMultimedia, uintptr(unsafe.Pointer(&device))); err != nil {
+		return fmt.Errorf("no default playback device: %w", err)
+	}
+	defer device.release()
+	var meter *comObject
+	if err := device.call(mmDeviceActivate, uintptr(unsafe.Pointer(&iidIAudioMeterInformation)), clsctxAll, 0, uintptr(unsafe.Pointer(&meter))); err != nil {
+		return fmt.Errorf("activate peak meter: %w", err)
+	}
+	defer meter.release()
+	var channels uint32
+	if err := meter.call(audioMeterGetMeteringChannelCount, uintptr(unsafe.Pointer(&channels))); err != nil {
+		return fmt.Errorf("peak meter channels: %w", err)
+	}
+	if channels == 0 {
+		return fmt.Errorf("playback device has no channels")
+	}
+
+	values := make([]float32, channels)
+	deadline := time.Now().Add(wasapiEndpointRefresh)
+	for {
+		select {
+		case <-stop:
+			return nil
+		case now := <-tick:
+			if err := meter.call(audioMeterGetChannelsPeakValues, uintptr(channels), uintptr(unsafe.Pointer(&values[0]))); err != nil {
+				return fmt.Errorf("read peak meter: %w", err)
+			}
+			peaks := make([]float64, len(values))
+			for idx, value := range values {
+				peaks[idx] = float64(value)
+			}
+			emit(audioSample{at: now, peaks: peaks})
+			if now.After(deadline) {
+				return nil
+			}
+		}
+	}
+}
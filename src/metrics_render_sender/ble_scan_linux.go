@@ -0,0 +1,116 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	hciCommandPacket = 0x01
+	hciEventPacket   = 0x04
+
+	hciEventLEMeta          = 0x3E
+	hciSubeventLEAdvReport  = 0x02
+	hciOpLESetScanParams    = 0x200B
+	hciOpLESetScanEnable    = 0x200C
+	hciSocketFilterOption   = 2
+	hciSocketReadTimeout    = 500 * time.Millisecond
+	hciScanIntervalSlots    = 0x0060
+	hciScanWindowSlots      = 0x0030
+	hciAdvertisingBufferLen = 260
+)
+
+// runBLEScan opens a raw HCI socket on adapter, enables passive LE scanning
+// and feeds every advertising report to handle until stopCh is closed. The
+// raw socket needs CAP_NET_RAW and CAP_NET_ADMIN.
+func runBLEScan(adapter int, stopCh <-chan struct{}, handle func(mac string, data []byte)) error {
+	fd, err := unix.Socket(unix.AF_BLUETOOTH, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.BTPROTO_HCI)
+	if err != nil {
+		return fmt.Errorf("open hci socket: %w", err)
+	}
+	defer unix.Close(fd)
+	if err := unix.Bind(fd, &unix.SockaddrHCI{Dev: uint16(adapter), Channel: unix.HCI_CHANNEL_RAW}); err != nil {
+		return fmt.Errorf("bind hci%d: %w", adapter, err)
+	}
+
+	// struct hci_filter: type mask, two event mask words, opcode, padding.
+	filter := make([]byte, 16)
+	binary.LittleEndian.PutUint32(filter[0:4], 1<<hciEventPacket)
+	binary.LittleEndian.PutUint32(filter[8:12], 1<<(hciEventLEMeta-32))
+	if err := unix.SetsockoptString(fd, unix.SOL_HCI, hciSocketFilterOption, string(filter)); err != nil {
+		return fmt.Errorf("set hci filter: %w", err)
+	}
+	timeout := unix.NsecToTimeval(hciSocketReadTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		return fmt.Errorf("set hci read timeout: %w", err)
+	}
+
+	params := make([]byte, 7)
+	binary.LittleEndian.PutUint16(params[1:3], hciScanIntervalSlots)
+	binary.LittleEndian.PutUint16(params[3:5], hciScanWindowSlots)
+	if err := writeHCICommand(fd, hciOpLESetScanParams, params); err != nil {
+		return err
+	}
+	if err := writeHCICommand(fd, hciOpLESetScanEnable, []byte{1, 0}); err != nil {
+		return err
+	}
+	defer writeHCICommand(fd, hciOpLESetScanEnable, []byte{0, 0})
+
+	buf := make([]byte, hciAdvertisingBufferLen)
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		default:
+		}
+		n, err := unix.Read(fd, buf)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return fmt.Errorf("read hci%d: %w", adapter, err)
+		}
+		dispatchHCIAdvertisingReports(buf[:n], handle)
+	}
+}
+
+func writeHCICommand(fd int, opcode uint16, params []byte) error {
+	packet := make([]byte, 4, 4+len(params))
+	packet[0] = hciCommandPacket
+	binary.LittleEndian.PutUint16(packet[1:3], opcode)
+	packet[3] = byte(len(params))
+	packet = append(packet, params...)
+	if _, err := unix.Write(fd, packet); err != nil {
+		return fmt.Errorf("hci command 0x%04x: %w", opcode, err)
+	}
+	return nil
+}
+
+// dispatchHCIAdvertisingReports walks an LE advertising report event. Each
+// report is event type, address type, address (little endian), data length,
+// data and RSSI.
+func dispatchHCIAdvertisingReports(packet []byte, handle func(mac string, data []byte)) {
+	if len(packet) < 5 || packet[0] != hciEventPacket || packet[1] != hciEventLEMeta || packet[3] != hciSubeventLEAdvReport {
+		return
+	}
+	count := int(packet[4])
+	reports := packet[5:]
+	for idx := 0; idx < count && len(reports) >= 9; idx++ {
+		address := make([]byte, 6)
+		for b := 0; b < 6; b++ {
+			address[b] = reports[7-b]
+		}
+		length := int(reports[8])
+		if len(reports) < 9+length+1 {
+			return
+		}
+		handle(formatBLEAddress(address), reports[9:9+length])
+		reports = reports[9+length+1:]
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package main
+
+import "fmt"
+
+func runBLEScan(adapter int, stopCh <-chan struct{}, handle func(mac string, data []byte)) error {
+	return fmt.Errorf("ble scanning is not supported on this platform")
+}
@@ -3,10 +3,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"sync"
 
@@ -113,7 +114,7 @@ func detectBtrfsRootSourceOnce() (btrfsRootSource, bool) {
 }
 
 func readRootBtrfsMountInfo() (btrfsRootMountInfo, bool) {
-	data, err := os.ReadFile("/proc/self/mountinfo")
+	data, err := sensors.ReadFile("/proc/self/mountinfo")
 	if err != nil {
 		return btrfsRootMountInfo{}, false
 	}
@@ -293,21 +294,13 @@ func readOptionalBtrfsUint(path string) (uint64, bool, error) {
 	if strings.TrimSpace(path) == "" {
 		return 0, false, nil
 	}
-	data, err := os.ReadFile(path)
+	value, err := sensors.ReadAttrUint(path)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return 0, false, nil
 		}
 		return 0, false, err
 	}
-	trimmed := strings.TrimSpace(string(data))
-	if trimmed == "" {
-		return 0, false, fmt.Errorf("empty btrfs sysfs value: %s", path)
-	}
-	value, err := strconv.ParseUint(trimmed, 10, 64)
-	if err != nil {
-		return 0, false, fmt.Errorf("invalid btrfs sysfs value %s: %w", path, err)
-	}
 	return value, true, nil
 }
 
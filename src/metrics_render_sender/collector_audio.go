@@ -0,0 +1,278 @@
+package main
+
+import (
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// audioMeterFloorDB is the bottom of the meter scale; quieter is 0 %.
+	audioMeterFloorDB = -60.0
+	// audioMeterReleaseDBPerSecond is how fast the meter falls back once
+	// the signal drops, like the slow return of a VU needle.
+	audioMeterReleaseDBPerSecond = 24.0
+	// audioPeakHold is how long audio.peak holds the highest level.
+	audioPeakHold = 2 * time.Second
+	// audioStaleAfter hides the values once the source stops delivering.
+	audioStaleAfter       = 2 * time.Second
+	audioRetryInterval    = 10 * time.Second
+	defaultAudioParec     = "parec"
+	defaultAudioParecFrom = "@DEFAULT_MONITOR@"
+)
+
+// audioSample is the peak of each channel over one capture block, as linear
+// amplitude from 0 to 1.
+type audioSample struct {
+	at    time.Time
+	peaks []float64
+}
+
+type audioLevels struct {
+	Left    float64
+	Right   float64
+	Level   float64
+	LevelDB float64
+	Peak    float64
+}
+
+// audioMeter turns block peaks into meter readings with VU-style ballistics:
+// a rise shows at once and a fall is limited to audioMeterReleaseDBPerSecond.
+type audioMeter struct {
+	channels [2]float64
+	updated  time.Time
+	peak     float64
+	peakAt   time.Time
+}
+
+func (m *audioMeter) add(sample audioSample) {
+	if len(sample.peaks) == 0 {
+		return
+	}
+	release := 0.0
+	if !m.updated.IsZero() {
+		release = sample.at.Sub(m.updated).Seconds() * audioMeterReleaseDBPerSecond
+	}
+	// A mono source drives both channels.
+	right := sample.peaks[0]
+	if len(sample.peaks) > 1 {
+		right = sample.peaks[1]
+	}
+	for idx, peak := range []float64{sample.peaks[0], right} {
+		db := audioAmplitudeDB(peak)
+		if m.updated.IsZero() || db >= m.channels[idx]-release {
+			m.channels[idx] = db
+		} else {
+			m.channels[idx] -= release
+		}
+	}
+	m.updated = sample.at
+
+	level := math.Max(m.channels[0], m.channels[1])
+	if level >= m.peak || sample.at.Sub(m.peakAt) > audioPeakHold {
+		m.peak, m.peakAt = level, sample.at
+	}
+}
+
+func (m *audioMeter) levels(now time.Time) (audioLevels, bool) {
+	if m.updated.IsZero() || now.Sub(m.updated) > audioStaleAfter {
+		return audioLevels{}, false
+	}
+	levelDB := math.Max(m.channels[0], m.channels[1])
+	return audioLevels{
+		Left:    audioMeterPercent(m.channels[0]),
+		Right:   audioMeterPercent(m.channels[1]),
+		Level:   audioMeterPercent(levelDB),
+		LevelDB: levelDB,
+		Peak:    audioMeterPercent(m.peak),
+	}, true
+}
+
+// audioAmplitudeDB converts a linear peak to dBFS, clamped to the meter
+// floor.
+func audioAmplitudeDB(peak float64) float64 {
+	if peak <= 0 || math.IsNaN(peak) {
+		return audioMeterFloorDB
+	}
+	db := 20 * math.Log10(peak)
+	if db < audioMeterFloorDB {
+		return audioMeterFloorDB
+	}
+	if db > 0 {
+		return 0
+	}
+	return db
+}
+
+// audioMeterPercent places a dBFS value on the 0-100 meter scale, linear in
+// dB so quiet passages still move the bar.
+func audioMeterPercent(db float64) float64 {
+	return clampFloat64((db-audioMeterFloorDB)/-audioMeterFloorDB*100, 0, 100)
+}
+
+// audioSource streams samples to emit until stop is closed.
+type audioSource func(stop <-chan struct{}, emit func(audioSample)) error
+
+// AudioLevelCollector reports the level of what the machine is playing, for
+// VU meters on the display. On Linux it records the monitor of the default
+// output with parec, which PulseAudio and PipeWire (pipewire-pulse) both
+// provide; on Windows it reads the WASAPI peak meter of the default render
+// endpoint.
+//
+// Options: device (Linux, the source parec records, default the default
+// sink's monitor) and command (Linux, default "parec").
+type AudioLevelCollector struct {
+	*BaseCollector
+	mu        sync.Mutex
+	meter     audioMeter
+	sourceKey string
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+}
+
+func NewAudioLevelCollector() *AudioLevelCollector {
+	if !isCollectorSupportedOnCurrentPlatform(collectorAudio) {
+		return nil
+	}
+	return &AudioLevelCollector{BaseCollector: NewBaseCollector(collectorAudio)}
+}
+
+func (c *AudioLevelCollector) ApplyConfig(cfg *MonitorConfig) {
+	enabled := cfg != nil && cfg.IsCollectorEnabled(collectorAudio, false)
+	c.SetEnabled(enabled)
+	if !enabled {
+		c.stopSource()
+		c.mu.Lock()
+		c.meter = audioMeter{}
+		c.clearItems()
+		c.mu.Unlock()
+		return
+	}
+
+	key, source := audioSourceForPlatform(cfg)
+	c.mu.Lock()
+	restart := c.sourceKey != key
+	c.mu.Unlock()
+	if restart {
+		c.stopSource()
+	}
+	c.startSource(key, source)
+	_ = c.GetAllItems()
+}
+
+func audioSourceForPlatform(cfg *MonitorConfig) (string, audioSource) {
+	if runtime.GOOS == "windows" {
+		return "wasapi", runWASAPIPeakMeter
+	}
+	command := strings.TrimSpace(cfg.GetCollectorStringOption(collectorAudio, "command", ""))
+	if command == "" {
+		command = defaultAudioParec
+	}
+	device := strings.TrimSpace(cfg.GetCollectorStringOption(collectorAudio, "device", ""))
+	if device == "" {
+		device = defaultAudioParecFrom
+	}
+	return "parec|" + command + "|" + device, func(stop <-chan struct{}, emit func(audioSample)) error {
+		return runParec(command, device, stop, emit)
+	}
+}
+
+func (c *AudioLevelCollector) GetAllItems() map[string]*CollectItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.IsEnabled() && c.getItem("audio.level") == nil {
+		c.setItem("audio.level", NewCollectItem("audio.level", "Audio level", "%", 0, 100, 0))
+		c.setItem("audio.left", NewCollectItem("audio.left", "Audio left", "%", 0, 100, 0))
+		c.setItem("audio.right", NewCollectItem("audio.right", "Audio right", "%", 0, 100, 0))
+		c.setItem("audio.peak", NewCollectItem("audio.peak", "Audio peak", "%", 0, 100, 0))
+		c.setItem("audio.level_db", NewCollectItem("audio.level_db", "Audio level dB", "dB", audioMeterFloorDB, 0, 1))
+	}
+	return c.ItemsSnapshot()
+}
+
+func (c *AudioLevelCollector) UpdateItems() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	c.mu.Lock()
+	levels, ok := c.meter.levels(time.Now())
+	c.mu.Unlock()
+	c.setAudioValue("audio.level", levels.Level, ok)
+	c.setAudioValue("audio.left", levels.Left, ok)
+	c.setAudioValue("audio.right", levels.Right, ok)
+	c.setAudioValue("audio.peak", levels.Peak, ok)
+	c.setAudioValue("audio.level_db", levels.LevelDB, ok)
+	return nil
+}
+
+func (c *AudioLevelCollector) setAudioValue(name string, value float64, ok bool) {
+	item := c.getItem(name)
+	if item == nil {
+		return
+	}
+	if !ok {
+		item.SetAvailable(false)
+		return
+	}
+	item.SetValue(value)
+	item.SetAvailable(true)
+}
+
+// Close stops parec or the WASAPI meter when the collector manager shuts
+// down.
+func (c *AudioLevelCollector) Close() {
+	c.stopSource()
+}
+
+func (c *AudioLevelCollector) handleSample(sample audioSample) {
+	c.mu.Lock()
+	c.meter.add(sample)
+	c.mu.Unlock()
+}
+
+func (c *AudioLevelCollector) startSource(key string, source audioSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopCh != nil {
+		return
+	}
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	c.stopCh = stopCh
+	c.doneCh = doneCh
+	c.sourceKey = key
+	go func() {
+		defer close(doneCh)
+		lastErr := ""
+		for {
+			// Log each distinct failure once; a missing parec or sound
+			// server fails the same way on every retry.
+			if err := source(stopCh, c.handleSample); err != nil && err.Error() != lastErr {
+				lastErr = err.Error()
+				logWarnModule("audio", "capture failed: %v", err)
+			}
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(audioRetryInterval):
+			}
+		}
+	}()
+}
+
+func (c *AudioLevelCollector) stopSource() {
+	c.mu.Lock()
+	if c.stopCh == nil {
+		c.mu.Unlock()
+		return
+	}
+	stopCh, doneCh := c.stopCh, c.doneCh
+	c.stopCh = nil
+	c.doneCh = nil
+	c.sourceKey = ""
+	c.mu.Unlock()
+	close(stopCh)
+	<-doneCh
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestReadPCMPeaksPerChannel(t *testing.T) {
+	// Two blocks of two stereo frames: the left channel peaks at half scale
+	// in the first block, the right at full negative scale in the second.
+	var raw bytes.Buffer
+	for _, value := range []int16{16384, -100, -8192, 200, 0, -32768, 100, 50} {
+		_ = binary.Write(&raw, binary.LittleEndian, value)
+	}
+	// A trailing partial block is dropped.
+	raw.Write([]byte{1, 2, 3})
+
+	now := time.Now()
+	var samples []audioSample
+	if err := readPCMPeaks(&raw, 2, 2, func() time.Time { return now }, func(sample audioSample) {
+		samples = append(samples, sample)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(samples))
+	}
+	if samples[0].peaks[0] != 0.5 || samples[0].peaks[1] != 200.0/32768 {
+		t.Fatalf("unexpected first block peaks: %v", samples[0].peaks)
+	}
+	if samples[1].peaks[0] != 100.0/32768 || samples[1].peaks[1] != 1 {
+		t.Fatalf("unexpected second block peaks: %v", samples[1].peaks)
+	}
+}
+
+func TestAudioMeterBallistics(t *testing.T) {
+	now := time.Now()
+	var meter audioMeter
+	if _, ok := meter.levels(now); ok {
+		t.Fatal("expected no levels before any sample")
+	}
+
+	// Full scale on the left, -30 dBFS on the right.
+	meter.add(audioSample{at: now, peaks: []float64{1, math.Pow(10, -30.0/20)}})
+	levels, ok := meter.levels(now)
+	if !ok {
+		t.Fatal("expected levels")
+	}
+	if levels.Left != 100 || math.Abs(levels.Right-50) > 1e-9 || levels.Level != 100 || levels.LevelDB != 0 {
+		t.Fatalf("unexpected levels: %+v", levels)
+	}
+
+	// Silence half a second later: the meter falls by the release rate
+	// instead of dropping to the floor, and the peak holds.
+	later := now.Add(500 * time.Millisecond)
+	meter.add(audioSample{at: later, peaks: []float64{0, 0}})
+	levels, _ = meter.levels(later)
+	if want := -audioMeterReleaseDBPerSecond / 2; levels.LevelDB != want {
+		t.Fatalf("expected level %v dB after release, got %v", want, levels.LevelDB)
+	}
+	if levels.Peak != 100 {
+		t.Fatalf("expected held peak 100, got %v", levels.Peak)
+	}
+
+	// A mono source drives both channels.
+	meter = audioMeter{}
+	meter.add(audioSample{at: now, peaks: []float64{0.1}})
+	levels, _ = meter.levels(now)
+	if levels.Left != levels.Right || levels.Left <= 0 {
+		t.Fatalf("expected mono level on both channels, got %+v", levels)
+	}
+
+	if _, ok := meter.levels(now.Add(audioStaleAfter + time.Second)); ok {
+		t.Fatal("expected no levels once samples stop")
+	}
+}
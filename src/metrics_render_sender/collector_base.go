@@ -1,6 +1,9 @@
 package main
 
-import "sync"
+import (
+	"context"
+	"sync"
+)
 
 type CollectItem struct {
 	*BaseCollectItem
@@ -22,10 +25,32 @@ type Collector interface {
 	UpdateItems() error
 }
 
+// CollectorContextUpdater is implemented by collectors whose update waits on
+// a backend, e.g. an HTTP request. The manager calls UpdateItemsContext
+// instead of UpdateItems with a context that ends after the collector's
+// collect timeout, so a hung backend is abandoned rather than holding the
+// collector worker until the request returns.
+type CollectorContextUpdater interface {
+	UpdateItemsContext(ctx context.Context) error
+}
+
 type CollectorConfigApplier interface {
 	ApplyConfig(cfg *MonitorConfig)
 }
 
+// CollectorCloser is implemented by collectors that own background work,
+// such as a listening socket, which must stop with the manager.
+type CollectorCloser interface {
+	Close()
+}
+
+// CollectorRateResetter is implemented by collectors that derive rates from
+// counter deltas. ResetRates drops the previous sample, so a gap such as a
+// system suspend does not end up averaged into the next value.
+type CollectorRateResetter interface {
+	ResetRates()
+}
+
 type CollectorItemSnapshotProvider interface {
 	ItemsSnapshot() map[string]*CollectItem
 }
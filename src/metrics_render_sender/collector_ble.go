@@ -0,0 +1,564 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBLESensorStaleAfter = 5 * time.Minute
+	bleScanRetryInterval       = 10 * time.Second
+
+	bleUUIDEnvironmentalSensing = 0x181A
+	bleUUIDXiaomiMiBeacon       = 0xFE95
+	bleUUIDBTHome               = 0xFCD2
+	bleCompanyGoveeH5075        = 0xEC88
+)
+
+var bleAliasInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// bleSensorReading holds the fields decoded from one advertisement. Xiaomi
+// sensors announce temperature and humidity in separate packets, so every
+// field carries its own presence flag and readings are merged per sensor.
+type bleSensorReading struct {
+	Temperature    float64
+	Humidity       float64
+	Battery        float64
+	HasTemperature bool
+	HasHumidity    bool
+	HasBattery     bool
+}
+
+func (r bleSensorReading) empty() bool {
+	return !r.HasTemperature && !r.HasHumidity && !r.HasBattery
+}
+
+func (r *bleSensorReading) merge(next bleSensorReading) {
+	if next.HasTemperature {
+		r.Temperature = next.Temperature
+		r.HasTemperature = true
+	}
+	if next.HasHumidity {
+		r.Humidity = next.Humidity
+		r.HasHumidity = true
+	}
+	if next.HasBattery {
+		r.Battery = next.Battery
+		r.HasBattery = true
+	}
+}
+
+type bleSensorState struct {
+	reading bleSensorReading
+	seenAt  time.Time
+}
+
+// BLESensorCollector passively listens for advertisements of Xiaomi
+// (stock MiBeacon and ATC/pvvx firmware), BTHome and Govee H5075-family
+// thermometers and exposes them as ble.<alias>.temp/humidity/battery.
+type BLESensorCollector struct {
+	*BaseCollector
+	mu         sync.RWMutex
+	aliases    map[string]string
+	adapter    int
+	staleAfter time.Duration
+	sensors    map[string]bleSensorState
+	stopCh     chan struct{}
+	doneCh     chan struct{}
+	scanning   bool
+}
+
+func NewBLESensorCollector(cfg *MonitorConfig) *BLESensorCollector {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	return &BLESensorCollector{
+		BaseCollector: NewBaseCollector(collectorBLE),
+		aliases:       map[string]string{},
+		staleAfter:    defaultBLESensorStaleAfter,
+		sensors:       map[string]bleSensorState{},
+	}
+}
+
+func (c *BLESensorCollector) ApplyConfig(cfg *MonitorConfig) {
+	enabled := cfg != nil && cfg.IsCollectorEnabled(collectorBLE, false)
+	c.SetEnabled(enabled)
+	if !enabled {
+		c.stopScan()
+		c.mu.Lock()
+		c.aliases = map[string]string{}
+		c.sensors = map[string]bleSensorState{}
+		c.clearItems()
+		c.mu.Unlock()
+		return
+	}
+
+	aliases := parseBLEDeviceAliases(cfg.GetCollectorStringOption(collectorBLE, "devices", ""))
+	adapter := parseBLEAdapterIndex(cfg.GetCollectorStringOption(collectorBLE, "adapter", "hci0"))
+	staleAfter := defaultBLESensorStaleAfter
+	if seconds, err := strconv.Atoi(cfg.GetCollectorStringOption(collectorBLE, "stale_seconds", "")); err == nil && seconds > 0 {
+		staleAfter = time.Duration(seconds) * time.Second
+	}
+
+	c.mu.Lock()
+	restart := c.adapter != adapter
+	if !sameStringMap(c.aliases, aliases) {
+		c.clearItems()
+	}
+	c.aliases = aliases
+	c.adapter = adapter
+	c.staleAfter = staleAfter
+	c.mu.Unlock()
+
+	if restart {
+		c.stopScan()
+	}
+	c.startScan(adapter)
+	_ = c.GetAllItems()
+}
+
+func (c *BLESensorCollector) GetAllItems() map[string]*CollectItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, alias := range c.visibleAliasesLocked() {
+		c.ensureSensorItemsLocked(alias)
+	}
+	return c.ItemsSnapshot()
+}
+
+func (c *BLESensorCollector) UpdateItems() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	now := time.Now()
+	c.mu.RLock()
+	staleAfter := c.staleAfter
+	states := make(map[string]bleSensorState, len(c.aliases))
+	for mac, state := range c.sensors {
+		if alias := c.aliasForLocked(mac); alias != "" {
+			states[alias] = state
+		}
+	}
+	aliases := c.visibleAliasesLocked()
+	c.mu.RUnlock()
+
+	for _, alias := range aliases {
+		state, ok := states[alias]
+		fresh := ok && now.Sub(state.seenAt) <= staleAfter
+		c.setSensorValue(alias+".temp", state.reading.Temperature, fresh && state.reading.HasTemperature)
+		c.setSensorValue(alias+".humidity", state.reading.Humidity, fresh && state.reading.HasHumidity)
+		c.setSensorValue(alias+".battery", state.reading.Battery, fresh && state.reading.HasBattery)
+	}
+	return nil
+}
+
+// Close stops the background scan when the collector manager shuts down.
+func (c *BLESensorCollector) Close() {
+	c.stopScan()
+}
+
+func (c *BLESensorCollector) setSensorValue(suffix string, value float64, ok bool) {
+	item := c.getItem("ble." + suffix)
+	if item == nil {
+		return
+	}
+	if !ok {
+		item.SetAvailable(false)
+		return
+	}
+	item.SetValue(value)
+	item.SetAvailable(true)
+}
+
+func (c *BLESensorCollector) ensureSensorItemsLocked(alias string) {
+	prefix := "ble." + alias
+	if c.getItem(prefix+".temp") != nil {
+		return
+	}
+	c.setItem(prefix+".temp", NewCollectItem(prefix+".temp", alias+" temperature", "°C", -20, 50, 1))
+	c.setItem(prefix+".humidity", NewCollectItem(prefix+".humidity", alias+" humidity", "%", 0, 100, 0))
+	c.setItem(prefix+".battery", NewCollectItem(prefix+".battery", alias+" battery", "%", 0, 100, 0))
+}
+
+// visibleAliasesLocked returns the configured aliases, or every sensor heard
+// so far when no devices are configured.
+func (c *BLESensorCollector) visibleAliasesLocked() []string {
+	seen := make(map[string]struct{})
+	if len(c.aliases) > 0 {
+		for _, alias := range c.aliases {
+			seen[alias] = struct{}{}
+		}
+	} else {
+		for mac := range c.sensors {
+			seen[bleAliasFromMAC(mac)] = struct{}{}
+		}
+	}
+	aliases := make([]string, 0, len(seen))
+	for alias := range seen {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+func (c *BLESensorCollector) aliasForLocked(mac string) string {
+	if len(c.aliases) == 0 {
+		return bleAliasFromMAC(mac)
+	}
+	return c.aliases[mac]
+}
+
+func (c *BLESensorCollector) handleAdvertisement(mac string, data []byte) {
+	reading, ok := parseBLEAdvertisement(mac, data)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.aliases) > 0 {
+		if _, wanted := c.aliases[mac]; !wanted {
+			return
+		}
+	}
+	state := c.sensors[mac]
+	state.reading.merge(reading)
+	state.seenAt = time.Now()
+	c.sensors[mac] = state
+}
+
+func (c *BLESensorCollector) startScan(adapter int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.scanning {
+		return
+	}
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	c.stopCh = stopCh
+	c.doneCh = doneCh
+	c.scanning = true
+	go func() {
+		defer close(doneCh)
+		for {
+			err := runBLEScan(adapter, stopCh, c.handleAdvertisement)
+			if err != nil {
+				logWarnModule("ble", "scan on hci%d failed: %v", adapter, err)
+			}
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(bleScanRetryInterval):
+			}
+		}
+	}()
+}
+
+func (c *BLESensorCollector) stopScan() {
+	c.mu.Lock()
+	if !c.scanning {
+		c.mu.Unlock()
+		return
+	}
+	stopCh, doneCh := c.stopCh, c.doneCh
+	c.scanning = false
+	c.stopCh = nil
+	c.doneCh = nil
+	c.mu.Unlock()
+	close(stopCh)
+	<-doneCh
+}
+
+// parseBLEDeviceAliases parses the "devices" option, a list such as
+// "A4:C1:38:12:34:56=desk, A4:C1:38:AA:BB:CC". Entries without an alias are
+// named after the last three bytes of their address.
+func parseBLEDeviceAliases(raw string) map[string]string {
+	aliases := map[string]string{}
+	for _, entry := range strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	}) {
+		macText, alias, _ := strings.Cut(entry, "=")
+		mac, ok := normalizeBLEAddress(macText)
+		if !ok {
+			continue
+		}
+		alias = bleAliasInvalidChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(alias)), "_")
+		alias = strings.Trim(alias, "_")
+		if alias == "" {
+			alias = bleAliasFromMAC(mac)
+		}
+		aliases[mac] = alias
+	}
+	return aliases
+}
+
+func parseBLEAdapterIndex(raw string) int {
+	value := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(raw)), "hci")
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 {
+		return 0
+	}
+	return index
+}
+
+func normalizeBLEAddress(raw string) (string, bool) {
+	hex := strings.ToUpper(strings.NewReplacer(":", "", "-", "", " ", "").Replace(raw))
+	if len(hex) != 12 {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(hex, 16, 64); err != nil {
+		return "", false
+	}
+	parts := make([]string, 0, 6)
+	for idx := 0; idx < 12; idx += 2 {
+		parts = append(parts, hex[idx:idx+2])
+	}
+	return strings.Join(parts, ":"), true
+}
+
+func bleAliasFromMAC(mac string) string {
+	hex := strings.ToLower(strings.ReplaceAll(mac, ":", ""))
+	if len(hex) > 6 {
+		hex = hex[len(hex)-6:]
+	}
+	return "sensor_" + hex
+}
+
+func sameStringMap(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBLEAdvertisement decodes the AD structures of an advertising report
+// and returns the first sensor reading found.
+func parseBLEAdvertisement(mac string, data []byte) (bleSensorReading, bool) {
+	for len(data) > 1 {
+		length := int(data[0])
+		if length == 0 || length >= len(data) {
+			break
+		}
+		adType := data[1]
+		payload := data[2 : 1+length]
+		data = data[1+length:]
+
+		var reading bleSensorReading
+		switch {
+		case adType == 0x16 && len(payload) >= 2:
+			uuid := binary.LittleEndian.Uint16(payload[:2])
+			reading = parseBLEServiceData(mac, uuid, payload[2:])
+		case adType == 0xFF && len(payload) >= 2:
+			company := binary.LittleEndian.Uint16(payload[:2])
+			reading = parseBLEManufacturerData(company, payload[2:])
+		}
+		if !reading.empty() {
+			return reading, true
+		}
+	}
+	return bleSensorReading{}, false
+}
+
+func parseBLEServiceData(mac string, uuid uint16, data []byte) bleSensorReading {
+	switch uuid {
+	case bleUUIDEnvironmentalSensing:
+		return parseBLECustomFirmware(mac, data)
+	case bleUUIDXiaomiMiBeacon:
+		return parseBLEMiBeacon(data)
+	case bleUUIDBTHome:
+		return parseBLEBTHome(data)
+	}
+	return bleSensorReading{}
+}
+
+// parseBLECustomFirmware handles the ATC1441 (13 bytes, big endian) and pvvx
+// (15 bytes, little endian) formats flashed onto LYWSD03MMC and friends.
+func parseBLECustomFirmware(mac string, data []byte) bleSensorReading {
+	switch len(data) {
+	case 13:
+		if !bleAddressMatches(mac, data[:6], false) {
+			return bleSensorReading{}
+		}
+		return bleSensorReading{
+			Temperature:    float64(int16(binary.BigEndian.Uint16(data[6:8]))) / 10,
+			Humidity:       float64(data[8]),
+			Battery:        float64(data[9]),
+			HasTemperature: true,
+			HasHumidity:    true,
+			HasBattery:     true,
+		}
+	case 15:
+		if !bleAddressMatches(mac, data[:6], true) {
+			return bleSensorReading{}
+		}
+		return bleSensorReading{
+			Temperature:    float64(int16(binary.LittleEndian.Uint16(data[6:8]))) / 100,
+			Humidity:       float64(binary.LittleEndian.Uint16(data[8:10])) / 100,
+			Battery:        float64(data[12]),
+			HasTemperature: true,
+			HasHumidity:    true,
+			HasBattery:     true,
+		}
+	}
+	return bleSensorReading{}
+}
+
+// bleAddressMatches guards the custom firmware formats, which embed the
+// sender address, against unrelated 0x181A service data. An empty mac skips
+// the check.
+func bleAddressMatches(mac string, raw []byte, reversed bool) bool {
+	if mac == "" {
+		return true
+	}
+	bytes := make([]byte, len(raw))
+	copy(bytes, raw)
+	if reversed {
+		for i, j := 0, len(bytes)-1; i < j; i, j = i+1, j-1 {
+			bytes[i], bytes[j] = bytes[j], bytes[i]
+		}
+	}
+	return formatBLEAddress(bytes) == mac
+}
+
+func formatBLEAddress(raw []byte) string {
+	parts := make([]string, len(raw))
+	for idx, b := range raw {
+		parts[idx] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// parseBLEMiBeacon decodes unencrypted MiBeacon objects. Encrypted beacons
+// need the per-device bind key and are ignored.
+func parseBLEMiBeacon(data []byte) bleSensorReading {
+	if len(data) < 5 {
+		return bleSensorReading{}
+	}
+	frameControl := binary.LittleEndian.Uint16(data[:2])
+	if frameControl&0x0008 != 0 || frameControl&0x0040 == 0 {
+		return bleSensorReading{}
+	}
+	offset := 5
+	if frameControl&0x0010 != 0 {
+		offset += 6
+	}
+	if frameControl&0x0020 != 0 {
+		if offset >= len(data) {
+			return bleSensorReading{}
+		}
+		capability := data[offset]
+		offset++
+		if capability&0x20 != 0 {
+			offset += 2
+		}
+	}
+	if offset+3 > len(data) {
+		return bleSensorReading{}
+	}
+	objectID := binary.LittleEndian.Uint16(data[offset : offset+2])
+	size := int(data[offset+2])
+	object := data[offset+3:]
+	if len(object) < size {
+		return bleSensorReading{}
+	}
+	object = object[:size]
+
+	var reading bleSensorReading
+	switch {
+	case objectID == 0x1004 && size == 2:
+		reading.Temperature = float64(int16(binary.LittleEndian.Uint16(object))) / 10
+		reading.HasTemperature = true
+	case objectID == 0x1006 && size == 2:
+		reading.Humidity = float64(binary.LittleEndian.Uint16(object)) / 10
+		reading.HasHumidity = true
+	case objectID == 0x100A && size >= 1:
+		reading.Battery = float64(object[0])
+		reading.HasBattery = true
+	case objectID == 0x100D && size == 4:
+		reading.Temperature = float64(int16(binary.LittleEndian.Uint16(object[:2]))) / 10
+		reading.Humidity = float64(binary.LittleEndian.Uint16(object[2:4])) / 10
+		reading.HasTemperature = true
+		reading.HasHumidity = true
+	}
+	return reading
+}
+
+// parseBLEBTHome decodes unencrypted BTHome v2 objects. Objects are not
+// length-prefixed, so parsing stops at the first unknown id.
+func parseBLEBTHome(data []byte) bleSensorReading {
+	var reading bleSensorReading
+	if len(data) < 1 || data[0]&0x01 != 0 || data[0]>>5 != 2 {
+		return reading
+	}
+	data = data[1:]
+	for len(data) > 0 {
+		id := data[0]
+		data = data[1:]
+		var size int
+		switch id {
+		case 0x00, 0x01, 0x2E:
+			size = 1
+		case 0x02, 0x03, 0x0C, 0x45:
+			size = 2
+		default:
+			return reading
+		}
+		if len(data) < size {
+			return reading
+		}
+		value := data[:size]
+		data = data[size:]
+		switch id {
+		case 0x01:
+			reading.Battery = float64(value[0])
+			reading.HasBattery = true
+		case 0x02:
+			reading.Temperature = float64(int16(binary.LittleEndian.Uint16(value))) / 100
+			reading.HasTemperature = true
+		case 0x45:
+			reading.Temperature = float64(int16(binary.LittleEndian.Uint16(value))) / 10
+			reading.HasTemperature = true
+		case 0x03:
+			reading.Humidity = float64(binary.LittleEndian.Uint16(value)) / 100
+			reading.HasHumidity = true
+		case 0x2E:
+			reading.Humidity = float64(value[0])
+			reading.HasHumidity = true
+		}
+	}
+	return reading
+}
+
+// parseBLEManufacturerData decodes Govee H5072/H5075 style packets, which
+// pack temperature and humidity into one 24-bit big endian value.
+func parseBLEManufacturerData(company uint16, data []byte) bleSensorReading {
+	if company != bleCompanyGoveeH5075 || len(data) < 5 {
+		return bleSensorReading{}
+	}
+	packed := uint32(data[1])<<16 | uint32(data[2])<<8 | uint32(data[3])
+	negative := packed&0x800000 != 0
+	packed &= 0x7FFFFF
+	temperature := float64(packed/1000) / 10
+	if negative {
+		temperature = -temperature
+	}
+	return bleSensorReading{
+		Temperature:    temperature,
+		Humidity:       float64(packed%1000) / 10,
+		Battery:        float64(data[4]),
+		HasTemperature: true,
+		HasHumidity:    true,
+		HasBattery:     true,
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func bleAdvertisement(adType byte, payload ...byte) []byte {
+	return append([]byte{byte(len(payload) + 1), adType}, payload...)
+}
+
+func assertBLEReading(t *testing.T, got bleSensorReading, temperature, humidity, battery float64) {
+	t.Helper()
+	if !got.HasTemperature || math.Abs(got.Temperature-temperature) > 1e-9 {
+		t.Fatalf("unexpected temperature: %+v want=%v", got, temperature)
+	}
+	if !got.HasHumidity || math.Abs(got.Humidity-humidity) > 1e-9 {
+		t.Fatalf("unexpected humidity: %+v want=%v", got, humidity)
+	}
+	if battery >= 0 && (!got.HasBattery || got.Battery != battery) {
+		t.Fatalf("unexpected battery: %+v want=%v", got, battery)
+	}
+}
+
+func TestParseBLEAdvertisementCustomFirmware(t *testing.T) {
+	mac := "A4:C1:38:12:34:56"
+	atc := bleAdvertisement(0x16, 0x1A, 0x18, 0xA4, 0xC1, 0x38, 0x12, 0x34, 0x56, 0x00, 0xE5, 0x2D, 0x58, 0x0B, 0xB8, 0x01)
+	reading, ok := parseBLEAdvertisement(mac, atc)
+	if !ok {
+		t.Fatalf("expected ATC reading")
+	}
+	assertBLEReading(t, reading, 22.9, 45, 88)
+
+	pvvx := bleAdvertisement(0x16, 0x1A, 0x18, 0x56, 0x34, 0x12, 0x38, 0xC1, 0xA4, 0x2A, 0xF8, 0x9A, 0x12, 0xB8, 0x0B, 0x5A, 0x01, 0x04)
+	reading, ok = parseBLEAdvertisement(mac, pvvx)
+	if !ok {
+		t.Fatalf("expected pvvx reading")
+	}
+	assertBLEReading(t, reading, -20.06, 47.62, 90)
+
+	if _, ok := parseBLEAdvertisement("A4:C1:38:00:00:00", atc); ok {
+		t.Fatalf("expected address mismatch to be ignored")
+	}
+}
+
+func TestParseBLEAdvertisementMiBeaconAndBTHome(t *testing.T) {
+	// Frame control 0x5050: object and MAC included, not encrypted.
+	mi := bleAdvertisement(0x16, 0x95, 0xFE, 0x50, 0x50, 0x5B, 0x05, 0x01,
+		0x56, 0x34, 0x12, 0x38, 0xC1, 0xA4,
+		0x0D, 0x10, 0x04, 0xE6, 0x00, 0xC2, 0x01)
+	reading, ok := parseBLEAdvertisement("", mi)
+	if !ok {
+		t.Fatalf("expected MiBeacon reading")
+	}
+	assertBLEReading(t, reading, 23, 45, -1)
+
+	encrypted := append([]byte(nil), mi...)
+	encrypted[4] |= 0x08
+	if _, ok := parseBLEAdvertisement("", encrypted); ok {
+		t.Fatalf("expected encrypted MiBeacon to be ignored")
+	}
+
+	bthome := bleAdvertisement(0x16, 0xD2, 0xFC, 0x40, 0x00, 0x07, 0x01, 0x61, 0x02, 0xCA, 0x09, 0x03, 0xBF, 0x13)
+	reading, ok = parseBLEAdvertisement("", bthome)
+	if !ok {
+		t.Fatalf("expected BTHome reading")
+	}
+	assertBLEReading(t, reading, 25.06, 50.55, 97)
+}
+
+func TestParseBLEAdvertisementGovee(t *testing.T) {
+	flags := bleAdvertisement(0x01, 0x06)
+	govee := bleAdvertisement(0xFF, 0x88, 0xEC, 0x00, 0x03, 0x7F, 0x1A, 0x64, 0x00)
+	reading, ok := parseBLEAdvertisement("", append(flags, govee...))
+	if !ok {
+		t.Fatalf("expected Govee reading")
+	}
+	// 0x037F1A = 229146 -> 22.9°C, 14.6%.
+	assertBLEReading(t, reading, 22.9, 14.6, 100)
+
+	negative := bleAdvertisement(0xFF, 0x88, 0xEC, 0x00, 0x80, 0x13, 0x88, 0x50, 0x00)
+	reading, _ = parseBLEAdvertisement("", negative)
+	assertBLEReading(t, reading, -0.5, 0, 80)
+}
+
+func TestParseBLEDeviceAliases(t *testing.T) {
+	got := parseBLEDeviceAliases("a4:c1:38:12:34:56=Living Room; A4C138AABBCC, bogus=x")
+	want := map[string]string{
+		"A4:C1:38:12:34:56": "living_room",
+		"A4:C1:38:AA:BB:CC": "sensor_aabbcc",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected aliases: got=%v want=%v", got, want)
+	}
+	if idx := parseBLEAdapterIndex("hci1"); idx != 1 {
+		t.Fatalf("unexpected adapter index: %d", idx)
+	}
+}
+
+func TestBLESensorCollectorMergesReadings(t *testing.T) {
+	collector := &BLESensorCollector{
+		BaseCollector: NewBaseCollector(collectorBLE),
+		aliases:       map[string]string{"A4:C1:38:12:34:56": "desk"},
+		staleAfter:    defaultBLESensorStaleAfter,
+		sensors:       map[string]bleSensorState{},
+	}
+	collector.GetAllItems()
+	temp := bleAdvertisement(0x16, 0x95, 0xFE, 0x40, 0x40, 0x5B, 0x05, 0x01, 0x04, 0x10, 0x02, 0xE6, 0x00)
+	humidity := bleAdvertisement(0x16, 0x95, 0xFE, 0x40, 0x40, 0x5B, 0x05, 0x02, 0x06, 0x10, 0x02, 0xC2, 0x01)
+	collector.handleAdvertisement("A4:C1:38:12:34:56", temp)
+	collector.handleAdvertisement("A4:C1:38:12:34:56", humidity)
+	collector.handleAdvertisement("A4:C1:38:00:00:00", temp)
+	if err := collector.UpdateItems(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	if item := collector.getItem("ble.desk.temp"); item == nil || !item.IsAvailable() || item.GetValue().Value != 23.0 {
+		t.Fatalf("unexpected temperature item: %+v", item)
+	}
+	if item := collector.getItem("ble.desk.humidity"); item == nil || !item.IsAvailable() || item.GetValue().Value != 45.0 {
+		t.Fatalf("unexpected humidity item: %+v", item)
+	}
+	if item := collector.getItem("ble.desk.battery"); item == nil || item.IsAvailable() {
+		t.Fatalf("expected battery to stay unavailable until reported")
+	}
+	if len(collector.sensors) != 1 {
+		t.Fatalf("expected unconfigured sensors to be ignored, got %d", len(collector.sensors))
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"runtime"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -30,11 +31,14 @@ type CPUInfo struct {
 type DiskInfo struct {
 	Name             string
 	Model            string
-	Size             int64   // Total size in GB
-	Used             int64   // Used size in GB
-	Available        int64   // Available size in GB
-	Usage            float64 // Usage percentage
-	Temperature      float64 // Disk temperature in Celsius
+	Serial           string
+	WWN              string
+	ByID             []string // names under /dev/disk/by-id linking to the disk
+	Size             int64    // Total size in GB
+	Used             int64    // Used size in GB
+	Available        int64    // Available size in GB
+	Usage            float64  // Usage percentage
+	Temperature      float64  // Disk temperature in Celsius
 	TempAvailable    bool
 	ReadSpeed        float64 // MiB/s
 	WriteSpeed       float64 // MiB/s
@@ -57,6 +61,10 @@ var (
 	diskUpdatePeriod = 1 * time.Second
 	diskScanPeriod   = 30 * time.Second
 
+	// lastDiskFilterKey forces a rescan when the include/exclude options change.
+	lastDiskFilterKey string
+	cachedDiskTotals  diskCapacityTotals
+
 	// 无锁读取用原子存储
 	diskInfoStore atomic.Value // []*DiskInfo
 
@@ -86,6 +94,7 @@ func initializeCache() {
 		go func() {
 			updateDiskInfo()
 			startDiskSampler()
+			startHotplugWatch()
 			printSystemInfo()
 		}()
 	})
@@ -94,18 +103,22 @@ func initializeCache() {
 // updateDiskInfo updates disk information if enough time has passed
 func updateDiskInfo() {
 	now := time.Now()
+	filter := currentDeviceFilter(collectorGoNativeDisk)
 	diskInfoMutex.Lock()
 	if now.Sub(lastDiskUpdate) < diskUpdatePeriod {
 		diskInfoMutex.Unlock()
 		return
 	}
 	existing := cloneDiskInfoList(cachedDiskInfo)
-	needScan := len(existing) == 0 || now.Sub(lastDiskScanAt) >= diskScanPeriod
+	needScan := len(existing) == 0 || now.Sub(lastDiskScanAt) >= diskScanPeriod || filter.key() != lastDiskFilterKey
 	diskInfoMutex.Unlock()
 
 	newDisks := existing
+	var totals diskCapacityTotals
 	if needScan {
-		newDisks = detectDiskInfoStatic()
+		detected := currentPlatform.DetectDisks()
+		newDisks = filterDisks(detected, filter)
+		totals = sumMountedFilesystems(readMountedFilesystems(), detected, newDisks, filter)
 	}
 	populateDiskDynamicMetrics(newDisks)
 	if len(newDisks) > 1 {
@@ -118,11 +131,30 @@ func updateDiskInfo() {
 	lastDiskUpdate = now
 	if needScan {
 		lastDiskScanAt = now
+		lastDiskFilterKey = filter.key()
+		cachedDiskTotals = totals
 	}
 	diskInfoMutex.Unlock()
 	diskInfoStore.Store(newDisks)
 }
 
+// getCachedDiskTotals returns the mounted filesystem totals of the last
+// disk scan.
+func getCachedDiskTotals() diskCapacityTotals {
+	diskInfoMutex.RLock()
+	defer diskInfoMutex.RUnlock()
+	return cachedDiskTotals
+}
+
+// requestDiskRescan re-detects disks now instead of after diskScanPeriod.
+func requestDiskRescan() {
+	diskInfoMutex.Lock()
+	lastDiskUpdate = time.Time{}
+	lastDiskScanAt = time.Time{}
+	diskInfoMutex.Unlock()
+	updateDiskInfo()
+}
+
 // getCachedDiskInfo returns current disk information without lock (atomic)
 func getCachedDiskInfo() []*DiskInfo {
 	initializeCache()
@@ -167,6 +199,9 @@ func printSystemInfo() {
 			if i < 3 {
 				logInfo("Disk %d: %s (%s) - %.0f GB used=%d%% read=%.1f MiB/s write=%.1f MiB/s busy=%.0f%%", i+1, disk.Name, disk.Model, float64(disk.Size), int64(disk.Usage+0.5), disk.ReadSpeed, disk.WriteSpeed, disk.BusyPercent)
 			}
+			if disk.Serial != "" || disk.WWN != "" || len(disk.ByID) > 0 {
+				logInfo("Disk %s ids: serial=%s wwn=%s by-id=%s", disk.Name, disk.Serial, disk.WWN, strings.Join(disk.ByID, ","))
+			}
 		}
 	}
 	logInfo("OS: %s %s", runtime.GOOS, runtime.GOARCH)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"runtime"
 	"strings"
 	"sync"
@@ -112,20 +113,7 @@ func (c *CoolerControlCollector) GetAllItems() map[string]*CollectItem {
 			}
 			continue
 		}
-		precision := 2
-		maxValue := 0.0
-		switch unit {
-		case "°C":
-			precision = 1
-			maxValue = 120
-		case "%":
-			precision = 0
-			maxValue = 100
-		case "RPM", "MHz":
-			precision = 0
-		case "W":
-			precision = 1
-		}
+		precision, maxValue := valueKindDefaults(valueKindForUnit(unit))
 		item := NewCollectItem(name, option.Label, unit, 0, maxValue, precision)
 		c.setItem(name, item)
 		c.sources[name] = name
@@ -134,6 +122,10 @@ func (c *CoolerControlCollector) GetAllItems() map[string]*CollectItem {
 }
 
 func (c *CoolerControlCollector) UpdateItems() error {
+	return c.UpdateItemsContext(context.Background())
+}
+
+func (c *CoolerControlCollector) UpdateItemsContext(ctx context.Context) error {
 	c.mu.RLock()
 	client := c.client
 	sources := make(map[string]string, len(c.sources))
@@ -144,7 +136,7 @@ func (c *CoolerControlCollector) UpdateItems() error {
 	if !c.IsEnabled() || client == nil {
 		return nil
 	}
-	_, err := client.FetchSnapshot()
+	_, err := client.FetchSnapshotContext(ctx)
 	if err != nil {
 		return err
 	}
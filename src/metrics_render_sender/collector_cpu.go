@@ -2,12 +2,14 @@ package main
 
 import (
 	"github.com/shirou/gopsutil/v3/cpu"
-	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+const cpuTempSensorPrefix = "go_native.cpu.temp."
+
 type GoNativeCPUCollector struct {
 	*BaseCollector
 
@@ -26,6 +28,12 @@ type GoNativeCPUCollector struct {
 	tempOK       bool
 	tempAt       time.Time
 	tempUpdating int32
+	// tempSensor is the temp_sensor option: the channel that feeds
+	// go_native.cpu.temp instead of the hottest one.
+	tempSensor     string
+	tempSensors    []cpuTempSensor
+	tempSensorsSet bool
+	tempMissing    string
 
 	freqMu       sync.RWMutex
 	freqValue    float64
@@ -62,6 +70,15 @@ func (c *GoNativeCPUCollector) GetAllItems() map[string]*CollectItem {
 		c.setItem("go_native.cpu.cores", NewCollectItem("go_native.cpu.cores", "CPU cores", "", 0, 0, 0))
 	}
 
+	if nativeCPUTemperatureSupported() {
+		for _, sensor := range c.cpuTempSensors() {
+			name := cpuTempSensorPrefix + sensor.slug()
+			if c.getItem(name) == nil {
+				c.setItem(name, NewCollectItem(name, "CPU temperature "+sensor.Label, "°C", 0, 120, 0))
+			}
+		}
+	}
+
 	initializeCache()
 	if cachedCPUInfo != nil {
 		if item := c.getItem("go_native.cpu.model"); item != nil {
@@ -117,6 +134,8 @@ func (c *GoNativeCPUCollector) UpdateItems() error {
 		}
 	}
 
+	c.updateCPUTempSensorItems()
+
 	if freq := c.getItem("go_native.cpu.freq"); freq != nil {
 		if value, ok := c.getCachedFreq(); ok {
 			freq.SetValue(value)
@@ -253,7 +272,7 @@ func computeCPUUsageBreakdown(
 }
 
 func nativeCPUTemperatureSupported() bool {
-	return runtime.GOOS != "windows"
+	return currentPlatform.HasCPUTemperature()
 }
 
 func (c *GoNativeCPUCollector) maybeRefreshTemp(now time.Time) {
@@ -277,18 +296,91 @@ func (c *GoNativeCPUCollector) triggerTempRefresh() {
 	}
 	go func() {
 		defer atomic.StoreInt32(&c.tempUpdating, 0)
-		value := getRealCPUTemperature()
-		now := time.Now()
-		c.tempMu.Lock()
-		c.tempAt = now
-		if value > 0 {
-			c.tempValue = value
-			c.tempOK = true
+		c.refreshTemp()
+	}()
+}
+
+func (c *GoNativeCPUCollector) refreshTemp() {
+	sensors := currentPlatform.CPUTemperatureSensors()
+	c.tempMu.RLock()
+	selected := c.tempSensor
+	c.tempMu.RUnlock()
+
+	value, ok := 0.0, false
+	if selected == "" {
+		value, ok = currentPlatform.CPUTemperature()
+	} else if sensor, found := selectCPUTempSensor(sensors, selected); found {
+		value, ok = sensor.Value, true
+	}
+
+	now := time.Now()
+	c.tempMu.Lock()
+	defer c.tempMu.Unlock()
+	c.tempAt = now
+	c.tempValue, c.tempOK = value, ok
+	c.tempSensors, c.tempSensorsSet = sensors, true
+	if selected != "" && !ok && c.tempMissing != selected {
+		labels := make([]string, 0, len(sensors))
+		for _, sensor := range sensors {
+			labels = append(labels, sensor.Label)
+		}
+		logWarnModule("cpu", "temp_sensor %q not found, available: %s", selected, strings.Join(labels, ", "))
+	}
+	if ok {
+		c.tempMissing = ""
+	} else {
+		c.tempMissing = selected
+	}
+}
+
+// ApplyConfig picks up the temp_sensor option and rereads the temperature
+// right away when it changes.
+func (c *GoNativeCPUCollector) ApplyConfig(cfg *MonitorConfig) {
+	selected := ""
+	if cfg != nil {
+		selected = strings.TrimSpace(cfg.GetCollectorStringOption(collectorGoNativeCPU, "temp_sensor", ""))
+	}
+	c.tempMu.Lock()
+	changed := c.tempSensor != selected
+	c.tempSensor = selected
+	c.tempMu.Unlock()
+	if changed && nativeCPUTemperatureSupported() {
+		c.triggerTempRefresh()
+	}
+}
+
+// cpuTempSensors returns the channels of the last refresh, reading them
+// once directly when no refresh has finished yet so the per-channel items
+// exist from the start.
+func (c *GoNativeCPUCollector) cpuTempSensors() []cpuTempSensor {
+	c.tempMu.RLock()
+	sensors, set := c.tempSensors, c.tempSensorsSet
+	c.tempMu.RUnlock()
+	if set {
+		return sensors
+	}
+	return currentPlatform.CPUTemperatureSensors()
+}
+
+func (c *GoNativeCPUCollector) updateCPUTempSensorItems() {
+	c.tempMu.RLock()
+	sensors := c.tempSensors
+	c.tempMu.RUnlock()
+	values := make(map[string]float64, len(sensors))
+	for _, sensor := range sensors {
+		values[cpuTempSensorPrefix+sensor.slug()] = sensor.Value
+	}
+	for name, item := range c.ItemsSnapshot() {
+		if !strings.HasPrefix(name, cpuTempSensorPrefix) {
+			continue
+		}
+		if value, ok := values[name]; ok {
+			item.SetValue(value)
+			item.SetAvailable(true)
 		} else {
-			c.tempOK = false
+			item.SetAvailable(false)
 		}
-		c.tempMu.Unlock()
-	}()
+	}
 }
 
 func (c *GoNativeCPUCollector) getCachedTemp() (float64, bool) {
@@ -315,11 +407,11 @@ func (c *GoNativeCPUCollector) triggerFreqRefresh() {
 	}
 	go func() {
 		defer atomic.StoreInt32(&c.freqUpdating, 0)
-		current, maxFreq := getRealCPUFrequency()
+		current, maxFreq, ok := currentPlatform.CPUFrequency()
 		now := time.Now()
 		c.freqMu.Lock()
 		c.freqAt = now
-		if current > 0 {
+		if ok && current > 0 {
 			c.freqValue = current
 			c.freqOK = true
 		} else {
@@ -44,3 +44,58 @@ func TestComputeCPUUsageBreakdown(t *testing.T) {
 		t.Fatalf("expected softirq ~= 1.471, got %v", got.Softirq)
 	}
 }
+
+type cpuTempFakePlatform struct {
+	fakePlatform
+	sensors []cpuTempSensor
+}
+
+func (p *cpuTempFakePlatform) CPUTemperature() (float64, bool) {
+	return 70, true
+}
+
+func (p *cpuTempFakePlatform) CPUTemperatureSensors() []cpuTempSensor {
+	return p.sensors
+}
+
+func TestCPUCollectorTempSensorSelection(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	useFakePlatform(t, &cpuTempFakePlatform{sensors: []cpuTempSensor{
+		{Label: "Tctl", Value: 70},
+		{Label: "Tccd1", Value: 58.5},
+	}})
+	collector := &GoNativeCPUCollector{BaseCollector: NewBaseCollector(collectorGoNativeCPU)}
+	for _, sensor := range collector.cpuTempSensors() {
+		name := cpuTempSensorPrefix + sensor.slug()
+		collector.setItem(name, NewCollectItem(name, "CPU temperature "+sensor.Label, "°C", 0, 120, 0))
+	}
+	collector.setItem(cpuTempSensorPrefix+"gone", NewCollectItem(cpuTempSensorPrefix+"gone", "CPU temperature gone", "°C", 0, 120, 0))
+
+	cases := []struct {
+		option string
+		want   float64
+		ok     bool
+	}{
+		{option: "", want: 70, ok: true},
+		{option: "tccd1", want: 58.5, ok: true},
+		{option: "Tctl", want: 70, ok: true},
+		{option: "Tdie", ok: false},
+	}
+	for _, tc := range cases {
+		collector.tempSensor = tc.option
+		collector.refreshTemp()
+		value, ok := collector.getCachedTemp()
+		if ok != tc.ok || (ok && value != tc.want) {
+			t.Fatalf("temp_sensor %q: expected %v/%v, got %v/%v", tc.option, tc.want, tc.ok, value, ok)
+		}
+	}
+
+	collector.updateCPUTempSensorItems()
+	items := collector.ItemsSnapshot()
+	if got := items["go_native.cpu.temp.tccd1"].GetValue().Value; got != 58.5 {
+		t.Fatalf("expected go_native.cpu.temp.tccd1=58.5, got %v", got)
+	}
+	if !items["go_native.cpu.temp.tctl"].IsAvailable() || items[cpuTempSensorPrefix+"gone"].IsAvailable() {
+		t.Fatal("expected present channels available and vanished ones not")
+	}
+}
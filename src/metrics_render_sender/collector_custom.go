@@ -2,22 +2,42 @@ package main
 
 import (
 	"math"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 type customEntry struct {
 	cfg  CustomMonitorConfig
 	item *CollectItem
+
+	serial  *customSerialReader
+	pattern *regexp.Regexp
+	query   *promQuery
+
+	// DNS and HTTP checks add <name>.up and, for HTTP, <name>.status next to
+	// the latency in <name>. SSH monitors only use the runner.
+	check      *customCheckRunner
+	upItem     *CollectItem
+	statusItem *CollectItem
+
+	// Energy monitors add <name>.kwh_day and <name>.cost_day next to the
+	// power draw in <name>.
+	energy   *customEnergyMeter
+	kwhItem  *CollectItem
+	costItem *CollectItem
 }
 
 type CustomCollector struct {
 	*BaseCollector
-	mu     sync.RWMutex
-	cfg    *MonitorConfig
-	lookup func(string) *CollectItem
-	items  map[string]customEntry
+	mu      sync.RWMutex
+	cfg     *MonitorConfig
+	lookup  func(string) *CollectItem
+	items   map[string]customEntry
+	serials map[string]*customSerialReader
+	checks  map[string]*customCheckRunner
 }
 
 func NewCustomCollector(cfg *MonitorConfig, lookup func(string) *CollectItem) *CustomCollector {
@@ -25,6 +45,8 @@ func NewCustomCollector(cfg *MonitorConfig, lookup func(string) *CollectItem) *C
 		BaseCollector: NewBaseCollector("custom.all"),
 		lookup:        lookup,
 		items:         make(map[string]customEntry),
+		serials:       make(map[string]*customSerialReader),
+		checks:        make(map[string]*customCheckRunner),
 	}
 	return collector
 }
@@ -38,7 +60,31 @@ func (c *CustomCollector) ApplyConfig(cfg *MonitorConfig) {
 		c.SetEnabled(true)
 	}
 	c.rebuildItemsLocked()
+	unused := c.syncSerialReadersLocked()
+	unusedChecks := c.syncCheckRunnersLocked()
+	c.mu.Unlock()
+	for _, reader := range unused {
+		reader.stop()
+	}
+	for _, runner := range unusedChecks {
+		runner.stop()
+	}
+}
+
+// Close stops the serial port readers and checks when the collector manager
+// shuts down.
+func (c *CustomCollector) Close() {
+	c.mu.Lock()
+	c.items = make(map[string]customEntry)
+	unused := c.syncSerialReadersLocked()
+	unusedChecks := c.syncCheckRunnersLocked()
 	c.mu.Unlock()
+	for _, reader := range unused {
+		reader.stop()
+	}
+	for _, runner := range unusedChecks {
+		runner.stop()
+	}
 }
 
 func (c *CustomCollector) rebuildItemsLocked() {
@@ -52,12 +98,118 @@ func (c *CustomCollector) rebuildItemsLocked() {
 		if name == "" {
 			continue
 		}
+		customType := normalizeCustomMonitorType(custom.Type)
+		if customType == "dns" || customType == "http" {
+			c.items[name] = c.buildCheckEntryLocked(name, custom)
+			continue
+		}
+		if customType == "energy" {
+			c.items[name] = c.buildEnergyEntryLocked(name, custom)
+			continue
+		}
 		item := buildCustomCollectItem(&custom, custom.Name, "", 2, 0, 0)
-		c.items[name] = customEntry{cfg: custom, item: item}
+		entry := customEntry{cfg: custom, item: item}
+		if customType == "serial" || customType == "ssh" {
+			pattern, err := compileCustomPattern(custom)
+			if err != nil {
+				logWarnModule("custom", "%v", err)
+			}
+			entry.pattern = pattern
+		}
+		if customType == "prometheus" {
+			query, err := parsePromQuery(custom.Query)
+			if err != nil {
+				logWarnModule("custom", "invalid query for %s: %v", name, err)
+			}
+			entry.query = query
+		}
+		c.items[name] = entry
 		c.setItem(name, item)
 	}
 }
 
+func (c *CustomCollector) buildCheckEntryLocked(name string, custom CustomMonitorConfig) customEntry {
+	label := strings.TrimSpace(custom.Label)
+	if label == "" {
+		label = name
+	}
+	entry := customEntry{
+		cfg:    custom,
+		item:   buildCustomCollectItem(&custom, label+" latency", "ms", 0, 0, 0),
+		upItem: NewCollectItem(name+".up", label+" up", "", 0, 1, 0),
+	}
+	c.setItem(name, entry.item)
+	c.setItem(name+".up", entry.upItem)
+	if normalizeCustomMonitorType(custom.Type) == "http" {
+		entry.statusItem = NewCollectItem(name+".status", label+" status", "", 0, 0, 0)
+		c.setItem(name+".status", entry.statusItem)
+	}
+	return entry
+}
+
+// syncSerialReadersLocked opens one reader per configured port and returns
+// the readers no monitor uses anymore. They are stopped by the caller after
+// unlocking because stopping waits for a pending read to time out.
+func (c *CustomCollector) syncSerialReadersLocked() []*customSerialReader {
+	wanted := make(map[string]struct{})
+	if c.IsEnabled() {
+		for name, entry := range c.items {
+			if normalizeCustomMonitorType(entry.cfg.Type) != "serial" || strings.TrimSpace(entry.cfg.Path) == "" {
+				continue
+			}
+			key, baud := customSerialReaderKey(entry.cfg)
+			reader := c.serials[key]
+			if reader == nil {
+				reader = startCustomSerialReader(strings.TrimSpace(entry.cfg.Path), baud)
+				c.serials[key] = reader
+			}
+			entry.serial = reader
+			c.items[name] = entry
+			wanted[key] = struct{}{}
+		}
+	}
+	var unused []*customSerialReader
+	for key, reader := range c.serials {
+		if _, ok := wanted[key]; !ok {
+			unused = append(unused, reader)
+			delete(c.serials, key)
+		}
+	}
+	return unused
+}
+
+// syncCheckRunnersLocked starts one runner per distinct check and returns
+// those no monitor uses anymore, to be stopped after unlocking.
+func (c *CustomCollector) syncCheckRunnersLocked() []*customCheckRunner {
+	wanted := make(map[string]struct{})
+	if c.IsEnabled() {
+		for name, entry := range c.items {
+			switch normalizeCustomMonitorType(entry.cfg.Type) {
+			case "dns", "http", "ssh", "prometheus":
+			default:
+				continue
+			}
+			key := customCheckRunnerKey(entry.cfg)
+			runner := c.checks[key]
+			if runner == nil {
+				runner = startCustomCheckRunner(entry.cfg)
+				c.checks[key] = runner
+			}
+			entry.check = runner
+			c.items[name] = entry
+			wanted[key] = struct{}{}
+		}
+	}
+	var unused []*customCheckRunner
+	for key, runner := range c.checks {
+		if _, ok := wanted[key]; !ok {
+			unused = append(unused, runner)
+			delete(c.checks, key)
+		}
+	}
+	return unused
+}
+
 func (c *CustomCollector) GetAllItems() map[string]*CollectItem {
 	c.mu.RLock()
 	entries := make([]customEntry, 0, len(c.items))
@@ -85,7 +237,16 @@ func (c *CustomCollector) UpdateItems() error {
 	lookup := c.lookup
 	c.mu.RUnlock()
 
+	now := time.Now()
 	for _, entry := range entries {
+		if entry.upItem != nil {
+			updateCustomCheckItems(entry)
+			continue
+		}
+		if entry.energy != nil {
+			updateCustomEnergyItems(entry, lookup, now)
+			continue
+		}
 		item := entry.item
 		if item == nil || !item.IsEnabled() {
 			continue
@@ -111,6 +272,67 @@ func (c *CustomCollector) UpdateItems() error {
 			value += custom.Offset
 			item.SetValue(value)
 			item.SetAvailable(true)
+		case "message":
+			previous := ""
+			if current := item.GetValue(); current != nil {
+				if text, ok := current.Value.(string); ok {
+					previous = text
+				}
+			}
+			message, ok, err := readCustomMessage(custom.Path, previous)
+			if err != nil || !ok {
+				item.SetAvailable(false)
+				continue
+			}
+			item.SetValue(message)
+			item.SetAvailable(true)
+		case "serial":
+			if entry.serial == nil || (entry.pattern == nil && strings.TrimSpace(custom.Pattern) != "") {
+				item.SetAvailable(false)
+				continue
+			}
+			text, ok := entry.serial.latest(entry.pattern, time.Now())
+			if !ok {
+				item.SetAvailable(false)
+				continue
+			}
+			item.SetValue(customSerialValue(custom, text))
+			item.SetAvailable(true)
+		case "ssh":
+			if entry.check == nil || (entry.pattern == nil && strings.TrimSpace(custom.Pattern) != "") {
+				item.SetAvailable(false)
+				continue
+			}
+			result, ok := entry.check.latest()
+			text := ""
+			if ok && result.up {
+				text, ok = customOutputText(entry.pattern, result.output)
+			}
+			if !ok || !result.up {
+				item.SetAvailable(false)
+				continue
+			}
+			item.SetValue(customSerialValue(custom, text))
+			item.SetAvailable(true)
+		case "prometheus":
+			if entry.check == nil || entry.query == nil {
+				item.SetAvailable(false)
+				continue
+			}
+			result, ok := entry.check.latest()
+			value := 0.0
+			if ok && result.up {
+				value, ok = entry.query.evaluate(result.samples)
+			}
+			if !ok || !result.up {
+				item.SetAvailable(false)
+				continue
+			}
+			if custom.Scale != nil {
+				value *= *custom.Scale
+			}
+			item.SetValue(value + custom.Offset)
+			item.SetAvailable(true)
 		case "mixed":
 			values := make([]float64, 0, len(custom.Sources))
 			for _, sourceName := range custom.Sources {
@@ -190,6 +412,43 @@ func (c *CustomCollector) UpdateItems() error {
 	return nil
 }
 
+// updateCustomCheckItems copies the last check result. Latency and status
+// stay unavailable while the endpoint is down; up reads 0.
+func updateCustomCheckItems(entry customEntry) {
+	result, ok := customCheckResult{}, false
+	if entry.check != nil {
+		result, ok = entry.check.latest()
+	}
+	if !ok {
+		entry.item.SetAvailable(false)
+		entry.upItem.SetAvailable(false)
+		if entry.statusItem != nil {
+			entry.statusItem.SetAvailable(false)
+		}
+		return
+	}
+	up := 0.0
+	if result.up {
+		up = 1
+	}
+	entry.upItem.SetValue(up)
+	entry.upItem.SetAvailable(true)
+	if result.up {
+		entry.item.SetValue(float64(result.latency) / float64(time.Millisecond))
+		entry.item.SetAvailable(true)
+	} else {
+		entry.item.SetAvailable(false)
+	}
+	if entry.statusItem != nil {
+		if result.status > 0 {
+			entry.statusItem.SetValue(float64(result.status))
+			entry.statusItem.SetAvailable(true)
+		} else {
+			entry.statusItem.SetAvailable(false)
+		}
+	}
+}
+
 func refreshCustomItemStaticUnit(entry customEntry, lookup func(string) *CollectItem) {
 	item := entry.item
 	if item == nil {
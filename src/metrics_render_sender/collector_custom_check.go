@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	customCheckDefaultInterval = 30 * time.Second
+	customCheckMinInterval     = time.Second
+	customCheckMaxTimeout      = 5 * time.Second
+	customCheckMaxBodyBytes    = 64 << 10
+)
+
+// customCheckResult is the outcome of one probe. Status is the HTTP status
+// code, 0 when no response arrived; output is what an SSH command printed
+// and samples what a Prometheus scrape returned.
+type customCheckResult struct {
+	up      bool
+	latency time.Duration
+	status  int
+	output  string
+	samples []promSample
+	at      time.Time
+}
+
+// customCheckRunner probes one DNS name or HTTP URL, runs one SSH command
+// or scrapes one metrics endpoint, on its own schedule in the background, so
+// a slow endpoint never holds up a collect pass. Monitors with the same check
+// share a runner, so several SSH or Prometheus monitors can pick values out
+// of one command's output or one scrape.
+type customCheckRunner struct {
+	kind     string
+	target   string
+	interval time.Duration
+	probe    func(ctx context.Context) (customCheckResult, error)
+
+	mu     sync.Mutex
+	result customCheckResult
+
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+func customCheckInterval(custom CustomMonitorConfig) time.Duration {
+	if custom.IntervalMS <= 0 {
+		return customCheckDefaultInterval
+	}
+	interval := time.Duration(custom.IntervalMS) * time.Millisecond
+	if interval < customCheckMinInterval {
+		return customCheckMinInterval
+	}
+	return interval
+}
+
+// customCheckTimeout is TimeoutMS, or by default customCheckMaxTimeout,
+// and never longer than the interval.
+func customCheckTimeout(custom CustomMonitorConfig) time.Duration {
+	timeout := customCheckMaxTimeout
+	if custom.TimeoutMS > 0 {
+		timeout = time.Duration(custom.TimeoutMS) * time.Millisecond
+	}
+	if interval := customCheckInterval(custom); timeout > interval {
+		timeout = interval
+	}
+	return timeout
+}
+
+func customCheckRunnerKey(custom CustomMonitorConfig) string {
+	return strings.Join([]string{
+		normalizeCustomMonitorType(custom.Type),
+		strings.TrimSpace(custom.Target),
+		strings.TrimSpace(custom.Server),
+		strings.TrimSpace(custom.Command),
+		strings.TrimSpace(custom.Key),
+		customCheckInterval(custom).String(),
+		customCheckTimeout(custom).String(),
+	}, "|")
+}
+
+func startCustomCheckRunner(custom CustomMonitorConfig) *customCheckRunner {
+	kind := normalizeCustomMonitorType(custom.Type)
+	target := strings.TrimSpace(custom.Target)
+	timeout := customCheckTimeout(custom)
+	var probe func(ctx context.Context) (customCheckResult, error)
+	switch kind {
+	case "dns":
+		probe = newCustomDNSProbe(target, strings.TrimSpace(custom.Server), timeout)
+	case "ssh":
+		probe = newCustomSSHProbe(custom, timeout)
+	case "prometheus":
+		probe = newCustomPrometheusProbe(target, timeout)
+	default:
+		probe = newCustomHTTPProbe(target, timeout)
+	}
+	return startCheckRunner(kind, target, customCheckInterval(custom), probe)
+}
+
+// startCheckRunner calls probe right away and then every interval until the
+// runner is stopped. Kind and target only label log messages.
+func startCheckRunner(kind, target string, interval time.Duration, probe func(ctx context.Context) (customCheckResult, error)) *customCheckRunner {
+	runner := &customCheckRunner{
+		kind:     kind,
+		target:   target,
+		interval: interval,
+		probe:    probe,
+		doneCh:   make(chan struct{}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.cancel = cancel
+	go runner.run(ctx)
+	return runner
+}
+
+// stop cancels a probe in flight and waits for the runner to exit.
+func (r *customCheckRunner) stop() {
+	r.cancel()
+	<-r.doneCh
+}
+
+func (r *customCheckRunner) run(ctx context.Context) {
+	defer close(r.doneCh)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	lastErr := ""
+	for {
+		result, err := r.probe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		result.at = time.Now()
+		r.mu.Lock()
+		r.result = result
+		r.mu.Unlock()
+
+		message := ""
+		if err != nil {
+			message = err.Error()
+		}
+		if message != lastErr {
+			if err != nil {
+				logWarnModule("custom", "%s check %s failed: %v", r.kind, r.target, err)
+			} else {
+				logInfoModule("custom", "%s check %s recovered", r.kind, r.target)
+			}
+			lastErr = message
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// latest returns the last result, or false before the first probe finished.
+func (r *customCheckRunner) latest() (customCheckResult, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.result, !r.result.at.IsZero()
+}
+
+// newCustomDNSProbe resolves host and reports how long it took.
+func newCustomDNSProbe(host, server string, timeout time.Duration) func(ctx context.Context) (customCheckResult, error) {
+	resolver := net.DefaultResolver
+	if server != "" {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, "53")
+		}
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+	return func(ctx context.Context) (customCheckResult, error) {
+		if host == "" {
+			return customCheckResult{}, errors.New("no target")
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		startedAt := time.Now()
+		addrs, err := resolver.LookupHost(ctx, host)
+		latency := time.Since(startedAt)
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		if len(addrs) == 0 {
+			return customCheckResult{}, errors.New("no addresses")
+		}
+		return customCheckResult{up: true, latency: latency}, nil
+	}
+}
+
+// newCustomHTTPProbe requests target on a fresh connection each time, so the
+// latency includes connecting and the TLS handshake. Any 2xx or 3xx status
+// counts as up.
+func newCustomHTTPProbe(target string, timeout time.Duration) func(ctx context.Context) (customCheckResult, error) {
+	parsed, parseErr := url.Parse(target)
+	if parseErr == nil && parsed.Scheme != "http" && parsed.Scheme != "https" {
+		parseErr = fmt.Errorf("unsupported url %q", target)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	client := &http.Client{Transport: transport}
+	return func(ctx context.Context) (customCheckResult, error) {
+		if target == "" {
+			return customCheckResult{}, errors.New("no target")
+		}
+		if parseErr != nil {
+			return customCheckResult{}, parseErr
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		req.Header.Set("User-Agent", "ax206monitor-check")
+		startedAt := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		latency := time.Since(startedAt)
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, customCheckMaxBodyBytes))
+		resp.Body.Close()
+
+		result := customCheckResult{latency: latency, status: resp.StatusCode}
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return result, errors.New("status " + strconv.Itoa(resp.StatusCode))
+		}
+		result.up = true
+		return result, nil
+	}
+}
+
+// newCustomSSHProbe runs the command through the ssh client, so hosts,
+// ports and known_hosts come from the usual OpenSSH configuration. BatchMode
+// makes a missing key or unknown host key fail instead of prompting.
+func newCustomSSHProbe(custom CustomMonitorConfig, timeout time.Duration) func(ctx context.Context) (customCheckResult, error) {
+	args := customSSHArgs(custom, timeout)
+	return func(ctx context.Context) (customCheckResult, error) {
+		if args == nil {
+			return customCheckResult{}, errors.New("target and command are required")
+		}
+		path, err := exec.LookPath("ssh")
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		startedAt := time.Now()
+		output, err := exec.CommandContext(ctx, path, args...).Output()
+		latency := time.Since(startedAt)
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return customCheckResult{}, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		if len(output) > customCheckMaxBodyBytes {
+			return customCheckResult{}, fmt.Errorf("output larger than %d bytes", customCheckMaxBodyBytes)
+		}
+		return customCheckResult{up: true, latency: latency, output: string(output)}, nil
+	}
+}
+
+func customSSHArgs(custom CustomMonitorConfig, timeout time.Duration) []string {
+	target := strings.TrimSpace(custom.Target)
+	command := strings.TrimSpace(custom.Command)
+	if target == "" || command == "" {
+		return nil
+	}
+	connectTimeout := int(timeout / time.Second)
+	if connectTimeout < 1 {
+		connectTimeout = 1
+	}
+	args := []string{
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=" + strconv.Itoa(connectTimeout),
+	}
+	if key := strings.TrimSpace(custom.Key); key != "" {
+		args = append(args, "-i", key, "-o", "IdentitiesOnly=yes")
+	}
+	return append(args, "--", target, command)
+}
+
+// customOutputText picks a value out of command output: the first match of
+// pattern (its first capture group when it has one), or without a pattern
+// the last non-empty line.
+func customOutputText(pattern *regexp.Regexp, output string) (string, bool) {
+	if pattern != nil {
+		match := pattern.FindStringSubmatch(output)
+		if match == nil {
+			return "", false
+		}
+		if len(match) > 1 {
+			return strings.TrimSpace(match[1]), true
+		}
+		return strings.TrimSpace(match[0]), true
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	line := strings.TrimSpace(lines[len(lines)-1])
+	return line, line != ""
+}
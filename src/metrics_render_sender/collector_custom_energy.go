@@ -0,0 +1,113 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// customEnergyWindow is the time constant of the power average the daily
+// estimates use, so a short load spike does not swing the cost.
+const customEnergyWindow = 5 * time.Minute
+
+// customEnergyMeter keeps an exponentially weighted average of the power
+// draw across collect passes.
+type customEnergyMeter struct {
+	mu      sync.Mutex
+	average float64
+	at      time.Time
+}
+
+func (m *customEnergyMeter) add(watts float64, now time.Time) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.at.IsZero() {
+		m.average = watts
+	} else if elapsed := now.Sub(m.at); elapsed > 0 {
+		weight := 1 - math.Exp(-elapsed.Seconds()/customEnergyWindow.Seconds())
+		m.average += weight * (watts - m.average)
+	}
+	m.at = now
+	return m.average
+}
+
+// buildEnergyEntryLocked registers <name> with the combined power draw,
+// <name>.kwh_day with the energy that draw adds up to over a day and, when
+// a price is set, <name>.cost_day with what that energy costs.
+func (c *CustomCollector) buildEnergyEntryLocked(name string, custom CustomMonitorConfig) customEntry {
+	label := strings.TrimSpace(custom.Label)
+	if label == "" {
+		label = name
+	}
+	entry := customEntry{
+		cfg:     custom,
+		item:    buildCustomCollectItem(&custom, label+" power", "W", 1, 0, 0),
+		kwhItem: NewCollectItem(name+".kwh_day", label+" energy per day", "kWh", 0, 0, 2),
+		energy:  &customEnergyMeter{},
+	}
+	c.setItem(name, entry.item)
+	c.setItem(name+".kwh_day", entry.kwhItem)
+	if custom.Price > 0 {
+		entry.costItem = NewCollectItem(name+".cost_day", label+" cost per day", strings.TrimSpace(custom.Currency), 0, 0, 2)
+		c.setItem(name+".cost_day", entry.costItem)
+	}
+	return entry
+}
+
+// updateCustomEnergyItems sums the source power monitors. Scale and offset
+// apply to the sum, e.g. to account for PSU losses and parts no sensor
+// covers. The estimates stay unavailable until some source reports.
+func updateCustomEnergyItems(entry customEntry, lookup func(string) *CollectItem, now time.Time) {
+	custom := entry.cfg
+	total, found := 0.0, false
+	for _, sourceName := range custom.Sources {
+		sourceName = strings.TrimSpace(sourceName)
+		if sourceName == "" || lookup == nil {
+			continue
+		}
+		source := lookup(sourceName)
+		if source == nil || !source.IsAvailable() {
+			continue
+		}
+		if watts, ok := customPowerWatts(source.GetValue()); ok {
+			total += watts
+			found = true
+		}
+	}
+	if !found {
+		entry.item.SetAvailable(false)
+		entry.kwhItem.SetAvailable(false)
+		if entry.costItem != nil {
+			entry.costItem.SetAvailable(false)
+		}
+		return
+	}
+	if custom.Scale != nil {
+		total *= *custom.Scale
+	}
+	total += custom.Offset
+	entry.item.SetValue(total)
+	entry.item.SetAvailable(true)
+
+	kwhPerDay := entry.energy.add(total, now) * 24 / 1000
+	entry.kwhItem.SetValue(kwhPerDay)
+	entry.kwhItem.SetAvailable(true)
+	if entry.costItem != nil {
+		entry.costItem.SetValue(kwhPerDay * custom.Price)
+		entry.costItem.SetAvailable(true)
+	}
+}
+
+// customPowerWatts reads a power monitor in watts. Sources reporting mW or
+// kW are converted; a source without a unit is taken to be in watts.
+func customPowerWatts(value *CollectValue) (float64, bool) {
+	number, ok := value.Float64()
+	if !ok || math.IsNaN(number) {
+		return 0, false
+	}
+	if watts, ok := convertUnitValue(number, value.Unit, "W"); ok {
+		return watts, true
+	}
+	return number, true
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	customMessageMaxBytes    = 4096
+	customMessageMaxRunes    = 256
+	customMessageFIFOTimeout = 20 * time.Millisecond
+)
+
+// readCustomMessage returns the latest one-line message from path.
+// Regular files are re-read every tick; FIFOs are drained without blocking and
+// keep the previous message when no writer pushed anything new.
+func readCustomMessage(path string, previous string) (string, bool, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", false, errors.New("missing message path")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false, err
+	}
+	if info.Mode()&os.ModeNamedPipe != 0 {
+		data, err := drainCustomMessageFIFO(path)
+		if err != nil {
+			return "", false, err
+		}
+		if message, ok := lastCustomMessageLine(data); ok {
+			return message, true, nil
+		}
+		return previous, previous != "", nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", false, err
+	}
+	defer file.Close()
+	if size := info.Size(); size > customMessageMaxBytes {
+		if _, err := file.Seek(size-customMessageMaxBytes, io.SeekStart); err != nil {
+			return "", false, err
+		}
+	}
+	data, err := io.ReadAll(io.LimitReader(file, customMessageMaxBytes))
+	if err != nil {
+		return "", false, err
+	}
+	message, ok := lastCustomMessageLine(data)
+	return message, ok, nil
+}
+
+func drainCustomMessageFIFO(path string) ([]byte, error) {
+	file, err := openCustomMessageFIFO(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	_ = file.SetReadDeadline(time.Now().Add(customMessageFIFOTimeout))
+
+	var data []byte
+	buf := make([]byte, 1024)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			data = append(data, buf[:n]...)
+			if len(data) > customMessageMaxBytes {
+				data = data[len(data)-customMessageMaxBytes:]
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, os.ErrDeadlineExceeded) {
+				return data, nil
+			}
+			return data, err
+		}
+	}
+}
+
+func lastCustomMessageLine(data []byte) (string, bool) {
+	lines := bytes.Split(data, []byte{'\n'})
+	for idx := len(lines) - 1; idx >= 0; idx-- {
+		line := strings.TrimSpace(strings.ToValidUTF8(string(lines[idx]), ""))
+		if line == "" {
+			continue
+		}
+		runes := []rune(line)
+		if len(runes) > customMessageMaxRunes {
+			line = string(runes[:customMessageMaxRunes])
+		}
+		return line, true
+	}
+	return "", false
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+func openCustomMessageFIFO(path string) (*os.File, error) {
+	// O_NONBLOCK keeps open(2) from waiting for a writer to attach.
+	return os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+}
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package main
+
+import (
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestReadCustomMessageFIFOKeepsPreviousWithoutWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "message.fifo")
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		t.Skipf("mkfifo failed: %v", err)
+	}
+	message, ok, err := readCustomMessage(path, "previous")
+	if err != nil {
+		t.Fatalf("readCustomMessage failed: %v", err)
+	}
+	if !ok || message != "previous" {
+		t.Fatalf("expected previous message, got %q ok=%v", message, ok)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package main
+
+import "os"
+
+func openCustomMessageFIFO(path string) (*os.File, error) {
+	return os.Open(path)
+}
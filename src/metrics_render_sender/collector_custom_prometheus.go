@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// customPrometheusMaxBytes bounds a scrape; kube-state-metrics on a busy
+// cluster runs to a few megabytes.
+const customPrometheusMaxBytes = 16 << 20
+
+// promSample is one series of a scrape in the text exposition format.
+type promSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// promMatcher is one label condition: =, !=, =~ or !~.
+type promMatcher struct {
+	label string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+// promQuery is the small subset of PromQL Prometheus monitors accept: a
+// metric name with optional label matchers, optionally wrapped in sum, avg,
+// min, max or count, e.g. count(kube_pod_info{namespace="default"}).
+type promQuery struct {
+	aggregate string
+	name      string
+	matchers  []promMatcher
+}
+
+var promAggregates = map[string]struct{}{"sum": {}, "avg": {}, "min": {}, "max": {}, "count": {}}
+
+func parsePromQuery(text string) (*promQuery, error) {
+	text = strings.TrimSpace(text)
+	query := &promQuery{}
+	if open := strings.IndexByte(text, '('); open > 0 && strings.HasSuffix(text, ")") {
+		aggregate := strings.ToLower(strings.TrimSpace(text[:open]))
+		if _, ok := promAggregates[aggregate]; !ok {
+			return nil, fmt.Errorf("unsupported function %q", aggregate)
+		}
+		query.aggregate = aggregate
+		text = strings.TrimSpace(text[open+1 : len(text)-1])
+	}
+	name := text
+	selector := ""
+	if brace := strings.IndexByte(text, '{'); brace >= 0 {
+		if !strings.HasSuffix(text, "}") {
+			return nil, errors.New("unterminated label matchers")
+		}
+		name = strings.TrimSpace(text[:brace])
+		selector = text[brace+1 : len(text)-1]
+	}
+	if name == "" {
+		return nil, errors.New("missing metric name")
+	}
+	query.name = name
+	for _, part := range splitPromMatchers(selector) {
+		matcher, err := parsePromMatcher(part)
+		if err != nil {
+			return nil, err
+		}
+		query.matchers = append(query.matchers, matcher)
+	}
+	return query, nil
+}
+
+// splitPromMatchers splits on commas outside quoted values.
+func splitPromMatchers(selector string) []string {
+	var parts []string
+	var current strings.Builder
+	quoted, escaped := false, false
+	for _, r := range selector {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	parts = append(parts, current.String())
+	out := parts[:0]
+	for _, part := range parts {
+		if strings.TrimSpace(part) != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func parsePromMatcher(text string) (promMatcher, error) {
+	text = strings.TrimSpace(text)
+	for _, op := range []string{"=~", "!~", "!=", "="} {
+		idx := strings.Index(text, op)
+		if idx <= 0 {
+			continue
+		}
+		matcher := promMatcher{label: strings.TrimSpace(text[:idx]), op: op}
+		value, err := strconv.Unquote(strings.TrimSpace(text[idx+len(op):]))
+		if err != nil {
+			return promMatcher{}, fmt.Errorf("label %s: value must be quoted", matcher.label)
+		}
+		matcher.value = value
+		if op == "=~" || op == "!~" {
+			// PromQL regexes are anchored.
+			if matcher.re, err = regexp.Compile("^(?:" + value + ")$"); err != nil {
+				return promMatcher{}, fmt.Errorf("label %s: %w", matcher.label, err)
+			}
+		}
+		return matcher, nil
+	}
+	return promMatcher{}, fmt.Errorf("invalid label matcher %q", text)
+}
+
+func (m promMatcher) matches(labels map[string]string) bool {
+	value := labels[m.label]
+	switch m.op {
+	case "=":
+		return value == m.value
+	case "!=":
+		return value != m.value
+	case "=~":
+		return m.re.MatchString(value)
+	default:
+		return !m.re.MatchString(value)
+	}
+}
+
+// evaluate picks the matching series. Without an aggregate the query has to
+// select exactly one series, so a selector that matches too much shows as
+// unavailable instead of an arbitrary value.
+func (q *promQuery) evaluate(samples []promSample) (float64, bool) {
+	values := make([]float64, 0, 1)
+	for _, sample := range samples {
+		if sample.name != q.name || math.IsNaN(sample.value) {
+			continue
+		}
+		matched := true
+		for _, matcher := range q.matchers {
+			if !matcher.matches(sample.labels) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			values = append(values, sample.value)
+		}
+	}
+	switch q.aggregate {
+	case "count":
+		return float64(len(values)), true
+	case "":
+		if len(values) != 1 {
+			return 0, false
+		}
+		return values[0], true
+	}
+	if len(values) == 0 {
+		return 0, false
+	}
+	result := values[0]
+	for _, value := range values[1:] {
+		switch q.aggregate {
+		case "min":
+			result = math.Min(result, value)
+		case "max":
+			result = math.Max(result, value)
+		default:
+			result += value
+		}
+	}
+	if q.aggregate == "avg" {
+		result /= float64(len(values))
+	}
+	return result, true
+}
+
+// parsePrometheusText reads the text exposition format. Comments, and
+// lines that do not parse, are skipped.
+func parsePrometheusText(r io.Reader) ([]promSample, error) {
+	var samples []promSample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if sample, ok := parsePrometheusLine(line); ok {
+			samples = append(samples, sample)
+		}
+	}
+	return samples, scanner.Err()
+}
+
+func parsePrometheusLine(line string) (promSample, bool) {
+	sample := promSample{labels: map[string]string{}}
+	rest := line
+	if brace := strings.IndexByte(line, '{'); brace >= 0 && brace < strings.IndexAny(line+" ", " \t") {
+		sample.name = line[:brace]
+		end, ok := parsePrometheusLabels(line[brace+1:], sample.labels)
+		if !ok {
+			return promSample{}, false
+		}
+		rest = line[brace+1+end:]
+	} else {
+		fields := strings.Fields(line)
+		sample.name = fields[0]
+		rest = strings.TrimPrefix(line, fields[0])
+	}
+	fields := strings.Fields(rest)
+	if sample.name == "" || len(fields) == 0 {
+		return promSample{}, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return promSample{}, false
+	}
+	sample.value = value
+	return sample, true
+}
+
+// parsePrometheusLabels reads label="value" pairs up to the closing brace
+// and returns the offset just past it.
+func parsePrometheusLabels(text string, labels map[string]string) (int, bool) {
+	pos := 0
+	for {
+		for pos < len(text) && (text[pos] == ' ' || text[pos] == ',') {
+			pos++
+		}
+		if pos >= len(text) {
+			return 0, false
+		}
+		if text[pos] == '}' {
+			return pos + 1, true
+		}
+		eq := strings.IndexByte(text[pos:], '=')
+		if eq <= 0 || pos+eq+1 >= len(text) || text[pos+eq+1] != '"' {
+			return 0, false
+		}
+		name := strings.TrimSpace(text[pos : pos+eq])
+		pos += eq + 2
+		var value strings.Builder
+		for {
+			if pos >= len(text) {
+				return 0, false
+			}
+			c := text[pos]
+			pos++
+			if c == '"' {
+				break
+			}
+			if c == '\\' && pos < len(text) {
+				switch text[pos] {
+				case 'n':
+					c = '\n'
+				default:
+					c = text[pos]
+				}
+				pos++
+			}
+			value.WriteByte(c)
+		}
+		labels[name] = value.String()
+	}
+}
+
+// newCustomPrometheusProbe scrapes target, keeping the parsed samples so
+// every monitor on the endpoint evaluates its query against one scrape.
+func newCustomPrometheusProbe(target string, timeout time.Duration) func(ctx context.Context) (customCheckResult, error) {
+	parsed, parseErr := url.Parse(target)
+	if parseErr == nil && parsed.Scheme != "http" && parsed.Scheme != "https" {
+		parseErr = fmt.Errorf("unsupported url %q", target)
+	}
+	client := &http.Client{}
+	return func(ctx context.Context) (customCheckResult, error) {
+		if target == "" {
+			return customCheckResult{}, errors.New("no target")
+		}
+		if parseErr != nil {
+			return customCheckResult{}, parseErr
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		req.Header.Set("Accept", "text/plain;version=0.0.4")
+		startedAt := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return customCheckResult{status: resp.StatusCode}, errors.New("status " + strconv.Itoa(resp.StatusCode))
+		}
+		samples, err := parsePrometheusText(io.LimitReader(resp.Body, customPrometheusMaxBytes))
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		return customCheckResult{up: true, latency: time.Since(startedAt), status: resp.StatusCode, samples: samples}, nil
+	}
+}
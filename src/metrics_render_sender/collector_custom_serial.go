@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	customSerialDefaultBaud   = 9600
+	customSerialLineHistory   = 32
+	customSerialMaxLineBytes  = 1024
+	customSerialStaleAfter    = 30 * time.Second
+	customSerialRetryInterval = 5 * time.Second
+)
+
+type customSerialLine struct {
+	text string
+	at   time.Time
+}
+
+// customSerialReader owns one open port and keeps its most recent lines, so
+// several serial monitors can pick different values out of the same stream,
+// e.g. "T:23.4 H:45" lines from an Arduino.
+type customSerialReader struct {
+	path string
+	baud int
+
+	mu    sync.Mutex
+	lines []customSerialLine
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func customSerialReaderKey(custom CustomMonitorConfig) (string, int) {
+	baud := custom.Baud
+	if baud <= 0 {
+		baud = customSerialDefaultBaud
+	}
+	return strings.TrimSpace(custom.Path) + "@" + strconv.Itoa(baud), baud
+}
+
+func startCustomSerialReader(path string, baud int) *customSerialReader {
+	reader := &customSerialReader{
+		path:   path,
+		baud:   baud,
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+	go reader.run()
+	return reader
+}
+
+func (r *customSerialReader) stop() {
+	close(r.stopCh)
+	<-r.doneCh
+}
+
+func (r *customSerialReader) run() {
+	defer close(r.doneCh)
+	lastErr := ""
+	for {
+		err := r.readPort()
+		if err != nil && err.Error() != lastErr {
+			logWarnModule("custom", "serial port %s@%d: %v", r.path, r.baud, err)
+			lastErr = err.Error()
+		}
+		select {
+		case <-r.stopCh:
+			return
+		case <-time.After(customSerialRetryInterval):
+		}
+	}
+}
+
+func (r *customSerialReader) readPort() error {
+	port, err := openSerialPort(r.path, r.baud)
+	if err != nil {
+		return err
+	}
+	defer port.Close()
+
+	var pending []byte
+	buf := make([]byte, 256)
+	fastEOFs := 0
+	for {
+		select {
+		case <-r.stopCh:
+			return nil
+		default:
+		}
+		startedAt := time.Now()
+		n, err := port.Read(buf)
+		if n > 0 {
+			pending = r.consume(append(pending, buf[:n]...))
+			fastEOFs = 0
+		}
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return err
+		}
+		// A read timeout also surfaces as EOF; only an immediate EOF with no
+		// data, repeated, means the device went away.
+		if n == 0 && time.Since(startedAt) < 50*time.Millisecond {
+			fastEOFs++
+			if fastEOFs >= 3 {
+				return errors.New("port closed")
+			}
+		}
+	}
+}
+
+// consume stores every complete line of data and returns the unterminated
+// tail. Overlong tails are dropped so a port spewing binary cannot grow it.
+func (r *customSerialReader) consume(data []byte) []byte {
+	for {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			break
+		}
+		line := strings.TrimSpace(strings.ToValidUTF8(string(data[:idx]), ""))
+		data = data[idx+1:]
+		if line != "" {
+			r.push(line, time.Now())
+		}
+	}
+	if len(data) > customSerialMaxLineBytes {
+		return nil
+	}
+	return append([]byte(nil), data...)
+}
+
+func (r *customSerialReader) push(line string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines = append(r.lines, customSerialLine{text: line, at: at})
+	if len(r.lines) > customSerialLineHistory {
+		r.lines = r.lines[len(r.lines)-customSerialLineHistory:]
+	}
+}
+
+// latest returns the newest fresh line matching pattern. With a capture group
+// the first group is returned, otherwise the whole match; a nil pattern takes
+// the newest line as is.
+func (r *customSerialReader) latest(pattern *regexp.Regexp, now time.Time) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for idx := len(r.lines) - 1; idx >= 0; idx-- {
+		line := r.lines[idx]
+		if now.Sub(line.at) > customSerialStaleAfter {
+			break
+		}
+		if pattern == nil {
+			return line.text, true
+		}
+		match := pattern.FindStringSubmatch(line.text)
+		if match == nil {
+			continue
+		}
+		if len(match) > 1 {
+			return strings.TrimSpace(match[1]), true
+		}
+		return strings.TrimSpace(match[0]), true
+	}
+	return "", false
+}
+
+func compileCustomPattern(custom CustomMonitorConfig) (*regexp.Regexp, error) {
+	pattern := strings.TrimSpace(custom.Pattern)
+	if pattern == "" {
+		return nil, nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern for %s: %w", strings.TrimSpace(custom.Name), err)
+	}
+	return compiled, nil
+}
+
+// customSerialValue turns the extracted text into a number when it parses as
+// one, applying scale and offset; anything else is shown as text.
+func customSerialValue(custom CustomMonitorConfig, text string) interface{} {
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return text
+	}
+	if custom.Scale != nil {
+		value *= *custom.Scale
+	}
+	return value + custom.Offset
+}
@@ -1,6 +1,19 @@
 package main
 
-import "testing"
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
 
 func TestNormalizeAggregateMethodSupportsSum(t *testing.T) {
 	if got := normalizeAggregateMethod("sum"); got != "sum" {
@@ -58,3 +71,368 @@ func TestCustomCollectorMixedSum(t *testing.T) {
 		t.Fatalf("expected sum 4.0, got %v", got)
 	}
 }
+
+func TestCustomCollectorEnergyEstimatesCost(t *testing.T) {
+	sources := map[string]*CollectItem{
+		"cpu.power": NewCollectItem("cpu.power", "CPU power", "W", 0, 0, 1),
+		"gpu.power": NewCollectItem("gpu.power", "GPU power", "mW", 0, 0, 0),
+	}
+	sources["cpu.power"].SetValue(45.0)
+	sources["cpu.power"].SetAvailable(true)
+	sources["gpu.power"].SetValue(120000.0)
+	sources["gpu.power"].SetAvailable(true)
+	collector := NewCustomCollector(nil, func(name string) *CollectItem { return sources[name] })
+	collector.ApplyConfig(&MonitorConfig{
+		CustomMonitors: []CustomMonitorConfig{
+			{Name: "pc", Type: "energy", Sources: []string{"cpu.power", "gpu.power", "missing"}, Offset: 15, Price: 0.3, Currency: "€"},
+			{Name: "nas", Type: "energy", Sources: []string{"missing"}},
+		},
+	})
+	if err := collector.UpdateItems(); err != nil {
+		t.Fatalf("UpdateItems failed: %v", err)
+	}
+	for name, want := range map[string]float64{"pc": 180, "pc.kwh_day": 4.32, "pc.cost_day": 1.296} {
+		item := collector.getItem(name)
+		got, _ := item.GetValue().Float64()
+		if !item.IsAvailable() || math.Abs(got-want) > 1e-9 {
+			t.Fatalf("expected %s=%v, got %+v", name, want, item.GetValue())
+		}
+	}
+	if unit := collector.getItem("pc.cost_day").GetValue().Unit; unit != "€" {
+		t.Fatalf("expected cost in €, got %q", unit)
+	}
+	if collector.getItem("nas").IsAvailable() || collector.getItem("nas.kwh_day").IsAvailable() {
+		t.Fatal("expected an energy monitor without sources to be unavailable")
+	}
+	if collector.getItem("nas.cost_day") != nil {
+		t.Fatal("expected no cost without a price")
+	}
+
+	required := getRequiredMonitors(&MonitorConfig{
+		Items:          []ItemConfig{{Type: "simple_value", Monitor: "pc.cost_day"}},
+		CustomMonitors: collector.cfg.CustomMonitors,
+	})
+	sort.Strings(required)
+	if want := []string{"cpu.power", "gpu.power", "missing", "pc.cost_day"}; !reflect.DeepEqual(required, want) {
+		t.Fatalf("expected the cost to require its sources, got %v", required)
+	}
+}
+
+func TestCustomEnergyMeterAverages(t *testing.T) {
+	meter := &customEnergyMeter{}
+	start := time.Now()
+	if got := meter.add(100, start); got != 100 {
+		t.Fatalf("expected the first sample as average, got %v", got)
+	}
+	want := 100 + (1-math.Exp(-1))*100
+	if got := meter.add(200, start.Add(customEnergyWindow)); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected %v after one window, got %v", want, got)
+	}
+}
+
+func TestCustomCollectorMessageReadsLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "message")
+	if err := os.WriteFile(path, []byte("boot: power button\n\nbackup done\n"), 0o644); err != nil {
+		t.Fatalf("write message file failed: %v", err)
+	}
+	collector := NewCustomCollector(nil, nil)
+	collector.cfg = &MonitorConfig{
+		CustomMonitors: []CustomMonitorConfig{
+			{Name: "status.message", Type: "message", Path: path},
+		},
+	}
+	collector.rebuildItemsLocked()
+
+	if err := collector.UpdateItems(); err != nil {
+		t.Fatalf("UpdateItems failed: %v", err)
+	}
+	item := collector.getItem("status.message")
+	if item == nil || !item.IsAvailable() {
+		t.Fatalf("expected available message item")
+	}
+	if got := item.GetValue().Value; got != "backup done" {
+		t.Fatalf("expected last line, got %v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("   \n"), 0o644); err != nil {
+		t.Fatalf("rewrite message file failed: %v", err)
+	}
+	if err := collector.UpdateItems(); err != nil {
+		t.Fatalf("UpdateItems failed: %v", err)
+	}
+	if item.IsAvailable() {
+		t.Fatalf("expected empty message file to be unavailable")
+	}
+}
+
+func TestCustomSerialReaderPicksLatestMatchingLine(t *testing.T) {
+	reader := &customSerialReader{}
+	tail := reader.consume([]byte("T:21.5 H:40\r\nT:22.0 H:41\nboot\nT:22"))
+	if string(tail) != "T:22" {
+		t.Fatalf("unexpected pending tail: %q", tail)
+	}
+
+	pattern, err := compileCustomPattern(CustomMonitorConfig{Pattern: `H:(\d+)`})
+	if err != nil {
+		t.Fatalf("compile pattern: %v", err)
+	}
+	text, ok := reader.latest(pattern, time.Now())
+	if !ok || text != "41" {
+		t.Fatalf("unexpected humidity match: %q ok=%v", text, ok)
+	}
+	if text, _ := reader.latest(nil, time.Now()); text != "boot" {
+		t.Fatalf("expected newest raw line, got %q", text)
+	}
+	if _, ok := reader.latest(pattern, time.Now().Add(customSerialStaleAfter+time.Second)); ok {
+		t.Fatalf("expected stale lines to be ignored")
+	}
+
+	scale := 0.1
+	if value := customSerialValue(CustomMonitorConfig{Scale: &scale, Offset: 1}, "220"); value != 23.0 {
+		t.Fatalf("unexpected scaled value: %v", value)
+	}
+	if value := customSerialValue(CustomMonitorConfig{}, "ok"); value != "ok" {
+		t.Fatalf("expected text passthrough, got %v", value)
+	}
+}
+
+func TestCustomCollectorHTTPCheck(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	status := http.StatusOK
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	collector := NewCustomCollector(nil, nil)
+	collector.ApplyConfig(&MonitorConfig{
+		CustomMonitors: []CustomMonitorConfig{
+			{Name: "check.web", Type: "http", Target: server.URL + "/health", IntervalMS: 1000},
+			{Name: "check.web_again", Type: "http", Target: server.URL + "/health", IntervalMS: 1000},
+		},
+	})
+	defer collector.Close()
+	if len(collector.checks) != 1 {
+		t.Fatalf("expected identical checks to share a runner, got %d", len(collector.checks))
+	}
+
+	waitForCheck := func(want float64) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if err := collector.UpdateItems(); err != nil {
+				t.Fatalf("UpdateItems failed: %v", err)
+			}
+			if up := collector.getItem("check.web.up"); up.IsAvailable() && up.GetValue().Value == want {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("check did not report up=%v", want)
+	}
+
+	waitForCheck(1)
+	latency := collector.getItem("check.web")
+	if !latency.IsAvailable() || latency.GetValue().Unit != "ms" {
+		t.Fatalf("expected latency in ms, got %+v", latency.GetValue())
+	}
+	if got := collector.getItem("check.web.status").GetValue().Value; got != 200.0 {
+		t.Fatalf("expected status 200, got %v", got)
+	}
+
+	mu.Lock()
+	status = http.StatusServiceUnavailable
+	mu.Unlock()
+	waitForCheck(0)
+	if latency.IsAvailable() {
+		t.Fatal("expected no latency while the endpoint is down")
+	}
+	if got := collector.getItem("check.web.status").GetValue().Value; got != 503.0 {
+		t.Fatalf("expected status 503, got %v", got)
+	}
+}
+
+func TestCustomDNSProbeResolvesLocalhost(t *testing.T) {
+	probe := newCustomDNSProbe("localhost", "", time.Second)
+	result, err := probe(context.Background())
+	if err != nil || !result.up {
+		t.Fatalf("expected localhost to resolve: %+v %v", result, err)
+	}
+	if _, err := newCustomDNSProbe("", "", time.Second)(context.Background()); err == nil {
+		t.Fatal("expected an error without a target")
+	}
+	if _, err := newCustomHTTPProbe("ftp://example.com", time.Second)(context.Background()); err == nil {
+		t.Fatal("expected an error for a non-http url")
+	}
+}
+
+func TestCustomSSHArgs(t *testing.T) {
+	args := customSSHArgs(CustomMonitorConfig{Target: "ssh://pi@nas:2222", Command: "uptime", Key: "/root/.ssh/nas"}, 2500*time.Millisecond)
+	want := []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=2", "-i", "/root/.ssh/nas", "-o", "IdentitiesOnly=yes", "--", "ssh://pi@nas:2222", "uptime"}
+	if len(args) != len(want) {
+		t.Fatalf("unexpected args: %q", args)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Fatalf("unexpected args: %q", args)
+		}
+	}
+	if args := customSSHArgs(CustomMonitorConfig{Target: "nas"}, time.Second); args != nil {
+		t.Fatalf("expected no args without a command, got %q", args)
+	}
+	if got := customCheckTimeout(CustomMonitorConfig{IntervalMS: 2000, TimeoutMS: 10000}); got != 2*time.Second {
+		t.Fatalf("expected timeout capped at the interval, got %v", got)
+	}
+}
+
+func TestCustomCollectorSSHPicksValueFromOutput(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	scale := 0.001
+	collector := NewCustomCollector(nil, nil)
+	collector.ApplyConfig(&MonitorConfig{
+		CustomMonitors: []CustomMonitorConfig{
+			{Name: "nas.temp", Type: "ssh", Target: "nas", Scale: &scale},
+			{Name: "nas.load", Type: "ssh", Target: "nas", Pattern: `load average: ([\d.]+)`},
+			{Name: "nas.users", Type: "ssh", Target: "nas", Pattern: `(\d+) users`},
+		},
+	})
+	defer collector.Close()
+
+	output := " 10:00:00 up 3 days,  2 users,  load average: 0.42, 0.30, 0.25\n41500\n\n"
+	collector.mu.Lock()
+	for name, entry := range collector.items {
+		entry.check = &customCheckRunner{result: customCheckResult{up: true, output: output, at: time.Now()}}
+		collector.items[name] = entry
+	}
+	collector.mu.Unlock()
+	if err := collector.UpdateItems(); err != nil {
+		t.Fatalf("UpdateItems failed: %v", err)
+	}
+	for name, want := range map[string]float64{"nas.temp": 41.5, "nas.load": 0.42, "nas.users": 2} {
+		item := collector.getItem(name)
+		if !item.IsAvailable() || item.GetValue().Value != want {
+			t.Fatalf("expected %s=%v, got %+v", name, want, item.GetValue())
+		}
+	}
+
+	collector.mu.Lock()
+	for name, entry := range collector.items {
+		entry.check = &customCheckRunner{result: customCheckResult{at: time.Now()}}
+		collector.items[name] = entry
+	}
+	collector.mu.Unlock()
+	if err := collector.UpdateItems(); err != nil {
+		t.Fatalf("UpdateItems failed: %v", err)
+	}
+	if collector.getItem("nas.load").IsAvailable() {
+		t.Fatal("expected a failed command to leave the monitor unavailable")
+	}
+}
+
+const testPrometheusScrape = `# HELP kube_pod_info Information about pod.
+# TYPE kube_pod_info gauge
+kube_pod_info{namespace="default",pod="web-1",node="n1"} 1
+kube_pod_info{namespace="default",pod="web-2",node="n2"} 1
+kube_pod_info{namespace="kube-system",pod="dns, \"core\"",node="n1"} 1
+node_load1 0.75 1700000000000
+node_filesystem_avail_bytes{mountpoint="/"} 2e+09
+node_filesystem_avail_bytes{mountpoint="/boot"} 1e+08
+node_hwmon_temp_celsius{chip="nvme"} NaN
+`
+
+func TestPromQueryEvaluate(t *testing.T) {
+	samples, err := parsePrometheusText(strings.NewReader(testPrometheusScrape))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(samples) != 7 {
+		t.Fatalf("expected 7 samples, got %d", len(samples))
+	}
+	if got := samples[2].labels["pod"]; got != `dns, "core"` {
+		t.Fatalf("expected escaped label value, got %q", got)
+	}
+
+	cases := []struct {
+		query string
+		want  float64
+		ok    bool
+	}{
+		{`node_load1`, 0.75, true},
+		{`count(kube_pod_info{namespace="default"})`, 2, true},
+		{`count(kube_pod_info{node=~"n.*", namespace!="default"})`, 1, true},
+		{`sum(node_filesystem_avail_bytes)`, 2.1e9, true},
+		{`max(node_filesystem_avail_bytes{mountpoint!~"/boot"})`, 2e9, true},
+		{`node_filesystem_avail_bytes{mountpoint="/boot"}`, 1e8, true},
+		{`node_filesystem_avail_bytes`, 0, false},
+		{`node_hwmon_temp_celsius`, 0, false},
+		{`count(missing_metric)`, 0, true},
+		{`avg(missing_metric)`, 0, false},
+	}
+	for _, tc := range cases {
+		query, err := parsePromQuery(tc.query)
+		if err != nil {
+			t.Fatalf("%s: parse failed: %v", tc.query, err)
+		}
+		got, ok := query.evaluate(samples)
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("%s: expected %v (%v), got %v (%v)", tc.query, tc.want, tc.ok, got, ok)
+		}
+	}
+
+	for _, invalid := range []string{"", `rate(node_load1)`, `node_load1{mode=idle}`, `node_load1{mode="idle"`} {
+		if _, err := parsePromQuery(invalid); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestCustomCollectorPrometheusScrape(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	var mu sync.Mutex
+	scrapes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		scrapes++
+		mu.Unlock()
+		_, _ = w.Write([]byte(testPrometheusScrape))
+	}))
+	defer server.Close()
+
+	scale := 1e-9
+	collector := NewCustomCollector(nil, nil)
+	collector.ApplyConfig(&MonitorConfig{
+		CustomMonitors: []CustomMonitorConfig{
+			{Name: "k8s.pods", Type: "prometheus", Target: server.URL + "/metrics", Query: `count(kube_pod_info{namespace="default"})`},
+			{Name: "k8s.free", Type: "prom", Target: server.URL + "/metrics", Query: `node_filesystem_avail_bytes{mountpoint="/"}`, Scale: &scale},
+		},
+	})
+	defer collector.Close()
+	if len(collector.checks) != 1 {
+		t.Fatalf("expected monitors on one endpoint to share a scrape, got %d runners", len(collector.checks))
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := collector.UpdateItems(); err != nil {
+			t.Fatalf("UpdateItems failed: %v", err)
+		}
+		if collector.getItem("k8s.pods").IsAvailable() {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	for name, want := range map[string]float64{"k8s.pods": 2, "k8s.free": 2} {
+		item := collector.getItem(name)
+		if !item.IsAvailable() || item.GetValue().Value != want {
+			t.Fatalf("expected %s=%v, got %+v", name, want, item.GetValue())
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if scrapes != 1 {
+		t.Fatalf("expected one scrape, got %d", scrapes)
+	}
+}
@@ -1,12 +1,10 @@
 package main
 
 import (
-	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/shirou/gopsutil/v3/cpu"
@@ -67,25 +65,11 @@ func detectCPUInfo() *CPUInfo {
 }
 
 func detectDiskInfo() []*DiskInfo {
-	disks := detectDiskInfoStatic()
+	disks := filterDisks(currentPlatform.DetectDisks(), currentDeviceFilter(collectorGoNativeDisk))
 	populateDiskDynamicMetrics(disks)
 	return disks
 }
 
-func detectDiskInfoStatic() []*DiskInfo {
-	if runtime.GOOS == "windows" {
-		return detectDiskInfoByWindows()
-	}
-	if runtime.GOOS == "linux" {
-		disks, err := detectDiskInfoBySysfs()
-		if err == nil {
-			return disks
-		}
-		logWarnModule("disk", "sysfs disk detection failed, fallback to gopsutil: %v", err)
-	}
-	return detectDiskInfoByGopsutil()
-}
-
 type diskUsageAccumulator struct {
 	info       *DiskInfo
 	totalBytes uint64
@@ -93,12 +77,13 @@ type diskUsageAccumulator struct {
 }
 
 func detectDiskInfoBySysfs() ([]*DiskInfo, error) {
-	entries, err := os.ReadDir("/sys/block")
+	entries, err := sensors.ReadDir("/sys/block")
 	if err != nil {
 		return nil, err
 	}
 
 	usageByDisk := collectDiskUsageByBaseName()
+	byID := readLinuxDiskByIDNames()
 	disks := make([]*DiskInfo, 0, len(entries))
 	for _, entry := range entries {
 		if entry == nil {
@@ -113,6 +98,7 @@ func detectDiskInfoBySysfs() ([]*DiskInfo, error) {
 		if info == nil {
 			continue
 		}
+		info.ByID = byID[baseName]
 		disks = append(disks, info)
 	}
 
@@ -248,7 +234,7 @@ func isLinuxPhysicalDiskName(name string) bool {
 		return false
 	}
 	infoPath := filepath.Join("/sys/block", name, "device")
-	if _, err := os.Stat(infoPath); err != nil {
+	if _, err := sensors.Stat(infoPath); err != nil {
 		return false
 	}
 	return true
@@ -262,9 +248,11 @@ func buildDiskInfoFromSysfs(baseName string, usage *diskUsageAccumulator) *DiskI
 
 	sizeBytes := readSysfsUint64(filepath.Join("/sys/block", baseName, "size")) * 512
 	info := &DiskInfo{
-		Name:  baseName,
-		Model: readDiskModelFromSysfs(baseName),
-		Size:  int64(sizeBytes / (1024 * 1024 * 1024)),
+		Name:   baseName,
+		Model:  readDiskModelFromSysfs(baseName),
+		Serial: readSysfsTrimmed(filepath.Join("/sys/block", baseName, "device", "serial")),
+		WWN:    readDiskWWNFromSysfs(baseName),
+		Size:   int64(sizeBytes / (1024 * 1024 * 1024)),
 	}
 	if usage != nil && usage.totalBytes > 0 {
 		info.Size = int64(usage.totalBytes / (1024 * 1024 * 1024))
@@ -357,22 +345,51 @@ func readDiskModelFromSysfs(baseName string) string {
 	return model
 }
 
+// readDiskWWNFromSysfs reads the world wide name NVMe namespaces keep in
+// wwid and SCSI and SATA disks in device/wwid, e.g. eui.0025388b91b1d1a4
+// or naa.5002538e40a2b3c4.
+func readDiskWWNFromSysfs(baseName string) string {
+	if wwn := readSysfsTrimmed(filepath.Join("/sys/block", baseName, "wwid")); wwn != "" {
+		return wwn
+	}
+	return readSysfsTrimmed(filepath.Join("/sys/block", baseName, "device", "wwid"))
+}
+
+// readLinuxDiskByIDNames maps whole disks to the names udev links to them
+// under /dev/disk/by-id, such as ata-Samsung_SSD_860_EVO_1TB_S3Z9NB0K and
+// wwn-0x5002538e40a2b3c4. Partition links are skipped.
+func readLinuxDiskByIDNames() map[string][]string {
+	entries, err := sensors.ReadDir("/dev/disk/by-id")
+	if err != nil {
+		return map[string][]string{}
+	}
+	result := make(map[string][]string)
+	for _, entry := range entries {
+		target, err := sensors.EvalSymlinks(filepath.Join("/dev/disk/by-id", entry.Name()))
+		if err != nil {
+			continue
+		}
+		device := filepath.Base(target)
+		if device != trimDiskPartitionSuffix(device) {
+			continue
+		}
+		result[device] = append(result[device], entry.Name())
+	}
+	return result
+}
+
 func readSysfsTrimmed(filename string) string {
-	data, err := os.ReadFile(filename)
+	value, err := sensors.ReadAttr(filename)
 	if err != nil {
 		return ""
 	}
-	return strings.TrimSpace(string(data))
+	return value
 }
 
 func readSysfsUint64(filename string) uint64 {
-	value := readSysfsTrimmed(filename)
-	if value == "" {
-		return 0
-	}
-	number, err := strconv.ParseUint(value, 10, 64)
+	value, err := sensors.ReadAttrUint(filename)
 	if err != nil {
 		return 0
 	}
-	return number
+	return value
 }
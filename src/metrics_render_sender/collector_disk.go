@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -28,6 +30,7 @@ type goNativeDiskSlot struct {
 	availableItem    *CollectItem
 	usageItem        *CollectItem
 	tempItem         *CollectItem
+	healthItem       *CollectItem
 	busyItem         *CollectItem
 	readItem         *CollectItem
 	writeItem        *CollectItem
@@ -41,7 +44,18 @@ type GoNativeDiskCollector struct {
 	*BaseCollector
 	requiredProvider func() []string
 	slots            map[int]*goNativeDiskSlot
+	totalSizeItem    *CollectItem
+	totalUsedItem    *CollectItem
+	totalFreeItem    *CollectItem
 	runtimeMu        runtimeDiskMetricsStore
+	// resetRates asks the next update to drop runtimeMu, which only the
+	// collector worker touches.
+	resetRates atomic.Bool
+	// rateMaxGap is the rateMaxGap of the collector's update_interval_ms.
+	rateMaxGap atomic.Int64
+	// slotIDs is the slots option: the disks that go_native.disk.<N> pins.
+	slotIDsMu sync.RWMutex
+	slotIDs   []string
 }
 
 type runtimeDiskMetricsStore struct {
@@ -96,14 +110,47 @@ func smoothDiskMetrics(previous *diskComputedMetrics, current *diskComputedMetri
 }
 
 func NewGoNativeDiskCollector(requiredProvider func() []string) *GoNativeDiskCollector {
-	return &GoNativeDiskCollector{
+	c := &GoNativeDiskCollector{
 		BaseCollector:    NewBaseCollector("go_native.disk"),
 		requiredProvider: requiredProvider,
 		slots:            make(map[int]*goNativeDiskSlot),
+		totalSizeItem:    NewCollectItem("go_native.disk.total_size", "Disks total size", "GB", 0, 0, 0),
+		totalUsedItem:    NewCollectItem("go_native.disk.total_used", "Disks total used", "GB", 0, 0, 0),
+		totalFreeItem:    NewCollectItem("go_native.disk.total_free", "Disks total free", "GB", 0, 0, 0),
 		runtimeMu: runtimeDiskMetricsStore{
 			states: make(map[string]*runtimeDiskMetricsState),
 		},
 	}
+	c.setItem(c.totalSizeItem.GetName(), c.totalSizeItem)
+	c.setItem(c.totalUsedItem.GetName(), c.totalUsedItem)
+	c.setItem(c.totalFreeItem.GetName(), c.totalFreeItem)
+	c.rateMaxGap.Store(int64(rateSampleMaxGap))
+	return c
+}
+
+// ApplyConfig picks up the slots option, which pins disks to slot numbers
+// by serial, WWN or by-id name instead of device name order, and the
+// include and exclude filters, which apply from the next disk scan. The
+// rates may span twice update_interval_ms.
+func (c *GoNativeDiskCollector) ApplyConfig(cfg *MonitorConfig) {
+	var slotIDs []string
+	maxGap := rateSampleMaxGap
+	if cfg != nil {
+		slotIDs = parseDiskSlotOption(cfg.GetCollectorStringOption(collectorGoNativeDisk, "slots", ""))
+		maxGap = rateMaxGap(cfg.GetCollectorSchedule(collectorGoNativeDisk).interval)
+	}
+	c.rateMaxGap.Store(int64(maxGap))
+	setDeviceFilter(collectorGoNativeDisk, deviceFilterFromConfig(cfg, collectorGoNativeDisk))
+	healthInterval := defaultDiskHealthInterval
+	if cfg != nil {
+		if minutes, err := strconv.Atoi(strings.TrimSpace(cfg.GetCollectorStringOption(collectorGoNativeDisk, "health_interval_minutes", ""))); err == nil && minutes > 0 {
+			healthInterval = time.Duration(minutes) * time.Minute
+		}
+	}
+	sharedDiskHealthService.SetInterval(healthInterval)
+	c.slotIDsMu.Lock()
+	c.slotIDs = slotIDs
+	c.slotIDsMu.Unlock()
 }
 
 func (c *GoNativeDiskCollector) requiredMaxIndex() int {
@@ -127,7 +174,7 @@ func (c *GoNativeDiskCollector) requiredMaxIndex() int {
 			continue
 		}
 		switch parts[1] {
-		case "name", "size", "used", "available", "usage", "temp", "busy", "read", "write", "read_iops", "write_iops", "read_latency", "write_latency":
+		case "name", "size", "used", "available", "usage", "temp", "health", "busy", "read", "write", "read_iops", "write_iops", "read_latency", "write_latency":
 			if idx > maxIndex {
 				maxIndex = idx
 			}
@@ -157,6 +204,7 @@ func (c *GoNativeDiskCollector) ensureSlotsForCount(detected int) {
 			availableItem:    NewCollectItem(fmt.Sprintf("go_native.disk.%d.available", index), fmt.Sprintf("Disk %d available", index), "GB", 0, 0, 0),
 			usageItem:        NewCollectItem(fmt.Sprintf("go_native.disk.%d.usage", index), fmt.Sprintf("Disk %d usage", index), "%", 0, 100, 0),
 			tempItem:         NewCollectItem(fmt.Sprintf("go_native.disk.%d.temp", index), fmt.Sprintf("Disk %d temperature", index), "°C", 0, DiskTempMax, 1),
+			healthItem:       NewCollectItem(fmt.Sprintf("go_native.disk.%d.health", index), fmt.Sprintf("Disk %d health", index), "", 0, 0, 0),
 			busyItem:         NewCollectItem(fmt.Sprintf("go_native.disk.%d.busy", index), fmt.Sprintf("Disk %d busy", index), "%", 0, 100, 0),
 			readItem:         NewCollectItem(fmt.Sprintf("go_native.disk.%d.read", index), fmt.Sprintf("Disk %d read speed", index), "MiB/s", 0, 0, 2),
 			writeItem:        NewCollectItem(fmt.Sprintf("go_native.disk.%d.write", index), fmt.Sprintf("Disk %d write speed", index), "MiB/s", 0, 0, 2),
@@ -172,6 +220,7 @@ func (c *GoNativeDiskCollector) ensureSlotsForCount(detected int) {
 		c.setItem(slot.availableItem.GetName(), slot.availableItem)
 		c.setItem(slot.usageItem.GetName(), slot.usageItem)
 		c.setItem(slot.tempItem.GetName(), slot.tempItem)
+		c.setItem(slot.healthItem.GetName(), slot.healthItem)
 		c.setItem(slot.busyItem.GetName(), slot.busyItem)
 		c.setItem(slot.readItem.GetName(), slot.readItem)
 		c.setItem(slot.writeItem.GetName(), slot.writeItem)
@@ -185,7 +234,10 @@ func (c *GoNativeDiskCollector) ensureSlotsForCount(detected int) {
 func (c *GoNativeDiskCollector) snapshotDisks() []*DiskInfo {
 	initializeCache()
 	updateDiskInfo()
-	return getCachedDiskInfo()
+	c.slotIDsMu.RLock()
+	slotIDs := c.slotIDs
+	c.slotIDsMu.RUnlock()
+	return orderDisksBySlots(getCachedDiskInfo(), slotIDs)
 }
 
 func updateDiskStaticItems(slot *goNativeDiskSlot, disk *DiskInfo) {
@@ -281,6 +333,32 @@ func updateDiskTemperatureSnapshotItem(slot *goNativeDiskSlot, disk *DiskInfo, s
 	slot.tempItem.SetAvailable(true)
 }
 
+func updateDiskHealthItem(slot *goNativeDiskSlot, disk *DiskInfo, statuses map[string]string) {
+	if slot == nil || slot.healthItem == nil {
+		return
+	}
+	if disk == nil {
+		slot.healthItem.SetAvailable(false)
+		return
+	}
+	status, ok := statuses[strings.TrimSpace(disk.Name)]
+	if !ok {
+		slot.healthItem.SetAvailable(false)
+		return
+	}
+	slot.healthItem.SetValue(status)
+	slot.healthItem.SetAvailable(true)
+}
+
+func diskHealthItemsEnabled(slots map[int]*goNativeDiskSlot) bool {
+	for _, slot := range slots {
+		if slot != nil && slot.healthItem != nil && slot.healthItem.IsEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
 func diskTemperatureItemsEnabled(slots map[int]*goNativeDiskSlot) bool {
 	for _, slot := range slots {
 		if slot != nil && slot.tempItem != nil && slot.tempItem.IsEnabled() {
@@ -311,14 +389,14 @@ func setDiskDynamicMetrics(slot *goNativeDiskSlot, metrics *diskComputedMetrics)
 	slot.busyItem.SetAvailable(true)
 }
 
-func computeDiskMetrics(current diskRateSnapshot, previous diskRateSnapshot) (*diskComputedMetrics, bool) {
-	if current.at.IsZero() || previous.at.IsZero() {
+func computeDiskMetrics(current diskRateSnapshot, previous diskRateSnapshot, maxGap time.Duration) (*diskComputedMetrics, bool) {
+	elapsed, ok := rateElapsed(previous.at, current.at, maxGap)
+	if !ok {
 		return nil, false
 	}
-	seconds := current.at.Sub(previous.at).Seconds()
-	elapsedMS := current.at.Sub(previous.at).Seconds() * 1000
-	if seconds <= 0 || elapsedMS <= 0 ||
-		current.ReadBytes < previous.ReadBytes ||
+	seconds := elapsed.Seconds()
+	elapsedMS := seconds * 1000
+	if current.ReadBytes < previous.ReadBytes ||
 		current.WriteBytes < previous.WriteBytes ||
 		current.ReadCount < previous.ReadCount ||
 		current.WriteCount < previous.WriteCount ||
@@ -367,9 +445,43 @@ func (c *GoNativeDiskCollector) GetAllItems() map[string]*CollectItem {
 		updateDiskRateItems(slot, disk)
 		updateDiskTemperatureItem(slot, disk)
 	}
+	c.updateTotalItems()
 	return c.ItemsSnapshot()
 }
 
+// enabledHealthDiskNames lists the disks whose health monitor is in use;
+// only those are checked with smartctl.
+func (c *GoNativeDiskCollector) enabledHealthDiskNames(disks []*DiskInfo) []string {
+	names := make([]string, 0, len(disks))
+	for index, slot := range c.slots {
+		if slot == nil || slot.healthItem == nil || !slot.healthItem.IsEnabled() {
+			continue
+		}
+		if index > 0 && index <= len(disks) && disks[index-1] != nil {
+			names = append(names, disks[index-1].Name)
+		}
+	}
+	return names
+}
+
+// updateTotalItems sets go_native.disk.total_size, total_used and
+// total_free from the mounted filesystems of the last disk scan.
+func (c *GoNativeDiskCollector) updateTotalItems() {
+	totals := getCachedDiskTotals()
+	for item, value := range map[*CollectItem]int64{
+		c.totalSizeItem: totals.Size,
+		c.totalUsedItem: totals.Used,
+		c.totalFreeItem: totals.Free,
+	} {
+		if !totals.OK {
+			item.SetAvailable(false)
+			continue
+		}
+		item.SetValue(value)
+		item.SetAvailable(true)
+	}
+}
+
 func (c *GoNativeDiskCollector) diskState(name string) *runtimeDiskMetricsState {
 	key := strings.TrimSpace(name)
 	if key == "" {
@@ -383,10 +495,18 @@ func (c *GoNativeDiskCollector) diskState(name string) *runtimeDiskMetricsState
 	return state
 }
 
+// ResetRates makes the next update start every disk from a fresh sample.
+func (c *GoNativeDiskCollector) ResetRates() {
+	c.resetRates.Store(true)
+}
+
 func (c *GoNativeDiskCollector) UpdateItems() error {
 	if !c.IsEnabled() {
 		return nil
 	}
+	if c.resetRates.Swap(false) {
+		clear(c.runtimeMu.states)
+	}
 	disks := c.snapshotDisks()
 	names := make([]string, 0, len(disks))
 	for _, disk := range disks {
@@ -400,6 +520,10 @@ func (c *GoNativeDiskCollector) UpdateItems() error {
 	if diskTemperatureItemsEnabled(c.slots) {
 		temperatureSnapshots = getDiskTemperatureSnapshots(names)
 	}
+	healthStatuses := map[string]string{}
+	if diskHealthItemsEnabled(c.slots) {
+		healthStatuses = sharedDiskHealthService.Read(c.enabledHealthDiskNames(disks))
+	}
 	for index, slot := range c.slots {
 		if slot == nil {
 			continue
@@ -425,7 +549,7 @@ func (c *GoNativeDiskCollector) UpdateItems() error {
 					updateDiskRateItems(slot, nil)
 				} else if sample, ok := samples[stateName]; ok {
 					if state.hasLast {
-						if metrics, metricsOK := computeDiskMetrics(sample, state.last); metricsOK {
+						if metrics, metricsOK := computeDiskMetrics(sample, state.last, time.Duration(c.rateMaxGap.Load())); metricsOK {
 							state.lastGood = smoothDiskMetrics(state.lastGood, metrics)
 							state.validUntil = time.Now().Add(15 * time.Second)
 							setDiskDynamicMetrics(slot, state.lastGood)
@@ -435,10 +559,9 @@ func (c *GoNativeDiskCollector) UpdateItems() error {
 							updateDiskRateItems(slot, nil)
 						}
 					} else {
-						zero := &diskComputedMetrics{}
-						state.lastGood = zero
-						state.validUntil = time.Now().Add(15 * time.Second)
-						setDiskDynamicMetrics(slot, zero)
+						// A rate needs two samples; until then the items
+						// stay unready and render as placeholders.
+						updateDiskRateItems(slot, nil)
 					}
 					state.last = sample
 					state.hasLast = true
@@ -452,6 +575,10 @@ func (c *GoNativeDiskCollector) UpdateItems() error {
 		if slot.tempItem != nil && slot.tempItem.IsEnabled() {
 			updateDiskTemperatureSnapshotItem(slot, disk, temperatureSnapshots)
 		}
+		if slot.healthItem != nil && slot.healthItem.IsEnabled() {
+			updateDiskHealthItem(slot, disk, healthStatuses)
+		}
 	}
+	c.updateTotalItems()
 	return nil
 }
@@ -29,7 +29,7 @@ func TestComputeDiskMetricsSnapshot(t *testing.T) {
 	previous.at = time.Unix(10, 0)
 	current.at = previous.at.Add(2 * time.Second)
 
-	got, ok := computeDiskMetrics(current, previous)
+	got, ok := computeDiskMetrics(current, previous, rateSampleMaxGap)
 	if !ok || got == nil {
 		t.Fatalf("expected computed metrics, got %#v ok=%v", got, ok)
 	}
@@ -58,3 +58,52 @@ func TestComputeDiskMetricsSnapshot(t *testing.T) {
 		t.Fatalf("expected queue depth 2, got %v", got.queueDepth)
 	}
 }
+
+func TestComputeDiskMetricsSkipsTimeJumps(t *testing.T) {
+	previous := diskRateSnapshot{ReadBytes: 100 << 20}
+	current := diskCounterSample{ReadBytes: 400 << 20}
+	previous.at = time.Unix(1000, 0)
+
+	// A wall clock stepped back by NTP, on timestamps without a monotonic
+	// reading.
+	current.at = previous.at.Add(-30 * time.Second)
+	if got, ok := computeDiskMetrics(current, previous, rateSampleMaxGap); ok {
+		t.Fatalf("expected a backwards step to be skipped, got %#v", got)
+	}
+	// A gap longer than any refresh interval, e.g. across hibernation.
+	current.at = previous.at.Add(rateSampleMaxGap + time.Second)
+	if got, ok := computeDiskMetrics(current, previous, rateSampleMaxGap); ok {
+		t.Fatalf("expected a long gap to be skipped, got %#v", got)
+	}
+
+	// time.Now readings measure the gap on the monotonic clock, so a step
+	// of the wall clock in between does not change it.
+	previous.at = time.Now()
+	current.at = previous.at.Add(3 * time.Second)
+	if elapsed, ok := rateElapsed(previous.at, current.at, rateSampleMaxGap); !ok || elapsed != 3*time.Second {
+		t.Fatalf("expected a 3s gap, got %v ok=%v", elapsed, ok)
+	}
+}
+
+func TestDiskRatesSpanSlowSchedules(t *testing.T) {
+	cfg := &MonitorConfig{CollectorConfig: map[string]CollectorConfig{
+		collectorGoNativeDisk: {Options: map[string]interface{}{"update_interval_ms": 120_000}},
+	}}
+	collector := NewGoNativeDiskCollector(nil)
+	collector.ApplyConfig(cfg)
+	maxGap := time.Duration(collector.rateMaxGap.Load())
+	if maxGap != 4*time.Minute {
+		t.Fatalf("expected a 2 minute schedule to allow a 4 minute gap, got %v", maxGap)
+	}
+
+	previous := diskRateSnapshot{ReadBytes: 100 << 20, at: time.Unix(1000, 0)}
+	current := diskCounterSample{ReadBytes: 220 << 20, at: previous.at.Add(2 * time.Minute)}
+	got, ok := computeDiskMetrics(current, previous, maxGap)
+	if !ok || !almostEqualFloat64(got.read, 1) {
+		t.Fatalf("expected 1 MiB/s across a 2 minute tick, got %#v ok=%v", got, ok)
+	}
+	current.at = previous.at.Add(maxGap + time.Second)
+	if got, ok := computeDiskMetrics(current, previous, maxGap); ok {
+		t.Fatalf("expected a gap over twice the interval to be skipped, got %#v", got)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+)
+
+// parseDiskSlotOption splits the go_native.disk slots option, a comma or
+// newline separated list of disk identifiers in slot order.
+func parseDiskSlotOption(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+	slots := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			slots = append(slots, field)
+		}
+	}
+	return slots
+}
+
+// orderDisksBySlots puts the disk matching the Nth identifier in slot N and
+// the disks no identifier names after them in name order. A listed disk
+// that is missing leaves its slot empty, so go_native.disk.<N> never shows
+// another disk when one is unplugged or renamed across reboots.
+func orderDisksBySlots(disks []*DiskInfo, slots []string) []*DiskInfo {
+	if len(slots) == 0 {
+		return disks
+	}
+	ordered := make([]*DiskInfo, len(slots), len(slots)+len(disks))
+	used := make(map[*DiskInfo]bool, len(disks))
+	for index, id := range slots {
+		for _, disk := range disks {
+			if disk != nil && !used[disk] && diskMatchesID(disk, id) {
+				ordered[index] = disk
+				used[disk] = true
+				break
+			}
+		}
+	}
+	for _, disk := range disks {
+		if disk != nil && !used[disk] {
+			ordered = append(ordered, disk)
+		}
+	}
+	return ordered
+}
+
+// diskMatchesID compares an identifier, ignoring case, with the device
+// name (sda, /dev/nvme0n1), the serial, the WWN with or without its
+// naa./eui./wwn-0x prefix, and the /dev/disk/by-id names. SATA disks
+// expose their serial only through by-id, as the suffix of
+// ata-<model>_<serial>.
+func diskMatchesID(disk *DiskInfo, id string) bool {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		return false
+	}
+	name := strings.ToLower(strings.TrimSpace(disk.Name))
+	if id == name || id == "/dev/"+name {
+		return true
+	}
+	if serial := strings.ToLower(strings.TrimSpace(disk.Serial)); serial != "" && id == serial {
+		return true
+	}
+	if wwn := normalizeDiskWWN(disk.WWN); wwn != "" && normalizeDiskWWN(id) == wwn {
+		return true
+	}
+	id = strings.TrimPrefix(id, "/dev/disk/by-id/")
+	for _, link := range disk.ByID {
+		link = strings.ToLower(link)
+		if id == link {
+			return true
+		}
+		if strings.HasPrefix(link, "wwn-") && normalizeDiskWWN(link) == normalizeDiskWWN(id) {
+			return true
+		}
+		if (strings.HasPrefix(link, "ata-") || strings.HasPrefix(link, "usb-")) && strings.HasSuffix(link, "_"+id) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDiskWWN reduces the forms a WWN appears in, naa.5002538e40a2b3c4
+// in sysfs and wwn-0x5002538e40a2b3c4 in by-id, to the hex digits.
+func normalizeDiskWWN(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, prefix := range []string{"wwn-", "naa.", "eui.", "0x"} {
+		value = strings.TrimPrefix(value, prefix)
+	}
+	return value
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestOrderDisksBySlots(t *testing.T) {
+	sata := &DiskInfo{Name: "sda", WWN: "naa.5002538e40a2b3c4", ByID: []string{"ata-Samsung_SSD_860_EVO_1TB_S3Z9NB0K", "wwn-0x5002538e40a2b3c4"}}
+	nvme0 := &DiskInfo{Name: "nvme0n1", Serial: "S4EWNX0R123456", WWN: "eui.0025388b91b1d1a4"}
+	nvme1 := &DiskInfo{Name: "nvme1n1", Serial: "2238E6A1B2C3"}
+	usb := &DiskInfo{Name: "sdb"}
+	disks := []*DiskInfo{nvme0, nvme1, sata, usb}
+
+	cases := []struct {
+		option string
+		want   []*DiskInfo
+	}{
+		{option: "", want: disks},
+		{option: "2238e6a1b2c3, S4EWNX0R123456", want: []*DiskInfo{nvme1, nvme0, sata, usb}},
+		{option: "S3Z9NB0K", want: []*DiskInfo{sata, nvme0, nvme1, usb}},
+		{option: "/dev/disk/by-id/wwn-0x5002538e40a2b3c4;eui.0025388b91b1d1a4", want: []*DiskInfo{sata, nvme0, nvme1, usb}},
+		{option: "0x5002538E40A2B3C4\n/dev/sdb", want: []*DiskInfo{sata, usb, nvme0, nvme1}},
+		{option: "MISSING,nvme1n1", want: []*DiskInfo{nil, nvme1, nvme0, sata, usb}},
+		{option: "sda,sda", want: []*DiskInfo{sata, nil, nvme0, nvme1, usb}},
+	}
+	for _, tc := range cases {
+		got := orderDisksBySlots(disks, parseDiskSlotOption(tc.option))
+		if len(got) != len(tc.want) {
+			t.Fatalf("slots %q: expected %d disks, got %d", tc.option, len(tc.want), len(got))
+		}
+		for idx := range got {
+			if got[idx] != tc.want[idx] {
+				t.Fatalf("slots %q: slot %d is %+v, expected %+v", tc.option, idx+1, got[idx], tc.want[idx])
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// diskCapacityTotals is the capacity of all mounted filesystems, in GB.
+type diskCapacityTotals struct {
+	Size int64
+	Used int64
+	Free int64
+	OK   bool
+}
+
+// mountedFilesystem is one mount with its statfs figures in bytes.
+type mountedFilesystem struct {
+	Device     string
+	Mountpoint string
+	Fstype     string
+	Total      uint64
+	Used       uint64
+	Free       uint64
+}
+
+func readMountedFilesystems() []mountedFilesystem {
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		return nil
+	}
+	mounts := make([]mountedFilesystem, 0, len(partitions))
+	for _, part := range partitions {
+		mountpoint := normalizeUsageMountpoint(part.Mountpoint)
+		if mountpoint == "" {
+			continue
+		}
+		usage, err := disk.Usage(mountpoint)
+		if err != nil || usage.Total == 0 {
+			continue
+		}
+		mounts = append(mounts, mountedFilesystem{
+			Device:     part.Device,
+			Mountpoint: mountpoint,
+			Fstype:     part.Fstype,
+			Total:      usage.Total,
+			Used:       usage.Used,
+			Free:       usage.Free,
+		})
+	}
+	return mounts
+}
+
+// sumMountedFilesystems adds up the mounts once per filesystem: a device
+// mounted twice, such as btrfs subvolumes or bind mounts, counts once and
+// so do the datasets of one ZFS pool, which share its space. Mounts on a
+// disk the filter dropped are left out. Mounts on a device that is not a
+// detected disk, like md0 or an LVM volume, are matched against the filter
+// by device name, device path and mountpoint.
+func sumMountedFilesystems(mounts []mountedFilesystem, detected, kept []*DiskInfo, filter deviceFilter) diskCapacityTotals {
+	status := make(map[string]bool, len(detected))
+	for _, disk := range detected {
+		if disk != nil {
+			status[disk.Name] = false
+		}
+	}
+	for _, disk := range kept {
+		if disk != nil {
+			status[disk.Name] = true
+		}
+	}
+
+	var totals diskCapacityTotals
+	var size, used, free uint64
+	seen := make(map[string]struct{}, len(mounts))
+	for _, mount := range mounts {
+		key := mount.Device
+		if strings.EqualFold(mount.Fstype, "zfs") {
+			key = "zfs:" + strings.SplitN(mount.Device, "/", 2)[0]
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		base := normalizeDiskBaseName("", mount.Device)
+		if allowed, known := status[base]; known {
+			if !allowed {
+				continue
+			}
+		} else if !filter.allows(base, mount.Device, mount.Mountpoint) {
+			continue
+		}
+		seen[key] = struct{}{}
+		size += mount.Total
+		used += mount.Used
+		free += mount.Free
+		totals.OK = true
+	}
+	totals.Size = int64(size / (1024 * 1024 * 1024))
+	totals.Used = int64(used / (1024 * 1024 * 1024))
+	totals.Free = int64(free / (1024 * 1024 * 1024))
+	return totals
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestSumMountedFilesystems(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+	nvme := &DiskInfo{Name: "nvme0n1"}
+	sata := &DiskInfo{Name: "sda"}
+	backup := &DiskInfo{Name: "sdb", ByID: []string{"usb-WD_Elements_25A3"}}
+	mounts := []mountedFilesystem{
+		{Device: "/dev/nvme0n1p2", Mountpoint: "/", Fstype: "btrfs", Total: 900 * gib, Used: 300 * gib, Free: 600 * gib},
+		{Device: "/dev/nvme0n1p2", Mountpoint: "/home", Fstype: "btrfs", Total: 900 * gib, Used: 300 * gib, Free: 600 * gib},
+		{Device: "/dev/sda1", Mountpoint: "/srv", Fstype: "ext4", Total: 4000 * gib, Used: 1000 * gib, Free: 2800 * gib},
+		{Device: "/dev/sdb1", Mountpoint: "/mnt/backup", Fstype: "ext4", Total: 2000 * gib, Used: 1500 * gib, Free: 500 * gib},
+		{Device: "tank/media", Mountpoint: "/tank/media", Fstype: "zfs", Total: 8000 * gib, Used: 5000 * gib, Free: 3000 * gib},
+		{Device: "tank/photos", Mountpoint: "/tank/photos", Fstype: "zfs", Total: 8000 * gib, Used: 5000 * gib, Free: 3000 * gib},
+		{Device: "/dev/md0", Mountpoint: "/scratch", Fstype: "xfs", Total: 100 * gib, Used: 10 * gib, Free: 90 * gib},
+	}
+	detected := []*DiskInfo{nvme, sata, backup}
+
+	totals := sumMountedFilesystems(mounts, detected, detected, deviceFilter{})
+	if !totals.OK || totals.Size != 15000 || totals.Used != 7810 || totals.Free != 6990 {
+		t.Fatalf("unexpected totals without a filter %+v", totals)
+	}
+
+	cfg := &MonitorConfig{CollectorConfig: map[string]CollectorConfig{
+		collectorGoNativeDisk: {Options: map[string]interface{}{"exclude": "^usb-|^md"}},
+	}}
+	filter := deviceFilterFromConfig(cfg, collectorGoNativeDisk)
+	totals = sumMountedFilesystems(mounts, detected, filterDisks(detected, filter), filter)
+	if !totals.OK || totals.Size != 12900 || totals.Used != 6300 || totals.Free != 6400 {
+		t.Fatalf("unexpected filtered totals %+v", totals)
+	}
+
+	if totals := sumMountedFilesystems(nil, detected, detected, deviceFilter{}); totals.OK {
+		t.Fatalf("expected no totals without mounts, got %+v", totals)
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// frameTimeWindow is how far back the average and the lows look.
+	frameTimeWindow = 10 * time.Second
+	// frameTimeFPSWindow is what the current FPS averages over.
+	frameTimeFPSWindow = time.Second
+	// frameTimeStaleAfter hides the values once the game stops presenting.
+	frameTimeStaleAfter    = 2 * time.Second
+	frameTimeRetryInterval = 10 * time.Second
+)
+
+// frameSample is one frame, or for MangoHud one log interval, and the
+// application that presented it.
+type frameSample struct {
+	app string
+	at  time.Time
+	ms  float64
+}
+
+type frameTimeStats struct {
+	App            string
+	FPS            float64
+	FrameTimeMS    float64
+	FrameTimeAvgMS float64
+	FPS1PLow       float64
+	FPS01PLow      float64
+}
+
+// frameTimeBuffer keeps the samples of the last frameTimeWindow.
+type frameTimeBuffer struct {
+	samples []frameSample
+}
+
+func (b *frameTimeBuffer) add(sample frameSample) {
+	if sample.ms <= 0 || math.IsNaN(sample.ms) || math.IsInf(sample.ms, 0) {
+		return
+	}
+	b.samples = append(b.samples, sample)
+}
+
+func (b *frameTimeBuffer) trim(now time.Time) {
+	cutoff := now.Add(-frameTimeWindow)
+	drop := 0
+	for drop < len(b.samples) && b.samples[drop].at.Before(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		b.samples = append(b.samples[:0], b.samples[drop:]...)
+	}
+}
+
+// stats reports the application with the most frames in the last second,
+// which with several presenting at once is the game rather than a launcher
+// or overlay. The lows follow RTSS: the 1% low is the FPS at the 99th
+// percentile frame time.
+func (b *frameTimeBuffer) stats(now time.Time) (frameTimeStats, bool) {
+	b.trim(now)
+	if len(b.samples) == 0 || now.Sub(b.samples[len(b.samples)-1].at) > frameTimeStaleAfter {
+		return frameTimeStats{}, false
+	}
+	recent := make(map[string]int)
+	app := ""
+	for _, sample := range b.samples {
+		if now.Sub(sample.at) > frameTimeFPSWindow {
+			continue
+		}
+		recent[sample.app]++
+		if recent[sample.app] > recent[app] || (recent[sample.app] == recent[app] && sample.app < app) {
+			app = sample.app
+		}
+	}
+	if len(recent) == 0 {
+		return frameTimeStats{}, false
+	}
+
+	times := make([]float64, 0, len(b.samples))
+	total, recentTotal, recentCount := 0.0, 0.0, 0
+	last := 0.0
+	for _, sample := range b.samples {
+		if sample.app != app {
+			continue
+		}
+		times = append(times, sample.ms)
+		total += sample.ms
+		last = sample.ms
+		if now.Sub(sample.at) <= frameTimeFPSWindow {
+			recentTotal += sample.ms
+			recentCount++
+		}
+	}
+	sort.Float64s(times)
+	return frameTimeStats{
+		App:            app,
+		FPS:            1000 / (recentTotal / float64(recentCount)),
+		FrameTimeMS:    last,
+		FrameTimeAvgMS: total / float64(len(times)),
+		FPS1PLow:       1000 / frameTimePercentile(times, 0.99),
+		FPS01PLow:      1000 / frameTimePercentile(times, 0.999),
+	}, true
+}
+
+// frameTimePercentile picks the nearest rank from sorted frame times.
+func frameTimePercentile(sorted []float64, p float64) float64 {
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// frameCSVColumn returns the index of the first of names present in a CSV
+// header, compared case-insensitively, or -1.
+func frameCSVColumn(header []string, names ...string) int {
+	for _, name := range names {
+		for idx, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// frameTimeSource streams samples to emit until stop is closed.
+type frameTimeSource func(stop <-chan struct{}, emit func(frameSample)) error
+
+// FrameTimeCollector reports FPS, frame time and the 1% and 0.1% lows of
+// the game in front. On Linux it follows the CSV logs MangoHud writes while
+// logging (autostart_log or the logging toggle), on Windows it runs
+// PresentMon and reads its CSV from stdout.
+//
+// Options: log_dir (Linux, MangoHud's output_folder, default the home
+// directory), command (Windows, default "PresentMon.exe") and args
+// (Windows, replaces the default PresentMon arguments).
+type FrameTimeCollector struct {
+	*BaseCollector
+	mu        sync.Mutex
+	buffer    frameTimeBuffer
+	sourceKey string
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+}
+
+func NewFrameTimeCollector() *FrameTimeCollector {
+	if !isCollectorSupportedOnCurrentPlatform(collectorFrameTime) {
+		return nil
+	}
+	return &FrameTimeCollector{BaseCollector: NewBaseCollector(collectorFrameTime)}
+}
+
+func (c *FrameTimeCollector) ApplyConfig(cfg *MonitorConfig) {
+	enabled := cfg != nil && cfg.IsCollectorEnabled(collectorFrameTime, false)
+	c.SetEnabled(enabled)
+	if !enabled {
+		c.stopSource()
+		c.mu.Lock()
+		c.buffer = frameTimeBuffer{}
+		c.clearItems()
+		c.mu.Unlock()
+		return
+	}
+
+	key, source := frameTimeSourceForPlatform(cfg)
+	c.mu.Lock()
+	restart := c.sourceKey != key
+	c.mu.Unlock()
+	if restart {
+		c.stopSource()
+	}
+	c.startSource(key, source)
+	_ = c.GetAllItems()
+}
+
+func frameTimeSourceForPlatform(cfg *MonitorConfig) (string, frameTimeSource) {
+	if runtime.GOOS == "windows" {
+		command := strings.TrimSpace(cfg.GetCollectorStringOption(collectorFrameTime, "command", ""))
+		if command == "" {
+			command = defaultPresentMonCommand
+		}
+		args := strings.Fields(cfg.GetCollectorStringOption(collectorFrameTime, "args", ""))
+		if len(args) == 0 {
+			args = defaultPresentMonArgs
+		}
+		return "presentmon|" + command + "|" + strings.Join(args, " "), func(stop <-chan struct{}, emit func(frameSample)) error {
+			return runPresentMon(command, args, stop, emit)
+		}
+	}
+	dir := strings.TrimSpace(cfg.GetCollectorStringOption(collectorFrameTime, "log_dir", ""))
+	if dir == "" {
+		dir, _ = os.UserHomeDir()
+	}
+	return "mangohud|" + dir, func(stop <-chan struct{}, emit func(frameSample)) error {
+		return runMangoHudLogs(dir, stop, emit)
+	}
+}
+
+func (c *FrameTimeCollector) GetAllItems() map[string]*CollectItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.IsEnabled() && c.getItem("frametime.fps") == nil {
+		c.setItem("frametime.fps", NewCollectItem("frametime.fps", "FPS", "FPS", 0, 0, 0))
+		c.setItem("frametime.frametime_ms", NewCollectItem("frametime.frametime_ms", "Frame time", "ms", 0, 0, 1))
+		c.setItem("frametime.frametime_avg", NewCollectItem("frametime.frametime_avg", "Frame time avg", "ms", 0, 0, 1))
+		c.setItem("frametime.fps_1p_low", NewCollectItem("frametime.fps_1p_low", "FPS 1% low", "FPS", 0, 0, 0))
+		c.setItem("frametime.fps_01p_low", NewCollectItem("frametime.fps_01p_low", "FPS 0.1% low", "FPS", 0, 0, 0))
+		c.setItem("frametime.app", NewCollectItem("frametime.app", "Game", "", 0, 0, 0))
+	}
+	return c.ItemsSnapshot()
+}
+
+func (c *FrameTimeCollector) UpdateItems() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	c.mu.Lock()
+	stats, ok := c.buffer.stats(time.Now())
+	c.mu.Unlock()
+	c.setFrameValue("frametime.fps", stats.FPS, ok)
+	c.setFrameValue("frametime.frametime_ms", stats.FrameTimeMS, ok)
+	c.setFrameValue("frametime.frametime_avg", stats.FrameTimeAvgMS, ok)
+	c.setFrameValue("frametime.fps_1p_low", stats.FPS1PLow, ok)
+	c.setFrameValue("frametime.fps_01p_low", stats.FPS01PLow, ok)
+	if item := c.getItem("frametime.app"); item != nil {
+		if ok && stats.App != "" {
+			item.SetValue(stats.App)
+			item.SetAvailable(true)
+		} else {
+			item.SetAvailable(false)
+		}
+	}
+	return nil
+}
+
+func (c *FrameTimeCollector) setFrameValue(name string, value float64, ok bool) {
+	item := c.getItem(name)
+	if item == nil {
+		return
+	}
+	if !ok {
+		item.SetAvailable(false)
+		return
+	}
+	item.SetValue(value)
+	item.SetAvailable(true)
+}
+
+// Close stops MangoHud log following or PresentMon when the collector
+// manager shuts down.
+func (c *FrameTimeCollector) Close() {
+	c.stopSource()
+}
+
+func (c *FrameTimeCollector) handleSample(sample frameSample) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buffer.add(sample)
+	// Trim as samples arrive so the buffer stays bounded even while no
+	// monitor reads it.
+	if len(c.buffer.samples)%256 == 0 {
+		c.buffer.trim(sample.at)
+	}
+}
+
+func (c *FrameTimeCollector) startSource(key string, source frameTimeSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopCh != nil {
+		return
+	}
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	c.stopCh = stopCh
+	c.doneCh = doneCh
+	c.sourceKey = key
+	go func() {
+		defer close(doneCh)
+		lastErr := ""
+		for {
+			// Log each distinct failure once; a missing PresentMon or
+			// privileges fail the same way on every retry.
+			if err := source(stopCh, c.handleSample); err != nil && err.Error() != lastErr {
+				lastErr = err.Error()
+				logWarnModule("frametime", "capture failed: %v", err)
+			}
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(frameTimeRetryInterval):
+			}
+		}
+	}()
+}
+
+func (c *FrameTimeCollector) stopSource() {
+	c.mu.Lock()
+	if c.stopCh == nil {
+		c.mu.Unlock()
+		return
+	}
+	stopCh, doneCh := c.stopCh, c.doneCh
+	c.stopCh = nil
+	c.doneCh = nil
+	c.sourceKey = ""
+	c.mu.Unlock()
+	close(stopCh)
+	<-doneCh
+}
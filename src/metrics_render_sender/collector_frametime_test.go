@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFrameTimeBufferStats(t *testing.T) {
+	now := time.Now()
+	// Twenty seconds of a game at 10 ms with two 50 ms hitches in the last
+	// ten, and a launcher presenting once a second next to it.
+	var buffer frameTimeBuffer
+	for i := 1; i <= 2000; i++ {
+		at := now.Add(-20*time.Second + time.Duration(i)*10*time.Millisecond - 5*time.Millisecond)
+		ms := 10.0
+		if i == 1500 || i == 1700 {
+			ms = 50
+		}
+		buffer.add(frameSample{app: "game.exe", at: at, ms: ms})
+		if i%100 == 0 {
+			buffer.add(frameSample{app: "launcher.exe", at: at, ms: 1000})
+		}
+	}
+	buffer.add(frameSample{app: "game.exe", at: now, ms: 0})
+
+	stats, ok := buffer.stats(now)
+	if !ok {
+		t.Fatal("expected stats")
+	}
+	if stats.App != "game.exe" || stats.FPS != 100 || stats.FrameTimeMS != 10 {
+		t.Fatalf("unexpected current values: %+v", stats)
+	}
+	if want := (998*10.0 + 100) / 1000; math.Abs(stats.FrameTimeAvgMS-want) > 1e-9 {
+		t.Fatalf("expected avg %v, got %v", want, stats.FrameTimeAvgMS)
+	}
+	if stats.FPS1PLow != 100 || stats.FPS01PLow != 20 {
+		t.Fatalf("expected lows 100 and 20, got %v and %v", stats.FPS1PLow, stats.FPS01PLow)
+	}
+
+	if _, ok := buffer.stats(now.Add(3 * time.Second)); ok {
+		t.Fatal("expected no stats once frames stop")
+	}
+}
+
+func TestMangoHudTailFollowsNewestLog(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "game_2024-05-01_20-15-00.csv")
+	header := "os,cpu,gpu,ram,kernel,driver,cpuscheduler\nArch,Ryzen,RX 7800,32,6.8,Mesa,\nfps,frametime,cpu_load,gpu_load\n"
+	if err := os.WriteFile(path, []byte(header+"120,8.33,40,90\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "game_2024-05-01_20-15-00_summary.csv"), []byte("x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var samples []frameSample
+	emit := func(sample frameSample) { samples = append(samples, sample) }
+	tail := &mangoHudTail{dir: dir}
+	defer tail.close()
+	if err := tail.poll(time.Now(), emit); err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+	if len(samples) != 0 {
+		t.Fatalf("expected rows logged before start to be skipped, got %v", samples)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString("60,16.6,40,90\n50,20"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tail.poll(time.Now(), emit); err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+	if _, err := file.WriteString(".0,40,90\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tail.poll(time.Now(), emit); err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+	if len(samples) != 2 || samples[0].ms != 16.6 || samples[1].ms != 20 || samples[0].app != "game" {
+		t.Fatalf("unexpected samples: %+v", samples)
+	}
+}
+
+func TestReadPresentMonCSV(t *testing.T) {
+	output := strings.Join([]string{
+		"Application,ProcessID,SwapChainAddress,Runtime,SyncInterval,PresentFlags,Dropped,TimeInSeconds,msInPresentAPI,msBetweenPresents",
+		"game.exe,1234,0x1,DXGI,0,0,0,1.0,0.2,6.94",
+		"dwm.exe,88,0x2,Other,0,0,0,1.0,0.1,16.6",
+		"game.exe,1234,0x1,DXGI,0,0,0,1.0,0.2,7.10",
+	}, "\r\n")
+	var samples []frameSample
+	err := readPresentMonCSV(strings.NewReader(output), time.Now, func(sample frameSample) { samples = append(samples, sample) })
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if len(samples) != 2 || samples[0].app != "game.exe" || samples[0].ms != 6.94 || samples[1].ms != 7.10 {
+		t.Fatalf("unexpected samples: %+v", samples)
+	}
+}
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+const (
+	hostDefaultInterval  = 5 * time.Second
+	hostMinInterval      = time.Second
+	hostMaxResponseBytes = 64 << 10
+	// hostSSHCommand prints the first /proc/stat line, total and available
+	// memory and every thermal zone; cat fails on hosts without any zone.
+	hostSSHCommand = "head -n1 /proc/stat; grep -E '^(MemTotal|MemAvailable):' /proc/meminfo; cat /sys/class/thermal/thermal_zone*/temp 2>/dev/null; true"
+)
+
+// hostMetrics are the monitors every host provides, in table column order.
+var hostMetrics = []struct {
+	name  string
+	label string
+	unit  string
+	max   float64
+}{
+	{name: "cpu", label: "CPU", unit: "%", max: 100},
+	{name: "ram", label: "RAM", unit: "%", max: 100},
+	{name: "temp", label: "CPU temp", unit: "°C", max: 120},
+}
+
+// hostSummary is what one poll of a host yields and what /api/host serves
+// to other instances polling this one as an agent. Missing readings are nil.
+type hostSummary struct {
+	CPU  *float64 `json:"cpu,omitempty"`
+	RAM  *float64 `json:"ram,omitempty"`
+	Temp *float64 `json:"temp,omitempty"`
+}
+
+func (s hostSummary) value(metric string) (float64, bool) {
+	var value *float64
+	switch metric {
+	case "cpu":
+		value = s.CPU
+	case "ram":
+		value = s.RAM
+	case "temp":
+		value = s.Temp
+	}
+	if value == nil {
+		return 0, false
+	}
+	return *value, true
+}
+
+// hostCPUCounter turns cumulative CPU times into usage between two samples.
+type hostCPUCounter struct {
+	total float64
+	idle  float64
+	ready bool
+}
+
+func (c *hostCPUCounter) usage(total, idle float64) (float64, bool) {
+	lastTotal, lastIdle, ready := c.total, c.idle, c.ready
+	c.total, c.idle, c.ready = total, idle, true
+	delta := total - lastTotal
+	if !ready || delta <= 0 {
+		return 0, false
+	}
+	return clampPercentage((delta - (idle - lastIdle)) * 100 / delta), true
+}
+
+type hostEntry struct {
+	cfg     HostConfig
+	check   *customCheckRunner
+	upItem  *CollectItem
+	metrics map[string]*CollectItem
+}
+
+// HostsCollector polls the machines in the hosts section, each on its own
+// background runner, so a slow or unreachable host never holds up a
+// collect pass.
+type HostsCollector struct {
+	*BaseCollector
+	mu     sync.Mutex
+	hosts  []hostEntry
+	checks map[string]*customCheckRunner
+}
+
+func NewHostsCollector() *HostsCollector {
+	return &HostsCollector{
+		BaseCollector: NewBaseCollector(collectorHosts),
+		checks:        make(map[string]*customCheckRunner),
+	}
+}
+
+func normalizeHostType(t string) string {
+	switch strings.ToLower(strings.TrimSpace(t)) {
+	case "", "local":
+		return "local"
+	case "ssh":
+		return "ssh"
+	case "agent", "ax206monitor":
+		return "agent"
+	case "librehardwaremonitor", "lhm":
+		return "librehardwaremonitor"
+	default:
+		return ""
+	}
+}
+
+func hostInterval(host HostConfig) time.Duration {
+	if host.IntervalMS <= 0 {
+		return hostDefaultInterval
+	}
+	interval := time.Duration(host.IntervalMS) * time.Millisecond
+	if interval < hostMinInterval {
+		return hostMinInterval
+	}
+	return interval
+}
+
+func hostRunnerKey(host HostConfig) string {
+	return strings.Join([]string{
+		normalizeHostType(host.Type),
+		strings.TrimSpace(host.Target),
+		strings.TrimSpace(host.Key),
+		strings.TrimSpace(host.Username),
+		host.Password,
+		hostInterval(host).String(),
+	}, "|")
+}
+
+func (c *HostsCollector) ApplyConfig(cfg *MonitorConfig) {
+	c.mu.Lock()
+	c.SetEnabled(cfg != nil && cfg.IsCollectorEnabled(collectorHosts, true))
+	c.clearItems()
+	c.hosts = nil
+	if cfg != nil {
+		seen := make(map[string]struct{})
+		for _, host := range cfg.Hosts {
+			slug := slugifyProviderName(host.Name)
+			if slug == "" {
+				continue
+			}
+			if normalizeHostType(host.Type) == "" {
+				logWarnModule("collector", "hosts: %s has unknown type %q", host.Name, host.Type)
+				continue
+			}
+			if _, ok := seen[slug]; ok {
+				logWarnModule("collector", "hosts: duplicate host %s", host.Name)
+				continue
+			}
+			seen[slug] = struct{}{}
+			c.hosts = append(c.hosts, c.buildHostEntryLocked(host, slug))
+		}
+	}
+	unused := c.syncHostRunnersLocked()
+	c.mu.Unlock()
+	for _, runner := range unused {
+		runner.stop()
+	}
+}
+
+func (c *HostsCollector) buildHostEntryLocked(host HostConfig, slug string) hostEntry {
+	label := strings.TrimSpace(host.Label)
+	if label == "" {
+		label = strings.TrimSpace(host.Name)
+	}
+	prefix := collectorHosts + "." + slug + "."
+	entry := hostEntry{
+		cfg:     host,
+		upItem:  NewCollectItem(prefix+"up", label+" up", "", 0, 1, 0),
+		metrics: make(map[string]*CollectItem, len(hostMetrics)),
+	}
+	c.setItem(prefix+"up", entry.upItem)
+	for _, metric := range hostMetrics {
+		item := NewCollectItem(prefix+metric.name, label+" "+metric.label, metric.unit, 0, metric.max, 0)
+		entry.metrics[metric.name] = item
+		c.setItem(prefix+metric.name, item)
+	}
+	return entry
+}
+
+// syncHostRunnersLocked starts one runner per distinct host and returns
+// those no host uses anymore, to be stopped after unlocking.
+func (c *HostsCollector) syncHostRunnersLocked() []*customCheckRunner {
+	wanted := make(map[string]struct{})
+	if c.IsEnabled() {
+		for idx := range c.hosts {
+			host := c.hosts[idx].cfg
+			key := hostRunnerKey(host)
+			runner := c.checks[key]
+			if runner == nil {
+				runner = startCheckRunner("host", strings.TrimSpace(host.Name), hostInterval(host), newHostProbe(host))
+				c.checks[key] = runner
+			}
+			c.hosts[idx].check = runner
+			wanted[key] = struct{}{}
+		}
+	}
+	var unused []*customCheckRunner
+	for key, runner := range c.checks {
+		if _, ok := wanted[key]; !ok {
+			unused = append(unused, runner)
+			delete(c.checks, key)
+		}
+	}
+	return unused
+}
+
+func (c *HostsCollector) Close() {
+	c.mu.Lock()
+	c.hosts = nil
+	unused := c.syncHostRunnersLocked()
+	c.mu.Unlock()
+	for _, runner := range unused {
+		runner.stop()
+	}
+}
+
+func (c *HostsCollector) GetAllItems() map[string]*CollectItem {
+	return c.ItemsSnapshot()
+}
+
+func (c *HostsCollector) UpdateItems() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	c.mu.Lock()
+	hosts := append([]hostEntry(nil), c.hosts...)
+	c.mu.Unlock()
+	for _, host := range hosts {
+		updateHostItems(host)
+	}
+	return nil
+}
+
+func updateHostItems(host hostEntry) {
+	var summary hostSummary
+	result, ok := customCheckResult{}, false
+	if host.check != nil {
+		result, ok = host.check.latest()
+	}
+	up := ok && result.up
+	if up {
+		up = json.Unmarshal([]byte(result.output), &summary) == nil
+	}
+	if ok {
+		if up {
+			host.upItem.SetValue(1.0)
+		} else {
+			host.upItem.SetValue(0.0)
+		}
+	}
+	host.upItem.SetAvailable(ok)
+	for name, item := range host.metrics {
+		value, has := summary.value(name)
+		if !up || !has {
+			item.SetAvailable(false)
+			continue
+		}
+		item.SetValue(value)
+		item.SetAvailable(true)
+	}
+}
+
+// newHostProbe returns the poll for one host. Every kind of host reports a
+// hostSummary as JSON in the result output, the same document /api/host
+// serves, so agents and the other kinds share one path from there on.
+func newHostProbe(host HostConfig) func(ctx context.Context) (customCheckResult, error) {
+	target := strings.TrimSpace(host.Target)
+	timeout := customCheckMaxTimeout
+	if interval := hostInterval(host); timeout > interval {
+		timeout = interval
+	}
+	switch normalizeHostType(host.Type) {
+	case "ssh":
+		run := newCustomSSHProbe(CustomMonitorConfig{Target: target, Key: host.Key, Command: hostSSHCommand}, timeout)
+		var counter hostCPUCounter
+		return func(ctx context.Context) (customCheckResult, error) {
+			result, err := run(ctx)
+			if err != nil {
+				return customCheckResult{}, err
+			}
+			return hostSummaryResult(parseHostProcOutput(result.output, &counter))
+		}
+	case "agent":
+		return newHostAgentProbe(target, timeout)
+	case "librehardwaremonitor":
+		client := GetLibreHardwareMonitorClient(target, host.Username, host.Password)
+		return func(ctx context.Context) (customCheckResult, error) {
+			if err := client.FetchData(); err != nil {
+				return customCheckResult{}, err
+			}
+			data := client.GetData()
+			summary := hostSummary{CPU: &data.CPUUsage, RAM: &data.MemoryUsage}
+			if data.CPUTemp > 0 {
+				summary.Temp = &data.CPUTemp
+			}
+			return hostSummaryResult(summary)
+		}
+	default:
+		var counter hostCPUCounter
+		return func(ctx context.Context) (customCheckResult, error) {
+			return hostSummaryResult(readLocalHostSummary(&counter))
+		}
+	}
+}
+
+func hostSummaryResult(summary hostSummary) (customCheckResult, error) {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return customCheckResult{}, err
+	}
+	return customCheckResult{up: true, output: string(data)}, nil
+}
+
+// newHostAgentProbe polls /api/host of another ax206monitor; target is the
+// address of its web UI, e.g. http://nas:18086.
+func newHostAgentProbe(target string, timeout time.Duration) func(ctx context.Context) (customCheckResult, error) {
+	client := &http.Client{}
+	// The poller id keeps the agent's CPU sample for this process apart from
+	// other instances polling it from the same address.
+	endpoint := strings.TrimRight(target, "/") + "/api/host?poller=" + strconv.Itoa(os.Getpid())
+	return func(ctx context.Context) (customCheckResult, error) {
+		if target == "" {
+			return customCheckResult{}, errors.New("no target")
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return customCheckResult{}, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return customCheckResult{}, fmt.Errorf("status %d", resp.StatusCode)
+		}
+		var summary hostSummary
+		if err := json.NewDecoder(io.LimitReader(resp.Body, hostMaxResponseBytes)).Decode(&summary); err != nil {
+			return customCheckResult{}, fmt.Errorf("invalid response: %w", err)
+		}
+		return hostSummaryResult(summary)
+	}
+}
+
+// parseHostProcOutput reads the output of hostSSHCommand. CPU usage needs
+// the previous sample, so the first poll of a host reports none.
+func parseHostProcOutput(output string, counter *hostCPUCounter) hostSummary {
+	var summary hostSummary
+	var memTotal, memAvailable float64
+	var temp float64
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "cpu":
+			// user nice system idle iowait irq softirq steal; guest time is
+			// already counted in user.
+			var times [8]float64
+			for i := 0; i < len(times) && i+1 < len(fields); i++ {
+				times[i], _ = strconv.ParseFloat(fields[i+1], 64)
+			}
+			total := 0.0
+			for _, value := range times {
+				total += value
+			}
+			if usage, ok := counter.usage(total, times[3]+times[4]); ok {
+				summary.CPU = &usage
+			}
+		case "MemTotal:", "MemAvailable:":
+			if len(fields) < 2 {
+				continue
+			}
+			value, _ := strconv.ParseFloat(fields[1], 64)
+			if fields[0] == "MemTotal:" {
+				memTotal = value
+			} else {
+				memAvailable = value
+			}
+		default:
+			// Thermal zones in millidegrees; the hottest one stands for the
+			// CPU, as on the local platforms.
+			value, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				continue
+			}
+			if value := value / 1000; value > 0 && value < 150 && value > temp {
+				temp = value
+			}
+		}
+	}
+	if memTotal > 0 && memAvailable <= memTotal {
+		ram := (memTotal - memAvailable) * 100 / memTotal
+		summary.RAM = &ram
+	}
+	if temp > 0 {
+		summary.Temp = &temp
+	}
+	return summary
+}
+
+// readLocalHostSummary samples this machine with its own CPU counter, so
+// it does not disturb the CPU collector's deltas.
+func readLocalHostSummary(counter *hostCPUCounter) hostSummary {
+	var summary hostSummary
+	if stats, err := cpu.Times(false); err == nil && len(stats) > 0 {
+		sample := stats[0]
+		total := sample.User + sample.System + sample.Idle + sample.Nice + sample.Iowait + sample.Irq + sample.Softirq + sample.Steal
+		if usage, ok := counter.usage(total, sample.Idle+sample.Iowait); ok {
+			summary.CPU = &usage
+		}
+	}
+	info, err := mem.VirtualMemory()
+	if _, percent, ok := memoryUsageValues(info, err == nil); ok {
+		summary.RAM = &percent
+	}
+	if currentPlatform.HasCPUTemperature() {
+		if temp, ok := currentPlatform.CPUTemperature(); ok {
+			summary.Temp = &temp
+		}
+	}
+	return summary
+}
+
+// hostPollerTTL is how long /api/host keeps the CPU sample of a poller that
+// stopped asking.
+const hostPollerTTL = 10 * time.Minute
+
+type hostPollerState struct {
+	counter hostCPUCounter
+	seenAt  time.Time
+}
+
+var (
+	localHostSummaryMu      sync.Mutex
+	localHostSummaryPollers = make(map[string]*hostPollerState)
+)
+
+// currentLocalHostSummary is served to instances that poll this one as an
+// agent. Each poller keeps its own CPU sample, so its usage covers the time
+// since its own previous request rather than anyone's.
+func currentLocalHostSummary(poller string) hostSummary {
+	now := time.Now()
+	localHostSummaryMu.Lock()
+	defer localHostSummaryMu.Unlock()
+	for key, state := range localHostSummaryPollers {
+		if now.Sub(state.seenAt) > hostPollerTTL {
+			delete(localHostSummaryPollers, key)
+		}
+	}
+	state := localHostSummaryPollers[poller]
+	if state == nil {
+		state = &hostPollerState{}
+		localHostSummaryPollers[poller] = state
+	}
+	state.seenAt = now
+	return readLocalHostSummary(&state.counter)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseHostProcOutput(t *testing.T) {
+	var counter hostCPUCounter
+	first := parseHostProcOutput("cpu  100 0 100 800 0 0 0 0 0 0\nMemTotal:       8000000 kB\nMemAvailable:   6000000 kB\n41000\n52500\n", &counter)
+	if first.CPU != nil {
+		t.Fatalf("expected no CPU usage from the first sample, got %v", *first.CPU)
+	}
+	if first.RAM == nil || *first.RAM != 25 {
+		t.Fatalf("unexpected RAM usage: %+v", first.RAM)
+	}
+	if first.Temp == nil || *first.Temp != 52.5 {
+		t.Fatalf("expected the hottest zone, got %+v", first.Temp)
+	}
+
+	second := parseHostProcOutput("cpu  150 0 150 900 0 0 0 0 0 0\n", &counter)
+	if second.CPU == nil || *second.CPU != 50 {
+		t.Fatalf("unexpected CPU usage: %+v", second.CPU)
+	}
+	if second.RAM != nil || second.Temp != nil {
+		t.Fatalf("expected missing readings to stay empty: %+v", second)
+	}
+}
+
+func TestHostsCollectorPollsAgent(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/host" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"cpu":12.5,"ram":40}`))
+	}))
+	defer server.Close()
+
+	collector := NewHostsCollector()
+	collector.ApplyConfig(&MonitorConfig{
+		Hosts: []HostConfig{
+			{Name: "NAS", Type: "agent", Target: server.URL + "/", IntervalMS: 1000},
+			{Name: "nas", Type: "local"},
+			{Name: "router", Type: "telnet"},
+		},
+	})
+	defer collector.Close()
+	if len(collector.hosts) != 1 {
+		t.Fatalf("expected duplicate and unknown hosts to be skipped, got %d", len(collector.hosts))
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := collector.UpdateItems(); err != nil {
+			t.Fatalf("UpdateItems failed: %v", err)
+		}
+		if up := collector.getItem("hosts.nas.up"); up.IsAvailable() && up.GetValue().Value == 1.0 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if got := collector.getItem("hosts.nas.cpu"); !got.IsAvailable() || got.GetValue().Value != 12.5 {
+		t.Fatalf("unexpected cpu: %+v", got.GetValue())
+	}
+	if got := collector.getItem("hosts.nas.ram"); !got.IsAvailable() || got.GetValue().Value != 40.0 {
+		t.Fatalf("unexpected ram: %+v", got.GetValue())
+	}
+	if collector.getItem("hosts.nas.temp").IsAvailable() {
+		t.Fatal("expected temp to be unavailable when the agent has none")
+	}
+}
+
+func TestLocalHostSummaryKeepsCPUSamplePerPoller(t *testing.T) {
+	currentLocalHostSummary("10.0.0.2/1")
+	if summary := currentLocalHostSummary("10.0.0.3/1"); summary.CPU != nil {
+		t.Fatalf("expected a new poller to start from its own sample, got cpu=%v", *summary.CPU)
+	}
+	localHostSummaryMu.Lock()
+	first := localHostSummaryPollers["10.0.0.2/1"]
+	if first != nil {
+		first.seenAt = time.Now().Add(-hostPollerTTL - time.Second)
+	}
+	localHostSummaryMu.Unlock()
+	if first == nil {
+		t.Fatal("expected the first poller to be tracked")
+	}
+	currentLocalHostSummary("10.0.0.3/1")
+	localHostSummaryMu.Lock()
+	_, kept := localHostSummaryPollers["10.0.0.2/1"]
+	localHostSummaryMu.Unlock()
+	if kept {
+		t.Fatal("expected a poller that stopped asking to be dropped")
+	}
+}
@@ -14,20 +14,27 @@ const (
 	collectorGoNativeBtrfsRoot = "go_native.btrfs_root"
 	collectorGoNativeZram      = "go_native.zram"
 	collectorCustomAll         = "custom.all"
+	collectorHosts             = "hosts"
+	collectorTimer             = "timer"
 
 	collectorCoolerControl        = "coolercontrol"
 	collectorLibreHardwareMonitor = "librehardwaremonitor"
 	collectorRTSS                 = "rtss"
+	collectorBLE                  = "ble"
+	collectorFrameTime            = "frametime"
+	collectorAudio                = "audio"
 )
 
 func isCollectorSupportedOnCurrentPlatform(name string) bool {
 	switch name {
-	case collectorCoolerControl:
+	case collectorCoolerControl, collectorBLE:
 		return runtime.GOOS == "linux"
 	case collectorLibreHardwareMonitor:
 		return runtime.GOOS == "windows"
 	case collectorRTSS:
 		return runtime.GOOS == "windows"
+	case collectorFrameTime, collectorAudio:
+		return runtime.GOOS == "linux" || runtime.GOOS == "windows"
 	case collectorGoNativeBtrfsRoot:
 		return runtime.GOOS == "linux" && isBtrfsRootAvailable()
 	case collectorGoNativeZram:
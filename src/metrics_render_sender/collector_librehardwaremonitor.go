@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"runtime"
 	"strings"
 	"sync"
@@ -118,18 +119,7 @@ func (c *LibreHardwareMonitorCollector) GetAllItems() map[string]*CollectItem {
 			}
 			continue
 		}
-		precision := 2
-		maxValue := 0.0
-		switch strings.ToUpper(unit) {
-		case "°C":
-			precision = 1
-			maxValue = 120
-		case "%":
-			precision = 0
-			maxValue = 100
-		case "RPM", "MHZ", "GHZ", "HZ":
-			precision = 0
-		}
+		precision, maxValue := valueKindDefaults(valueKindForUnit(unit))
 		item := NewCollectItem(name, option.Label, unit, 0, maxValue, precision)
 		c.setItem(name, item)
 		c.sources[name] = name
@@ -138,6 +128,10 @@ func (c *LibreHardwareMonitorCollector) GetAllItems() map[string]*CollectItem {
 }
 
 func (c *LibreHardwareMonitorCollector) UpdateItems() error {
+	return c.UpdateItemsContext(context.Background())
+}
+
+func (c *LibreHardwareMonitorCollector) UpdateItemsContext(ctx context.Context) error {
 	c.mu.RLock()
 	client := c.client
 	sources := make(map[string]string, len(c.sources))
@@ -148,7 +142,7 @@ func (c *LibreHardwareMonitorCollector) UpdateItems() error {
 	if !c.IsEnabled() || client == nil {
 		return nil
 	}
-	if err := client.FetchData(); err != nil {
+	if err := client.FetchDataContext(ctx); err != nil {
 		return err
 	}
 	for key, sourceName := range sources {
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
@@ -17,6 +19,9 @@ type CollectValue struct {
 	Min       float64
 	Max       float64
 	Precision int
+	// ValueKind is derived from Unit unless a collector sets it; read it
+	// through Kind, which also accounts for text values.
+	ValueKind ValueKind
 }
 
 type BaseCollectItem struct {
@@ -24,6 +29,9 @@ type BaseCollectItem struct {
 	label       string
 	value       *CollectValue
 	available   bool
+	ready       bool
+	updatedAt   time.Time
+	updateGap   time.Duration
 	enabled     bool
 	rateWindow  time.Duration
 	rateSamples []rateSample
@@ -51,6 +59,7 @@ func NewBaseCollectItem(name, label string, min, max float64, unit string, preci
 			Min:       min,
 			Max:       max,
 			Precision: precision,
+			ValueKind: valueKindForUnit(unit),
 		},
 	}
 }
@@ -68,7 +77,7 @@ func (b *BaseCollectItem) GetValue() *CollectValue {
 	return &copied
 }
 
-func (b *BaseCollectItem) SnapshotState() (uint64, bool, *CollectValue) {
+func (b *BaseCollectItem) SnapshotState() (uint64, bool, bool, *CollectValue) {
 	b.mutex.RLock()
 	defer b.mutex.RUnlock()
 	var copied *CollectValue
@@ -76,7 +85,7 @@ func (b *BaseCollectItem) SnapshotState() (uint64, bool, *CollectValue) {
 		valueCopy := *b.value
 		copied = &valueCopy
 	}
-	return b.version, b.available, copied
+	return b.version, b.available, b.ready, copied
 }
 
 func (b *BaseCollectItem) Version() uint64 {
@@ -91,6 +100,42 @@ func (b *BaseCollectItem) IsAvailable() bool {
 	return b.available
 }
 
+// IsReady reports whether the item has had a valid sample. Until then its
+// value is the zero it was created with, which is shown as a placeholder.
+func (b *BaseCollectItem) IsReady() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.ready
+}
+
+// UpdatedAt returns when the item was last set, zero before the first set.
+func (b *BaseCollectItem) UpdatedAt() time.Time {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.updatedAt
+}
+
+// Staleness returns how long ago the item was last set and whether that is
+// more than cycles of its refresh cycle, the shortest gap seen between two
+// sets but at least tick, so slow sources are judged by their own pace.
+// Items never set or holding text, which is often set once, are not stale.
+func (b *BaseCollectItem) Staleness(now time.Time, tick time.Duration, cycles int) (time.Duration, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	if b.updatedAt.IsZero() || b.value == nil {
+		return 0, false
+	}
+	if _, ok := numericValue(b.value.Value); !ok {
+		return 0, false
+	}
+	age := now.Sub(b.updatedAt)
+	cycle := tick
+	if b.updateGap > cycle {
+		cycle = b.updateGap
+	}
+	return age, age > cycle*time.Duration(cycles)
+}
+
 func (b *BaseCollectItem) IsEnabled() bool {
 	b.mutex.RLock()
 	defer b.mutex.RUnlock()
@@ -110,9 +155,15 @@ func (b *BaseCollectItem) SetValue(value interface{}) {
 	if b.value == nil {
 		return
 	}
+	now := time.Now()
+	if !b.updatedAt.IsZero() {
+		if gap := now.Sub(b.updatedAt); gap > 0 && (b.updateGap == 0 || gap < b.updateGap) {
+			b.updateGap = gap
+		}
+	}
+	b.updatedAt = now
 	if b.rateWindow > 0 {
-		if numeric, ok := toRateFloat64(value); ok {
-			now := time.Now()
+		if numeric, ok := numericValue(value); ok {
 			b.rateSamples = append(b.rateSamples, rateSample{at: now, value: numeric})
 			cutoff := now.Add(-b.rateWindow)
 			total := 0.0
@@ -136,9 +187,24 @@ func (b *BaseCollectItem) SetValue(value interface{}) {
 		}
 	}
 	b.value.Value = value
+	if isValidSampleValue(value) {
+		b.ready = true
+	}
 	b.version++
 }
 
+// isValidSampleValue rejects nil and the NaN or infinite readings sensors
+// report while they initialize.
+func isValidSampleValue(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	if number, ok := numericValue(value); ok {
+		return !math.IsNaN(number) && !math.IsInf(number, 0)
+	}
+	return true
+}
+
 func (b *BaseCollectItem) SetUnit(unit string) {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
@@ -146,6 +212,7 @@ func (b *BaseCollectItem) SetUnit(unit string) {
 		return
 	}
 	b.value.Unit = unit
+	b.value.ValueKind = valueKindForUnit(unit)
 	b.rateWindow = builtinRateWindow(b.name, unit)
 	if b.rateWindow <= 0 {
 		b.rateSamples = nil
@@ -172,100 +239,34 @@ func FormatCollectValueParts(value *CollectValue, unitOverride string) (string,
 	if value == nil {
 		return "N/A", ""
 	}
-	unit := value.Unit
-	autoScale := true
-	if unitOverride != "" {
-		unit = unitOverride
-		autoScale = false
-	}
-	switch v := value.Value.(type) {
-	case string:
-		return v, ""
-	case float64, float32, int, int64, uint64:
-		val := getFloat64Value(v)
-		precision := value.Precision
-		if autoScale {
-			val, unit, precision = autoScaleUnitValue(val, unit, precision)
-		}
-		format := "%." + itoa(max(0, precision)) + "f"
-		return fmt.Sprintf(format, val), unit
-	default:
+	if value.Kind() == ValueKindText {
+		return value.Value.(string), ""
+	}
+	val, unit, precision, ok := scaleCollectNumber(value, unitOverride)
+	if !ok {
 		return fmt.Sprintf("%v", value.Value), ""
 	}
+	format := "%." + itoa(max(0, precision)) + "f"
+	return fmt.Sprintf(format, val), unit
 }
 
-func autoScaleUnitValue(value float64, unit string, precision int) (float64, string, int) {
-	trimmedUnit := strings.ToLower(strings.TrimSpace(unit))
-	if trimmedUnit == "" {
-		return value, unit, precision
-	}
-	family, index, scaleFactor, ok := getAutoScaleFamily(trimmedUnit)
-	if !ok || index < 0 || index >= len(family) {
-		return value, unit, precision
-	}
-	scaled := value
-	absValue := math.Abs(value)
-	if absValue > 0 {
-		for absValue >= scaleFactor && index < len(family)-1 {
-			scaled /= scaleFactor
-			index++
-			absValue = math.Abs(scaled)
-		}
-		for absValue > 0 && absValue < 1 && index > 0 {
-			scaled *= scaleFactor
-			index--
-			absValue = math.Abs(scaled)
-		}
-	}
-	scaledUnit := family[index]
-	if strings.HasPrefix(unit, " ") {
-		scaledUnit = " " + scaledUnit
-	}
-	return scaled, scaledUnit, autoScalePrecision(scaled, precision, scaledUnit != strings.TrimSpace(unit))
-}
-
-func getAutoScaleFamily(unit string) ([]string, int, float64, bool) {
-	switch unit {
-	case "b", "kb", "mb", "gb", "tb":
-		return []string{"B", "KB", "MB", "GB", "TB"}, unitIndex(unit, []string{"b", "kb", "mb", "gb", "tb"}), 1024, true
-	case "b/s", "kb/s", "mb/s", "gb/s", "tb/s":
-		return []string{"B/s", "KB/s", "MB/s", "GB/s", "TB/s"}, unitIndex(unit, []string{"b/s", "kb/s", "mb/s", "gb/s", "tb/s"}), 1024, true
-	case "kib", "mib", "gib", "tib":
-		return []string{"B", "KiB", "MiB", "GiB", "TiB"}, unitIndex(unit, []string{"b", "kib", "mib", "gib", "tib"}), 1024, true
-	case "kib/s", "mib/s", "gib/s", "tib/s":
-		return []string{"B/s", "KiB/s", "MiB/s", "GiB/s", "TiB/s"}, unitIndex(unit, []string{"b/s", "kib/s", "mib/s", "gib/s", "tib/s"}), 1024, true
-	case "hz", "khz", "mhz", "ghz", "thz":
-		return []string{"Hz", "KHz", "MHz", "GHz", "THz"}, unitIndex(unit, []string{"hz", "khz", "mhz", "ghz", "thz"}), 1000, true
-	default:
-		return nil, -1, 0, false
+// scaleCollectNumber converts a numeric value to the unit it is displayed in.
+// An override of the same kind converts the number, e.g. MiB/s shown as
+// KiB/s; any other override only replaces the label. ok is false for
+// non-numeric values.
+func scaleCollectNumber(value *CollectValue, unitOverride string) (float64, string, int, bool) {
+	val, ok := value.Float64()
+	if !ok {
+		return 0, "", 0, false
 	}
-}
-
-func unitIndex(unit string, family []string) int {
-	for idx, value := range family {
-		if unit == value {
-			return idx
+	if unitOverride != "" {
+		if converted, ok := convertUnitValue(val, value.Unit, unitOverride); ok {
+			val = converted
 		}
+		return val, unitOverride, value.Precision, true
 	}
-	return -1
-}
-
-func autoScalePrecision(value float64, defaultPrecision int, scaled bool) int {
-	precision := max(0, defaultPrecision)
-	if !scaled {
-		return precision
-	}
-	absValue := math.Abs(value)
-	switch {
-	case absValue >= 100:
-		return 0
-	case absValue >= 10:
-		return max(1, min(precision, 1))
-	case absValue >= 1:
-		return max(1, min(precision, 2))
-	default:
-		return max(2, precision)
-	}
+	val, unit, precision := autoScaleUnitValue(val, value.Unit, value.Precision)
+	return val, unit, precision, true
 }
 
 func max(a, b int) int {
@@ -283,65 +284,21 @@ func min(a, b int) int {
 }
 
 func getFloat64Value(value interface{}) float64 {
-	switch v := value.(type) {
-	case float64:
-		return v
-	case float32:
-		return float64(v)
-	case int:
-		return float64(v)
-	case int64:
-		return float64(v)
-	case uint64:
-		return float64(v)
-	default:
-		return 0.0
-	}
+	number, _ := numericValue(value)
+	return number
 }
 
 func builtinRateWindow(name, unit string) time.Duration {
 	trimmedName := strings.ToLower(strings.TrimSpace(name))
-	trimmedUnit := strings.ToLower(strings.TrimSpace(unit))
 	if !strings.HasPrefix(trimmedName, "go_native.") {
 		return 0
 	}
-	if strings.Contains(trimmedUnit, "/s") {
+	if valueKindForUnit(unit) == ValueKindByteRate {
 		return defaultThroughputRateWindow
 	}
 	return 0
 }
 
-func toRateFloat64(value interface{}) (float64, bool) {
-	switch v := value.(type) {
-	case float64:
-		return v, true
-	case float32:
-		return float64(v), true
-	case int:
-		return float64(v), true
-	case int8:
-		return float64(v), true
-	case int16:
-		return float64(v), true
-	case int32:
-		return float64(v), true
-	case int64:
-		return float64(v), true
-	case uint:
-		return float64(v), true
-	case uint8:
-		return float64(v), true
-	case uint16:
-		return float64(v), true
-	case uint32:
-		return float64(v), true
-	case uint64:
-		return float64(v), true
-	default:
-		return 0, false
-	}
-}
-
 func defaultMonitorWorkerCount() int {
 	workers := runtime.NumCPU()
 	if workers < 2 {
@@ -412,6 +369,10 @@ type CollectorManagerStats struct {
 	OutputAvgMS  int64                                `json:"output_avg_ms"`
 	OutputStats  map[string]OutputHandlerRuntimeStats `json:"output_stats,omitempty"`
 	TCPPushStats map[string]TCPPushAvailabilityStats  `json:"tcp_push_stats,omitempty"`
+
+	FramesRendered int64 `json:"frames_rendered"`
+	FramesShipped  int64 `json:"frames_shipped"`
+	FramesSkipped  int64 `json:"frames_skipped"`
 }
 
 type CollectorManager struct {
@@ -453,7 +414,11 @@ type CollectorManager struct {
 	mutex  sync.RWMutex
 
 	tickDuration    time.Duration
+	staleCycles     int
 	collectWarn     time.Duration
+	collectTimeouts map[string]time.Duration
+	schedules       map[string]collectorSchedule
+	lastDispatch    map[string]time.Time
 	renderWaitMax   time.Duration
 	currentEpoch    int64
 	lastRenderEpoch int64
@@ -468,6 +433,16 @@ type CollectorManager struct {
 	stopOnce    sync.Once
 	wg          sync.WaitGroup
 	started     bool
+
+	// sharedWorker runs every collector on one goroutine, fed batches over
+	// sharedChan, in place of one goroutine per collector (low_power).
+	sharedWorker bool
+	sharedChan   chan collectorBatch
+}
+
+type collectorBatch struct {
+	epochID    int64
+	collectors []namedCollector
 }
 
 type collectorEpochState struct {
@@ -493,8 +468,12 @@ func NewCollectorManager() *CollectorManager {
 		requiredResolved: make(map[string]struct{}),
 		epochStates:      make(map[int64]*collectorEpochState),
 		workerChans:      make(map[string]chan int64),
+		collectTimeouts:  make(map[string]time.Duration),
+		schedules:        make(map[string]collectorSchedule),
+		lastDispatch:     make(map[string]time.Time),
 		stopCh:           make(chan struct{}),
 		tickDuration:     time.Second,
+		staleCycles:      defaultStaleAfterCycles,
 		collectWarn:      100 * time.Millisecond,
 		renderWaitMax:    300 * time.Millisecond,
 	}
@@ -676,6 +655,34 @@ func (m *CollectorManager) discoverAll(trigger string) {
 	)
 }
 
+// Rediscover re-reads the items of the named collectors, e.g. after a
+// device was plugged in.
+func (m *CollectorManager) Rediscover(collectorNames []string, trigger string) {
+	wanted := make(map[string]struct{}, len(collectorNames))
+	for _, name := range collectorNames {
+		wanted[name] = struct{}{}
+	}
+	collectors := make([]namedCollector, 0, len(collectorNames))
+	for _, entry := range m.snapshotCollectors() {
+		if _, ok := wanted[entry.name]; ok {
+			collectors = append(collectors, entry)
+		}
+	}
+	if len(collectors) == 0 {
+		return
+	}
+	result := m.discoverFromCollectors(collectors, trigger)
+	logInfoModule(
+		"collect",
+		"action=discover,trigger=%s,collectors=%d,discovered=%d,new=%d,total=%d",
+		strings.TrimSpace(trigger),
+		len(collectors),
+		result.discovered,
+		result.newItems,
+		result.totalItems,
+	)
+}
+
 func normalizeCollectorSnapshotItems(items map[string]*CollectItem) map[string]*CollectItem {
 	if len(items) == 0 {
 		return map[string]*CollectItem{}
@@ -926,12 +933,16 @@ func alignedNextTick(now time.Time, tick time.Duration) time.Time {
 
 func (m *CollectorManager) configureRuntimeFromConfig(cfg *MonitorConfig) {
 	tick := time.Second
+	staleCycles := defaultStaleAfterCycles
 	collectWarn := 100 * time.Millisecond
 	renderWait := 300 * time.Millisecond
+	sharedWorker := false
 	if cfg != nil {
 		tick = cfg.GetCollectTickDuration()
+		staleCycles = cfg.GetStaleAfterCycles()
 		collectWarn = cfg.GetCollectWarnDuration()
 		renderWait = cfg.GetRenderWaitMaxDuration()
+		sharedWorker = cfg.LowPower
 	}
 	if tick <= 0 {
 		tick = time.Second
@@ -945,12 +956,29 @@ func (m *CollectorManager) configureRuntimeFromConfig(cfg *MonitorConfig) {
 
 	m.mutex.Lock()
 	m.tickDuration = tick
+	m.staleCycles = staleCycles
 	m.collectWarn = collectWarn
 	m.renderWaitMax = renderWait
+	if !m.started {
+		// Workers are laid out once, when collection starts.
+		m.sharedWorker = sharedWorker
+	}
 	m.mutex.Unlock()
 }
 
 func (m *CollectorManager) startCollectorWorkers() {
+	m.mutex.Lock()
+	shared := m.sharedWorker
+	if shared {
+		m.sharedChan = make(chan collectorBatch, 1)
+	}
+	m.mutex.Unlock()
+	if shared {
+		m.wg.Add(1)
+		go m.runSharedCollectorWorker()
+		return
+	}
+
 	collectors := m.snapshotCollectors()
 	for _, entry := range collectors {
 		entry := entry
@@ -980,6 +1008,13 @@ func (m *CollectorManager) runCollectorEpoch(name string, collector Collector, e
 		return
 	}
 
+	m.mutex.RLock()
+	timeout, ok := m.collectTimeouts[name]
+	m.mutex.RUnlock()
+	if !ok {
+		timeout = defaultCollectTimeoutMS * time.Millisecond
+	}
+
 	startedAt := time.Now()
 	err := func() (retErr error) {
 		defer func() {
@@ -987,9 +1022,24 @@ func (m *CollectorManager) runCollectorEpoch(name string, collector Collector, e
 				retErr = fmt.Errorf("panic: %v", recovered)
 			}
 		}()
-		return collector.UpdateItems()
+		var updateErr error
+		if updater, ok := collector.(CollectorContextUpdater); ok {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			updateErr = updater.UpdateItemsContext(ctx)
+			cancel()
+		} else {
+			updateErr = collector.UpdateItems()
+		}
+		if demoMode {
+			// Demo values stand in for hardware that may not be there.
+			applyDemoValues(collector, time.Now())
+			return nil
+		}
+		return updateErr
 	}()
 	duration := time.Since(startedAt)
+	reportCollectorStatus(name, err)
+	runtimeTimings.observeCollector(name, duration)
 
 	m.mutex.RLock()
 	warnThreshold := m.collectWarn
@@ -998,6 +1048,17 @@ func (m *CollectorManager) runCollectorEpoch(name string, collector Collector, e
 	slow := duration > warnThreshold && warnThreshold > 0
 	if slow {
 		atomic.AddInt64(&m.timeoutTotal, 1)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		logWarnModule(
+			"collect",
+			"action=update,result=timeout,collector=%s,epoch=%d,duration_ms=%d,timeout_ms=%d",
+			name,
+			epochID,
+			duration.Milliseconds(),
+			timeout.Milliseconds(),
+		)
+	} else if slow {
 		logWarnModule(
 			"collect",
 			"action=update,result=slow,collector=%s,epoch=%d,duration_ms=%d,threshold_ms=%d",
@@ -1009,13 +1070,29 @@ func (m *CollectorManager) runCollectorEpoch(name string, collector Collector, e
 	}
 
 	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.totalCollectNS += duration.Nanoseconds()
+	m.totalCollectCount++
+	if duration > m.collectMax {
+		m.collectMax = duration
+	}
+	if slow {
+		m.totalSlow++
+	}
+	if err != nil {
+		m.totalDropped++
+		logDebugModule("collect", "action=update,result=failed,collector=%s,epoch=%d,error=%q", name, epochID, err)
+	} else {
+		m.totalCompleted++
+	}
+
+	// Background collectors and runs of pruned epochs only count in the
+	// totals above.
 	state := m.epochStates[epochID]
 	if state == nil {
-		m.mutex.Unlock()
 		return
 	}
 	if _, expected := state.collectors[name]; !expected {
-		m.mutex.Unlock()
 		return
 	}
 	delete(state.collectors, name)
@@ -1024,22 +1101,13 @@ func (m *CollectorManager) runCollectorEpoch(name string, collector Collector, e
 	if duration.Nanoseconds() > state.maxNS {
 		state.maxNS = duration.Nanoseconds()
 	}
-	m.totalCollectNS += duration.Nanoseconds()
-	m.totalCollectCount++
-	if duration > m.collectMax {
-		m.collectMax = duration
-	}
 	if slow {
 		state.slow++
-		m.totalSlow++
 	}
 	if err != nil {
 		state.dropped++
-		m.totalDropped++
-		logDebugModule("collect", "action=update,result=failed,collector=%s,epoch=%d,error=%q", name, epochID, err)
 	} else {
 		state.success++
-		m.totalCompleted++
 	}
 	if state.completed >= state.expected {
 		m.lastWindowScheduled = int64(state.expected)
@@ -1056,7 +1124,6 @@ func (m *CollectorManager) runCollectorEpoch(name string, collector Collector, e
 			close(state.doneCh)
 		}
 	}
-	m.mutex.Unlock()
 }
 
 func (m *CollectorManager) snapshotActiveCollectorsLocked() []namedCollector {
@@ -1097,7 +1164,63 @@ func trySendLatestEpoch(ch chan int64, epochID int64) {
 	}
 }
 
+// runSharedCollectorWorker updates the collectors of each batch in turn. A
+// batch that arrives while one is running replaces any batch still waiting.
+func (m *CollectorManager) runSharedCollectorWorker() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case batch := <-m.sharedChan:
+			for _, entry := range batch.collectors {
+				if atomic.LoadInt32(&m.closed) == 1 {
+					return
+				}
+				m.runCollectorEpoch(entry.name, entry.collector, batch.epochID)
+			}
+		}
+	}
+}
+
+// sendSharedBatch queues a batch for the shared worker with frame collectors
+// first, so renders do not wait behind background ones.
+func (m *CollectorManager) sendSharedBatch(epochID int64, active []namedCollector) {
+	batch := collectorBatch{epochID: epochID, collectors: make([]namedCollector, 0, len(active))}
+	var background []namedCollector
+	m.mutex.RLock()
+	for _, entry := range active {
+		schedule, ok := m.schedules[entry.name]
+		if !ok {
+			schedule.priority = defaultCollectorPriority(entry.name)
+		}
+		if schedule.priority == collectorPriorityBackground {
+			background = append(background, entry)
+		} else {
+			batch.collectors = append(batch.collectors, entry)
+		}
+	}
+	m.mutex.RUnlock()
+	batch.collectors = append(batch.collectors, background...)
+
+	select {
+	case <-m.sharedChan:
+	default:
+	}
+	select {
+	case m.sharedChan <- batch:
+	default:
+	}
+}
+
 func (m *CollectorManager) dispatchEpoch(epochID int64, active []namedCollector) {
+	m.mutex.RLock()
+	shared := m.sharedChan != nil
+	m.mutex.RUnlock()
+	if shared {
+		m.sendSharedBatch(epochID, active)
+		return
+	}
 	for _, entry := range active {
 		m.mutex.RLock()
 		ch := m.workerChans[entry.name]
@@ -1144,26 +1267,28 @@ func (m *CollectorManager) runEpochScheduler() {
 		active := func() []namedCollector {
 			m.mutex.Lock()
 			defer m.mutex.Unlock()
-			activeCollectors := []namedCollector{}
+			var dueCollectors, frameCollectors []namedCollector
 			if !m.paused {
-				activeCollectors = m.snapshotActiveCollectorsLocked()
+				dueCollectors, frameCollectors = m.dueCollectorsLocked(m.snapshotActiveCollectorsLocked(), now)
 			}
+			// Only frame collectors count towards the epoch renders wait on;
+			// background collectors are dispatched alongside them.
 			state := &collectorEpochState{
 				doneCh:     make(chan struct{}),
-				expected:   len(activeCollectors),
-				collectors: make(map[string]struct{}, len(activeCollectors)),
+				expected:   len(frameCollectors),
+				collectors: make(map[string]struct{}, len(frameCollectors)),
 			}
-			for _, entry := range activeCollectors {
+			for _, entry := range frameCollectors {
 				state.collectors[entry.name] = struct{}{}
 			}
 			if state.expected == 0 {
 				close(state.doneCh)
 			}
 			m.epochStates[epochID] = state
-			m.totalScheduled += int64(state.expected)
+			m.totalScheduled += int64(len(dueCollectors))
 			m.pruneEpochStatesLocked(epochID)
 			m.epochCond.Broadcast()
-			return activeCollectors
+			return dueCollectors
 		}()
 
 		m.dispatchEpoch(epochID, active)
@@ -1251,6 +1376,47 @@ func (m *CollectorManager) WaitForNextEpoch(lastEpoch int64, maxWait time.Durati
 	return epochID, completed, waited
 }
 
+// defaultStaleAfterCycles is how many refresh cycles a monitor may miss
+// before it counts as stale, e.g. behind a hung sensor backend.
+const defaultStaleAfterCycles = 5
+
+// ItemStaleness returns how long ago item was last set and whether it is
+// stale by the configured stale_after_cycles.
+func (m *CollectorManager) ItemStaleness(item *CollectItem, now time.Time) (time.Duration, bool) {
+	if m == nil || item == nil {
+		return 0, false
+	}
+	m.mutex.RLock()
+	tick, cycles := m.tickDuration, m.staleCycles
+	m.mutex.RUnlock()
+	return item.Staleness(now, tick, cycles)
+}
+
+// WaitForReady waits, one collect pass at a time, until every enabled and
+// available monitor has had a sample or maxWait has passed, and reports
+// whether they all did.
+func (m *CollectorManager) WaitForReady(maxWait time.Duration) bool {
+	deadline := time.Now().Add(maxWait)
+	epoch := m.CurrentEpoch()
+	for !m.allMonitorsReady() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 || atomic.LoadInt32(&m.closed) == 1 {
+			return false
+		}
+		epoch, _, _ = m.WaitForNextEpoch(epoch, remaining)
+	}
+	return true
+}
+
+func (m *CollectorManager) allMonitorsReady() bool {
+	for _, item := range m.GetAll() {
+		if item != nil && item.IsEnabled() && item.IsAvailable() && !item.IsReady() {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *CollectorManager) CurrentEpoch() int64 {
 	return atomic.LoadInt64(&m.currentEpoch)
 }
@@ -1371,7 +1537,7 @@ func (m *CollectorManager) UpdateAll() error {
 
 func (m *CollectorManager) SetCollectorEnabled(name string, enabled bool) bool {
 	trimmed := strings.TrimSpace(name)
-	if trimmed == "" {
+	if trimmed == "" || (enabled && !isCollectorAllowed(trimmed)) {
 		return false
 	}
 	m.mutex.Lock()
@@ -1458,14 +1624,19 @@ func (m *CollectorManager) Stats() CollectorManagerStats {
 		queueLen += len(ch)
 	}
 	queueSize := len(m.workerChans)
+	workerCount := len(m.workerChans)
+	if m.sharedChan != nil {
+		queueLen, queueSize, workerCount = len(m.sharedChan), cap(m.sharedChan), 1
+	}
 	collectAvg := time.Duration(0)
 	if m.totalCollectCount > 0 && m.totalCollectNS > 0 {
 		collectAvg = time.Duration(m.totalCollectNS / m.totalCollectCount)
 	}
 	renderStats := renderRuntimeSnapshot()
 	outputStats := GetOutputRuntimeStats()
+	frameStats := frameRuntimeSnapshot()
 	return CollectorManagerStats{
-		WorkerCount: len(m.workerChans),
+		WorkerCount: workerCount,
 		QueueSize:   queueSize,
 		QueueLen:    queueLen,
 		Paused:      m.paused,
@@ -1512,6 +1683,10 @@ func (m *CollectorManager) Stats() CollectorManagerStats {
 		OutputAvgMS:  outputStats.AvgMS,
 		OutputStats:  outputStats.Handlers,
 		TCPPushStats: GetTCPPushAvailabilityStats(),
+
+		FramesRendered: frameStats.Rendered,
+		FramesShipped:  frameStats.Shipped,
+		FramesSkipped:  frameStats.SkippedTotal,
 	}
 }
 
@@ -1526,6 +1701,23 @@ func (m *CollectorManager) Close() {
 	m.epochCond.Broadcast()
 	m.mutex.Unlock()
 	m.wg.Wait()
+	for _, entry := range m.snapshotCollectors() {
+		if closer, ok := entry.collector.(CollectorCloser); ok {
+			closer.Close()
+		}
+	}
+}
+
+// ResetRates restarts every counter based rate from a fresh sample.
+func (m *CollectorManager) ResetRates(trigger string) {
+	names := make([]string, 0)
+	for _, entry := range m.snapshotCollectors() {
+		if resetter, ok := entry.collector.(CollectorRateResetter); ok {
+			resetter.ResetRates()
+			names = append(names, entry.name)
+		}
+	}
+	logInfoModule("collect", "action=reset_rates,trigger=%s,collectors=%s", trigger, strings.Join(names, "|"))
 }
 
 type CollectItemConfig struct {
@@ -1556,6 +1748,9 @@ func getCollectorManagerConfig() *CollectorManagerConfig {
 		"go_native.disk.max_busy",
 		"go_native.disk.max_latency",
 		"go_native.disk.max_temp",
+		"go_native.disk.total_size",
+		"go_native.disk.total_used",
+		"go_native.disk.total_free",
 		"go_native.memory.usage",
 		"go_native.memory.used",
 		"go_native.memory.total",
@@ -1573,6 +1768,11 @@ func getCollectorManagerConfig() *CollectorManagerConfig {
 		"go_native.system.render.avg_ms",
 		"go_native.system.output.max_ms",
 		"go_native.system.output.avg_ms",
+		"go_native.system.frames.rendered",
+		"go_native.system.frames.shipped",
+		"go_native.system.frames.skipped",
+		"go_native.system.status",
+		"go_native.system.error_count",
 		"go_native.system.output.memimg.last_ms",
 		"go_native.system.output.memimg.max_ms",
 		"go_native.system.output.memimg.avg_ms",
@@ -1636,6 +1836,13 @@ func initializeCollectors(manager *CollectorManager, cfg *MonitorConfig) {
 	if cfg == nil {
 		cfg = &MonitorConfig{}
 	}
+	if replaySource != nil {
+		// Recorded values stand in for the hardware; custom monitors still
+		// derive from them.
+		registerCollectorWithConfig(manager, cfg, NewReplayCollector(replaySource), true)
+		registerCollectorWithConfig(manager, cfg, NewCustomCollector(cfg, manager.Get), true)
+		return
+	}
 	registerCollectorWithConfig(manager, cfg, NewGoNativeCPUCollector(), true)
 	registerCollectorWithConfig(manager, cfg, NewGoNativeMemoryCollector(), true)
 	registerCollectorWithConfig(manager, cfg, NewGoNativeSystemCollector(), true)
@@ -1656,6 +1863,22 @@ func initializeCollectors(manager *CollectorManager, cfg *MonitorConfig) {
 	if rtss := NewRTSSCollector(cfg); rtss != nil {
 		registerCollectorWithConfig(manager, cfg, rtss, true)
 	}
+	if ble := NewBLESensorCollector(cfg); ble != nil {
+		registerCollectorWithConfig(manager, cfg, ble, true)
+	}
+	if frameTime := NewFrameTimeCollector(); frameTime != nil {
+		registerCollectorWithConfig(manager, cfg, frameTime, true)
+	}
+	if audio := NewAudioLevelCollector(); audio != nil {
+		registerCollectorWithConfig(manager, cfg, audio, true)
+	}
+	registerCollectorWithConfig(manager, cfg, NewHostsCollector(), true)
+	registerCollectorWithConfig(manager, cfg, NewTimerCollector(), true)
+	for _, name := range monitorProviderNames() {
+		if provider := NewProviderCollector(name, lookupMonitorProvider(name)); provider != nil {
+			registerCollectorWithConfig(manager, cfg, provider, false)
+		}
+	}
 	registerCollectorWithConfig(manager, cfg, NewCustomCollector(cfg, manager.Get), true)
 }
 
@@ -1671,10 +1894,10 @@ func registerCollectorWithConfig(manager *CollectorManager, cfg *MonitorConfig,
 
 func defaultCollectorEnabled(name string) bool {
 	switch strings.TrimSpace(name) {
-	case collectorCoolerControl, collectorLibreHardwareMonitor, collectorRTSS:
+	case collectorCoolerControl, collectorLibreHardwareMonitor, collectorRTSS, collectorBLE, collectorFrameTime, collectorAudio:
 		return false
 	default:
-		return true
+		return !isMonitorProviderName(name)
 	}
 }
 
@@ -1697,6 +1920,8 @@ func (m *CollectorManager) applyConfig(cfg *MonitorConfig, requiredItems []strin
 	for _, entry := range collectors {
 		defaultEnabled := defaultCollectorEnabled(entry.name)
 		m.collectorEnabled[entry.name] = cfg.IsCollectorEnabled(entry.name, defaultEnabled)
+		m.collectTimeouts[entry.name] = cfg.GetCollectTimeoutDuration(entry.name)
+		m.schedules[entry.name] = cfg.GetCollectorSchedule(entry.name)
 	}
 	m.mutex.Unlock()
 
@@ -1722,15 +1947,19 @@ func newCollectorManagerFromConfig(cfg *MonitorConfig, requiredItems []string) *
 
 var (
 	globalCollectorManager *CollectorManager
-	globalCollectorConfig  *MonitorConfig
 	globalCollectorMu      sync.RWMutex
+
+	// globalCollectorConfig is written under globalCollectorMu but read
+	// without it: collectors read it during discovery, which runs while the
+	// manager is built with the mutex held.
+	globalCollectorConfig atomic.Pointer[MonitorConfig]
 )
 
 func GetCollectorManager() *CollectorManager {
 	globalCollectorMu.Lock()
 	defer globalCollectorMu.Unlock()
 	if globalCollectorManager == nil {
-		globalCollectorManager = newCollectorManagerFromConfig(globalCollectorConfig, nil)
+		globalCollectorManager = newCollectorManagerFromConfig(globalCollectorConfig.Load(), nil)
 	}
 	return globalCollectorManager
 }
@@ -1740,10 +1969,10 @@ func GetCollectorManagerWithConfig(requiredItems []string, networkInterface stri
 	globalCollectorMu.Lock()
 	defer globalCollectorMu.Unlock()
 	if globalCollectorManager == nil {
-		globalCollectorManager = newCollectorManagerFromConfig(globalCollectorConfig, requiredItems)
+		globalCollectorManager = newCollectorManagerFromConfig(globalCollectorConfig.Load(), requiredItems)
 		return globalCollectorManager
 	}
-	globalCollectorManager.ApplyConfig(globalCollectorConfig, requiredItems)
+	globalCollectorManager.ApplyConfig(globalCollectorConfig.Load(), requiredItems)
 	return globalCollectorManager
 }
 
@@ -1756,16 +1985,14 @@ func CurrentCollectorManager() *CollectorManager {
 func SetGlobalCollectorConfig(config *MonitorConfig) {
 	globalCollectorMu.Lock()
 	defer globalCollectorMu.Unlock()
-	globalCollectorConfig = config
+	globalCollectorConfig.Store(config)
 	if globalCollectorManager != nil {
 		globalCollectorManager.ApplyConfig(config, globalCollectorManager.requiredItemsSnapshot())
 	}
 }
 
 func GetGlobalCollectorConfig() *MonitorConfig {
-	globalCollectorMu.RLock()
-	defer globalCollectorMu.RUnlock()
-	return globalCollectorConfig
+	return globalCollectorConfig.Load()
 }
 
 func ResetGlobalCollectorManager() {
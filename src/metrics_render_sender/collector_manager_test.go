@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -74,7 +75,7 @@ func TestGetCollectorManagerReturnsExistingManagerWithoutApply(t *testing.T) {
 	manager.RegisterCollector(collector)
 
 	globalCollectorMu.Lock()
-	globalCollectorConfig = &MonitorConfig{}
+	globalCollectorConfig.Store(&MonitorConfig{})
 	globalCollectorManager = manager
 	globalCollectorMu.Unlock()
 
@@ -119,7 +120,7 @@ func TestSetGlobalCollectorConfigAppliesWithoutDiscover(t *testing.T) {
 
 	globalCollectorMu.Lock()
 	globalCollectorManager = manager
-	globalCollectorConfig = nil
+	globalCollectorConfig.Store(nil)
 	globalCollectorMu.Unlock()
 
 	SetGlobalCollectorConfig(&MonitorConfig{})
@@ -131,3 +132,103 @@ func TestSetGlobalCollectorConfigAppliesWithoutDiscover(t *testing.T) {
 		t.Fatalf("expected SetGlobalCollectorConfig to avoid discovery, got %d GetAllItems calls", collector.getAllItemsCalls)
 	}
 }
+
+func TestItemStalenessFollowsRefreshCycle(t *testing.T) {
+	manager := NewCollectorManager()
+	manager.configureRuntimeFromConfig(&MonitorConfig{RefreshInterval: 2000, StaleAfterCycles: 3})
+	item := NewCollectItem("test.temp", "Temp", "°C", 0, 100, 0)
+	if _, stale := manager.ItemStaleness(item, time.Now().Add(time.Hour)); stale {
+		t.Fatal("expected an item that was never set not to be stale")
+	}
+
+	item.SetValue(0.0)
+	updated := item.UpdatedAt()
+	if _, stale := manager.ItemStaleness(item, updated.Add(5*time.Second)); stale {
+		t.Fatal("expected a zero set 5s ago to be fresh with three 2s cycles")
+	}
+	age, stale := manager.ItemStaleness(item, updated.Add(7*time.Second))
+	if !stale || age != 7*time.Second {
+		t.Fatalf("expected stale after 7s, got %v %v", age, stale)
+	}
+
+	// A source that updates once a minute is judged by its own pace.
+	item.mutex.Lock()
+	item.updateGap = time.Minute
+	item.mutex.Unlock()
+	if _, stale := manager.ItemStaleness(item, updated.Add(2*time.Minute)); stale {
+		t.Fatal("expected a slow source to be fresh within three of its cycles")
+	}
+	if _, stale := manager.ItemStaleness(item, updated.Add(4*time.Minute)); !stale {
+		t.Fatal("expected a slow source to go stale after three of its cycles")
+	}
+
+	item.SetValue("eth0")
+	if _, stale := manager.ItemStaleness(item, time.Now().Add(time.Hour)); stale {
+		t.Fatal("expected text values never to be stale")
+	}
+}
+
+type hungCollector struct {
+	*BaseCollector
+	err chan error
+}
+
+func (c *hungCollector) GetAllItems() map[string]*CollectItem { return c.ItemsSnapshot() }
+
+func (c *hungCollector) UpdateItems() error {
+	select {}
+}
+
+func (c *hungCollector) UpdateItemsContext(ctx context.Context) error {
+	<-ctx.Done()
+	c.err <- ctx.Err()
+	return ctx.Err()
+}
+
+func TestCollectorUpdateIsCancelledAtTimeout(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	manager := NewCollectorManager()
+	collector := &hungCollector{BaseCollector: NewBaseCollector("test.hung"), err: make(chan error, 1)}
+	manager.RegisterCollector(collector)
+	manager.ApplyConfig(&MonitorConfig{
+		CollectTimeoutMS: 30_000,
+		CollectorConfig: map[string]CollectorConfig{
+			"test.hung": {Options: map[string]interface{}{"timeout_ms": 150}},
+		},
+	}, nil)
+
+	startedAt := time.Now()
+	manager.runCollectorEpoch("test.hung", collector, 1)
+	if elapsed := time.Since(startedAt); elapsed < 150*time.Millisecond || elapsed > 5*time.Second {
+		t.Fatalf("expected the update to end at the 150ms timeout_ms option, took %v", elapsed)
+	}
+	if err := <-collector.err; err != context.DeadlineExceeded {
+		t.Fatalf("expected the update context to expire, got %v", err)
+	}
+}
+
+func TestEpochWaitsOnlyForFrameCollectors(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	cfg := &MonitorConfig{
+		CollectorConfig: map[string]CollectorConfig{
+			"test.probe": {Options: map[string]interface{}{"priority": "background", "update_interval_ms": 10_000}},
+		},
+	}
+	manager := NewCollectorManager()
+	manager.schedules["test.probe"] = cfg.GetCollectorSchedule("test.probe")
+	active := []namedCollector{{name: collectorGoNativeCPU}, {name: "test.probe"}}
+
+	now := time.Now()
+	due, frame := manager.dueCollectorsLocked(active, now)
+	if len(due) != 2 || len(frame) != 1 || frame[0].name != collectorGoNativeCPU {
+		t.Fatalf("expected both collectors due and only cpu waited on, got due=%v frame=%v", due, frame)
+	}
+	due, _ = manager.dueCollectorsLocked(active, now.Add(time.Second))
+	if len(due) != 1 || due[0].name != collectorGoNativeCPU {
+		t.Fatalf("expected the probe to wait for update_interval_ms, got %v", due)
+	}
+	due, _ = manager.dueCollectorsLocked(active, now.Add(10*time.Second))
+	if len(due) != 2 {
+		t.Fatalf("expected the probe to run again after update_interval_ms, got %v", due)
+	}
+}
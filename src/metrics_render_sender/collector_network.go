@@ -6,31 +6,112 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	gopsutilNet "github.com/shirou/gopsutil/v3/net"
 )
 
+const (
+	networkIPFamilyV4   = "v4"
+	networkIPFamilyV6   = "v6"
+	networkIPFamilyBoth = "both"
+)
+
+// networkAddresses are the addresses an interface is shown with; either
+// may be empty.
+type networkAddresses struct {
+	IPv4 string
+	IPv6 string
+}
+
 type goNativeNetworkSlot struct {
 	uploadItem    *CollectItem
 	downloadItem  *CollectItem
 	ipItem        *CollectItem
+	ip6Item       *CollectItem
 	nameItem      *CollectItem
 	interfaceName string
-	ipv4          string
+	addresses     networkAddresses
 }
 
 type GoNativeNetworkCollector struct {
 	*BaseCollector
 	requiredProvider func() []string
-	slots            map[int]*goNativeNetworkSlot
+	interfaces       *networkInterfaceWatcher
+	unsubscribe      func()
+
+	slotsMu     sync.Mutex
+	slots       map[int]*goNativeNetworkSlot
+	defaultSlot *goNativeNetworkSlot
+	ipFamily    string
 }
 
 func NewGoNativeNetworkCollector(requiredProvider func() []string) *GoNativeNetworkCollector {
-	return &GoNativeNetworkCollector{
+	return newGoNativeNetworkCollector(requiredProvider, sharedNetworkInterfaces)
+}
+
+func newGoNativeNetworkCollector(requiredProvider func() []string, interfaces *networkInterfaceWatcher) *GoNativeNetworkCollector {
+	c := &GoNativeNetworkCollector{
 		BaseCollector:    NewBaseCollector("go_native.network"),
 		requiredProvider: requiredProvider,
+		interfaces:       interfaces,
 		slots:            make(map[int]*goNativeNetworkSlot),
+		defaultSlot: &goNativeNetworkSlot{
+			uploadItem:   NewCollectItem("go_native.net.default.upload", "Default net upload", " MiB/s", 0, 0, 2),
+			downloadItem: NewCollectItem("go_native.net.default.download", "Default net download", " MiB/s", 0, 0, 2),
+			ipItem:       NewCollectItem("go_native.net.default.ip", "Default net ip", "", 0, 0, 0),
+			ip6Item:      NewCollectItem("go_native.net.default.ip6", "Default net ipv6", "", 0, 0, 0),
+			nameItem:     NewCollectItem("go_native.net.default.interface", "Default net interface", "", 0, 0, 0),
+		},
+		ipFamily: networkIPFamilyV4,
 	}
+	c.setSlotItems(c.defaultSlot)
+	c.unsubscribe = c.interfaces.OnChange(func(previous, current networkInterfaceState) {
+		if c.applyInterfaceState(current) {
+			// New slots only become monitors once the manager sees them.
+			// The first refresh runs in discovery, while the manager is
+			// built under the global lock, so it is looked up off this path.
+			go func() {
+				if manager := CurrentCollectorManager(); manager != nil {
+					manager.Rediscover([]string{collectorGoNativeNetwork}, "interfaces")
+				}
+			}()
+		}
+	})
+	return c
+}
+
+// ApplyConfig picks up the ip_family option, on which the ip monitors
+// switch at once, and the include and exclude filters, which apply from the
+// next interface scan. The rates may span twice update_interval_ms.
+func (c *GoNativeNetworkCollector) ApplyConfig(cfg *MonitorConfig) {
+	family := networkIPFamilyV4
+	maxGap := rateSampleMaxGap
+	if cfg != nil {
+		family = cfg.GetNetworkIPFamily()
+		maxGap = rateMaxGap(cfg.GetCollectorSchedule(collectorGoNativeNetwork).interval)
+	}
+	sharedNetworkSampler.SetMaxGap(maxGap)
+	setDeviceFilter(collectorGoNativeNetwork, deviceFilterFromConfig(cfg, collectorGoNativeNetwork))
+	c.slotsMu.Lock()
+	changed := c.ipFamily != family
+	c.ipFamily = family
+	c.slotsMu.Unlock()
+	if changed {
+		c.applyInterfaceState(c.interfaces.Refresh())
+	}
+}
+
+func (c *GoNativeNetworkCollector) Close() {
+	if c.unsubscribe != nil {
+		c.unsubscribe()
+	}
+}
+
+// ResetRates restarts the interface rates, which come from the shared
+// sampler.
+func (c *GoNativeNetworkCollector) ResetRates() {
+	sharedNetworkSampler.Reset()
 }
 
 func (c *GoNativeNetworkCollector) requiredMaxIndex() int {
@@ -54,7 +135,7 @@ func (c *GoNativeNetworkCollector) requiredMaxIndex() int {
 			continue
 		}
 		switch parts[1] {
-		case "upload", "download", "ip", "interface":
+		case "upload", "download", "ip", "ip6", "interface":
 			if idx > maxIndex {
 				maxIndex = idx
 			}
@@ -63,17 +144,14 @@ func (c *GoNativeNetworkCollector) requiredMaxIndex() int {
 	return maxIndex
 }
 
-func (c *GoNativeNetworkCollector) ensureSlots() {
-	names, _ := getActiveNetworkInterfacesAndIPv4()
-	c.ensureSlotsForCount(len(names))
-}
-
-func (c *GoNativeNetworkCollector) ensureSlotsForCount(detected int) {
+// ensureSlotsForCountLocked reports whether it created any slot.
+func (c *GoNativeNetworkCollector) ensureSlotsForCountLocked(detected int) bool {
 	requiredMax := c.requiredMaxIndex()
 	slotCount := max(detected, requiredMax)
 	if slotCount > 16 {
 		slotCount = 16
 	}
+	created := false
 	for index := 1; index <= slotCount; index++ {
 		if _, exists := c.slots[index]; exists {
 			continue
@@ -82,41 +160,87 @@ func (c *GoNativeNetworkCollector) ensureSlotsForCount(detected int) {
 			uploadItem:   NewCollectItem(fmt.Sprintf("go_native.net.%d.upload", index), fmt.Sprintf("Net %d upload", index), " MiB/s", 0, 0, 2),
 			downloadItem: NewCollectItem(fmt.Sprintf("go_native.net.%d.download", index), fmt.Sprintf("Net %d download", index), " MiB/s", 0, 0, 2),
 			ipItem:       NewCollectItem(fmt.Sprintf("go_native.net.%d.ip", index), fmt.Sprintf("Net %d ip", index), "", 0, 0, 0),
+			ip6Item:      NewCollectItem(fmt.Sprintf("go_native.net.%d.ip6", index), fmt.Sprintf("Net %d ipv6", index), "", 0, 0, 0),
 			nameItem:     NewCollectItem(fmt.Sprintf("go_native.net.%d.interface", index), fmt.Sprintf("Net %d interface", index), "", 0, 0, 0),
 		}
 		c.slots[index] = slot
-		c.setItem(slot.uploadItem.GetName(), slot.uploadItem)
-		c.setItem(slot.downloadItem.GetName(), slot.downloadItem)
-		c.setItem(slot.ipItem.GetName(), slot.ipItem)
-		c.setItem(slot.nameItem.GetName(), slot.nameItem)
+		c.setSlotItems(slot)
+		created = true
 	}
+	return created
 }
 
-func (c *GoNativeNetworkCollector) GetAllItems() map[string]*CollectItem {
-	interfaces, ipv4ByName := getActiveNetworkInterfacesAndIPv4()
-	c.ensureSlotsForCount(len(interfaces))
+func (c *GoNativeNetworkCollector) setSlotItems(slot *goNativeNetworkSlot) {
+	c.setItem(slot.uploadItem.GetName(), slot.uploadItem)
+	c.setItem(slot.downloadItem.GetName(), slot.downloadItem)
+	c.setItem(slot.ipItem.GetName(), slot.ipItem)
+	c.setItem(slot.ip6Item.GetName(), slot.ip6Item)
+	c.setItem(slot.nameItem.GetName(), slot.nameItem)
+}
+
+// applyInterfaceState points every slot at its interface and reports
+// whether new slots had to be created.
+func (c *GoNativeNetworkCollector) applyInterfaceState(state networkInterfaceState) bool {
+	c.slotsMu.Lock()
+	defer c.slotsMu.Unlock()
+	created := c.ensureSlotsForCountLocked(len(state.Interfaces))
 	for index, slot := range c.slots {
-		iface := resolveInterfaceByIndex(interfaces, index)
-		slot.interfaceName = iface
-		slot.ipv4 = strings.TrimSpace(ipv4ByName[iface])
-		if strings.TrimSpace(iface) == "" {
-			slot.nameItem.SetValue("-")
-			slot.nameItem.SetAvailable(false)
-			slot.ipItem.SetValue("-")
-			slot.ipItem.SetAvailable(false)
-			continue
+		assignNetworkSlot(slot, resolveInterfaceByIndex(state.Interfaces, index), state.Addresses, c.ipFamily)
+	}
+	assignNetworkSlot(c.defaultSlot, state.Default, state.Addresses, c.ipFamily)
+	return created
+}
+
+func assignNetworkSlot(slot *goNativeNetworkSlot, iface string, addressesByName map[string]networkAddresses, family string) {
+	slot.interfaceName = iface
+	slot.addresses = addressesByName[iface]
+	if strings.TrimSpace(iface) == "" {
+		slot.nameItem.SetValue("-")
+		slot.nameItem.SetAvailable(false)
+		setNetworkAddressItem(slot.ipItem, "")
+		setNetworkAddressItem(slot.ip6Item, "")
+		return
+	}
+	slot.nameItem.SetValue(iface)
+	slot.nameItem.SetAvailable(true)
+	setNetworkAddressItem(slot.ipItem, displayNetworkAddress(slot.addresses, family))
+	setNetworkAddressItem(slot.ip6Item, slot.addresses.IPv6)
+}
+
+func setNetworkAddressItem(item *CollectItem, address string) {
+	if address == "" {
+		item.SetValue("-")
+		item.SetAvailable(false)
+		return
+	}
+	item.SetValue(address)
+	item.SetAvailable(true)
+}
+
+// displayNetworkAddress is what the ip monitor shows for the ip_family
+// setting. A single-stack interface shows its one address either way.
+func displayNetworkAddress(addresses networkAddresses, family string) string {
+	switch family {
+	case networkIPFamilyV6:
+		if addresses.IPv6 != "" {
+			return addresses.IPv6
 		}
-		slot.nameItem.SetValue(iface)
-		slot.nameItem.SetAvailable(true)
-		ip := slot.ipv4
-		if ip == "" {
-			slot.ipItem.SetValue("-")
-			slot.ipItem.SetAvailable(false)
-		} else {
-			slot.ipItem.SetValue(ip)
-			slot.ipItem.SetAvailable(true)
+		return addresses.IPv4
+	case networkIPFamilyBoth:
+		if addresses.IPv4 != "" && addresses.IPv6 != "" {
+			return addresses.IPv4 + " / " + addresses.IPv6
 		}
+		return addresses.IPv4 + addresses.IPv6
+	default:
+		if addresses.IPv4 != "" {
+			return addresses.IPv4
+		}
+		return addresses.IPv6
 	}
+}
+
+func (c *GoNativeNetworkCollector) GetAllItems() map[string]*CollectItem {
+	c.applyInterfaceState(c.interfaces.Refresh())
 	return c.ItemsSnapshot()
 }
 
@@ -124,21 +248,29 @@ func (c *GoNativeNetworkCollector) UpdateItems() error {
 	if !c.IsEnabled() {
 		return nil
 	}
-	interfaceNames := make([]string, 0, len(c.slots))
+	// Runs the change callbacks first, so a switch of default interface
+	// shows up in this update rather than the next discovery.
+	c.interfaces.Refresh()
+
+	c.slotsMu.Lock()
+	defer c.slotsMu.Unlock()
+	slots := make([]*goNativeNetworkSlot, 0, len(c.slots)+1)
 	for _, slot := range c.slots {
-		if slot == nil {
-			continue
+		if slot != nil {
+			slots = append(slots, slot)
 		}
+	}
+	slots = append(slots, c.defaultSlot)
+
+	interfaceNames := make([]string, 0, len(slots))
+	for _, slot := range slots {
 		if name := strings.TrimSpace(slot.interfaceName); name != "" {
 			interfaceNames = append(interfaceNames, name)
 		}
 	}
-	speedByName := getNetworkSpeedSnapshots(interfaceNames)
+	speedByName := sharedNetworkSampler.Rates(interfaceNames)
 
-	for _, slot := range c.slots {
-		if slot == nil {
-			continue
-		}
+	for _, slot := range slots {
 		if strings.TrimSpace(slot.interfaceName) == "" {
 			if slot.uploadItem.IsEnabled() {
 				slot.uploadItem.SetAvailable(false)
@@ -176,25 +308,21 @@ func resolveInterfaceByIndex(names []string, index int) string {
 	return names[index-1]
 }
 
-func getActiveNetworkInterfaces() []string {
-	names, _ := getActiveNetworkInterfacesAndIPv4()
-	return names
-}
-
-func getActiveNetworkInterfacesAndIPv4() ([]string, map[string]string) {
+func getActiveNetworkInterfaces() ([]string, map[string]networkAddresses) {
 	interfaces, err := gopsutilNet.Interfaces()
 	if err != nil {
-		return []string{}, map[string]string{}
+		return []string{}, map[string]networkAddresses{}
 	}
 	active := make([]string, 0, len(interfaces))
-	ipv4ByName := make(map[string]string, len(interfaces))
+	addressesByName := make(map[string]networkAddresses, len(interfaces))
 	seen := make(map[string]struct{}, len(interfaces))
+	filter := currentDeviceFilter(collectorGoNativeNetwork)
 	for _, iface := range interfaces {
 		name := strings.TrimSpace(iface.Name)
 		if name == "" {
 			continue
 		}
-		if isVirtualInterface(name) {
+		if !networkInterfaceAllowed(filter, name) {
 			continue
 		}
 		if len(iface.Flags) == 0 {
@@ -218,10 +346,13 @@ func getActiveNetworkInterfacesAndIPv4() ([]string, map[string]string) {
 		}
 		seen[name] = struct{}{}
 		active = append(active, name)
-		ipv4ByName[name] = extractInterfaceIPv4(iface)
+		addressesByName[name] = networkAddresses{
+			IPv4: extractInterfaceIPv4(iface),
+			IPv6: extractInterfaceIPv6(iface),
+		}
 	}
 	sort.Strings(active)
-	return active, ipv4ByName
+	return active, addressesByName
 }
 
 func isVirtualInterface(name string) bool {
@@ -253,24 +384,6 @@ func hasValidIP(iface gopsutilNet.InterfaceStat) bool {
 	return false
 }
 
-func getInterfaceIPv4(interfaceName string) string {
-	interfaceName = strings.TrimSpace(interfaceName)
-	if interfaceName == "" {
-		return ""
-	}
-	ifaces, err := gopsutilNet.Interfaces()
-	if err != nil {
-		return ""
-	}
-	for _, iface := range ifaces {
-		if iface.Name != interfaceName {
-			continue
-		}
-		return extractInterfaceIPv4(iface)
-	}
-	return ""
-}
-
 func extractInterfaceIPv4(iface gopsutilNet.InterfaceStat) string {
 	for _, addr := range iface.Addrs {
 		if strings.TrimSpace(addr.Addr) == "" {
@@ -286,3 +399,35 @@ func extractInterfaceIPv4(iface gopsutilNet.InterfaceStat) string {
 	}
 	return ""
 }
+
+// extractInterfaceIPv6 picks the address to show when an interface has
+// several: global unicast before unique local (fc00::/7). Link-local
+// addresses are never shown, they are only meaningful on the link.
+func extractInterfaceIPv6(iface gopsutilNet.InterfaceStat) string {
+	best := ""
+	bestRank := 0
+	for _, addr := range iface.Addrs {
+		ip := net.ParseIP(strings.Split(strings.TrimSpace(addr.Addr), "/")[0])
+		rank, ok := ipv6AddressRank(ip)
+		if !ok {
+			continue
+		}
+		if best == "" || rank < bestRank {
+			best = ip.String()
+			bestRank = rank
+		}
+	}
+	return best
+}
+
+func ipv6AddressRank(ip net.IP) (int, bool) {
+	if ip == nil || ip.To4() != nil || !ip.IsGlobalUnicast() {
+		// IsGlobalUnicast also rules out loopback, link-local, multicast
+		// and the unspecified address.
+		return 0, false
+	}
+	if ip.IsPrivate() {
+		return 1, true
+	}
+	return 0, true
+}
@@ -1,28 +1,47 @@
 package main
 
 import (
-	"net"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/host"
-	gopsutilNet "github.com/shirou/gopsutil/v3/net"
 )
 
-type netRateSnapshot struct {
-	counter gopsutilNet.IOCountersStat
-	at      time.Time
+// rateSampleMaxGap is the longest gap a counter rate is computed across for
+// a collector on the refresh cadence. A longer gap means the previous sample
+// predates a suspend, hibernation or paused collection, and dividing by it
+// would smear or inflate the rate.
+const rateSampleMaxGap = time.Minute
+
+// rateMaxGap is the longest gap a collector updated every interval computes
+// a rate across: twice its update_interval_ms, so one late tick still
+// counts, and never less than rateSampleMaxGap.
+func rateMaxGap(interval time.Duration) time.Duration {
+	if 2*interval > rateSampleMaxGap {
+		return 2 * interval
+	}
+	return rateSampleMaxGap
 }
 
-type networkSpeedSnapshot struct {
-	Upload   float64
-	Download float64
-	OK       bool
+// rateElapsed returns the time between two counter samples for a rate.
+// Timestamps from time.Now carry a monotonic reading, which Sub prefers, so
+// NTP steps of the wall clock do not move the result. It reports false for
+// gaps that must not be divided by: zero or negative, which a timestamp
+// without a monotonic reading can produce after a step back, or longer than
+// maxGap.
+func rateElapsed(previous, current time.Time, maxGap time.Duration) (time.Duration, bool) {
+	if previous.IsZero() || current.IsZero() {
+		return 0, false
+	}
+	elapsed := current.Sub(previous)
+	if elapsed <= 0 || elapsed > maxGap {
+		return 0, false
+	}
+	return elapsed, true
 }
 
 type diskRateSnapshot struct {
@@ -57,25 +76,6 @@ type diskTemperatureSnapshot struct {
 	OK          bool
 }
 
-var (
-	networkRateMu    sync.Mutex
-	networkRateCache = make(map[string]netRateSnapshot)
-)
-
-func getRealCPUTemperature() float64 {
-	if temp := getTemperatureByKeywords([]string{"cpu", "package", "core", "tctl", "ccd"}); temp > 0 {
-		return temp
-	}
-	return 0
-}
-
-func getRealCPUFrequency() (float64, float64) {
-	if current, maxFreq, ok := getCPUFrequencyByGopsutil(); ok {
-		return current, maxFreq
-	}
-	return 0, 0
-}
-
 func getCPUFrequencyByGopsutil() (float64, float64, bool) {
 	infos, err := cpu.Info()
 	if err != nil || len(infos) == 0 {
@@ -103,130 +103,6 @@ func getCPUFrequencyByGopsutil() (float64, float64, bool) {
 	return total / float64(count), maxV, true
 }
 
-func getNetworkInfo() NetworkInfoData {
-	info := NetworkInfoData{}
-	ifaceName, ip := getPrimaryIPv4Interface()
-	info.IP = ip
-	if ifaceName != "" {
-		upload, download, ok := getNetworkSpeedByInterface(ifaceName)
-		if ok {
-			info.UploadSpeed = upload
-			info.DownloadSpeed = download
-			return info
-		}
-	}
-
-	return info
-}
-
-func getPrimaryIPv4Interface() (string, string) {
-	interfaces, err := net.Interfaces()
-	if err != nil {
-		return "", ""
-	}
-
-	sort.Slice(interfaces, func(i, j int) bool {
-		return interfaces[i].Index < interfaces[j].Index
-	})
-
-	for _, iface := range interfaces {
-		name := strings.TrimSpace(iface.Name)
-		if name == "" {
-			continue
-		}
-		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
-			continue
-		}
-		addrs, err := iface.Addrs()
-		if err != nil {
-			continue
-		}
-		for _, addr := range addrs {
-			ipnet, ok := addr.(*net.IPNet)
-			if !ok || ipnet == nil || ipnet.IP == nil || ipnet.IP.IsLoopback() {
-				continue
-			}
-			if ip4 := ipnet.IP.To4(); ip4 != nil {
-				return name, ip4.String()
-			}
-		}
-	}
-	return "", ""
-}
-
-func getNetworkSpeedByInterface(interfaceName string) (float64, float64, bool) {
-	snapshots := getNetworkSpeedSnapshots([]string{interfaceName})
-	snapshot, exists := snapshots[interfaceName]
-	if !exists || !snapshot.OK {
-		return 0, 0, false
-	}
-	return snapshot.Upload, snapshot.Download, true
-}
-
-func getNetworkSpeedSnapshots(interfaceNames []string) map[string]networkSpeedSnapshot {
-	result := make(map[string]networkSpeedSnapshot, len(interfaceNames))
-	if len(interfaceNames) == 0 {
-		return result
-	}
-
-	stats, err := gopsutilNet.IOCounters(true)
-	if err != nil {
-		return result
-	}
-
-	currentByName := make(map[string]gopsutilNet.IOCountersStat, len(stats))
-	for _, item := range stats {
-		currentByName[item.Name] = item
-	}
-
-	now := time.Now()
-	networkRateMu.Lock()
-	defer networkRateMu.Unlock()
-
-	for _, interfaceName := range interfaceNames {
-		current, ok := currentByName[interfaceName]
-		if !ok {
-			result[interfaceName] = networkSpeedSnapshot{}
-			continue
-		}
-		previous, hasPrevious := networkRateCache[interfaceName]
-		networkRateCache[interfaceName] = netRateSnapshot{counter: current, at: now}
-
-		if !hasPrevious || previous.at.IsZero() {
-			result[interfaceName] = networkSpeedSnapshot{}
-			continue
-		}
-
-		seconds := now.Sub(previous.at).Seconds()
-		if seconds <= 0 {
-			result[interfaceName] = networkSpeedSnapshot{}
-			continue
-		}
-		if current.BytesSent < previous.counter.BytesSent || current.BytesRecv < previous.counter.BytesRecv {
-			result[interfaceName] = networkSpeedSnapshot{}
-			continue
-		}
-
-		upload := float64(current.BytesSent-previous.counter.BytesSent) / seconds / 1024 / 1024
-		download := float64(current.BytesRecv-previous.counter.BytesRecv) / seconds / 1024 / 1024
-		result[interfaceName] = networkSpeedSnapshot{
-			Upload:   upload,
-			Download: download,
-			OK:       true,
-		}
-	}
-	return result
-}
-
-func findNetworkCounter(stats []gopsutilNet.IOCountersStat, interfaceName string) (gopsutilNet.IOCountersStat, bool) {
-	for _, item := range stats {
-		if item.Name == interfaceName {
-			return item, true
-		}
-	}
-	return gopsutilNet.IOCountersStat{}, false
-}
-
 var diskPartitionSuffixPattern = regexp.MustCompile(`(p?\d+)$`)
 
 func normalizeDiskCounterCandidates(deviceName string) []string {
@@ -260,7 +136,7 @@ func normalizeDiskCounterCandidates(deviceName string) []string {
 func getDiskMetricsSnapshots(deviceNames []string) map[string]diskMetricsSnapshot {
 	result := make(map[string]diskMetricsSnapshot, len(deviceNames))
 	samples := getDiskCounterSamples(deviceNames)
-	now := time.Now()
+	now := sensors.Now()
 	for name, current := range samples {
 		if current.at.IsZero() {
 			current.at = now
@@ -279,7 +155,7 @@ func getDiskMetricsSnapshots(deviceNames []string) map[string]diskMetricsSnapsho
 }
 
 func getDiskTemperatureSnapshots(deviceNames []string) map[string]diskTemperatureSnapshot {
-	return readPlatformDiskTemperatures(deviceNames)
+	return currentPlatform.DiskTemperatures(deviceNames)
 }
 
 func getDiskCounterSamples(deviceNames []string) map[string]diskRateSnapshot {
@@ -288,11 +164,11 @@ func getDiskCounterSamples(deviceNames []string) map[string]diskRateSnapshot {
 		return result
 	}
 
-	stats, err := readPlatformDiskCounters()
+	stats, err := currentPlatform.DiskCounters()
 	if err != nil {
 		return result
 	}
-	now := time.Now()
+	now := sensors.Now()
 	for _, deviceName := range deviceNames {
 		candidates := normalizeDiskCounterCandidates(deviceName)
 		if len(candidates) == 0 {
@@ -321,6 +197,31 @@ func getDiskCounterSamples(deviceNames []string) map[string]diskRateSnapshot {
 	return result
 }
 
+// getTemperatureSensorsByKeywords lists the matching sensors by their
+// gopsutil key, sorted.
+func getTemperatureSensorsByKeywords(keywords []string) []cpuTempSensor {
+	temps, err := host.SensorsTemperatures()
+	if err != nil {
+		return nil
+	}
+	var found []cpuTempSensor
+	for _, stat := range temps {
+		key := strings.TrimSpace(stat.SensorKey)
+		lower := strings.ToLower(key)
+		if key == "" || stat.Temperature <= 0 || stat.Temperature > 130 {
+			continue
+		}
+		for _, keyword := range keywords {
+			if strings.Contains(lower, keyword) {
+				found = append(found, cpuTempSensor{Label: key, Value: stat.Temperature})
+				break
+			}
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Label < found[j].Label })
+	return uniqueCPUTempSensorLabels(found)
+}
+
 func getTemperatureByKeywords(keywords []string) float64 {
 	temps, err := host.SensorsTemperatures()
 	if err != nil {
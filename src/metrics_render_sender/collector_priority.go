@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// collectorPriority decides whether rendering waits for a collector.
+type collectorPriority string
+
+const (
+	// collectorPriorityFrame collectors feed values that move every frame,
+	// such as CPU usage; a render waits up to render_wait_max_ms for them.
+	collectorPriorityFrame collectorPriority = "frame"
+	// collectorPriorityBackground collectors read slow or cached sources:
+	// HTTP backends, helper commands, remote hosts. They run beside the
+	// frame collectors, but no render waits for them, so a slow probe never
+	// holds back the frame. Their values show up on the next frame after
+	// they land.
+	collectorPriorityBackground collectorPriority = "background"
+)
+
+// collectorSchedule is how the epoch scheduler treats one collector.
+type collectorSchedule struct {
+	priority collectorPriority
+	// interval spaces updates further apart than refresh_interval; zero
+	// runs the collector on every epoch.
+	interval time.Duration
+}
+
+func defaultCollectorPriority(name string) collectorPriority {
+	switch strings.TrimSpace(name) {
+	case collectorCoolerControl, collectorLibreHardwareMonitor, collectorBLE, collectorHosts:
+		return collectorPriorityBackground
+	}
+	if isMonitorProviderName(name) {
+		return collectorPriorityBackground
+	}
+	return collectorPriorityFrame
+}
+
+func normalizeCollectorPriority(value string) (collectorPriority, bool) {
+	switch collectorPriority(strings.ToLower(strings.TrimSpace(value))) {
+	case collectorPriorityFrame:
+		return collectorPriorityFrame, true
+	case collectorPriorityBackground:
+		return collectorPriorityBackground, true
+	}
+	return "", false
+}
+
+// GetCollectorSchedule reads the priority and update_interval_ms options of
+// the named collector.
+func (config *MonitorConfig) GetCollectorSchedule(name string) collectorSchedule {
+	schedule := collectorSchedule{priority: defaultCollectorPriority(name)}
+	if raw := config.GetCollectorStringOption(name, "priority", ""); raw != "" {
+		if priority, ok := normalizeCollectorPriority(raw); ok {
+			schedule.priority = priority
+		} else {
+			logWarnModule("collect", "collector %s: unknown priority %q, using %s", name, raw, schedule.priority)
+		}
+	}
+	if ms, err := strconv.Atoi(strings.TrimSpace(config.GetCollectorStringOption(name, "update_interval_ms", ""))); err == nil && ms > 0 {
+		schedule.interval = time.Duration(min(ms, 3_600_000)) * time.Millisecond
+	}
+	return schedule
+}
+
+// dueCollectorsLocked picks the collectors to dispatch this epoch and splits
+// off the frame collectors the epoch waits for.
+func (m *CollectorManager) dueCollectorsLocked(active []namedCollector, now time.Time) (due, frame []namedCollector) {
+	for _, entry := range active {
+		schedule, ok := m.schedules[entry.name]
+		if !ok {
+			schedule = collectorSchedule{priority: defaultCollectorPriority(entry.name)}
+		}
+		if last, ran := m.lastDispatch[entry.name]; ran && schedule.interval > 0 && now.Sub(last) < schedule.interval {
+			continue
+		}
+		m.lastDispatch[entry.name] = now
+		due = append(due, entry)
+		if schedule.priority != collectorPriorityBackground {
+			frame = append(frame, entry)
+		}
+	}
+	return due, frame
+}
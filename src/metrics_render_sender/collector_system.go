@@ -32,6 +32,11 @@ func (c *GoNativeSystemCollector) ensureStaticItems() {
 	c.setItem("go_native.system.render.avg_ms", NewCollectItem("go_native.system.render.avg_ms", "Render avg duration", "ms", 0, 0, 0))
 	c.setItem("go_native.system.output.max_ms", NewCollectItem("go_native.system.output.max_ms", "Output max duration", "ms", 0, 0, 0))
 	c.setItem("go_native.system.output.avg_ms", NewCollectItem("go_native.system.output.avg_ms", "Output avg duration", "ms", 0, 0, 0))
+	c.setItem("go_native.system.frames.rendered", NewCollectItem("go_native.system.frames.rendered", "Frames rendered", "", 0, 0, 0))
+	c.setItem("go_native.system.frames.shipped", NewCollectItem("go_native.system.frames.shipped", "Frames shipped", "", 0, 0, 0))
+	c.setItem("go_native.system.frames.skipped", NewCollectItem("go_native.system.frames.skipped", "Frames skipped", "", 0, 0, 0))
+	c.setItem("go_native.system.status", NewCollectItem("go_native.system.status", "Last subsystem error", "", 0, 0, 0))
+	c.setItem("go_native.system.error_count", NewCollectItem("go_native.system.error_count", "Failing subsystems", "", 0, 0, 0))
 	c.setItem("go_native.cpu.min_freq", NewCollectItem("go_native.cpu.min_freq", "CPU min frequency", "MHz", 0, 0, 0))
 	c.setItem("go_native.disk.total_read", NewCollectItem("go_native.disk.total_read", "Disk total read speed", "MiB/s", 0, 0, 2))
 	c.setItem("go_native.disk.total_write", NewCollectItem("go_native.disk.total_write", "Disk total write speed", "MiB/s", 0, 0, 2))
@@ -42,6 +47,13 @@ func (c *GoNativeSystemCollector) ensureStaticItems() {
 	ensureOutputMetricItems(c, outputTypeAX206USB, "AX206 refresh")
 	ensureOutputMetricItems(c, outputTypeHTTPPush, "HTTP push")
 	ensureOutputMetricItems(c, outputTypeTCPPush, "TCP push")
+	ensureOutputMetricItems(c, outputTypeFile, "File output")
+	ensureOutputMetricItems(c, outputTypeHTTP, "HTTP stream")
+	ensureOutputMetricItems(c, outputTypeSSD1306, "SSD1306 panel")
+	ensureOutputMetricItems(c, outputTypeST7789, "ST7789 panel")
+	ensureOutputMetricItems(c, outputTypeTuring, "Turing screen")
+	ensureOutputMetricItems(c, outputTypeFramebuffer, "Framebuffer")
+	ensureOutputMetricItems(c, outputTypeMQTT, "MQTT")
 }
 
 func (c *GoNativeSystemCollector) ApplyConfig(cfg *MonitorConfig) {
@@ -73,6 +85,7 @@ func (c *GoNativeSystemCollector) GetAllItems() map[string]*CollectItem {
 		}
 	}
 	updateSystemDisplayItems(c)
+	updateSystemStatusItems(c)
 	return c.ItemsSnapshot()
 }
 
@@ -97,6 +110,7 @@ func (c *GoNativeSystemCollector) UpdateItems() error {
 		item.SetAvailable(true)
 	}
 	updateSystemDisplayItems(c)
+	updateSystemStatusItems(c)
 
 	if manager := CurrentCollectorManager(); manager != nil {
 		updateAggregateMonitorItems(c, manager.GetAll())
@@ -107,6 +121,9 @@ func (c *GoNativeSystemCollector) UpdateItems() error {
 		setSystemMetricItem(c.getItem("go_native.system.render.avg_ms"), stats.RenderAvgMS)
 		setSystemMetricItem(c.getItem("go_native.system.output.max_ms"), stats.OutputMaxMS)
 		setSystemMetricItem(c.getItem("go_native.system.output.avg_ms"), stats.OutputAvgMS)
+		setSystemMetricItem(c.getItem("go_native.system.frames.rendered"), stats.FramesRendered)
+		setSystemMetricItem(c.getItem("go_native.system.frames.shipped"), stats.FramesShipped)
+		setSystemMetricItem(c.getItem("go_native.system.frames.skipped"), stats.FramesSkipped)
 
 		for typeName := range stats.OutputStats {
 			setOutputTypeMetric(c, typeName, stats.OutputStats)
@@ -421,6 +438,23 @@ func setOutputMetricValues(c *GoNativeSystemCollector, typeName string, calls, l
 	}
 }
 
+// updateSystemStatusItems shows the most recent subsystem error, such as an
+// unreachable sensor backend or a disconnected panel, or "OK".
+func updateSystemStatusItems(c *GoNativeSystemCollector) {
+	if c == nil {
+		return
+	}
+	status, count := currentSubsystemStatus(time.Now())
+	if status == "" {
+		status = "OK"
+	}
+	if item := c.getItem("go_native.system.status"); item != nil {
+		item.SetValue(status)
+		item.SetAvailable(true)
+	}
+	setSystemMetricItem(c.getItem("go_native.system.error_count"), int64(count))
+}
+
 func updateSystemDisplayItems(c *GoNativeSystemCollector) {
 	if c == nil {
 		return
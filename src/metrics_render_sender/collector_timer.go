@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// TimerCollector publishes the shared pomodoro timer as monitors:
+// timer.remaining (seconds), timer.remaining_text (m:ss), timer.progress
+// (elapsed share of the phase), timer.phase (work, short_break or
+// long_break), timer.state (stopped, running or paused) and
+// timer.completed (work sessions since the last reset). The timer itself
+// is driven by the timer_* display control actions.
+type TimerCollector struct {
+	*BaseCollector
+}
+
+func NewTimerCollector() *TimerCollector {
+	return &TimerCollector{BaseCollector: NewBaseCollector(collectorTimer)}
+}
+
+func (c *TimerCollector) ApplyConfig(cfg *MonitorConfig) {
+	enabled := cfg != nil && cfg.IsCollectorEnabled(collectorTimer, true)
+	c.SetEnabled(enabled)
+	if !enabled {
+		c.clearItems()
+		return
+	}
+	sharedPomodoroTimer.configure(pomodoroSettingsFromConfig(cfg))
+	_ = c.GetAllItems()
+}
+
+func (c *TimerCollector) GetAllItems() map[string]*CollectItem {
+	if c.IsEnabled() && c.getItem("timer.remaining") == nil {
+		c.setItem("timer.remaining", NewCollectItem("timer.remaining", "Timer remaining", "s", 0, 0, 0))
+		c.setItem("timer.remaining_text", NewCollectItem("timer.remaining_text", "Timer remaining", "", 0, 0, 0))
+		c.setItem("timer.progress", NewCollectItem("timer.progress", "Timer progress", "%", 0, 100, 0))
+		c.setItem("timer.phase", NewCollectItem("timer.phase", "Timer phase", "", 0, 0, 0))
+		c.setItem("timer.state", NewCollectItem("timer.state", "Timer state", "", 0, 0, 0))
+		c.setItem("timer.completed", NewCollectItem("timer.completed", "Timer sessions", "", 0, 0, 0))
+	}
+	return c.ItemsSnapshot()
+}
+
+func (c *TimerCollector) UpdateItems() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	status := sharedPomodoroTimer.status(time.Now())
+	c.setTimerValue("timer.remaining", math.Max(0, math.Ceil(status.Remaining.Seconds())))
+	c.setTimerValue("timer.remaining_text", formatPomodoroRemaining(status.Remaining))
+	c.setTimerValue("timer.progress", status.Progress())
+	c.setTimerValue("timer.phase", status.Phase)
+	c.setTimerValue("timer.state", status.State)
+	c.setTimerValue("timer.completed", float64(status.Completed))
+	return nil
+}
+
+func (c *TimerCollector) setTimerValue(name string, value interface{}) {
+	if item := c.getItem(name); item != nil {
+		item.SetValue(value)
+		item.SetAvailable(true)
+	}
+}
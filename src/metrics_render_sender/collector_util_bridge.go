@@ -7,12 +7,10 @@ import (
 
 type CachedSensorPath = monitorutil.CachedSensorPath
 type MonitorDataCache = monitorutil.MonitorDataCache
-type NetworkInfoData = monitorutil.NetworkInfoData
 
 var (
-	CPUSensorPatterns  = monitorutil.CPUSensorPatterns
-	GPUSensorPatterns  = monitorutil.GPUSensorPatterns
-	DiskSensorPatterns = monitorutil.DiskSensorPatterns
+	CPUSensorPatterns = monitorutil.CPUSensorPatterns
+	GPUSensorPatterns = monitorutil.GPUSensorPatterns
 )
 
 const (
@@ -43,7 +41,3 @@ func readSysFile(path string) (string, error) {
 func readSysFileInt(path string) (int, error) {
 	return monitorutil.ReadSysFileInt(path)
 }
-
-func findHwmonSensor(namePatterns []string, tempFile string) (string, float64, error) {
-	return monitorutil.FindHwmonSensor(namePatterns, tempFile)
-}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"math"
+	"strings"
+)
+
+// ValueKind says what a monitor value measures. Collectors set it through
+// the unit they register; formatting, scaling, rate smoothing and default
+// ranges all key off the kind instead of matching monitor names.
+type ValueKind string
+
+const (
+	ValueKindText        ValueKind = "text"
+	ValueKindCount       ValueKind = "count"
+	ValueKindNumber      ValueKind = "number"
+	ValueKindPercent     ValueKind = "percent"
+	ValueKindTemperature ValueKind = "temperature"
+	ValueKindBytes       ValueKind = "bytes"
+	ValueKindByteRate    ValueKind = "bytes_per_sec"
+	ValueKindFrequency   ValueKind = "frequency"
+	ValueKindRPM         ValueKind = "rpm"
+	ValueKindPower       ValueKind = "power"
+	ValueKindDuration    ValueKind = "duration"
+)
+
+// valueKindForUnit classifies a unit string. Unitless numbers are counts.
+func valueKindForUnit(unit string) ValueKind {
+	trimmed := strings.ToLower(strings.TrimSpace(unit))
+	switch trimmed {
+	case "":
+		return ValueKindCount
+	case "%":
+		return ValueKindPercent
+	case "°c", "°f", "℃", "℉":
+		return ValueKindTemperature
+	case "rpm":
+		return ValueKindRPM
+	case "w", "mw", "kw":
+		return ValueKindPower
+	case "ns", "us", "µs", "ms", "s":
+		return ValueKindDuration
+	}
+	if family, _ := lookupUnitFamily(trimmed); family != nil {
+		return family.kind
+	}
+	return ValueKindNumber
+}
+
+// Kind returns the explicit kind, or derives it from the value and unit.
+// A string value is always text.
+func (v *CollectValue) Kind() ValueKind {
+	if v == nil {
+		return ValueKindText
+	}
+	if _, ok := v.Value.(string); ok {
+		return ValueKindText
+	}
+	if v.ValueKind != "" {
+		return v.ValueKind
+	}
+	return valueKindForUnit(v.Unit)
+}
+
+// Float64 returns the value as a number. Text values are not parsed.
+func (v *CollectValue) Float64() (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return numericValue(v.Value)
+}
+
+// valueKindDefaults returns the precision and maximum a collector should use
+// for a kind when the source does not say.
+func valueKindDefaults(kind ValueKind) (int, float64) {
+	switch kind {
+	case ValueKindTemperature:
+		return 1, 120
+	case ValueKindPercent:
+		return 0, 100
+	case ValueKindRPM, ValueKindFrequency:
+		return 0, 0
+	case ValueKindPower:
+		return 1, 0
+	default:
+		return 2, 0
+	}
+}
+
+// convertUnitValue converts value from one unit to another of the same
+// kind, e.g. MiB/s to KiB/s or °C to °F. ok is false when the units are not
+// convertible, in which case the target is only a label.
+func convertUnitValue(value float64, from, to string) (float64, bool) {
+	fromUnit := strings.ToLower(strings.TrimSpace(from))
+	toUnit := strings.ToLower(strings.TrimSpace(to))
+	if fromUnit == "" || toUnit == "" {
+		return value, false
+	}
+	if fromUnit == toUnit {
+		return value, true
+	}
+	switch {
+	case isCelsiusUnit(fromUnit) && isFahrenheitUnit(toUnit):
+		return value*9/5 + 32, true
+	case isFahrenheitUnit(fromUnit) && isCelsiusUnit(toUnit):
+		return (value - 32) * 5 / 9, true
+	}
+	fromFamily, fromIndex := lookupUnitFamily(fromUnit)
+	toFamily, toIndex := lookupUnitFamily(toUnit)
+	if fromFamily == nil || toFamily == nil || fromFamily.kind != toFamily.kind {
+		return value, false
+	}
+	return value * math.Pow(fromFamily.factor, float64(fromIndex-toIndex)), true
+}
+
+// unitFamily is a ladder of units, each factor times the one before,
+// starting from the base unit.
+type unitFamily struct {
+	kind   ValueKind
+	units  []string
+	factor float64
+}
+
+// unitFamilies is searched in order, so a bare "B" belongs to the decimal
+// prefixed family.
+var unitFamilies = []*unitFamily{
+	{kind: ValueKindBytes, units: []string{"B", "KB", "MB", "GB", "TB"}, factor: 1024},
+	{kind: ValueKindByteRate, units: []string{"B/s", "KB/s", "MB/s", "GB/s", "TB/s"}, factor: 1024},
+	{kind: ValueKindBytes, units: []string{"B", "KiB", "MiB", "GiB", "TiB"}, factor: 1024},
+	{kind: ValueKindByteRate, units: []string{"B/s", "KiB/s", "MiB/s", "GiB/s", "TiB/s"}, factor: 1024},
+	{kind: ValueKindFrequency, units: []string{"Hz", "KHz", "MHz", "GHz", "THz"}, factor: 1000},
+	{kind: ValueKindPower, units: []string{"mW", "W", "kW"}, factor: 1000},
+}
+
+// autoScaleStepUp is where a value moves to the next larger unit, so scaled
+// numbers never need more than three integer digits: 1000 KiB/s is shown as
+// 0.98 MiB/s rather than 1000 KiB/s.
+const autoScaleStepUp = 1000.0
+
+func lookupUnitFamily(unit string) (*unitFamily, int) {
+	trimmed := strings.ToLower(strings.TrimSpace(unit))
+	if trimmed == "" {
+		return nil, -1
+	}
+	for _, family := range unitFamilies {
+		for idx, name := range family.units {
+			if strings.ToLower(name) == trimmed {
+				return family, idx
+			}
+		}
+	}
+	return nil, -1
+}
+
+// autoScaleUnitValue moves a value along its unit family until it reads
+// between roughly one and autoScaleStepUp. Units outside any family are
+// returned unchanged. A leading space in unit is kept.
+func autoScaleUnitValue(value float64, unit string, precision int) (float64, string, int) {
+	family, index := lookupUnitFamily(unit)
+	if family == nil {
+		return value, unit, precision
+	}
+	stepDown := autoScaleStepUp / family.factor
+	scaled := value
+	absValue := math.Abs(value)
+	if absValue > 0 {
+		for absValue >= autoScaleStepUp && index < len(family.units)-1 {
+			scaled /= family.factor
+			index++
+			absValue = math.Abs(scaled)
+		}
+		for absValue < stepDown && index > 0 {
+			scaled *= family.factor
+			index--
+			absValue = math.Abs(scaled)
+		}
+	}
+	scaledUnit := family.units[index]
+	if strings.HasPrefix(unit, " ") {
+		scaledUnit = " " + scaledUnit
+	}
+	return scaled, scaledUnit, autoScalePrecision(scaled, precision, scaledUnit != strings.TrimSpace(unit))
+}
+
+func autoScalePrecision(value float64, defaultPrecision int, scaled bool) int {
+	precision := max(0, defaultPrecision)
+	if !scaled {
+		return precision
+	}
+	absValue := math.Abs(value)
+	switch {
+	case absValue >= 100:
+		return 0
+	case absValue >= 10:
+		return max(1, min(precision, 1))
+	case absValue >= 1:
+		return max(1, min(precision, 2))
+	default:
+		return max(2, precision)
+	}
+}
+
+func isCelsiusUnit(unit string) bool {
+	return unit == "°c" || unit == "℃"
+}
+
+func isFahrenheitUnit(unit string) bool {
+	return unit == "°f" || unit == "℉"
+}
+
+// numericValue converts any Go number to float64.
+func numericValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestValueKindForUnit(t *testing.T) {
+	cases := map[string]ValueKind{
+		"":       ValueKindCount,
+		"%":      ValueKindPercent,
+		"°C":     ValueKindTemperature,
+		" MiB/s": ValueKindByteRate,
+		"B/s":    ValueKindByteRate,
+		"GB":     ValueKindBytes,
+		"MHz":    ValueKindFrequency,
+		"RPM":    ValueKindRPM,
+		"W":      ValueKindPower,
+		"ms":     ValueKindDuration,
+		"IOPS":   ValueKindNumber,
+	}
+	for unit, want := range cases {
+		if got := valueKindForUnit(unit); got != want {
+			t.Errorf("valueKindForUnit(%q) = %q, want %q", unit, got, want)
+		}
+	}
+}
+
+func TestCollectValueKindAndFloat(t *testing.T) {
+	item := NewCollectItem("go_native.net.download", "Download", "MiB/s", 0, 0, 2)
+	if item.GetValue().Kind() != ValueKindByteRate {
+		t.Fatalf("expected the unit to set the kind, got %q", item.GetValue().Kind())
+	}
+	item.SetValue(uint32(3))
+	if number, ok := item.GetValue().Float64(); !ok || number != 3 {
+		t.Fatalf("expected any Go number to convert, got %v %v", number, ok)
+	}
+	item.SetValue("eth0")
+	if item.GetValue().Kind() != ValueKindText {
+		t.Fatal("expected a string value to be text")
+	}
+	if _, ok := item.GetValue().Float64(); ok {
+		t.Fatal("expected text not to parse as a number")
+	}
+	item.SetUnit("°C")
+	item.SetValue(40.0)
+	if item.GetValue().Kind() != ValueKindTemperature {
+		t.Fatalf("expected SetUnit to update the kind, got %q", item.GetValue().Kind())
+	}
+}
+
+func TestConvertUnitValue(t *testing.T) {
+	cases := []struct {
+		value    float64
+		from, to string
+		want     float64
+		ok       bool
+	}{
+		{2, "MiB/s", "KiB/s", 2048, true},
+		{1536, "KB/s", "MB/s", 1.5, true},
+		{3, "GHz", "MHz", 3000, true},
+		{1500, "mW", "W", 1.5, true},
+		{2, "kW", "W", 2000, true},
+		{100, "°C", "°F", 212, true},
+		{212, "°F", "°C", 100, true},
+		{5, "MiB/s", "GB", 5, false},
+		{5, "%", "pct", 5, false},
+	}
+	for _, tc := range cases {
+		got, ok := convertUnitValue(tc.value, tc.from, tc.to)
+		if ok != tc.ok || math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("convertUnitValue(%v, %q, %q) = %v %v, want %v %v", tc.value, tc.from, tc.to, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestFormatCollectValueConvertsUnitOverride(t *testing.T) {
+	value := &CollectValue{Value: 2.0, Unit: "MiB/s", Precision: 0}
+	if got := FormatCollectValue(value, true, "KiB/s"); got != "2048KiB/s" {
+		t.Fatalf("expected the override to convert, got %q", got)
+	}
+	if got := FormatCollectValue(value, true, "MB"); got != "2MB" {
+		t.Fatalf("expected an unrelated override to only relabel, got %q", got)
+	}
+	if got := FormatCollectValue(&CollectValue{Value: int32(7), Unit: ""}, true, ""); got != "7" {
+		t.Fatalf("expected int32 to format as a number, got %q", got)
+	}
+}
+
+func TestAutoScaleUnitValueKeepsThreeIntegerDigits(t *testing.T) {
+	cases := []struct {
+		value     float64
+		unit      string
+		want      float64
+		wantUnit  string
+		precision int
+	}{
+		{999, "KiB/s", 999, "KiB/s", 2},
+		{1000, "KiB/s", 1000.0 / 1024, "MiB/s", 2},
+		{0.5, "MiB/s", 512, "KiB/s", 0},
+		{0.99, "MiB/s", 0.99, "MiB/s", 2},
+		{1500, "MHz", 1.5, "GHz", 2},
+		{3, " MiB/s", 3, " MiB/s", 2},
+		{2048, "B", 2, "KB", 2},
+		{42, "IOPS", 42, "IOPS", 2},
+	}
+	for _, tc := range cases {
+		got, unit, precision := autoScaleUnitValue(tc.value, tc.unit, 2)
+		if math.Abs(got-tc.want) > 1e-9 || unit != tc.wantUnit || precision != tc.precision {
+			t.Errorf("autoScaleUnitValue(%v, %q) = %v %q %d, want %v %q %d", tc.value, tc.unit, got, unit, precision, tc.want, tc.wantUnit, tc.precision)
+		}
+	}
+}
+
+func TestCollectItemReadyAfterFirstValidSample(t *testing.T) {
+	item := NewCollectItem("go_native.cpu.temp", "CPU temperature", "°C", 0, 120, 0)
+	item.SetAvailable(true)
+	if item.IsReady() {
+		t.Fatal("expected a new item to wait for its first sample")
+	}
+	item.SetValue(math.NaN())
+	if item.IsReady() {
+		t.Fatal("expected a NaN reading not to count as a sample")
+	}
+	item.SetValue(42.0)
+	if _, available, ready, _ := item.SnapshotState(); !available || !ready {
+		t.Fatalf("expected the item ready after a valid sample, got available=%v ready=%v", available, ready)
+	}
+	item.SetValue(nil)
+	if !item.IsReady() {
+		t.Fatal("expected the item to stay ready once it had a sample")
+	}
+}
@@ -1,12 +1,9 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"runtime"
-	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -14,6 +11,10 @@ import (
 const (
 	defaultCoolerControlURL        = "http://127.0.0.1:11987"
 	defaultLibreHardwareMonitorURL = "http://127.0.0.1:8085"
+	maxOutputFPS                   = 60
+	// defaultCollectTimeoutMS matches the HTTP client timeout the sensor
+	// backends used before updates could be cancelled.
+	defaultCollectTimeoutMS = 5000
 )
 
 type CustomMonitorConfig struct {
@@ -25,7 +26,8 @@ type CustomMonitorConfig struct {
 	Min       *float64 `json:"min,omitempty"`
 	Max       *float64 `json:"max,omitempty"`
 
-	// File monitor
+	// File monitor; message monitors reuse Path for the text file or FIFO and
+	// serial monitors for the port, e.g. /dev/ttyUSB0 or COM3.
 	Path   string   `json:"path,omitempty"`
 	Scale  *float64 `json:"scale,omitempty"`
 	Offset float64  `json:"offset,omitempty"`
@@ -34,6 +36,35 @@ type CustomMonitorConfig struct {
 	Sources   []string `json:"sources,omitempty"`
 	Aggregate string   `json:"aggregate,omitempty"`
 
+	// Serial monitor reads lines from Path; Pattern picks the value out of
+	// each line, using its first capture group when present.
+	Baud    int    `json:"baud,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+
+	// DNS and HTTP checks probe Target, a host name or URL, every IntervalMS.
+	// DNS checks ask Server (host or host:port) instead of the system
+	// resolver when it is set.
+	Target     string `json:"target,omitempty"`
+	IntervalMS int    `json:"interval_ms,omitempty"`
+	TimeoutMS  int    `json:"timeout_ms,omitempty"`
+	Server     string `json:"server,omitempty"`
+
+	// SSH monitors run Command on Target (user@host, ssh://user@host:port or
+	// a ~/.ssh/config alias), logging in with the private key file Key.
+	// Pattern picks the value out of the output as for serial monitors.
+	Command string `json:"command,omitempty"`
+	Key     string `json:"key,omitempty"`
+
+	// Prometheus monitors scrape Target, a /metrics URL, and show the series
+	// Query selects: a metric name with label matchers, optionally inside
+	// sum, avg, min, max or count.
+	Query string `json:"query,omitempty"`
+
+	// Energy monitors add up the power monitors in Sources and estimate
+	// energy and cost per day at Price per kWh, shown in Currency.
+	Price    float64 `json:"price,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+
 	// CoolerControl monitor
 	Source string `json:"source,omitempty"`
 
@@ -55,18 +86,142 @@ type ThresholdRangeConfig struct {
 	Min   *float64 `json:"min,omitempty"`
 	Max   *float64 `json:"max,omitempty"`
 	Color string   `json:"color,omitempty"`
+	Label string   `json:"label,omitempty"`
 }
 
 type ThresholdGroupConfig struct {
-	Name     string                 `json:"name"`
-	Monitors []string               `json:"monitors,omitempty"`
-	Ranges   []ThresholdRangeConfig `json:"ranges,omitempty"`
+	Name       string                 `json:"name"`
+	Monitors   []string               `json:"monitors,omitempty"`
+	Ranges     []ThresholdRangeConfig `json:"ranges,omitempty"`
+	Hysteresis float64                `json:"hysteresis,omitempty"`
+	Direction  string                 `json:"direction,omitempty"`
+	// Unit is what the range bounds are written in, e.g. "B/s". Values of
+	// the same kind are converted to it, so one group can hold monitors that
+	// report in KiB/s and MiB/s. Empty compares each monitor's own number.
+	Unit string `json:"unit,omitempty"`
+}
+
+// GPIOConfig wires optional buttons and an alert LED on a Linux GPIO chip,
+// e.g. for a stand-alone Raspberry Pi build.
+type GPIOConfig struct {
+	Chip     string             `json:"chip,omitempty"`
+	Buttons  []GPIOButtonConfig `json:"buttons,omitempty"`
+	AlertLED *GPIOLEDConfig     `json:"alert_led,omitempty"`
+}
+
+type GPIOButtonConfig struct {
+	Line       int    `json:"line"`
+	Action     string `json:"action"`
+	ActiveHigh bool   `json:"active_high,omitempty"`
+}
+
+type GPIOLEDConfig struct {
+	Line      int  `json:"line"`
+	ActiveLow bool `json:"active_low,omitempty"`
+}
+
+// OpenRGBConfig drives keyboard, strip and motherboard lighting through an
+// OpenRGB SDK server. Colors follow Monitor's threshold group, the same
+// color the display uses, and switch to AlertColor while any group is in its
+// worst zone.
+type OpenRGBConfig struct {
+	Address    string   `json:"address,omitempty"`
+	Devices    []string `json:"devices,omitempty"`
+	Monitor    string   `json:"monitor,omitempty"`
+	IdleColor  string   `json:"idle_color,omitempty"`
+	AlertColor string   `json:"alert_color,omitempty"`
+}
+
+// SummaryConfig schedules a stats summary page: the highest temperatures,
+// the average load and the network traffic since the previous summary,
+// shown in place of the layout for a while and optionally archived as PNG.
+//
+// Schedule is "daily" (default) or "weekly", At the local time as HH:MM
+// and Weekday the day of a weekly summary. ArchiveDir is resolved against
+// the config directory when relative.
+type SummaryConfig struct {
+	Schedule    string   `json:"schedule,omitempty"`
+	At          string   `json:"at,omitempty"`
+	Weekday     string   `json:"weekday,omitempty"`
+	DurationSec int      `json:"duration_sec,omitempty"`
+	Temps       []string `json:"temps,omitempty"`
+	Load        string   `json:"load,omitempty"`
+	ArchiveDir  string   `json:"archive_dir,omitempty"`
+}
+
+// SplashConfig sets the screens around the layout: a splash with the host
+// name, version and IP address while the sensors warm up, and what a
+// graceful shutdown leaves on the panel.
+//
+// DurationSec is how long the splash stays up, 3 by default and negative
+// to skip it. Shutdown is "message" (default) for ShutdownText on black,
+// "blank" for a black frame with the AX206 backlight off, or "none" to keep
+// the last frame.
+type SplashConfig struct {
+	Title        string `json:"title,omitempty"`
+	DurationSec  int    `json:"duration_sec,omitempty"`
+	Shutdown     string `json:"shutdown,omitempty"`
+	ShutdownText string `json:"shutdown_text,omitempty"`
+}
+
+// QuietHoursConfig mutes alerts from Start to End, local HH:MM times that
+// may span midnight, on the listed Days or every day when none are listed.
+// Equal times cover the whole day.
+type QuietHoursConfig struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days,omitempty"`
+}
+
+// StaleConfig draws monitors that went unavailable as Placeholder ("N/A"
+// by default) instead of leaving their items blank, and styles numeric
+// values that have not updated for MaxAgeSec, or by stale_after_cycles
+// when it is 0, as stale. Style is "dim" (default), "strike" or "none" for
+// the placeholder alone.
+type StaleConfig struct {
+	Placeholder string  `json:"placeholder,omitempty"`
+	Style       string  `json:"style,omitempty"`
+	MaxAgeSec   float64 `json:"max_age_sec,omitempty"`
+}
+
+// ProcessConfig lowers the monitor's own scheduling priority so it does not
+// add to the load it displays.
+//
+// Nice (-20 to 19) and IOClass ("idle" or "best-effort") apply on Linux,
+// PriorityClass ("idle" or "below_normal") on Windows. Cores pins the
+// process to a CPU list such as "0-3,6", or to the efficiency cores of a
+// hybrid CPU with "efficiency".
+type ProcessConfig struct {
+	Nice          int    `json:"nice,omitempty"`
+	IOClass       string `json:"io_class,omitempty"`
+	PriorityClass string `json:"priority_class,omitempty"`
+	Cores         string `json:"cores,omitempty"`
+}
+
+// HostConfig adds a machine to the multi-host dashboard. Each host reports
+// hosts.<name>.cpu and .ram (usage in %), .temp (CPU temperature) and .up.
+//
+// Type is "local" for this machine, "ssh" for a Linux host reached with the
+// system ssh client (Target is the destination, Key an optional identity
+// file), "agent" for another ax206monitor whose web UI is at Target, or
+// "librehardwaremonitor" for a LibreHardwareMonitor web server at Target.
+type HostConfig struct {
+	Name       string `json:"name"`
+	Label      string `json:"label,omitempty"`
+	Type       string `json:"type"`
+	Target     string `json:"target,omitempty"`
+	Key        string `json:"key,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	IntervalMS int    `json:"interval_ms,omitempty"`
 }
 
 type MonitorConfig struct {
+	SchemaVersion           int                         `json:"schema_version,omitempty"`
 	Name                    string                      `json:"name"`
 	Width                   int                         `json:"width"`
 	Height                  int                         `json:"height"`
+	AutoResolution          bool                        `json:"auto_resolution,omitempty"`
 	LayoutPadding           int                         `json:"layout_padding,omitempty"`
 	MonitorUpdateWorkers    int                         `json:"monitor_update_workers,omitempty"`
 	MonitorUpdateQueueSize  int                         `json:"monitor_update_queue_size,omitempty"`
@@ -77,8 +232,13 @@ type MonitorConfig struct {
 	Outputs                 []OutputConfig              `json:"outputs"`
 	OutputTypes             []string                    `json:"output_types"`
 	RefreshInterval         int                         `json:"refresh_interval"`
+	MaxFPS                  int                         `json:"max_fps,omitempty"`
+	LowPower                bool                        `json:"low_power,omitempty"`
 	CollectWarnMS           int                         `json:"collect_warn_ms,omitempty"`
+	CollectTimeoutMS        int                         `json:"collect_timeout_ms,omitempty"`
+	StaleAfterCycles        int                         `json:"stale_after_cycles,omitempty"`
 	RenderWaitMaxMS         int                         `json:"render_wait_max_ms,omitempty"`
+	Scale                   int                         `json:"scale,omitempty"`
 	HistorySize             int                         `json:"history_size,omitempty"`
 	DefaultHistoryPoints    int                         `json:"default_history_points,omitempty"`
 	NetworkInterface        string                      `json:"network_interface,omitempty"`
@@ -90,9 +250,28 @@ type MonitorConfig struct {
 	TypeDefaults            map[string]ItemTypeDefaults `json:"type_defaults,omitempty"`
 	ThresholdGroups         []ThresholdGroupConfig      `json:"threshold_groups,omitempty"`
 	CustomMonitors          []CustomMonitorConfig       `json:"custom_monitors,omitempty"`
+	Hosts                   []HostConfig                `json:"hosts,omitempty"`
+	GPIO                    *GPIOConfig                 `json:"gpio,omitempty"`
+	OpenRGB                 *OpenRGBConfig              `json:"openrgb,omitempty"`
+	Summary                 *SummaryConfig              `json:"summary,omitempty"`
+	QuietHours              *QuietHoursConfig           `json:"quiet_hours,omitempty"`
+	Splash                  *SplashConfig               `json:"splash,omitempty"`
+	Stale                   *StaleConfig                `json:"stale,omitempty"`
+	Process                 *ProcessConfig              `json:"process,omitempty"`
+	Groups                  []ItemGroupConfig           `json:"groups,omitempty"`
+	Templates               map[string]WidgetTemplate   `json:"templates,omitempty"`
+	Widgets                 []WidgetConfig              `json:"widgets,omitempty"`
 	Items                   []ItemConfig                `json:"items"`
 }
 
+// ItemGroupConfig names a set of items that share a position offset, so a
+// widget cluster can be moved as a unit.
+type ItemGroupConfig struct {
+	Name string `json:"name"`
+	X    int    `json:"x,omitempty"`
+	Y    int    `json:"y,omitempty"`
+}
+
 type ItemConfig struct {
 	ID             string                 `json:"id,omitempty"`
 	Type           string                 `json:"type"`
@@ -106,67 +285,14 @@ type ItemConfig struct {
 	Y              int                    `json:"y"`
 	Width          int                    `json:"width"`
 	Height         int                    `json:"height"`
+	Z              int                    `json:"z,omitempty"`
+	Group          string                 `json:"group,omitempty"`
 	Text           string                 `json:"text,omitempty"`
 	Style          map[string]interface{} `json:"style,omitempty"`
 	RenderAttrsMap map[string]interface{} `json:"render_attrs_map,omitempty"`
 	runtime        renderItemRuntime
 }
 
-type ConfigManager struct {
-	configDir string
-	configs   map[string]*MonitorConfig
-}
-
-func NewConfigManager(configDir string) *ConfigManager {
-	return &ConfigManager{
-		configDir: configDir,
-		configs:   make(map[string]*MonitorConfig),
-	}
-}
-
-func (cm *ConfigManager) LoadConfig(configName string) (*MonitorConfig, error) {
-	if config, exists := cm.configs[configName]; exists {
-		return config, nil
-	}
-
-	configFile := filepath.Join(cm.configDir, configName+".json")
-
-	if _, err := os.Stat(configFile); os.IsNotExist(err) {
-		return nil, fmt.Errorf("config file not found: %s", configFile)
-	}
-
-	data, err := os.ReadFile(configFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %v", err)
-	}
-
-	var config MonitorConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %v", err)
-	}
-	normalizeMonitorConfig(&config)
-
-	cm.configs[configName] = &config
-	return &config, nil
-}
-
-func (cm *ConfigManager) ListConfigs() ([]string, error) {
-	files, err := os.ReadDir(cm.configDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config directory: %v", err)
-	}
-
-	configs := make([]string, 0, len(files))
-	for _, file := range files {
-		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
-			continue
-		}
-		configs = append(configs, file.Name()[:len(file.Name())-5])
-	}
-	sort.Strings(configs)
-	return configs, nil
-}
-
 func getDefaultFontFamilies() []string {
 	switch runtime.GOOS {
 	case "windows":
@@ -315,6 +441,24 @@ func (config *MonitorConfig) GetNetworkInterface() string {
 	return strings.TrimSpace(config.NetworkInterface)
 }
 
+// GetNetworkIPFamily is which address the network ip monitors show: "v4"
+// and "v6" prefer that family and fall back to the other, "both" shows
+// both.
+func (config *MonitorConfig) GetNetworkIPFamily() string {
+	return normalizeNetworkIPFamily(config.GetCollectorStringOption(collectorGoNativeNetwork, "ip_family", ""))
+}
+
+func normalizeNetworkIPFamily(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case networkIPFamilyV6, "ipv6", "6":
+		return networkIPFamilyV6
+	case networkIPFamilyBoth, "all", "dual":
+		return networkIPFamilyBoth
+	default:
+		return networkIPFamilyV4
+	}
+}
+
 func (config *MonitorConfig) IsRTSSCollectEnabled() bool {
 	if runtime.GOOS != "windows" {
 		return false
@@ -336,9 +480,28 @@ func (config *MonitorConfig) GetCollectTickDuration() time.Duration {
 	if intervalMS > 10_000 {
 		intervalMS = 10_000
 	}
+	if config.LowPower && intervalMS < lowPowerMinRefreshMS {
+		intervalMS = lowPowerMinRefreshMS
+	}
 	return time.Duration(intervalMS) * time.Millisecond
 }
 
+// GetStaleAfterCycles returns how many of its refresh cycles a monitor may
+// go without an update before it counts as stale.
+func (config *MonitorConfig) GetStaleAfterCycles() int {
+	cycles := config.StaleAfterCycles
+	if cycles <= 0 {
+		cycles = defaultStaleAfterCycles
+	}
+	if cycles < 2 {
+		cycles = 2
+	}
+	if cycles > 1000 {
+		cycles = 1000
+	}
+	return cycles
+}
+
 func (config *MonitorConfig) GetCollectWarnDuration() time.Duration {
 	warnMS := config.CollectWarnMS
 	if warnMS <= 0 {
@@ -353,6 +516,26 @@ func (config *MonitorConfig) GetCollectWarnDuration() time.Duration {
 	return time.Duration(warnMS) * time.Millisecond
 }
 
+// GetCollectTimeoutDuration returns how long one update of the named
+// collector may wait on a sensor backend before its request is cancelled.
+// The collector's timeout_ms option overrides collect_timeout_ms.
+func (config *MonitorConfig) GetCollectTimeoutDuration(name string) time.Duration {
+	timeoutMS := defaultCollectTimeoutMS
+	if config != nil && config.CollectTimeoutMS > 0 {
+		timeoutMS = config.CollectTimeoutMS
+	}
+	if value, err := strconv.Atoi(strings.TrimSpace(config.GetCollectorStringOption(name, "timeout_ms", ""))); err == nil && value > 0 {
+		timeoutMS = value
+	}
+	if timeoutMS < 100 {
+		timeoutMS = 100
+	}
+	if timeoutMS > 60_000 {
+		timeoutMS = 60_000
+	}
+	return time.Duration(timeoutMS) * time.Millisecond
+}
+
 func (config *MonitorConfig) GetRenderWaitMaxDuration() time.Duration {
 	waitMS := config.RenderWaitMaxMS
 	if waitMS <= 0 {
@@ -369,6 +552,32 @@ func (config *MonitorConfig) GetRenderWaitMaxDuration() time.Duration {
 	return time.Duration(waitMS) * time.Millisecond
 }
 
+// GetOutputFrameInterval returns the minimum spacing between frames sent to
+// outputs, or 0 when max_fps is unset.
+func (config *MonitorConfig) GetOutputFrameInterval() time.Duration {
+	fps := config.MaxFPS
+	if fps <= 0 {
+		return 0
+	}
+	if fps > maxOutputFPS {
+		fps = maxOutputFPS
+	}
+	return time.Second / time.Duration(fps)
+}
+
+// GetRenderScale returns the supersampling factor. Frames are drawn at
+// Width*scale x Height*scale and box-filtered back down before output.
+func (config *MonitorConfig) GetRenderScale() int {
+	scale := config.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	if scale > maxRenderScale {
+		scale = maxRenderScale
+	}
+	return scale
+}
+
 func (config *MonitorConfig) GetMonitorUpdateWorkers() int {
 	workers := config.MonitorUpdateWorkers
 	if workers <= 0 {
@@ -440,6 +649,9 @@ func (config *MonitorConfig) GetCollectorConfig(name string) CollectorConfig {
 }
 
 func (config *MonitorConfig) IsCollectorEnabled(name string, defaultValue bool) bool {
+	if !isCollectorAllowed(name) {
+		return false
+	}
 	collector := config.GetCollectorConfig(name)
 	if collector.Enabled == nil {
 		return defaultValue
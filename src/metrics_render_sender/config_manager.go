@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConfigManager loads the named configs of one directory, e.g. the
+// profiles. A cached config is reused while its file is unchanged, so edits
+// made outside the process are picked up on the next load.
+type ConfigManager struct {
+	configDir string
+
+	mu      sync.Mutex
+	configs map[string]*configCacheEntry
+}
+
+type configCacheEntry struct {
+	config   *MonitorConfig
+	modTime  time.Time
+	size     int64
+	loadedAt time.Time
+}
+
+// ConfigFileInfo describes a config file. LoadedAt is zero while the config
+// is not cached.
+type ConfigFileInfo struct {
+	Name      string
+	Path      string
+	UpdatedAt time.Time
+	LoadedAt  time.Time
+	Size      int64
+}
+
+func NewConfigManager(configDir string) *ConfigManager {
+	return &ConfigManager{
+		configDir: configDir,
+		configs:   make(map[string]*configCacheEntry),
+	}
+}
+
+func (cm *ConfigManager) configPath(configName string) string {
+	return filepath.Join(cm.configDir, configName+".json")
+}
+
+func (cm *ConfigManager) LoadConfig(configName string) (*MonitorConfig, error) {
+	configFile := cm.configPath(configName)
+	info, err := os.Stat(configFile)
+	if os.IsNotExist(err) {
+		cm.Invalidate(configName)
+		return nil, fmt.Errorf("config file not found: %s", configFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	cm.mu.Lock()
+	entry, exists := cm.configs[configName]
+	cm.mu.Unlock()
+	if exists && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		return entry.config, nil
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	config, err := decodeMonitorConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	expandWidgetTemplates(config)
+	normalizeMonitorConfig(config)
+
+	cm.mu.Lock()
+	cm.configs[configName] = &configCacheEntry{
+		config:   config,
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+		loadedAt: time.Now(),
+	}
+	cm.mu.Unlock()
+	return config, nil
+}
+
+// Invalidate drops the cached config so the next load reads the file.
+func (cm *ConfigManager) Invalidate(configName string) {
+	cm.mu.Lock()
+	delete(cm.configs, configName)
+	cm.mu.Unlock()
+}
+
+func (cm *ConfigManager) ListConfigs() ([]string, error) {
+	infos, err := cm.ListConfigInfos()
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]string, 0, len(infos))
+	for _, info := range infos {
+		configs = append(configs, info.Name)
+	}
+	return configs, nil
+}
+
+// ListConfigInfos lists the directory's configs in name order with their
+// file and cache timestamps.
+func (cm *ConfigManager) ListConfigInfos() ([]ConfigFileInfo, error) {
+	files, err := os.ReadDir(cm.configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config directory: %v", err)
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	configs := make([]ConfigFileInfo, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		name := strings.TrimSuffix(file.Name(), ".json")
+		entry := ConfigFileInfo{
+			Name:      name,
+			Path:      cm.configPath(name),
+			UpdatedAt: info.ModTime(),
+			Size:      info.Size(),
+		}
+		if cached, exists := cm.configs[name]; exists {
+			entry.LoadedAt = cached.loadedAt
+		}
+		configs = append(configs, entry)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+	return configs, nil
+}
+
+// ConfigDirChange lists the configs added, edited or removed since the
+// previous scan of the directory.
+type ConfigDirChange struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+func (c ConfigDirChange) empty() bool {
+	return len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0
+}
+
+// Watch scans the directory every interval until stop is called. Changed
+// and removed configs are invalidated before onChange runs, so a load from
+// the callback sees the new file.
+func (cm *ConfigManager) Watch(interval time.Duration, onChange func(ConfigDirChange)) (stop func()) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	previous := cm.scanConfigDir()
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			current := cm.scanConfigDir()
+			change := diffConfigDirScans(previous, current)
+			previous = current
+			if change.empty() {
+				continue
+			}
+			for _, name := range append(change.Changed, change.Removed...) {
+				cm.Invalidate(name)
+			}
+			if onChange != nil {
+				onChange(change)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+type configFileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// scanConfigDir returns the stamps of the directory's configs. An
+// unreadable directory scans as empty, so its configs show as removed.
+func (cm *ConfigManager) scanConfigDir() map[string]configFileStamp {
+	stamps := make(map[string]configFileStamp)
+	infos, err := cm.ListConfigInfos()
+	if err != nil {
+		return stamps
+	}
+	for _, info := range infos {
+		stamps[info.Name] = configFileStamp{modTime: info.UpdatedAt, size: info.Size}
+	}
+	return stamps
+}
+
+func diffConfigDirScans(previous, current map[string]configFileStamp) ConfigDirChange {
+	var change ConfigDirChange
+	for name, stamp := range current {
+		old, exists := previous[name]
+		switch {
+		case !exists:
+			change.Added = append(change.Added, name)
+		case !old.modTime.Equal(stamp.modTime) || old.size != stamp.size:
+			change.Changed = append(change.Changed, name)
+		}
+	}
+	for name := range previous {
+		if _, exists := current[name]; !exists {
+			change.Removed = append(change.Removed, name)
+		}
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Changed)
+	sort.Strings(change.Removed)
+	return change
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigManagerReloadsEditedConfigs(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "desk.json")
+	writeConfig := func(body string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager := NewConfigManager(dir)
+
+	writeConfig(`{"name": "desk", "width": 480, "height": 320}`, time.Unix(1000, 0))
+	first, err := manager.LoadConfig("desk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := manager.LoadConfig("desk"); again != first {
+		t.Fatal("expected an unchanged file to come from the cache")
+	}
+	infos, err := manager.ListConfigInfos()
+	if err != nil || len(infos) != 1 || infos[0].LoadedAt.IsZero() || !infos[0].UpdatedAt.Equal(time.Unix(1000, 0)) {
+		t.Fatalf("unexpected infos %+v err=%v", infos, err)
+	}
+
+	writeConfig(`{"name": "desk", "width": 320, "height": 240}`, time.Unix(2000, 0))
+	edited, err := manager.LoadConfig("desk")
+	if err != nil || edited.Width != 320 {
+		t.Fatalf("expected the edited file, got %+v err=%v", edited, err)
+	}
+
+	manager.Invalidate("desk")
+	if reloaded, _ := manager.LoadConfig("desk"); reloaded == edited {
+		t.Fatal("expected Invalidate to force a reload")
+	}
+
+	os.Remove(path)
+	if _, err := manager.LoadConfig("desk"); err == nil {
+		t.Fatal("expected a removed file to fail instead of using the cache")
+	}
+}
+
+func TestConfigManagerWatchReportsDirChanges(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string, modTime time.Time) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("desk.json", `{}`, time.Unix(1000, 0))
+	write("old.json", `{}`, time.Unix(1000, 0))
+
+	manager := NewConfigManager(dir)
+	changes := make(chan ConfigDirChange, 16)
+	stop := manager.Watch(10*time.Millisecond, func(change ConfigDirChange) { changes <- change })
+	defer stop()
+
+	write("desk.json", `{"width": 1}`, time.Unix(2000, 0))
+	write("new.json", `{}`, time.Unix(2000, 0))
+	os.Remove(filepath.Join(dir, "old.json"))
+
+	// A write can span two scans, so the first change seen for a name counts.
+	want := map[string]string{"new": "added", "desk": "changed", "old": "removed"}
+	got := make(map[string]string)
+	deadline := time.After(2 * time.Second)
+	for !reflect.DeepEqual(got, want) {
+		select {
+		case change := <-changes:
+			for kind, names := range map[string][]string{"added": change.Added, "changed": change.Changed, "removed": change.Removed} {
+				for _, name := range names {
+					if _, seen := got[name]; !seen {
+						got[name] = kind
+					}
+				}
+			}
+		case <-deadline:
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestProfileManagerWatchActiveAppliesEdits(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	dir := t.TempDir()
+	profiles, err := NewProfileManager(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := profiles.SaveProfile("desk", &MonitorConfig{Width: 480, Height: 320}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := profiles.Switch("desk"); err != nil {
+		t.Fatal(err)
+	}
+
+	applied := make(chan *MonitorConfig, 4)
+	stop := profiles.WatchActive(10*time.Millisecond, func(cfg *MonitorConfig) error {
+		applied <- cfg
+		return nil
+	})
+	defer stop()
+
+	path := profiles.profilePath("desk")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := []byte(strings.Replace(string(data), `"width": 480`, `"width": 800`, 1))
+	if err := os.WriteFile(path, edited, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case cfg := <-applied:
+		if cfg.Width != 800 || cfg.Name != "desk" {
+			t.Fatalf("expected the edited desk profile, got width=%d name=%q", cfg.Width, cfg.Name)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the edited active profile to be applied")
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// currentConfigSchemaVersion is bumped whenever a release renames or moves
+// config fields; configMigrations must then gain the matching step.
+const currentConfigSchemaVersion = 1
+
+// A configMigration upgrades raw config JSON from version-1 to version and
+// describes each change it made. Steps work on the raw map so fields that
+// no longer exist in MonitorConfig can still be read.
+type configMigration struct {
+	version int
+	apply   func(raw map[string]interface{}) []string
+}
+
+var configMigrations = []configMigration{
+	{version: 1, apply: migrateConfigV1},
+}
+
+// migrateConfigData upgrades raw config JSON to the current schema version.
+// It returns the data unchanged when it is already current.
+func migrateConfigData(data []byte) ([]byte, int, []string, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, 0, nil, err
+	}
+	from := configSchemaVersion(raw)
+	if from >= currentConfigSchemaVersion {
+		return data, from, nil, nil
+	}
+	changes := make([]string, 0)
+	for _, step := range configMigrations {
+		if step.version <= from {
+			continue
+		}
+		changes = append(changes, step.apply(raw)...)
+	}
+	raw["schema_version"] = currentConfigSchemaVersion
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, from, nil, err
+	}
+	return migrated, from, changes, nil
+}
+
+// decodeMonitorConfig migrates and parses config JSON. Normalization is
+// left to the caller.
+func decodeMonitorConfig(data []byte) (*MonitorConfig, error) {
+	migrated, from, _, err := migrateConfigData(data)
+	if err != nil {
+		return nil, err
+	}
+	if from > currentConfigSchemaVersion {
+		logWarnModule("config", "config schema_version %d is newer than %d, unknown fields are ignored", from, currentConfigSchemaVersion)
+	}
+	var cfg MonitorConfig
+	if err := json.Unmarshal(migrated, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func configSchemaVersion(raw map[string]interface{}) int {
+	if version, ok := raw["schema_version"].(float64); ok && version > 0 {
+		return int(version)
+	}
+	return 0
+}
+
+// migrateConfigV1 covers configs written before schema_version existed:
+// monitor aliases become their real names, and the top-level CoolerControl
+// and Libre Hardware Monitor endpoints move into collector_config, where
+// they are otherwise shadowed by the default URL.
+func migrateConfigV1(raw map[string]interface{}) []string {
+	changes := make([]string, 0)
+	rename := func(where string, value interface{}) interface{} {
+		name, ok := value.(string)
+		if !ok {
+			return value
+		}
+		target := normalizeMonitorAlias(name)
+		if target != strings.TrimSpace(name) {
+			changes = append(changes, fmt.Sprintf("%s: monitor %s renamed to %s", where, name, target))
+		}
+		return target
+	}
+
+	if items, ok := raw["items"].([]interface{}); ok {
+		for idx, entry := range items {
+			if item, ok := entry.(map[string]interface{}); ok {
+				if monitor, exists := item["monitor"]; exists {
+					item["monitor"] = rename(fmt.Sprintf("items[%d]", idx), monitor)
+				}
+			}
+		}
+	}
+	if customs, ok := raw["custom_monitors"].([]interface{}); ok {
+		for idx, entry := range customs {
+			custom, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			where := fmt.Sprintf("custom_monitors[%d]", idx)
+			if source, exists := custom["source"]; exists {
+				custom["source"] = rename(where, source)
+			}
+			if sources, ok := custom["sources"].([]interface{}); ok {
+				for sourceIdx, source := range sources {
+					sources[sourceIdx] = rename(where, source)
+				}
+			}
+		}
+	}
+
+	moveOption := func(field, collector, option, defaultValue string) {
+		value, ok := raw[field].(string)
+		delete(raw, field)
+		if !ok || strings.TrimSpace(value) == "" {
+			return
+		}
+		collectors, _ := raw["collector_config"].(map[string]interface{})
+		if collectors == nil {
+			collectors = map[string]interface{}{}
+			raw["collector_config"] = collectors
+		}
+		entry, _ := collectors[collector].(map[string]interface{})
+		if entry == nil {
+			entry = map[string]interface{}{}
+			collectors[collector] = entry
+		}
+		options, _ := entry["options"].(map[string]interface{})
+		if options == nil {
+			options = map[string]interface{}{}
+			entry["options"] = options
+		}
+		// Older releases filled the option with the default URL, which hid
+		// the top-level one; only an explicitly set option is kept.
+		if current, _ := options[option].(string); strings.TrimSpace(current) != "" && current != defaultValue {
+			return
+		}
+		options[option] = value
+		changes = append(changes, fmt.Sprintf("%s moved to collector_config.%s.options.%s", field, collector, option))
+	}
+	moveOption("coolercontrol_url", collectorCoolerControl, "url", defaultCoolerControlURL)
+	moveOption("coolercontrol_password", collectorCoolerControl, "password", "")
+	moveOption("libre_hardware_monitor_url", collectorLibreHardwareMonitor, "url", defaultLibreHardwareMonitorURL)
+	return changes
+}
+
+// runMigrateCommand upgrades config files in place, by default the user
+// config and every saved profile, keeping a backup of each changed file.
+func runMigrateCommand(paths []string) error {
+	if len(paths) == 0 {
+		defaults, err := defaultConfigFilePaths()
+		if err != nil {
+			return err
+		}
+		paths = defaults
+	}
+	failed := 0
+	for _, path := range paths {
+		if err := migrateConfigFile(path); err != nil {
+			fmt.Printf("%s: %v\n", path, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed", failed, len(paths))
+	}
+	return nil
+}
+
+// defaultConfigFilePaths lists the user config and every saved profile.
+func defaultConfigFilePaths() ([]string, error) {
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	profiles, _ := filepath.Glob(filepath.Join(filepath.Dir(configPath), "profiles", "*.json"))
+	return append([]string{configPath}, profiles...), nil
+}
+
+func migrateConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Printf("%s: not found, skipped\n", path)
+			return nil
+		}
+		return err
+	}
+	migrated, from, changes, err := migrateConfigData(data)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if from >= currentConfigSchemaVersion {
+		fmt.Printf("%s: schema_version %d, up to date\n", path, from)
+		return nil
+	}
+	var cfg MonitorConfig
+	if err := json.Unmarshal(migrated, &cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	normalizeMonitorConfig(&cfg)
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, from)
+	if err := os.WriteFile(backup, data, 0o644); err != nil {
+		return fmt.Errorf("write backup: %w", err)
+	}
+	if err := saveUserConfig(path, &cfg); err != nil {
+		return err
+	}
+	fmt.Printf("%s: schema_version %d -> %d, backup %s\n", path, from, currentConfigSchemaVersion, backup)
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+	return nil
+}
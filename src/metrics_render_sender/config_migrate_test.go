@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrateConfigDataUpgradesLegacyConfig(t *testing.T) {
+	legacy := `{
+		"name": "old",
+		"coolercontrol_url": "http://10.0.0.5:11987",
+		"collector_config": {"coolercontrol": {"options": {"url": "http://127.0.0.1:11987"}}},
+		"items": [{"type": "simple_value", "monitor": "disk_default_read_speed"}],
+		"custom_monitors": [{"name": "hot", "sources": ["disk_default_temp"]}]
+	}`
+	data, from, changes, err := migrateConfigData([]byte(legacy))
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if from != 0 || len(changes) != 3 {
+		t.Fatalf("unexpected migration: from=%d changes=%v", from, changes)
+	}
+	cfg, err := decodeMonitorConfig(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if cfg.SchemaVersion != currentConfigSchemaVersion {
+		t.Fatalf("expected schema_version %d, got %d", currentConfigSchemaVersion, cfg.SchemaVersion)
+	}
+	if cfg.Items[0].Monitor != "go_native.disk.total_read" {
+		t.Fatalf("monitor alias not renamed: %s", cfg.Items[0].Monitor)
+	}
+	if cfg.CustomMonitors[0].Sources[0] != "go_native.disk.max_temp" {
+		t.Fatalf("custom source alias not renamed: %v", cfg.CustomMonitors[0].Sources)
+	}
+	if cfg.CoolerControlURL != "" {
+		t.Fatalf("expected top-level coolercontrol_url to be removed, got %q", cfg.CoolerControlURL)
+	}
+	if url := cfg.GetCoolerControlURL(); url != "http://10.0.0.5:11987" {
+		t.Fatalf("expected top-level URL to replace the default option, got %q", url)
+	}
+}
+
+func TestMigrateConfigDataKeepsExplicitOption(t *testing.T) {
+	legacy := `{"coolercontrol_url": "http://a:1", "collector_config": {"coolercontrol": {"options": {"url": "http://b:2"}}}}`
+	data, _, _, err := migrateConfigData([]byte(legacy))
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	cfg, err := decodeMonitorConfig(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if url := cfg.GetCoolerControlURL(); url != "http://b:2" {
+		t.Fatalf("expected explicit option to win, got %q", url)
+	}
+}
+
+func TestMigrateConfigDataLeavesCurrentConfig(t *testing.T) {
+	current := []byte(`{"schema_version": 1, "items": [{"monitor": "disk_default_read_speed"}]}`)
+	data, from, changes, err := migrateConfigData(current)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if from != 1 || len(changes) != 0 || string(data) != string(current) {
+		t.Fatalf("expected current config untouched: from=%d changes=%v data=%s", from, changes, data)
+	}
+}
+
+func TestMigrateConfigFileWritesBackup(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	legacy := []byte(`{"name": "old", "items": [{"type": "simple_value", "monitor": "disk_default_write_speed"}]}`)
+	if err := os.WriteFile(path, legacy, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateConfigFile(path); err != nil {
+		t.Fatalf("migrate file: %v", err)
+	}
+	backup, err := os.ReadFile(path + ".v0.bak")
+	if err != nil || string(backup) != string(legacy) {
+		t.Fatalf("expected original in backup: err=%v data=%s", err, backup)
+	}
+	cfg, err := loadUserConfigOrDefault(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if cfg.SchemaVersion != currentConfigSchemaVersion || cfg.Items[0].Monitor != "go_native.disk.total_write" {
+		t.Fatalf("unexpected migrated config: version=%d monitor=%s", cfg.SchemaVersion, cfg.Items[0].Monitor)
+	}
+}
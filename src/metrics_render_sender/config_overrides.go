@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	configOverrideEnvPrefix = "AX206_"
+	// configOverrideOutputType replaces the output list with one default
+	// output per comma-separated type, e.g. AX206_OUTPUT_TYPE=memimg.
+	configOverrideOutputType = "output_type"
+)
+
+// configOverride sets one config field by its JSON path, e.g.
+// "refresh_interval" or "collector_config.coolercontrol.enabled".
+type configOverride struct {
+	key    string
+	value  string
+	source string
+}
+
+// Overrides apply to the running config only and are never written back, so
+// saving from the Web UI keeps the file's own values.
+var (
+	configOverridesMu sync.RWMutex
+	configOverrides   []configOverride
+)
+
+// configOverrideFlag collects repeated -set key=value flags.
+type configOverrideFlag []configOverride
+
+func (f *configOverrideFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(*f))
+	for _, item := range *f {
+		parts = append(parts, item.key+"="+item.value)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (f *configOverrideFlag) Set(raw string) error {
+	key, value, found := strings.Cut(raw, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return fmt.Errorf("expected key=value, got %q", raw)
+	}
+	*f = append(*f, configOverride{key: key, value: value, source: "-set " + key})
+	return nil
+}
+
+// configOverridesFromEnv maps AX206_<FIELD> variables onto top-level config
+// fields, e.g. AX206_REFRESH_INTERVAL to refresh_interval. Variables that do
+// not name a field, such as AX206_MONITOR_WEB, are left alone.
+func configOverridesFromEnv(environ []string) []configOverride {
+	fields := monitorConfigJSONFields()
+	result := make([]configOverride, 0)
+	for _, entry := range environ {
+		name, value, found := strings.Cut(entry, "=")
+		if !found || !strings.HasPrefix(name, configOverrideEnvPrefix) {
+			continue
+		}
+		key := strings.ToLower(strings.TrimPrefix(name, configOverrideEnvPrefix))
+		if _, ok := fields[key]; !ok && key != configOverrideOutputType {
+			continue
+		}
+		result = append(result, configOverride{key: key, value: value, source: name})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].key < result[j].key })
+	return result
+}
+
+// setConfigOverrides checks and installs overrides; -set flags come after
+// the environment so they win. Top-level values are type-checked here, while
+// nested keys such as outputs.0.url depend on the loaded config and are only
+// checked when applied.
+func setConfigOverrides(overrides []configOverride) error {
+	probe := &MonitorConfig{}
+	for _, item := range overrides {
+		if strings.Contains(item.key, ".") {
+			root, _, _ := strings.Cut(item.key, ".")
+			if _, ok := monitorConfigJSONFields()[root]; !ok {
+				return fmt.Errorf("%s: unknown config field %q", item.source, root)
+			}
+			continue
+		}
+		if err := applyConfigOverride(probe, item.key, item.value); err != nil {
+			return fmt.Errorf("%s: %w", item.source, err)
+		}
+	}
+	configOverridesMu.Lock()
+	configOverrides = append([]configOverride(nil), overrides...)
+	configOverridesMu.Unlock()
+	for _, item := range overrides {
+		logInfoModule("config", "override %s from %s", item.key, item.source)
+	}
+	return nil
+}
+
+// applyConfigOverrides applies the installed overrides to cfg in place. A
+// nested key that does not fit the config, e.g. an output index past the
+// end of the list, is skipped with a warning.
+func applyConfigOverrides(cfg *MonitorConfig) {
+	if cfg == nil {
+		return
+	}
+	configOverridesMu.RLock()
+	overrides := configOverrides
+	configOverridesMu.RUnlock()
+	for _, item := range overrides {
+		if err := applyConfigOverride(cfg, item.key, item.value); err != nil {
+			logWarnModule("config", "override %s skipped: %v", item.source, err)
+		}
+	}
+}
+
+func applyConfigOverride(cfg *MonitorConfig, key, raw string) error {
+	key = strings.TrimSpace(key)
+	if key == configOverrideOutputType {
+		types := make([]string, 0)
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				types = append(types, item)
+			}
+		}
+		cfg.Outputs = outputConfigsFromTypes(types)
+		cfg.OutputTypes = types
+		return nil
+	}
+
+	path := strings.Split(key, ".")
+	if _, ok := monitorConfigJSONFields()[path[0]]; !ok {
+		return fmt.Errorf("unknown config field %q", path[0])
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Values are JSON where they parse as JSON, so numbers, booleans and
+	// lists keep their type; a string field given something like "1" falls
+	// back to the raw text.
+	var parsed interface{}
+	parsedJSON := json.Unmarshal([]byte(raw), &parsed) == nil
+	if !parsedJSON {
+		parsed = raw
+	}
+	next, err := decodeConfigOverride(data, path, parsed)
+	if err != nil && parsedJSON {
+		next, err = decodeConfigOverride(data, path, raw)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*cfg = *next
+	return nil
+}
+
+func decodeConfigOverride(data []byte, path []string, value interface{}) (*MonitorConfig, error) {
+	var tree map[string]interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	if err := setConfigTreeValue(tree, path, value); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	var next MonitorConfig
+	if err := json.Unmarshal(data, &next); err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+// setConfigTreeValue walks path through objects, creating missing ones, and
+// through lists by index.
+func setConfigTreeValue(node interface{}, path []string, value interface{}) error {
+	segment := path[0]
+	last := len(path) == 1
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		if last {
+			typed[segment] = value
+			return nil
+		}
+		child, ok := typed[segment]
+		if !ok || child == nil {
+			child = map[string]interface{}{}
+			typed[segment] = child
+		}
+		return setConfigTreeValue(child, path[1:], value)
+	case []interface{}:
+		idx, err := strconv.Atoi(segment)
+		if err != nil || idx < 0 || idx >= len(typed) {
+			return fmt.Errorf("index %q out of range (have %d)", segment, len(typed))
+		}
+		if last {
+			typed[idx] = value
+			return nil
+		}
+		return setConfigTreeValue(typed[idx], path[1:], value)
+	default:
+		return fmt.Errorf("cannot set %q inside a scalar", segment)
+	}
+}
+
+var (
+	monitorConfigFieldsOnce sync.Once
+	monitorConfigFields     map[string]struct{}
+)
+
+func monitorConfigJSONFields() map[string]struct{} {
+	monitorConfigFieldsOnce.Do(func() {
+		monitorConfigFields = make(map[string]struct{})
+		configType := reflect.TypeOf(MonitorConfig{})
+		for idx := 0; idx < configType.NumField(); idx++ {
+			name, _, _ := strings.Cut(configType.Field(idx).Tag.Get("json"), ",")
+			if name != "" && name != "-" {
+				monitorConfigFields[name] = struct{}{}
+			}
+		}
+	})
+	return monitorConfigFields
+}
+
+func loadConfigOverrides(setFlags configOverrideFlag) error {
+	overrides := configOverridesFromEnv(os.Environ())
+	overrides = append(overrides, setFlags...)
+	if len(overrides) == 0 {
+		return nil
+	}
+	return setConfigOverrides(overrides)
+}
@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+func TestConfigOverridesFromEnv(t *testing.T) {
+	overrides := configOverridesFromEnv([]string{
+		"AX206_REFRESH_INTERVAL=500",
+		"AX206_OUTPUT_TYPE=memimg",
+		"AX206_MONITOR_WEB=1",
+		"AX206_NOT_A_FIELD=1",
+		"HOME=/root",
+	})
+	if len(overrides) != 2 || overrides[0].key != "output_type" || overrides[1].key != "refresh_interval" {
+		t.Fatalf("unexpected env overrides: %+v", overrides)
+	}
+}
+
+func TestApplyConfigOverride(t *testing.T) {
+	cfg := &MonitorConfig{
+		RefreshInterval: 1000,
+		Outputs:         []OutputConfig{{Type: "httppush", URL: "http://old"}},
+	}
+	steps := []struct{ key, value string }{
+		{"refresh_interval", "250"},
+		{"network_interface", "1"},
+		{"outputs.0.url", "http://new"},
+		{"collector_config.coolercontrol.enabled", "true"},
+	}
+	for _, step := range steps {
+		if err := applyConfigOverride(cfg, step.key, step.value); err != nil {
+			t.Fatalf("%s: %v", step.key, err)
+		}
+	}
+	if cfg.RefreshInterval != 250 || cfg.NetworkInterface != "1" || cfg.Outputs[0].URL != "http://new" {
+		t.Fatalf("overrides not applied: refresh=%d iface=%q url=%q", cfg.RefreshInterval, cfg.NetworkInterface, cfg.Outputs[0].URL)
+	}
+	if !cfg.IsCollectorEnabled(collectorCoolerControl, false) {
+		t.Fatalf("expected nested collector override to enable coolercontrol")
+	}
+
+	if err := applyConfigOverride(cfg, "output_type", "memimg, ax206usb"); err != nil {
+		t.Fatalf("output_type: %v", err)
+	}
+	if len(cfg.Outputs) != 2 || cfg.Outputs[0].Type != "memimg" {
+		t.Fatalf("unexpected outputs after output_type override: %+v", cfg.Outputs)
+	}
+
+	for _, bad := range []struct{ key, value string }{
+		{"no_such_field", "1"},
+		{"refresh_interval", "fast"},
+		{"outputs.5.url", "x"},
+	} {
+		if err := applyConfigOverride(cfg, bad.key, bad.value); err == nil {
+			t.Fatalf("expected %s=%s to be rejected", bad.key, bad.value)
+		}
+	}
+}
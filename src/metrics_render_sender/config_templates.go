@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WidgetTemplate is a named set of items, e.g. a label, a big value
+// and a sparkline, laid out from 0,0 and placed as one widget. Strings in
+// the items may use ${name} parameters filled in by each widget.
+type WidgetTemplate struct {
+	Items []ItemConfig `json:"items"`
+}
+
+// WidgetConfig places a copy of Template with its top-left corner at X, Y.
+// Params fill the template's ${name} parameters, e.g. the monitor. Z is
+// added to the z of each item and Group, when set, replaces theirs.
+type WidgetConfig struct {
+	Template string            `json:"template"`
+	X        int               `json:"x"`
+	Y        int               `json:"y"`
+	Z        int               `json:"z,omitempty"`
+	Group    string            `json:"group,omitempty"`
+	Params   map[string]string `json:"params,omitempty"`
+}
+
+var widgetParamPattern = regexp.MustCompile(`\$\{([a-zA-Z0-9_.-]+)\}`)
+
+// expandWidgetTemplates appends the items of every widget to cfg.Items and
+// drops the widgets, so the renderer and the monitor list only see plain
+// items. It runs on runtime copies; saved configs keep their widgets.
+// Unknown templates and parameters are logged, and a parameter without a
+// value is left as written.
+func expandWidgetTemplates(cfg *MonitorConfig) {
+	if cfg == nil || len(cfg.Widgets) == 0 {
+		return
+	}
+	for idx, widget := range cfg.Widgets {
+		items, err := instantiateWidget(cfg.Templates, widget)
+		if err != nil {
+			logWarnModule("config", "skip widget idx=%d: %v", idx, err)
+			continue
+		}
+		cfg.Items = append(cfg.Items, items...)
+	}
+	cfg.Widgets = nil
+}
+
+func instantiateWidget(templates map[string]WidgetTemplate, widget WidgetConfig) ([]ItemConfig, error) {
+	name := strings.TrimSpace(widget.Template)
+	template, exists := templates[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown template %q", widget.Template)
+	}
+	data, err := json.Marshal(template.Items)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	expanded := widgetParamPattern.ReplaceAllFunc(data, func(match []byte) []byte {
+		key := string(widgetParamPattern.FindSubmatch(match)[1])
+		value, ok := widget.Params[key]
+		if !ok {
+			missing = append(missing, key)
+			return match
+		}
+		// The parameter sits inside a JSON string, so its value is
+		// escaped the same way.
+		quoted, _ := json.Marshal(value)
+		return quoted[1 : len(quoted)-1]
+	})
+	if len(missing) > 0 {
+		logWarnModule("config", "template %q has no value for %s", name, strings.Join(missing, ", "))
+	}
+	var items []ItemConfig
+	if err := json.Unmarshal(expanded, &items); err != nil {
+		return nil, fmt.Errorf("template %q: %w", name, err)
+	}
+	for idx := range items {
+		item := &items[idx]
+		item.X += widget.X
+		item.Y += widget.Y
+		item.Z += widget.Z
+		if group := strings.TrimSpace(widget.Group); group != "" {
+			item.Group = group
+		}
+		// Copies share the template's IDs; normalization assigns fresh ones.
+		item.ID = ""
+	}
+	return items, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestExpandWidgetTemplates(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	cfg := &MonitorConfig{
+		Templates: map[string]WidgetTemplate{
+			"temp_card": {Items: []ItemConfig{
+				{ID: "label", Type: itemTypeSimpleLabel, Text: "${label} \"temp\"", Width: 100, Height: 20},
+				{ID: "value", Type: itemTypeSimpleValue, Monitor: "${monitor}", Y: 20, Width: 100, Height: 40},
+				{Type: itemTypeSimpleChart, Monitor: "${monitor}", Y: 60, Z: 1, Width: 100, Height: 30, RenderAttrsMap: map[string]interface{}{"title": "${label} history"}},
+			}},
+		},
+		Widgets: []WidgetConfig{
+			{Template: "temp_card", X: 10, Y: 5, Params: map[string]string{"monitor": "go_native.cpu.temp", "label": "CPU"}},
+			{Template: "temp_card", X: 120, Y: 5, Z: 2, Group: "gpu", Params: map[string]string{"monitor": "go_native.gpu.temp", "label": "GPU"}},
+			{Template: "missing", X: 0, Y: 0},
+		},
+		Items: []ItemConfig{{Type: itemTypeSimpleLabel, Text: "title"}},
+	}
+	expandWidgetTemplates(cfg)
+	normalizeMonitorConfig(cfg)
+
+	if len(cfg.Widgets) != 0 {
+		t.Fatal("expected the widgets to be consumed")
+	}
+	if len(cfg.Items) != 7 {
+		t.Fatalf("expected the title plus two copies of three items, got %d", len(cfg.Items))
+	}
+	label, value, chart := cfg.Items[1], cfg.Items[2], cfg.Items[3]
+	if label.Text != `CPU "temp"` || label.X != 10 || label.Y != 5 {
+		t.Fatalf("unexpected label %+v", label)
+	}
+	if value.Monitor != "go_native.cpu.temp" || value.X != 10 || value.Y != 25 {
+		t.Fatalf("unexpected value %+v", value)
+	}
+	if chart.RenderAttrsMap["title"] != "CPU history" {
+		t.Fatalf("expected parameters in render attrs, got %v", chart.RenderAttrsMap)
+	}
+	gpuChart := cfg.Items[6]
+	if gpuChart.Monitor != "go_native.gpu.temp" || gpuChart.X != 120 || gpuChart.Z != 3 || gpuChart.Group != "gpu" {
+		t.Fatalf("unexpected second copy %+v", gpuChart)
+	}
+	if cfg.Items[1].ID == cfg.Items[4].ID {
+		t.Fatalf("expected every copy to get its own ID, both are %q", cfg.Items[1].ID)
+	}
+	if got := getRequiredMonitors(cfg); len(got) < 2 {
+		t.Fatalf("expected the template monitors to be required, got %v", got)
+	}
+	if len(cfg.Templates["temp_card"].Items) != 3 || cfg.Templates["temp_card"].Items[1].Monitor != "${monitor}" {
+		t.Fatal("expected the template itself to stay untouched")
+	}
+}
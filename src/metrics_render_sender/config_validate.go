@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// monitorRefKeys are the config keys whose string values name monitors,
+// whether on items, in render_attrs_map, custom monitors or threshold groups.
+var monitorRefKeys = map[string]struct{}{
+	"monitor":        {},
+	"monitors":       {},
+	"source":         {},
+	"sources":        {},
+	"stack_monitors": {},
+}
+
+// validateConfigData reports problems in raw config JSON that loading would
+// silently paper over. It works on the file as written, before migration and
+// normalization rewrite aliases.
+func validateConfigData(data []byte) ([]string, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	var probe MonitorConfig
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	warnings := make([]string, 0)
+	switch version := configSchemaVersion(raw); {
+	case version > currentConfigSchemaVersion:
+		warnings = append(warnings, fmt.Sprintf("schema_version %d is newer than this build (%d)", version, currentConfigSchemaVersion))
+	case version < currentConfigSchemaVersion:
+		warnings = append(warnings, fmt.Sprintf("schema_version %d is outdated, run migrate", version))
+	}
+	walkConfigMonitorRefs(raw, "", false, func(path, name string) {
+		if target, ok := lookupMonitorAlias(normalizeMonitorAliasInput(name)); ok {
+			warnings = append(warnings, fmt.Sprintf("%s: monitor %s is deprecated, use %s", path, name, target))
+		}
+	})
+	sort.Strings(warnings)
+	return warnings, nil
+}
+
+// lintConfig reports what only shows on this system or once the layout is
+// drawn: monitors the registry does not have, whether on items, threshold
+// groups, OpenRGB or the summary, and value items drawn over each other at
+// the same z. cfg is normalized with its widgets expanded; items are
+// numbered as after normalization, widget items after the layout's own.
+func lintConfig(cfg *MonitorConfig, registry *CollectorManager) []string {
+	if cfg == nil {
+		return nil
+	}
+	warnings := make([]string, 0)
+	known := func(name string) bool {
+		return (registry != nil && registry.Get(name) != nil) || configDeclaresMonitor(cfg, name)
+	}
+	checkRefs := func(where string, refs []string) {
+		for _, ref := range refs {
+			if name := normalizeMonitorAlias(ref); name != "" && !known(name) {
+				warnings = append(warnings, fmt.Sprintf("%s: monitor %s not found on this system", where, name))
+			}
+		}
+	}
+	for idx := range cfg.Items {
+		checkRefs(describeLintItem(cfg, idx), collectItemMonitorRefs(&cfg.Items[idx]))
+	}
+	for idx, group := range cfg.ThresholdGroups {
+		checkRefs(fmt.Sprintf("threshold_groups[%d] %q", idx, group.Name), group.Monitors)
+	}
+	if cfg.OpenRGB != nil {
+		checkRefs("openrgb", []string{cfg.OpenRGB.Monitor})
+	}
+	if cfg.Summary != nil {
+		checkRefs("summary", summaryMonitorRefs(cfg.Summary))
+	}
+	warnings = append(warnings, lintThresholdGroupUnits(cfg, registry)...)
+	warnings = append(warnings, lintOverlappingItems(cfg)...)
+	sort.Strings(warnings)
+	return warnings
+}
+
+// lintThresholdGroupUnits reports threshold groups whose bounds cannot match
+// their monitors' values: a group without a unit on a byte, rate or
+// frequency monitor, which is shown scaled so the bounds are easily written
+// in a different prefix, and a group whose unit a monitor cannot convert to.
+func lintThresholdGroupUnits(cfg *MonitorConfig, registry *CollectorManager) []string {
+	if registry == nil {
+		return nil
+	}
+	warnings := make([]string, 0)
+	for idx, group := range cfg.ThresholdGroups {
+		for _, ref := range group.Monitors {
+			item := registry.Get(normalizeMonitorAlias(ref))
+			if item == nil {
+				continue
+			}
+			value := item.GetValue()
+			if value == nil {
+				continue
+			}
+			unit := strings.TrimSpace(value.Unit)
+			where := fmt.Sprintf("threshold_groups[%d] %q", idx, group.Name)
+			if group.Unit == "" {
+				if family, _ := lookupUnitFamily(unit); family != nil {
+					warnings = append(warnings, fmt.Sprintf("%s: monitor %s reports %s, set unit to the one the ranges are written in", where, item.GetName(), unit))
+				}
+				continue
+			}
+			if _, ok := convertUnitValue(0, unit, group.Unit); !ok {
+				warnings = append(warnings, fmt.Sprintf("%s: monitor %s reports %q, which cannot be converted to %s", where, item.GetName(), unit, group.Unit))
+			}
+		}
+	}
+	return warnings
+}
+
+// configDeclaresMonitor reports whether the config itself creates the
+// monitor, as custom monitors and hosts do, so it need not be running yet.
+func configDeclaresMonitor(cfg *MonitorConfig, name string) bool {
+	for _, custom := range cfg.CustomMonitors {
+		if custom.Name == "" {
+			continue
+		}
+		if name == custom.Name || name == custom.Name+".kwh_day" || name == custom.Name+".cost_day" {
+			return true
+		}
+	}
+	for _, host := range cfg.Hosts {
+		// Host monitors are named by slug, e.g. hosts.my_nas.cpu.
+		if slug := slugifyProviderName(host.Name); slug != "" && strings.HasPrefix(name, collectorHosts+"."+slug+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// lintOverlappingItems reports value items at the same z whose boxes
+// intersect. Items at different z values are taken as deliberate layers,
+// and static items such as rectangles and labels as backgrounds.
+func lintOverlappingItems(cfg *MonitorConfig) []string {
+	offsets := resolveItemGroupOffsets(cfg)
+	type box struct {
+		idx, z         int
+		x0, y0, x1, y1 int
+	}
+	boxes := make([]box, 0, len(cfg.Items))
+	for idx := range cfg.Items {
+		item := &cfg.Items[idx]
+		if len(collectItemMonitorRefs(item)) == 0 || item.Width <= 0 || item.Height <= 0 {
+			continue
+		}
+		offset := offsets[item.Group]
+		x, y := item.X+offset.X, item.Y+offset.Y
+		boxes = append(boxes, box{idx: idx, z: item.Z, x0: x, y0: y, x1: x + item.Width, y1: y + item.Height})
+	}
+	warnings := make([]string, 0)
+	for i := range boxes {
+		for j := i + 1; j < len(boxes); j++ {
+			a, b := boxes[i], boxes[j]
+			if a.z != b.z || a.x0 >= b.x1 || b.x0 >= a.x1 || a.y0 >= b.y1 || b.y0 >= a.y1 {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("%s overlaps %s", describeLintItem(cfg, a.idx), describeLintItem(cfg, b.idx)))
+		}
+	}
+	return warnings
+}
+
+func describeLintItem(cfg *MonitorConfig, idx int) string {
+	item := &cfg.Items[idx]
+	if name := strings.TrimSpace(item.EditUIName); name != "" {
+		return fmt.Sprintf("items[%d] %q", idx, name)
+	}
+	return fmt.Sprintf("items[%d] %s", idx, item.Type)
+}
+
+// logConfigLint writes lintConfig's warnings to the log at startup.
+func logConfigLint(cfg *MonitorConfig, registry *CollectorManager) {
+	for _, warning := range lintConfig(cfg, registry) {
+		logWarnModule("config", "%s", warning)
+	}
+}
+
+func walkConfigMonitorRefs(node interface{}, path string, isRef bool, visit func(path, name string)) {
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			_, ref := monitorRefKeys[key]
+			walkConfigMonitorRefs(child, joinConfigPath(path, key), ref, visit)
+		}
+	case []interface{}:
+		for idx, child := range typed {
+			walkConfigMonitorRefs(child, fmt.Sprintf("%s[%d]", path, idx), isRef, visit)
+		}
+	case string:
+		if isRef {
+			visit(path, typed)
+		}
+	}
+}
+
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// validateRegistry discovers this system's monitors once for -validate,
+// with the collectors of the user config so custom ones are included. The
+// registry is built outside the global manager: -validate never renders.
+var validateRegistry = sync.OnceValue(func() *CollectorManager {
+	var cfg *MonitorConfig
+	if configPath, err := getUserConfigPath(); err == nil {
+		cfg, _ = loadUserConfigOrDefault(configPath)
+	}
+	initializeCache()
+	return newCollectorManagerFromConfig(cfg, nil)
+})
+
+// runValidateCommand checks config files, by default the user config and
+// every saved profile. Warnings are printed; only unreadable or unparsable
+// files fail.
+func runValidateCommand(paths []string) error {
+	if len(paths) == 0 {
+		defaults, err := defaultConfigFilePaths()
+		if err != nil {
+			return err
+		}
+		paths = defaults
+	}
+	failed := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				fmt.Printf("%s: not found, skipped\n", path)
+				continue
+			}
+			fmt.Printf("%s: %v\n", path, err)
+			failed++
+			continue
+		}
+		warnings, err := validateConfigData(data)
+		if err != nil {
+			fmt.Printf("%s: invalid config: %v\n", path, err)
+			failed++
+			continue
+		}
+		if cfg, err := decodeMonitorConfig(data); err == nil {
+			expandWidgetTemplates(cfg)
+			normalizeMonitorConfig(cfg)
+			warnings = append(warnings, lintConfig(cfg, validateRegistry())...)
+		}
+		if len(warnings) == 0 {
+			fmt.Printf("%s: ok\n", path)
+			continue
+		}
+		fmt.Printf("%s: %d warning(s)\n", path, len(warnings))
+		for _, warning := range warnings {
+			fmt.Printf("  %s\n", warning)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files invalid", failed, len(paths))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintConfigReportsMissingMonitorsAndOverlaps(t *testing.T) {
+	registry := NewCollectorManager()
+	for _, name := range []string{"go_native.cpu.temp", "go_native.cpu.usage"} {
+		registry.items[name] = NewCollectItem(name, name, "", 0, 100, 0)
+	}
+	cfg := &MonitorConfig{
+		CustomMonitors: []CustomMonitorConfig{{Name: "custom.room_temp"}},
+		Hosts:          []HostConfig{{Name: "nas"}},
+		Groups:         []ItemGroupConfig{{Name: "right", X: 100}},
+		ThresholdGroups: []ThresholdGroupConfig{
+			{Name: "temps", Monitors: []string{"go_native.cpu.temp", "go_native.gpu.temp"}},
+		},
+		Items: []ItemConfig{
+			{Type: itemTypeSimpleValue, EditUIName: "CPU", Monitor: "go_native.cpu.temp", Width: 50, Height: 20},
+			{Type: itemTypeSimpleValue, EditUIName: "GPU", Monitor: "go_native.gpu.temp", X: 40, Width: 50, Height: 20},
+			{Type: itemTypeSimpleValue, EditUIName: "Load", Monitor: "go_native.cpu.usage", Group: "right", X: -20, Width: 50, Height: 20, Z: 1},
+			{Type: itemTypeSimpleValue, EditUIName: "Room", Monitor: "custom.room_temp", Y: 30, Width: 50, Height: 20},
+			{Type: itemTypeSimpleValue, EditUIName: "NAS", Monitor: "hosts.nas.cpu", X: 50, Y: 30, Width: 50, Height: 20},
+			{Type: itemTypeSimpleLabel, Text: "background", Width: 200, Height: 100},
+		},
+	}
+
+	got := lintConfig(cfg, registry)
+	want := []string{
+		`items[0] "CPU" overlaps items[1] "GPU"`,
+		`items[1] "GPU": monitor go_native.gpu.temp not found on this system`,
+		`threshold_groups[0] "temps": monitor go_native.gpu.temp not found on this system`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected warnings:\n%s", strings.Join(got, "\n"))
+	}
+
+	// Ranges on a scaled monitor need a unit, and one the monitor can reach.
+	registry.items["go_native.net.download"] = NewCollectItem("go_native.net.download", "download", " MiB/s", 0, 0, 2)
+	cfg.ThresholdGroups = append(cfg.ThresholdGroups,
+		ThresholdGroupConfig{Name: "net", Monitors: []string{"go_native.net.download"}},
+		ThresholdGroupConfig{Name: "net_bytes", Unit: "B/s", Monitors: []string{"go_native.net.download"}},
+		ThresholdGroupConfig{Name: "cpu_bytes", Unit: "B/s", Monitors: []string{"go_native.cpu.usage"}},
+	)
+	got = lintConfig(cfg, registry)
+	for _, want := range []string{
+		`threshold_groups[1] "net": monitor go_native.net.download reports MiB/s, set unit to the one the ranges are written in`,
+		`threshold_groups[3] "cpu_bytes": monitor go_native.cpu.usage reports "", which cannot be converted to B/s`,
+	} {
+		if !strings.Contains(strings.Join(got, "\n"), want) {
+			t.Fatalf("expected %q in:\n%s", want, strings.Join(got, "\n"))
+		}
+	}
+	if len(got) != 5 {
+		t.Fatalf("expected the group with a matching unit to pass, got:\n%s", strings.Join(got, "\n"))
+	}
+	cfg.ThresholdGroups = cfg.ThresholdGroups[:1]
+
+	// Moving the grouped item to the same z makes it overlap the GPU value.
+	cfg.Items[2].Z = 0
+	if got := lintConfig(cfg, registry); len(got) != 4 || !strings.Contains(strings.Join(got, "\n"), `items[1] "GPU" overlaps items[2] "Load"`) {
+		t.Fatalf("expected the group offset to count, got:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestLintConfigMatchesHostMonitorsBySlug(t *testing.T) {
+	cfg := &MonitorConfig{
+		Hosts: []HostConfig{{Name: "My NAS"}},
+		Items: []ItemConfig{
+			{Type: itemTypeSimpleValue, EditUIName: "NAS", Monitor: "hosts.my_nas.cpu", Width: 50, Height: 20},
+			{Type: itemTypeSimpleValue, EditUIName: "Raw", Monitor: "hosts.My NAS.cpu", Y: 30, Width: 50, Height: 20},
+		},
+	}
+	got := lintConfig(cfg, NewCollectorManager())
+	want := []string{`items[1] "Raw": monitor hosts.My NAS.cpu not found on this system`}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected warnings:\n%s", strings.Join(got, "\n"))
+	}
+}
@@ -2,6 +2,7 @@ package coolercontrol
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -162,6 +163,12 @@ func GetCoolerControlClient(baseURL, password string) *CoolerControlClient {
 }
 
 func (c *CoolerControlClient) FetchSnapshot() (*coolerControlSnapshot, error) {
+	return c.FetchSnapshotContext(context.Background())
+}
+
+// FetchSnapshotContext is FetchSnapshot with the wait for the first SSE
+// event and the fallback status request abandoned once ctx is done.
+func (c *CoolerControlClient) FetchSnapshotContext(ctx context.Context) (*coolerControlSnapshot, error) {
 	c.startOnce.Do(func() {
 		go c.runSSELoop()
 	})
@@ -173,6 +180,8 @@ func (c *CoolerControlClient) FetchSnapshot() (*coolerControlSnapshot, error) {
 	select {
 	case <-c.readyCh:
 	case <-time.After(1500 * time.Millisecond):
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 
 	if snapshot := c.getFreshSnapshot(15 * time.Second); snapshot != nil {
@@ -180,7 +189,7 @@ func (c *CoolerControlClient) FetchSnapshot() (*coolerControlSnapshot, error) {
 	}
 
 	// Fallback: if SSE is temporarily unavailable, perform one normal status request.
-	status, err := c.getStatus()
+	status, err := c.getStatus(ctx)
 	if err == nil {
 		snapshot := buildCoolerControlSnapshot(status)
 		c.setSnapshot(snapshot)
@@ -320,7 +329,7 @@ func (c *CoolerControlClient) runSSELoop() {
 				retryDelay = time.Second
 			}
 			if errors.Is(err, errCoolerControlUnauthorized) && c.password != "" {
-				if loginErr := c.login(); loginErr != nil {
+				if loginErr := c.login(context.Background()); loginErr != nil {
 					c.setLastError(loginErr)
 					logWarnModule("coolercontrol", "login failed: %v", loginErr)
 				} else {
@@ -411,17 +420,17 @@ func (c *CoolerControlClient) applySSEPayload(payload string) {
 	c.setLastError(nil)
 }
 
-func (c *CoolerControlClient) getStatus() (*coolerControlStatusResponse, error) {
-	status, statusCode, err := c.requestStatus()
+func (c *CoolerControlClient) getStatus(ctx context.Context) (*coolerControlStatusResponse, error) {
+	status, statusCode, err := c.requestStatus(ctx)
 	if err == nil {
 		return status, nil
 	}
 
 	if statusCode == http.StatusUnauthorized && c.password != "" {
-		if loginErr := c.login(); loginErr != nil {
+		if loginErr := c.login(ctx); loginErr != nil {
 			return nil, loginErr
 		}
-		status, _, retryErr := c.requestStatus()
+		status, _, retryErr := c.requestStatus(ctx)
 		if retryErr != nil {
 			return nil, retryErr
 		}
@@ -430,8 +439,8 @@ func (c *CoolerControlClient) getStatus() (*coolerControlStatusResponse, error)
 	return nil, err
 }
 
-func (c *CoolerControlClient) requestStatus() (*coolerControlStatusResponse, int, error) {
-	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/status", nil)
+func (c *CoolerControlClient) requestStatus(ctx context.Context) (*coolerControlStatusResponse, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/status", nil)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -544,8 +553,8 @@ func (c *CoolerControlClient) fetchDeviceMeta() (map[string]coolerControlDeviceN
 	return result, nil
 }
 
-func (c *CoolerControlClient) login() error {
-	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/login", strings.NewReader("{}"))
+func (c *CoolerControlClient) login(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/login", strings.NewReader("{}"))
 	if err != nil {
 		return err
 	}
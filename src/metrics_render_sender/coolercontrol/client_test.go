@@ -2,6 +2,7 @@ package coolercontrol
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -111,7 +112,7 @@ func TestGetStatusUsesFixedSessionUsernameAndSessionCookie(t *testing.T) {
 		deviceMeta: make(map[string]coolerControlDeviceNameMap),
 	}
 
-	status, err := client.getStatus()
+	status, err := client.getStatus(context.Background())
 	if err != nil {
 		t.Fatalf("getStatus returned error: %v", err)
 	}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// cpuTempSensor is one CPU temperature channel.
+type cpuTempSensor struct {
+	Label string
+	Value float64
+}
+
+// slug is the suffix of the channel's go_native.cpu.temp.<slug> monitor.
+func (s cpuTempSensor) slug() string {
+	return slugifyProviderName(s.Label)
+}
+
+// uniqueCPUTempSensorLabels numbers repeated labels, such as Core 0 on each
+// socket of a dual-socket board, so every channel gets its own monitor.
+func uniqueCPUTempSensorLabels(sensors []cpuTempSensor) []cpuTempSensor {
+	seen := make(map[string]int, len(sensors))
+	for idx := range sensors {
+		slug := sensors[idx].slug()
+		seen[slug]++
+		if count := seen[slug]; count > 1 {
+			sensors[idx].Label += " #" + strconv.Itoa(count)
+		}
+	}
+	return sensors
+}
+
+// selectCPUTempSensor finds the channel the temp_sensor option names, by
+// label or monitor suffix and ignoring case, so "Tdie", "tdie" and
+// "package_id_0" all work.
+func selectCPUTempSensor(sensors []cpuTempSensor, name string) (cpuTempSensor, bool) {
+	name = strings.TrimSpace(name)
+	slug := slugifyProviderName(name)
+	for _, sensor := range sensors {
+		if strings.EqualFold(sensor.Label, name) || sensor.slug() == slug {
+			return sensor, true
+		}
+	}
+	return cpuTempSensor{}, false
+}
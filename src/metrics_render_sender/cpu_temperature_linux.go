@@ -0,0 +1,96 @@
+//go:build linux
+
+package main
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const linuxHwmonRoot = "/sys/class/hwmon"
+
+// linuxCPUHwmonChips are the hwmon drivers that report the CPU: AMD
+// k10temp and zenpower, Intel coretemp, and the cpu_thermal zone of ARM
+// boards such as the Raspberry Pi.
+var linuxCPUHwmonChips = []string{"k10temp", "zenpower", "coretemp", "cpu_thermal"}
+
+func readLinuxCPUTemperatureSensors() []cpuTempSensor {
+	entries, err := sensors.ReadDir(linuxHwmonRoot)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	var found []cpuTempSensor
+	for _, name := range names {
+		dir := filepath.Join(linuxHwmonRoot, name)
+		chip := strings.ToLower(readSysfsTrimmed(filepath.Join(dir, "name")))
+		if !isLinuxCPUHwmonChip(chip) {
+			continue
+		}
+		found = append(found, readLinuxHwmonTemperatures(dir)...)
+	}
+	return uniqueCPUTempSensorLabels(found)
+}
+
+func isLinuxCPUHwmonChip(chip string) bool {
+	for _, known := range linuxCPUHwmonChips {
+		if chip == known {
+			return true
+		}
+	}
+	return false
+}
+
+// readLinuxHwmonTemperatures reads temp<N>_input of one chip, labeled by
+// temp<N>_label or, without one, by the channel name.
+func readLinuxHwmonTemperatures(dir string) []cpuTempSensor {
+	entries, err := sensors.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	inputs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, "temp") && strings.HasSuffix(name, "_input") {
+			inputs = append(inputs, name)
+		}
+	}
+	// temp10 comes after temp9.
+	sort.Slice(inputs, func(i, j int) bool {
+		return linuxHwmonChannelIndex(inputs[i]) < linuxHwmonChannelIndex(inputs[j])
+	})
+
+	found := make([]cpuTempSensor, 0, len(inputs))
+	for _, input := range inputs {
+		channel := strings.TrimSuffix(input, "_input")
+		raw, err := sensors.ReadAttrInt(filepath.Join(dir, input))
+		if err != nil {
+			continue
+		}
+		value := float64(raw) / 1000
+		if value <= 0 || value > 130 {
+			continue
+		}
+		label := readSysfsTrimmed(filepath.Join(dir, channel+"_label"))
+		if label == "" {
+			label = channel
+		}
+		found = append(found, cpuTempSensor{Label: label, Value: value})
+	}
+	return found
+}
+
+func linuxHwmonChannelIndex(input string) int {
+	index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(input, "temp"), "_input"))
+	if err != nil {
+		return 0
+	}
+	return index
+}
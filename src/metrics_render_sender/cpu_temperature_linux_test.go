@@ -0,0 +1,55 @@
+//go:build linux
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadLinuxCPUTemperatureSensors(t *testing.T) {
+	useSensorFS(t, fstest.MapFS{
+		"sys/class/hwmon/hwmon0/name":         {Data: []byte("nvme\n")},
+		"sys/class/hwmon/hwmon0/temp1_input":  {Data: []byte("41850\n")},
+		"sys/class/hwmon/hwmon1/name":         {Data: []byte("k10temp\n")},
+		"sys/class/hwmon/hwmon1/temp1_input":  {Data: []byte("62125\n")},
+		"sys/class/hwmon/hwmon1/temp1_label":  {Data: []byte("Tctl\n")},
+		"sys/class/hwmon/hwmon1/temp10_input": {Data: []byte("55000\n")},
+		"sys/class/hwmon/hwmon1/temp10_label": {Data: []byte("Tccd8\n")},
+		"sys/class/hwmon/hwmon1/temp3_input":  {Data: []byte("52250\n")},
+		"sys/class/hwmon/hwmon1/temp3_label":  {Data: []byte("Tccd1\n")},
+		"sys/class/hwmon/hwmon1/temp4_input":  {Data: []byte("0\n")},
+		"sys/class/hwmon/hwmon2/name":         {Data: []byte("coretemp\n")},
+		"sys/class/hwmon/hwmon2/temp2_input":  {Data: []byte("48000\n")},
+		"sys/class/hwmon/hwmon2/temp2_label":  {Data: []byte("Core 0\n")},
+		"sys/class/hwmon/hwmon3/name":         {Data: []byte("coretemp\n")},
+		"sys/class/hwmon/hwmon3/temp2_input":  {Data: []byte("47000\n")},
+		"sys/class/hwmon/hwmon3/temp2_label":  {Data: []byte("Core 0\n")},
+		"sys/class/hwmon/hwmon4/name":         {Data: []byte("cpu_thermal\n")},
+		"sys/class/hwmon/hwmon4/temp1_input":  {Data: []byte("45277\n")},
+	})
+
+	got := readLinuxCPUTemperatureSensors()
+	want := []cpuTempSensor{
+		{Label: "Tctl", Value: 62.125},
+		{Label: "Tccd1", Value: 52.25},
+		{Label: "Tccd8", Value: 55},
+		{Label: "Core 0", Value: 48},
+		{Label: "Core 0 #2", Value: 47},
+		{Label: "temp1", Value: 45.277},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected sensors:\n got %+v\nwant %+v", got, want)
+	}
+
+	for name, label := range map[string]string{"tctl": "Tctl", "core_0_2": "Core 0 #2", "Core 0": "Core 0"} {
+		sensor, ok := selectCPUTempSensor(got, name)
+		if !ok || sensor.Label != label {
+			t.Fatalf("expected %q to select %s, got %+v", name, label, sensor)
+		}
+	}
+	if _, ok := selectCPUTempSensor(got, "Tdie"); ok {
+		t.Fatal("expected Tdie to be missing")
+	}
+}
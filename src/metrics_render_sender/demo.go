@@ -0,0 +1,130 @@
+package main
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+)
+
+// demoMode replaces numeric monitor values with synthetic, animated ones so
+// layouts, threshold colors and charts can be shown off without real load.
+// Text monitors such as the CPU model keep their real values.
+var (
+	demoMode      bool
+	demoStartedAt = time.Now()
+)
+
+// applyDemoValues overwrites the numeric items of one collector after its
+// real update. go_native.system is left alone since it reports on the
+// program itself.
+func applyDemoValues(collector Collector, now time.Time) {
+	if collector == nil || collector.Name() == collectorGoNativeSystem {
+		return
+	}
+	provider, ok := collector.(CollectorItemSnapshotProvider)
+	if !ok {
+		return
+	}
+	elapsed := now.Sub(demoStartedAt).Seconds()
+	for name, item := range provider.ItemsSnapshot() {
+		if item == nil || !item.IsEnabled() {
+			continue
+		}
+		current := item.GetValue()
+		if current == nil {
+			continue
+		}
+		if _, isText := current.Value.(string); isText {
+			continue
+		}
+		value, ok := demoValue(name, current, elapsed)
+		if !ok {
+			continue
+		}
+		item.SetValue(value)
+		item.SetAvailable(true)
+	}
+}
+
+// demoValue picks a waveform by unit: sweeping percentages, slow sine
+// temperatures and fan speeds, and bursty throughput and latency. Each
+// monitor gets its own phase so neighbouring widgets do not move in lockstep.
+func demoValue(name string, current *CollectValue, elapsed float64) (float64, bool) {
+	seed := demoHash(name, 0)
+	phase := float64(seed%1000) / 1000
+	wave := func(period float64) float64 {
+		return 0.5 + 0.5*math.Sin(2*math.Pi*(elapsed/period+phase))
+	}
+	between := func(low, high, fraction float64) float64 {
+		return low + (high-low)*fraction
+	}
+
+	switch strings.ToLower(strings.TrimSpace(current.Unit)) {
+	case "%":
+		// Triangle sweep so bars and gauges visit every threshold band.
+		position := math.Mod(elapsed/20+phase, 1)
+		return 100 * (1 - math.Abs(2*position-1)), true
+	case "°c":
+		return between(30, 90, wave(30)), true
+	case "rpm":
+		return between(700, 2400, wave(25)), true
+	case "mhz":
+		if current.Max > 0 {
+			return between(current.Max*0.4, current.Max, wave(12)), true
+		}
+		return between(800, 4800, wave(12)), true
+	case "w":
+		return between(20, 220, wave(18)), true
+	case "v":
+		return between(11.9, 12.1, wave(9)), true
+	case "gb":
+		if current.Max > 0 {
+			return between(current.Max*0.2, current.Max*0.9, wave(60)), true
+		}
+		return between(8, 24, wave(60)), true
+	case "mib/s", "mb/s":
+		return demoBurst(name, elapsed, 0.5, 120), true
+	case "kib/s", "kb/s":
+		return demoBurst(name, elapsed, 20, 4000), true
+	case "iops":
+		return demoBurst(name, elapsed, 10, 5000), true
+	case "ms":
+		return demoBurst(name, elapsed, 0.2, 25), true
+	case "":
+		// Unitless items are mostly counts and labels; only animate those
+		// that declare a range.
+		if current.Max <= current.Min {
+			return 0, false
+		}
+		return between(current.Min, current.Max, wave(20)), true
+	default:
+		if current.Max > current.Min {
+			return between(current.Min, current.Max, wave(20)), true
+		}
+		return between(0, 100, wave(20)), true
+	}
+}
+
+// demoBurst idles near low with occasional seconds-long bursts towards high,
+// like network or disk traffic.
+func demoBurst(name string, elapsed, low, high float64) float64 {
+	bucket := uint64(elapsed)
+	roll := demoHash(name, bucket)
+	noise := float64(roll%1000) / 1000
+	if roll%5 == 0 {
+		return low + (high-low)*(0.5+0.5*noise)
+	}
+	return low * (1 + noise)
+}
+
+func demoHash(name string, bucket uint64) uint64 {
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(name))
+	var buf [8]byte
+	for idx := range buf {
+		buf[idx] = byte(bucket >> (8 * idx))
+	}
+	_, _ = hasher.Write(buf[:])
+	return hasher.Sum64()
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyDemoValuesAnimatesNumericItems(t *testing.T) {
+	collector := newTestConfigurableCollector("go_native.cpu")
+	usage := NewCollectItem("go_native.cpu.usage", "CPU usage", "%", 0, 100, 0)
+	temp := NewCollectItem("go_native.cpu.temp", "CPU temperature", "°C", 0, 120, 0)
+	model := NewCollectItem("go_native.cpu.model", "CPU model", "", 0, 0, 0)
+	cores := NewCollectItem("go_native.cpu.cores", "CPU cores", "", 0, 0, 0)
+	model.SetValue("Ryzen 7")
+	cores.SetValue(8.0)
+	temp.SetAvailable(false)
+	for _, item := range []*CollectItem{usage, temp, model, cores} {
+		collector.setItem(item.GetName(), item)
+	}
+
+	seen := map[float64]struct{}{}
+	for step := 0; step < 5; step++ {
+		applyDemoValues(collector, demoStartedAt.Add(time.Duration(step)*3*time.Second))
+		value, _ := tryGetFloat64(usage.GetValue().Value)
+		if value < 0 || value > 100 {
+			t.Fatalf("usage out of range: %v", value)
+		}
+		seen[value] = struct{}{}
+	}
+	if len(seen) < 3 {
+		t.Fatalf("expected usage to move over time, got %v", seen)
+	}
+	tempValue, _ := tryGetFloat64(temp.GetValue().Value)
+	if !temp.IsAvailable() || tempValue < 30 || tempValue > 90 {
+		t.Fatalf("expected an available demo temperature, got %v available=%v", tempValue, temp.IsAvailable())
+	}
+	if model.GetValue().Value != "Ryzen 7" {
+		t.Fatalf("expected text monitors to keep their value, got %v", model.GetValue().Value)
+	}
+	if cores.GetValue().Value != 8.0 {
+		t.Fatalf("expected unitless counts to keep their value, got %v", cores.GetValue().Value)
+	}
+}
+
+func TestApplyDemoValuesSkipsSystemCollector(t *testing.T) {
+	collector := newTestConfigurableCollector(collectorGoNativeSystem)
+	item := NewCollectItem("go_native.system.load1", "Load", "%", 0, 100, 0)
+	item.SetValue(1.5)
+	collector.setItem(item.GetName(), item)
+	applyDemoValues(collector, demoStartedAt.Add(7*time.Second))
+	if item.GetValue().Value != 1.5 {
+		t.Fatalf("expected go_native.system to be left alone, got %v", item.GetValue().Value)
+	}
+}
+
+func TestDemoBurstStaysInRange(t *testing.T) {
+	bursts := 0
+	for second := 0; second < 200; second++ {
+		value := demoBurst("go_native.net.1.download", float64(second), 0.5, 120)
+		if value < 0.5 || value > 120 {
+			t.Fatalf("burst value out of range at %ds: %v", second, value)
+		}
+		if value > 10 {
+			bursts++
+		}
+	}
+	if bursts == 0 || bursts > 100 {
+		t.Fatalf("expected occasional bursts, got %d of 200", bursts)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// deviceFilter is the include and exclude regexes of go_native.disk or
+// go_native.network, which decide the devices that get slots and count
+// toward totals, e.g. to leave out a USB backup drive or a VM bridge.
+type deviceFilter struct {
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+// includes reports whether include names any of ids explicitly.
+func (f deviceFilter) includes(ids ...string) bool {
+	return f.include != nil && deviceFilterMatches(f.include, ids)
+}
+
+// allows reports whether a device with the given names passes: exclude
+// must match none of them and include, when set, at least one.
+func (f deviceFilter) allows(ids ...string) bool {
+	if f.exclude != nil && deviceFilterMatches(f.exclude, ids) {
+		return false
+	}
+	return f.include == nil || deviceFilterMatches(f.include, ids)
+}
+
+func (f deviceFilter) key() string {
+	if f.include == nil && f.exclude == nil {
+		return ""
+	}
+	key := ""
+	if f.include != nil {
+		key += f.include.String()
+	}
+	key += "\x00"
+	if f.exclude != nil {
+		key += f.exclude.String()
+	}
+	return key
+}
+
+func deviceFilterMatches(re *regexp.Regexp, ids []string) bool {
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" && re.MatchString(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// deviceFilters holds the filter of each collector. The collectors set it
+// from ApplyConfig, and disk detection and the interface scan read it.
+var deviceFilters = struct {
+	sync.RWMutex
+	byCollector map[string]deviceFilter
+}{byCollector: make(map[string]deviceFilter)}
+
+func setDeviceFilter(collector string, filter deviceFilter) {
+	deviceFilters.Lock()
+	defer deviceFilters.Unlock()
+	deviceFilters.byCollector[collector] = filter
+}
+
+func currentDeviceFilter(collector string) deviceFilter {
+	deviceFilters.RLock()
+	defer deviceFilters.RUnlock()
+	return deviceFilters.byCollector[collector]
+}
+
+// deviceFilterFromConfig compiles the include and exclude options of
+// collector. A pattern that does not compile is logged and ignored.
+func deviceFilterFromConfig(cfg *MonitorConfig, collector string) deviceFilter {
+	if cfg == nil {
+		return deviceFilter{}
+	}
+	return deviceFilter{
+		include: compileDeviceFilterPattern(collector, "include", cfg.GetCollectorStringOption(collector, "include", "")),
+		exclude: compileDeviceFilterPattern(collector, "exclude", cfg.GetCollectorStringOption(collector, "exclude", "")),
+	}
+}
+
+func compileDeviceFilterPattern(collector, option, pattern string) *regexp.Regexp {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		logWarnModule("config", "%s %s pattern ignored: %v", collector, option, err)
+		return nil
+	}
+	return compiled
+}
+
+// diskFilterIDs are the names a disk filter is matched against: the device
+// name, model, serial and /dev/disk/by-id names, so ^usb- in exclude drops
+// every USB-attached disk.
+func diskFilterIDs(disk *DiskInfo) []string {
+	return append([]string{disk.Name, disk.Model, disk.Serial}, disk.ByID...)
+}
+
+// filterDisks drops the disks the go_native.disk filter rejects.
+func filterDisks(disks []*DiskInfo, filter deviceFilter) []*DiskInfo {
+	if filter.include == nil && filter.exclude == nil {
+		return disks
+	}
+	kept := make([]*DiskInfo, 0, len(disks))
+	for _, disk := range disks {
+		if disk != nil && filter.allows(diskFilterIDs(disk)...) {
+			kept = append(kept, disk)
+		}
+	}
+	return kept
+}
+
+// networkInterfaceAllowed applies the go_native.network filter on top of
+// the built-in list of virtual interfaces, which include can override to
+// monitor, say, the br0 bridge a VM host's traffic goes through.
+func networkInterfaceAllowed(filter deviceFilter, name string) bool {
+	if isVirtualInterface(name) && !filter.includes(name) {
+		return false
+	}
+	return filter.allows(name)
+}
@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestDeviceFilterDisks(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	nvme := &DiskInfo{Name: "nvme0n1", Model: "Samsung SSD 980 PRO 1TB"}
+	sata := &DiskInfo{Name: "sda", ByID: []string{"ata-WDC_WD40EFRX_WD-WCC7K1234567"}}
+	backup := &DiskInfo{Name: "sdb", ByID: []string{"usb-WD_Elements_25A3_5758-0:0"}}
+	disks := []*DiskInfo{nvme, sata, backup}
+
+	cases := []struct {
+		include string
+		exclude string
+		want    []*DiskInfo
+	}{
+		{want: disks},
+		{exclude: "^usb-", want: []*DiskInfo{nvme, sata}},
+		{include: "^nvme", want: []*DiskInfo{nvme}},
+		{include: "Samsung|^ata-", exclude: "sda", want: []*DiskInfo{nvme}},
+		{include: "(", exclude: "^sd", want: []*DiskInfo{nvme}},
+	}
+	for _, tc := range cases {
+		cfg := &MonitorConfig{CollectorConfig: map[string]CollectorConfig{
+			collectorGoNativeDisk: {Options: map[string]interface{}{"include": tc.include, "exclude": tc.exclude}},
+		}}
+		got := filterDisks(disks, deviceFilterFromConfig(cfg, collectorGoNativeDisk))
+		if len(got) != len(tc.want) {
+			t.Fatalf("include %q exclude %q: expected %d disks, got %d", tc.include, tc.exclude, len(tc.want), len(got))
+		}
+		for idx := range got {
+			if got[idx] != tc.want[idx] {
+				t.Fatalf("include %q exclude %q: unexpected disk %s", tc.include, tc.exclude, got[idx].Name)
+			}
+		}
+	}
+}
+
+func TestDeviceFilterNetworkInterfaces(t *testing.T) {
+	cfg := &MonitorConfig{CollectorConfig: map[string]CollectorConfig{
+		collectorGoNativeNetwork: {Options: map[string]interface{}{"include": "^(eth|en|br0$)", "exclude": "^enx"}},
+	}}
+	filter := deviceFilterFromConfig(cfg, collectorGoNativeNetwork)
+	for name, want := range map[string]bool{
+		"eth0":            true,
+		"enp3s0":          true,
+		"br0":             true,
+		"enx00e04c680001": false,
+		"wlan0":           false,
+		"virbr0":          false,
+	} {
+		if got := networkInterfaceAllowed(filter, name); got != want {
+			t.Fatalf("%s: expected %v, got %v", name, want, got)
+		}
+	}
+	if networkInterfaceAllowed(deviceFilter{}, "docker0") || !networkInterfaceAllowed(deviceFilter{}, "wlan0") {
+		t.Fatal("expected the built-in virtual interface list without a filter")
+	}
+}
@@ -17,7 +17,7 @@ const (
 	storageDeviceTemperatureID     = 52
 	storagePropertyStandardQuery   = 0
 	storageTemperatureNotReported  = 0x8000
-	windowsDiskHandleRefreshPeriod = int64(time.Minute)
+	windowsDiskHandleRefreshPeriod = time.Minute
 )
 
 type windowsDiskPerformance struct {
@@ -67,25 +67,25 @@ type windowsStorageTemperatureDescriptor struct {
 }
 
 type windowsDiskHandleState struct {
-	mu                        sync.Mutex
-	handles                   map[string]windows.Handle
-	temperatureBlockedUntilNS map[string]int64
-	lastRefreshNS             int64
+	mu                      sync.Mutex
+	handles                 map[string]windows.Handle
+	temperatureBlockedUntil map[string]time.Time
+	lastRefreshAt           time.Time
 }
 
 var windowsDiskHandles = windowsDiskHandleState{
-	handles:                   make(map[string]windows.Handle),
-	temperatureBlockedUntilNS: make(map[string]int64),
+	handles:                 make(map[string]windows.Handle),
+	temperatureBlockedUntil: make(map[string]time.Time),
 }
 
-func readPlatformDiskCounters() (map[string]diskCounterSample, error) {
+func readWindowsDiskCounters() (map[string]diskCounterSample, error) {
 	windowsDiskHandles.mu.Lock()
 	defer windowsDiskHandles.mu.Unlock()
 
-	nowNS := unixNowNS()
-	if nowNS-windowsDiskHandles.lastRefreshNS >= windowsDiskHandleRefreshPeriod || len(windowsDiskHandles.handles) == 0 {
+	now := time.Now()
+	if now.Sub(windowsDiskHandles.lastRefreshAt) >= windowsDiskHandleRefreshPeriod || len(windowsDiskHandles.handles) == 0 {
 		refreshWindowsDiskHandles()
-		windowsDiskHandles.lastRefreshNS = nowNS
+		windowsDiskHandles.lastRefreshAt = now
 	}
 
 	result := make(map[string]diskCounterSample, len(windowsDiskHandles.handles))
@@ -113,7 +113,7 @@ func readPlatformDiskCounters() (map[string]diskCounterSample, error) {
 	return result, nil
 }
 
-func readPlatformDiskTemperatures(deviceNames []string) map[string]diskTemperatureSnapshot {
+func readWindowsDiskTemperatures(deviceNames []string) map[string]diskTemperatureSnapshot {
 	result := make(map[string]diskTemperatureSnapshot, len(deviceNames))
 	if len(deviceNames) == 0 {
 		return result
@@ -122,10 +122,10 @@ func readPlatformDiskTemperatures(deviceNames []string) map[string]diskTemperatu
 	windowsDiskHandles.mu.Lock()
 	defer windowsDiskHandles.mu.Unlock()
 
-	nowNS := unixNowNS()
-	if nowNS-windowsDiskHandles.lastRefreshNS >= windowsDiskHandleRefreshPeriod || len(windowsDiskHandles.handles) == 0 {
+	now := time.Now()
+	if now.Sub(windowsDiskHandles.lastRefreshAt) >= windowsDiskHandleRefreshPeriod || len(windowsDiskHandles.handles) == 0 {
 		refreshWindowsDiskHandles()
-		windowsDiskHandles.lastRefreshNS = nowNS
+		windowsDiskHandles.lastRefreshAt = now
 	}
 
 	for _, deviceName := range deviceNames {
@@ -138,12 +138,12 @@ func readPlatformDiskTemperatures(deviceNames []string) map[string]diskTemperatu
 			if !exists {
 				continue
 			}
-			if blockedUntil := windowsDiskHandles.temperatureBlockedUntilNS[candidate]; blockedUntil > nowNS {
+			if blockedUntil := windowsDiskHandles.temperatureBlockedUntil[candidate]; now.Before(blockedUntil) {
 				break
 			}
 			value, ok := readWindowsDiskTemperature(handle)
 			if !ok {
-				windowsDiskHandles.temperatureBlockedUntilNS[candidate] = nowNS + windowsDiskHandleRefreshPeriod
+				windowsDiskHandles.temperatureBlockedUntil[candidate] = now.Add(windowsDiskHandleRefreshPeriod)
 				break
 			}
 			result[deviceName] = diskTemperatureSnapshot{
@@ -164,7 +164,7 @@ func refreshWindowsDiskHandles() {
 		}
 		_ = windows.CloseHandle(handle)
 		delete(windowsDiskHandles.handles, name)
-		delete(windowsDiskHandles.temperatureBlockedUntilNS, name)
+		delete(windowsDiskHandles.temperatureBlockedUntil, name)
 	}
 	for name := range live {
 		if _, ok := windowsDiskHandles.handles[name]; ok {
@@ -302,7 +302,3 @@ func windowsDiskTimeToMS(value int64) float64 {
 	}
 	return float64(value) / 10000.0
 }
-
-func unixNowNS() int64 {
-	return time.Now().UnixNano()
-}
@@ -1,7 +0,0 @@
-//go:build !windows
-
-package main
-
-func detectDiskInfoByWindows() []*DiskInfo {
-	return []*DiskInfo{}
-}
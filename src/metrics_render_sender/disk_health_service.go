@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	diskHealthPassed = "PASSED"
+	diskHealthFailed = "FAILED"
+
+	defaultDiskHealthInterval = 30 * time.Minute
+	diskHealthTimeout         = 30 * time.Second
+)
+
+type diskHealthEntry struct {
+	checkedAt time.Time
+	status    string
+	running   bool
+}
+
+// diskHealthService runs `smartctl -H` for the disks whose health monitor
+// is in use, in the background and at most once per interval: the overall
+// assessment only changes when a drive starts failing, and waking a
+// sleeping disk every collect pass would keep it spinning.
+type diskHealthService struct {
+	run func(ctx context.Context, device string) ([]byte, error)
+	now func() time.Time
+
+	mu       sync.Mutex
+	interval time.Duration
+	entries  map[string]*diskHealthEntry
+	lastErr  string
+}
+
+var sharedDiskHealthService = newDiskHealthService(newSmartctlHealthRunner())
+
+func newDiskHealthService(run func(ctx context.Context, device string) ([]byte, error)) *diskHealthService {
+	return &diskHealthService{
+		run:      run,
+		now:      sensors.Now,
+		interval: defaultDiskHealthInterval,
+		entries:  make(map[string]*diskHealthEntry),
+	}
+}
+
+func newSmartctlHealthRunner() func(ctx context.Context, device string) ([]byte, error) {
+	path, err := exec.LookPath("smartctl")
+	if err != nil {
+		return nil
+	}
+	return func(ctx context.Context, device string) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, path, "-H", "-j", device)
+		hideCommandWindow(cmd)
+		// Bit 3 of the exit status means the disk is failing, and the JSON
+		// says so too; only output that does not parse is an error.
+		output, err := cmd.Output()
+		if len(output) > 0 {
+			return output, nil
+		}
+		return nil, err
+	}
+}
+
+// SetInterval changes how often each disk is checked.
+func (s *diskHealthService) SetInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultDiskHealthInterval
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interval = interval
+}
+
+// Read returns the last known status of each device, PASSED or FAILED,
+// and starts a check for the ones that are due. Devices not checked yet,
+// or whose check failed, are missing from the result.
+func (s *diskHealthService) Read(deviceNames []string) map[string]string {
+	result := make(map[string]string, len(deviceNames))
+	if s.run == nil {
+		return result
+	}
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, deviceName := range deviceNames {
+		name := normalizeDiskBaseName(deviceName, "")
+		if name == "" {
+			continue
+		}
+		entry := s.entries[name]
+		if entry == nil {
+			entry = &diskHealthEntry{}
+			s.entries[name] = entry
+		}
+		if !entry.running && (entry.checkedAt.IsZero() || now.Sub(entry.checkedAt) >= s.interval) {
+			entry.running = true
+			go s.check(name, entry)
+		}
+		if entry.status != "" {
+			result[name] = entry.status
+		}
+	}
+	return result
+}
+
+// Failing reports whether any checked disk failed its assessment.
+func (s *diskHealthService) Failing() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if entry.status == diskHealthFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Forget drops devices that were removed, so a replaced drive is checked
+// afresh and a failed one that was pulled stops raising the alert.
+func (s *diskHealthService) Forget(deviceNames []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, deviceName := range deviceNames {
+		delete(s.entries, normalizeDiskBaseName(deviceName, ""))
+	}
+}
+
+func (s *diskHealthService) check(name string, entry *diskHealthEntry) {
+	ctx, cancel := context.WithTimeout(context.Background(), diskHealthTimeout)
+	defer cancel()
+	output, err := s.run(ctx, smartctlDevicePath(name))
+	status, ok := "", false
+	if err == nil {
+		status, ok = parseSmartctlHealth(output)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry.running = false
+	entry.checkedAt = s.now()
+	if ok {
+		entry.status = status
+		if status == diskHealthFailed {
+			logWarnModule("disk", "%s failed its SMART overall-health self-assessment", name)
+		}
+		return
+	}
+	entry.status = ""
+	// smartctl without root fails the same way for every disk.
+	message := "no SMART health status"
+	if err != nil {
+		message = err.Error()
+	}
+	if message != s.lastErr {
+		s.lastErr = message
+		logWarnModule("disk", "smartctl -H %s: %s", name, message)
+	}
+}
+
+// smartctlDevicePath is /dev/<name>; on Windows smartctl takes a drive
+// letter such as C: for the physical drive holding it.
+func smartctlDevicePath(name string) string {
+	if runtime.GOOS == "windows" {
+		return name
+	}
+	return "/dev/" + name
+}
+
+// parseSmartctlHealth reads smart_status.passed from `smartctl -H -j`.
+func parseSmartctlHealth(data []byte) (string, bool) {
+	var report struct {
+		SmartStatus *struct {
+			Passed *bool `json:"passed"`
+		} `json:"smart_status"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(data))), &report); err != nil {
+		return "", false
+	}
+	if report.SmartStatus == nil || report.SmartStatus.Passed == nil {
+		return "", false
+	}
+	if *report.SmartStatus.Passed {
+		return diskHealthPassed, true
+	}
+	return diskHealthFailed, true
+}
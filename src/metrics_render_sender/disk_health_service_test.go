@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiskHealthServiceChecksInBackgroundOncePerInterval(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	var mu sync.Mutex
+	runs := map[string]int{}
+	service := newDiskHealthService(func(ctx context.Context, device string) ([]byte, error) {
+		mu.Lock()
+		runs[device]++
+		mu.Unlock()
+		switch device {
+		case "/dev/sda":
+			return []byte(`{"smart_status":{"passed":true}}`), nil
+		case "/dev/sdb":
+			return []byte(`{"smart_status":{"passed":false}}`), nil
+		}
+		return nil, errors.New("permission denied")
+	})
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var clockMu sync.Mutex
+	now := start
+	service.now = func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		return now
+	}
+	service.SetInterval(time.Hour)
+
+	devices := []string{"sda", "sdb1", "nvme0n1"}
+	var statuses map[string]string
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		statuses = service.Read(devices)
+		if len(statuses) == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("checks did not finish, got %+v", statuses)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if statuses["sda"] != diskHealthPassed || statuses["sdb"] != diskHealthFailed {
+		t.Fatalf("unexpected statuses %+v", statuses)
+	}
+	if !service.Failing() {
+		t.Fatal("expected a failing disk to be reported")
+	}
+
+	clockMu.Lock()
+	now = start.Add(30 * time.Minute)
+	clockMu.Unlock()
+	service.Read(devices)
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	if runs["/dev/sda"] != 1 || runs["/dev/sdb"] != 1 {
+		t.Fatalf("expected one check per disk within the interval, got %+v", runs)
+	}
+	mu.Unlock()
+
+	service.Forget([]string{"sdb"})
+	if service.Failing() {
+		t.Fatal("expected a removed disk to stop alerting")
+	}
+}
+
+func TestParseSmartctlHealth(t *testing.T) {
+	cases := map[string]string{
+		`{"smart_status":{"passed":true}}`:  diskHealthPassed,
+		`{"smart_status":{"passed":false}}`: diskHealthFailed,
+		`{"smartctl":{"exit_status":2}}`:    "",
+		`not json`:                          "",
+	}
+	for input, want := range cases {
+		got, ok := parseSmartctlHealth([]byte(input))
+		if got != want || ok != (want != "") {
+			t.Fatalf("%s: expected %q, got %q/%v", input, want, got, ok)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	diskTempSysfsTTL     = time.Second
+	diskTempSmartctlTTL  = time.Minute
+	diskTempSmartTimeout = 5 * time.Second
+)
+
+// diskTempReader reads the sensor a device is bound to.
+type diskTempReader func() (float64, bool)
+
+// diskTempProbe is one rung of the fallback ladder: bind looks for a sensor
+// of its kind for a device, and readings are reused for ttl.
+type diskTempProbe struct {
+	source string
+	ttl    time.Duration
+	bind   func(name string) (diskTempReader, bool)
+}
+
+type diskTempBinding struct {
+	source   string
+	ttl      time.Duration
+	read     diskTempReader
+	probedAt time.Time
+	readAt   time.Time
+	value    float64
+	ok       bool
+}
+
+// diskTempService is the one place disk temperatures come from. Each device
+// is bound to the first probe that finds a sensor for it; the binding is
+// kept until a read fails, and devices without a sensor are probed again
+// after diskScanPeriod.
+type diskTempService struct {
+	probes []diskTempProbe
+	now    func() time.Time
+
+	mu       sync.Mutex
+	bindings map[string]*diskTempBinding
+}
+
+var sharedDiskTempService = newDiskTempService(defaultDiskTempProbes())
+
+func newDiskTempService(probes []diskTempProbe) *diskTempService {
+	return &diskTempService{
+		probes:   probes,
+		now:      sensors.Now,
+		bindings: make(map[string]*diskTempBinding),
+	}
+}
+
+// defaultDiskTempProbes is the platform's sysfs ladder followed by smartctl
+// when it is installed.
+func defaultDiskTempProbes() []diskTempProbe {
+	probes := platformDiskTempProbes()
+	if probe, ok := newSmartctlDiskTempProbe(); ok {
+		probes = append(probes, probe)
+	}
+	return probes
+}
+
+func (s *diskTempService) Read(deviceNames []string) map[string]diskTemperatureSnapshot {
+	result := make(map[string]diskTemperatureSnapshot, len(deviceNames))
+	if len(deviceNames) == 0 {
+		return result
+	}
+	now := s.now()
+	// Binding and reading may run smartctl for seconds, so the devices that
+	// need it are picked under the lock and probed without it.
+	type diskTempWork struct {
+		name string
+		// seen is the binding the device had when picked; a result is only
+		// stored while it is still in place.
+		seen *diskTempBinding
+		bind bool
+	}
+	var work []diskTempWork
+	picked := make(map[string]bool, len(deviceNames))
+	s.mu.Lock()
+	for _, deviceName := range deviceNames {
+		name := normalizeDiskBaseName(deviceName, "")
+		if name == "" || picked[name] {
+			continue
+		}
+		picked[name] = true
+		binding := s.bindings[name]
+		if binding == nil || (binding.read == nil && now.Sub(binding.probedAt) >= diskScanPeriod) {
+			work = append(work, diskTempWork{name: name, seen: binding, bind: true})
+			continue
+		}
+		if binding.read == nil {
+			continue
+		}
+		if binding.readAt.IsZero() || now.Sub(binding.readAt) >= binding.ttl {
+			work = append(work, diskTempWork{name: name, seen: binding})
+			continue
+		}
+		if binding.ok {
+			result[name] = diskTemperatureSnapshot{Temperature: binding.value, OK: true}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, item := range work {
+		binding := item.seen
+		if item.bind {
+			binding = s.bind(item.name, now)
+		}
+		var value float64
+		var ok bool
+		if binding.read != nil {
+			value, ok = binding.read()
+		}
+
+		s.mu.Lock()
+		if s.bindings[item.name] != item.seen {
+			// Forgotten or rebound while probing.
+			s.mu.Unlock()
+			continue
+		}
+		switch {
+		case binding.read == nil:
+			s.bindings[item.name] = binding
+		case !ok:
+			// Drop the binding so the ladder is walked again later; the
+			// sensor may have moved, e.g. after a driver reload.
+			s.bindings[item.name] = &diskTempBinding{probedAt: now}
+		default:
+			binding.value, binding.ok, binding.readAt = value, true, now
+			s.bindings[item.name] = binding
+			result[item.name] = diskTemperatureSnapshot{Temperature: value, OK: true}
+		}
+		s.mu.Unlock()
+	}
+	return result
+}
+
+// Source reports which probe a device is bound to, or "" when none.
+func (s *diskTempService) Source(deviceName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if binding := s.bindings[normalizeDiskBaseName(deviceName, "")]; binding != nil {
+		return binding.source
+	}
+	return ""
+}
+
+// Forget drops the bindings of devices that were added or removed, so the
+// next read walks the ladder again.
+func (s *diskTempService) Forget(deviceNames []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, deviceName := range deviceNames {
+		delete(s.bindings, normalizeDiskBaseName(deviceName, ""))
+	}
+}
+
+// RetryUnbound makes devices without a sensor probe again on the next read,
+// e.g. after a hwmon driver loads.
+func (s *diskTempService) RetryUnbound() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, binding := range s.bindings {
+		if binding.read == nil {
+			delete(s.bindings, name)
+		}
+	}
+}
+
+func (s *diskTempService) bind(name string, now time.Time) *diskTempBinding {
+	for _, probe := range s.probes {
+		if read, ok := probe.bind(name); ok {
+			return &diskTempBinding{source: probe.source, ttl: probe.ttl, read: read, probedAt: now}
+		}
+	}
+	return &diskTempBinding{probedAt: now}
+}
+
+func newSmartctlDiskTempProbe() (diskTempProbe, bool) {
+	path, err := exec.LookPath("smartctl")
+	if err != nil {
+		return diskTempProbe{}, false
+	}
+	return smartctlDiskTempProbe(func(ctx context.Context, device string) ([]byte, error) {
+		// smartctl sets bits in its exit status for health warnings while
+		// still printing valid JSON, so the output is parsed regardless.
+		output, _ := exec.CommandContext(ctx, path, "-A", "-j", device).Output()
+		return output, nil
+	}), true
+}
+
+func smartctlDiskTempProbe(run func(ctx context.Context, device string) ([]byte, error)) diskTempProbe {
+	return diskTempProbe{
+		source: "smartctl",
+		ttl:    diskTempSmartctlTTL,
+		bind: func(name string) (diskTempReader, bool) {
+			device := "/dev/" + name
+			read := func() (float64, bool) {
+				ctx, cancel := context.WithTimeout(context.Background(), diskTempSmartTimeout)
+				defer cancel()
+				output, err := run(ctx, device)
+				if err != nil {
+					return 0, false
+				}
+				return parseSmartctlTemperature(output)
+			}
+			if _, ok := read(); !ok {
+				return nil, false
+			}
+			return read, true
+		},
+	}
+}
+
+// parseSmartctlTemperature reads the drive temperature from `smartctl -j`
+// output, using the NVMe health log when the generic field is missing.
+func parseSmartctlTemperature(data []byte) (float64, bool) {
+	var report struct {
+		Temperature *struct {
+			Current *float64 `json:"current"`
+		} `json:"temperature"`
+		NVMeHealth *struct {
+			Temperature *float64 `json:"temperature"`
+		} `json:"nvme_smart_health_information_log"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(data))), &report); err != nil {
+		return 0, false
+	}
+	var value *float64
+	if report.Temperature != nil && report.Temperature.Current != nil {
+		value = report.Temperature.Current
+	} else if report.NVMeHealth != nil {
+		value = report.NVMeHealth.Temperature
+	}
+	if value == nil || *value < DiskTempMin || *value > DiskTempMax {
+		return 0, false
+	}
+	return *value, true
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDiskTempServiceFallsBackAndCachesPerSource(t *testing.T) {
+	sysfsValue, sysfsOK := 41.0, true
+	smartReads := 0
+	service := newDiskTempService([]diskTempProbe{
+		{source: "hwmon", ttl: diskTempSysfsTTL, bind: func(name string) (diskTempReader, bool) {
+			if name != "sda" || !sysfsOK {
+				return nil, false
+			}
+			return func() (float64, bool) { return sysfsValue, sysfsOK }, true
+		}},
+		smartctlDiskTempProbe(func(ctx context.Context, device string) ([]byte, error) {
+			smartReads++
+			if device != "/dev/nvme0n1" {
+				return nil, context.Canceled
+			}
+			return []byte(`{"nvme_smart_health_information_log":{"temperature":48}}`), nil
+		}),
+	})
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	service.now = func() time.Time { return now }
+
+	temps := service.Read([]string{"sda1", "nvme0n1p2", "sdb"})
+	if got := temps["sda"]; !got.OK || got.Temperature != 41 {
+		t.Fatalf("unexpected sda reading %+v", got)
+	}
+	if got := temps["nvme0n1"]; !got.OK || got.Temperature != 48 {
+		t.Fatalf("unexpected nvme0n1 reading %+v", got)
+	}
+	if _, ok := temps["sdb"]; ok {
+		t.Fatal("expected no reading for a disk without a sensor")
+	}
+	if service.Source("sda") != "hwmon" || service.Source("nvme0n1") != "smartctl" {
+		t.Fatalf("unexpected sources %q %q", service.Source("sda"), service.Source("nvme0n1"))
+	}
+
+	// sysfs is read again after its short TTL; smartctl is not.
+	readsAfterBind := smartReads
+	now = start.Add(2 * time.Second)
+	sysfsValue = 43
+	temps = service.Read([]string{"sda", "nvme0n1", "sdb"})
+	if temps["sda"].Temperature != 43 || smartReads != readsAfterBind {
+		t.Fatalf("unexpected refresh: sda=%+v smartctl reads=%d", temps["sda"], smartReads-readsAfterBind)
+	}
+
+	// A failed read drops the binding until the next scan.
+	sysfsOK = false
+	now = now.Add(2 * time.Second)
+	if _, ok := service.Read([]string{"sda"})["sda"]; ok || service.Source("sda") != "" {
+		t.Fatal("expected a failed read to unbind the sensor")
+	}
+	sysfsOK = true
+	now = now.Add(diskScanPeriod)
+	if got := service.Read([]string{"sda"})["sda"]; !got.OK {
+		t.Fatal("expected the sensor to be bound again after the scan period")
+	}
+}
+
+func TestParseSmartctlTemperature(t *testing.T) {
+	cases := []struct {
+		data string
+		want float64
+		ok   bool
+	}{
+		{`{"temperature":{"current":35}}`, 35, true},
+		{`{"temperature":{"current":36},"nvme_smart_health_information_log":{"temperature":50}}`, 36, true},
+		{`{"nvme_smart_health_information_log":{"temperature":50}}`, 50, true},
+		{`{"smartctl":{"exit_status":2}}`, 0, false},
+		{`{"temperature":{"current":500}}`, 0, false},
+		{``, 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := parseSmartctlTemperature([]byte(tc.data))
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("parseSmartctlTemperature(%s) = %v, %v; want %v, %v", tc.data, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestDiskTempServiceProbesWithoutTheLock(t *testing.T) {
+	release := make(chan struct{})
+	probing := make(chan struct{})
+	service := newDiskTempService([]diskTempProbe{
+		smartctlDiskTempProbe(func(ctx context.Context, device string) ([]byte, error) {
+			select {
+			case probing <- struct{}{}:
+			default:
+			}
+			<-release
+			return []byte(`{"temperature":{"current":37}}`), nil
+		}),
+	})
+
+	done := make(chan map[string]diskTemperatureSnapshot, 1)
+	go func() { done <- service.Read([]string{"sda"}) }()
+	<-probing
+	sourced := make(chan string, 1)
+	go func() { sourced <- service.Source("sda") }()
+	select {
+	case <-sourced:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Source to answer while smartctl runs")
+	}
+	close(release)
+	if got := (<-done)["sda"]; !got.OK || got.Temperature != 37 {
+		t.Fatalf("unexpected sda reading %+v", got)
+	}
+	if service.Source("sda") != "smartctl" {
+		t.Fatalf("expected sda to be bound to smartctl, got %q", service.Source("sda"))
+	}
+}
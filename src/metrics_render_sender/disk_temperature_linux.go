@@ -3,72 +3,71 @@
 package main
 
 import (
-	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
-	"sync"
-	"time"
 )
 
-type linuxDiskTemperatureState struct {
-	paths     []string
-	lastProbe time.Time
-}
+var linuxNVMeNamespacePattern = regexp.MustCompile(`^nvme(\d+)n\d+$`)
 
-var linuxDiskTemperatureCache = struct {
-	mu     sync.Mutex
-	states map[string]linuxDiskTemperatureState
-}{
-	states: make(map[string]linuxDiskTemperatureState),
-}
+// linuxDiskDeviceParentDepth is how far up the resolved device path hwmon
+// nodes are looked for, e.g. a SATA bridge exposing drivetemp on the port.
+const linuxDiskDeviceParentDepth = 2
 
-func readLinuxDiskTemperatures(deviceNames []string) map[string]diskTemperatureSnapshot {
-	result := make(map[string]diskTemperatureSnapshot, len(deviceNames))
-	if len(deviceNames) == 0 {
-		return result
+// platformDiskTempProbes is the sysfs part of the ladder: hwmon on the block
+// device, hwmon on its parent devices, then the NVMe controller.
+func platformDiskTempProbes() []diskTempProbe {
+	return []diskTempProbe{
+		{source: "hwmon", ttl: diskTempSysfsTTL, bind: bindLinuxDiskHwmon},
+		{source: "device", ttl: diskTempSysfsTTL, bind: bindLinuxDiskDeviceParents},
+		{source: "nvme", ttl: diskTempSysfsTTL, bind: bindLinuxNVMeController},
 	}
+}
 
-	now := time.Now()
-	linuxDiskTemperatureCache.mu.Lock()
-	defer linuxDiskTemperatureCache.mu.Unlock()
+func bindLinuxDiskHwmon(baseName string) (diskTempReader, bool) {
+	return linuxDiskTemperatureReader(filepath.Join("/sys/block", baseName, "device"))
+}
 
-	for _, deviceName := range deviceNames {
-		name := normalizeDiskBaseName(deviceName, "")
-		if name == "" {
-			continue
-		}
-		state := linuxDiskTemperatureCache.states[name]
-		if len(state.paths) == 0 && (state.lastProbe.IsZero() || now.Sub(state.lastProbe) >= diskScanPeriod) {
-			state.paths = discoverLinuxDiskTemperaturePaths(name)
-			state.lastProbe = now
-			linuxDiskTemperatureCache.states[name] = state
-		}
-		if len(state.paths) == 0 {
-			continue
+func bindLinuxDiskDeviceParents(baseName string) (diskTempReader, bool) {
+	devicePath, err := sensors.EvalSymlinks(filepath.Join("/sys/block", baseName, "device"))
+	if err != nil {
+		return nil, false
+	}
+	for depth := 0; depth < linuxDiskDeviceParentDepth; depth++ {
+		devicePath = filepath.Dir(devicePath)
+		if read, ok := linuxDiskTemperatureReader(devicePath); ok {
+			return read, true
 		}
+	}
+	return nil, false
+}
 
-		value, ok := readMaxLinuxDiskTemperature(state.paths)
-		if !ok {
-			state.paths = nil
-			state.lastProbe = now
-			linuxDiskTemperatureCache.states[name] = state
-			continue
-		}
-		result[name] = diskTemperatureSnapshot{
-			Temperature: value,
-			OK:          true,
-		}
+// bindLinuxNVMeController covers namespaces without a device link, as with
+// native NVMe multipath, by going to the controller the name points at.
+func bindLinuxNVMeController(baseName string) (diskTempReader, bool) {
+	match := linuxNVMeNamespacePattern.FindStringSubmatch(baseName)
+	if match == nil {
+		return nil, false
 	}
-	return result
+	controllerPath := filepath.Join("/sys/class/nvme", "nvme"+match[1])
+	if read, ok := linuxDiskTemperatureReader(controllerPath); ok {
+		return read, true
+	}
+	return linuxDiskTemperatureReader(filepath.Join(controllerPath, "device"))
 }
 
-func discoverLinuxDiskTemperaturePaths(baseName string) []string {
-	baseName = normalizeDiskBaseName(baseName, "")
-	if baseName == "" {
-		return nil
+func linuxDiskTemperatureReader(devicePath string) (diskTempReader, bool) {
+	paths := discoverLinuxDiskTemperaturePaths(devicePath)
+	if len(paths) == 0 {
+		return nil, false
 	}
-	devicePath := filepath.Join("/sys/block", baseName, "device")
+	return func() (float64, bool) {
+		return readMaxLinuxDiskTemperature(paths)
+	}, true
+}
+
+func discoverLinuxDiskTemperaturePaths(devicePath string) []string {
 	hwmonDirs := linuxDiskHwmonDirs(devicePath)
 	if len(hwmonDirs) == 0 {
 		return nil
@@ -98,7 +97,7 @@ func selectLinuxDiskTemperaturePath(hwmonDir string) string {
 		return ""
 	}
 
-	entries, err := os.ReadDir(hwmonDir)
+	entries, err := sensors.ReadDir(hwmonDir)
 	if err != nil {
 		return ""
 	}
@@ -130,7 +129,7 @@ func selectLinuxDiskTemperaturePath(hwmonDir string) string {
 }
 
 func linuxDiskHwmonDirs(devicePath string) []string {
-	entries, err := os.ReadDir(devicePath)
+	entries, err := sensors.ReadDir(devicePath)
 	if err != nil {
 		return nil
 	}
@@ -141,14 +140,17 @@ func linuxDiskHwmonDirs(devicePath string) []string {
 		}
 		name := entry.Name()
 		path := filepath.Join(devicePath, name)
-		if strings.HasPrefix(name, "hwmon") && linuxPathIsDir(path) {
-			dirs = append(dirs, path)
+		if name != "hwmon" {
+			if strings.HasPrefix(name, "hwmon") && sensors.IsDir(path) {
+				dirs = append(dirs, path)
+			}
 			continue
 		}
-		if name != "hwmon" || !linuxPathIsDir(path) {
+		// drivetemp and friends register under a "hwmon" class directory.
+		if !sensors.IsDir(path) {
 			continue
 		}
-		children, err := os.ReadDir(path)
+		children, err := sensors.ReadDir(path)
 		if err != nil {
 			continue
 		}
@@ -158,7 +160,7 @@ func linuxDiskHwmonDirs(devicePath string) []string {
 			}
 			childName := child.Name()
 			childPath := filepath.Join(path, childName)
-			if strings.HasPrefix(childName, "hwmon") && linuxPathIsDir(childPath) {
+			if strings.HasPrefix(childName, "hwmon") && sensors.IsDir(childPath) {
 				dirs = append(dirs, childPath)
 			}
 		}
@@ -166,11 +168,6 @@ func linuxDiskHwmonDirs(devicePath string) []string {
 	return dirs
 }
 
-func linuxPathIsDir(path string) bool {
-	info, err := os.Stat(path)
-	return err == nil && info.IsDir()
-}
-
 func readMaxLinuxDiskTemperature(paths []string) (float64, bool) {
 	maxTemp := 0.0
 	ok := false
@@ -188,56 +185,13 @@ func readMaxLinuxDiskTemperature(paths []string) (float64, bool) {
 }
 
 func readLinuxTemperatureInput(path string) (float64, bool) {
-	file, err := os.Open(path)
+	raw, err := sensors.ReadAttrInt(path)
 	if err != nil {
 		return 0, false
 	}
-	defer file.Close()
-
-	var buf [32]byte
-	n, _ := file.Read(buf[:])
-	if n <= 0 {
-		return 0, false
-	}
-	raw, ok := parseLinuxTemperatureMilliCelsius(buf[:n])
-	if !ok {
-		return 0, false
-	}
 	value := float64(raw) / 1000.0
 	if value < DiskTempMin || value > DiskTempMax {
 		return 0, false
 	}
 	return value, true
 }
-
-func parseLinuxTemperatureMilliCelsius(data []byte) (int64, bool) {
-	i := 0
-	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
-		i++
-	}
-	if i >= len(data) {
-		return 0, false
-	}
-	sign := int64(1)
-	if data[i] == '-' {
-		sign = -1
-		i++
-	} else if data[i] == '+' {
-		i++
-	}
-	value := int64(0)
-	digits := 0
-	for i < len(data) {
-		ch := data[i]
-		if ch < '0' || ch > '9' {
-			break
-		}
-		value = value*10 + int64(ch-'0')
-		digits++
-		i++
-	}
-	if digits == 0 {
-		return 0, false
-	}
-	return value * sign, true
-}
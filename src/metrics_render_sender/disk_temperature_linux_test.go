@@ -0,0 +1,73 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFakeSysfsFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLinuxDiskTempProbesWalkTheLadder(t *testing.T) {
+	root := t.TempDir()
+	useSensorFS(t, newHostFS(root))
+
+	// sda: drivetemp directly on the block device.
+	writeFakeSysfsFile(t, filepath.Join(root, "sys/block/sda/device/hwmon/hwmon3/name"), "drivetemp\n")
+	writeFakeSysfsFile(t, filepath.Join(root, "sys/block/sda/device/hwmon/hwmon3/temp1_input"), "38000\n")
+
+	// sdb: the sensor sits on the bridge the disk hangs off.
+	port := filepath.Join(root, "sys/devices/usb1/port1")
+	writeFakeSysfsFile(t, filepath.Join(port, "hwmon5/name"), "drivetemp\n")
+	writeFakeSysfsFile(t, filepath.Join(port, "hwmon5/temp1_input"), "44000\n")
+	if err := os.MkdirAll(filepath.Join(port, "target0/0:0:0:0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "sys/block/sdb"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(port, "target0/0:0:0:0"), filepath.Join(root, "sys/block/sdb/device")); err != nil {
+		t.Fatal(err)
+	}
+
+	// nvme1n1: no device link, only the controller's composite sensor.
+	writeFakeSysfsFile(t, filepath.Join(root, "sys/class/nvme/nvme1/hwmon2/name"), "nvme\n")
+	writeFakeSysfsFile(t, filepath.Join(root, "sys/class/nvme/nvme1/hwmon2/temp1_label"), "Composite\n")
+	writeFakeSysfsFile(t, filepath.Join(root, "sys/class/nvme/nvme1/hwmon2/temp1_input"), "51850\n")
+	writeFakeSysfsFile(t, filepath.Join(root, "sys/class/nvme/nvme1/hwmon2/temp2_label"), "Sensor 1\n")
+	writeFakeSysfsFile(t, filepath.Join(root, "sys/class/nvme/nvme1/hwmon2/temp2_input"), "70000\n")
+
+	service := newDiskTempService(platformDiskTempProbes())
+	temps := service.Read([]string{"sda", "sdb", "nvme1n1", "sdc"})
+
+	want := map[string]struct {
+		temp   float64
+		source string
+	}{
+		"sda":     {38, "hwmon"},
+		"sdb":     {44, "device"},
+		"nvme1n1": {51.85, "nvme"},
+	}
+	for name, expected := range want {
+		got := temps[name]
+		if !got.OK || got.Temperature != expected.temp {
+			t.Fatalf("%s: unexpected reading %+v", name, got)
+		}
+		if source := service.Source(name); source != expected.source {
+			t.Fatalf("%s: expected source %q, got %q", name, expected.source, source)
+		}
+	}
+	if _, ok := temps["sdc"]; ok {
+		t.Fatal("expected no reading for a missing disk")
+	}
+}
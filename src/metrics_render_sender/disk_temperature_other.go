@@ -2,6 +2,6 @@
 
 package main
 
-func readLinuxDiskTemperatures(deviceNames []string) map[string]diskTemperatureSnapshot {
-	return map[string]diskTemperatureSnapshot{}
+func platformDiskTempProbes() []diskTempProbe {
+	return nil
 }
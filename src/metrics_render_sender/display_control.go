@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Display control actions shared by every input source: Web UI shortcuts,
+// the control API and GPIO buttons.
+const (
+	displayActionNextProfile = "next_profile"
+	displayActionPrevProfile = "prev_profile"
+	displayActionPause       = "pause"
+	displayActionScreenshot  = "screenshot"
+	displayActionBrightness  = "brightness"
+	displayActionTimerToggle = "timer_toggle"
+	displayActionTimerStart  = "timer_start"
+	displayActionTimerPause  = "timer_pause"
+	displayActionTimerReset  = "timer_reset"
+	displayActionTimerSkip   = "timer_skip"
+)
+
+// displayBrightnessSteps are the backlight levels the brightness action cycles
+// through, ending with the panel dark.
+var displayBrightnessSteps = []int{7, 4, 1, 0}
+
+func normalizeDisplayAction(action string) string {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case displayActionNextProfile, "next", "next_page":
+		return displayActionNextProfile
+	case displayActionPrevProfile, "prev", "previous", "prev_page", "previous_profile":
+		return displayActionPrevProfile
+	case displayActionPause, "toggle_pause":
+		return displayActionPause
+	case displayActionScreenshot, "snapshot":
+		return displayActionScreenshot
+	case displayActionBrightness, "backlight":
+		return displayActionBrightness
+	case displayActionTimerToggle, "timer", "pomodoro":
+		return displayActionTimerToggle
+	case displayActionTimerStart, displayActionTimerPause, displayActionTimerReset, displayActionTimerSkip:
+		return strings.ToLower(strings.TrimSpace(action))
+	default:
+		return ""
+	}
+}
+
+// runDisplayAction performs action and returns a short human readable result.
+func runDisplayAction(action string) (string, error) {
+	switch normalizeDisplayAction(action) {
+	case displayActionNextProfile:
+		name, err := switchAdjacentProfile(1)
+		if err != nil {
+			return "", err
+		}
+		return "profile " + name, nil
+	case displayActionPrevProfile:
+		name, err := switchAdjacentProfile(-1)
+		if err != nil {
+			return "", err
+		}
+		return "profile " + name, nil
+	case displayActionPause:
+		runtime := CurrentSharedWebAPI()
+		if runtime == nil {
+			return "", fmt.Errorf("runtime is not running")
+		}
+		paused := !runtime.IsPaused()
+		runtime.SetPaused(paused)
+		if paused {
+			return "paused", nil
+		}
+		return "resumed", nil
+	case displayActionScreenshot:
+		path, err := saveDisplayScreenshot(time.Now())
+		if err != nil {
+			return "", err
+		}
+		return "screenshot " + path, nil
+	case displayActionBrightness:
+		level := nextDisplayBrightness(AX206Brightness())
+		SetAX206Brightness(level)
+		return fmt.Sprintf("brightness %d", level), nil
+	case displayActionTimerToggle, displayActionTimerStart, displayActionTimerPause, displayActionTimerReset, displayActionTimerSkip:
+		status := sharedPomodoroTimer.apply(normalizeDisplayAction(action), time.Now())
+		return describePomodoroStatus(status), nil
+	default:
+		return "", fmt.Errorf("unknown action %q", strings.TrimSpace(action))
+	}
+}
+
+func nextDisplayBrightness(current int) int {
+	for idx, level := range displayBrightnessSteps {
+		if current >= level {
+			return displayBrightnessSteps[(idx+1)%len(displayBrightnessSteps)]
+		}
+	}
+	return displayBrightnessSteps[0]
+}
+
+// saveDisplayScreenshot writes the latest rendered frame to the screenshots
+// directory next to the user config.
+func saveDisplayScreenshot(now time.Time) (string, error) {
+	pngData, ok := GetMemImgPNG()
+	if !ok || len(pngData) == 0 {
+		return "", fmt.Errorf("no rendered frame available")
+	}
+	configDir, err := getUserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(configDir, "screenshots")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "screenshot-"+now.Format("20060102-150405")+".png")
+	if err := os.WriteFile(path, pngData, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
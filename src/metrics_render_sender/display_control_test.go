@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestNextDisplayBrightnessCycles(t *testing.T) {
+	cases := map[int]int{7: 4, 5: 1, 4: 1, 1: 0, 0: 7}
+	for current, want := range cases {
+		if got := nextDisplayBrightness(current); got != want {
+			t.Fatalf("nextDisplayBrightness(%d)=%d want=%d", current, got, want)
+		}
+	}
+}
+
+func TestNormalizeDisplayAction(t *testing.T) {
+	cases := map[string]string{
+		" Next ":       displayActionNextProfile,
+		"prev_page":    displayActionPrevProfile,
+		"toggle_pause": displayActionPause,
+		"snapshot":     displayActionScreenshot,
+		"backlight":    displayActionBrightness,
+		"Pomodoro":     displayActionTimerToggle,
+		"timer_skip":   displayActionTimerSkip,
+		"reboot":       "",
+	}
+	for input, want := range cases {
+		if got := normalizeDisplayAction(input); got != want {
+			t.Fatalf("normalizeDisplayAction(%q)=%q want=%q", input, got, want)
+		}
+	}
+}
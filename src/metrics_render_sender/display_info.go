@@ -1,7 +1,6 @@
 package main
 
 import (
-	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -95,11 +94,11 @@ func detectDisplayByDRMMode() (int, int, bool) {
 		return 0, 0, false
 	}
 	for _, path := range matches {
-		data, readErr := os.ReadFile(path)
+		data, readErr := sensors.ReadAttr(path)
 		if readErr != nil {
 			continue
 		}
-		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		lines := strings.Split(data, "\n")
 		for _, line := range lines {
 			text := strings.TrimSpace(line)
 			if text == "" {
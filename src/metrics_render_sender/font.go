@@ -22,6 +22,7 @@ type FontCache struct {
 	largeFont   font.Face
 	headerFont  font.Face
 	fontMap     map[int]font.Face
+	tabularMap  map[font.Face]font.Face
 	fontPath    string
 	mutex       sync.RWMutex
 }
@@ -68,6 +69,9 @@ func loadFontCache() (*FontCache, error) {
 }
 
 func findSystemFont() string {
+	if font := resolveFontOverride(); font != "" {
+		return font
+	}
 	if cfg := GetGlobalCollectorConfig(); cfg != nil {
 		preferred := make([]string, 0, len(cfg.FontFamilies)+1)
 		if strings.TrimSpace(cfg.GetDefaultFontName()) != "" {
@@ -164,6 +168,7 @@ func expandHomePath(path string) string {
 func loadFontFaceOrFallback(fontPath string, size float64, fallback font.Face) (font.Face, error) {
 	if strings.TrimSpace(fontPath) != "" {
 		if face, err := gg.LoadFontFace(fontPath, size); err == nil && !isNilFontFace(face) {
+			registerFontFaceSource(face, fontPath, size)
 			return face, nil
 		} else if err != nil {
 			if !isNilFontFace(fallback) {
@@ -204,6 +209,12 @@ func findFontByName(fontNames []string) string {
 		}
 	}
 
+	// Headless startup must not depend on crawling font directories; fonts
+	// come from explicit paths or the font override instead.
+	if headlessMode {
+		return ""
+	}
+
 	for _, dir := range defaultFontDirs() {
 		expandedDir := expandHomePath(dir)
 		if expandedDir == "" {
@@ -380,6 +391,7 @@ func (fc *FontCache) GetFont(size int) (font.Face, error) {
 	if strings.TrimSpace(fc.fontPath) != "" {
 		face, err := gg.LoadFontFace(fc.fontPath, float64(size))
 		if err == nil && !isNilFontFace(face) {
+			registerFontFaceSource(face, fc.fontPath, float64(size))
 			fc.mutex.Lock()
 			fc.fontMap[size] = face
 			fc.mutex.Unlock()
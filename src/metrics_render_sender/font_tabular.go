@@ -0,0 +1,96 @@
+package main
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// tabularDigitsFace gives every ASCII digit the advance of the widest digit and
+// centers the glyph in that cell, emulating OpenType tabular figures for fonts
+// rendered without feature support so changing values do not shift the text.
+type tabularDigitsFace struct {
+	font.Face
+	digitAdvance fixed.Int26_6
+}
+
+func newTabularDigitsFace(face font.Face) font.Face {
+	if isNilFontFace(face) {
+		return face
+	}
+	digitAdvance := fixed.Int26_6(0)
+	for r := '0'; r <= '9'; r++ {
+		if advance, ok := face.GlyphAdvance(r); ok && advance > digitAdvance {
+			digitAdvance = advance
+		}
+	}
+	if digitAdvance <= 0 {
+		return face
+	}
+	return &tabularDigitsFace{Face: face, digitAdvance: digitAdvance}
+}
+
+func isTabularDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func (f *tabularDigitsFace) digitShift(r rune) fixed.Int26_6 {
+	advance, ok := f.Face.GlyphAdvance(r)
+	if !ok {
+		return 0
+	}
+	return (f.digitAdvance - advance) / 2
+}
+
+func (f *tabularDigitsFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	if !isTabularDigit(r) {
+		return f.Face.Glyph(dot, r)
+	}
+	dot.X += f.digitShift(r)
+	dr, mask, maskp, _, ok := f.Face.Glyph(dot, r)
+	return dr, mask, maskp, f.digitAdvance, ok
+}
+
+func (f *tabularDigitsFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	bounds, advance, ok := f.Face.GlyphBounds(r)
+	if !isTabularDigit(r) {
+		return bounds, advance, ok
+	}
+	shift := f.digitShift(r)
+	bounds.Min.X += shift
+	bounds.Max.X += shift
+	return bounds, f.digitAdvance, ok
+}
+
+func (f *tabularDigitsFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	if isTabularDigit(r) {
+		return f.digitAdvance, true
+	}
+	return f.Face.GlyphAdvance(r)
+}
+
+func (f *tabularDigitsFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	if isTabularDigit(r0) || isTabularDigit(r1) {
+		return 0
+	}
+	return f.Face.Kern(r0, r1)
+}
+
+// tabularFace returns the cached tabular wrapper for face.
+func (fc *FontCache) tabularFace(face font.Face) font.Face {
+	if fc == nil || isNilFontFace(face) {
+		return newTabularDigitsFace(face)
+	}
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	if fc.tabularMap == nil {
+		fc.tabularMap = make(map[font.Face]font.Face)
+	}
+	if wrapped, exists := fc.tabularMap[face]; exists {
+		return wrapped
+	}
+	wrapped := newTabularDigitsFace(face)
+	fc.tabularMap[face] = wrapped
+	return wrapped
+}
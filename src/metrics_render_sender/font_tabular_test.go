@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+func TestTabularDigitsFaceEqualizesDigitWidths(t *testing.T) {
+	parsed, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("parse font: %v", err)
+	}
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: 24, DPI: 72})
+	if err != nil {
+		t.Fatalf("new face: %v", err)
+	}
+
+	dc := gg.NewContext(10, 10)
+	dc.SetFontFace(face)
+	narrow, _ := dc.MeasureString("111")
+	wide, _ := dc.MeasureString("888")
+	if narrow == wide {
+		t.Skip("font has tabular digits already")
+	}
+
+	fontCache := &FontCache{}
+	tabular := fontCache.tabularFace(face)
+	if fontCache.tabularFace(face) != tabular {
+		t.Fatalf("expected tabular face to be cached")
+	}
+	dc.SetFontFace(tabular)
+	narrow, _ = dc.MeasureString("11.1%")
+	wide, _ = dc.MeasureString("88.8%")
+	if narrow != wide {
+		t.Fatalf("expected equal widths with tabular digits, got %v and %v", narrow, wide)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const mangoHudPollInterval = 250 * time.Millisecond
+
+// mangoHudLogName matches the <app>_<date>_<time>.csv names MangoHud gives
+// its logs, capturing the application.
+var mangoHudLogName = regexp.MustCompile(`^(.+?)_\d{4}-\d{2}-\d{2}_\d{2}-\d{2}-\d{2}\.csv$`)
+
+// mangoHudTail follows the newest MangoHud log in dir. A log is a few lines
+// of system information, a header starting with fps,frametime and then one
+// row per log_interval; each row becomes a sample with its frame time.
+type mangoHudTail struct {
+	dir     string
+	path    string
+	app     string
+	file    *os.File
+	column  int
+	pending string
+	// skip drops the rows already in a log found on the first poll, so a
+	// session still being logged is picked up from now on.
+	skip  bool
+	polls int
+}
+
+func runMangoHudLogs(dir string, stop <-chan struct{}, emit func(frameSample)) error {
+	tail := &mangoHudTail{dir: dir}
+	defer tail.close()
+	ticker := time.NewTicker(mangoHudPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := tail.poll(time.Now(), emit); err != nil {
+			return err
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *mangoHudTail) poll(now time.Time, emit func(frameSample)) error {
+	t.polls++
+	path, app := newestMangoHudLog(t.dir, now)
+	if path != t.path {
+		t.close()
+		if path == "" {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		t.path, t.app, t.file = path, app, file
+		t.column, t.pending = -1, ""
+		t.skip = t.polls == 1
+	}
+	if t.file == nil {
+		return nil
+	}
+	data, err := io.ReadAll(t.file)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(t.pending+string(data), "\n")
+	// The last element is a row MangoHud has not finished writing.
+	t.pending = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		fields := strings.Split(strings.TrimRight(line, "\r"), ",")
+		if t.column < 0 {
+			if column := frameCSVColumn(fields, "frametime"); column >= 0 && frameCSVColumn(fields, "fps") >= 0 {
+				t.column = column
+			}
+			continue
+		}
+		if t.skip || t.column >= len(fields) {
+			continue
+		}
+		if ms, err := strconv.ParseFloat(strings.TrimSpace(fields[t.column]), 64); err == nil {
+			emit(frameSample{app: t.app, at: now, ms: ms})
+		}
+	}
+	t.skip = false
+	return nil
+}
+
+func (t *mangoHudTail) close() {
+	if t.file != nil {
+		t.file.Close()
+	}
+	t.path, t.app, t.file = "", "", nil
+}
+
+// newestMangoHudLog returns the most recently written log, ignoring the
+// _summary.csv files and logs untouched for longer than frameTimeWindow,
+// which belong to sessions that have ended.
+func newestMangoHudLog(dir string, now time.Time) (string, string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", ""
+	}
+	newestPath, newestApp := "", ""
+	var newestAt time.Time
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := mangoHudLogName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) > frameTimeWindow {
+			continue
+		}
+		if newestPath == "" || info.ModTime().After(newestAt) {
+			newestPath = filepath.Join(dir, entry.Name())
+			newestApp = match[1]
+			newestAt = info.ModTime()
+		}
+	}
+	return newestPath, newestApp
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultPresentMonCommand = "PresentMon.exe"
+
+// defaultPresentMonArgs are for PresentMon 2.x; 1.x users set args to the
+// single-dash forms. A fixed session name lets a restart take over the
+// trace a crashed run left behind.
+var defaultPresentMonArgs = []string{
+	"--output_stdout",
+	"--no_console_stats",
+	"--stop_existing_session",
+	"--session_name", "ax206monitor",
+}
+
+// presentMonIgnoredApps present on every refresh without being a game.
+var presentMonIgnoredApps = map[string]struct{}{"dwm.exe": {}}
+
+// runPresentMon runs PresentMon until stop is closed. PresentMon needs
+// administrator rights or membership in Performance Log Users.
+func runPresentMon(command string, args []string, stop <-chan struct{}, emit func(frameSample)) error {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return fmt.Errorf("%s not found: %w", command, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path, args...)
+	hideCommandWindow(cmd)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-done:
+		}
+	}()
+	readErr := readPresentMonCSV(stdout, time.Now, emit)
+	waitErr := cmd.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if waitErr != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%w: %s", waitErr, message)
+		}
+		return waitErr
+	}
+	if readErr != nil {
+		return readErr
+	}
+	return errors.New("presentmon exited")
+}
+
+// readPresentMonCSV reads PresentMon's CSV: msBetweenPresents in 1.x and
+// with --v1_metrics, MsBetweenPresents or FrameTime in 2.x.
+func readPresentMonCSV(r io.Reader, now func() time.Time, emit func(frameSample)) error {
+	scanner := bufio.NewScanner(r)
+	appColumn, timeColumn := -1, -1
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ",")
+		if timeColumn < 0 {
+			appColumn = frameCSVColumn(fields, "Application")
+			timeColumn = frameCSVColumn(fields, "msBetweenPresents", "FrameTime")
+			continue
+		}
+		if timeColumn >= len(fields) || appColumn < 0 || appColumn >= len(fields) {
+			continue
+		}
+		app := strings.TrimSpace(fields[appColumn])
+		if _, ignored := presentMonIgnoredApps[strings.ToLower(app)]; ignored {
+			continue
+		}
+		if ms, err := strconv.ParseFloat(strings.TrimSpace(fields[timeColumn]), 64); err == nil {
+			emit(frameSample{app: app, at: now(), ms: ms})
+		}
+	}
+	return scanner.Err()
+}
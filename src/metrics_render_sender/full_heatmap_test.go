@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestGetRequiredMonitorsIncludesHeatmapCells(t *testing.T) {
+	config := &MonitorConfig{
+		Items: []ItemConfig{
+			{
+				Type: itemTypeFullHeatmap,
+				RenderAttrsMap: map[string]interface{}{
+					"monitors": []interface{}{"go_native.disk.0.temp", " go_native.disk.1.temp ", "go_native.disk.0.temp"},
+				},
+			},
+		},
+	}
+
+	required := getRequiredMonitors(config)
+	sort.Strings(required)
+
+	expected := []string{"go_native.disk.0.temp", "go_native.disk.1.temp"}
+	if !reflect.DeepEqual(required, expected) {
+		t.Fatalf("unexpected required monitors: got=%v want=%v", required, expected)
+	}
+}
+
+func TestResolveFullHeatmapGrid(t *testing.T) {
+	cases := []struct {
+		count, colCount int
+		width, height   float64
+		cols, rows      int
+	}{
+		{count: 8, width: 200, height: 100, cols: 4, rows: 2},
+		{count: 16, width: 100, height: 100, cols: 4, rows: 4},
+		{count: 3, width: 300, height: 20, cols: 3, rows: 1},
+		{count: 10, colCount: 3, width: 300, height: 20, cols: 3, rows: 4},
+		{count: 2, colCount: 6, width: 100, height: 100, cols: 2, rows: 1},
+	}
+	for _, tc := range cases {
+		cols, rows := resolveFullHeatmapGrid(tc.count, tc.colCount, tc.width, tc.height)
+		if cols != tc.cols || rows != tc.rows {
+			t.Fatalf("resolveFullHeatmapGrid(%d, %d, %v, %v) = %dx%d, want %dx%d", tc.count, tc.colCount, tc.width, tc.height, cols, rows, tc.cols, tc.rows)
+		}
+	}
+}
+
+func TestMixHeatmapColor(t *testing.T) {
+	if got := mixHeatmapColor("#000000", "#ffffff", 0); got != "#000000ff" {
+		t.Fatalf("expected low color at ratio 0, got %s", got)
+	}
+	if got := mixHeatmapColor("#000000", "#ffffff", 1); got != "#ffffffff" {
+		t.Fatalf("expected high color at ratio 1, got %s", got)
+	}
+	if got := mixHeatmapColor("#000000", "#ff0000", 0.5); got != "#800000ff" {
+		t.Fatalf("expected midpoint color, got %s", got)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetRequiredMonitorsIncludesVUMeterBars(t *testing.T) {
+	config := &MonitorConfig{
+		Items: []ItemConfig{
+			{
+				Type: itemTypeFullVUMeter,
+				RenderAttrsMap: map[string]interface{}{
+					"monitors": []interface{}{"audio.left", "audio.right", "audio.left"},
+				},
+			},
+		},
+	}
+
+	required := getRequiredMonitors(config)
+	expected := []string{"audio.left", "audio.right"}
+	if !reflect.DeepEqual(required, expected) {
+		t.Fatalf("unexpected required monitors: got=%v want=%v", required, expected)
+	}
+}
+
+func TestFullVUMeterSegments(t *testing.T) {
+	cases := []struct {
+		ratio float64
+		lit   int
+	}{
+		{ratio: 0, lit: 0},
+		{ratio: 0.01, lit: 1},
+		{ratio: 0.5, lit: 10},
+		{ratio: 0.51, lit: 11},
+		{ratio: 1.2, lit: 20},
+	}
+	for _, tc := range cases {
+		if lit := fullVUMeterLitSegments(tc.ratio, 20); lit != tc.lit {
+			t.Fatalf("fullVUMeterLitSegments(%v, 20) = %d, want %d", tc.ratio, lit, tc.lit)
+		}
+	}
+
+	meter := renderFullVUMeterRuntime{segments: 10, lowColor: "low", midColor: "mid", highColor: "high", midAt: 70, highAt: 90}
+	var colors []string
+	for segment := 0; segment < meter.segments; segment++ {
+		colors = append(colors, meter.segmentColor(segment))
+	}
+	expected := []string{"low", "low", "low", "low", "low", "low", "low", "mid", "mid", "high"}
+	if !reflect.DeepEqual(colors, expected) {
+		t.Fatalf("unexpected segment colors: got=%v want=%v", colors, expected)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultGPIOChip     = "/dev/gpiochip0"
+	gpioPollInterval    = 20 * time.Millisecond
+	gpioLEDPollInterval = 500 * time.Millisecond
+)
+
+type gpioLine interface {
+	Value() (bool, error)
+	SetValue(on bool) error
+	Close() error
+}
+
+type gpioButton struct {
+	line    gpioLine
+	action  string
+	last    bool
+	pressed bool
+}
+
+// sample feeds one reading into the debouncer and reports a press once the
+// line has read active on two consecutive polls.
+func (b *gpioButton) sample(active bool) bool {
+	stable := active == b.last
+	b.last = active
+	if !stable || active == b.pressed {
+		return false
+	}
+	b.pressed = active
+	return active
+}
+
+// gpioController polls the configured buttons and mirrors the alert state to
+// an LED. Actions run outside the poll loop because switching profiles
+// re-applies the config, which may restart the controller itself.
+type gpioController struct {
+	buttons     []*gpioButton
+	led         gpioLine
+	alertActive func() bool
+	busy        atomic.Bool
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// startGPIOController opens the lines described by cfg. Lines that cannot be
+// opened are logged and skipped; nil is returned when nothing is usable.
+func startGPIOController(cfg *GPIOConfig, alertActive func() bool) *gpioController {
+	if cfg == nil {
+		return nil
+	}
+	chip := strings.TrimSpace(cfg.Chip)
+	if chip == "" {
+		chip = defaultGPIOChip
+	}
+	controller := &gpioController{
+		alertActive: alertActive,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+	for _, buttonCfg := range cfg.Buttons {
+		action := normalizeDisplayAction(buttonCfg.Action)
+		if action == "" {
+			logWarnModule("gpio", "line %d: unknown button action %q", buttonCfg.Line, buttonCfg.Action)
+			continue
+		}
+		line, err := openGPIOInput(chip, buttonCfg.Line, buttonCfg.ActiveHigh)
+		if err != nil {
+			logWarnModule("gpio", "button %s: %v", action, err)
+			continue
+		}
+		controller.buttons = append(controller.buttons, &gpioButton{line: line, action: action})
+	}
+	if cfg.AlertLED != nil {
+		line, err := openGPIOOutput(chip, cfg.AlertLED.Line, cfg.AlertLED.ActiveLow)
+		if err != nil {
+			logWarnModule("gpio", "alert led: %v", err)
+		} else {
+			controller.led = line
+		}
+	}
+	if len(controller.buttons) == 0 && controller.led == nil {
+		return nil
+	}
+	logInfoModule("gpio", "started on %s: %d button(s), alert led=%v", chip, len(controller.buttons), controller.led != nil)
+	go controller.run()
+	return controller
+}
+
+func cloneGPIOConfig(cfg *GPIOConfig) *GPIOConfig {
+	if cfg == nil {
+		return nil
+	}
+	copyCfg := *cfg
+	copyCfg.Buttons = append([]GPIOButtonConfig(nil), cfg.Buttons...)
+	if cfg.AlertLED != nil {
+		led := *cfg.AlertLED
+		copyCfg.AlertLED = &led
+	}
+	return &copyCfg
+}
+
+func gpioConfigsEqual(left, right *GPIOConfig) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	return reflect.DeepEqual(*left, *right)
+}
+
+func (c *gpioController) stop() {
+	if c == nil {
+		return
+	}
+	close(c.stopCh)
+	<-c.doneCh
+}
+
+func (c *gpioController) run() {
+	defer close(c.doneCh)
+	defer c.closeLines()
+
+	ticker := time.NewTicker(gpioPollInterval)
+	defer ticker.Stop()
+	ledOn := false
+	var lastLED time.Time
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case now := <-ticker.C:
+			for _, button := range c.buttons {
+				active, err := button.line.Value()
+				if err != nil {
+					continue
+				}
+				if button.sample(active) {
+					c.dispatch(button.action)
+				}
+			}
+			if c.led != nil && c.alertActive != nil && now.Sub(lastLED) >= gpioLEDPollInterval {
+				lastLED = now
+				if on := c.alertActive(); on != ledOn {
+					if err := c.led.SetValue(on); err == nil {
+						ledOn = on
+					}
+				}
+			}
+		}
+	}
+}
+
+func (c *gpioController) closeLines() {
+	for _, button := range c.buttons {
+		_ = button.line.Close()
+	}
+	if c.led != nil {
+		_ = c.led.SetValue(false)
+		_ = c.led.Close()
+	}
+}
+
+// dispatch runs action in the background, dropping presses that arrive while
+// the previous action is still being applied.
+func (c *gpioController) dispatch(action string) {
+	if !c.busy.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer c.busy.Store(false)
+		result, err := runDisplayAction(action)
+		if err != nil {
+			logWarnModule("gpio", "%s failed: %v", action, err)
+			return
+		}
+		logInfoModule("gpio", "%s: %s", action, result)
+	}()
+}
+
+// shareGPIOWithOutputs lets the ssd1306 and st7789 outputs request their
+// data/command and reset lines through the same character device code.
+func shareGPIOWithOutputs() {
+	SetOutputPinOpener(func(chip string, line int) (OutputPin, error) {
+		pin, err := openGPIOOutput(chip, line, false)
+		if err != nil {
+			return nil, err
+		}
+		return pin, nil
+	})
+}
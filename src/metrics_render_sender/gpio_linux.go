@@ -0,0 +1,125 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// GPIO character device uAPI v2 (linux/gpio.h), available since Linux 5.10.
+const (
+	gpioV2GetLineIOCTL   = 0xC250B407
+	gpioV2GetValuesIOCTL = 0xC010B40E
+	gpioV2SetValuesIOCTL = 0xC010B40F
+
+	gpioV2LineFlagActiveLow    = 1 << 1
+	gpioV2LineFlagInput        = 1 << 2
+	gpioV2LineFlagOutput       = 1 << 3
+	gpioV2LineFlagBiasPullUp   = 1 << 8
+	gpioV2LineFlagBiasPullDown = 1 << 9
+)
+
+type gpioV2LineConfigAttribute struct {
+	ID      uint32
+	Padding uint32
+	Value   uint64
+	Mask    uint64
+}
+
+type gpioV2LineConfig struct {
+	Flags    uint64
+	NumAttrs uint32
+	Padding  [5]uint32
+	Attrs    [10]gpioV2LineConfigAttribute
+}
+
+type gpioV2LineRequest struct {
+	Offsets         [64]uint32
+	Consumer        [32]byte
+	Config          gpioV2LineConfig
+	NumLines        uint32
+	EventBufferSize uint32
+	Padding         [5]uint32
+	Fd              int32
+}
+
+type gpioV2LineValues struct {
+	Bits uint64
+	Mask uint64
+}
+
+var _ [592]byte = [unsafe.Sizeof(gpioV2LineRequest{})]byte{}
+
+type gpioCdevLine struct {
+	file *os.File
+}
+
+// openGPIOInput requests line as an input with the pull resistor matching
+// the button wiring: pull-up and active-low unless activeHigh is set.
+func openGPIOInput(chip string, line int, activeHigh bool) (gpioLine, error) {
+	flags := uint64(gpioV2LineFlagInput | gpioV2LineFlagBiasPullUp | gpioV2LineFlagActiveLow)
+	if activeHigh {
+		flags = gpioV2LineFlagInput | gpioV2LineFlagBiasPullDown
+	}
+	return requestGPIOLine(chip, line, flags)
+}
+
+func openGPIOOutput(chip string, line int, activeLow bool) (gpioLine, error) {
+	flags := uint64(gpioV2LineFlagOutput)
+	if activeLow {
+		flags |= gpioV2LineFlagActiveLow
+	}
+	return requestGPIOLine(chip, line, flags)
+}
+
+func requestGPIOLine(chip string, line int, flags uint64) (gpioLine, error) {
+	if line < 0 {
+		return nil, fmt.Errorf("invalid gpio line %d", line)
+	}
+	chipFile, err := os.OpenFile(chip, os.O_RDWR|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer chipFile.Close()
+
+	var request gpioV2LineRequest
+	request.Offsets[0] = uint32(line)
+	request.NumLines = 1
+	request.Config.Flags = flags
+	copy(request.Consumer[:len(request.Consumer)-1], "ax206monitor")
+	if err := gpioIoctl(chipFile.Fd(), gpioV2GetLineIOCTL, unsafe.Pointer(&request)); err != nil {
+		return nil, fmt.Errorf("request %s line %d: %w", chip, line, err)
+	}
+	return &gpioCdevLine{file: os.NewFile(uintptr(request.Fd), fmt.Sprintf("%s:%d", chip, line))}, nil
+}
+
+func (l *gpioCdevLine) Value() (bool, error) {
+	values := gpioV2LineValues{Mask: 1}
+	if err := gpioIoctl(l.file.Fd(), gpioV2GetValuesIOCTL, unsafe.Pointer(&values)); err != nil {
+		return false, err
+	}
+	return values.Bits&1 != 0, nil
+}
+
+func (l *gpioCdevLine) SetValue(on bool) error {
+	values := gpioV2LineValues{Mask: 1}
+	if on {
+		values.Bits = 1
+	}
+	return gpioIoctl(l.file.Fd(), gpioV2SetValuesIOCTL, unsafe.Pointer(&values))
+}
+
+func (l *gpioCdevLine) Close() error {
+	return l.file.Close()
+}
+
+func gpioIoctl(fd uintptr, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package main
+
+import "fmt"
+
+func openGPIOInput(chip string, line int, activeHigh bool) (gpioLine, error) {
+	return nil, fmt.Errorf("gpio is only supported on linux")
+}
+
+func openGPIOOutput(chip string, line int, activeLow bool) (gpioLine, error) {
+	return nil, fmt.Errorf("gpio is only supported on linux")
+}
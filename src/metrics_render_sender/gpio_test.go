@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestGPIOButtonDebounceFiresOncePerPress(t *testing.T) {
+	button := &gpioButton{}
+	samples := []bool{true, false, true, true, true, false, true, false, false, true, true}
+	presses := 0
+	for _, active := range samples {
+		if button.sample(active) {
+			presses++
+		}
+	}
+	if presses != 2 {
+		t.Fatalf("expected 2 debounced presses, got %d", presses)
+	}
+}
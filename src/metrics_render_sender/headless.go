@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Headless mode serves the Web UI in-process with no tray, browser or
+// updater, for containers and system services. Both globals are set once in
+// main before any collector starts.
+var (
+	headlessMode       bool
+	collectorAllowlist map[string]struct{}
+)
+
+func resolveHeadlessFromEnv() bool {
+	return parseEnvBool(firstNonEmptyEnv("METRICS_RENDER_SENDER_HEADLESS", "AX206_MONITOR_HEADLESS"))
+}
+
+// setCollectorAllowlist restricts collectors to the comma-separated names in
+// raw. Names may omit the "go_native." prefix, e.g. "cpu,memory,network".
+// An empty list allows every collector.
+func setCollectorAllowlist(raw string) {
+	collectorAllowlist = nil
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if collectorAllowlist == nil {
+			collectorAllowlist = make(map[string]struct{})
+		}
+		collectorAllowlist[name] = struct{}{}
+	}
+}
+
+// isCollectorAllowed reports whether the allowlist permits name. The system
+// collector is always allowed since it only reads process-local state and
+// carries the clock and status items, and so is the replay collector, which
+// stands in for all the others.
+func isCollectorAllowed(name string) bool {
+	if collectorAllowlist == nil {
+		return true
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == collectorGoNativeSystem || name == collectorReplay {
+		return true
+	}
+	if _, ok := collectorAllowlist[name]; ok {
+		return true
+	}
+	_, ok := collectorAllowlist[strings.TrimPrefix(name, "go_native.")]
+	return ok
+}
+
+// resolveHeadlessBindHost listens on all interfaces unless overridden, since
+// a container's loopback is not reachable through a published port.
+func resolveHeadlessBindHost() string {
+	if host := firstNonEmptyEnv("METRICS_RENDER_SENDER_BIND", "AX206_MONITOR_BIND"); host != "" {
+		return normalizeWebBindHost(host)
+	}
+	return publicWebBindHost
+}
+
+// resolveFontOverride returns the font file named by the environment, if it
+// loads.
+func resolveFontOverride() string {
+	path := firstNonEmptyEnv("METRICS_RENDER_SENDER_FONT", "AX206_MONITOR_FONT")
+	if path == "" {
+		return ""
+	}
+	font := findFontByName([]string{path})
+	if font == "" {
+		logWarnModule("font", "font override %s is not a loadable font file", path)
+	}
+	return font
+}
+
+func runHeadless(port int, devMode bool, viteURL string) error {
+	bindHost := resolveHeadlessBindHost()
+	logInfoModule("web", "Headless mode enabled on host %s port %d", bindHost, port)
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalChan)
+	defer startServiceControl(signalChan)()
+	pauseChan := make(chan os.Signal, 1)
+	notifyPauseSignal(pauseChan)
+	defer signal.Stop(pauseChan)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-pauseChan:
+				if _, err := runDisplayAction(displayActionPause); err != nil {
+					logWarn("Pause toggle failed: %v", err)
+				}
+			case <-signalChan:
+				logInfo("Shutdown initiated")
+				if err := stopRunningWebServer(3 * time.Second); err != nil {
+					logWarnModule("web", "Web server stop failed on shutdown: %v", err)
+				}
+				return
+			}
+		}
+	}()
+
+	return RunWebServer(WebServerOptions{
+		Addr:    buildWebListenAddr(bindHost, port),
+		DevMode: devMode,
+		ViteURL: viteURL,
+	})
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestCollectorAllowlist(t *testing.T) {
+	defer setCollectorAllowlist("")
+
+	setCollectorAllowlist("")
+	if !isCollectorAllowed(collectorCoolerControl) {
+		t.Fatalf("expected an empty allowlist to allow every collector")
+	}
+
+	setCollectorAllowlist(" CPU, go_native.memory ,coolercontrol")
+	for _, name := range []string{collectorGoNativeCPU, collectorGoNativeMemory, collectorCoolerControl, collectorGoNativeSystem} {
+		if !isCollectorAllowed(name) {
+			t.Fatalf("expected %s to be allowed", name)
+		}
+	}
+	for _, name := range []string{collectorGoNativeDisk, collectorBLE} {
+		if isCollectorAllowed(name) {
+			t.Fatalf("expected %s to be blocked", name)
+		}
+	}
+
+	enabled := true
+	cfg := &MonitorConfig{CollectorConfig: map[string]CollectorConfig{collectorGoNativeDisk: {Enabled: &enabled}}}
+	if cfg.IsCollectorEnabled(collectorGoNativeDisk, true) {
+		t.Fatalf("expected the allowlist to override collector_config")
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// hotplugSettleDelay groups the burst of events one device produces, e.g. a
+// USB disk announcing the disk and then each partition, into one refresh.
+const hotplugSettleDelay = 500 * time.Millisecond
+
+// deviceEvent is one kernel uevent for a device the collectors care about.
+type deviceEvent struct {
+	Action    string
+	Subsystem string
+	DevName   string
+}
+
+// deviceEventSource delivers device events until Close. Next blocks.
+type deviceEventSource interface {
+	Next() (deviceEvent, error)
+	Close() error
+}
+
+// hotplugChanges is what a settled burst of events touched.
+type hotplugChanges struct {
+	disks     bool
+	hwmon     bool
+	network   bool
+	diskNames []string
+}
+
+func (c *hotplugChanges) add(event deviceEvent) bool {
+	switch event.Action {
+	case "add", "remove", "move":
+	default:
+		return false
+	}
+	switch event.Subsystem {
+	case "block":
+		name := normalizeDiskBaseName(event.DevName, "")
+		if name == "" || isLinuxPseudoDiskName(name) {
+			return false
+		}
+		c.disks = true
+		if !slices.Contains(c.diskNames, name) {
+			c.diskNames = append(c.diskNames, name)
+		}
+	case "hwmon":
+		c.hwmon = true
+	case "net":
+		if isVirtualInterface(event.DevName) {
+			return false
+		}
+		c.network = true
+	default:
+		return false
+	}
+	return true
+}
+
+var hotplugWatchOnce sync.Once
+
+// startHotplugWatch refreshes disks, disk sensors and network slots as soon
+// as the kernel reports a device change. Without an event source the
+// periodic rescans remain the only discovery path.
+func startHotplugWatch() {
+	hotplugWatchOnce.Do(func() {
+		source, err := openDeviceEventSource()
+		if err != nil {
+			logInfoModule("hotplug", "device events unavailable, using periodic rescans: %v", err)
+			return
+		}
+		go runHotplugWatch(source, hotplugSettleDelay, applyHotplugChanges)
+	})
+}
+
+func runHotplugWatch(source deviceEventSource, settle time.Duration, apply func(hotplugChanges)) {
+	events := make(chan deviceEvent, 64)
+	go func() {
+		defer close(events)
+		defer source.Close()
+		for {
+			event, err := source.Next()
+			if err != nil {
+				logWarnModule("hotplug", "device event stream stopped: %v", err)
+				return
+			}
+			events <- event
+		}
+	}()
+
+	var pending hotplugChanges
+	var settled <-chan time.Time
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if pending.add(event) && settled == nil {
+				settled = time.After(settle)
+			}
+		case <-settled:
+			apply(pending)
+			pending = hotplugChanges{}
+			settled = nil
+		}
+	}
+}
+
+func applyHotplugChanges(changes hotplugChanges) {
+	logInfoModule(
+		"hotplug",
+		"action=refresh,disks=%t,hwmon=%t,network=%t,devices=%s",
+		changes.disks,
+		changes.hwmon,
+		changes.network,
+		strings.Join(changes.diskNames, "|"),
+	)
+	if changes.disks {
+		sharedDiskTempService.Forget(changes.diskNames)
+		sharedDiskHealthService.Forget(changes.diskNames)
+		requestDiskRescan()
+	}
+	if changes.hwmon {
+		sharedDiskTempService.RetryUnbound()
+	}
+	collectors := make([]string, 0, 2)
+	if changes.disks {
+		collectors = append(collectors, collectorGoNativeDisk)
+	}
+	if changes.network {
+		collectors = append(collectors, collectorGoNativeNetwork)
+	}
+	if manager := CurrentCollectorManager(); manager != nil && len(collectors) > 0 {
+		manager.Rediscover(collectors, "hotplug")
+	}
+}
+
+// parseUevent decodes a kernel uevent: an "action@devpath" header followed
+// by NUL separated KEY=VALUE pairs. Messages rebroadcast by udev carry a
+// binary header and are not handled.
+func parseUevent(data []byte) (deviceEvent, bool) {
+	parts := bytes.Split(data, []byte{0})
+	if len(parts) < 2 || !bytes.Contains(parts[0], []byte("@")) {
+		return deviceEvent{}, false
+	}
+	event := deviceEvent{}
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(string(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "ACTION":
+			event.Action = value
+		case "SUBSYSTEM":
+			event.Subsystem = value
+		case "DEVNAME":
+			event.DevName = value
+		case "INTERFACE":
+			event.DevName = value
+		}
+	}
+	if event.Action == "" || event.Subsystem == "" {
+		return deviceEvent{}, false
+	}
+	return event, true
+}
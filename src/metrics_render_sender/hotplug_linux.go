@@ -0,0 +1,49 @@
+//go:build linux
+
+package main
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// ueventKernelGroup is the netlink multicast group the kernel itself sends
+// uevents to; unprivileged processes may listen on it.
+const ueventKernelGroup = 1
+
+type ueventSocket struct {
+	fd  int
+	buf []byte
+}
+
+func openDeviceEventSource() (deviceEventSource, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: ueventKernelGroup}); err != nil {
+		_ = unix.Close(fd)
+		return nil, err
+	}
+	return &ueventSocket{fd: fd, buf: make([]byte, 16<<10)}, nil
+}
+
+func (s *ueventSocket) Next() (deviceEvent, error) {
+	for {
+		n, _, err := unix.Recvfrom(s.fd, s.buf, 0)
+		if err == unix.EINTR || err == unix.ENOBUFS {
+			// ENOBUFS means events were dropped under load; the periodic
+			// rescans pick up anything missed.
+			continue
+		}
+		if err != nil {
+			return deviceEvent{}, err
+		}
+		if event, ok := parseUevent(s.buf[:n]); ok {
+			return event, nil
+		}
+	}
+}
+
+func (s *ueventSocket) Close() error {
+	return unix.Close(s.fd)
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+func openDeviceEventSource() (deviceEventSource, error) {
+	return nil, errors.New("device events are only supported on Linux")
+}
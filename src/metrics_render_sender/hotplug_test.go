@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeDeviceEventSource struct {
+	events chan deviceEvent
+}
+
+func (s *fakeDeviceEventSource) Next() (deviceEvent, error) {
+	event, ok := <-s.events
+	if !ok {
+		return deviceEvent{}, errors.New("closed")
+	}
+	return event, nil
+}
+
+func (s *fakeDeviceEventSource) Close() error {
+	return nil
+}
+
+func TestParseUevent(t *testing.T) {
+	raw := strings.Join([]string{
+		"add@/devices/pci0000:00/0000:00:14.0/usb2/2-1/2-1:1.0/host6/target6:0:0/6:0:0:0/block/sdb",
+		"ACTION=add",
+		"DEVPATH=/devices/pci0000:00/0000:00:14.0/usb2/2-1/2-1:1.0/host6/target6:0:0/6:0:0:0/block/sdb",
+		"SUBSYSTEM=block",
+		"MAJOR=8",
+		"MINOR=16",
+		"DEVNAME=sdb",
+		"DEVTYPE=disk",
+		"SEQNUM=4242",
+	}, "\x00") + "\x00"
+	event, ok := parseUevent([]byte(raw))
+	if !ok || event != (deviceEvent{Action: "add", Subsystem: "block", DevName: "sdb"}) {
+		t.Fatalf("unexpected event %+v, %v", event, ok)
+	}
+
+	netEvent, ok := parseUevent([]byte("remove@/devices/virtual/net/eth9\x00ACTION=remove\x00SUBSYSTEM=net\x00INTERFACE=eth9\x00"))
+	if !ok || netEvent.DevName != "eth9" || netEvent.Action != "remove" {
+		t.Fatalf("unexpected net event %+v", netEvent)
+	}
+
+	if _, ok := parseUevent([]byte("libudev\x00\xfe\xed\xca\xfe")); ok {
+		t.Fatal("expected udev's binary format to be ignored")
+	}
+}
+
+func TestHotplugWatchCoalescesABurst(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	source := &fakeDeviceEventSource{events: make(chan deviceEvent, 8)}
+	applied := make(chan hotplugChanges, 4)
+	go runHotplugWatch(source, 20*time.Millisecond, func(changes hotplugChanges) { applied <- changes })
+
+	source.events <- deviceEvent{Action: "add", Subsystem: "block", DevName: "sdb"}
+	source.events <- deviceEvent{Action: "add", Subsystem: "block", DevName: "sdb1"}
+	source.events <- deviceEvent{Action: "add", Subsystem: "block", DevName: "loop7"}
+	source.events <- deviceEvent{Action: "change", Subsystem: "block", DevName: "sdc"}
+	source.events <- deviceEvent{Action: "add", Subsystem: "net", DevName: "veth12ab"}
+	source.events <- deviceEvent{Action: "add", Subsystem: "hwmon", DevName: ""}
+
+	select {
+	case changes := <-applied:
+		want := hotplugChanges{disks: true, hwmon: true, diskNames: []string{"sdb"}}
+		if !reflect.DeepEqual(changes, want) {
+			t.Fatalf("unexpected changes %+v", changes)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the burst to be applied")
+	}
+
+	source.events <- deviceEvent{Action: "add", Subsystem: "net", DevName: "enx001122"}
+	select {
+	case changes := <-applied:
+		if !changes.network || changes.disks {
+			t.Fatalf("unexpected changes %+v", changes)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the network change to be applied")
+	}
+	close(source.events)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// instanceLockFile sits in the config directory. The running instance holds
+// an OS lock on it, released even when the process dies, and writes its PID
+// into it for the error message and -takeover.
+const instanceLockFile = "metrics_render_sender.pid"
+
+const (
+	// instanceStopTimeout is how long -takeover waits for the running
+	// instance to shut down cleanly before it is killed.
+	instanceStopTimeout = 10 * time.Second
+	instanceKillTimeout = 3 * time.Second
+)
+
+var errInstanceLocked = errors.New("instance lock held")
+
+type instanceLock struct {
+	file *os.File
+}
+
+// acquireInstanceLock makes this process the only one driving the outputs
+// of its config directory, so two instances never interleave frames on one
+// USB panel. With takeover a running instance is stopped first.
+func acquireInstanceLock(takeover bool) (*instanceLock, error) {
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(configPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create config directory failed: %w", err)
+	}
+	path := filepath.Join(dir, instanceLockFile)
+
+	lock, err := tryInstanceLock(path)
+	if !errors.Is(err, errInstanceLocked) {
+		return lock, err
+	}
+	pid := readInstancePID(path)
+	if !takeover {
+		return nil, fmt.Errorf("another instance (pid %d) is already running with %s; stop it first or start with -takeover", pid, dir)
+	}
+	if pid <= 0 {
+		return nil, fmt.Errorf("another instance holds %s but its pid is unknown, stop it by hand", path)
+	}
+
+	logWarn("Taking over from running instance pid %d", pid)
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return nil, fmt.Errorf("find instance pid %d failed: %w", pid, err)
+	}
+	if err := stopInstanceProcess(process); err != nil {
+		logWarn("Stop request to pid %d failed: %v", pid, err)
+	}
+	if lock, err := waitInstanceLock(path, instanceStopTimeout); !errors.Is(err, errInstanceLocked) {
+		return lock, err
+	}
+	logWarn("Instance pid %d did not stop within %v, killing it", pid, instanceStopTimeout)
+	if err := process.Kill(); err != nil {
+		return nil, fmt.Errorf("kill instance pid %d failed: %w", pid, err)
+	}
+	lock, err = waitInstanceLock(path, instanceKillTimeout)
+	if errors.Is(err, errInstanceLocked) {
+		return nil, fmt.Errorf("instance pid %d still holds %s", pid, path)
+	}
+	return lock, err
+}
+
+func tryInstanceLock(path string) (*instanceLock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open instance lock failed: %w", err)
+	}
+	if err := lockInstanceFile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	// Other instances read the PID while the lock is held, so the file is
+	// rewritten in place rather than replaced.
+	pid := []byte(strconv.Itoa(os.Getpid()) + "\n")
+	if err := file.Truncate(0); err == nil {
+		_, err = file.WriteAt(pid, 0)
+	}
+	if err != nil {
+		logWarn("Write pid file %s failed: %v", path, err)
+	}
+	return &instanceLock{file: file}, nil
+}
+
+func waitInstanceLock(path string, timeout time.Duration) (*instanceLock, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		lock, err := tryInstanceLock(path)
+		if !errors.Is(err, errInstanceLocked) || time.Now().After(deadline) {
+			return lock, err
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+func readInstancePID(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
+// Release empties the PID file and drops the lock. The file itself stays:
+// removing it could let a waiting instance lock the unlinked file while a
+// third creates and locks a new one.
+func (l *instanceLock) Release() {
+	if l == nil || l.file == nil {
+		return
+	}
+	_ = l.file.Truncate(0)
+	_ = unlockInstanceFile(l.file)
+	_ = l.file.Close()
+	l.file = nil
+}
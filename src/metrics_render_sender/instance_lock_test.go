@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestInstanceLockRefusesSecondInstance(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	path := filepath.Join(xdg, "metrics_render_sender", instanceLockFile)
+
+	lock, err := acquireInstanceLock(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pid := readInstancePID(path); pid != os.Getpid() {
+		t.Fatalf("expected our pid in the lock file, got %d", pid)
+	}
+	if _, err := tryInstanceLock(path); !errors.Is(err, errInstanceLocked) {
+		t.Fatalf("expected the held lock to refuse, got %v", err)
+	}
+	if _, err := acquireInstanceLock(false); err == nil || !strings.Contains(err.Error(), strconv.Itoa(os.Getpid())) {
+		t.Fatalf("expected an error naming the running pid, got %v", err)
+	}
+
+	lock.Release()
+	if pid := readInstancePID(path); pid != 0 {
+		t.Fatalf("expected the pid cleared on release, got %d", pid)
+	}
+	again, err := acquireInstanceLock(false)
+	if err != nil {
+		t.Fatalf("expected the released lock to be free, got %v", err)
+	}
+	again.Release()
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+func lockInstanceFile(file *os.File) error {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return errInstanceLocked
+	}
+	if err != nil {
+		return fmt.Errorf("lock %s failed: %w", file.Name(), err)
+	}
+	return nil
+}
+
+func unlockInstanceFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
+
+// stopInstanceProcess asks the instance to shut down the way SIGTERM from a
+// service manager does, blanking the panel.
+func stopInstanceProcess(process *os.Process) error {
+	return process.Signal(syscall.SIGTERM)
+}
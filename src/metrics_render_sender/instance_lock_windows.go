@@ -0,0 +1,43 @@
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// instanceLockOffset is the byte locked in the PID file. Windows locks
+// block reads of the locked range, so the lock sits past the PID where
+// other instances can still read it.
+const instanceLockOffset = 1 << 30
+
+func lockInstanceFile(file *os.File) error {
+	overlapped := windows.Overlapped{Offset: instanceLockOffset}
+	err := windows.LockFileEx(
+		windows.Handle(file.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0, 1, 0, &overlapped,
+	)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return errInstanceLocked
+	}
+	if err != nil {
+		return fmt.Errorf("lock %s failed: %w", file.Name(), err)
+	}
+	return nil
+}
+
+func unlockInstanceFile(file *os.File) error {
+	overlapped := windows.Overlapped{Offset: instanceLockOffset}
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &overlapped)
+}
+
+// stopInstanceProcess ends the instance. Windows has no SIGTERM for another
+// process, so it is killed and the panel keeps its last frame.
+func stopInstanceProcess(process *os.Process) error {
+	return process.Kill()
+}
@@ -9,10 +9,22 @@ func collectItemMonitorRefs(item *ItemConfig) []string {
 	if item.Type == itemTypeFullTable {
 		return fullTableMonitorRefs(item)
 	}
+	if item.Type == itemTypeFullHeatmap {
+		return fullHeatmapMonitorsAttr(item)
+	}
+	if item.Type == itemTypeFullVUMeter {
+		return fullVUMeterMonitorsAttr(item)
+	}
+	if item.Type == itemTypeFullTimer {
+		return append([]string(nil), fullTimerMonitors...)
+	}
 	name := normalizeMonitorAlias(item.Monitor)
 	if name == "" {
 		return nil
 	}
+	if isChartItemType(item.Type) {
+		return append([]string{name}, chartStackMonitorsAttr(item)...)
+	}
 	return []string{name}
 }
 
@@ -17,6 +17,9 @@ const (
 	itemTypeFullProgressH = "full_progress_h"
 	itemTypeFullProgressV = "full_progress_v"
 	itemTypeFullGauge     = "full_gauge"
+	itemTypeFullHeatmap   = "full_heatmap"
+	itemTypeFullVUMeter   = "full_vu_meter"
+	itemTypeFullTimer     = "full_timer"
 )
 
 var simpleItemTypes = []string{
@@ -36,6 +39,9 @@ var fullItemTypes = []string{
 	itemTypeFullProgressH,
 	itemTypeFullProgressV,
 	itemTypeFullGauge,
+	itemTypeFullHeatmap,
+	itemTypeFullVUMeter,
+	itemTypeFullTimer,
 }
 
 var allItemTypes = append(append([]string{}, simpleItemTypes...), fullItemTypes...)
@@ -56,6 +62,8 @@ var rangeItemTypeSet = toItemTypeSet([]string{
 	itemTypeFullProgressH,
 	itemTypeFullProgressV,
 	itemTypeFullGauge,
+	itemTypeFullHeatmap,
+	itemTypeFullVUMeter,
 })
 
 var historyItemTypeSet = toItemTypeSet([]string{
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// layoutDefaultsFile sits next to config.json and holds the defaults every
+// profile inherits.
+const layoutDefaultsFile = "defaults.json"
+
+// LayoutDefaults is the shared defaults file: the same style_base and
+// type_defaults blocks a profile has, applied beneath the profile's own so
+// a profile only sets what differs from its siblings.
+type LayoutDefaults struct {
+	StyleBase    map[string]interface{}      `json:"style_base,omitempty"`
+	TypeDefaults map[string]ItemTypeDefaults `json:"type_defaults,omitempty"`
+}
+
+var (
+	activeLayoutDefaults atomic.Pointer[LayoutDefaults]
+
+	layoutDefaultsMu      sync.Mutex
+	layoutDefaultsPath    string
+	layoutDefaultsModTime time.Time
+)
+
+// reloadLayoutDefaults reads defaults.json from the config directory when it
+// changed since the last call. A missing file clears the defaults; a broken
+// one is logged and keeps the previous defaults.
+func reloadLayoutDefaults() {
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return
+	}
+	path := filepath.Join(filepath.Dir(configPath), layoutDefaultsFile)
+
+	layoutDefaultsMu.Lock()
+	defer layoutDefaultsMu.Unlock()
+	info, err := os.Stat(path)
+	if err != nil {
+		if layoutDefaultsPath != "" {
+			logInfoModule("config", "layout defaults %s removed", layoutDefaultsPath)
+		}
+		layoutDefaultsPath, layoutDefaultsModTime = "", time.Time{}
+		activeLayoutDefaults.Store(nil)
+		return
+	}
+	if path == layoutDefaultsPath && info.ModTime().Equal(layoutDefaultsModTime) {
+		return
+	}
+	defaults, err := loadLayoutDefaults(path)
+	if err != nil {
+		logWarnModule("config", "layout defaults %s ignored: %v", path, err)
+		return
+	}
+	layoutDefaultsPath, layoutDefaultsModTime = path, info.ModTime()
+	activeLayoutDefaults.Store(defaults)
+	logInfoModule("config", "layout defaults loaded from %s", path)
+}
+
+func loadLayoutDefaults(path string) (*LayoutDefaults, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defaults LayoutDefaults
+	if err := json.Unmarshal(data, &defaults); err != nil {
+		return nil, err
+	}
+	normalizeLayoutDefaults(&defaults)
+	return &defaults, nil
+}
+
+// normalizeLayoutDefaults drops unknown or misplaced style keys the same way
+// a profile's blocks are normalized.
+func normalizeLayoutDefaults(defaults *LayoutDefaults) {
+	defaults.StyleBase = normalizeStyleMap(defaults.StyleBase, styleScopeBase, "")
+	types := make(map[string]ItemTypeDefaults, len(defaults.TypeDefaults))
+	for itemType, entry := range defaults.TypeDefaults {
+		normalizedType := normalizeItemTypeName(itemType)
+		entry.Style = normalizeStyleMap(entry.Style, styleScopeType, normalizedType)
+		entry.RenderAttrsMap = stripStyleKeysFromRenderAttrs(entry.RenderAttrsMap)
+		types[normalizedType] = entry
+	}
+	defaults.TypeDefaults = types
+}
+
+func currentLayoutDefaults() *LayoutDefaults {
+	return activeLayoutDefaults.Load()
+}
+
+func (d *LayoutDefaults) typeDefaults(itemType string) ItemTypeDefaults {
+	if d == nil {
+		return ItemTypeDefaults{}
+	}
+	return d.TypeDefaults[normalizeItemTypeName(itemType)]
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLayoutDefaultsApplyBeneathProfile(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	dir := filepath.Join(xdg, "metrics_render_sender")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, layoutDefaultsFile)
+	writeDefaults := func(body string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		os.Remove(path)
+		reloadLayoutDefaults()
+	})
+
+	writeDefaults(`{
+		"style_base": {"color": "#111111", "value_font_size": 30},
+		"type_defaults": {"simple_value": {"style": {"color": "#222222"}, "render_attrs_map": {"unit": "rpm"}}}
+	}`, time.Unix(1000, 0))
+	reloadLayoutDefaults()
+
+	config := &MonitorConfig{StyleBase: map[string]interface{}{"value_font_size": 24}}
+	value := &ItemConfig{Type: itemTypeSimpleValue}
+	label := &ItemConfig{Type: itemTypeSimpleLabel}
+	if got := resolveStyleColor(value, config, "color", ""); got != "#222222" {
+		t.Fatalf("expected the shared type default, got %q", got)
+	}
+	if got := resolveStyleColor(label, config, "color", ""); got != "#111111" {
+		t.Fatalf("expected the shared base for other types, got %q", got)
+	}
+	if got := resolveStyleInt(value, config, "value_font_size", 0); got != 24 {
+		t.Fatalf("expected the profile base to win over the shared file, got %d", got)
+	}
+	if got, _ := getItemAttrWithDefaults(value, config, "unit"); got != "rpm" {
+		t.Fatalf("expected the shared render attr, got %v", got)
+	}
+
+	config.AllowCustomStyle = true
+	value.CustomStyle = true
+	value.Style = map[string]interface{}{"color": "#333333"}
+	if got := resolveStyleColor(value, config, "color", ""); got != "#333333" {
+		t.Fatalf("expected the item style to win, got %q", got)
+	}
+
+	writeDefaults(`{"style_base": {"color": "#444444"}}`, time.Unix(2000, 0))
+	reloadLayoutDefaults()
+	if got := resolveStyleColor(label, config, "color", ""); got != "#444444" {
+		t.Fatalf("expected the edited file to be picked up, got %q", got)
+	}
+
+	writeDefaults(`{broken`, time.Unix(3000, 0))
+	reloadLayoutDefaults()
+	if got := resolveStyleColor(label, config, "color", ""); got != "#444444" {
+		t.Fatalf("expected a broken file to keep the previous defaults, got %q", got)
+	}
+
+	os.Remove(path)
+	reloadLayoutDefaults()
+	if currentLayoutDefaults() != nil {
+		t.Fatal("expected the defaults cleared once the file is gone")
+	}
+}
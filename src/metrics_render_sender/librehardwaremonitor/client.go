@@ -1,6 +1,7 @@
 package librehardwaremonitor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -110,6 +111,11 @@ func GetLibreHardwareMonitorClient(url, username, password string) *LibreHardwar
 }
 
 func (c *LibreHardwareMonitorClient) FetchData() error {
+	return c.FetchDataContext(context.Background())
+}
+
+// FetchDataContext is FetchData with the request abandoned once ctx is done.
+func (c *LibreHardwareMonitorClient) FetchDataContext(ctx context.Context) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -118,7 +124,7 @@ func (c *LibreHardwareMonitorClient) FetchData() error {
 	}
 
 	url := c.baseURL + "/data.json"
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to build request for %s: %v", url, err)
 	}
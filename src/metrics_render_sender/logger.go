@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -109,8 +110,12 @@ func resolveLogDirectoryPath() string {
 	return filepath.Dir(resolveLogFilePath())
 }
 
-// Convenience functions with module support
+// Convenience functions with module support. Info and debug lines are
+// dropped during quiet hours; warnings and errors always go through.
 func logInfo(msg string, args ...interface{}) {
+	if quietHoursActive(time.Now()) {
+		return
+	}
 	entry := logger.WithField("module", "main")
 	if len(args) > 0 {
 		entry.Infof(msg, args...)
@@ -138,6 +143,9 @@ func logError(msg string, args ...interface{}) {
 }
 
 func logDebug(msg string, args ...interface{}) {
+	if quietHoursActive(time.Now()) {
+		return
+	}
 	entry := logger.WithField("module", "main")
 	if len(args) > 0 {
 		entry.Debugf(msg, args...)
@@ -157,6 +165,9 @@ func logFatal(msg string, args ...interface{}) {
 
 // Module-specific logging functions
 func logInfoModule(module, msg string, args ...interface{}) {
+	if quietHoursActive(time.Now()) {
+		return
+	}
 	entry := logger.WithField("module", module)
 	if len(args) > 0 {
 		entry.Infof(msg, args...)
@@ -184,6 +195,9 @@ func logErrorModule(module, msg string, args ...interface{}) {
 }
 
 func logDebugModule(module, msg string, args ...interface{}) {
+	if quietHoursActive(time.Now()) {
+		return
+	}
 	entry := logger.WithField("module", module)
 	if len(args) > 0 {
 		entry.Debugf(msg, args...)
@@ -0,0 +1,39 @@
+package main
+
+// low_power trims the monitor for single-core boards such as the Raspberry
+// Pi Zero: refresh_interval of at least lowPowerMinRefreshMS, no charts,
+// steady alert highlights instead of blink and pulse, skip_unchanged on
+// every output, and all collectors on one worker goroutine.
+const lowPowerMinRefreshMS = 5000
+
+// lowPowerAlertAlpha is the steady alert highlight in low power mode, where
+// an animated one would make every frame differ.
+const lowPowerAlertAlpha = 0.6
+
+// lowPowerSkippedItemTypeSet holds the item types low power mode does not
+// draw: charts keep a history per item and redraw their lines every frame.
+var lowPowerSkippedItemTypeSet = toItemTypeSet([]string{
+	itemTypeSimpleChart,
+	itemTypeFullChart,
+	itemTypeFullHeatmap,
+})
+
+func lowPowerSkipsItem(config *MonitorConfig, item *ItemConfig) bool {
+	if config == nil || item == nil || !config.LowPower {
+		return false
+	}
+	_, skipped := lowPowerSkippedItemTypeSet[item.Type]
+	return skipped
+}
+
+// applyLowPowerOutputs turns skip_unchanged on for every output in low power
+// mode, so each output holds back frames identical to the last one it took.
+func applyLowPowerOutputs(config *MonitorConfig) {
+	if config == nil || !config.LowPower {
+		return
+	}
+	for idx := range config.Outputs {
+		skip := true
+		config.Outputs[idx].SkipUnchanged = &skip
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLowPowerConfig(t *testing.T) {
+	cfg := &MonitorConfig{RefreshInterval: 1000, LowPower: true}
+	if tick := cfg.GetCollectTickDuration(); tick != lowPowerMinRefreshMS*time.Millisecond {
+		t.Fatalf("expected low power to stretch the refresh to %dms, got %v", lowPowerMinRefreshMS, tick)
+	}
+	cfg.RefreshInterval = 8000
+	if tick := cfg.GetCollectTickDuration(); tick != 8*time.Second {
+		t.Fatalf("expected a longer refresh_interval to stay, got %v", tick)
+	}
+	if !lowPowerSkipsItem(cfg, &ItemConfig{Type: itemTypeFullChart}) || lowPowerSkipsItem(cfg, &ItemConfig{Type: itemTypeSimpleValue}) {
+		t.Fatal("expected low power to skip charts only")
+	}
+	cfg.LowPower = false
+	if lowPowerSkipsItem(cfg, &ItemConfig{Type: itemTypeFullChart}) {
+		t.Fatal("expected charts to be drawn outside low power")
+	}
+}
+
+func TestLowPowerSkipsUnchangedOnEveryOutput(t *testing.T) {
+	off := false
+	cfg := &MonitorConfig{LowPower: true, Outputs: []OutputConfig{
+		{Type: "tcppush", URL: "tcp://127.0.0.1:1"},
+		{Type: "ax206usb", SkipUnchanged: &off},
+	}}
+	applyLowPowerOutputs(cfg)
+	for idx, out := range cfg.Outputs {
+		if out.SkipUnchanged == nil || !*out.SkipUnchanged {
+			t.Fatalf("expected outputs[%d] to skip unchanged frames in low power mode", idx)
+		}
+	}
+
+	cfg = &MonitorConfig{Outputs: []OutputConfig{{Type: "tcppush"}}}
+	applyLowPowerOutputs(cfg)
+	if cfg.Outputs[0].SkipUnchanged != nil {
+		t.Fatal("expected outputs to keep their setting outside low power")
+	}
+}
+
+func TestSharedWorkerRunsFrameCollectorsFirst(t *testing.T) {
+	manager := NewCollectorManager()
+	manager.sharedChan = make(chan collectorBatch, 1)
+	manager.sendSharedBatch(1, []namedCollector{{name: "test.stale"}})
+	manager.sendSharedBatch(2, []namedCollector{{name: collectorHosts}, {name: collectorGoNativeCPU}})
+
+	batch := <-manager.sharedChan
+	if batch.epochID != 2 || len(batch.collectors) != 2 {
+		t.Fatalf("expected only the latest batch, got %+v", batch)
+	}
+	if batch.collectors[0].name != collectorGoNativeCPU || batch.collectors[1].name != collectorHosts {
+		t.Fatalf("expected the frame collector before the background one, got %+v", batch.collectors)
+	}
+}
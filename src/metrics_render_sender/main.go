@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -27,10 +28,22 @@ func main() {
 	logInfo("MetricsRenderSender - Repository: %s", RepositoryURL)
 
 	listMonitorsFlag := flag.Bool("list-monitors", false, "List all available monitor items and exit")
+	listConfigsFlag := flag.Bool("list-configs", false, "List the saved profiles with their modification times and exit")
 	portFlag := flag.Int("port", 18086, "Web UI listen port (tray/env web mode)")
 	addUdevRuleFlag := flag.Bool("add-udev-rule", false, "Install AX206 USB udev rule for current user and reload udev")
 	// New: dump all monitor values for N seconds and exit
 	dumpSecondsFlag := flag.Int("dump", 0, "Dump all monitor values for N seconds and exit (0 to disable)")
+	demoFlag := flag.Bool("demo", false, "Feed synthetic, animated values into all numeric monitors (for showcasing and testing layouts)")
+	replayFlag := flag.String("replay", "", "Play back a recorded monitor dump (JSON lines or CSV) instead of reading sensors")
+	recordFlag := flag.String("record", "", "Write every frame's monitor values to a file (JSON lines, or CSV for a .csv path) while running")
+	headlessFlag := flag.Bool("headless", false, "Run without tray, serving the Web UI in-process (for containers and services)")
+	collectorsFlag := flag.String("collectors", "", "Comma-separated collector allowlist, e.g. cpu,memory,network (default all)")
+	configFlag := flag.String("config", "", "Config file path, or an http(s) URL to fetch the config from")
+	configRefreshFlag := flag.Duration("config-refresh", 5*time.Minute, "How often to refetch a URL -config (0 to fetch only at startup)")
+	validateFlag := flag.Bool("validate", false, "Check config files (default: config and profiles) for deprecated or missing monitors and overlapping items and exit")
+	takeoverFlag := flag.Bool("takeover", false, "Stop an instance already running with the same config directory and replace it")
+	var setFlags configOverrideFlag
+	flag.Var(&setFlags, "set", "Override a config field for this run, e.g. -set refresh_interval=500 (repeatable)")
 
 	flag.Parse()
 
@@ -42,7 +55,43 @@ func main() {
 		logFatal("--add-udev-rule cannot be used with other execution flags")
 	}
 
+	if err := loadConfigOverrides(setFlags); err != nil {
+		logFatal("Invalid config override: %v", err)
+	}
+
 	webModeEnabled, webDevEnabled, devViteURL := resolveWebModeFromEnv()
+	headlessMode = *headlessFlag || resolveHeadlessFromEnv()
+	demoMode = *demoFlag || parseEnvBool(firstNonEmptyEnv("METRICS_RENDER_SENDER_DEMO", "AX206_MONITOR_DEMO"))
+	if demoMode {
+		logInfo("Demo mode: numeric monitors show synthetic values")
+	}
+	replayPath := strings.TrimSpace(*replayFlag)
+	if replayPath == "" {
+		replayPath = strings.TrimSpace(firstNonEmptyEnv("METRICS_RENDER_SENDER_REPLAY", "AX206_MONITOR_REPLAY"))
+	}
+	if replayPath != "" {
+		if demoMode {
+			logFatal("--demo and --replay cannot be used together")
+		}
+		if err := initReplaySource(replayPath); err != nil {
+			logFatal("Replay load failed '%s': %v", replayPath, err)
+		}
+	}
+	recordPath := strings.TrimSpace(*recordFlag)
+	if recordPath == "" {
+		recordPath = strings.TrimSpace(firstNonEmptyEnv("METRICS_RENDER_SENDER_RECORD", "AX206_MONITOR_RECORD"))
+	}
+	if recordPath != "" {
+		if err := initMonitorRecorder(recordPath); err != nil {
+			logFatal("Record file open failed '%s': %v", recordPath, err)
+		}
+		defer closeMonitorRecorder()
+	}
+	if *collectorsFlag != "" {
+		setCollectorAllowlist(*collectorsFlag)
+	} else {
+		setCollectorAllowlist(firstNonEmptyEnv("METRICS_RENDER_SENDER_COLLECTORS", "AX206_MONITOR_COLLECTORS"))
+	}
 
 	if *addUdevRuleFlag {
 		if err := InstallAX206UdevRule(); err != nil {
@@ -56,6 +105,64 @@ func main() {
 		return
 	}
 
+	if command := flag.Arg(0); command == "install-service" || command == "uninstall-service" {
+		if err := runServiceCommand(command, flag.Args()[1:], *configFlag, *portFlag); err != nil {
+			logFatal("%s failed: %v", command, err)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "migrate" || *validateFlag || *listConfigsFlag {
+		if isRemoteConfigLocation(*configFlag) {
+			logFatal("migrate, --validate and --list-configs work on local config files, not '%s'", *configFlag)
+		}
+		if err := resolveConfigLocation(*configFlag, 0); err != nil {
+			logFatal("Config load failed '%s': %v", *configFlag, err)
+		}
+		if *listConfigsFlag {
+			if err := listAllConfigs(); err != nil {
+				logFatal("List configs failed: %v", err)
+			}
+			return
+		}
+		if *validateFlag {
+			if err := runValidateCommand(flag.Args()); err != nil {
+				logFatal("Config validation failed: %v", err)
+			}
+			return
+		}
+		if err := runMigrateCommand(flag.Args()[1:]); err != nil {
+			logFatal("Config migration failed: %v", err)
+		}
+		return
+	}
+
+	if err := resolveConfigLocation(*configFlag, *configRefreshFlag); err != nil {
+		logFatal("Config load failed '%s': %v", *configFlag, err)
+	}
+
+	// Dumps only read sensors, so they may run beside the display.
+	if *dumpSecondsFlag <= 0 {
+		lock, err := acquireInstanceLock(*takeoverFlag)
+		if err != nil {
+			logFatal("%v", err)
+		}
+		defer lock.Release()
+	}
+
+	watchPanelResolution()
+	startPowerWatch()
+	SetOutputStatusHook(reportSubsystemStatus)
+	shareGPIOWithOutputs()
+	SetSerialPortOpener(openSerialPort)
+
+	if headlessMode {
+		if err := runHeadless(*portFlag, webDevEnabled, devViteURL); err != nil {
+			logFatal("Web server failed: %v", err)
+		}
+		return
+	}
+
 	if webModeEnabled {
 		bindHost, err := loadWebBindHost()
 		if err != nil {
@@ -81,11 +188,15 @@ func main() {
 	if err != nil {
 		logFatal("Config load failed '%s': %v", userConfigPath, err)
 	}
-	_, config, err = InitializeGlobalProfileManager(userConfigPath, config)
+	profiles, config, err := InitializeGlobalProfileManager(userConfigPath, config)
 	if err != nil {
 		logFatal("Profile initialization failed: %v", err)
 	}
 	configSource := userConfigPath
+	config = cloneMonitorConfig(config)
+	applyConfigOverrides(config)
+	expandWidgetTemplates(config)
+	normalizeMonitorConfig(config)
 
 	// Set global config for monitor system
 	SetGlobalCollectorConfig(config)
@@ -133,7 +244,7 @@ func main() {
 			for _, name := range names {
 				it := items[name]
 				val := "-"
-				if it != nil && it.IsAvailable() {
+				if it != nil && it.IsAvailable() && it.IsReady() {
 					if mv := it.GetValue(); mv != nil {
 						val = FormatCollectValue(mv, true, "")
 					}
@@ -155,6 +266,8 @@ func main() {
 		logFatal("Runtime initialization failed: %v", err)
 	}
 	defer ReleaseSharedWebAPI(runtimeAPI)
+	stopProfileWatch := profiles.WatchActive(profileWatchInterval, runtimeAPI.ApplyConfig)
+	defer stopProfileWatch()
 
 	outputTypes := resolveOutputConfigSummaryFromList(config.Outputs, false).Types
 	webProcessController := NewWebServerProcess(*portFlag, webDevEnabled, devViteURL)
@@ -189,6 +302,8 @@ func main() {
 
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	pauseChan := make(chan os.Signal, 1)
+	notifyPauseSignal(pauseChan)
 
 	logInfo("Monitoring %d items", len(requiredMonitors))
 	for {
@@ -196,6 +311,10 @@ func main() {
 		case <-signalChan:
 			logInfo("Shutdown initiated")
 			return
+		case <-pauseChan:
+			if _, err := runDisplayAction(displayActionPause); err != nil {
+				logWarn("Pause toggle failed: %v", err)
+			}
 		default:
 			time.Sleep(200 * time.Millisecond)
 		}
@@ -237,6 +356,12 @@ func getRequiredMonitors(config *MonitorConfig) []string {
 		refs := collectItemMonitorRefs(&item)
 		queue = appendUniqueMonitorRefs(queue, monitors, refs)
 	}
+	if config.OpenRGB != nil {
+		queue = appendUniqueMonitorRefs(queue, monitors, []string{config.OpenRGB.Monitor})
+	}
+	if config.Summary != nil {
+		queue = appendUniqueMonitorRefs(queue, monitors, summaryMonitorRefs(config.Summary))
+	}
 
 	customByName := make(map[string]CustomMonitorConfig)
 	for _, custom := range config.CustomMonitors {
@@ -244,6 +369,10 @@ func getRequiredMonitors(config *MonitorConfig) []string {
 			continue
 		}
 		customByName[custom.Name] = custom
+		if normalizeCustomMonitorType(custom.Type) == "energy" {
+			customByName[custom.Name+".kwh_day"] = custom
+			customByName[custom.Name+".cost_day"] = custom
+		}
 	}
 
 	for len(queue) > 0 {
@@ -255,7 +384,9 @@ func getRequiredMonitors(config *MonitorConfig) []string {
 			continue
 		}
 
-		if normalizeCustomMonitorType(custom.Type) != "mixed" {
+		switch normalizeCustomMonitorType(custom.Type) {
+		case "mixed", "energy":
+		default:
 			continue
 		}
 
@@ -276,6 +407,7 @@ func getRequiredMonitors(config *MonitorConfig) []string {
 	for monitor := range monitors {
 		result = append(result, monitor)
 	}
+	sort.Strings(result)
 	return result
 }
 
@@ -285,6 +417,20 @@ func normalizeCustomMonitorType(t string) string {
 		return "mixed"
 	case "file":
 		return "file"
+	case "message", "text", "ticker":
+		return "message"
+	case "serial", "uart":
+		return "serial"
+	case "dns":
+		return "dns"
+	case "http", "https":
+		return "http"
+	case "ssh":
+		return "ssh"
+	case "prometheus", "prom":
+		return "prometheus"
+	case "energy", "power":
+		return "energy"
 	case "coolercontrol":
 		return "coolercontrol"
 	case "librehardwaremonitor", "libre", "lhm":
@@ -294,6 +440,36 @@ func normalizeCustomMonitorType(t string) string {
 	}
 }
 
+// listAllConfigs prints the profiles directory as it is on disk, marking
+// the active profile.
+func listAllConfigs() error {
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return err
+	}
+	profiles, err := GetProfileManagerWithPath(configPath)
+	if err != nil {
+		return err
+	}
+	infos, err := NewConfigManager(profiles.profilesDir).ListConfigInfos()
+	if err != nil {
+		return err
+	}
+	active := profiles.ActiveName()
+	fmt.Printf("  %-30s %-20s %s\n", "Name", "Modified", "Size")
+	for _, info := range infos {
+		marker := " "
+		if info.Name == active {
+			marker = "*"
+		}
+		fmt.Printf("%s %-30s %-20s %d\n", marker, info.Name, info.UpdatedAt.Format("2006-01-02 15:04:05"), info.Size)
+	}
+	return nil
+}
+
+// listMonitorsWarmup bounds the wait for every monitor's first sample.
+const listMonitorsWarmup = 5 * time.Second
+
 func listAllMonitors() {
 	fmt.Println("Initializing system monitoring...")
 
@@ -302,13 +478,11 @@ func listAllMonitors() {
 
 	registry := GetCollectorManager()
 
-	// Wait 2 seconds for data to stabilize
-	fmt.Println("Waiting for data to stabilize...")
-	time.Sleep(2 * time.Second)
-
-	// Update all monitors to get current values
-	fmt.Println("Updating monitor values...")
-	_, _, _ = registry.WaitForNextEpoch(0, 500*time.Millisecond)
+	// Rate monitors need two passes before their first sample.
+	fmt.Println("Waiting for first samples...")
+	if !registry.WaitForReady(listMonitorsWarmup) {
+		fmt.Println("Some monitors have no sample yet and are listed as --")
+	}
 
 	// Collect and sort monitor names
 	items := registry.GetAll()
@@ -332,7 +506,9 @@ func listAllMonitors() {
 		}
 
 		value := "-"
-		if monitor.IsAvailable() {
+		if monitor.IsAvailable() && !monitor.IsReady() {
+			value = monitorWarmupPlaceholder
+		} else if monitor.IsAvailable() {
 			monitorValue := monitor.GetValue()
 			if monitorValue != nil {
 				value = FormatCollectValue(monitorValue, true, "")
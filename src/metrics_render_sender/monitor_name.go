@@ -1,17 +1,104 @@
 package main
 
-import "strings"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
 
+// Aliases are old monitor names that still resolve so saved layouts keep
+// working. All of them are deprecated: -validate reports them and migrate
+// rewrites them to the target name.
 var monitorAliasMap = map[string]string{
 	"disk_default_read_speed":  "go_native.disk.total_read",
 	"disk_default_write_speed": "go_native.disk.total_write",
 	"disk_default_temp":        "go_native.disk.max_temp",
+	"disks_total_size":         "go_native.disk.total_size",
+	"disks_total_used":         "go_native.disk.total_used",
+	"disks_total_free":         "go_native.disk.total_free",
+	"net_default_upload":       "go_native.net.default.upload",
+	"net_default_download":     "go_native.net.default.download",
+	"net_default_ip":           "go_native.net.default.ip",
+	"net_default_ip6":          "go_native.net.default.ip6",
+	"net_default_interface":    "go_native.net.default.interface",
+	"gpu_fps":                  "frametime.fps",
+	"frametime_avg":            "frametime.frametime_avg",
+	"fps_1p_low":               "frametime.fps_1p_low",
 }
 
 var monitorAliasLabelMap = map[string]string{
 	"disk_default_read_speed":  "Disk total read speed",
 	"disk_default_write_speed": "Disk total write speed",
 	"disk_default_temp":        "Disk max temperature",
+	"disks_total_size":         "Disks total size",
+	"disks_total_used":         "Disks total used",
+	"disks_total_free":         "Disks total free",
+	"net_default_upload":       "Default net upload",
+	"net_default_download":     "Default net download",
+	"net_default_ip":           "Default net ip",
+	"net_default_ip6":          "Default net ipv6",
+	"net_default_interface":    "Default net interface",
+	"gpu_fps":                  "FPS",
+	"frametime_avg":            "Frame time avg",
+	"fps_1p_low":               "FPS 1% low",
+}
+
+// monitorSlotAlias maps per-slot names such as disk1_temp onto the indexed
+// go_native names, e.g. go_native.disk.1.temp.
+type monitorSlotAlias struct {
+	pattern *regexp.Regexp
+	prefix  string
+	metrics map[string]string
+}
+
+var monitorSlotAliases = []monitorSlotAlias{
+	{
+		pattern: regexp.MustCompile(`^disk([1-9][0-9]*)_([a-z_]+)$`),
+		prefix:  "go_native.disk",
+		metrics: map[string]string{
+			"name":        "name",
+			"size":        "size",
+			"used":        "used",
+			"available":   "available",
+			"usage":       "usage",
+			"temp":        "temp",
+			"health":      "health",
+			"busy":        "busy",
+			"read_speed":  "read",
+			"write_speed": "write",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`^net([1-9][0-9]*)_([a-z0-9_]+)$`),
+		prefix:  "go_native.net",
+		metrics: map[string]string{
+			"upload":    "upload",
+			"download":  "download",
+			"ip":        "ip",
+			"ip6":       "ip6",
+			"interface": "interface",
+		},
+	},
+}
+
+func lookupMonitorAlias(name string) (string, bool) {
+	if target, ok := monitorAliasMap[name]; ok {
+		return target, true
+	}
+	// Current names are dotted; skip the patterns for them on hot paths.
+	if strings.Contains(name, ".") {
+		return "", false
+	}
+	for _, alias := range monitorSlotAliases {
+		match := alias.pattern.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		if metric, ok := alias.metrics[match[2]]; ok {
+			return fmt.Sprintf("%s.%s.%s", alias.prefix, match[1], metric), true
+		}
+	}
+	return "", false
 }
 
 func normalizeMonitorNameInput(name string) string {
@@ -27,14 +114,14 @@ func normalizeMonitorAlias(name string) string {
 	if trimmed == "" {
 		return ""
 	}
-	if target, ok := monitorAliasMap[trimmed]; ok {
+	if target, ok := lookupMonitorAlias(trimmed); ok {
 		return target
 	}
 	return trimmed
 }
 
 func isMonitorAliasName(name string) bool {
-	_, ok := monitorAliasMap[normalizeMonitorAliasInput(name)]
+	_, ok := lookupMonitorAlias(normalizeMonitorAliasInput(name))
 	return ok
 }
 
@@ -59,7 +146,7 @@ func resolveMonitorAliasWithItems(name string, items map[string]*CollectItem) st
 	if normalized == "" {
 		return ""
 	}
-	target, ok := monitorAliasMap[normalized]
+	target, ok := lookupMonitorAlias(normalized)
 	if !ok {
 		return normalized
 	}
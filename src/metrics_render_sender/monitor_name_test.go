@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeMonitorAliasResolvesSlotNames(t *testing.T) {
+	cases := map[string]string{
+		"disk_default_temp":      "go_native.disk.max_temp",
+		"disk1_temp":             "go_native.disk.1.temp",
+		" disk12_read_speed ":    "go_native.disk.12.read",
+		"net2_download":          "go_native.net.2.download",
+		"net_default_download":   "go_native.net.default.download",
+		"net1_ip6":               "go_native.net.1.ip6",
+		"disk0_temp":             "disk0_temp",
+		"disk1_unknown":          "disk1_unknown",
+		"go_native.disk.1.temp":  "go_native.disk.1.temp",
+		"go_native.cpu.usage":    "go_native.cpu.usage",
+		"coolercontrol.fan1_rpm": "coolercontrol.fan1_rpm",
+	}
+	for input, want := range cases {
+		if got := normalizeMonitorAlias(input); got != want {
+			t.Errorf("normalizeMonitorAlias(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if !isMonitorAliasName("disk3_usage") || isMonitorAliasName("go_native.disk.3.usage") {
+		t.Fatal("unexpected alias detection for slot names")
+	}
+}
+
+func TestValidateConfigDataWarnsOnDeprecatedNames(t *testing.T) {
+	data := []byte(`{
+		"schema_version": 1,
+		"items": [
+			{"type": "simple_value", "monitor": "disk1_temp"},
+			{"type": "simple_line_chart", "monitor": "go_native.cpu.usage", "render_attrs_map": {"stack_monitors": ["net1_upload"]}}
+		],
+		"threshold_groups": [{"monitors": ["disk_default_temp"]}]
+	}`)
+	warnings, err := validateConfigData(data)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(warnings) != 3 {
+		t.Fatalf("expected 3 warnings, got %v", warnings)
+	}
+	joined := strings.Join(warnings, "\n")
+	for _, want := range []string{
+		"items[0].monitor: monitor disk1_temp is deprecated, use go_native.disk.1.temp",
+		"items[1].render_attrs_map.stack_monitors[0]: monitor net1_upload is deprecated",
+		"threshold_groups[0].monitors[0]: monitor disk_default_temp is deprecated",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing warning %q in %v", want, warnings)
+		}
+	}
+
+	if _, err := validateConfigData([]byte(`{"items": 1}`)); err == nil {
+		t.Fatal("expected a type error for a malformed config")
+	}
+}
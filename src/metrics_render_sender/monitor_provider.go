@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// MonitorProvider is the extension point for sensor backends kept outside the
+// core collectors, such as vendor SDK bridges. A provider lives in its own
+// file, usually behind a build tag so it is only compiled in on request, and
+// registers a factory from init:
+//
+//	//go:build provider_icue
+//
+//	func init() { RegisterMonitorProvider("icue", newICUEProvider) }
+//
+// It then appears as collector "<name>", off until enabled in
+// collector_config, and its monitors are named "<name>.<monitor>".
+// Monitors and Read may run concurrently. Providers that also implement
+// io.Closer are closed when replaced, disabled or when the collector manager
+// shuts down, and providers that implement MonitorProviderContextReader are
+// read with a context that ends at the collector's collect timeout.
+type MonitorProvider interface {
+	// Monitors lists what the provider can read. It runs on every discovery
+	// pass, so monitors may be added as devices appear.
+	Monitors() ([]MonitorDescriptor, error)
+	// Read returns current values keyed by monitor name without the provider
+	// prefix. Monitors missing from the result are marked unavailable.
+	Read() (map[string]interface{}, error)
+}
+
+// MonitorProviderContextReader is implemented by providers whose Read can be
+// cancelled, e.g. one that queries a vendor service over the network.
+type MonitorProviderContextReader interface {
+	ReadContext(ctx context.Context) (map[string]interface{}, error)
+}
+
+// MonitorDescriptor describes one provider monitor; Label defaults to Name.
+type MonitorDescriptor struct {
+	Name      string
+	Label     string
+	Unit      string
+	Min       float64
+	Max       float64
+	Precision int
+}
+
+// MonitorProviderFactory builds a provider from collector_config.<name>.options.
+// It runs when the collector is enabled and again whenever the options change.
+type MonitorProviderFactory func(options map[string]interface{}) (MonitorProvider, error)
+
+var (
+	monitorProvidersMu sync.RWMutex
+	monitorProviders   = map[string]MonitorProviderFactory{}
+)
+
+// RegisterMonitorProvider makes a provider available to every collector
+// manager. Like database/sql drivers it is meant for init functions and
+// panics on a bad or duplicate name.
+func RegisterMonitorProvider(name string, factory MonitorProviderFactory) {
+	name = strings.TrimSpace(name)
+	if factory == nil {
+		panic("monitor provider " + name + ": nil factory")
+	}
+	if name == "" || strings.ContainsAny(name, ". ") || strings.HasPrefix(name, "go_native") {
+		panic(fmt.Sprintf("monitor provider: invalid name %q", name))
+	}
+	switch name {
+	case collectorCoolerControl, collectorLibreHardwareMonitor, collectorRTSS, collectorBLE, "custom":
+		panic("monitor provider " + name + ": name is taken by a built-in collector")
+	}
+	monitorProvidersMu.Lock()
+	defer monitorProvidersMu.Unlock()
+	if _, exists := monitorProviders[name]; exists {
+		panic("monitor provider " + name + ": registered twice")
+	}
+	monitorProviders[name] = factory
+}
+
+func monitorProviderNames() []string {
+	monitorProvidersMu.RLock()
+	defer monitorProvidersMu.RUnlock()
+	names := make([]string, 0, len(monitorProviders))
+	for name := range monitorProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isMonitorProviderName(name string) bool {
+	monitorProvidersMu.RLock()
+	defer monitorProvidersMu.RUnlock()
+	_, ok := monitorProviders[strings.TrimSpace(name)]
+	return ok
+}
+
+func lookupMonitorProvider(name string) MonitorProviderFactory {
+	monitorProvidersMu.RLock()
+	defer monitorProvidersMu.RUnlock()
+	return monitorProviders[name]
+}
+
+// ProviderCollector adapts a registered MonitorProvider to the Collector
+// interface.
+type ProviderCollector struct {
+	*BaseCollector
+	factory    MonitorProviderFactory
+	mu         sync.Mutex
+	provider   MonitorProvider
+	options    map[string]interface{}
+	optionsKey string
+	lastErr    string
+}
+
+func NewProviderCollector(name string, factory MonitorProviderFactory) *ProviderCollector {
+	if factory == nil {
+		return nil
+	}
+	return &ProviderCollector{
+		BaseCollector: NewBaseCollector(name),
+		factory:       factory,
+	}
+}
+
+func (c *ProviderCollector) ApplyConfig(cfg *MonitorConfig) {
+	name := c.Name()
+	enabled := cfg != nil && cfg.IsCollectorEnabled(name, false)
+	c.SetEnabled(enabled)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !enabled {
+		c.closeProviderLocked()
+		c.options = nil
+		c.optionsKey = ""
+		c.clearItems()
+		return
+	}
+	options := cfg.GetCollectorConfig(name).Options
+	key, _ := json.Marshal(options)
+	if string(key) == c.optionsKey {
+		return
+	}
+	c.closeProviderLocked()
+	c.clearItems()
+	c.options = options
+	c.optionsKey = string(key)
+}
+
+// ensureProviderLocked builds the provider on first use, and retries on each
+// discovery pass after a failed start.
+func (c *ProviderCollector) ensureProviderLocked() MonitorProvider {
+	if c.provider != nil || !c.IsEnabled() {
+		return c.provider
+	}
+	provider, err := c.factory(c.options)
+	if err != nil || provider == nil {
+		if err == nil {
+			err = fmt.Errorf("factory returned no provider")
+		}
+		c.logErrorLocked("start", err)
+		return nil
+	}
+	c.provider = provider
+	c.lastErr = ""
+	return provider
+}
+
+func (c *ProviderCollector) GetAllItems() map[string]*CollectItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	provider := c.ensureProviderLocked()
+	if provider == nil {
+		return c.ItemsSnapshot()
+	}
+	monitors, err := provider.Monitors()
+	if err != nil {
+		c.logErrorLocked("list monitors", err)
+		return c.ItemsSnapshot()
+	}
+	prefix := c.Name() + "."
+	for _, monitor := range monitors {
+		name := strings.TrimSpace(monitor.Name)
+		if name == "" {
+			continue
+		}
+		if item := c.getItem(prefix + name); item != nil {
+			if unit := strings.TrimSpace(monitor.Unit); unit != "" {
+				item.SetUnit(unit)
+			}
+			continue
+		}
+		label := strings.TrimSpace(monitor.Label)
+		if label == "" {
+			label = name
+		}
+		c.setItem(prefix+name, NewCollectItem(prefix+name, label, strings.TrimSpace(monitor.Unit), monitor.Min, monitor.Max, monitor.Precision))
+	}
+	return c.ItemsSnapshot()
+}
+
+func (c *ProviderCollector) UpdateItems() error {
+	return c.UpdateItemsContext(context.Background())
+}
+
+func (c *ProviderCollector) UpdateItemsContext(ctx context.Context) error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	c.mu.Lock()
+	provider := c.provider
+	c.mu.Unlock()
+	if provider == nil {
+		return nil
+	}
+	var values map[string]interface{}
+	var err error
+	if reader, ok := provider.(MonitorProviderContextReader); ok {
+		values, err = reader.ReadContext(ctx)
+	} else {
+		values, err = provider.Read()
+	}
+	items := c.ItemsSnapshot()
+	prefix := c.Name() + "."
+	for key, item := range items {
+		if item == nil || !item.IsEnabled() {
+			continue
+		}
+		value, ok := values[strings.TrimPrefix(key, prefix)]
+		if err != nil || !ok || value == nil {
+			item.SetAvailable(false)
+			continue
+		}
+		item.SetValue(value)
+		item.SetAvailable(true)
+	}
+	return err
+}
+
+// Close releases the provider when the collector manager shuts down.
+func (c *ProviderCollector) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeProviderLocked()
+}
+
+func (c *ProviderCollector) closeProviderLocked() {
+	if closer, ok := c.provider.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logWarnModule("collector", "provider %s close failed: %v", c.Name(), err)
+		}
+	}
+	c.provider = nil
+}
+
+// logErrorLocked logs each distinct failure once rather than on every
+// discovery retry.
+func (c *ProviderCollector) logErrorLocked(action string, err error) {
+	message := err.Error()
+	if message == c.lastErr {
+		return
+	}
+	c.lastErr = message
+	logWarnModule("collector", "provider %s %s failed: %v", c.Name(), action, err)
+}
+
+// slugifyProviderName lowercases text and joins its words with underscores
+// so it can be used inside a monitor name.
+func slugifyProviderName(text string) string {
+	var builder strings.Builder
+	pendingSep := false
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingSep && builder.Len() > 0 {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(r)
+			pendingSep = false
+			continue
+		}
+		pendingSep = true
+	}
+	return builder.String()
+}
+
+const providerCommandStderrBytes = 4096
+
+// runProviderCommand runs a provider's helper tool and returns its stdout.
+// Output is read through a limit and the tool is killed once it writes more
+// than limit bytes, so a misbehaving tool cannot grow the process. The
+// start of stderr is added to the error of a failed run.
+func runProviderCommand(ctx context.Context, limit int, path string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path, args...)
+	stderr := &providerCommandStderr{}
+	cmd.Stderr = stderr
+	// A killed tool may leave children holding its pipes; don't wait on them.
+	cmd.WaitDelay = time.Second
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	output, readErr := io.ReadAll(io.LimitReader(stdout, int64(limit)+1))
+	if len(output) > limit {
+		cancel()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("output larger than %d bytes", limit)
+	}
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && stderr.buf.Len() > 0 {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.buf.String()))
+	}
+	if err == nil {
+		err = readErr
+	}
+	return output, err
+}
+
+// providerCommandStderr keeps the first providerCommandStderrBytes of a
+// tool's stderr and discards the rest.
+type providerCommandStderr struct {
+	buf bytes.Buffer
+}
+
+func (w *providerCommandStderr) Write(p []byte) (int, error) {
+	if room := providerCommandStderrBytes - w.buf.Len(); room > 0 {
+		w.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+func providerStringOption(options map[string]interface{}, key string) string {
+	value, ok := options[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if text, ok := value.(string); ok {
+		return text
+	}
+	return fmt.Sprintf("%v", value)
+}
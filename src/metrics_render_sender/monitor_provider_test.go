@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+type testMonitorProvider struct {
+	options map[string]interface{}
+	values  map[string]interface{}
+	readErr error
+	closed  bool
+}
+
+func (p *testMonitorProvider) Monitors() ([]MonitorDescriptor, error) {
+	return []MonitorDescriptor{
+		{Name: "pump_rpm", Label: "Pump speed", Unit: "RPM"},
+		{Name: "liquid_temp", Unit: "°C", Max: 60, Precision: 1},
+	}, nil
+}
+
+func (p *testMonitorProvider) Read() (map[string]interface{}, error) {
+	return p.values, p.readErr
+}
+
+func (p *testMonitorProvider) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestProviderCollectorExposesPrefixedMonitors(t *testing.T) {
+	var built []*testMonitorProvider
+	collector := NewProviderCollector("testpump", func(options map[string]interface{}) (MonitorProvider, error) {
+		provider := &testMonitorProvider{options: options, values: map[string]interface{}{"pump_rpm": 2400.0}}
+		built = append(built, provider)
+		return provider, nil
+	})
+
+	collector.ApplyConfig(&MonitorConfig{})
+	if collector.IsEnabled() || len(collector.GetAllItems()) != 0 || len(built) != 0 {
+		t.Fatal("expected provider collector to stay off until enabled")
+	}
+
+	cfg := &MonitorConfig{CollectorConfig: map[string]CollectorConfig{
+		"testpump": {Enabled: boolPtr(true), Options: map[string]interface{}{"device": "kraken"}},
+	}}
+	collector.ApplyConfig(cfg)
+	items := collector.GetAllItems()
+	if len(items) != 2 || items["testpump.pump_rpm"] == nil || items["testpump.liquid_temp"] == nil {
+		t.Fatalf("unexpected items: %v", items)
+	}
+	if label := items["testpump.liquid_temp"].GetLabel(); label != "liquid_temp" {
+		t.Fatalf("expected label to default to the name, got %q", label)
+	}
+	if len(built) != 1 || built[0].options["device"] != "kraken" {
+		t.Fatalf("expected one provider built with the collector options, got %d", len(built))
+	}
+
+	if err := collector.UpdateItems(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !items["testpump.pump_rpm"].IsAvailable() || items["testpump.liquid_temp"].IsAvailable() {
+		t.Fatal("expected only monitors present in Read to be available")
+	}
+
+	built[0].readErr = errors.New("device gone")
+	if err := collector.UpdateItems(); err == nil {
+		t.Fatal("expected read error to be returned")
+	}
+	if items["testpump.pump_rpm"].IsAvailable() {
+		t.Fatal("expected monitors to go unavailable on read error")
+	}
+
+	collector.ApplyConfig(cfg)
+	if len(built) != 1 || built[0].closed {
+		t.Fatal("expected unchanged options to keep the provider")
+	}
+	collector.ApplyConfig(&MonitorConfig{CollectorConfig: map[string]CollectorConfig{
+		"testpump": {Enabled: boolPtr(true), Options: map[string]interface{}{"device": "h150i"}},
+	}})
+	if !built[0].closed {
+		t.Fatal("expected changed options to close the old provider")
+	}
+	collector.GetAllItems()
+	if len(built) != 2 || built[1].options["device"] != "h150i" {
+		t.Fatal("expected a new provider for the changed options")
+	}
+}
+
+func TestRegisterMonitorProviderRejectsBuiltinNames(t *testing.T) {
+	factory := func(map[string]interface{}) (MonitorProvider, error) { return &testMonitorProvider{}, nil }
+	for _, name := range []string{"", "coolercontrol", "go_native.fan", "two words"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected RegisterMonitorProvider(%q) to panic", name)
+				}
+			}()
+			RegisterMonitorProvider(name, factory)
+		}()
+	}
+	if isMonitorProviderName("coolercontrol") || defaultCollectorEnabled("go_native.cpu") != true {
+		t.Fatal("rejected names must not be registered")
+	}
+}
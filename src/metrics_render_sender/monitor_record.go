@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// monitorRecorder writes every rendered frame's monitor values to a file, in
+// the JSON lines format -replay reads, or as CSV when the path ends in .csv.
+// Each frame is flushed so a crash or power loss keeps what was captured.
+type monitorRecorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+	csv    *csv.Writer
+
+	// described holds the monitors already in a JSON header line; new ones
+	// get another header line before the frame that first has them.
+	described map[string]struct{}
+	// columns is fixed by the first CSV frame; later monitors are not added.
+	columns []string
+}
+
+// activeRecorder is set by -record; the render loop feeds it.
+var activeRecorder *monitorRecorder
+
+func newMonitorRecorder(path string) (*monitorRecorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	recorder := &monitorRecorder{
+		file:      file,
+		writer:    bufio.NewWriter(file),
+		described: map[string]struct{}{},
+	}
+	if isCSVRecordingPath(path) {
+		recorder.csv = csv.NewWriter(recorder.writer)
+	}
+	return recorder, nil
+}
+
+func isCSVRecordingPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+func initMonitorRecorder(path string) error {
+	recorder, err := newMonitorRecorder(path)
+	if err != nil {
+		return err
+	}
+	activeRecorder = recorder
+	logInfoModule("record", "Recording monitor values to %s", path)
+	return nil
+}
+
+func closeMonitorRecorder() {
+	if activeRecorder == nil {
+		return
+	}
+	if err := activeRecorder.Close(); err != nil {
+		logWarnModule("record", "close failed: %v", err)
+	}
+}
+
+// recordMonitorFrame appends the registry's enabled monitors to the active
+// recording. Write errors are logged and stop the recording.
+func recordMonitorFrame(registry *CollectorManager, now time.Time) {
+	recorder := activeRecorder
+	if recorder == nil || registry == nil {
+		return
+	}
+	if err := recorder.Record(now, registry.GetAll()); err != nil && err != os.ErrClosed {
+		logWarnModule("record", "write failed, recording stopped: %v", err)
+		_ = recorder.Close()
+	}
+}
+
+func (r *monitorRecorder) Record(now time.Time, items map[string]*CollectItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return os.ErrClosed
+	}
+	names := make([]string, 0, len(items))
+	for name, item := range items {
+		if item != nil && item.IsEnabled() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var err error
+	if r.csv != nil {
+		err = r.writeCSVFrame(now, names, items)
+	} else {
+		err = r.writeJSONFrame(now, names, items)
+	}
+	if err != nil {
+		return err
+	}
+	return r.writer.Flush()
+}
+
+func (r *monitorRecorder) writeJSONFrame(now time.Time, names []string, items map[string]*CollectItem) error {
+	header := monitorRecordingHeader{Version: monitorRecordingVersion, Monitors: map[string]monitorRecordingDescriptor{}}
+	frame := monitorRecordingFrame{Time: now, Values: make(map[string]interface{}, len(names))}
+	for _, name := range names {
+		item := items[name]
+		value := item.GetValue()
+		if _, ok := r.described[name]; !ok && value != nil {
+			header.Monitors[name] = monitorRecordingDescriptor{
+				Label:     item.GetLabel(),
+				Unit:      value.Unit,
+				Min:       value.Min,
+				Max:       value.Max,
+				Precision: value.Precision,
+			}
+			r.described[name] = struct{}{}
+		}
+		if value == nil || !item.IsAvailable() || !item.IsReady() {
+			frame.Values[name] = nil
+			continue
+		}
+		frame.Values[name] = value.Value
+	}
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetEscapeHTML(false)
+	if len(header.Monitors) > 0 {
+		if err := encoder.Encode(header); err != nil {
+			return err
+		}
+	}
+	return encoder.Encode(frame)
+}
+
+func (r *monitorRecorder) writeCSVFrame(now time.Time, names []string, items map[string]*CollectItem) error {
+	if r.columns == nil {
+		r.columns = names
+		if err := r.csv.Write(append([]string{"time"}, names...)); err != nil {
+			return err
+		}
+	}
+	row := make([]string, 0, len(r.columns)+1)
+	row = append(row, now.Format(time.RFC3339Nano))
+	for _, name := range r.columns {
+		row = append(row, formatRecordedValue(items[name]))
+	}
+	if err := r.csv.Write(row); err != nil {
+		return err
+	}
+	r.csv.Flush()
+	return r.csv.Error()
+}
+
+// formatRecordedValue writes numbers at full precision and leaves the cell
+// empty when the monitor has no reading.
+func formatRecordedValue(item *CollectItem) string {
+	if item == nil || !item.IsAvailable() || !item.IsReady() {
+		return ""
+	}
+	value := item.GetValue()
+	if value == nil || value.Value == nil {
+		return ""
+	}
+	if text, ok := value.Value.(string); ok {
+		return text
+	}
+	if number, ok := tryGetFloat64(value.Value); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value.Value)
+}
+
+func (r *monitorRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	flushErr := r.writer.Flush()
+	closeErr := r.file.Close()
+	r.file = nil
+	if flushErr != nil {
+		return flushErr
+	}
+	return closeErr
+}
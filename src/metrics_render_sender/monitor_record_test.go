@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMonitorRecorderRoundTripsThroughReplay(t *testing.T) {
+	temp := NewCollectItem("go_native.cpu.temp", "CPU temperature", "°C", 0, 120, 1)
+	model := NewCollectItem("go_native.cpu.model", "CPU model", "", 0, 0, 0)
+	fan := NewCollectItem("go_native.fan.1", "Fan", "RPM", 0, 0, 0)
+	items := map[string]*CollectItem{temp.GetName(): temp, model.GetName(): model, fan.GetName(): fan}
+	temp.SetValue(48.25)
+	temp.SetAvailable(true)
+	model.SetValue("Ryzen 7, 8 cores")
+	model.SetAvailable(true)
+	fan.SetAvailable(false)
+
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"dump.jsonl", "dump.csv"} {
+		path := filepath.Join(t.TempDir(), name)
+		recorder, err := newMonitorRecorder(path)
+		if err != nil {
+			t.Fatalf("%s: open: %v", name, err)
+		}
+		temp.SetValue(48.25)
+		if err := recorder.Record(start, items); err != nil {
+			t.Fatalf("%s: record: %v", name, err)
+		}
+		temp.SetValue(61.0)
+		if err := recorder.Record(start.Add(time.Second), items); err != nil {
+			t.Fatalf("%s: record: %v", name, err)
+		}
+		if err := recorder.Close(); err != nil {
+			t.Fatalf("%s: close: %v", name, err)
+		}
+		if err := recorder.Record(start, items); err != os.ErrClosed {
+			t.Fatalf("%s: expected a closed recorder to refuse frames, got %v", name, err)
+		}
+
+		recording, err := loadMonitorRecording(path)
+		if err != nil {
+			t.Fatalf("%s: load: %v", name, err)
+		}
+		if len(recording.frames) != 2 || recording.offsets[1] != time.Second {
+			t.Fatalf("%s: unexpected timeline %v", name, recording.offsets)
+		}
+		first, second := recording.frameAt(0), recording.frameAt(time.Second)
+		if first.Values["go_native.cpu.temp"] != 48.25 || second.Values["go_native.cpu.temp"] != 61.0 {
+			t.Fatalf("%s: unexpected temperatures %v %v", name, first.Values, second.Values)
+		}
+		if first.Values["go_native.cpu.model"] != "Ryzen 7, 8 cores" || first.Values["go_native.fan.1"] != nil {
+			t.Fatalf("%s: unexpected values %v", name, first.Values)
+		}
+		if strings.HasSuffix(name, ".jsonl") && recording.monitors["go_native.cpu.temp"].Unit != "°C" {
+			t.Fatalf("%s: expected the header to keep units, got %+v", name, recording.monitors)
+		}
+	}
+}
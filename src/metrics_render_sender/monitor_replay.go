@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	collectorReplay           = "replay"
+	monitorRecordingVersion   = 1
+	monitorRecordingMaxLineKB = 4096
+)
+
+// A monitor recording is JSON lines: an optional header describing the
+// monitors, then one frame per collect tick.
+//
+//	{"version":1,"monitors":{"go_native.cpu.temp":{"label":"CPU temperature","unit":"°C","max":120}}}
+//	{"time":"2026-01-02T15:04:05.1Z","values":{"go_native.cpu.temp":54.5,"go_native.cpu.model":"Ryzen 7"}}
+//
+// A null or missing value marks the monitor unavailable in that frame.
+type monitorRecordingHeader struct {
+	Version  int                                   `json:"version"`
+	Monitors map[string]monitorRecordingDescriptor `json:"monitors,omitempty"`
+}
+
+type monitorRecordingDescriptor struct {
+	Label     string  `json:"label,omitempty"`
+	Unit      string  `json:"unit,omitempty"`
+	Min       float64 `json:"min,omitempty"`
+	Max       float64 `json:"max,omitempty"`
+	Precision int     `json:"precision,omitempty"`
+}
+
+type monitorRecordingFrame struct {
+	Time   time.Time              `json:"time"`
+	Values map[string]interface{} `json:"values"`
+}
+
+type monitorRecording struct {
+	path     string
+	monitors map[string]monitorRecordingDescriptor
+	frames   []monitorRecordingFrame
+	// offsets[i] is frame i's time since the first frame; period is the
+	// length of one loop including the gap before the first frame repeats.
+	offsets []time.Duration
+	period  time.Duration
+}
+
+// replaySource is set by -replay; collector managers then play it back
+// instead of running the hardware collectors.
+var replaySource *monitorRecording
+
+func loadMonitorRecording(path string) (*monitorRecording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	recording := &monitorRecording{path: path, monitors: map[string]monitorRecordingDescriptor{}}
+	if isCSVRecordingPath(path) {
+		err = recording.readCSV(file)
+	} else {
+		err = recording.readJSONLines(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(recording.frames) == 0 {
+		return nil, fmt.Errorf("%s has no frames", path)
+	}
+	recording.buildTimeline()
+	return recording, nil
+}
+
+func (r *monitorRecording) readJSONLines(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), monitorRecordingMaxLineKB*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(line), &probe); err != nil {
+			return fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if _, isFrame := probe["values"]; !isFrame {
+			var header monitorRecordingHeader
+			if err := json.Unmarshal([]byte(line), &header); err != nil {
+				return fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			if header.Version > monitorRecordingVersion {
+				return fmt.Errorf("line %d: recording version %d is newer than %d", lineNo, header.Version, monitorRecordingVersion)
+			}
+			for name, descriptor := range header.Monitors {
+				r.monitors[normalizeMonitorAlias(name)] = descriptor
+			}
+			continue
+		}
+		var frame monitorRecordingFrame
+		if err := json.Unmarshal([]byte(line), &frame); err != nil {
+			return fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		values := make(map[string]interface{}, len(frame.Values))
+		for name, value := range frame.Values {
+			name = normalizeMonitorAlias(name)
+			if name == "" {
+				continue
+			}
+			values[name] = value
+			if _, known := r.monitors[name]; !known {
+				r.monitors[name] = monitorRecordingDescriptor{}
+			}
+		}
+		frame.Values = values
+		r.frames = append(r.frames, frame)
+	}
+	return scanner.Err()
+}
+
+// readCSV reads what -record writes for a .csv path: a time column followed
+// by one column per monitor. Empty cells are missing readings.
+func (r *monitorRecording) readCSV(reader io.Reader) error {
+	rows, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	columns := make([]string, len(rows[0]))
+	for idx, name := range rows[0] {
+		if idx == 0 {
+			continue
+		}
+		columns[idx] = normalizeMonitorAlias(name)
+		if columns[idx] != "" {
+			r.monitors[columns[idx]] = monitorRecordingDescriptor{}
+		}
+	}
+	for rowIdx, row := range rows[1:] {
+		frame := monitorRecordingFrame{Values: make(map[string]interface{}, len(row))}
+		for idx, cell := range row {
+			if idx == 0 {
+				at, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(cell))
+				if err != nil {
+					return fmt.Errorf("row %d: %w", rowIdx+2, err)
+				}
+				frame.Time = at
+				continue
+			}
+			if idx >= len(columns) || columns[idx] == "" || cell == "" {
+				continue
+			}
+			if number, err := strconv.ParseFloat(cell, 64); err == nil {
+				frame.Values[columns[idx]] = number
+			} else {
+				frame.Values[columns[idx]] = cell
+			}
+		}
+		r.frames = append(r.frames, frame)
+	}
+	return nil
+}
+
+// buildTimeline orders frames by time. Frames without a time are spaced one
+// second apart.
+func (r *monitorRecording) buildTimeline() {
+	sort.SliceStable(r.frames, func(i, j int) bool { return r.frames[i].Time.Before(r.frames[j].Time) })
+	r.offsets = make([]time.Duration, len(r.frames))
+	first := r.frames[0].Time
+	for idx, frame := range r.frames {
+		if first.IsZero() || frame.Time.IsZero() {
+			r.offsets[idx] = time.Duration(idx) * time.Second
+			continue
+		}
+		r.offsets[idx] = frame.Time.Sub(first)
+	}
+	last := r.offsets[len(r.offsets)-1]
+	gap := time.Second
+	if len(r.offsets) > 1 && last > 0 {
+		gap = last / time.Duration(len(r.offsets)-1)
+	}
+	r.period = last + gap
+}
+
+// frameAt returns the frame playing at elapsed, looping the recording.
+func (r *monitorRecording) frameAt(elapsed time.Duration) monitorRecordingFrame {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	position := elapsed % r.period
+	idx := sort.Search(len(r.offsets), func(i int) bool { return r.offsets[i] > position }) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return r.frames[idx]
+}
+
+// ReplayCollector plays a recording back through the registry, owning every
+// monitor the recording mentions.
+type ReplayCollector struct {
+	*BaseCollector
+	recording *monitorRecording
+	now       func() time.Time
+
+	mu        sync.Mutex
+	startedAt time.Time
+}
+
+func NewReplayCollector(recording *monitorRecording) *ReplayCollector {
+	if recording == nil {
+		return nil
+	}
+	collector := &ReplayCollector{
+		BaseCollector: NewBaseCollector(collectorReplay),
+		recording:     recording,
+		now:           time.Now,
+	}
+	for name, descriptor := range recording.monitors {
+		label := descriptor.Label
+		if label == "" {
+			label = name
+		}
+		collector.setItem(name, NewCollectItem(name, label, descriptor.Unit, descriptor.Min, descriptor.Max, descriptor.Precision))
+	}
+	return collector
+}
+
+func (c *ReplayCollector) GetAllItems() map[string]*CollectItem {
+	return c.ItemsSnapshot()
+}
+
+func (c *ReplayCollector) UpdateItems() error {
+	now := c.now()
+	c.mu.Lock()
+	if c.startedAt.IsZero() {
+		c.startedAt = now
+	}
+	elapsed := now.Sub(c.startedAt)
+	c.mu.Unlock()
+
+	frame := c.recording.frameAt(elapsed)
+	for name, item := range c.ItemsSnapshot() {
+		if !item.IsEnabled() {
+			continue
+		}
+		value, ok := frame.Values[name]
+		if !ok || value == nil {
+			item.SetAvailable(false)
+			continue
+		}
+		item.SetValue(value)
+		item.SetAvailable(true)
+	}
+	return nil
+}
+
+func initReplaySource(path string) error {
+	recording, err := loadMonitorRecording(path)
+	if err != nil {
+		return err
+	}
+	replaySource = recording
+	logInfoModule("replay", "Replaying %s: %d frame(s), %d monitor(s), loop %v", path, len(recording.frames), len(recording.monitors), recording.period)
+	return nil
+}
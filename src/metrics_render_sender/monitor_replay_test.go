@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReplayCollectorPlaysFramesAndLoops(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.jsonl")
+	data := `{"version":1,"monitors":{"go_native.cpu.temp":{"label":"CPU temperature","unit":"°C","max":120,"precision":1}}}
+{"time":"2026-01-02T10:00:00Z","values":{"go_native.cpu.temp":40,"go_native.cpu.model":"Ryzen 7"}}
+{"time":"2026-01-02T10:00:01Z","values":{"go_native.cpu.temp":55.5,"disk1_temp":38}}
+
+{"time":"2026-01-02T10:00:02Z","values":{"go_native.cpu.temp":null}}
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	recording, err := loadMonitorRecording(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if recording.period != 3*time.Second {
+		t.Fatalf("expected a 3s loop, got %v", recording.period)
+	}
+
+	collector := NewReplayCollector(recording)
+	items := collector.GetAllItems()
+	temp := items["go_native.cpu.temp"]
+	if temp == nil || temp.GetLabel() != "CPU temperature" || temp.GetValue().Unit != "°C" {
+		t.Fatalf("expected the header to describe the temperature, got %+v", temp)
+	}
+	disk := items["go_native.disk.1.temp"]
+	if disk == nil {
+		t.Fatalf("expected aliased names to resolve, got %v", items)
+	}
+
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	collector.now = func() time.Time { return now }
+	check := func(offset time.Duration, want interface{}) {
+		t.Helper()
+		now = start.Add(offset)
+		if err := collector.UpdateItems(); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+		if want == nil {
+			if temp.IsAvailable() {
+				t.Fatalf("at %v expected temp unavailable", offset)
+			}
+			return
+		}
+		if !temp.IsAvailable() || temp.GetValue().Value != want {
+			t.Fatalf("at %v expected %v, got %v", offset, want, temp.GetValue().Value)
+		}
+	}
+	check(0, 40.0)
+	if items["go_native.cpu.model"].GetValue().Value != "Ryzen 7" || disk.IsAvailable() {
+		t.Fatal("expected text values to replay and unrecorded monitors to be unavailable")
+	}
+	check(1500*time.Millisecond, 55.5)
+	check(2*time.Second, nil)
+	check(3*time.Second, 40.0)
+}
+
+func TestLoadMonitorRecordingRejectsEmptyAndNewer(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"empty.jsonl":  `{"version":1}` + "\n",
+		"newer.jsonl":  `{"version":99}` + "\n" + `{"values":{"a":1}}` + "\n",
+		"broken.jsonl": `{"values":`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadMonitorRecording(path); err == nil {
+			t.Fatalf("expected %s to fail", name)
+		}
+	}
+}
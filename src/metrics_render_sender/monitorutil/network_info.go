@@ -1,7 +0,0 @@
-package monitorutil
-
-type NetworkInfoData struct {
-	IP            string
-	UploadSpeed   float64
-	DownloadSpeed float64
-}
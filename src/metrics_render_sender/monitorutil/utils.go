@@ -2,6 +2,7 @@ package monitorutil
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -15,12 +16,24 @@ func ReadSysFile(path string) (string, error) {
 	return readSysFile(path)
 }
 
+// SysFileMaxBytes caps readSysFile so a path pointing at a large or endless
+// file can not exhaust memory.
+const SysFileMaxBytes = 64 << 10
+
 // readSysFile reads a system file and returns its content as string
 func readSysFile(path string) (string, error) {
-	data, err := os.ReadFile(path)
+	file, err := os.Open(path)
 	if err != nil {
 		return "", err
 	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, SysFileMaxBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > SysFileMaxBytes {
+		return "", fmt.Errorf("%s: larger than %d bytes", path, SysFileMaxBytes)
+	}
 	return strings.TrimSpace(string(data)), nil
 }
 
@@ -48,11 +61,10 @@ func findHwmonSensor(namePatterns []string, tempFile string) (string, float64, e
 	for _, hwmon := range hwmonDirs {
 		hwmonPath := fmt.Sprintf("/sys/class/hwmon/%s", hwmon.Name())
 
-		nameBytes, err := os.ReadFile(hwmonPath + "/name")
+		hwmonName, err := readSysFile(hwmonPath + "/name")
 		if err != nil {
 			continue
 		}
-		hwmonName := strings.TrimSpace(string(nameBytes))
 
 		// Check if this hwmon matches any of the patterns
 		matched := false
@@ -188,9 +200,8 @@ func (c *MonitorDataCache) Clear() {
 
 // Common sensor name patterns
 var (
-	CPUSensorPatterns  = []string{"k10temp", "coretemp", "zenpower", "cpu", "package"}
-	GPUSensorPatterns  = []string{"nouveau", "amdgpu", "radeon", "i915", "xe", "intel", "nvidia"}
-	DiskSensorPatterns = []string{"nvme", "sata", "ata", "scsi", "drivetemp"}
+	CPUSensorPatterns = []string{"k10temp", "coretemp", "zenpower", "cpu", "package"}
+	GPUSensorPatterns = []string{"nouveau", "amdgpu", "radeon", "i915", "xe", "intel", "nvidia"}
 )
 
 // Common temperature ranges
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// networkInterfaceState is the set of active interfaces and which one is
+// the default, i.e. carries the default route.
+type networkInterfaceState struct {
+	Interfaces []string
+	Addresses  map[string]networkAddresses
+	Default    string
+}
+
+func (s networkInterfaceState) equal(other networkInterfaceState) bool {
+	if s.Default != other.Default || !slices.Equal(s.Interfaces, other.Interfaces) || len(s.Addresses) != len(other.Addresses) {
+		return false
+	}
+	for name, addresses := range s.Addresses {
+		if otherAddresses, ok := other.Addresses[name]; !ok || otherAddresses != addresses {
+			return false
+		}
+	}
+	return true
+}
+
+// networkInterfaceWatcher polls the interface list and tells subscribers
+// when it or the default interface changes, e.g. when a laptop moves from
+// Ethernet to Wi-Fi.
+type networkInterfaceWatcher struct {
+	now     func() time.Time
+	sampler *networkSampler
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	state     networkInterfaceState
+	nextID    int
+	callbacks map[int]func(previous, current networkInterfaceState)
+}
+
+var sharedNetworkInterfaces = newNetworkInterfaceWatcher(sharedNetworkSampler)
+
+func newNetworkInterfaceWatcher(sampler *networkSampler) *networkInterfaceWatcher {
+	return &networkInterfaceWatcher{
+		now:       sensors.Now,
+		sampler:   sampler,
+		callbacks: make(map[int]func(previous, current networkInterfaceState)),
+	}
+}
+
+// OnChange registers a callback run by Refresh after a change. The returned
+// function removes it.
+func (w *networkInterfaceWatcher) OnChange(callback func(previous, current networkInterfaceState)) func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.nextID++
+	id := w.nextID
+	w.callbacks[id] = callback
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.callbacks, id)
+	}
+}
+
+// Refresh re-reads the interfaces at most once per networkSampleMinInterval
+// and returns the current state. Callbacks run on the caller's goroutine.
+func (w *networkInterfaceWatcher) Refresh() networkInterfaceState {
+	w.mu.Lock()
+	now := w.now()
+	if !w.checkedAt.IsZero() && now.Sub(w.checkedAt) < networkSampleMinInterval {
+		state := w.state
+		w.mu.Unlock()
+		return state
+	}
+	w.checkedAt = now
+	w.mu.Unlock()
+
+	current := readNetworkInterfaceState(preferredNetworkInterface())
+
+	w.mu.Lock()
+	previous := w.state
+	w.state = current
+	changed := !previous.equal(current)
+	callbacks := make([]func(previous, current networkInterfaceState), 0, len(w.callbacks))
+	if changed {
+		ids := make([]int, 0, len(w.callbacks))
+		for id := range w.callbacks {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		for _, id := range ids {
+			callbacks = append(callbacks, w.callbacks[id])
+		}
+	}
+	w.mu.Unlock()
+
+	if changed && w.sampler != nil {
+		w.sampler.Forget(removedNetworkInterfaces(previous, current))
+	}
+	for _, callback := range callbacks {
+		callback(previous, current)
+	}
+	return current
+}
+
+func removedNetworkInterfaces(previous, current networkInterfaceState) []string {
+	removed := make([]string, 0)
+	for _, name := range previous.Interfaces {
+		if !slices.Contains(current.Interfaces, name) {
+			removed = append(removed, name)
+		}
+	}
+	return removed
+}
+
+// readNetworkInterfaceState picks the default interface: the configured
+// one if it is up, else the one with the default route, else the first.
+func readNetworkInterfaceState(preferred string) networkInterfaceState {
+	interfaces, addresses := currentPlatform.NetworkInterfaces()
+	state := networkInterfaceState{Interfaces: interfaces, Addresses: addresses}
+	if len(interfaces) == 0 {
+		return state
+	}
+	switch routed := currentPlatform.DefaultNetworkInterface(); {
+	case preferred != "" && slices.Contains(interfaces, preferred):
+		state.Default = preferred
+	case routed != "" && slices.Contains(interfaces, routed):
+		state.Default = routed
+	default:
+		state.Default = interfaces[0]
+	}
+	return state
+}
+
+// preferredNetworkInterface is the network_interface setting, or "" for
+// automatic selection.
+func preferredNetworkInterface() string {
+	cfg := GetGlobalCollectorConfig()
+	if cfg == nil {
+		return ""
+	}
+	return cfg.GetNetworkInterface()
+}
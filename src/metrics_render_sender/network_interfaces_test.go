@@ -0,0 +1,161 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	gopsutilNet "github.com/shirou/gopsutil/v3/net"
+)
+
+func TestNetworkInterfaceWatcherReportsDefaultSwitch(t *testing.T) {
+	platform := &fakePlatform{
+		interfaces:       []string{"eth0", "wlan0"},
+		addresses:        map[string]networkAddresses{"eth0": {IPv4: "10.0.0.2"}, "wlan0": {IPv4: "192.168.1.20"}},
+		defaultInterface: "eth0",
+	}
+	useFakePlatform(t, platform)
+
+	sampler := newNetworkSampler(nil)
+	sampler.counters["eth0"] = gopsutilNet.IOCountersStat{Name: "eth0", BytesSent: 1}
+	watcher := newNetworkInterfaceWatcher(sampler)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	watcher.now = func() time.Time { return now }
+
+	var changes []networkInterfaceState
+	unsubscribe := watcher.OnChange(func(previous, current networkInterfaceState) {
+		changes = append(changes, current)
+	})
+	if state := watcher.Refresh(); state.Default != "eth0" || len(changes) != 1 {
+		t.Fatalf("unexpected first state %+v after %d changes", state, len(changes))
+	}
+
+	// Unplugging the cable moves the default route to Wi-Fi. Inside the poll
+	// window nothing is re-read; the next poll reports the switch.
+	platform.interfaces = []string{"wlan0"}
+	platform.defaultInterface = "wlan0"
+	now = now.Add(100 * time.Millisecond)
+	if state := watcher.Refresh(); state.Default != "eth0" {
+		t.Fatalf("expected the cached state inside the window, got %+v", state)
+	}
+	now = now.Add(networkSampleMinInterval)
+	if state := watcher.Refresh(); state.Default != "wlan0" || len(changes) != 2 {
+		t.Fatalf("expected the switch to be reported, got %+v after %d changes", state, len(changes))
+	}
+	if _, ok := sampler.counters["eth0"]; ok {
+		t.Fatal("expected the removed interface's baseline to be dropped")
+	}
+
+	now = now.Add(networkSampleMinInterval)
+	watcher.Refresh()
+	if len(changes) != 2 {
+		t.Fatal("expected no callback without a change")
+	}
+
+	unsubscribe()
+	platform.defaultInterface = ""
+	platform.interfaces = []string{"eth1", "wlan0"}
+	now = now.Add(networkSampleMinInterval)
+	if state := watcher.Refresh(); state.Default != "eth1" || len(changes) != 2 {
+		t.Fatalf("expected the first interface without a route and no callback after unsubscribe, got %+v", state)
+	}
+}
+
+func TestNetworkCollectorDefaultSlotFollowsSwitchInOneUpdate(t *testing.T) {
+	platform := &fakePlatform{
+		interfaces:       []string{"eth0", "wlan0"},
+		addresses:        map[string]networkAddresses{"eth0": {IPv4: "10.0.0.2"}, "wlan0": {IPv4: "192.168.1.20"}},
+		defaultInterface: "eth0",
+	}
+	useFakePlatform(t, platform)
+	watcher := newNetworkInterfaceWatcher(nil)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	watcher.now = func() time.Time { return now }
+	collector := newGoNativeNetworkCollector(nil, watcher)
+	defer collector.Close()
+
+	items := collector.GetAllItems()
+	if got := items["go_native.net.default.interface"].GetValue().Value; got != "eth0" {
+		t.Fatalf("expected eth0 as default, got %v", got)
+	}
+
+	platform.defaultInterface = "wlan0"
+	now = now.Add(networkSampleMinInterval)
+	if err := collector.UpdateItems(); err != nil {
+		t.Fatal(err)
+	}
+	if got := items["go_native.net.default.interface"].GetValue().Value; got != "wlan0" {
+		t.Fatalf("expected wlan0 after one update, got %v", got)
+	}
+	if got := items["go_native.net.default.ip"].GetValue().Value; got != "192.168.1.20" {
+		t.Fatalf("expected the Wi-Fi address, got %v", got)
+	}
+	if got := items["go_native.net.1.interface"].GetValue().Value; got != "eth0" {
+		t.Fatalf("expected indexed slots to stay put, got %v", got)
+	}
+}
+
+func TestExtractInterfaceIPv6PrefersGlobalOverUniqueLocal(t *testing.T) {
+	iface := gopsutilNet.InterfaceStat{Addrs: gopsutilNet.InterfaceAddrList{
+		{Addr: "192.168.1.20/24"},
+		{Addr: "fe80::1c2b:3dff:fe4e:5f60/64"},
+		{Addr: "fd12:3456:789a::20/64"},
+		{Addr: "2001:db8:1::20/64"},
+		{Addr: "2001:db8:1::21/64"},
+	}}
+	if got := extractInterfaceIPv6(iface); got != "2001:db8:1::20" {
+		t.Fatalf("expected the first global address, got %q", got)
+	}
+
+	iface.Addrs = gopsutilNet.InterfaceAddrList{{Addr: "fe80::1/64"}, {Addr: "fd00::5/64"}}
+	if got := extractInterfaceIPv6(iface); got != "fd00::5" {
+		t.Fatalf("expected the unique local address, got %q", got)
+	}
+	iface.Addrs = gopsutilNet.InterfaceAddrList{{Addr: "fe80::1/64"}, {Addr: "::1/128"}}
+	if got := extractInterfaceIPv6(iface); got != "" {
+		t.Fatalf("expected link-local and loopback to be skipped, got %q", got)
+	}
+}
+
+func TestNetworkCollectorIPFamilySetting(t *testing.T) {
+	platform := &fakePlatform{
+		interfaces: []string{"eth0", "wlan0"},
+		addresses: map[string]networkAddresses{
+			"eth0":  {IPv4: "10.0.0.2", IPv6: "2001:db8::2"},
+			"wlan0": {IPv4: "192.168.1.20"},
+		},
+		defaultInterface: "eth0",
+	}
+	useFakePlatform(t, platform)
+	collector := newGoNativeNetworkCollector(nil, newNetworkInterfaceWatcher(nil))
+	defer collector.Close()
+	items := collector.GetAllItems()
+
+	cases := []struct {
+		family      string
+		defaultIP   string
+		secondIP    string
+		defaultIPv6 string
+	}{
+		{"", "10.0.0.2", "192.168.1.20", "2001:db8::2"},
+		{"v6", "2001:db8::2", "192.168.1.20", "2001:db8::2"},
+		{"both", "10.0.0.2 / 2001:db8::2", "192.168.1.20", "2001:db8::2"},
+	}
+	for _, tc := range cases {
+		cfg := &MonitorConfig{CollectorConfig: map[string]CollectorConfig{
+			collectorGoNativeNetwork: {Options: map[string]interface{}{"ip_family": tc.family}},
+		}}
+		collector.ApplyConfig(cfg)
+		if got := items["go_native.net.default.ip"].GetValue().Value; got != tc.defaultIP {
+			t.Errorf("%q: default ip = %v, want %q", tc.family, got, tc.defaultIP)
+		}
+		if got := items["go_native.net.2.ip"].GetValue().Value; got != tc.secondIP {
+			t.Errorf("%q: net 2 ip = %v, want %q", tc.family, got, tc.secondIP)
+		}
+		if got := items["go_native.net.default.ip6"].GetValue().Value; got != tc.defaultIPv6 {
+			t.Errorf("%q: default ip6 = %v, want %q", tc.family, got, tc.defaultIPv6)
+		}
+	}
+	if items["go_native.net.2.ip6"].IsAvailable() {
+		t.Fatal("expected ip6 to be unavailable on an IPv4-only interface")
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	gopsutilNet "github.com/shirou/gopsutil/v3/net"
+)
+
+// networkSampleMinInterval bounds how often the counters are read. Callers
+// inside the same window share one sample instead of shortening each
+// other's measurement window.
+const networkSampleMinInterval = 500 * time.Millisecond
+
+type networkSpeedSnapshot struct {
+	Upload   float64
+	Download float64
+	OK       bool
+}
+
+// networkSampler is the single reader of interface byte counters. Every
+// network rate shown anywhere comes from its latest sample, so two widgets
+// on the same interface can not disagree.
+type networkSampler struct {
+	readCounters func() ([]gopsutilNet.IOCountersStat, error)
+	now          func() time.Time
+
+	mu sync.Mutex
+	// maxGap is the rateMaxGap of the network collector's schedule.
+	maxGap    time.Duration
+	sampledAt time.Time
+	counters  map[string]gopsutilNet.IOCountersStat
+	rates     map[string]networkSpeedSnapshot
+}
+
+var sharedNetworkSampler = newNetworkSampler(func() ([]gopsutilNet.IOCountersStat, error) {
+	return currentPlatform.NetworkCounters()
+})
+
+func newNetworkSampler(readCounters func() ([]gopsutilNet.IOCountersStat, error)) *networkSampler {
+	return &networkSampler{
+		readCounters: readCounters,
+		now:          sensors.Now,
+		maxGap:       rateSampleMaxGap,
+		counters:     make(map[string]gopsutilNet.IOCountersStat),
+		rates:        make(map[string]networkSpeedSnapshot),
+	}
+}
+
+// Rates returns upload and download in MiB/s for each named interface. An
+// interface is not OK until it has been seen in two samples.
+func (s *networkSampler) Rates(interfaceNames []string) map[string]networkSpeedSnapshot {
+	result := make(map[string]networkSpeedSnapshot, len(interfaceNames))
+	if len(interfaceNames) == 0 {
+		return result
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sampleLocked()
+	for _, name := range interfaceNames {
+		result[name] = s.rates[name]
+	}
+	return result
+}
+
+// Forget drops the baseline of interfaces that went away, so one that comes
+// back starts from a fresh sample.
+func (s *networkSampler) Forget(interfaceNames []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, name := range interfaceNames {
+		delete(s.counters, name)
+		delete(s.rates, name)
+	}
+}
+
+// SetMaxGap sets the longest gap between two samples a rate is computed
+// across.
+func (s *networkSampler) SetMaxGap(maxGap time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxGap = maxGap
+}
+
+// Reset drops every baseline, so rates restart from the next two samples.
+func (s *networkSampler) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sampledAt = time.Time{}
+	s.counters = make(map[string]gopsutilNet.IOCountersStat)
+	s.rates = make(map[string]networkSpeedSnapshot)
+}
+
+func (s *networkSampler) sampleLocked() {
+	now := s.now()
+	// A clock that went backwards must not hold the old sample until it
+	// catches up again, so only a positive short gap reuses it.
+	if gap := now.Sub(s.sampledAt); !s.sampledAt.IsZero() && gap >= 0 && gap < networkSampleMinInterval {
+		return
+	}
+	stats, err := s.readCounters()
+	if err != nil {
+		return
+	}
+	elapsed, hasPrevious := rateElapsed(s.sampledAt, now, s.maxGap)
+	seconds := elapsed.Seconds()
+	counters := make(map[string]gopsutilNet.IOCountersStat, len(stats))
+	rates := make(map[string]networkSpeedSnapshot, len(stats))
+	for _, current := range stats {
+		counters[current.Name] = current
+		previous, ok := s.counters[current.Name]
+		if !hasPrevious || !ok {
+			continue
+		}
+		if current.BytesSent < previous.BytesSent || current.BytesRecv < previous.BytesRecv {
+			continue
+		}
+		rates[current.Name] = networkSpeedSnapshot{
+			Upload:   float64(current.BytesSent-previous.BytesSent) / seconds / 1024 / 1024,
+			Download: float64(current.BytesRecv-previous.BytesRecv) / seconds / 1024 / 1024,
+			OK:       true,
+		}
+	}
+	s.sampledAt = now
+	s.counters = counters
+	s.rates = rates
+}
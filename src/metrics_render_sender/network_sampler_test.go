@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	gopsutilNet "github.com/shirou/gopsutil/v3/net"
+)
+
+func TestNetworkSamplerSharesOneSamplePerWindow(t *testing.T) {
+	var sent, recv uint64
+	reads := 0
+	sampler := newNetworkSampler(func() ([]gopsutilNet.IOCountersStat, error) {
+		reads++
+		return []gopsutilNet.IOCountersStat{{Name: "eth0", BytesSent: sent, BytesRecv: recv}}, nil
+	})
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	sampler.now = func() time.Time { return now }
+
+	if rates := sampler.Rates([]string{"eth0"}); rates["eth0"].OK {
+		t.Fatal("expected no rate before a second sample")
+	}
+	now = start.Add(time.Second)
+	sent, recv = 1<<20, 4<<20
+	first := sampler.Rates([]string{"eth0", "wlan0"})
+	if !first["eth0"].OK || first["eth0"].Upload != 1 || first["eth0"].Download != 4 {
+		t.Fatalf("unexpected rate %+v", first["eth0"])
+	}
+	if first["wlan0"].OK {
+		t.Fatal("expected an unknown interface to have no rate")
+	}
+
+	// A second consumer inside the window gets the same numbers and does not
+	// reset the baseline.
+	now = now.Add(100 * time.Millisecond)
+	sent = 2 << 20
+	if second := sampler.Rates([]string{"eth0"}); second["eth0"] != first["eth0"] || reads != 2 {
+		t.Fatalf("expected the cached sample, got %+v after %d reads", second["eth0"], reads)
+	}
+
+	now = start.Add(2 * time.Second)
+	sent = 0
+	if rates := sampler.Rates([]string{"eth0"}); rates["eth0"].OK {
+		t.Fatal("expected a counter reset to drop the rate")
+	}
+}
+
+func TestNetworkSamplerRebaselinesAcrossTimeJumps(t *testing.T) {
+	var sent uint64
+	sampler := newNetworkSampler(func() ([]gopsutilNet.IOCountersStat, error) {
+		return []gopsutilNet.IOCountersStat{{Name: "eth0", BytesSent: sent}}, nil
+	})
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	sampler.now = func() time.Time { return now }
+	sampler.Rates([]string{"eth0"})
+
+	// The clock stepped back an hour: the sample is taken again instead of
+	// being held until the clock catches up, and no rate is derived from it.
+	now = start.Add(-time.Hour)
+	sent = 10 << 20
+	if rates := sampler.Rates([]string{"eth0"}); rates["eth0"].OK {
+		t.Fatalf("expected no rate across a backwards step, got %+v", rates["eth0"])
+	}
+	now = now.Add(time.Second)
+	sent = 11 << 20
+	if rates := sampler.Rates([]string{"eth0"}); !rates["eth0"].OK || rates["eth0"].Upload != 1 {
+		t.Fatalf("expected 1 MiB/s from the new baseline, got %+v", rates["eth0"])
+	}
+
+	now = now.Add(rateSampleMaxGap + time.Second)
+	sent = 500 << 20
+	if rates := sampler.Rates([]string{"eth0"}); rates["eth0"].OK {
+		t.Fatalf("expected no rate across a long gap, got %+v", rates["eth0"])
+	}
+}
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	defaultOpenRGBAddress   = "127.0.0.1:6742"
+	openRGBClientName       = "MetricsRenderSender"
+	openRGBDialTimeout      = 3 * time.Second
+	openRGBIOTimeout        = 5 * time.Second
+	openRGBPollInterval     = time.Second
+	openRGBRetryInterval    = 10 * time.Second
+	openRGBMaxPacketSize    = 16 << 20
+	openRGBDefaultIdleColor = "#000000"
+
+	// OpenRGB SDK packet ids, protocol version 0.
+	openRGBPacketControllerCount = 0
+	openRGBPacketControllerData  = 1
+	openRGBPacketSetClientName   = 50
+	openRGBPacketUpdateLEDs      = 1050
+	openRGBPacketSetCustomMode   = 1100
+)
+
+var openRGBMagic = []byte("ORGB")
+
+type openRGBController struct {
+	index int
+	name  string
+	leds  int
+}
+
+// openRGBClient speaks the OpenRGB SDK protocol. It stays on protocol 0,
+// which every server accepts and which carries everything needed to paint
+// whole devices.
+type openRGBClient struct {
+	conn net.Conn
+}
+
+func dialOpenRGB(address string) (*openRGBClient, error) {
+	conn, err := net.DialTimeout("tcp", address, openRGBDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	client := &openRGBClient{conn: conn}
+	if err := client.send(0, openRGBPacketSetClientName, append([]byte(openRGBClientName), 0)); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+func (c *openRGBClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *openRGBClient) send(device, packetID int, data []byte) error {
+	header := make([]byte, 16)
+	copy(header, openRGBMagic)
+	binary.LittleEndian.PutUint32(header[4:], uint32(device))
+	binary.LittleEndian.PutUint32(header[8:], uint32(packetID))
+	binary.LittleEndian.PutUint32(header[12:], uint32(len(data)))
+	_ = c.conn.SetWriteDeadline(time.Now().Add(openRGBIOTimeout))
+	_, err := c.conn.Write(append(header, data...))
+	return err
+}
+
+func (c *openRGBClient) receive(packetID int) ([]byte, error) {
+	_ = c.conn.SetReadDeadline(time.Now().Add(openRGBIOTimeout))
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(c.conn, header); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:4], openRGBMagic) {
+		return nil, fmt.Errorf("openrgb: bad packet magic")
+	}
+	if got := int(binary.LittleEndian.Uint32(header[8:])); got != packetID {
+		return nil, fmt.Errorf("openrgb: expected packet %d, got %d", packetID, got)
+	}
+	size := binary.LittleEndian.Uint32(header[12:])
+	if size > openRGBMaxPacketSize {
+		return nil, fmt.Errorf("openrgb: packet of %d bytes too large", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(c.conn, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *openRGBClient) controllers() ([]openRGBController, error) {
+	if err := c.send(0, openRGBPacketControllerCount, nil); err != nil {
+		return nil, err
+	}
+	data, err := c.receive(openRGBPacketControllerCount)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("openrgb: short controller count")
+	}
+	count := int(binary.LittleEndian.Uint32(data))
+	controllers := make([]openRGBController, 0, count)
+	for idx := 0; idx < count; idx++ {
+		if err := c.send(idx, openRGBPacketControllerData, nil); err != nil {
+			return nil, err
+		}
+		data, err := c.receive(openRGBPacketControllerData)
+		if err != nil {
+			return nil, err
+		}
+		controller, err := parseOpenRGBControllerData(data)
+		if err != nil {
+			return nil, fmt.Errorf("controller %d: %w", idx, err)
+		}
+		controller.index = idx
+		controllers = append(controllers, controller)
+	}
+	return controllers, nil
+}
+
+// setColor switches the controller to its direct/custom mode and paints every
+// LED with rgb.
+func (c *openRGBClient) setColor(controller openRGBController, rgb color.RGBA) error {
+	if err := c.send(controller.index, openRGBPacketSetCustomMode, nil); err != nil {
+		return err
+	}
+	data := make([]byte, 6+4*controller.leds)
+	binary.LittleEndian.PutUint32(data, uint32(len(data)))
+	binary.LittleEndian.PutUint16(data[4:], uint16(controller.leds))
+	for idx := 0; idx < controller.leds; idx++ {
+		offset := 6 + 4*idx
+		data[offset] = rgb.R
+		data[offset+1] = rgb.G
+		data[offset+2] = rgb.B
+	}
+	return c.send(controller.index, openRGBPacketUpdateLEDs, data)
+}
+
+// openRGBReader walks a protocol 0 controller description. Only the name and
+// LED count are kept; modes and zones are skipped field by field since they
+// precede the LED list.
+type openRGBReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *openRGBReader) skip(n int) {
+	if r.err != nil {
+		return
+	}
+	if n < 0 || r.pos+n > len(r.data) {
+		r.err = fmt.Errorf("openrgb: truncated controller data")
+		return
+	}
+	r.pos += n
+}
+
+func (r *openRGBReader) u16() int {
+	start := r.pos
+	r.skip(2)
+	if r.err != nil {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint16(r.data[start:]))
+}
+
+func (r *openRGBReader) str() string {
+	length := r.u16()
+	start := r.pos
+	r.skip(length)
+	if r.err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(r.data[start:start+length]), "\x00")
+}
+
+func parseOpenRGBControllerData(data []byte) (openRGBController, error) {
+	r := &openRGBReader{data: data}
+	r.skip(4) // data size
+	r.skip(4) // device type
+	controller := openRGBController{name: r.str()}
+	r.str() // description
+	r.str() // version
+	r.str() // serial
+	r.str() // location
+	modes := r.u16()
+	r.skip(4) // active mode
+	for idx := 0; idx < modes && r.err == nil; idx++ {
+		r.str()
+		r.skip(4 * 9) // value, flags, speed and color limits, speed, direction, color mode
+		r.skip(4 * r.u16())
+	}
+	zones := r.u16()
+	for idx := 0; idx < zones && r.err == nil; idx++ {
+		r.str()
+		r.skip(4 * 4) // type, leds min/max/count
+		r.skip(r.u16())
+	}
+	controller.leds = r.u16()
+	if r.err != nil {
+		return openRGBController{}, r.err
+	}
+	return controller, nil
+}
+
+// openRGBSync repaints the configured devices whenever the resolved color
+// changes, reconnecting after the server goes away.
+type openRGBSync struct {
+	cfg     OpenRGBConfig
+	resolve func() string
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func startOpenRGBSync(cfg *OpenRGBConfig, resolve func(cfg *OpenRGBConfig) string) *openRGBSync {
+	if cfg == nil || resolve == nil {
+		return nil
+	}
+	copyCfg := *cloneOpenRGBConfig(cfg)
+	if strings.TrimSpace(copyCfg.Address) == "" {
+		copyCfg.Address = defaultOpenRGBAddress
+	}
+	syncer := &openRGBSync{
+		cfg:     copyCfg,
+		resolve: func() string { return resolve(&copyCfg) },
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+	logInfoModule("openrgb", "syncing lighting via %s", copyCfg.Address)
+	go syncer.run()
+	return syncer
+}
+
+func cloneOpenRGBConfig(cfg *OpenRGBConfig) *OpenRGBConfig {
+	if cfg == nil {
+		return nil
+	}
+	copyCfg := *cfg
+	copyCfg.Devices = append([]string(nil), cfg.Devices...)
+	return &copyCfg
+}
+
+func openRGBConfigsEqual(left, right *OpenRGBConfig) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	return reflect.DeepEqual(*left, *right)
+}
+
+func (s *openRGBSync) stop() {
+	if s == nil {
+		return
+	}
+	close(s.stopCh)
+	<-s.doneCh
+}
+
+func (s *openRGBSync) run() {
+	defer close(s.doneCh)
+	ticker := time.NewTicker(openRGBPollInterval)
+	defer ticker.Stop()
+
+	var client *openRGBClient
+	var targets []openRGBController
+	var retryAt time.Time
+	applied := ""
+	lastErr := ""
+	defer func() {
+		if client != nil {
+			_ = client.Close()
+		}
+	}()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case now := <-ticker.C:
+			next := s.resolve()
+			if next == "" || next == applied {
+				continue
+			}
+			if client == nil {
+				if now.Before(retryAt) {
+					continue
+				}
+				var err error
+				client, targets, err = s.connect()
+				if err != nil {
+					if err.Error() != lastErr {
+						logWarnModule("openrgb", "connect %s failed: %v", s.cfg.Address, err)
+					}
+					lastErr = err.Error()
+					retryAt = now.Add(openRGBRetryInterval)
+					continue
+				}
+				lastErr = ""
+			}
+			rgb := color.RGBAModel.Convert(parseColor(next)).(color.RGBA)
+			if err := s.paint(client, targets, rgb); err != nil {
+				logWarnModule("openrgb", "set color failed: %v", err)
+				_ = client.Close()
+				client = nil
+				retryAt = now.Add(openRGBRetryInterval)
+				continue
+			}
+			applied = next
+		}
+	}
+}
+
+func (s *openRGBSync) connect() (*openRGBClient, []openRGBController, error) {
+	client, err := dialOpenRGB(s.cfg.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+	controllers, err := client.controllers()
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, err
+	}
+	targets := filterOpenRGBControllers(controllers, s.cfg.Devices)
+	logInfoModule("openrgb", "connected to %s: %d of %d device(s) selected", s.cfg.Address, len(targets), len(controllers))
+	return client, targets, nil
+}
+
+func (s *openRGBSync) paint(client *openRGBClient, targets []openRGBController, rgb color.RGBA) error {
+	for _, target := range targets {
+		if err := client.setColor(target, rgb); err != nil {
+			return fmt.Errorf("%s: %w", target.name, err)
+		}
+	}
+	return nil
+}
+
+// filterOpenRGBControllers keeps controllers whose name contains one of the
+// configured device names, case-insensitively, or all of them when none are
+// configured.
+func filterOpenRGBControllers(controllers []openRGBController, devices []string) []openRGBController {
+	wanted := make([]string, 0, len(devices))
+	for _, device := range devices {
+		if device = strings.ToLower(strings.TrimSpace(device)); device != "" {
+			wanted = append(wanted, device)
+		}
+	}
+	result := make([]openRGBController, 0, len(controllers))
+	for _, controller := range controllers {
+		if controller.leds == 0 {
+			continue
+		}
+		if len(wanted) == 0 {
+			result = append(result, controller)
+			continue
+		}
+		name := strings.ToLower(controller.name)
+		for _, device := range wanted {
+			if strings.Contains(name, device) {
+				result = append(result, controller)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// resolveOpenRGBColor picks the lighting color: the alert color while any
+// threshold group is in its worst zone outside quiet hours, otherwise the
+// threshold color of cfg.Monitor as the display would draw it, otherwise the
+// idle color.
+func resolveOpenRGBColor(cfg *OpenRGBConfig, config *MonitorConfig, registry *CollectorManager) string {
+	if cfg == nil || config == nil || registry == nil {
+		return ""
+	}
+	if zoneColor, alerting := activeAlertZoneColor(config, registry); alerting && !quietHoursActive(time.Now()) {
+		if alertColor := strings.TrimSpace(cfg.AlertColor); alertColor != "" {
+			return alertColor
+		}
+		if zoneColor != "" {
+			return zoneColor
+		}
+	}
+	if monitorName := normalizeMonitorAlias(cfg.Monitor); monitorName != "" {
+		if group := findThresholdGroupForMonitor(config, monitorName); group != nil {
+			if item := registry.Get(monitorName); item != nil && item.IsAvailable() && item.IsReady() {
+				if value := item.GetValue(); value != nil {
+					if number, ok := tryGetFloat64(value.Value); ok {
+						if rangeColor := resolveThresholdRangeColor(group, monitorName, thresholdGroupNumber(group, value, number)); rangeColor != "" {
+							return rangeColor
+						}
+					}
+				}
+			}
+		}
+	}
+	if idleColor := strings.TrimSpace(cfg.IdleColor); idleColor != "" {
+		return idleColor
+	}
+	return openRGBDefaultIdleColor
+}
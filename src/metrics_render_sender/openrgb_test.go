@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/color"
+	"io"
+	"net"
+	"testing"
+)
+
+type openRGBTestWriter struct{ bytes.Buffer }
+
+func (w *openRGBTestWriter) u16(v int) { _ = binary.Write(&w.Buffer, binary.LittleEndian, uint16(v)) }
+func (w *openRGBTestWriter) u32(v int) { _ = binary.Write(&w.Buffer, binary.LittleEndian, uint32(v)) }
+func (w *openRGBTestWriter) str(s string) {
+	w.u16(len(s) + 1)
+	w.WriteString(s)
+	w.WriteByte(0)
+}
+
+// buildOpenRGBControllerData encodes a protocol 0 controller with one mode,
+// one matrix zone and the given number of LEDs.
+func buildOpenRGBControllerData(name string, leds int) []byte {
+	w := &openRGBTestWriter{}
+	w.u32(0)
+	w.u32(5)
+	w.str(name)
+	w.str("desc")
+	w.str("1.0")
+	w.str("serial")
+	w.str("HID: /dev/hidraw0")
+	w.u16(1)
+	w.u32(0)
+	w.str("Direct")
+	for idx := 0; idx < 9; idx++ {
+		w.u32(idx)
+	}
+	w.u16(2)
+	w.u32(0xff0000)
+	w.u32(0x00ff00)
+	w.u16(1)
+	w.str("Keyboard")
+	w.u32(2)
+	w.u32(leds)
+	w.u32(leds)
+	w.u32(leds)
+	w.u16(12)
+	w.u32(1)
+	w.u32(1)
+	w.u32(0)
+	w.u16(leds)
+	for idx := 0; idx < leds; idx++ {
+		w.str("Key")
+		w.u32(idx)
+	}
+	w.u16(leds)
+	for idx := 0; idx < leds; idx++ {
+		w.u32(0)
+	}
+	return w.Bytes()
+}
+
+func TestParseOpenRGBControllerData(t *testing.T) {
+	controller, err := parseOpenRGBControllerData(buildOpenRGBControllerData("Corsair K70", 3))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if controller.name != "Corsair K70" || controller.leds != 3 {
+		t.Fatalf("unexpected controller: %+v", controller)
+	}
+	data := buildOpenRGBControllerData("Corsair K70", 3)
+	if _, err := parseOpenRGBControllerData(data[:40]); err == nil {
+		t.Fatal("expected truncated data to fail")
+	}
+}
+
+func TestOpenRGBClientPaintsControllers(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	client := &openRGBClient{conn: clientConn}
+	defer client.Close()
+
+	readPacket := func() (int, int, []byte) {
+		header := make([]byte, 16)
+		if _, err := io.ReadFull(serverConn, header); err != nil {
+			t.Errorf("read header: %v", err)
+			return 0, 0, nil
+		}
+		data := make([]byte, binary.LittleEndian.Uint32(header[12:]))
+		_, _ = io.ReadFull(serverConn, data)
+		return int(binary.LittleEndian.Uint32(header[4:])), int(binary.LittleEndian.Uint32(header[8:])), data
+	}
+	reply := func(device, packetID int, data []byte) {
+		header := make([]byte, 16)
+		copy(header, openRGBMagic)
+		binary.LittleEndian.PutUint32(header[4:], uint32(device))
+		binary.LittleEndian.PutUint32(header[8:], uint32(packetID))
+		binary.LittleEndian.PutUint32(header[12:], uint32(len(data)))
+		_, _ = serverConn.Write(append(header, data...))
+	}
+
+	done := make(chan []byte, 1)
+	go func() {
+		if _, packetID, _ := readPacket(); packetID == openRGBPacketControllerCount {
+			reply(0, openRGBPacketControllerCount, []byte{2, 0, 0, 0})
+		}
+		for idx, name := range []string{"Corsair K70", "ASUS Aura Motherboard"} {
+			if device, packetID, _ := readPacket(); device == idx && packetID == openRGBPacketControllerData {
+				reply(idx, openRGBPacketControllerData, buildOpenRGBControllerData(name, 2))
+			}
+		}
+		readPacket()
+		_, _, leds := readPacket()
+		done <- leds
+	}()
+
+	controllers, err := client.controllers()
+	if err != nil || len(controllers) != 2 {
+		t.Fatalf("controllers: %v %v", controllers, err)
+	}
+	targets := filterOpenRGBControllers(controllers, []string{"aura"})
+	if len(targets) != 1 || targets[0].index != 1 {
+		t.Fatalf("expected only the motherboard to be selected, got %+v", targets)
+	}
+	if err := client.setColor(targets[0], color.RGBA{R: 0xff, G: 0x40, B: 0x10, A: 0xff}); err != nil {
+		t.Fatalf("set color: %v", err)
+	}
+	leds := <-done
+	want := []byte{14, 0, 0, 0, 2, 0, 0xff, 0x40, 0x10, 0, 0xff, 0x40, 0x10, 0}
+	if !bytes.Equal(leds, want) {
+		t.Fatalf("unexpected UpdateLEDs payload % x", leds)
+	}
+}
+
+func TestResolveOpenRGBColorFollowsThresholds(t *testing.T) {
+	config := &MonitorConfig{
+		ThresholdGroups: []ThresholdGroupConfig{{
+			Name:     "temp",
+			Monitors: []string{"cpu.temp"},
+			Ranges: []ThresholdRangeConfig{
+				{Max: float64Ptr(60), Color: "#00ff00"},
+				{Min: float64Ptr(60), Max: float64Ptr(80), Color: "#ffaa00"},
+				{Min: float64Ptr(80), Color: "#ff0000"},
+			},
+		}},
+	}
+	registry := NewCollectorManager()
+	item := NewCollectItem("cpu.temp", "CPU", "°C", 0, 100, 0)
+	registry.items["cpu.temp"] = item
+	item.SetValue(70.0)
+	item.SetAvailable(true)
+
+	cfg := &OpenRGBConfig{Monitor: "cpu.temp", IdleColor: "#101010"}
+	if got := resolveOpenRGBColor(cfg, config, registry); got != "#ffaa00" {
+		t.Fatalf("expected the monitor's band color, got %q", got)
+	}
+	item.SetValue(90.0)
+	if got := resolveOpenRGBColor(cfg, config, registry); got != "#ff0000" {
+		t.Fatalf("expected the worst zone color while alerting, got %q", got)
+	}
+	cfg.AlertColor = "#ff00ff"
+	if got := resolveOpenRGBColor(cfg, config, registry); got != "#ff00ff" {
+		t.Fatalf("expected the configured alert color, got %q", got)
+	}
+	item.SetAvailable(false)
+	if got := resolveOpenRGBColor(cfg, config, registry); got != "#101010" {
+		t.Fatalf("expected the idle color without a reading, got %q", got)
+	}
+}
@@ -0,0 +1,27 @@
+package output
+
+import "sync/atomic"
+
+const MaxAX206Brightness = 7
+
+var ax206Brightness atomic.Int32
+
+func init() {
+	ax206Brightness.Store(MaxAX206Brightness)
+}
+
+// SetAX206Brightness sets the backlight level (0-7) used by every AX206
+// output. Connected devices pick it up with the next frame.
+func SetAX206Brightness(level int) {
+	if level < 0 {
+		level = 0
+	}
+	if level > MaxAX206Brightness {
+		level = MaxAX206Brightness
+	}
+	ax206Brightness.Store(int32(level))
+}
+
+func AX206Brightness() int {
+	return int(ax206Brightness.Load())
+}
@@ -3,6 +3,9 @@
 package output
 
 import (
+	"fmt"
+	"image"
+	"strings"
 	"sync"
 	"time"
 )
@@ -27,6 +30,8 @@ func normalizeAX206ReconnectInterval(interval time.Duration) time.Duration {
 }
 
 type AX206USBOutputHandler struct {
+	typeName string
+
 	deviceMu sync.RWMutex
 	device   *AX206USB
 	rgb565   *ImageRGB565
@@ -36,6 +41,8 @@ type AX206USBOutputHandler struct {
 	loopWg   sync.WaitGroup
 
 	reconnectCh chan struct{}
+	redrawCh    chan struct{}
+	resetCh     chan struct{}
 	frameCh     chan *OutputFrame
 
 	lastConnectErrMu sync.Mutex
@@ -44,17 +51,32 @@ type AX206USBOutputHandler struct {
 	lastTransferErrMu sync.Mutex
 	lastTransferErrAt time.Time
 
-	reconnectIntervalMu sync.RWMutex
-	reconnectInterval   time.Duration
+	timingMu          sync.RWMutex
+	reconnectInterval time.Duration
+	watchdogInterval  time.Duration
+	latencyBudget     time.Duration
+	fit               string
+	usbOptions        AX206USBOptions
+
+	// Owned by outputLoop: the device and level the backlight was last set for,
+	// the run of consecutive blits over the latency budget and the buffer
+	// frames are scaled into when they do not match the panel.
+	brightnessDevice *AX206USB
+	brightnessLevel  int
+	slowTransfers    int
+	fitted           *image.RGBA
 }
 
-func NewAX206USBOutputHandler(cfg OutputConfig) (*AX206USBOutputHandler, error) {
+func NewAX206USBOutputHandler(cfg OutputConfig, typeName string) (*AX206USBOutputHandler, error) {
 	handler := &AX206USBOutputHandler{
-		stopCh:            make(chan struct{}),
-		reconnectCh:       make(chan struct{}, 1),
-		frameCh:           make(chan *OutputFrame, 1),
-		reconnectInterval: normalizeAX206ReconnectInterval(time.Duration(normalizeAX206ReconnectMS(cfg.ReconnectMS)) * time.Millisecond),
+		typeName:    typeName,
+		stopCh:      make(chan struct{}),
+		reconnectCh: make(chan struct{}, 1),
+		redrawCh:    make(chan struct{}, 1),
+		resetCh:     make(chan struct{}, 1),
+		frameCh:     make(chan *OutputFrame, 1),
 	}
+	handler.UpdateConfig(cfg)
 	handler.loopWg.Add(2)
 	go handler.connectionLoop()
 	go handler.outputLoop()
@@ -62,7 +84,10 @@ func NewAX206USBOutputHandler(cfg OutputConfig) (*AX206USBOutputHandler, error)
 }
 
 func (h *AX206USBOutputHandler) GetType() string {
-	return TypeAX206USB
+	if h.typeName == "" {
+		return TypeAX206USB
+	}
+	return h.typeName
 }
 
 func (h *AX206USBOutputHandler) OutputFrame(frame *OutputFrame) error {
@@ -82,25 +107,50 @@ func (h *AX206USBOutputHandler) Close() error {
 	return nil
 }
 
+// Reset reopens the panel. After a suspend the USB handle may still look
+// open while the device behind it was power cycled.
+func (h *AX206USBOutputHandler) Reset() {
+	select {
+	case h.resetCh <- struct{}{}:
+	default:
+	}
+}
+
 func (h *AX206USBOutputHandler) UpdateConfig(cfg OutputConfig) {
 	if h == nil {
 		return
 	}
 	interval := normalizeAX206ReconnectInterval(time.Duration(normalizeAX206ReconnectMS(cfg.ReconnectMS)) * time.Millisecond)
-	h.reconnectIntervalMu.Lock()
+	h.timingMu.Lock()
 	h.reconnectInterval = interval
-	h.reconnectIntervalMu.Unlock()
+	h.watchdogInterval = time.Duration(normalizeAX206WatchdogMS(cfg.WatchdogMS)) * time.Millisecond
+	h.latencyBudget = time.Duration(normalizeAX206LatencyBudgetMS(cfg.LatencyBudgetMS)) * time.Millisecond
+	h.fit = normalizeAX206FitMode(cfg.Fit)
+	h.usbOptions = AX206USBOptions{
+		Profile:         resolveAX206DeviceProfile(cfg.DeviceProfile, cfg.USBIDs),
+		USBPath:         strings.TrimSpace(cfg.USBPath),
+		USBSerial:       strings.TrimSpace(cfg.USBSerial),
+		TransferTimeout: time.Duration(normalizeAX206TransferTimeoutMS(cfg.TimeoutMS)) * time.Millisecond,
+		Retries:         max(0, normalizeAX206Retries(cfg.Retries)),
+	}
+	h.timingMu.Unlock()
 }
 
 func (h *AX206USBOutputHandler) reconnectDelay() time.Duration {
 	if h == nil {
 		return defaultAX206ReconnectInterval
 	}
-	h.reconnectIntervalMu.RLock()
-	defer h.reconnectIntervalMu.RUnlock()
+	h.timingMu.RLock()
+	defer h.timingMu.RUnlock()
 	return normalizeAX206ReconnectInterval(h.reconnectInterval)
 }
 
+func (h *AX206USBOutputHandler) watchdogTiming() (time.Duration, time.Duration) {
+	h.timingMu.RLock()
+	defer h.timingMu.RUnlock()
+	return h.watchdogInterval, h.latencyBudget
+}
+
 func (h *AX206USBOutputHandler) connectionLoop() {
 	defer h.loopWg.Done()
 
@@ -130,28 +180,122 @@ func (h *AX206USBOutputHandler) connectionLoop() {
 	}
 }
 
+// outputLoop owns all device I/O: frame blits, the redraw after a
+// (re)connect, resets and the periodic watchdog probe.
 func (h *AX206USBOutputHandler) outputLoop() {
 	defer h.loopWg.Done()
+	interval, _ := h.watchdogTiming()
+	watchdog := time.NewTimer(interval)
+	defer watchdog.Stop()
+
+	var lastFrame *OutputFrame
 	for {
 		select {
 		case <-h.stopCh:
+			// A frame queued right before Close, such as the shutdown
+			// screen, is still shown.
+			select {
+			case frame := <-h.frameCh:
+				if frame != nil && frame.Image != nil {
+					h.blitFrame(frame)
+				}
+			default:
+			}
 			return
 		case frame := <-h.frameCh:
-			device := h.getDevice()
-			if device == nil || frame == nil || frame.Image == nil {
+			if frame == nil || frame.Image == nil {
 				continue
 			}
-			startedAt := time.Now()
-			h.rgb565 = frame.RGB565(h.rgb565)
-			err := device.Blit(h.rgb565)
-			recordAX206DeviceFrameRuntime(time.Since(startedAt), err)
-			if err != nil {
-				h.handleTransferFailure(device, err)
+			lastFrame = frame
+			h.blitFrame(frame)
+		case <-h.redrawCh:
+			if lastFrame != nil {
+				h.blitFrame(lastFrame)
 			}
+		case <-h.resetCh:
+			h.slowTransfers = 0
+			h.detachDevice("Reconnecting after resume", nil)
+			h.triggerReconnect()
+		case <-watchdog.C:
+			h.checkDeviceHealth()
+			interval, _ = h.watchdogTiming()
+			watchdog.Reset(interval)
 		}
 	}
 }
 
+func (h *AX206USBOutputHandler) blitFrame(frame *OutputFrame) {
+	device := h.getDevice()
+	if device == nil || frame.Image == nil {
+		return
+	}
+	h.syncBrightness(device)
+	startedAt := time.Now()
+	img := frame.Image
+	if device.DimensionsKnown {
+		h.timingMu.RLock()
+		fit := h.fit
+		h.timingMu.RUnlock()
+		img, h.fitted = fitAX206Image(h.fitted, img, device.Width, device.Height, fit)
+	}
+	h.rgb565 = convertImageToRGB565(h.rgb565, img)
+	err := device.Blit(h.rgb565)
+	elapsed := time.Since(startedAt)
+	recordAX206DeviceFrameRuntime(elapsed, err)
+	recordOutputHealth(h.GetType(), err)
+	if err != nil {
+		h.handleTransferFailure(device, err)
+		return
+	}
+	if _, budget := h.watchdogTiming(); elapsed <= budget {
+		h.slowTransfers = 0
+		return
+	}
+	h.slowTransfers++
+	if h.slowTransfers >= ax206SlowTransferLimit {
+		h.reinitializeDevice(device, fmt.Errorf("%d consecutive transfers over the latency budget, last %v", h.slowTransfers, elapsed.Round(time.Millisecond)))
+	}
+}
+
+// checkDeviceHealth queries the panel size and re-initializes the device when
+// the reply is invalid or too slow. Units that never answered the size query
+// are only covered by the blit latency check.
+func (h *AX206USBOutputHandler) checkDeviceHealth() {
+	device := h.getDevice()
+	if device == nil || !device.DimensionsKnown {
+		return
+	}
+	_, budget := h.watchdogTiming()
+	startedAt := time.Now()
+	width, height, err := device.GetDimensions()
+	if err == nil {
+		err = validateAX206Probe(device.Width, device.Height, width, height, time.Since(startedAt), budget)
+	}
+	if err != nil {
+		h.reinitializeDevice(device, err)
+	}
+}
+
+func (h *AX206USBOutputHandler) reinitializeDevice(device *AX206USB, err error) {
+	logWarnModule(h.GetType(), "Watchdog: panel unresponsive, re-initializing: %v", err)
+	h.slowTransfers = 0
+	h.detachSpecificDevice(device, "Re-initializing", err)
+	h.triggerReconnect()
+}
+
+func (h *AX206USBOutputHandler) syncBrightness(device *AX206USB) {
+	level := AX206Brightness()
+	if device == h.brightnessDevice && level == h.brightnessLevel {
+		return
+	}
+	if err := device.Brightness(level); err != nil {
+		logWarnModule(h.GetType(), "Set brightness %d failed: %v", level, err)
+		return
+	}
+	h.brightnessDevice = device
+	h.brightnessLevel = level
+}
+
 func enqueueLatestAX206Frame(ch chan *OutputFrame, frame *OutputFrame) {
 	select {
 	case ch <- frame:
@@ -177,6 +321,13 @@ func (h *AX206USBOutputHandler) triggerReconnect() {
 	}
 }
 
+func (h *AX206USBOutputHandler) requestRedraw() {
+	select {
+	case h.redrawCh <- struct{}{}:
+	default:
+	}
+}
+
 func (h *AX206USBOutputHandler) getDevice() *AX206USB {
 	h.deviceMu.RLock()
 	defer h.deviceMu.RUnlock()
@@ -188,13 +339,16 @@ func (h *AX206USBOutputHandler) tryConnect() {
 		return
 	}
 
-	device, err := NewAX206USB()
+	h.timingMu.RLock()
+	options := h.usbOptions
+	h.timingMu.RUnlock()
+	device, err := NewAX206USB(options)
 	if err != nil {
 		h.logConnectFailure(err)
 		return
 	}
 
-	if err := device.Brightness(7); err != nil {
+	if err := device.Brightness(AX206Brightness()); err != nil {
 		device.Close()
 		h.logConnectFailure(err)
 		return
@@ -208,20 +362,27 @@ func (h *AX206USBOutputHandler) tryConnect() {
 	}
 	h.device = device
 	h.deviceMu.Unlock()
-	logInfoModule("ax206usb", "Connected (%dx%d)", device.Width, device.Height)
+	logInfoModule(h.GetType(), "Connected (%dx%d) at usb_path=%s usb_serial=%s", device.Width, device.Height, device.USBPath, device.Serial)
+	reportStatus(h.GetType(), nil)
+	if device.DimensionsKnown && h.GetType() == TypeAX206USB {
+		notifyAX206PanelSize(device.Width, device.Height)
+	}
+	h.requestRedraw()
 }
 
 func (h *AX206USBOutputHandler) logConnectFailure(err error) {
 	if err == nil {
 		return
 	}
+	reportStatus(h.GetType(), err)
+	recordOutputHealth(h.GetType(), err)
 	h.lastConnectErrMu.Lock()
 	defer h.lastConnectErrMu.Unlock()
 	if time.Since(h.lastConnectErrAt) < 10*time.Second {
 		return
 	}
 	h.lastConnectErrAt = time.Now()
-	logWarnModule("ax206usb", "Connect failed, will retry: %v", err)
+	logWarnModule(h.GetType(), "Connect failed, will retry: %v", err)
 }
 
 func (h *AX206USBOutputHandler) handleTransferFailure(failedDevice *AX206USB, err error) {
@@ -232,8 +393,9 @@ func (h *AX206USBOutputHandler) handleTransferFailure(failedDevice *AX206USB, er
 	}
 	h.lastTransferErrMu.Unlock()
 	if shouldLog {
-		logWarnModule("ax206usb", "Transfer failed, reconnect scheduled: %v", err)
+		logWarnModule(h.GetType(), "Transfer failed, reconnect scheduled: %v", err)
 	}
+	reportStatus(h.GetType(), err)
 	h.detachSpecificDevice(failedDevice, "Disconnected", err)
 	h.triggerReconnect()
 }
@@ -253,10 +415,10 @@ func (h *AX206USBOutputHandler) detachSpecificDevice(target *AX206USB, reason st
 	h.deviceMu.Unlock()
 	device.Close()
 	if err != nil {
-		logInfoModule("ax206usb", "%s: %v", reason, err)
+		logInfoModule(h.GetType(), "%s: %v", reason, err)
 		return
 	}
-	logInfoModule("ax206usb", "%s", reason)
+	logInfoModule(h.GetType(), "%s", reason)
 }
 
 func (h *AX206USBOutputHandler) detachDevice(reason string, err error) {
@@ -0,0 +1,108 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAX206WatchdogMS        = 30000
+	defaultAX206LatencyBudgetMS   = 2000
+	defaultAX206TransferTimeoutMS = 2000
+	defaultAX206Retries           = 2
+	maxAX206Retries               = 5
+
+	// ax206SlowTransferLimit consecutive blits over the latency budget count
+	// as a frozen panel; a single slow one is usually just USB contention.
+	ax206SlowTransferLimit = 3
+	ax206MaxDimension      = 4096
+
+	// Fit modes for frames whose size differs from the panel: "none" sends
+	// the frame as is, "stretch" scales it to the panel and "contain" keeps
+	// the aspect ratio and letterboxes with black.
+	AX206FitNone    = "none"
+	AX206FitStretch = "stretch"
+	AX206FitContain = "contain"
+)
+
+func normalizeAX206TransferTimeoutMS(timeoutMS int) int {
+	if timeoutMS <= 0 {
+		return defaultAX206TransferTimeoutMS
+	}
+	if timeoutMS < 100 {
+		return 100
+	}
+	if timeoutMS > 30000 {
+		return 30000
+	}
+	return timeoutMS
+}
+
+// normalizeAX206Retries keeps 0 as "use the default"; a negative value
+// disables retries and is kept as -1 so it survives re-normalization.
+func normalizeAX206Retries(retries int) int {
+	if retries < 0 {
+		return -1
+	}
+	if retries == 0 {
+		return defaultAX206Retries
+	}
+	if retries > maxAX206Retries {
+		return maxAX206Retries
+	}
+	return retries
+}
+
+func normalizeAX206WatchdogMS(watchdogMS int) int {
+	if watchdogMS <= 0 {
+		return defaultAX206WatchdogMS
+	}
+	if watchdogMS < 5000 {
+		return 5000
+	}
+	if watchdogMS > 600000 {
+		return 600000
+	}
+	return watchdogMS
+}
+
+func normalizeAX206LatencyBudgetMS(latencyMS int) int {
+	if latencyMS <= 0 {
+		return defaultAX206LatencyBudgetMS
+	}
+	if latencyMS < 100 {
+		return 100
+	}
+	if latencyMS > 30000 {
+		return 30000
+	}
+	return latencyMS
+}
+
+func normalizeAX206FitMode(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case AX206FitStretch:
+		return AX206FitStretch
+	case AX206FitContain:
+		return AX206FitContain
+	default:
+		return AX206FitNone
+	}
+}
+
+// validateAX206Probe checks a watchdog GetDimensions reply against the size
+// reported at connect time. Frozen units often keep ACKing commands but
+// answer queries with garbage or zeros.
+func validateAX206Probe(wantWidth, wantHeight, width, height int, elapsed, budget time.Duration) error {
+	if width <= 0 || height <= 0 || width > ax206MaxDimension || height > ax206MaxDimension {
+		return fmt.Errorf("invalid dimensions %dx%d", width, height)
+	}
+	if width != wantWidth || height != wantHeight {
+		return fmt.Errorf("dimensions changed from %dx%d to %dx%d", wantWidth, wantHeight, width, height)
+	}
+	if budget > 0 && elapsed > budget {
+		return fmt.Errorf("probe took %v, over the %v latency budget", elapsed.Round(time.Millisecond), budget)
+	}
+	return nil
+}
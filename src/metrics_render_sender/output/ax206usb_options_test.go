@@ -0,0 +1,58 @@
+package output
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateAX206Probe(t *testing.T) {
+	budget := 2 * time.Second
+	if err := validateAX206Probe(480, 320, 480, 320, 10*time.Millisecond, budget); err != nil {
+		t.Fatalf("expected healthy probe, got %v", err)
+	}
+	cases := []struct {
+		name          string
+		width, height int
+		elapsed       time.Duration
+	}{
+		{"zeros", 0, 0, time.Millisecond},
+		{"garbage", 0xffff, 0xffff, time.Millisecond},
+		{"changed", 320, 240, time.Millisecond},
+		{"slow", 480, 320, 3 * time.Second},
+	}
+	for _, tc := range cases {
+		if err := validateAX206Probe(480, 320, tc.width, tc.height, tc.elapsed, budget); err == nil {
+			t.Fatalf("%s: expected probe to be rejected", tc.name)
+		}
+	}
+}
+
+func TestNormalizeAX206WatchdogSettings(t *testing.T) {
+	if got := normalizeAX206WatchdogMS(0); got != defaultAX206WatchdogMS {
+		t.Fatalf("unexpected default watchdog: %d", got)
+	}
+	if got := normalizeAX206WatchdogMS(10); got != 5000 {
+		t.Fatalf("unexpected clamped watchdog: %d", got)
+	}
+	if got := normalizeAX206LatencyBudgetMS(-1); got != defaultAX206LatencyBudgetMS {
+		t.Fatalf("unexpected default latency budget: %d", got)
+	}
+	if got := normalizeAX206LatencyBudgetMS(60000); got != 30000 {
+		t.Fatalf("unexpected clamped latency budget: %d", got)
+	}
+}
+
+func TestNormalizeAX206TransferSettings(t *testing.T) {
+	if got := normalizeAX206TransferTimeoutMS(0); got != defaultAX206TransferTimeoutMS {
+		t.Fatalf("unexpected default transfer timeout: %d", got)
+	}
+	cases := map[int]int{0: defaultAX206Retries, -3: -1, 3: 3, 9: maxAX206Retries}
+	for input, want := range cases {
+		if got := normalizeAX206Retries(input); got != want {
+			t.Fatalf("normalizeAX206Retries(%d)=%d want=%d", input, got, want)
+		}
+		if got := normalizeAX206Retries(want); got != want {
+			t.Fatalf("expected normalized retries %d to be stable, got %d", want, got)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"image"
+	"image/color"
+	"sync"
+
+	"golang.org/x/image/draw"
+)
+
+var (
+	ax206PanelListenerMu sync.RWMutex
+	ax206PanelListener   func(width, height int)
+)
+
+// SetAX206PanelListener registers fn to be told the size the primary AX206
+// panel reported each time it connects. fn runs on the connection goroutine
+// and must not block or rebuild outputs synchronously.
+func SetAX206PanelListener(fn func(width, height int)) {
+	ax206PanelListenerMu.Lock()
+	ax206PanelListener = fn
+	ax206PanelListenerMu.Unlock()
+}
+
+func notifyAX206PanelSize(width, height int) {
+	ax206PanelListenerMu.RLock()
+	fn := ax206PanelListener
+	ax206PanelListenerMu.RUnlock()
+	if fn != nil && width > 0 && height > 0 {
+		fn(width, height)
+	}
+}
+
+// fitAX206Image scales src to width x height according to mode, reusing dst
+// when it already has the panel size. Frames that match the panel, and any
+// frame in "none" mode, are returned unchanged.
+func fitAX206Image(dst *image.RGBA, src image.Image, width, height int, mode string) (image.Image, *image.RGBA) {
+	if src == nil || width <= 0 || height <= 0 {
+		return src, dst
+	}
+	bounds := src.Bounds()
+	if mode == AX206FitNone || (bounds.Dx() == width && bounds.Dy() == height) {
+		return src, dst
+	}
+	if dst == nil || dst.Rect.Dx() != width || dst.Rect.Dy() != height {
+		dst = image.NewRGBA(image.Rect(0, 0, width, height))
+	}
+
+	target := dst.Rect
+	if mode == AX206FitContain {
+		draw.Draw(dst, dst.Rect, image.NewUniform(color.Black), image.Point{}, draw.Src)
+		target = containAX206Rect(bounds.Dx(), bounds.Dy(), width, height)
+	}
+	draw.ApproxBiLinear.Scale(dst, target, src, bounds, draw.Src, nil)
+	return dst, dst
+}
+
+// containAX206Rect returns the largest centered rectangle inside a
+// width x height panel with the aspect ratio of a srcWidth x srcHeight frame.
+func containAX206Rect(srcWidth, srcHeight, width, height int) image.Rectangle {
+	if srcWidth <= 0 || srcHeight <= 0 {
+		return image.Rect(0, 0, width, height)
+	}
+	fitWidth := width
+	fitHeight := srcHeight * width / srcWidth
+	if fitHeight > height {
+		fitHeight = height
+		fitWidth = srcWidth * height / srcHeight
+	}
+	x := (width - fitWidth) / 2
+	y := (height - fitHeight) / 2
+	return image.Rect(x, y, x+fitWidth, y+fitHeight)
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFitAX206Image(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 480, 320))
+	for i := range src.Pix {
+		src.Pix[i] = 0xff
+	}
+
+	if got, _ := fitAX206Image(nil, src, 320, 240, AX206FitNone); got != image.Image(src) {
+		t.Fatalf("expected none mode to keep the frame")
+	}
+	if got, _ := fitAX206Image(nil, src, 480, 320, AX206FitStretch); got != image.Image(src) {
+		t.Fatalf("expected matching frame to be kept")
+	}
+
+	got, buf := fitAX206Image(nil, src, 320, 240, AX206FitStretch)
+	if got.Bounds() != image.Rect(0, 0, 320, 240) || color.RGBAModel.Convert(got.At(0, 0)) != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Fatalf("unexpected stretched frame: %v", got.Bounds())
+	}
+
+	got, reused := fitAX206Image(buf, src, 320, 240, AX206FitContain)
+	if reused != buf {
+		t.Fatalf("expected buffer to be reused")
+	}
+	// 480x320 fits 320x240 as 320x213, leaving 13px black bars top and bottom.
+	if color.RGBAModel.Convert(got.At(160, 5)) != (color.RGBA{0, 0, 0, 0xff}) {
+		t.Fatalf("expected letterbox bar, got %v", got.At(160, 5))
+	}
+	if color.RGBAModel.Convert(got.At(160, 120)) != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Fatalf("expected frame content, got %v", got.At(160, 120))
+	}
+}
+
+func TestContainAX206Rect(t *testing.T) {
+	if got := containAX206Rect(480, 320, 320, 240); got != image.Rect(0, 13, 320, 226) {
+		t.Fatalf("unexpected letterbox rect: %v", got)
+	}
+	if got := containAX206Rect(320, 320, 480, 320); got != image.Rect(80, 0, 400, 320) {
+		t.Fatalf("unexpected pillarbox rect: %v", got)
+	}
+	if normalizeAX206FitMode(" Contain ") != AX206FitContain || normalizeAX206FitMode("zoom") != AX206FitNone {
+		t.Fatalf("unexpected fit mode normalization")
+	}
+}
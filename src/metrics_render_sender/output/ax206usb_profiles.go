@@ -0,0 +1,179 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultAX206DeviceProfile = "ax206"
+
+// AX206USBID is one vendor/product pair a profile can be opened with.
+type AX206USBID struct {
+	Vendor  uint16
+	Product uint16
+}
+
+func (id AX206USBID) String() string {
+	return fmt.Sprintf("%04x:%04x", id.Vendor, id.Product)
+}
+
+// AX206DeviceProfile describes one photo frame variant: how to find it on the
+// bus and which SCSI opcodes its firmware speaks. Supporting another hacked
+// frame means adding an entry to ax206DeviceProfiles, not another driver.
+type AX206DeviceProfile struct {
+	Name string
+	IDs  []AX206USBID
+
+	Interface   int
+	EndpointOut int
+	EndpointIn  int
+
+	CommandPrefix byte
+	OpSetProperty byte
+	OpBlit        byte
+	MaxBrightness int
+
+	// QueryDimensions is false for firmware that does not answer the size
+	// query; DefaultWidth/DefaultHeight are used instead.
+	QueryDimensions bool
+	DefaultWidth    int
+	DefaultHeight   int
+}
+
+var ax206DeviceProfiles = map[string]AX206DeviceProfile{
+	defaultAX206DeviceProfile: {
+		Name:            defaultAX206DeviceProfile,
+		IDs:             []AX206USBID{{Vendor: 0x1908, Product: 0x0102}},
+		Interface:       0x00,
+		EndpointOut:     0x01,
+		EndpointIn:      0x81,
+		CommandPrefix:   0xcd,
+		OpSetProperty:   0x01,
+		OpBlit:          0x12,
+		MaxBrightness:   7,
+		QueryDimensions: true,
+		DefaultWidth:    480,
+		DefaultHeight:   320,
+	},
+}
+
+func normalizeAX206DeviceProfileName(name string) string {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if _, ok := ax206DeviceProfiles[normalized]; ok {
+		return normalized
+	}
+	return defaultAX206DeviceProfile
+}
+
+// resolveAX206DeviceProfile returns the named profile, with its ID list
+// replaced by usbIDs when any are configured.
+func resolveAX206DeviceProfile(name string, usbIDs []string) AX206DeviceProfile {
+	profile := ax206DeviceProfiles[normalizeAX206DeviceProfileName(name)]
+	if ids := parseAX206USBIDs(usbIDs); len(ids) > 0 {
+		profile.IDs = ids
+	}
+	return profile
+}
+
+func parseAX206USBID(text string) (AX206USBID, error) {
+	vendorText, productText, ok := strings.Cut(strings.TrimSpace(text), ":")
+	if !ok {
+		return AX206USBID{}, fmt.Errorf("usb id %q is not vid:pid", text)
+	}
+	vendor, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(vendorText)), "0x"), 16, 16)
+	if err != nil {
+		return AX206USBID{}, fmt.Errorf("usb id %q: invalid vendor id", text)
+	}
+	product, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(productText)), "0x"), 16, 16)
+	if err != nil {
+		return AX206USBID{}, fmt.Errorf("usb id %q: invalid product id", text)
+	}
+	return AX206USBID{Vendor: uint16(vendor), Product: uint16(product)}, nil
+}
+
+func parseAX206USBIDs(items []string) []AX206USBID {
+	ids := make([]AX206USBID, 0, len(items))
+	seen := map[AX206USBID]struct{}{}
+	for _, item := range items {
+		id, err := parseAX206USBID(item)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func matchAX206USBID(ids []AX206USBID, vendor, product uint16) bool {
+	for _, id := range ids {
+		if id.Vendor == vendor && id.Product == product {
+			return true
+		}
+	}
+	return false
+}
+
+// formatAX206USBPath renders a bus and port chain the way Linux sysfs names
+// USB devices, e.g. "1-1.4" for bus 1, root port 1, hub port 4.
+func formatAX206USBPath(bus int, ports []int) string {
+	if len(ports) == 0 {
+		return strconv.Itoa(bus)
+	}
+	parts := make([]string, 0, len(ports))
+	for _, port := range ports {
+		parts = append(parts, strconv.Itoa(port))
+	}
+	return strconv.Itoa(bus) + "-" + strings.Join(parts, ".")
+}
+
+// parseAX206USBDeviceNode reads the bus and device address from a usbfs node
+// such as "/dev/bus/usb/001/004", the path handed to a container with
+// --device.
+func parseAX206USBDeviceNode(path string) (int, int, bool) {
+	rest, found := strings.CutPrefix(strings.TrimSpace(path), "/dev/bus/usb/")
+	if !found {
+		return 0, 0, false
+	}
+	busText, addressText, found := strings.Cut(rest, "/")
+	if !found {
+		return 0, 0, false
+	}
+	bus, busErr := strconv.Atoi(busText)
+	address, addressErr := strconv.Atoi(addressText)
+	if busErr != nil || addressErr != nil || bus <= 0 || address <= 0 {
+		return 0, 0, false
+	}
+	return bus, address, true
+}
+
+// matchAX206USBPath reports whether a device is the one usb_path selects,
+// given either as a bus-port chain or as a usbfs device node. An empty
+// usb_path matches every device.
+func matchAX206USBPath(usbPath string, bus, address int, ports []int) bool {
+	if usbPath == "" {
+		return true
+	}
+	if nodeBus, nodeAddress, ok := parseAX206USBDeviceNode(usbPath); ok {
+		return nodeBus == bus && nodeAddress == address
+	}
+	return formatAX206USBPath(bus, ports) == usbPath
+}
+
+// normalizeAX206USBIDs rewrites configured IDs as lowercase "vvvv:pppp",
+// dropping invalid and duplicate entries.
+func normalizeAX206USBIDs(items []string) []string {
+	ids := parseAX206USBIDs(items)
+	if len(ids) == 0 {
+		return nil
+	}
+	normalized := make([]string, 0, len(ids))
+	for _, id := range ids {
+		normalized = append(normalized, id.String())
+	}
+	return normalized
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeAX206USBIDs(t *testing.T) {
+	got := normalizeAX206USBIDs([]string{" 1908:0102 ", "0x1908:0x102", "ABCD:12", "bogus", "1908:10000"})
+	want := []string{"1908:0102", "abcd:0012"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected usb ids: got=%v want=%v", got, want)
+	}
+	if got := normalizeAX206USBIDs([]string{"bogus"}); got != nil {
+		t.Fatalf("expected no ids, got %v", got)
+	}
+}
+
+func TestResolveAX206DeviceProfile(t *testing.T) {
+	profile := resolveAX206DeviceProfile("unknown", nil)
+	if profile.Name != defaultAX206DeviceProfile || len(profile.IDs) != 1 || profile.IDs[0].String() != "1908:0102" {
+		t.Fatalf("unexpected default profile: %+v", profile)
+	}
+	profile = resolveAX206DeviceProfile("AX206", []string{"1234:5678"})
+	if len(profile.IDs) != 1 || profile.IDs[0] != (AX206USBID{Vendor: 0x1234, Product: 0x5678}) {
+		t.Fatalf("expected configured ids to replace profile ids, got %+v", profile.IDs)
+	}
+	if builtin := ax206DeviceProfiles[defaultAX206DeviceProfile]; builtin.IDs[0].Vendor != 0x1908 {
+		t.Fatalf("expected built-in profile to stay untouched, got %+v", builtin.IDs)
+	}
+}
+
+func TestFormatAX206USBPath(t *testing.T) {
+	if got := formatAX206USBPath(1, []int{1, 4}); got != "1-1.4" {
+		t.Fatalf("unexpected hub path: %q", got)
+	}
+	if got := formatAX206USBPath(3, []int{2}); got != "3-2" {
+		t.Fatalf("unexpected root port path: %q", got)
+	}
+}
+
+func TestMatchAX206USBPath(t *testing.T) {
+	if bus, address, ok := parseAX206USBDeviceNode("/dev/bus/usb/001/004"); !ok || bus != 1 || address != 4 {
+		t.Fatalf("unexpected device node parse: bus=%d address=%d ok=%v", bus, address, ok)
+	}
+	for _, invalid := range []string{"1-1.4", "/dev/bus/usb/001", "/dev/bus/usb/x/4", "/dev/bus/usb/000/004"} {
+		if _, _, ok := parseAX206USBDeviceNode(invalid); ok {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+	if !matchAX206USBPath("", 1, 4, []int{1, 4}) {
+		t.Fatalf("expected empty usb_path to match any device")
+	}
+	if !matchAX206USBPath("1-1.4", 1, 9, []int{1, 4}) || matchAX206USBPath("1-1.3", 1, 9, []int{1, 4}) {
+		t.Fatalf("expected bus-port selection to follow the port chain")
+	}
+	if !matchAX206USBPath("/dev/bus/usb/001/009", 1, 9, []int{1, 4}) || matchAX206USBPath("/dev/bus/usb/002/009", 1, 9, []int{1, 4}) {
+		t.Fatalf("expected device node selection to follow bus and address")
+	}
+}
@@ -3,24 +3,18 @@
 package output
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
+	"strings"
+	"time"
 
 	"github.com/google/gousb"
 )
 
 const (
-	ax206vid = 0x1908
-	ax206pid = 0x0102
-
-	usbCmdSetProperty = 0x01
-	usbCmdBlit        = 0x12
-
-	ax206interface = 0x00
-	ax206endpOut   = 0x01
-	ax206endpIn    = 0x81
-
 	usbMassStorageCSWSize   = 13
 	usbMassStorageCSWPassed = 0x00
 )
@@ -123,10 +117,33 @@ func (p *ImageRGB565) Bytes() []byte {
 	return p.PixRect()
 }
 
+// AX206USBOptions selects the device variant and tunes USB transfers. With
+// USBPath or USBSerial set only that exact device is opened, which keeps
+// several identical frames apart. Every bulk transfer is bounded by
+// TransferTimeout, and a failed SCSI command is retried up to Retries times
+// after re-claiming the interface.
+type AX206USBOptions struct {
+	Profile         AX206DeviceProfile
+	USBPath         string
+	USBSerial       string
+	TransferTimeout time.Duration
+	Retries         int
+}
+
 type AX206USB struct {
 	Width  int
 	Height int
 	Debug  bool
+	// DimensionsKnown is false when the size query failed at open time and
+	// Width/Height are defaults.
+	DimensionsKnown bool
+	// USBPath is the bus-port chain of the opened device, e.g. "1-1.4".
+	USBPath string
+	Serial  string
+
+	profile AX206DeviceProfile
+	timeout time.Duration
+	retries int
 
 	ctx       *gousb.Context
 	device    *gousb.Device
@@ -140,8 +157,12 @@ type AX206USB struct {
 	hasIntf   bool
 }
 
-func NewAX206USB() (*AX206USB, error) {
-	ax206 := new(AX206USB)
+func NewAX206USB(opts AX206USBOptions) (*AX206USB, error) {
+	profile := opts.Profile
+	if profile.Name == "" {
+		profile = resolveAX206DeviceProfile("", nil)
+	}
+	ax206 := &AX206USB{profile: profile, timeout: opts.TransferTimeout, retries: opts.Retries}
 
 	ctx := gousb.NewContext()
 	if ctx == nil {
@@ -150,17 +171,19 @@ func NewAX206USB() (*AX206USB, error) {
 	ax206.ctx = ctx
 	ax206.hasCtx = true
 
-	device, err := ctx.OpenDeviceWithVIDPID(ax206vid, ax206pid)
+	device, err := openAX206Device(ctx, profile.IDs, opts.USBPath, opts.USBSerial)
 	if err != nil {
 		ax206.Close()
-		return nil, fmt.Errorf("failed to open device: %v", err)
-	}
-	if device == nil {
-		ax206.Close()
-		return nil, fmt.Errorf("device is nil")
+		return nil, err
 	}
 	ax206.device = device
 	ax206.hasDevice = true
+	if device.Desc != nil {
+		ax206.USBPath = formatAX206USBPath(device.Desc.Bus, device.Desc.Path)
+	}
+	if serial, err := device.SerialNumber(); err == nil {
+		ax206.Serial = serial
+	}
 
 	if ax206.Debug {
 		logDebug("Device opened: %s", device)
@@ -178,44 +201,27 @@ func NewAX206USB() (*AX206USB, error) {
 	ax206.config = config
 	ax206.hasConfig = true
 
-	intf, err := config.Interface(ax206interface, 0)
-	if err != nil {
-		ax206.Close()
-		return nil, fmt.Errorf("failed to get interface: %v", err)
-	}
-	if intf == nil {
-		ax206.Close()
-		return nil, fmt.Errorf("interface is nil")
-	}
-	ax206.intf = intf
-	ax206.hasIntf = true
-
-	outEndp, err := intf.OutEndpoint(ax206endpOut)
-	if err != nil {
+	if err := ax206.claimInterface(); err != nil {
 		ax206.Close()
-		return nil, fmt.Errorf("failed to get out endpoint: %v", err)
+		return nil, err
 	}
-	ax206.outEndp = outEndp
-
-	inEndp, err := intf.InEndpoint(ax206endpIn)
-	if err != nil {
-		ax206.Close()
-		return nil, fmt.Errorf("failed to get in endpoint: %v", err)
-	}
-	ax206.inEndp = inEndp
 
 	// Get actual device dimensions
+	ax206.Width = profile.DefaultWidth
+	ax206.Height = profile.DefaultHeight
+	if !profile.QueryDimensions {
+		return ax206, nil
+	}
 	width, height, err := ax206.GetDimensions()
 	if err != nil {
 		// Fall back to default dimensions if query fails
-		ax206.Width = 480
-		ax206.Height = 320
 		if ax206.Debug {
 			logWarn("Failed to get device dimensions, using defaults: %v", err)
 		}
 	} else {
 		ax206.Width = width
 		ax206.Height = height
+		ax206.DimensionsKnown = true
 		if ax206.Debug {
 			logDebug("Device dimensions: %dx%d", width, height)
 		}
@@ -224,9 +230,115 @@ func NewAX206USB() (*AX206USB, error) {
 	return ax206, nil
 }
 
+// openAX206Device opens the first attached device matching one of ids and,
+// when given, the usb path and serial number.
+func openAX206Device(ctx *gousb.Context, ids []AX206USBID, usbPath, serial string) (*gousb.Device, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no usb ids configured")
+	}
+	if usbPath != "" || serial != "" {
+		return openSelectedAX206Device(ctx, ids, usbPath, serial)
+	}
+	var lastErr error
+	for _, id := range ids {
+		device, err := ctx.OpenDeviceWithVIDPID(gousb.ID(id.Vendor), gousb.ID(id.Product))
+		if err != nil {
+			lastErr = fmt.Errorf("failed to open device %s: %v", id, err)
+			continue
+		}
+		if device != nil {
+			return device, nil
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("device not found")
+}
+
+func openSelectedAX206Device(ctx *gousb.Context, ids []AX206USBID, usbPath, serial string) (*gousb.Device, error) {
+	devices, err := ctx.OpenDevices(func(desc *gousb.DeviceDesc) bool {
+		if !matchAX206USBID(ids, uint16(desc.Vendor), uint16(desc.Product)) {
+			return false
+		}
+		return matchAX206USBPath(usbPath, desc.Bus, desc.Address, desc.Path)
+	})
+	var selected *gousb.Device
+	for _, device := range devices {
+		if selected == nil && (serial == "" || ax206SerialMatches(device, serial)) {
+			selected = device
+			continue
+		}
+		device.Close()
+	}
+	if selected != nil {
+		return selected, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open device: %v", err)
+	}
+	return nil, fmt.Errorf("no device matching usb_path=%q usb_serial=%q", usbPath, serial)
+}
+
+func ax206SerialMatches(device *gousb.Device, serial string) bool {
+	deviceSerial, err := device.SerialNumber()
+	return err == nil && strings.TrimSpace(deviceSerial) == serial
+}
+
+func (ax206 *AX206USB) claimInterface() error {
+	intf, err := ax206.config.Interface(ax206.profile.Interface, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get interface: %v", err)
+	}
+	if intf == nil {
+		return fmt.Errorf("interface is nil")
+	}
+	ax206.intf = intf
+	ax206.hasIntf = true
+
+	outEndp, err := intf.OutEndpoint(ax206.profile.EndpointOut)
+	if err != nil {
+		return fmt.Errorf("failed to get out endpoint: %v", err)
+	}
+	ax206.outEndp = outEndp
+
+	inEndp, err := intf.InEndpoint(ax206.profile.EndpointIn)
+	if err != nil {
+		return fmt.Errorf("failed to get in endpoint: %v", err)
+	}
+	ax206.inEndp = inEndp
+	return nil
+}
+
+// reclaimInterface releases and claims the interface again, which drops any
+// half-finished bulk transaction left on the endpoints.
+func (ax206 *AX206USB) reclaimInterface() error {
+	if ax206.hasIntf {
+		ax206.intf.Close()
+		ax206.hasIntf = false
+	}
+	return ax206.claimInterface()
+}
+
+// withRetry runs one SCSI transaction, retrying it on a freshly claimed
+// interface when it fails.
+func (ax206 *AX206USB) withRetry(op func() error) error {
+	err := op()
+	for attempt := 1; err != nil && attempt <= ax206.retries; attempt++ {
+		if ax206.Debug {
+			logDebug("Retry %d/%d after: %v", attempt, ax206.retries, err)
+		}
+		if claimErr := ax206.reclaimInterface(); claimErr != nil {
+			return fmt.Errorf("%v (re-claim failed: %v)", err, claimErr)
+		}
+		err = op()
+	}
+	return err
+}
+
 func (ax206 *AX206USB) GetDimensions() (width, height int, err error) {
 	cmd := []byte{
-		0xcd, 0, 0, 0,
+		ax206.profile.CommandPrefix, 0, 0, 0,
 		0, 2, 0, 0,
 		0, 0, 0, 0,
 		0, 0, 0, 0,
@@ -250,10 +362,14 @@ func (ax206 *AX206USB) Brightness(lvl int) error {
 	if lvl > 7 {
 		lvl = 7
 	}
+	// Callers use the AX206 0-7 scale; rescale for variants with another range.
+	if maxLevel := ax206.profile.MaxBrightness; maxLevel > 0 && maxLevel != 7 {
+		lvl = (lvl*maxLevel + 3) / 7
+	}
 
 	cmd := []byte{
-		0xcd, 0, 0, 0,
-		0, 6, usbCmdSetProperty,
+		ax206.profile.CommandPrefix, 0, 0, 0,
+		0, 6, ax206.profile.OpSetProperty,
 		1, 0, // PROPERTY_BRIGHTNESS
 		byte(lvl), byte(lvl >> 8),
 		0, 0, 0, 0, 0,
@@ -272,8 +388,8 @@ func (ax206 *AX206USB) Blit(img *ImageRGB565) error {
 		return fmt.Errorf("image bounds are empty")
 	}
 	cmd := []byte{
-		0xcd, 0, 0, 0,
-		0, 6, usbCmdBlit,
+		ax206.profile.CommandPrefix, 0, 0, 0,
+		0, 6, ax206.profile.OpBlit,
 		byte(r.Min.X), byte(r.Min.X >> 8),
 		byte(r.Min.Y), byte(r.Min.Y >> 8),
 		byte(r.Max.X - 1), byte((r.Max.X - 1) >> 8),
@@ -283,9 +399,28 @@ func (ax206 *AX206USB) Blit(img *ImageRGB565) error {
 	return ax206.scsiWrite(cmd, img.Bytes())
 }
 
-func writeBulkAll(endp *gousb.OutEndpoint, data []byte) error {
+func transferContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+func transferError(ctx context.Context, timeout time.Duration, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %v: %w", timeout, err)
+	}
+	return err
+}
+
+func writeBulkAll(endp *gousb.OutEndpoint, data []byte, timeout time.Duration) error {
 	for len(data) > 0 {
-		n, err := endp.Write(data)
+		ctx, cancel := transferContext(timeout)
+		n, err := endp.WriteContext(ctx, data)
+		if err != nil {
+			err = transferError(ctx, timeout, err)
+		}
+		cancel()
 		if err != nil {
 			return err
 		}
@@ -297,10 +432,15 @@ func writeBulkAll(endp *gousb.OutEndpoint, data []byte) error {
 	return nil
 }
 
-func readBulkFull(endp *gousb.InEndpoint, data []byte) (int, error) {
+func readBulkFull(endp *gousb.InEndpoint, data []byte, timeout time.Duration) (int, error) {
 	total := 0
 	for total < len(data) {
-		n, err := endp.Read(data[total:])
+		ctx, cancel := transferContext(timeout)
+		n, err := endp.ReadContext(ctx, data[total:])
+		if err != nil {
+			err = transferError(ctx, timeout, err)
+		}
+		cancel()
 		if err != nil {
 			return total, err
 		}
@@ -368,7 +508,7 @@ func (ax206 *AX206USB) scsiGetAck() error {
 	if ax206.Debug {
 		logDebug("[ACK] Read ACK from device")
 	}
-	n, err := readBulkFull(ax206.inEndp, buf)
+	n, err := readBulkFull(ax206.inEndp, buf, ax206.timeout)
 	if err != nil {
 		return fmt.Errorf("ACK read failed: %v", err)
 	}
@@ -386,11 +526,17 @@ func (ax206 *AX206USB) scsiGetAck() error {
 }
 
 func (ax206 *AX206USB) scsiWrite(cmd []byte, data []byte) error {
+	return ax206.withRetry(func() error {
+		return ax206.scsiWriteOnce(cmd, data)
+	})
+}
+
+func (ax206 *AX206USB) scsiWriteOnce(cmd []byte, data []byte) error {
 	// Write command to device
 	if ax206.Debug {
 		logDebug("[WRITE] Write command to device")
 	}
-	if err := writeBulkAll(ax206.outEndp, ax206.scsiCmdPrepare(cmd, len(data), true)); err != nil {
+	if err := writeBulkAll(ax206.outEndp, ax206.scsiCmdPrepare(cmd, len(data), true), ax206.timeout); err != nil {
 		return fmt.Errorf("command write failed: %v", err)
 	}
 
@@ -399,7 +545,7 @@ func (ax206 *AX206USB) scsiWrite(cmd []byte, data []byte) error {
 		if ax206.Debug {
 			logDebug("[WRITE] Write data to device")
 		}
-		if err := writeBulkAll(ax206.outEndp, data); err != nil {
+		if err := writeBulkAll(ax206.outEndp, data, ax206.timeout); err != nil {
 			return fmt.Errorf("data write failed: %v", err)
 		}
 	}
@@ -408,11 +554,21 @@ func (ax206 *AX206USB) scsiWrite(cmd []byte, data []byte) error {
 }
 
 func (ax206 *AX206USB) scsiRead(cmd []byte, blockLen int) ([]byte, error) {
+	var data []byte
+	err := ax206.withRetry(func() error {
+		var err error
+		data, err = ax206.scsiReadOnce(cmd, blockLen)
+		return err
+	})
+	return data, err
+}
+
+func (ax206 *AX206USB) scsiReadOnce(cmd []byte, blockLen int) ([]byte, error) {
 	// Write command to device
 	if ax206.Debug {
 		logDebug("[READ] Write command to device")
 	}
-	if err := writeBulkAll(ax206.outEndp, ax206.scsiCmdPrepare(cmd, blockLen, false)); err != nil {
+	if err := writeBulkAll(ax206.outEndp, ax206.scsiCmdPrepare(cmd, blockLen, false), ax206.timeout); err != nil {
 		return nil, fmt.Errorf("command write failed: %v", err)
 	}
 
@@ -421,7 +577,7 @@ func (ax206 *AX206USB) scsiRead(cmd []byte, blockLen int) ([]byte, error) {
 	}
 	// Read data from device
 	data := make([]byte, blockLen)
-	n, err := readBulkFull(ax206.inEndp, data)
+	n, err := readBulkFull(ax206.inEndp, data, ax206.timeout)
 	if err != nil {
 		return nil, fmt.Errorf("data read failed: %v", err)
 	}
@@ -0,0 +1,147 @@
+package output
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// outputBreakerFailureLimit consecutive failures take a handler out of
+	// the frame path; after that only periodic probe frames are attempted.
+	outputBreakerFailureLimit = 5
+	outputBreakerMinProbe     = 2 * time.Second
+	outputBreakerMaxProbe     = time.Minute
+	outputBreakerSummaryEvery = time.Minute
+)
+
+// outputBreaker is a per-handler circuit breaker. While it is open frames are
+// skipped except for one probe per interval, the interval doubling up to
+// outputBreakerMaxProbe, and failures are folded into one summary line per
+// minute instead of a warning per frame.
+type outputBreaker struct {
+	name string
+
+	mu            sync.Mutex
+	failures      int
+	open          bool
+	openedAt      time.Time
+	nextProbeAt   time.Time
+	probeInterval time.Duration
+	lastErr       string
+
+	// Counters for the summary window while open.
+	skipped       int64
+	failedProbes  int64
+	lastSummaryAt time.Time
+	totalSkipped  int64
+}
+
+func newOutputBreaker(name string) *outputBreaker {
+	return &outputBreaker{name: name}
+}
+
+// allow reports whether a frame should be attempted now.
+func (b *outputBreaker) allow(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return true
+	}
+	if now.Before(b.nextProbeAt) {
+		b.skipped++
+		b.totalSkipped++
+		b.maybeSummarizeLocked(now)
+		return false
+	}
+	b.probeInterval = min(b.probeInterval*2, outputBreakerMaxProbe)
+	b.nextProbeAt = now.Add(b.probeInterval)
+	return true
+}
+
+// failure records a failed attempt. It returns true while the breaker is
+// still closed, meaning the caller should log the error itself.
+func (b *outputBreaker) failure(now time.Time, err error) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.lastErr = err.Error()
+	}
+	if b.open {
+		b.failedProbes++
+		b.maybeSummarizeLocked(now)
+		reportStatus(b.name, err)
+		return false
+	}
+	b.failures++
+	if b.failures < outputBreakerFailureLimit {
+		return true
+	}
+	b.open = true
+	b.openedAt = now
+	b.lastSummaryAt = now
+	b.probeInterval = outputBreakerMinProbe
+	b.nextProbeAt = now.Add(b.probeInterval)
+	b.skipped = 0
+	b.failedProbes = 0
+	b.totalSkipped = 0
+	setOutputDisabled(b.name, true)
+	logWarnModule(b.name, "Disabled after %d consecutive failures, probing every %v-%v: %s", b.failures, outputBreakerMinProbe, outputBreakerMaxProbe, b.lastErr)
+	reportStatus(b.name, err)
+	return false
+}
+
+// success closes the breaker, logging how long the handler was disabled.
+func (b *outputBreaker) success(now time.Time) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	if !b.open {
+		return
+	}
+	b.open = false
+	setOutputDisabled(b.name, false)
+	logInfoModule(b.name, "Recovered after %v, %d frames skipped", now.Sub(b.openedAt).Round(time.Second), b.totalSkipped)
+	reportStatus(b.name, nil)
+}
+
+// rearm lets an open breaker probe on the next frame, restarting the
+// probe interval from outputBreakerMinProbe.
+func (b *outputBreaker) rearm() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return
+	}
+	b.nextProbeAt = time.Time{}
+	b.probeInterval = outputBreakerMinProbe / 2
+}
+
+func (b *outputBreaker) maybeSummarizeLocked(now time.Time) {
+	if now.Sub(b.lastSummaryAt) < outputBreakerSummaryEvery {
+		return
+	}
+	logWarnModule(
+		b.name,
+		"Still failing for %v: %d frames skipped, %d probes failed in the last %v, last error: %s",
+		now.Sub(b.openedAt).Round(time.Second),
+		b.skipped,
+		b.failedProbes,
+		now.Sub(b.lastSummaryAt).Round(time.Second),
+		b.lastErr,
+	)
+	b.lastSummaryAt = now
+	b.skipped = 0
+	b.failedProbes = 0
+}
@@ -0,0 +1,138 @@
+package output
+
+import (
+	"errors"
+	"image"
+	"testing"
+	"time"
+)
+
+func TestOutputBreakerOpensAndProbes(t *testing.T) {
+	breaker := newOutputBreaker("test")
+	now := time.Unix(1000, 0)
+	failure := errors.New("connection refused")
+
+	for idx := 1; idx < outputBreakerFailureLimit; idx++ {
+		if !breaker.allow(now) || !breaker.failure(now, failure) {
+			t.Fatalf("failure %d: expected breaker to stay closed and the caller to log", idx)
+		}
+	}
+	if breaker.failure(now, failure) {
+		t.Fatalf("expected breaker to open at the failure limit")
+	}
+	if breaker.allow(now.Add(time.Second)) {
+		t.Fatalf("expected frames to be skipped while open")
+	}
+	if !breaker.allow(now.Add(outputBreakerMinProbe)) {
+		t.Fatalf("expected a probe after the first interval")
+	}
+	breaker.failure(now.Add(outputBreakerMinProbe), failure)
+	// The probe interval doubles after each probe.
+	if breaker.allow(now.Add(outputBreakerMinProbe * 2)) {
+		t.Fatalf("expected probe interval to back off")
+	}
+	probeAt := now.Add(outputBreakerMinProbe * 3)
+	if !breaker.allow(probeAt) {
+		t.Fatalf("expected a probe after the doubled interval")
+	}
+	breaker.success(probeAt)
+	if !breaker.allow(probeAt) || !breaker.failure(probeAt, failure) {
+		t.Fatalf("expected success to close the breaker and reset the failure count")
+	}
+	if breaker.skipped != 2 || breaker.totalSkipped != 2 {
+		t.Fatalf("unexpected skip counters: window=%d total=%d", breaker.skipped, breaker.totalSkipped)
+	}
+}
+
+type failingOutputHandler struct {
+	calls  int
+	resets int
+}
+
+func (h *failingOutputHandler) OutputFrame(frame *OutputFrame) error {
+	h.calls++
+	return errors.New("device gone")
+}
+
+func (h *failingOutputHandler) Close() error { return nil }
+
+func (h *failingOutputHandler) GetType() string { return "failing" }
+
+func (h *failingOutputHandler) Reset() { h.resets++ }
+
+func TestOutputManagerSkipsFailingHandler(t *testing.T) {
+	handler := &failingOutputHandler{}
+	manager := NewOutputManager()
+	manager.AddHandler(handler)
+	for idx := 0; idx < outputBreakerFailureLimit*3; idx++ {
+		_ = manager.OutputFrame(&OutputFrame{})
+	}
+	if handler.calls != outputBreakerFailureLimit {
+		t.Fatalf("expected handler to be skipped once the breaker opened, got %d calls", handler.calls)
+	}
+}
+
+func TestOutputManagerResetProbesDisabledHandler(t *testing.T) {
+	handler := &failingOutputHandler{}
+	manager := NewOutputManager()
+	manager.AddHandler(handler)
+	for idx := 0; idx < outputBreakerFailureLimit+2; idx++ {
+		_ = manager.OutputFrame(&OutputFrame{})
+	}
+	manager.Reset()
+	if handler.resets != 1 {
+		t.Fatalf("expected the handler to be reset once, got %d", handler.resets)
+	}
+	_ = manager.OutputFrame(&OutputFrame{})
+	_ = manager.OutputFrame(&OutputFrame{})
+	if handler.calls != outputBreakerFailureLimit+1 {
+		t.Fatalf("expected one probe right after the reset, got %d calls", handler.calls)
+	}
+}
+
+type countingOutputHandler struct {
+	calls int
+}
+
+func (h *countingOutputHandler) OutputFrame(frame *OutputFrame) error {
+	h.calls++
+	return nil
+}
+
+func (h *countingOutputHandler) Close() error { return nil }
+
+func (h *countingOutputHandler) GetType() string { return "counting" }
+
+func TestOutputManagerSkipsUnchangedFrames(t *testing.T) {
+	skipping, always := &countingOutputHandler{}, &countingOutputHandler{}
+	manager := NewOutputManager()
+	manager.addHandler(skipping, true)
+	manager.AddHandler(always)
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	_ = manager.OutputFrame(NewOutputFrame(img))
+	_ = manager.OutputFrame(NewOutputFrame(image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	if skipping.calls != 1 || always.calls != 2 {
+		t.Fatalf("expected the identical frame to reach only the plain handler, got %d and %d calls", skipping.calls, always.calls)
+	}
+	img.Pix[0] = 0xff
+	_ = manager.OutputFrame(NewOutputFrame(img))
+	if skipping.calls != 2 {
+		t.Fatalf("expected a changed frame to be sent, got %d calls", skipping.calls)
+	}
+	manager.Reset()
+	_ = manager.OutputFrame(NewOutputFrame(img))
+	if skipping.calls != 3 {
+		t.Fatalf("expected the frame to be resent after a reset, got %d calls", skipping.calls)
+	}
+	manager.unchanged[0].sentAt = time.Now().Add(-unchangedResendInterval)
+	_ = manager.OutputFrame(NewOutputFrame(img))
+	if skipping.calls != 4 {
+		t.Fatalf("expected an unchanged frame to be resent after the resend interval, got %d calls", skipping.calls)
+	}
+
+	configs := NormalizeConfigs([]OutputConfig{{Type: TypeAX206USB}, {Type: TypeTCPPush, URL: "tcp://127.0.0.1:1"}})
+	if !skipsUnchanged(configs[0]) || skipsUnchanged(configs[1]) {
+		t.Fatalf("expected skip_unchanged on for ax206usb only by default: %#v", configs)
+	}
+}
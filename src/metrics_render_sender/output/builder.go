@@ -3,14 +3,22 @@ package output
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 )
 
 const (
-	TypeMemImg   = "memimg"
-	TypeAX206USB = "ax206usb"
-	TypeHTTPPush = "httppush"
-	TypeTCPPush  = "tcppush"
+	TypeMemImg      = "memimg"
+	TypeAX206USB    = "ax206usb"
+	TypeHTTPPush    = "httppush"
+	TypeTCPPush     = "tcppush"
+	TypeFile        = "file"
+	TypeHTTP        = "http"
+	TypeSSD1306     = "ssd1306"
+	TypeST7789      = "st7789"
+	TypeTuring      = "turing"
+	TypeFramebuffer = "framebuffer"
+	TypeMQTT        = "mqtt"
 )
 
 type ConfigSummary struct {
@@ -25,28 +33,77 @@ type HTTPKeyValue struct {
 }
 
 type OutputConfig struct {
-	Type           string         `json:"type"`
-	Enabled        *bool          `json:"enabled,omitempty"`
-	URL            string         `json:"url,omitempty"`
-	Method         string         `json:"method,omitempty"`
-	BodyMode       string         `json:"body_mode,omitempty"`
-	Format         string         `json:"format,omitempty"`
-	Quality        int            `json:"quality,omitempty"`
-	ContentType    string         `json:"content_type,omitempty"`
-	Headers        []HTTPKeyValue `json:"headers,omitempty"`
-	AuthType       string         `json:"auth_type,omitempty"`
-	AuthUsername   string         `json:"auth_username,omitempty"`
-	AuthPassword   string         `json:"auth_password,omitempty"`
-	AuthToken      string         `json:"auth_token,omitempty"`
-	UploadToken    string         `json:"upload_token,omitempty"`
-	TimeoutMS      int            `json:"timeout_ms,omitempty"`
-	IdleTimeoutSec int            `json:"idle_timeout_sec,omitempty"`
-	BusyCheckMS    int            `json:"busy_check_ms,omitempty"`
-	FileField      string         `json:"file_field,omitempty"`
-	FileName       string         `json:"file_name,omitempty"`
-	FormFields     []HTTPKeyValue `json:"form_fields,omitempty"`
-	SuccessCodes   []int          `json:"success_codes,omitempty"`
-	ReconnectMS    int            `json:"reconnect_ms,omitempty"`
+	Type            string         `json:"type"`
+	Enabled         *bool          `json:"enabled,omitempty"`
+	URL             string         `json:"url,omitempty"`
+	Method          string         `json:"method,omitempty"`
+	BodyMode        string         `json:"body_mode,omitempty"`
+	Format          string         `json:"format,omitempty"`
+	Quality         int            `json:"quality,omitempty"`
+	ContentType     string         `json:"content_type,omitempty"`
+	Headers         []HTTPKeyValue `json:"headers,omitempty"`
+	AuthType        string         `json:"auth_type,omitempty"`
+	AuthUsername    string         `json:"auth_username,omitempty"`
+	AuthPassword    string         `json:"auth_password,omitempty"`
+	AuthToken       string         `json:"auth_token,omitempty"`
+	UploadToken     string         `json:"upload_token,omitempty"`
+	TimeoutMS       int            `json:"timeout_ms,omitempty"`
+	IdleTimeoutSec  int            `json:"idle_timeout_sec,omitempty"`
+	BusyCheckMS     int            `json:"busy_check_ms,omitempty"`
+	FileField       string         `json:"file_field,omitempty"`
+	FileName        string         `json:"file_name,omitempty"`
+	FormFields      []HTTPKeyValue `json:"form_fields,omitempty"`
+	SuccessCodes    []int          `json:"success_codes,omitempty"`
+	ReconnectMS     int            `json:"reconnect_ms,omitempty"`
+	WatchdogMS      int            `json:"watchdog_ms,omitempty"`
+	LatencyBudgetMS int            `json:"latency_budget_ms,omitempty"`
+	Retries         int            `json:"retries,omitempty"`
+	DeviceProfile   string         `json:"device_profile,omitempty"`
+	USBIDs          []string       `json:"usb_ids,omitempty"`
+	USBPath         string         `json:"usb_path,omitempty"`
+	USBSerial       string         `json:"usb_serial,omitempty"`
+	Fit             string         `json:"fit,omitempty"`
+	SkipUnchanged   *bool          `json:"skip_unchanged,omitempty"`
+	Path            string         `json:"path,omitempty"`
+	FileMode        string         `json:"file_mode,omitempty"`
+	Atomic          *bool          `json:"atomic,omitempty"`
+	TmpFS           bool           `json:"tmpfs,omitempty"`
+	MinIntervalMS   int            `json:"min_interval_ms,omitempty"`
+	Listen          string         `json:"listen,omitempty"`
+
+	// E-ink options for httppush, tcppush and file outputs feeding an
+	// e-paper panel, see eink.go.
+	EInk             string `json:"eink,omitempty"`
+	Invert           bool   `json:"invert,omitempty"`
+	FullRefreshEvery int    `json:"full_refresh_every,omitempty"`
+
+	// Panel options for ssd1306 and st7789 outputs, see minidisplay.go.
+	Device     string `json:"device,omitempty"`
+	Width      int    `json:"width,omitempty"`
+	Height     int    `json:"height,omitempty"`
+	I2CAddress int    `json:"i2c_address,omitempty"`
+	OffsetX    int    `json:"offset_x,omitempty"`
+	OffsetY    int    `json:"offset_y,omitempty"`
+	SPIMode    int    `json:"spi_mode,omitempty"`
+	SPISpeedHz int    `json:"spi_speed_hz,omitempty"`
+	GPIOChip   string `json:"gpio_chip,omitempty"`
+	DCLine     *int   `json:"dc_line,omitempty"`
+	ResetLine  *int   `json:"reset_line,omitempty"`
+
+	// Serial panel options for turing outputs, see turing.go. Device,
+	// Width and Height above name the port and the native panel size.
+	Baud        int    `json:"baud,omitempty"`
+	Brightness  int    `json:"brightness,omitempty"`
+	Orientation string `json:"orientation,omitempty"`
+
+	// Broker options for mqtt outputs, see mqtt.go. URL, AuthUsername,
+	// AuthPassword, TimeoutMS and MinIntervalMS above name the broker, the
+	// login, the network timeout and the publish interval.
+	Topic       string `json:"topic,omitempty"`
+	ValuesTopic string `json:"values_topic,omitempty"`
+	QoS         int    `json:"qos,omitempty"`
+	Retain      bool   `json:"retain,omitempty"`
+	ClientID    string `json:"client_id,omitempty"`
 }
 
 func normalizeOutputTypeName(typeName string) string {
@@ -174,6 +231,18 @@ func normalizeHTTPPushTimeoutMS(timeoutMS int) int {
 	return timeoutMS
 }
 
+// normalizeHTTPPushMinIntervalMS keeps 0, no limit, and caps the gap
+// between pushes at one hour.
+func normalizeHTTPPushMinIntervalMS(intervalMS int) int {
+	if intervalMS <= 0 {
+		return 0
+	}
+	if intervalMS > 3600000 {
+		return 3600000
+	}
+	return intervalMS
+}
+
 func normalizeHTTPPushContentType(contentType string) string {
 	return strings.TrimSpace(contentType)
 }
@@ -256,6 +325,14 @@ func isConfigEnabled(cfg OutputConfig) bool {
 	return cfg.Enabled == nil || *cfg.Enabled
 }
 
+// skipsUnchanged reports whether a normalized output holds back frames
+// identical to the last one it took: on by default for AX206 panels, where
+// every frame is a full USB transfer, and opt-in for push outputs whose
+// receivers may expect a steady stream.
+func skipsUnchanged(cfg OutputConfig) bool {
+	return cfg.SkipUnchanged != nil && *cfg.SkipUnchanged
+}
+
 func normalizeSingleConfig(raw OutputConfig) (OutputConfig, bool) {
 	cfg := OutputConfig{
 		Type:    normalizeOutputTypeName(raw.Type),
@@ -266,6 +343,16 @@ func normalizeSingleConfig(raw OutputConfig) (OutputConfig, bool) {
 		return cfg, true
 	case TypeAX206USB:
 		cfg.ReconnectMS = normalizeAX206ReconnectMS(raw.ReconnectMS)
+		cfg.WatchdogMS = normalizeAX206WatchdogMS(raw.WatchdogMS)
+		cfg.LatencyBudgetMS = normalizeAX206LatencyBudgetMS(raw.LatencyBudgetMS)
+		cfg.TimeoutMS = normalizeAX206TransferTimeoutMS(raw.TimeoutMS)
+		cfg.Retries = normalizeAX206Retries(raw.Retries)
+		cfg.DeviceProfile = normalizeAX206DeviceProfileName(raw.DeviceProfile)
+		cfg.USBIDs = normalizeAX206USBIDs(raw.USBIDs)
+		cfg.USBPath = strings.TrimSpace(raw.USBPath)
+		cfg.USBSerial = strings.TrimSpace(raw.USBSerial)
+		cfg.Fit = normalizeAX206FitMode(raw.Fit)
+		cfg.SkipUnchanged = cloneEnabledValue(raw.SkipUnchanged == nil || *raw.SkipUnchanged)
 		return cfg, true
 	case TypeHTTPPush:
 		cfg.URL = strings.TrimSpace(raw.URL)
@@ -284,6 +371,9 @@ func normalizeSingleConfig(raw OutputConfig) (OutputConfig, bool) {
 		cfg.FileName = normalizeHTTPPushFileName(raw.FileName)
 		cfg.FormFields = normalizeHTTPPushKeyValues(raw.FormFields)
 		cfg.SuccessCodes = normalizeHTTPPushSuccessCodes(raw.SuccessCodes)
+		cfg.MinIntervalMS = normalizeHTTPPushMinIntervalMS(raw.MinIntervalMS)
+		cfg.SkipUnchanged = cloneEnabledValue(raw.SkipUnchanged != nil && *raw.SkipUnchanged)
+		applyEInkConfig(&cfg, raw)
 		return cfg, true
 	case TypeTCPPush:
 		cfg.URL = strings.TrimSpace(raw.URL)
@@ -295,6 +385,34 @@ func normalizeSingleConfig(raw OutputConfig) (OutputConfig, bool) {
 		cfg.BusyCheckMS = normalizeTCPPushBusyCheckMS(raw.BusyCheckMS)
 		cfg.FileName = normalizeHTTPPushFileName(raw.FileName)
 		cfg.SuccessCodes = normalizeHTTPPushSuccessCodes(raw.SuccessCodes)
+		cfg.SkipUnchanged = cloneEnabledValue(raw.SkipUnchanged != nil && *raw.SkipUnchanged)
+		applyEInkConfig(&cfg, raw)
+		return cfg, true
+	case TypeFile:
+		cfg.Path = strings.TrimSpace(raw.Path)
+		cfg.Format = normalizeFileOutputFormat(raw.Format, cfg.Path)
+		cfg.Quality = normalizeHTTPPushQuality(raw.Quality)
+		cfg.FileMode = strings.TrimSpace(raw.FileMode)
+		cfg.Atomic = cloneEnabledValue(raw.Atomic == nil || *raw.Atomic)
+		cfg.TmpFS = raw.TmpFS
+		cfg.SkipUnchanged = cloneEnabledValue(raw.SkipUnchanged == nil || *raw.SkipUnchanged)
+		applyEInkConfig(&cfg, raw)
+		return cfg, true
+	case TypeHTTP:
+		cfg.Listen = normalizeHTTPServeListen(raw.Listen)
+		cfg.Quality = normalizeHTTPPushQuality(raw.Quality)
+		return cfg, true
+	case TypeSSD1306, TypeST7789:
+		normalizeMiniDisplayConfig(&cfg, raw)
+		return cfg, true
+	case TypeTuring:
+		normalizeTuringConfig(&cfg, raw)
+		return cfg, true
+	case TypeFramebuffer:
+		normalizeFramebufferConfig(&cfg, raw)
+		return cfg, true
+	case TypeMQTT:
+		normalizeMQTTConfig(&cfg, raw)
 		return cfg, true
 	default:
 		return OutputConfig{}, false
@@ -310,10 +428,31 @@ func NormalizeConfigs(configs []OutputConfig) []OutputConfig {
 		if !ok {
 			continue
 		}
-		if _, exists := seenSingleton[cfg.Type]; exists {
+		// Several AX206 frames can be driven at once as long as each entry
+		// selects a different device, several files as long as each entry
+		// writes a different path, several HTTP servers on different
+		// addresses, several panels on different buses, serial ports or
+		// framebuffer devices, and several MQTT outputs on different
+		// brokers or topics.
+		key := cfg.Type
+		switch cfg.Type {
+		case TypeAX206USB:
+			key += "|" + cfg.USBPath + "|" + cfg.USBSerial
+		case TypeFile:
+			key += "|" + cfg.Path
+		case TypeHTTP:
+			key += "|" + cfg.Listen
+		case TypeSSD1306, TypeST7789:
+			key += "|" + cfg.Device + "|" + strconv.Itoa(cfg.I2CAddress)
+		case TypeTuring, TypeFramebuffer:
+			key += "|" + cfg.Device
+		case TypeMQTT:
+			key += "|" + cfg.URL + "|" + cfg.Topic + "|" + cfg.ValuesTopic
+		}
+		if _, exists := seenSingleton[key]; exists {
 			continue
 		}
-		seenSingleton[cfg.Type] = struct{}{}
+		seenSingleton[key] = struct{}{}
 		normalized = append(normalized, cfg)
 	}
 
@@ -455,6 +594,30 @@ func EqualConfigs(left, right []OutputConfig) bool {
 		if lCfg.IdleTimeoutSec != rCfg.IdleTimeoutSec {
 			return false
 		}
+		if lCfg.MinIntervalMS != rCfg.MinIntervalMS {
+			return false
+		}
+		if lCfg.Listen != rCfg.Listen {
+			return false
+		}
+		if lCfg.Device != rCfg.Device || lCfg.Width != rCfg.Width || lCfg.Height != rCfg.Height || lCfg.I2CAddress != rCfg.I2CAddress {
+			return false
+		}
+		if lCfg.OffsetX != rCfg.OffsetX || lCfg.OffsetY != rCfg.OffsetY || lCfg.SPIMode != rCfg.SPIMode || lCfg.SPISpeedHz != rCfg.SPISpeedHz {
+			return false
+		}
+		if lCfg.GPIOChip != rCfg.GPIOChip || !equalLine(lCfg.DCLine, rCfg.DCLine) || !equalLine(lCfg.ResetLine, rCfg.ResetLine) {
+			return false
+		}
+		if lCfg.Baud != rCfg.Baud || lCfg.Brightness != rCfg.Brightness || lCfg.Orientation != rCfg.Orientation {
+			return false
+		}
+		if lCfg.Topic != rCfg.Topic || lCfg.ValuesTopic != rCfg.ValuesTopic || lCfg.QoS != rCfg.QoS || lCfg.Retain != rCfg.Retain || lCfg.ClientID != rCfg.ClientID {
+			return false
+		}
+		if lCfg.EInk != rCfg.EInk || lCfg.Invert != rCfg.Invert || lCfg.FullRefreshEvery != rCfg.FullRefreshEvery {
+			return false
+		}
 		if lCfg.FileField != rCfg.FileField {
 			return false
 		}
@@ -470,6 +633,30 @@ func EqualConfigs(left, right []OutputConfig) bool {
 		if lCfg.ReconnectMS != rCfg.ReconnectMS {
 			return false
 		}
+		if lCfg.WatchdogMS != rCfg.WatchdogMS || lCfg.LatencyBudgetMS != rCfg.LatencyBudgetMS {
+			return false
+		}
+		if lCfg.Retries != rCfg.Retries {
+			return false
+		}
+		if lCfg.DeviceProfile != rCfg.DeviceProfile || !equalStringSlice(lCfg.USBIDs, rCfg.USBIDs) {
+			return false
+		}
+		if lCfg.USBPath != rCfg.USBPath || lCfg.USBSerial != rCfg.USBSerial {
+			return false
+		}
+		if lCfg.Fit != rCfg.Fit {
+			return false
+		}
+		if skipsUnchanged(lCfg) != skipsUnchanged(rCfg) {
+			return false
+		}
+		if lCfg.Path != rCfg.Path || lCfg.FileMode != rCfg.FileMode || lCfg.TmpFS != rCfg.TmpFS {
+			return false
+		}
+		if (lCfg.Atomic == nil || *lCfg.Atomic) != (rCfg.Atomic == nil || *rCfg.Atomic) {
+			return false
+		}
 	}
 	return true
 }
@@ -501,6 +688,18 @@ func equalIntSlice(left, right []int) bool {
 	return true
 }
 
+func equalStringSlice(left, right []string) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for idx := range left {
+		if left[idx] != right[idx] {
+			return false
+		}
+	}
+	return true
+}
+
 func NormalizeTypes(types []string) []string {
 	return TypeNames(NormalizeConfigs(ConfigsFromTypes(types)))
 }
@@ -521,19 +720,31 @@ func BuildManager(configs []OutputConfig, forceMemImg bool) (*OutputManager, []O
 	summary := ResolveConfigSummary(configs, forceMemImg)
 	manager := NewOutputManager()
 
+	ax206Index := 0
 	httpPushIndex := 0
 	tcpPushIndex := 0
+	fileIndex := 0
+	httpIndex := 0
+	panelIndex := map[string]int{}
+	turingIndex := 0
+	framebufferIndex := 0
+	mqttIndex := 0
 	for _, cfg := range summary.Configs {
 		switch cfg.Type {
 		case TypeMemImg:
 			manager.AddHandler(NewMemImgOutputHandler())
 		case TypeAX206USB:
-			handler, err := NewAX206USBOutputHandler(cfg)
+			ax206Index++
+			typeName := TypeAX206USB
+			if ax206Index > 1 {
+				typeName = fmt.Sprintf("%s_%d", TypeAX206USB, ax206Index)
+			}
+			handler, err := NewAX206USBOutputHandler(cfg, typeName)
 			if err != nil {
 				logErrorModule("ax206usb", "Handler creation failed: %v", err)
 				continue
 			}
-			manager.AddHandler(handler)
+			manager.addHandler(handler, skipsUnchanged(cfg))
 		case TypeHTTPPush:
 			httpPushIndex++
 			typeName := TypeHTTPPush
@@ -541,7 +752,7 @@ func BuildManager(configs []OutputConfig, forceMemImg bool) (*OutputManager, []O
 				typeName = fmt.Sprintf("%s_%d", TypeHTTPPush, httpPushIndex)
 			}
 			handler := NewHTTPPushOutputHandler(cfg, typeName)
-			manager.AddHandler(handler)
+			manager.addHandler(newEInkOutputHandler(handler, cfg), skipsUnchanged(cfg))
 		case TypeTCPPush:
 			tcpPushIndex++
 			typeName := TypeTCPPush
@@ -549,7 +760,54 @@ func BuildManager(configs []OutputConfig, forceMemImg bool) (*OutputManager, []O
 				typeName = fmt.Sprintf("%s_%d", TypeTCPPush, tcpPushIndex)
 			}
 			handler := NewTCPPushOutputHandler(cfg, typeName)
-			manager.AddHandler(handler)
+			manager.addHandler(newEInkOutputHandler(handler, cfg), skipsUnchanged(cfg))
+		case TypeFile:
+			fileIndex++
+			typeName := TypeFile
+			if fileIndex > 1 {
+				typeName = fmt.Sprintf("%s_%d", TypeFile, fileIndex)
+			}
+			handler, err := NewFileOutputHandler(cfg, typeName)
+			if err != nil {
+				logErrorModule("file", "Handler creation failed: %v", err)
+				continue
+			}
+			manager.addHandler(newEInkOutputHandler(handler, cfg), skipsUnchanged(cfg))
+		case TypeHTTP:
+			httpIndex++
+			typeName := TypeHTTP
+			if httpIndex > 1 {
+				typeName = fmt.Sprintf("%s_%d", TypeHTTP, httpIndex)
+			}
+			manager.AddHandler(NewHTTPServeOutputHandler(cfg, typeName))
+		case TypeSSD1306, TypeST7789:
+			panelIndex[cfg.Type]++
+			typeName := cfg.Type
+			if panelIndex[cfg.Type] > 1 {
+				typeName = fmt.Sprintf("%s_%d", cfg.Type, panelIndex[cfg.Type])
+			}
+			manager.addHandler(NewMiniDisplayOutputHandler(cfg, typeName), skipsUnchanged(cfg))
+		case TypeTuring:
+			turingIndex++
+			typeName := TypeTuring
+			if turingIndex > 1 {
+				typeName = fmt.Sprintf("%s_%d", TypeTuring, turingIndex)
+			}
+			manager.addHandler(NewTuringOutputHandler(cfg, typeName), skipsUnchanged(cfg))
+		case TypeFramebuffer:
+			framebufferIndex++
+			typeName := TypeFramebuffer
+			if framebufferIndex > 1 {
+				typeName = fmt.Sprintf("%s_%d", TypeFramebuffer, framebufferIndex)
+			}
+			manager.addHandler(NewFramebufferOutputHandler(cfg, typeName), skipsUnchanged(cfg))
+		case TypeMQTT:
+			mqttIndex++
+			typeName := TypeMQTT
+			if mqttIndex > 1 {
+				typeName = fmt.Sprintf("%s_%d", TypeMQTT, mqttIndex)
+			}
+			manager.addHandler(NewMQTTOutputHandler(cfg, typeName), skipsUnchanged(cfg))
 		}
 	}
 
@@ -188,3 +188,25 @@ func TestBuildManagerKeepsTCPPushWithoutToken(t *testing.T) {
 		t.Fatalf("expected 1 handler, got %d", len(manager.handlers))
 	}
 }
+
+func TestBuildManagerKeepsAX206OutputsWithDistinctSelectors(t *testing.T) {
+	manager, configs := BuildManager([]OutputConfig{
+		{Type: TypeAX206USB, USBPath: "1-1.4"},
+		{Type: TypeAX206USB, USBSerial: " ABC123 "},
+		{Type: TypeAX206USB, USBPath: "1-1.4"},
+	}, false)
+	if manager == nil {
+		t.Fatal("expected manager")
+	}
+	defer manager.Close()
+
+	if len(configs) != 2 || configs[1].USBSerial != "ABC123" {
+		t.Fatalf("expected duplicate selector to be dropped, got %#v", configs)
+	}
+	if len(manager.handlers) != 2 {
+		t.Fatalf("expected 2 handlers, got %d", len(manager.handlers))
+	}
+	if got := manager.handlers[1].GetType(); got != "ax206usb_2" {
+		t.Fatalf("unexpected second handler type: %q", got)
+	}
+}
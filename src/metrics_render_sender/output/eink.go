@@ -0,0 +1,235 @@
+package output
+
+import (
+	"image"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// E-ink color modes: "mono" dithers frames to black and white, "gray4"
+	// to four gray levels.
+	EInkMono  = "mono"
+	EInkGray4 = "gray4"
+
+	defaultEInkIntervalMS     = 60000
+	defaultEInkFullRefreshDue = 10
+)
+
+func normalizeEInkMode(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "mono", "1bit", "bw":
+		return EInkMono
+	case "gray4", "grey4", "4gray":
+		return EInkGray4
+	default:
+		return ""
+	}
+}
+
+// normalizeEInkMinIntervalMS gives e-ink outputs a refresh of at most once a
+// minute unless configured; a panel refresh takes seconds and flashes.
+func normalizeEInkMinIntervalMS(intervalMS int) int {
+	if intervalMS <= 0 {
+		return defaultEInkIntervalMS
+	}
+	return normalizeHTTPPushMinIntervalMS(intervalMS)
+}
+
+func normalizeEInkFullRefreshEvery(count int) int {
+	if count <= 0 {
+		return defaultEInkFullRefreshDue
+	}
+	if count > 1000 {
+		return 1000
+	}
+	return count
+}
+
+// applyEInkConfig copies the e-ink options of raw into cfg, which already
+// holds the output's own normalized options.
+func applyEInkConfig(cfg *OutputConfig, raw OutputConfig) {
+	cfg.EInk = normalizeEInkMode(raw.EInk)
+	if cfg.EInk == "" {
+		return
+	}
+	cfg.Invert = raw.Invert
+	cfg.MinIntervalMS = normalizeEInkMinIntervalMS(raw.MinIntervalMS)
+	cfg.FullRefreshEvery = normalizeEInkFullRefreshEvery(raw.FullRefreshEvery)
+}
+
+// einkOutputHandler converts frames for an e-ink panel before passing them
+// to the handler that delivers them. It sends at most one frame per
+// min_interval_ms and only when the converted image changed, marks the
+// changed region for partial refresh, and asks for a full refresh every
+// full_refresh_every frames to clear ghosting.
+type einkOutputHandler struct {
+	inner            OutputHandler
+	levels           int
+	invert           bool
+	interval         time.Duration
+	fullRefreshEvery int
+	// resetPending asks the next frame to drop prev, which only the output
+	// goroutine touches.
+	resetPending atomic.Bool
+
+	prev     *image.Gray
+	sentAt   time.Time
+	partials int
+}
+
+func newEInkOutputHandler(inner OutputHandler, cfg OutputConfig) OutputHandler {
+	if cfg.EInk == "" {
+		return inner
+	}
+	levels := 2
+	if cfg.EInk == EInkGray4 {
+		levels = 4
+	}
+	return &einkOutputHandler{
+		inner:            inner,
+		levels:           levels,
+		invert:           cfg.Invert,
+		interval:         time.Duration(cfg.MinIntervalMS) * time.Millisecond,
+		fullRefreshEvery: cfg.FullRefreshEvery,
+	}
+}
+
+func (h *einkOutputHandler) GetType() string {
+	return h.inner.GetType()
+}
+
+func (h *einkOutputHandler) OutputFrame(frame *OutputFrame) error {
+	if frame == nil || frame.Image == nil {
+		return nil
+	}
+	if h.resetPending.Swap(false) {
+		h.prev = nil
+	}
+	now := time.Now()
+	if h.prev != nil && now.Sub(h.sentAt) < h.interval {
+		recordOutputUnchanged(h.GetType())
+		return nil
+	}
+	img := ditherGray(frame.Image, h.levels, h.invert)
+	region := einkChangedRegion(h.prev, img)
+	if region.Empty() {
+		recordOutputUnchanged(h.GetType())
+		return nil
+	}
+
+	converted := NewOutputFrame(img)
+	converted.Region = region
+	converted.FullRefresh = h.prev == nil || h.partials+1 >= h.fullRefreshEvery
+	if converted.FullRefresh {
+		converted.Region = img.Rect
+	}
+	if err := h.inner.OutputFrame(converted); err != nil {
+		return err
+	}
+	if converted.FullRefresh {
+		h.partials = 0
+	} else {
+		h.partials++
+	}
+	h.prev, h.sentAt = img, now
+	return nil
+}
+
+// Reset forces a full refresh on the next frame, since the panel may have
+// lost its contents.
+func (h *einkOutputHandler) Reset() {
+	if resetter, ok := h.inner.(outputResetter); ok {
+		resetter.Reset()
+	}
+	h.resetPending.Store(true)
+}
+
+func (h *einkOutputHandler) Close() error {
+	return h.inner.Close()
+}
+
+// ditherGray converts src to levels evenly spaced grays with Floyd-Steinberg
+// error diffusion. A new image is made each time, as handlers such as
+// httppush may still be encoding the previous one.
+func ditherGray(src image.Image, levels int, invert bool) *image.Gray {
+	bounds := src.Bounds()
+	dst := image.NewGray(bounds)
+	width := bounds.Dx()
+	step := 255 / (levels - 1)
+	// Two rows of accumulated error in 1/16 units, with a spare column on
+	// each side.
+	cur := make([]int, width+2)
+	next := make([]int, width+2)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for idx := range next {
+			next[idx] = 0
+		}
+		row := dst.Pix[(y-bounds.Min.Y)*dst.Stride:]
+		for x := 0; x < width; x++ {
+			r, g, b, _ := src.At(bounds.Min.X+x, y).RGBA()
+			luma := int((19595*r + 38470*g + 7471*b + 1<<15) >> 24)
+			if invert {
+				luma = 255 - luma
+			}
+			value := luma + cur[x+1]/16
+			level := (value + step/2) / step
+			if level < 0 {
+				level = 0
+			}
+			if level > levels-1 {
+				level = levels - 1
+			}
+			out := level * step
+			row[x] = uint8(out)
+			diff := value - out
+			cur[x+2] += diff * 7
+			next[x] += diff * 3
+			next[x+1] += diff * 5
+			next[x+2] += diff
+		}
+		cur, next = next, cur
+	}
+	return dst
+}
+
+// einkChangedRegion returns the bounding box of the pixels that differ
+// between prev and cur, widened to whole bytes horizontally as e-ink
+// controllers address 8 pixels per byte in partial updates. Without a
+// comparable prev the whole frame is changed.
+func einkChangedRegion(prev, cur *image.Gray) image.Rectangle {
+	if prev == nil || prev.Rect != cur.Rect {
+		return cur.Rect
+	}
+	width := cur.Rect.Dx()
+	minX, minY, maxX, maxY := width, -1, -1, -1
+	for y := 0; y < cur.Rect.Dy(); y++ {
+		prevRow := prev.Pix[y*prev.Stride : y*prev.Stride+width]
+		curRow := cur.Pix[y*cur.Stride : y*cur.Stride+width]
+		for x := 0; x < width; x++ {
+			if prevRow[x] == curRow[x] {
+				continue
+			}
+			if minY < 0 {
+				minY = y
+			}
+			maxY = y
+			if x < minX {
+				minX = x
+			}
+			if x > maxX {
+				maxX = x
+			}
+		}
+	}
+	if maxY < 0 {
+		return image.Rectangle{}
+	}
+	minX &^= 7
+	maxX = (maxX + 8) &^ 7
+	if maxX > width {
+		maxX = width
+	}
+	return image.Rect(minX, minY, maxX, maxY+1).Add(cur.Rect.Min)
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+type recordingOutputHandler struct {
+	frames []*OutputFrame
+}
+
+func (h *recordingOutputHandler) OutputFrame(frame *OutputFrame) error {
+	h.frames = append(h.frames, frame)
+	return nil
+}
+
+func (h *recordingOutputHandler) Close() error { return nil }
+
+func (h *recordingOutputHandler) GetType() string { return "recording" }
+
+func TestDitherGrayMonoUsesTwoLevels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for idx := 0; idx < len(src.Pix); idx += 4 {
+		src.Pix[idx], src.Pix[idx+1], src.Pix[idx+2], src.Pix[idx+3] = 128, 128, 128, 255
+	}
+	img := ditherGray(src, 2, false)
+	black := 0
+	for _, value := range img.Pix {
+		switch value {
+		case 0:
+			black++
+		case 255:
+		default:
+			t.Fatalf("unexpected gray level %d in mono output", value)
+		}
+	}
+	// Mid gray dithers to about half black pixels.
+	if black < 96 || black > 160 {
+		t.Fatalf("expected about half of 256 pixels black, got %d", black)
+	}
+
+	white := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for idx := range white.Pix {
+		white.Pix[idx] = 255
+	}
+	for _, value := range ditherGray(white, 4, true).Pix {
+		if value != 0 {
+			t.Fatalf("expected inverted white to be black, got %d", value)
+		}
+	}
+}
+
+func TestEInkOutputMarksChangedRegion(t *testing.T) {
+	inner := &recordingOutputHandler{}
+	handler := newEInkOutputHandler(inner, OutputConfig{EInk: EInkMono, FullRefreshEvery: 3})
+	frame := func(x, y int) *OutputFrame {
+		img := image.NewRGBA(image.Rect(0, 0, 32, 16))
+		for idx := range img.Pix {
+			img.Pix[idx] = 255
+		}
+		if x >= 0 {
+			img.Set(x, y, color.Black)
+		}
+		return NewOutputFrame(img)
+	}
+
+	_ = handler.OutputFrame(frame(-1, 0))
+	_ = handler.OutputFrame(frame(-1, 0))
+	_ = handler.OutputFrame(frame(10, 5))
+	_ = handler.OutputFrame(frame(20, 7))
+	_ = handler.OutputFrame(frame(30, 9))
+	if len(inner.frames) != 4 {
+		t.Fatalf("expected the unchanged frame to be held, got %d frames", len(inner.frames))
+	}
+	if first := inner.frames[0]; !first.FullRefresh || first.Region != image.Rect(0, 0, 32, 16) {
+		t.Fatalf("expected a full first refresh, got %v full=%v", first.Region, first.FullRefresh)
+	}
+	if second := inner.frames[1]; second.FullRefresh || second.Region != image.Rect(8, 5, 16, 6) {
+		t.Fatalf("expected a byte-aligned partial region, got %v full=%v", second.Region, second.FullRefresh)
+	}
+	if inner.frames[2].FullRefresh || !inner.frames[3].FullRefresh {
+		t.Fatalf("expected one full refresh in every three frames")
+	}
+	if gray, ok := inner.frames[1].Image.(*image.Gray); !ok || gray.GrayAt(10, 5).Y != 0 {
+		t.Fatalf("expected a converted gray frame with the black pixel kept")
+	}
+
+	handler.(outputResetter).Reset()
+	_ = handler.OutputFrame(frame(30, 9))
+	if len(inner.frames) != 5 || !inner.frames[4].FullRefresh {
+		t.Fatalf("expected an unchanged frame to be sent in full after Reset")
+	}
+}
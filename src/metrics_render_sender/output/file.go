@@ -0,0 +1,150 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const defaultFileOutputMode os.FileMode = 0o644
+
+// FileOutputHandler writes each frame to an image file for readers such as
+// conky or a web server. With atomic writes, the default, a frame goes to a
+// temporary file next to the target that is renamed over it, so a reader
+// never opens a half-written image.
+type FileOutputHandler struct {
+	typeName string
+	path     string
+	format   string
+	quality  int
+	mode     os.FileMode
+	atomic   bool
+}
+
+func NewFileOutputHandler(cfg OutputConfig, typeName string) (*FileOutputHandler, error) {
+	path := resolveFileOutputPath(cfg.Path, cfg.TmpFS)
+	if path == "" {
+		return nil, errors.New("file output needs a path")
+	}
+	mode, err := parseFileOutputMode(cfg.FileMode)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.TmpFS && !isMemoryBackedDir(filepath.Dir(path)) {
+		logInfoModule("file", "%s writes to disk; set tmpfs or a path under /dev/shm to keep frames in memory", path)
+	}
+	return &FileOutputHandler{
+		typeName: typeName,
+		path:     path,
+		format:   normalizeFileOutputFormat(cfg.Format, path),
+		quality:  normalizeHTTPPushQuality(cfg.Quality),
+		mode:     mode,
+		atomic:   cfg.Atomic == nil || *cfg.Atomic,
+	}, nil
+}
+
+func (h *FileOutputHandler) GetType() string {
+	return h.typeName
+}
+
+func (h *FileOutputHandler) OutputFrame(frame *OutputFrame) error {
+	if frame == nil {
+		return nil
+	}
+	var data []byte
+	var err error
+	if h.format == "jpeg" {
+		data, err = frame.JPEG(h.quality)
+	} else {
+		data, err = frame.PNG()
+	}
+	if err == nil {
+		err = h.write(data)
+	}
+	recordOutputHealth(h.typeName, err)
+	return err
+}
+
+func (h *FileOutputHandler) write(data []byte) error {
+	if !h.atomic {
+		if err := os.WriteFile(h.path, data, h.mode); err != nil {
+			return err
+		}
+		// WriteFile only applies the mode, less the umask, to a new file.
+		return os.Chmod(h.path, h.mode)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(h.path), "."+filepath.Base(h.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(h.mode)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, h.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+func (h *FileOutputHandler) Close() error {
+	return nil
+}
+
+// resolveFileOutputPath places a relative path with tmpfs set in a RAM
+// backed directory: $XDG_RUNTIME_DIR, else /dev/shm on Linux, else the
+// system temp directory.
+func resolveFileOutputPath(path string, tmpfs bool) string {
+	path = strings.TrimSpace(path)
+	if path == "" || !tmpfs || filepath.IsAbs(path) {
+		return path
+	}
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" && runtime.GOOS == "linux" {
+		dir = "/dev/shm"
+	}
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, path)
+}
+
+func normalizeFileOutputFormat(format, path string) string {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "png":
+		return "png"
+	case "jpg", "jpeg":
+		return "jpeg"
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		return "jpeg"
+	default:
+		return "png"
+	}
+}
+
+// parseFileOutputMode reads an octal permission string such as "0640".
+func parseFileOutputMode(value string) (os.FileMode, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultFileOutputMode, nil
+	}
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid file_mode %q, expected octal permissions such as 0644", value)
+	}
+	return os.FileMode(mode), nil
+}
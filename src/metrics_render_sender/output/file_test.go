@@ -0,0 +1,72 @@
+package output
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileOutputWritesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "monitor.png")
+	handler, err := NewFileOutputHandler(OutputConfig{Type: TypeFile, Path: path, FileMode: "0640"}, TypeFile)
+	if err != nil {
+		t.Fatalf("NewFileOutputHandler: %v", err)
+	}
+	for idx := 0; idx < 2; idx++ {
+		if err := handler.OutputFrame(NewOutputFrame(image.NewRGBA(image.Rect(0, 0, 4+idx, 4)))); err != nil {
+			t.Fatalf("OutputFrame: %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open output: %v", err)
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if img.Bounds().Dx() != 5 {
+		t.Fatalf("expected the second frame, got width %d", img.Bounds().Dx())
+	}
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatalf("stat output: %v", err)
+	}
+	if info.Mode().Perm() != 0o640 {
+		t.Fatalf("expected mode 0640, got %v", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected no temporary files left, got %d entries", len(entries))
+	}
+}
+
+func TestNormalizeFileOutputConfig(t *testing.T) {
+	cfg, ok := normalizeSingleConfig(OutputConfig{Type: "FILE", Path: " /tmp/monitor.jpg "})
+	if !ok {
+		t.Fatalf("expected file output to be supported")
+	}
+	if cfg.Path != "/tmp/monitor.jpg" || cfg.Format != "jpeg" {
+		t.Fatalf("unexpected path or format: %q %q", cfg.Path, cfg.Format)
+	}
+	if cfg.Atomic == nil || !*cfg.Atomic || !skipsUnchanged(cfg) {
+		t.Fatalf("expected atomic writes and unchanged-frame skipping by default")
+	}
+	if _, err := parseFileOutputMode("0999"); err == nil {
+		t.Fatalf("expected an invalid file_mode to be rejected")
+	}
+
+	configs := NormalizeConfigs([]OutputConfig{
+		{Type: TypeFile, Path: "/tmp/a.png"},
+		{Type: TypeFile, Path: "/tmp/b.png"},
+		{Type: TypeFile, Path: "/tmp/a.png"},
+	})
+	if len(configs) != 2 {
+		t.Fatalf("expected one output per path, got %d", len(configs))
+	}
+}
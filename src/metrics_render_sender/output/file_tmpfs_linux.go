@@ -0,0 +1,18 @@
+//go:build linux
+
+package output
+
+import "syscall"
+
+const (
+	tmpfsMagic = 0x01021994
+	ramfsMagic = 0x858458f6
+)
+
+func isMemoryBackedDir(dir string) bool {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return false
+	}
+	return stat.Type == tmpfsMagic || stat.Type == ramfsMagic
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package output
+
+// isMemoryBackedDir reports true where the filesystem type cannot be read,
+// so the tmpfs hint is only given on Linux.
+func isMemoryBackedDir(dir string) bool {
+	return true
+}
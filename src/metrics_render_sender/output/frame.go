@@ -2,7 +2,9 @@ package output
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"hash/maphash"
 	"image"
 	"image/jpeg"
 	"image/png"
@@ -11,6 +13,11 @@ import (
 
 type OutputFrame struct {
 	Image image.Image
+	// Region is the part of Image that changed since the previous frame
+	// sent to the same e-ink output, and FullRefresh asks the panel to
+	// redraw all of it to clear ghosting. Both are unset for other outputs.
+	Region      image.Rectangle
+	FullRefresh bool
 
 	mu          sync.Mutex
 	pngData     []byte
@@ -19,6 +26,9 @@ type OutputFrame struct {
 	rgb565Ready bool
 	jpegByQ     map[int][]byte
 	jpegErrors  map[int]error
+	hash        uint64
+	hashOK      bool
+	hashReady   bool
 }
 
 func NewOutputFrame(img image.Image) *OutputFrame {
@@ -32,6 +42,46 @@ func NewOutputFrame(img image.Image) *OutputFrame {
 	}
 }
 
+var frameHashSeed = maphash.MakeSeed()
+
+// Hash fingerprints the frame's size and pixels, so handlers can tell a
+// frame identical to the last one without keeping a copy. ok is false for
+// image types it cannot read directly.
+func (f *OutputFrame) Hash() (uint64, bool) {
+	if f == nil || f.Image == nil {
+		return 0, false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.hashReady {
+		return f.hash, f.hashOK
+	}
+	f.hashReady = true
+
+	var pix []byte
+	var bounds image.Rectangle
+	switch img := f.Image.(type) {
+	case *image.RGBA:
+		pix, bounds = img.Pix, img.Rect
+	case *image.NRGBA:
+		pix, bounds = img.Pix, img.Rect
+	default:
+		return 0, false
+	}
+	var size [16]byte
+	binary.LittleEndian.PutUint32(size[0:], uint32(bounds.Min.X))
+	binary.LittleEndian.PutUint32(size[4:], uint32(bounds.Min.Y))
+	binary.LittleEndian.PutUint32(size[8:], uint32(bounds.Max.X))
+	binary.LittleEndian.PutUint32(size[12:], uint32(bounds.Max.Y))
+	var hash maphash.Hash
+	hash.SetSeed(frameHashSeed)
+	_, _ = hash.Write(size[:])
+	_, _ = hash.Write(pix)
+	f.hash, f.hashOK = hash.Sum64(), true
+	return f.hash, true
+}
+
 func (f *OutputFrame) PNG() ([]byte, error) {
 	if f == nil || f.Image == nil {
 		return nil, nil
@@ -0,0 +1,195 @@
+package output
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"strings"
+)
+
+const defaultFramebufferDevice = "/dev/fb0"
+
+// framebufferBitfield is where one color channel sits in a pixel.
+type framebufferBitfield struct {
+	offset uint32
+	length uint32
+}
+
+// framebufferFormat is the visible screen of a framebuffer device as the
+// kernel reports it.
+type framebufferFormat struct {
+	width        int
+	height       int
+	lineLength   int
+	bitsPerPixel int
+	// origin is the byte offset of the visible screen, which is not 0
+	// while a double-buffering console has panned.
+	origin int64
+	red    framebufferBitfield
+	green  framebufferBitfield
+	blue   framebufferBitfield
+	transp framebufferBitfield
+}
+
+func (f framebufferFormat) String() string {
+	return fmt.Sprintf("%dx%d %dbpp r%d/%d g%d/%d b%d/%d", f.width, f.height, f.bitsPerPixel,
+		f.red.offset, f.red.length, f.green.offset, f.green.length, f.blue.offset, f.blue.length)
+}
+
+func (f framebufferFormat) validate() error {
+	switch f.bitsPerPixel {
+	case 16, 24, 32:
+	default:
+		return fmt.Errorf("unsupported framebuffer depth %d bpp", f.bitsPerPixel)
+	}
+	if f.width <= 0 || f.height <= 0 || f.lineLength < f.width*f.bitsPerPixel/8 {
+		return fmt.Errorf("invalid framebuffer geometry %dx%d, line length %d", f.width, f.height, f.lineLength)
+	}
+	return nil
+}
+
+type framebufferFile interface {
+	io.WriterAt
+	io.Closer
+}
+
+func normalizeFramebufferConfig(cfg *OutputConfig, raw OutputConfig) {
+	cfg.Device = strings.TrimSpace(raw.Device)
+	if cfg.Device == "" {
+		cfg.Device = defaultFramebufferDevice
+	}
+	cfg.Fit = AX206FitContain
+	if raw.Fit != "" {
+		cfg.Fit = normalizeAX206FitMode(raw.Fit)
+	}
+	cfg.SkipUnchanged = cloneEnabledValue(raw.SkipUnchanged == nil || *raw.SkipUnchanged)
+}
+
+// packFramebuffer converts img to the pixel layout of format, one
+// line_length row per screen line, leaving areas outside img black. The
+// channel bitfields cover RGB565, XRGB8888, BGR888 and the other packed
+// true-color layouts drivers report.
+func packFramebuffer(buf []byte, img image.Image, format framebufferFormat) []byte {
+	size := format.lineLength * format.height
+	if cap(buf) < size {
+		buf = make([]byte, size)
+	}
+	buf = buf[:size]
+	for idx := range buf {
+		buf[idx] = 0
+	}
+	bytesPerPixel := format.bitsPerPixel / 8
+	opaque := framebufferChannel(0xffff, format.transp)
+	bounds := img.Bounds()
+	for y := 0; y < format.height && y < bounds.Dy(); y++ {
+		row := buf[y*format.lineLength:]
+		for x := 0; x < format.width && x < bounds.Dx(); x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			value := framebufferChannel(r, format.red) |
+				framebufferChannel(g, format.green) |
+				framebufferChannel(b, format.blue) |
+				opaque
+			pixel := row[x*bytesPerPixel : (x+1)*bytesPerPixel]
+			for idx := range pixel {
+				pixel[idx] = byte(value >> (8 * idx))
+			}
+		}
+	}
+	return buf
+}
+
+// framebufferChannel scales a 16-bit color value to the bitfield width and
+// moves it into place.
+func framebufferChannel(value uint32, field framebufferBitfield) uint32 {
+	if field.length == 0 || field.length > 16 {
+		return 0
+	}
+	return (value >> (16 - field.length)) << field.offset
+}
+
+// FramebufferOutputHandler writes frames to a Linux framebuffer device such
+// as an HDMI console or an fbtft SPI panel, converted to its pixel format
+// and scaled to its resolution by the fit mode. The device and its
+// geometry are read with the first frame and again after a failed write.
+type FramebufferOutputHandler struct {
+	typeName string
+	cfg      OutputConfig
+	open     func(path string) (framebufferFile, framebufferFormat, error)
+	resetCh  chan struct{}
+
+	file   framebufferFile
+	format framebufferFormat
+	scaled *image.RGBA
+	buf    []byte
+}
+
+func NewFramebufferOutputHandler(cfg OutputConfig, typeName string) *FramebufferOutputHandler {
+	return &FramebufferOutputHandler{
+		typeName: typeName,
+		cfg:      cfg,
+		open:     openFramebuffer,
+		resetCh:  make(chan struct{}, 1),
+	}
+}
+
+func (h *FramebufferOutputHandler) GetType() string {
+	return h.typeName
+}
+
+func (h *FramebufferOutputHandler) OutputFrame(frame *OutputFrame) error {
+	if frame == nil || frame.Image == nil {
+		return nil
+	}
+	select {
+	case <-h.resetCh:
+		_ = h.Close()
+	default:
+	}
+	err := h.draw(frame.Image)
+	recordOutputHealth(h.typeName, err)
+	return err
+}
+
+func (h *FramebufferOutputHandler) draw(src image.Image) error {
+	if h.file == nil {
+		file, format, err := h.open(h.cfg.Device)
+		if err != nil {
+			return err
+		}
+		if err := format.validate(); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("%s: %w", h.cfg.Device, err)
+		}
+		logInfoModule(h.cfg.Type, "Opened %s %s", h.cfg.Device, format)
+		h.file = file
+		h.format = format
+	}
+	var img image.Image
+	img, h.scaled = fitAX206Image(h.scaled, src, h.format.width, h.format.height, h.cfg.Fit)
+	h.buf = packFramebuffer(h.buf, img, h.format)
+	if _, err := h.file.WriteAt(h.buf, h.format.origin); err != nil {
+		_ = h.file.Close()
+		h.file = nil
+		return err
+	}
+	return nil
+}
+
+// Reset reopens the device after a resume, which may have changed the
+// mode of a hotplugged HDMI screen. It runs on the power watcher, so the
+// device is closed by the next OutputFrame rather than under a draw.
+func (h *FramebufferOutputHandler) Reset() {
+	select {
+	case h.resetCh <- struct{}{}:
+	default:
+	}
+}
+
+func (h *FramebufferOutputHandler) Close() error {
+	if h.file == nil {
+		return nil
+	}
+	err := h.file.Close()
+	h.file = nil
+	return err
+}
@@ -0,0 +1,92 @@
+//go:build linux
+
+package output
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	fbGetVScreenInfoIOCTL = 0x4600
+	fbGetFScreenInfoIOCTL = 0x4602
+)
+
+type fbBitfield struct {
+	Offset   uint32
+	Length   uint32
+	MSBRight uint32
+}
+
+// fbVarScreenInfo mirrors struct fb_var_screeninfo.
+type fbVarScreenInfo struct {
+	XRes, YRes               uint32
+	XResVirtual, YResVirtual uint32
+	XOffset, YOffset         uint32
+	BitsPerPixel             uint32
+	Grayscale                uint32
+	Red, Green, Blue, Transp fbBitfield
+	NonStd                   uint32
+	Activate                 uint32
+	Height, Width            uint32
+	AccelFlags               uint32
+	Timing                   [11]uint32 // pixclock through colorspace
+	Reserved                 [4]uint32
+}
+
+// fbFixScreenInfo mirrors struct fb_fix_screeninfo; the unsigned long
+// fields are uintptr so the layout matches on 32- and 64-bit boards.
+type fbFixScreenInfo struct {
+	ID           [16]byte
+	SmemStart    uintptr
+	SmemLen      uint32
+	Type         uint32
+	TypeAux      uint32
+	Visual       uint32
+	XPanStep     uint16
+	YPanStep     uint16
+	YWrapStep    uint16
+	LineLength   uint32
+	MMIOStart    uintptr
+	MMIOLen      uint32
+	Accel        uint32
+	Capabilities uint16
+	Reserved     [2]uint16
+}
+
+// openFramebuffer opens a framebuffer device and reads the format of its
+// visible screen.
+func openFramebuffer(path string) (framebufferFile, framebufferFormat, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, framebufferFormat{}, err
+	}
+	var variable fbVarScreenInfo
+	var fixed fbFixScreenInfo
+	for _, query := range []struct {
+		request uintptr
+		value   unsafe.Pointer
+	}{
+		{fbGetVScreenInfoIOCTL, unsafe.Pointer(&variable)},
+		{fbGetFScreenInfoIOCTL, unsafe.Pointer(&fixed)},
+	} {
+		if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), query.request, uintptr(query.value)); errno != 0 {
+			file.Close()
+			return nil, framebufferFormat{}, fmt.Errorf("%s is not a framebuffer: %w", path, errno)
+		}
+	}
+	format := framebufferFormat{
+		width:        int(variable.XRes),
+		height:       int(variable.YRes),
+		lineLength:   int(fixed.LineLength),
+		bitsPerPixel: int(variable.BitsPerPixel),
+		origin:       int64(variable.YOffset)*int64(fixed.LineLength) + int64(variable.XOffset)*int64(variable.BitsPerPixel/8),
+		red:          framebufferBitfield{offset: variable.Red.Offset, length: variable.Red.Length},
+		green:        framebufferBitfield{offset: variable.Green.Offset, length: variable.Green.Length},
+		blue:         framebufferBitfield{offset: variable.Blue.Offset, length: variable.Blue.Length},
+		transp:       framebufferBitfield{offset: variable.Transp.Offset, length: variable.Transp.Length},
+	}
+	return file, format, nil
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package output
+
+import "errors"
+
+func openFramebuffer(path string) (framebufferFile, framebufferFormat, error) {
+	return nil, framebufferFormat{}, errors.New("framebuffer output is only supported on linux")
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+var (
+	framebufferRGB565 = framebufferFormat{
+		width: 2, height: 1, lineLength: 8, bitsPerPixel: 16,
+		red:   framebufferBitfield{offset: 11, length: 5},
+		green: framebufferBitfield{offset: 5, length: 6},
+		blue:  framebufferBitfield{offset: 0, length: 5},
+	}
+	framebufferXRGB8888 = framebufferFormat{
+		width: 2, height: 1, lineLength: 8, bitsPerPixel: 32,
+		red:   framebufferBitfield{offset: 16, length: 8},
+		green: framebufferBitfield{offset: 8, length: 8},
+		blue:  framebufferBitfield{offset: 0, length: 8},
+	}
+)
+
+type fakeFramebuffer struct {
+	data   []byte
+	offset int64
+	closed bool
+}
+
+func (f *fakeFramebuffer) WriteAt(p []byte, offset int64) (int, error) {
+	f.data = append([]byte(nil), p...)
+	f.offset = offset
+	return len(p), nil
+}
+
+func (f *fakeFramebuffer) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestPackFramebufferFormats(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(1, 0, color.RGBA{R: 255, G: 128, A: 255})
+
+	buf := packFramebuffer(nil, img, framebufferRGB565)
+	if len(buf) != 8 {
+		t.Fatalf("expected one line_length row, got %d bytes", len(buf))
+	}
+	// 0xfc00: red 11111, green 100000, blue 00000, little-endian.
+	if buf[2] != 0x00 || buf[3] != 0xfc {
+		t.Fatalf("unexpected rgb565 pixel %02x%02x", buf[3], buf[2])
+	}
+
+	buf = packFramebuffer(buf, img, framebufferXRGB8888)
+	if got := buf[4:8]; got[0] != 0x00 || got[1] != 0x80 || got[2] != 0xff || got[3] != 0x00 {
+		t.Fatalf("unexpected xrgb8888 pixel % x", got)
+	}
+}
+
+func TestFramebufferHandlerScalesToDevice(t *testing.T) {
+	cfg, ok := normalizeSingleConfig(OutputConfig{Type: TypeFramebuffer, Fit: AX206FitStretch})
+	if !ok || cfg.Device != defaultFramebufferDevice {
+		t.Fatalf("unexpected normalized config %+v", cfg)
+	}
+	device := &fakeFramebuffer{}
+	format := framebufferXRGB8888
+	format.origin = 64
+	handler := NewFramebufferOutputHandler(cfg, TypeFramebuffer)
+	handler.open = func(path string) (framebufferFile, framebufferFormat, error) {
+		return device, format, nil
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+	if err := handler.OutputFrame(&OutputFrame{Image: img}); err != nil {
+		t.Fatalf("output frame: %v", err)
+	}
+	if device.offset != 64 || len(device.data) != 8 || device.data[0] != 0xff || device.data[4] != 0xff {
+		t.Fatalf("expected a blue 2x1 frame at the screen origin, got % x at %d", device.data, device.offset)
+	}
+	if err := handler.Close(); err != nil || !device.closed {
+		t.Fatalf("expected the device to be closed, err %v", err)
+	}
+}
+
+func TestFramebufferResetReopensOnNextFrame(t *testing.T) {
+	cfg, _ := normalizeSingleConfig(OutputConfig{Type: TypeFramebuffer})
+	opens := 0
+	devices := []*fakeFramebuffer{}
+	handler := NewFramebufferOutputHandler(cfg, TypeFramebuffer)
+	handler.open = func(path string) (framebufferFile, framebufferFormat, error) {
+		opens++
+		device := &fakeFramebuffer{}
+		devices = append(devices, device)
+		return device, framebufferXRGB8888, nil
+	}
+
+	frame := &OutputFrame{Image: image.NewRGBA(image.Rect(0, 0, 2, 1))}
+	_ = handler.OutputFrame(frame)
+	handler.Reset()
+	handler.Reset()
+	if devices[0].closed {
+		t.Fatal("expected Reset to leave the device to the output goroutine")
+	}
+	_ = handler.OutputFrame(frame)
+	if opens != 2 || !devices[0].closed {
+		t.Fatalf("expected one reopen after Reset, got %d opens", opens)
+	}
+}
+
+func TestFramebufferRejectsPalettedModes(t *testing.T) {
+	format := framebufferRGB565
+	format.bitsPerPixel = 8
+	if err := format.validate(); err == nil {
+		t.Fatal("expected 8 bpp to be rejected")
+	}
+}
@@ -0,0 +1,101 @@
+package output
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutputHealth is the delivery state of one output handler. Times are
+// RFC3339 and empty until the first success or error.
+type OutputHealth struct {
+	Type          string `json:"type"`
+	Healthy       bool   `json:"healthy"`
+	Disabled      bool   `json:"disabled,omitempty"`
+	LastSuccessAt string `json:"last_success_at,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	LastErrorAt   string `json:"last_error_at,omitempty"`
+}
+
+type outputHealthEntry struct {
+	lastSuccessAt time.Time
+	lastErrorAt   time.Time
+	lastError     string
+	disabled      bool
+}
+
+var (
+	outputHealthMu     sync.RWMutex
+	outputHealthByType = make(map[string]*outputHealthEntry)
+)
+
+// recordOutputHealth records the result of an actual delivery to the device
+// or endpoint, not of queueing a frame for it.
+func recordOutputHealth(typeName string, err error) {
+	recordOutputHealthAt(typeName, err, time.Now())
+}
+
+func recordOutputHealthAt(typeName string, err error, now time.Time) {
+	typeName = normalizeTypeName(typeName)
+	outputHealthMu.Lock()
+	defer outputHealthMu.Unlock()
+	entry := outputHealthEntryLocked(typeName)
+	if err != nil {
+		entry.lastErrorAt = now
+		entry.lastError = strings.TrimSpace(err.Error())
+		return
+	}
+	entry.lastSuccessAt = now
+}
+
+func setOutputDisabled(typeName string, disabled bool) {
+	typeName = normalizeTypeName(typeName)
+	outputHealthMu.Lock()
+	defer outputHealthMu.Unlock()
+	outputHealthEntryLocked(typeName).disabled = disabled
+}
+
+func outputHealthEntryLocked(typeName string) *outputHealthEntry {
+	entry := outputHealthByType[typeName]
+	if entry == nil {
+		entry = &outputHealthEntry{}
+		outputHealthByType[typeName] = entry
+	}
+	return entry
+}
+
+func outputHealthFor(typeName string) OutputHealth {
+	typeName = normalizeTypeName(typeName)
+	outputHealthMu.RLock()
+	defer outputHealthMu.RUnlock()
+	health := OutputHealth{Type: typeName}
+	entry := outputHealthByType[typeName]
+	if entry == nil {
+		return health
+	}
+	health.Disabled = entry.disabled
+	health.LastError = entry.lastError
+	if !entry.lastSuccessAt.IsZero() {
+		health.LastSuccessAt = entry.lastSuccessAt.Format(time.RFC3339)
+	}
+	if !entry.lastErrorAt.IsZero() {
+		health.LastErrorAt = entry.lastErrorAt.Format(time.RFC3339)
+	}
+	// Healthy means the latest delivery worked; a handler that has not
+	// delivered anything yet is not.
+	health.Healthy = !entry.disabled && !entry.lastSuccessAt.IsZero() && !entry.lastSuccessAt.Before(entry.lastErrorAt)
+	return health
+}
+
+// Health reports the delivery state of every handler in the manager, in the
+// order they were added.
+func (om *OutputManager) Health() []OutputHealth {
+	if om == nil {
+		return []OutputHealth{}
+	}
+	result := make([]OutputHealth, 0, len(om.handlers))
+	for _, handler := range om.handlers {
+		result = append(result, outputHealthFor(handler.GetType()))
+	}
+	return result
+}
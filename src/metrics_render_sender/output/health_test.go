@@ -0,0 +1,47 @@
+package output
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type healthTestHandler struct{ typeName string }
+
+func (h *healthTestHandler) OutputFrame(frame *OutputFrame) error { return nil }
+
+func (h *healthTestHandler) Close() error { return nil }
+
+func (h *healthTestHandler) GetType() string { return h.typeName }
+
+func TestOutputManagerHealth(t *testing.T) {
+	manager := NewOutputManager()
+	manager.AddHandler(&healthTestHandler{typeName: "health_ok"})
+	manager.AddHandler(&healthTestHandler{typeName: "health_failing"})
+	manager.AddHandler(&healthTestHandler{typeName: "health_idle"})
+
+	now := time.Unix(2000, 0)
+	recordOutputHealthAt("health_ok", errors.New("timeout"), now)
+	recordOutputHealthAt("health_ok", nil, now.Add(time.Second))
+	recordOutputHealthAt("health_failing", nil, now)
+	recordOutputHealthAt("health_failing", errors.New("device not found"), now.Add(time.Second))
+
+	health := manager.Health()
+	if len(health) != 3 {
+		t.Fatalf("expected one entry per handler, got %d", len(health))
+	}
+	if !health[0].Healthy || health[0].LastError != "timeout" {
+		t.Fatalf("expected recovered handler to be healthy and keep its last error: %+v", health[0])
+	}
+	if health[1].Healthy || health[1].LastError != "device not found" || health[1].LastSuccessAt == "" {
+		t.Fatalf("expected failing handler to be unhealthy: %+v", health[1])
+	}
+	if health[2].Healthy || health[2].LastSuccessAt != "" {
+		t.Fatalf("expected handler without deliveries to be unhealthy: %+v", health[2])
+	}
+
+	setOutputDisabled("health_ok", true)
+	if manager.Health()[0].Healthy {
+		t.Fatalf("expected disabled handler to be unhealthy")
+	}
+}
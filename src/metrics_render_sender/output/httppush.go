@@ -26,6 +26,8 @@ type HTTPPushOutputHandler struct {
 
 	lastErrorMu sync.Mutex
 	lastErrorAt time.Time
+
+	breaker *outputBreaker
 }
 
 func NewHTTPPushOutputHandler(cfg OutputConfig, typeName string) *HTTPPushOutputHandler {
@@ -40,6 +42,7 @@ func NewHTTPPushOutputHandler(cfg OutputConfig, typeName string) *HTTPPushOutput
 		},
 		stopCh:  make(chan struct{}),
 		frameCh: make(chan *OutputFrame, 1),
+		breaker: newOutputBreaker(typeName),
 	}
 	handler.loopWg.Add(1)
 	go handler.loop()
@@ -71,33 +74,74 @@ func (h *HTTPPushOutputHandler) loop() {
 	for {
 		select {
 		case <-h.stopCh:
+			// A frame queued right before Close, such as the shutdown
+			// screen, is still sent.
+			select {
+			case frame := <-h.frameCh:
+				h.push(frame)
+			default:
+			}
 			return
 		case frame := <-h.frameCh:
 			h.push(frame)
+			if !h.waitMinInterval() {
+				return
+			}
+		}
+	}
+}
+
+// waitMinInterval holds the loop for min_interval_ms after a push, so a
+// slow receiver such as an e-ink gateway only gets the newest frame once
+// the gap has passed. It reports false once the handler is closing, after
+// sending a frame queued meanwhile.
+func (h *HTTPPushOutputHandler) waitMinInterval() bool {
+	if h.cfg.MinIntervalMS <= 0 {
+		return true
+	}
+	timer := time.NewTimer(time.Duration(h.cfg.MinIntervalMS) * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case <-h.stopCh:
+		select {
+		case frame := <-h.frameCh:
+			h.push(frame)
+		default:
 		}
+		return false
+	case <-timer.C:
+		return true
 	}
 }
 
 func (h *HTTPPushOutputHandler) push(frame *OutputFrame) {
-	if frame == nil {
+	if frame == nil || !h.breaker.allow(time.Now()) {
 		return
 	}
 	body, contentType, encodeErr := h.encodeFrame(frame)
 	if encodeErr != nil {
-		h.logError("encode failed: %v", encodeErr)
+		if h.breaker.failure(time.Now(), encodeErr) {
+			h.logError("encode failed: %v", encodeErr)
+		}
 		recordHTTPPushRuntime(h.typeName, 0, encodeErr)
+		recordOutputHealth(h.typeName, encodeErr)
 		return
 	}
 
 	startedAt := time.Now()
-	err := h.doRequest(body, contentType)
+	err := h.doRequest(body, contentType, frame)
 	recordHTTPPushRuntime(h.typeName, time.Since(startedAt), err)
+	recordOutputHealth(h.typeName, err)
 	if err != nil {
-		h.logError("push failed: %v", err)
+		if h.breaker.failure(time.Now(), err) {
+			h.logError("push failed: %v", err)
+		}
+		return
 	}
+	h.breaker.success(time.Now())
 }
 
-func (h *HTTPPushOutputHandler) doRequest(body []byte, contentType string) error {
+func (h *HTTPPushOutputHandler) doRequest(body []byte, contentType string, frame *OutputFrame) error {
 	if strings.TrimSpace(h.cfg.URL) == "" {
 		return fmt.Errorf("url is empty")
 	}
@@ -117,6 +161,7 @@ func (h *HTTPPushOutputHandler) doRequest(body []byte, contentType string) error
 		req.Header.Set("Content-Type", requestContentType)
 	}
 	h.applyAuth(req)
+	applyRefreshRegionHeaders(req, frame)
 	for _, header := range h.cfg.Headers {
 		req.Header.Set(header.Key, header.Value)
 	}
@@ -133,6 +178,22 @@ func (h *HTTPPushOutputHandler) doRequest(body []byte, contentType string) error
 	return nil
 }
 
+// applyRefreshRegionHeaders tells an e-ink gateway which area changed:
+// X-Refresh-Region as "x,y,width,height" and X-Refresh-Mode as "full" or
+// "partial".
+func applyRefreshRegionHeaders(req *http.Request, frame *OutputFrame) {
+	if frame == nil || frame.Region.Empty() {
+		return
+	}
+	region := frame.Region
+	req.Header.Set("X-Refresh-Region", fmt.Sprintf("%d,%d,%d,%d", region.Min.X, region.Min.Y, region.Dx(), region.Dy()))
+	if frame.FullRefresh {
+		req.Header.Set("X-Refresh-Mode", "full")
+	} else {
+		req.Header.Set("X-Refresh-Mode", "partial")
+	}
+}
+
 func (h *HTTPPushOutputHandler) buildRequestPayload(body []byte, encodedContentType string) ([]byte, string, error) {
 	switch h.cfg.BodyMode {
 	case "multipart":
@@ -9,7 +9,9 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type roundTripFunc func(*http.Request) (*http.Response, error)
@@ -123,7 +125,7 @@ func TestHTTPPushDoRequestHonorsMethodAuthHeadersAndSuccessCodes(t *testing.T) {
 		}, nil
 	})
 
-	if err := handler.doRequest([]byte("payload"), "image/jpeg"); err != nil {
+	if err := handler.doRequest([]byte("payload"), "image/jpeg", nil); err != nil {
 		t.Fatalf("do request: %v", err)
 	}
 	if seenMethod != "PATCH" {
@@ -165,3 +167,35 @@ func TestHTTPPushEncodeFrameHonorsBaselineFormat(t *testing.T) {
 		t.Fatalf("unexpected content type: %q", contentType)
 	}
 }
+
+func TestHTTPPushHoldsFramesForMinInterval(t *testing.T) {
+	cfg, _ := normalizeSingleConfig(OutputConfig{
+		Type:          TypeHTTPPush,
+		URL:           "http://127.0.0.1/frame",
+		Format:        "png",
+		MinIntervalMS: 3600000,
+	})
+	handler := NewHTTPPushOutputHandler(cfg, TypeHTTPPush)
+	var requests atomic.Int32
+	handler.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Header: make(http.Header)}, nil
+	})
+
+	_ = handler.OutputFrame(NewOutputFrame(image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	deadline := time.Now().Add(2 * time.Second)
+	for requests.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	_ = handler.OutputFrame(NewOutputFrame(image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	_ = handler.OutputFrame(NewOutputFrame(image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	time.Sleep(50 * time.Millisecond)
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected frames inside the interval to be held, got %d requests", got)
+	}
+	// Closing still sends the newest held frame, such as a shutdown screen.
+	_ = handler.Close()
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected the held frame to be sent on close, got %d requests", got)
+	}
+}
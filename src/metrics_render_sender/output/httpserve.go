@@ -0,0 +1,218 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultHTTPServeListen = "127.0.0.1:18087"
+
+const httpServeIndexHTML = `<!DOCTYPE html>
+<html><head><title>ax206monitor</title></head>
+<body style="margin:0;background:#111;display:flex;justify-content:center;align-items:center;height:100vh">
+<img src="/stream" alt="ax206monitor">
+</body></html>
+`
+
+func normalizeHTTPServeListen(listen string) string {
+	value := strings.TrimSpace(listen)
+	if value == "" {
+		return defaultHTTPServeListen
+	}
+	return value
+}
+
+// HTTPServeOutputHandler serves the latest frame over its own HTTP listener:
+// /frame.png and /frame.jpg return a snapshot and /stream an MJPEG stream,
+// so a layout can be watched in a browser without a panel attached.
+//
+// The listener is opened with the first frame rather than at creation, as
+// a config change builds the new handler before the old one is closed; a
+// port still held is retried on later frames.
+type HTTPServeOutputHandler struct {
+	typeName string
+	listen   string
+	quality  int
+
+	mu     sync.Mutex
+	frame  *OutputFrame
+	update chan struct{}
+	server *http.Server
+	addr   string
+	closed bool
+	done   chan struct{}
+}
+
+func NewHTTPServeOutputHandler(cfg OutputConfig, typeName string) *HTTPServeOutputHandler {
+	return &HTTPServeOutputHandler{
+		typeName: typeName,
+		listen:   normalizeHTTPServeListen(cfg.Listen),
+		quality:  normalizeHTTPPushQuality(cfg.Quality),
+		update:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (h *HTTPServeOutputHandler) GetType() string {
+	return h.typeName
+}
+
+func (h *HTTPServeOutputHandler) OutputFrame(frame *OutputFrame) error {
+	if frame == nil {
+		return nil
+	}
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.frame = frame
+	close(h.update)
+	h.update = make(chan struct{})
+	listening := h.server != nil
+	h.mu.Unlock()
+
+	var err error
+	if !listening {
+		err = h.start()
+	}
+	recordOutputHealth(h.typeName, err)
+	return err
+}
+
+func (h *HTTPServeOutputHandler) start() error {
+	listener, err := net.Listen("tcp", h.listen)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", h.serveIndex)
+	mux.HandleFunc("/frame.png", h.serveSnapshot)
+	mux.HandleFunc("/frame.jpg", h.serveSnapshot)
+	mux.HandleFunc("/stream", h.serveStream)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return listener.Close()
+	}
+	h.server = server
+	h.addr = listener.Addr().String()
+	h.mu.Unlock()
+
+	logInfoModule("http", "Serving frames on http://%s/stream", h.addr)
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logWarnModule("http", "Server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// latest returns the current frame and a channel closed when it is replaced.
+func (h *HTTPServeOutputHandler) latest() (*OutputFrame, <-chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.frame, h.update
+}
+
+func (h *HTTPServeOutputHandler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(httpServeIndexHTML))
+}
+
+func (h *HTTPServeOutputHandler) serveSnapshot(w http.ResponseWriter, r *http.Request) {
+	frame, _ := h.latest()
+	if frame == nil {
+		http.Error(w, "no frame rendered yet", http.StatusServiceUnavailable)
+		return
+	}
+	var data []byte
+	var err error
+	contentType := "image/png"
+	if strings.HasSuffix(r.URL.Path, ".jpg") {
+		contentType = "image/jpeg"
+		data, err = frame.JPEG(h.quality)
+	} else {
+		data, err = frame.PNG()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(data)
+}
+
+// serveStream sends every new frame as one JPEG part of a
+// multipart/x-mixed-replace response, which browsers show as live video.
+func (h *HTTPServeOutputHandler) serveStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary=frame")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var sent *OutputFrame
+	for {
+		frame, update := h.latest()
+		if frame != nil && frame != sent {
+			data, err := frame.JPEG(h.quality)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "--frame\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\n\r\n", len(data)); err != nil {
+				return
+			}
+			if _, err := w.Write(data); err != nil {
+				return
+			}
+			if _, err := w.Write([]byte("\r\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+			sent = frame
+		}
+		select {
+		case <-update:
+		case <-r.Context().Done():
+			return
+		case <-h.done:
+			return
+		}
+	}
+}
+
+func (h *HTTPServeOutputHandler) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	close(h.done)
+	server := h.server
+	h.mu.Unlock()
+	if server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		return server.Close()
+	}
+	return nil
+}
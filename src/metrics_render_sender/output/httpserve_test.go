@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bufio"
+	"image"
+	"image/png"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPServeServesSnapshotAndStream(t *testing.T) {
+	handler := NewHTTPServeOutputHandler(OutputConfig{Type: TypeHTTP, Listen: "127.0.0.1:0"}, TypeHTTP)
+	if err := handler.OutputFrame(NewOutputFrame(image.NewRGBA(image.Rect(0, 0, 6, 4)))); err != nil {
+		t.Fatalf("OutputFrame: %v", err)
+	}
+	handler.mu.Lock()
+	baseURL := "http://" + handler.addr
+	handler.mu.Unlock()
+
+	resp, err := http.Get(baseURL + "/frame.png")
+	if err != nil {
+		t.Fatalf("get snapshot: %v", err)
+	}
+	img, err := png.Decode(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decode snapshot: %v", err)
+	}
+	if img.Bounds().Dx() != 6 {
+		t.Fatalf("unexpected snapshot width %d", img.Bounds().Dx())
+	}
+
+	stream, err := http.Get(baseURL + "/stream")
+	if err != nil {
+		t.Fatalf("get stream: %v", err)
+	}
+	defer stream.Body.Close()
+	if !strings.HasPrefix(stream.Header.Get("Content-Type"), "multipart/x-mixed-replace") {
+		t.Fatalf("unexpected stream content type %q", stream.Header.Get("Content-Type"))
+	}
+	reader := bufio.NewReader(stream.Body)
+	line, err := reader.ReadString('\n')
+	if err != nil || strings.TrimSpace(line) != "--frame" {
+		t.Fatalf("expected a frame boundary, got %q (%v)", line, err)
+	}
+
+	// Close must not wait for the open stream to end on its own.
+	closed := make(chan struct{})
+	go func() {
+		_ = handler.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatalf("Close blocked on the open stream")
+	}
+}
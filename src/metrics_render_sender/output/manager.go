@@ -1,6 +1,9 @@
 package output
 
-import "time"
+import (
+	"sync/atomic"
+	"time"
+)
 
 type OutputHandler interface {
 	OutputFrame(frame *OutputFrame) error
@@ -8,8 +11,33 @@ type OutputHandler interface {
 	GetType() string
 }
 
+// outputResetter is implemented by handlers holding a connection that does
+// not survive a system suspend.
+type outputResetter interface {
+	Reset()
+}
+
 type OutputManager struct {
 	handlers []OutputHandler
+	breakers []*outputBreaker
+	// unchanged holds the last frame hash per handler that skips unchanged
+	// frames, nil for the others.
+	unchanged []*unchangedFilter
+}
+
+// unchangedResendInterval is how long an unchanged frame is held back
+// before it is sent anyway, so push receivers and file readers see the
+// monitor alive.
+const unchangedResendInterval = time.Minute
+
+// unchangedFilter remembers the hash of the last frame a handler took.
+// hash, valid and sentAt belong to the output loop; Reset runs on the power
+// watcher and only sets stale, which the next frame consumes.
+type unchangedFilter struct {
+	hash   uint64
+	valid  bool
+	sentAt time.Time
+	stale  atomic.Bool
 }
 
 func NewOutputManager() *OutputManager {
@@ -19,22 +47,62 @@ func NewOutputManager() *OutputManager {
 }
 
 func (om *OutputManager) AddHandler(handler OutputHandler) {
+	om.addHandler(handler, false)
+}
+
+func (om *OutputManager) addHandler(handler OutputHandler, skipUnchanged bool) {
+	var filter *unchangedFilter
+	if skipUnchanged {
+		filter = &unchangedFilter{}
+	}
 	om.handlers = append(om.handlers, handler)
+	om.breakers = append(om.breakers, newOutputBreaker(handler.GetType()))
+	om.unchanged = append(om.unchanged, filter)
 }
 
 func (om *OutputManager) OutputFrame(frame *OutputFrame) error {
 	var hasSuccess bool
 	var lastErr error
-	for _, handler := range om.handlers {
+	for idx, handler := range om.handlers {
+		var breaker *outputBreaker
+		if idx < len(om.breakers) {
+			breaker = om.breakers[idx]
+		}
 		startedAt := time.Now()
+		if !breaker.allow(startedAt) {
+			continue
+		}
+		filter := om.unchanged[idx]
+		var hash uint64
+		var hashed bool
+		if filter != nil {
+			if filter.stale.Swap(false) {
+				filter.valid = false
+			}
+			hash, hashed = frame.Hash()
+			if hashed && filter.valid && filter.hash == hash && startedAt.Sub(filter.sentAt) < unchangedResendInterval {
+				recordOutputUnchanged(handler.GetType())
+				hasSuccess = true
+				continue
+			}
+		}
 		err := handler.OutputFrame(frame)
 		duration := time.Since(startedAt)
 		recordOutputRuntime(handler.GetType(), duration, err)
 		if err != nil {
-			logWarnModule("output", "%s failed: %v", handler.GetType(), err)
+			if filter != nil {
+				filter.valid = false
+			}
+			if breaker.failure(time.Now(), err) {
+				logWarnModule("output", "%s failed: %v", handler.GetType(), err)
+			}
 			lastErr = err
 			continue
 		}
+		if filter != nil {
+			filter.hash, filter.valid, filter.sentAt = hash, hashed, startedAt
+		}
+		breaker.success(time.Now())
 		hasSuccess = true
 	}
 	if !hasSuccess && lastErr != nil {
@@ -48,3 +116,20 @@ func (om *OutputManager) Close() {
 		handler.Close()
 	}
 }
+
+// Reset reconnects handlers after the system resumed and lets disabled
+// handlers probe again on the next frame, since the device they failed on
+// may be back.
+func (om *OutputManager) Reset() {
+	for idx, handler := range om.handlers {
+		if resetter, ok := handler.(outputResetter); ok {
+			resetter.Reset()
+		}
+		if idx < len(om.breakers) {
+			om.breakers[idx].rearm()
+		}
+		if filter := om.unchanged[idx]; filter != nil {
+			filter.stale.Store(true)
+		}
+	}
+}
@@ -15,6 +15,7 @@ func (m *MemImgOutputHandler) OutputFrame(frame *OutputFrame) error {
 		return nil
 	}
 	data, err := frame.PNG()
+	recordOutputHealth(m.GetType(), err)
 	if err != nil {
 		return err
 	}
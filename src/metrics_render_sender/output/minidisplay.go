@@ -0,0 +1,431 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Small SPI and I2C panels driven straight from Linux device nodes:
+// ssd1306 monochrome OLEDs over /dev/i2c-N and st7789 color TFTs over
+// /dev/spidevX.Y with a GPIO line for data/command.
+const (
+	defaultSSD1306Device  = "/dev/i2c-1"
+	defaultSSD1306Address = 0x3c
+	defaultST7789Device   = "/dev/spidev0.0"
+	defaultST7789SpeedHz  = 40000000
+	defaultMiniGPIOChip   = "/dev/gpiochip0"
+
+	// spiChunkSize is the default spidev transfer limit (bufsiz).
+	spiChunkSize = 4096
+)
+
+// OutputPin is a GPIO output line used by a mini display.
+type OutputPin interface {
+	SetValue(on bool) error
+	Close() error
+}
+
+var (
+	outputPinOpenerMu sync.RWMutex
+	outputPinOpener   func(chip string, line int) (OutputPin, error)
+)
+
+// SetOutputPinOpener registers how GPIO output lines are requested, so the
+// display handlers share the GPIO code of the buttons and alert LED.
+func SetOutputPinOpener(fn func(chip string, line int) (OutputPin, error)) {
+	outputPinOpenerMu.Lock()
+	outputPinOpener = fn
+	outputPinOpenerMu.Unlock()
+}
+
+func openOutputPin(chip string, line int) (OutputPin, error) {
+	outputPinOpenerMu.RLock()
+	fn := outputPinOpener
+	outputPinOpenerMu.RUnlock()
+	if fn == nil {
+		return nil, errors.New("gpio is not available")
+	}
+	return fn(chip, line)
+}
+
+func normalizeMiniDisplayConfig(cfg *OutputConfig, raw OutputConfig) {
+	cfg.Device = strings.TrimSpace(raw.Device)
+	cfg.Width = raw.Width
+	cfg.Height = raw.Height
+	cfg.Invert = raw.Invert
+	cfg.Fit = AX206FitContain
+	if raw.Fit != "" {
+		cfg.Fit = normalizeAX206FitMode(raw.Fit)
+	}
+	cfg.SkipUnchanged = cloneEnabledValue(raw.SkipUnchanged == nil || *raw.SkipUnchanged)
+	switch cfg.Type {
+	case TypeSSD1306:
+		if cfg.Device == "" {
+			cfg.Device = defaultSSD1306Device
+		}
+		cfg.I2CAddress = raw.I2CAddress
+		if cfg.I2CAddress <= 0 || cfg.I2CAddress > 0x7f {
+			cfg.I2CAddress = defaultSSD1306Address
+		}
+		if cfg.Width <= 0 || cfg.Width > 128 {
+			cfg.Width = 128
+		}
+		// The controller addresses rows in pages of 8.
+		if cfg.Height != 32 && cfg.Height != 48 {
+			cfg.Height = 64
+		}
+	case TypeST7789:
+		if cfg.Device == "" {
+			cfg.Device = defaultST7789Device
+		}
+		if cfg.Width <= 0 || cfg.Width > 320 {
+			cfg.Width = 240
+		}
+		if cfg.Height <= 0 || cfg.Height > 320 {
+			cfg.Height = 240
+		}
+		cfg.OffsetX = raw.OffsetX
+		cfg.OffsetY = raw.OffsetY
+		cfg.SPIMode = raw.SPIMode
+		if cfg.SPIMode < 0 || cfg.SPIMode > 3 {
+			cfg.SPIMode = 0
+		}
+		cfg.SPISpeedHz = raw.SPISpeedHz
+		if cfg.SPISpeedHz <= 0 || cfg.SPISpeedHz > 80000000 {
+			cfg.SPISpeedHz = defaultST7789SpeedHz
+		}
+		cfg.GPIOChip = strings.TrimSpace(raw.GPIOChip)
+		if cfg.GPIOChip == "" {
+			cfg.GPIOChip = defaultMiniGPIOChip
+		}
+		cfg.DCLine = cloneLine(raw.DCLine)
+		cfg.ResetLine = cloneLine(raw.ResetLine)
+	}
+}
+
+func cloneLine(line *int) *int {
+	if line == nil || *line < 0 {
+		return nil
+	}
+	value := *line
+	return &value
+}
+
+func equalLine(left, right *int) bool {
+	if left == nil || right == nil {
+		return left == right
+	}
+	return *left == *right
+}
+
+// miniPanel draws a frame already scaled to the panel size.
+type miniPanel interface {
+	draw(img image.Image) error
+	Close() error
+}
+
+// MiniDisplayOutputHandler sends frames to an ssd1306 or st7789 panel,
+// scaled to it by the fit mode. The device is opened with the first frame
+// and reopened after a failed write.
+type MiniDisplayOutputHandler struct {
+	typeName string
+	cfg      OutputConfig
+	open     func(cfg OutputConfig) (miniPanel, error)
+	resetCh  chan struct{}
+
+	panel  miniPanel
+	scaled *image.RGBA
+}
+
+func NewMiniDisplayOutputHandler(cfg OutputConfig, typeName string) *MiniDisplayOutputHandler {
+	open := openST7789
+	if cfg.Type == TypeSSD1306 {
+		open = openSSD1306
+	}
+	return &MiniDisplayOutputHandler{typeName: typeName, cfg: cfg, open: open, resetCh: make(chan struct{}, 1)}
+}
+
+func (h *MiniDisplayOutputHandler) GetType() string {
+	return h.typeName
+}
+
+func (h *MiniDisplayOutputHandler) OutputFrame(frame *OutputFrame) error {
+	if frame == nil || frame.Image == nil {
+		return nil
+	}
+	select {
+	case <-h.resetCh:
+		_ = h.Close()
+	default:
+	}
+	err := h.draw(frame.Image)
+	recordOutputHealth(h.typeName, err)
+	return err
+}
+
+func (h *MiniDisplayOutputHandler) draw(src image.Image) error {
+	if h.panel == nil {
+		panel, err := h.open(h.cfg)
+		if err != nil {
+			return err
+		}
+		logInfoModule(h.cfg.Type, "Connected %s %dx%d", h.cfg.Device, h.cfg.Width, h.cfg.Height)
+		h.panel = panel
+	}
+	var img image.Image
+	img, h.scaled = fitAX206Image(h.scaled, src, h.cfg.Width, h.cfg.Height, h.cfg.Fit)
+	if err := h.panel.draw(img); err != nil {
+		_ = h.panel.Close()
+		h.panel = nil
+		return err
+	}
+	return nil
+}
+
+// Reset reopens the panel after a resume, which may have powered it down
+// and lost its init sequence. The panel is closed by the next OutputFrame
+// so it is never swapped out from under a draw.
+func (h *MiniDisplayOutputHandler) Reset() {
+	select {
+	case h.resetCh <- struct{}{}:
+	default:
+	}
+}
+
+func (h *MiniDisplayOutputHandler) Close() error {
+	if h.panel == nil {
+		return nil
+	}
+	err := h.panel.Close()
+	h.panel = nil
+	return err
+}
+
+type ssd1306Panel struct {
+	dev    io.WriteCloser
+	width  int
+	height int
+	invert bool
+	buf    []byte
+}
+
+func openSSD1306(cfg OutputConfig) (miniPanel, error) {
+	dev, err := openI2CDevice(cfg.Device, cfg.I2CAddress)
+	if err != nil {
+		return nil, err
+	}
+	panel := &ssd1306Panel{dev: dev, width: cfg.Width, height: cfg.Height, invert: cfg.Invert}
+	comPins := byte(0x12)
+	if cfg.Height == 32 {
+		comPins = 0x02
+	}
+	err = panel.command(
+		0xae,       // display off
+		0xd5, 0x80, // clock divide
+		0xa8, byte(cfg.Height-1), // multiplex
+		0xd3, 0x00, // display offset
+		0x40,       // start line 0
+		0x8d, 0x14, // charge pump on
+		0x20, 0x00, // horizontal addressing
+		0xa1, // segment remap
+		0xc8, // COM scan descending
+		0xda, comPins,
+		0x81, 0xcf, // contrast
+		0xd9, 0xf1, // precharge
+		0xdb, 0x40, // VCOMH deselect
+		0xa4, // show RAM
+		0xa6, // normal polarity
+		0xaf, // display on
+	)
+	if err != nil {
+		dev.Close()
+		return nil, fmt.Errorf("init %s: %w", cfg.Device, err)
+	}
+	return panel, nil
+}
+
+func (p *ssd1306Panel) command(cmds ...byte) error {
+	_, err := p.dev.Write(append([]byte{0x00}, cmds...))
+	return err
+}
+
+func (p *ssd1306Panel) draw(img image.Image) error {
+	p.buf = packSSD1306(p.buf, img, p.width, p.height, p.invert)
+	if err := p.command(0x21, 0, byte(p.width-1), 0x22, 0, byte(p.height/8-1)); err != nil {
+		return err
+	}
+	_, err := p.dev.Write(p.buf)
+	return err
+}
+
+func (p *ssd1306Panel) Close() error {
+	_ = p.command(0xae)
+	return p.dev.Close()
+}
+
+// packSSD1306 turns img into the data write for a width x height SSD1306:
+// the 0x40 control byte, then one byte per column of each 8-row page, least
+// significant bit on top. A pixel lights when its luminance is at least
+// half, or below half with invert.
+func packSSD1306(buf []byte, img image.Image, width, height int, invert bool) []byte {
+	size := 1 + width*height/8
+	if cap(buf) < size {
+		buf = make([]byte, size)
+	}
+	buf = buf[:size]
+	for idx := range buf {
+		buf[idx] = 0
+	}
+	buf[0] = 0x40
+	bounds := img.Bounds()
+	for y := 0; y < height && y < bounds.Dy(); y++ {
+		for x := 0; x < width && x < bounds.Dx(); x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			lit := 19595*r+38470*g+7471*b >= 1<<31
+			if lit != invert {
+				buf[1+(y/8)*width+x] |= 1 << (y % 8)
+			}
+		}
+	}
+	return buf
+}
+
+type st7789Panel struct {
+	dev     io.WriteCloser
+	dc      OutputPin
+	reset   OutputPin
+	width   int
+	height  int
+	offsetX int
+	offsetY int
+	buf     []byte
+}
+
+func openST7789(cfg OutputConfig) (miniPanel, error) {
+	if cfg.DCLine == nil {
+		return nil, errors.New("st7789 needs dc_line")
+	}
+	dev, err := openSPIDevice(cfg.Device, cfg.SPIMode, cfg.SPISpeedHz)
+	if err != nil {
+		return nil, err
+	}
+	panel := &st7789Panel{dev: dev, width: cfg.Width, height: cfg.Height, offsetX: cfg.OffsetX, offsetY: cfg.OffsetY}
+	if panel.dc, err = openOutputPin(cfg.GPIOChip, *cfg.DCLine); err != nil {
+		panel.Close()
+		return nil, fmt.Errorf("dc_line: %w", err)
+	}
+	if cfg.ResetLine != nil {
+		if panel.reset, err = openOutputPin(cfg.GPIOChip, *cfg.ResetLine); err != nil {
+			panel.Close()
+			return nil, fmt.Errorf("reset_line: %w", err)
+		}
+		_ = panel.reset.SetValue(false)
+		time.Sleep(10 * time.Millisecond)
+		_ = panel.reset.SetValue(true)
+		time.Sleep(120 * time.Millisecond)
+	}
+	// IPS panels, the common kind, need display inversion for true colors.
+	inversion := byte(0x21)
+	if cfg.Invert {
+		inversion = 0x20
+	}
+	steps := []struct {
+		cmd   byte
+		data  []byte
+		delay time.Duration
+	}{
+		{cmd: 0x01, delay: 150 * time.Millisecond}, // software reset
+		{cmd: 0x11, delay: 120 * time.Millisecond}, // sleep out
+		{cmd: 0x3a, data: []byte{0x55}},            // 16-bit color
+		{cmd: 0x36, data: []byte{0x00}},            // memory access order
+		{cmd: inversion},
+		{cmd: 0x13}, // normal display mode
+		{cmd: 0x29}, // display on
+	}
+	for _, step := range steps {
+		if err := panel.command(step.cmd, step.data...); err != nil {
+			panel.Close()
+			return nil, fmt.Errorf("init %s: %w", cfg.Device, err)
+		}
+		time.Sleep(step.delay)
+	}
+	return panel, nil
+}
+
+func (p *st7789Panel) command(cmd byte, data ...byte) error {
+	if err := p.dc.SetValue(false); err != nil {
+		return err
+	}
+	if _, err := p.dev.Write([]byte{cmd}); err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := p.dc.SetValue(true); err != nil {
+		return err
+	}
+	for start := 0; start < len(data); start += spiChunkSize {
+		end := start + spiChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		if _, err := p.dev.Write(data[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *st7789Panel) draw(img image.Image) error {
+	x0, y0 := p.offsetX, p.offsetY
+	x1, y1 := x0+p.width-1, y0+p.height-1
+	if err := p.command(0x2a, byte(x0>>8), byte(x0), byte(x1>>8), byte(x1)); err != nil {
+		return err
+	}
+	if err := p.command(0x2b, byte(y0>>8), byte(y0), byte(y1>>8), byte(y1)); err != nil {
+		return err
+	}
+	p.buf = packRGB565BE(p.buf, img, p.width, p.height)
+	return p.command(0x2c, p.buf...)
+}
+
+func (p *st7789Panel) Close() error {
+	if p.dc != nil {
+		_ = p.command(0x28) // display off
+		p.dc.Close()
+	}
+	if p.reset != nil {
+		p.reset.Close()
+	}
+	return p.dev.Close()
+}
+
+// packRGB565BE converts img to the big-endian RGB565 the ST7789 reads in
+// 16-bit color mode, leaving areas outside img black.
+func packRGB565BE(buf []byte, img image.Image, width, height int) []byte {
+	size := width * height * 2
+	if cap(buf) < size {
+		buf = make([]byte, size)
+	}
+	buf = buf[:size]
+	for idx := range buf {
+		buf[idx] = 0
+	}
+	bounds := img.Bounds()
+	for y := 0; y < height && y < bounds.Dy(); y++ {
+		for x := 0; x < width && x < bounds.Dx(); x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			value := uint16((r & 0xf800) | ((g & 0xfc00) >> 5) | ((b & 0xf800) >> 11))
+			offset := (y*width + x) * 2
+			buf[offset] = byte(value >> 8)
+			buf[offset+1] = byte(value)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,64 @@
+//go:build linux
+
+package output
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	i2cSlaveIOCTL         = 0x0703
+	spiWriteModeIOCTL     = 0x40016b01
+	spiWriteBitsIOCTL     = 0x40016b03
+	spiWriteMaxSpeedIOCTL = 0x40046b04
+)
+
+// openI2CDevice opens an i2c-dev bus node bound to the device at address;
+// each write is then one I2C transfer to it.
+func openI2CDevice(path string, address int) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), i2cSlaveIOCTL, uintptr(address)); errno != 0 {
+		file.Close()
+		return nil, fmt.Errorf("select i2c address 0x%02x on %s: %w", address, path, errno)
+	}
+	return file, nil
+}
+
+// openSPIDevice opens a spidev node with 8-bit words; each write is then
+// one half-duplex transfer.
+func openSPIDevice(path string, mode, speedHz int) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	modeValue := uint8(mode)
+	bits := uint8(8)
+	speed := uint32(speedHz)
+	for _, setting := range []struct {
+		request uintptr
+		value   unsafe.Pointer
+	}{
+		{spiWriteModeIOCTL, unsafe.Pointer(&modeValue)},
+		{spiWriteBitsIOCTL, unsafe.Pointer(&bits)},
+		{spiWriteMaxSpeedIOCTL, unsafe.Pointer(&speed)},
+	} {
+		if err := spiIoctl(file, setting.request, setting.value); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("configure %s: %w", path, err)
+		}
+	}
+	return file, nil
+}
+
+func spiIoctl(file *os.File, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package output
+
+import (
+	"errors"
+	"io"
+)
+
+var errMiniDisplayUnsupported = errors.New("ssd1306 and st7789 outputs are only supported on linux")
+
+func openI2CDevice(path string, address int) (io.WriteCloser, error) {
+	return nil, errMiniDisplayUnsupported
+}
+
+func openSPIDevice(path string, mode, speedHz int) (io.WriteCloser, error) {
+	return nil, errMiniDisplayUnsupported
+}
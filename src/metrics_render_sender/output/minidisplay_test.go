@@ -0,0 +1,90 @@
+package output
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestPackSSD1306PlacesPixelsInPages(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 128, 64))
+	img.Set(3, 10, color.White)
+	buf := packSSD1306(nil, img, 128, 64, false)
+	if len(buf) != 1+128*64/8 || buf[0] != 0x40 {
+		t.Fatalf("unexpected data write header or size %d", len(buf))
+	}
+	// Row 10 is bit 2 of page 1.
+	if buf[1+128+3] != 1<<2 {
+		t.Fatalf("expected pixel (3,10) in page 1, got %08b", buf[1+128+3])
+	}
+	lit := 0
+	for _, value := range packSSD1306(buf, img, 128, 64, true)[1:] {
+		for ; value != 0; value &= value - 1 {
+			lit++
+		}
+	}
+	if lit != 128*64-1 {
+		t.Fatalf("expected invert to light every other pixel, got %d", lit)
+	}
+}
+
+func TestPackRGB565BigEndian(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(1, 0, color.RGBA{R: 255, A: 255})
+	buf := packRGB565BE(nil, img, 2, 1)
+	if buf[2] != 0xf8 || buf[3] != 0x00 {
+		t.Fatalf("expected red as f800 big-endian, got %02x%02x", buf[2], buf[3])
+	}
+}
+
+type fakeMiniPanel struct {
+	draws []image.Rectangle
+	fail  bool
+}
+
+func (p *fakeMiniPanel) draw(img image.Image) error {
+	if p.fail {
+		return errors.New("i/o error")
+	}
+	p.draws = append(p.draws, img.Bounds())
+	return nil
+}
+
+func (p *fakeMiniPanel) Close() error { return nil }
+
+func TestMiniDisplayScalesAndReopens(t *testing.T) {
+	cfg, ok := normalizeSingleConfig(OutputConfig{Type: TypeSSD1306})
+	if !ok || cfg.Width != 128 || cfg.Height != 64 || cfg.Fit != AX206FitContain || cfg.I2CAddress != 0x3c {
+		t.Fatalf("unexpected ssd1306 defaults: %+v", cfg)
+	}
+	opens := 0
+	panel := &fakeMiniPanel{}
+	handler := NewMiniDisplayOutputHandler(cfg, TypeSSD1306)
+	handler.open = func(OutputConfig) (miniPanel, error) {
+		opens++
+		return panel, nil
+	}
+
+	frame := NewOutputFrame(image.NewRGBA(image.Rect(0, 0, 480, 320)))
+	if err := handler.OutputFrame(frame); err != nil {
+		t.Fatalf("OutputFrame: %v", err)
+	}
+	if len(panel.draws) != 1 || panel.draws[0] != image.Rect(0, 0, 128, 64) {
+		t.Fatalf("expected the frame scaled to the panel, got %v", panel.draws)
+	}
+	panel.fail = true
+	if err := handler.OutputFrame(frame); err == nil {
+		t.Fatalf("expected the write error to be returned")
+	}
+	panel.fail = false
+	_ = handler.OutputFrame(frame)
+	if opens != 2 {
+		t.Fatalf("expected the panel to be reopened after a failed write, got %d opens", opens)
+	}
+	handler.Reset()
+	_ = handler.OutputFrame(frame)
+	if opens != 3 {
+		t.Fatalf("expected the panel to be reopened after Reset, got %d opens", opens)
+	}
+}
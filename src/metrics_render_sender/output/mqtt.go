@@ -0,0 +1,523 @@
+package output
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	neturl "net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMQTTFrameTopic  = "ax206monitor/frame"
+	defaultMQTTValuesTopic = "ax206monitor/values"
+	defaultMQTTIntervalMS  = 5000
+
+	mqttPacketConnect    = 0x10
+	mqttPacketConnack    = 0x20
+	mqttPacketPublish    = 0x30
+	mqttPacketPuback     = 0x40
+	mqttPacketPubrec     = 0x50
+	mqttPacketPubrel     = 0x62
+	mqttPacketPubcomp    = 0x70
+	mqttPacketDisconnect = 0xe0
+
+	// mqttMaxRemainingLength is the largest packet body the four-byte
+	// length field can describe.
+	mqttMaxRemainingLength = 268435455
+)
+
+var (
+	monitorValuesSourceMu sync.RWMutex
+	monitorValuesSource   func() map[string]interface{}
+)
+
+// SetMonitorValuesSource registers where the mqtt output reads the current
+// monitor values, keyed by monitor name.
+func SetMonitorValuesSource(fn func() map[string]interface{}) {
+	monitorValuesSourceMu.Lock()
+	monitorValuesSource = fn
+	monitorValuesSourceMu.Unlock()
+}
+
+func currentMonitorValues() map[string]interface{} {
+	monitorValuesSourceMu.RLock()
+	fn := monitorValuesSource
+	monitorValuesSourceMu.RUnlock()
+	if fn == nil {
+		return nil
+	}
+	return fn()
+}
+
+func normalizeMQTTConfig(cfg *OutputConfig, raw OutputConfig) {
+	cfg.URL = strings.TrimSpace(raw.URL)
+	cfg.Topic = normalizeMQTTTopic(raw.Topic, defaultMQTTFrameTopic)
+	cfg.ValuesTopic = normalizeMQTTTopic(raw.ValuesTopic, defaultMQTTValuesTopic)
+	cfg.QoS = raw.QoS
+	if cfg.QoS < 0 || cfg.QoS > 2 {
+		cfg.QoS = 0
+	}
+	cfg.Retain = raw.Retain
+	cfg.ClientID = strings.TrimSpace(raw.ClientID)
+	cfg.AuthUsername = strings.TrimSpace(raw.AuthUsername)
+	cfg.AuthPassword = strings.TrimSpace(raw.AuthPassword)
+	cfg.TimeoutMS = normalizeHTTPPushTimeoutMS(raw.TimeoutMS)
+	cfg.MinIntervalMS = normalizeMQTTIntervalMS(raw.MinIntervalMS)
+}
+
+// normalizeMQTTTopic falls back to the default for topics a client may not
+// publish to, such as ones with + or # wildcards.
+func normalizeMQTTTopic(topic, fallback string) string {
+	value := strings.TrimSpace(topic)
+	if value == "" || strings.ContainsAny(value, "+#\x00") {
+		return fallback
+	}
+	return value
+}
+
+// normalizeMQTTIntervalMS defaults to a publish every five seconds, which is
+// plenty for Home Assistant, and keeps the gap between 200 ms and one hour.
+func normalizeMQTTIntervalMS(intervalMS int) int {
+	if intervalMS <= 0 {
+		return defaultMQTTIntervalMS
+	}
+	if intervalMS < 200 {
+		return 200
+	}
+	if intervalMS > 3600000 {
+		return 3600000
+	}
+	return intervalMS
+}
+
+// parseMQTTAddress accepts mqtt://host[:port] or tcp:// for a plain
+// connection and mqtts://, ssl:// or tls:// for TLS, defaulting to port
+// 1883 and 8883. A bare host:port is treated as mqtt://.
+func parseMQTTAddress(rawURL string) (address string, useTLS bool, err error) {
+	value := strings.TrimSpace(rawURL)
+	if value == "" {
+		return "", false, fmt.Errorf("url is empty")
+	}
+	if !strings.Contains(value, "://") {
+		value = "mqtt://" + value
+	}
+	parsed, err := neturl.Parse(value)
+	if err != nil {
+		return "", false, err
+	}
+	port := "1883"
+	switch strings.ToLower(parsed.Scheme) {
+	case "mqtt", "tcp":
+	case "mqtts", "ssl", "tls":
+		useTLS = true
+		port = "8883"
+	default:
+		return "", false, fmt.Errorf("unsupported scheme: %s", parsed.Scheme)
+	}
+	if parsed.Hostname() == "" {
+		return "", false, fmt.Errorf("mqtt host is empty")
+	}
+	if parsed.Port() != "" {
+		port = parsed.Port()
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), useTLS, nil
+}
+
+// defaultMQTTClientID names the client after the machine, plus the index of
+// the output when several publish to brokers, since a broker drops an
+// existing session when another client connects with the same ID.
+func defaultMQTTClientID(typeName string) string {
+	host, _ := os.Hostname()
+	var b strings.Builder
+	b.WriteString("ax206monitor")
+	if host != "" {
+		b.WriteByte('-')
+	}
+	for _, ch := range host {
+		if (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch == '-' {
+			b.WriteRune(ch)
+		}
+	}
+	b.WriteString(strings.TrimPrefix(typeName, TypeMQTT))
+	return b.String()
+}
+
+func appendMQTTString(buf []byte, value string) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(value)))
+	return append(buf, value...)
+}
+
+// mqttPacket frames body behind a fixed header, encoding the remaining
+// length seven bits at a time.
+func mqttPacket(header byte, body []byte) []byte {
+	packet := make([]byte, 0, len(body)+5)
+	packet = append(packet, header)
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+func mqttConnectPacket(clientID, username, password string, keepAlive time.Duration) []byte {
+	flags := byte(0x02) // clean session
+	if username != "" {
+		flags |= 0x80
+		if password != "" {
+			flags |= 0x40
+		}
+	}
+	body := appendMQTTString(nil, "MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(keepAlive/time.Second))
+	body = appendMQTTString(body, clientID)
+	if flags&0x80 != 0 {
+		body = appendMQTTString(body, username)
+	}
+	if flags&0x40 != 0 {
+		body = appendMQTTString(body, password)
+	}
+	return mqttPacket(mqttPacketConnect, body)
+}
+
+func mqttPublishPacket(topic string, payload []byte, qos int, retain bool, packetID uint16) []byte {
+	header := byte(mqttPacketPublish) | byte(qos)<<1
+	if retain {
+		header |= 0x01
+	}
+	body := appendMQTTString(make([]byte, 0, len(topic)+len(payload)+4), topic)
+	if qos > 0 {
+		body = binary.BigEndian.AppendUint16(body, packetID)
+	}
+	return mqttPacket(header, append(body, payload...))
+}
+
+func readMQTTPacket(reader *bufio.Reader) (byte, []byte, error) {
+	header, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length := 0
+	for shift := 0; ; shift += 7 {
+		if shift > 21 {
+			return 0, nil, errors.New("malformed mqtt packet length")
+		}
+		digit, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length |= int(digit&0x7f) << shift
+		if digit&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+func mqttConnackError(code byte) error {
+	switch code {
+	case 0:
+		return nil
+	case 1:
+		return errors.New("broker refused the connection: unacceptable protocol version")
+	case 2:
+		return errors.New("broker refused the connection: client id rejected")
+	case 3:
+		return errors.New("broker refused the connection: server unavailable")
+	case 4:
+		return errors.New("broker refused the connection: bad user name or password")
+	case 5:
+		return errors.New("broker refused the connection: not authorized")
+	default:
+		return fmt.Errorf("broker refused the connection: code %d", code)
+	}
+}
+
+// MQTTOutputHandler publishes the newest frame as PNG to topic and the
+// current monitor values as a JSON object to values_topic once per
+// min_interval_ms, e.g. for a Home Assistant MQTT camera and sensors. It
+// speaks MQTT 3.1.1 with QoS 0, 1 or 2 and keeps one connection open,
+// reconnecting after an error or when the broker would have timed it out.
+type MQTTOutputHandler struct {
+	cfg      OutputConfig
+	typeName string
+	clientID string
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	loopWg   sync.WaitGroup
+	frameCh  chan *OutputFrame
+
+	conn      net.Conn
+	reader    *bufio.Reader
+	keepAlive time.Duration
+	lastSent  time.Time
+	packetID  uint16
+
+	breaker *outputBreaker
+}
+
+func NewMQTTOutputHandler(cfg OutputConfig, typeName string) *MQTTOutputHandler {
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = defaultMQTTClientID(typeName)
+	}
+	// Publishing every interval keeps the session alive, so the keep-alive
+	// only has to outlast the gap between two publishes.
+	keepAlive := 2 * time.Duration(cfg.MinIntervalMS) * time.Millisecond
+	if keepAlive < time.Minute {
+		keepAlive = time.Minute
+	}
+	handler := &MQTTOutputHandler{
+		cfg:       cfg,
+		typeName:  typeName,
+		clientID:  clientID,
+		stopCh:    make(chan struct{}),
+		frameCh:   make(chan *OutputFrame, 1),
+		keepAlive: keepAlive,
+		breaker:   newOutputBreaker(typeName),
+	}
+	handler.loopWg.Add(1)
+	go handler.loop()
+	return handler
+}
+
+func (h *MQTTOutputHandler) GetType() string {
+	return h.typeName
+}
+
+func (h *MQTTOutputHandler) OutputFrame(frame *OutputFrame) error {
+	if frame == nil {
+		return nil
+	}
+	enqueueLatestHTTPPushFrame(h.frameCh, frame)
+	return nil
+}
+
+func (h *MQTTOutputHandler) Close() error {
+	h.stopOnce.Do(func() {
+		close(h.stopCh)
+		h.loopWg.Wait()
+		if h.conn != nil {
+			_ = h.conn.SetWriteDeadline(time.Now().Add(time.Duration(h.cfg.TimeoutMS) * time.Millisecond))
+			_, _ = h.conn.Write([]byte{mqttPacketDisconnect, 0})
+			h.closeConn()
+		}
+	})
+	return nil
+}
+
+func (h *MQTTOutputHandler) loop() {
+	defer h.loopWg.Done()
+	for {
+		select {
+		case <-h.stopCh:
+			// A frame queued right before Close, such as the shutdown
+			// screen, is still published.
+			h.publishQueued()
+			return
+		case frame := <-h.frameCh:
+			h.publish(frame)
+			if !h.waitInterval() {
+				return
+			}
+		}
+	}
+}
+
+// waitInterval holds the loop for min_interval_ms after a publish, so only
+// the newest frame goes out once the gap has passed. It reports false once
+// the handler is closing.
+func (h *MQTTOutputHandler) waitInterval() bool {
+	timer := time.NewTimer(time.Duration(h.cfg.MinIntervalMS) * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case <-h.stopCh:
+		h.publishQueued()
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (h *MQTTOutputHandler) publishQueued() {
+	select {
+	case frame := <-h.frameCh:
+		h.publish(frame)
+	default:
+	}
+}
+
+func (h *MQTTOutputHandler) publish(frame *OutputFrame) {
+	if frame == nil || !h.breaker.allow(time.Now()) {
+		return
+	}
+	err := h.send(frame)
+	recordOutputHealth(h.typeName, err)
+	if err != nil {
+		h.closeConn()
+		if h.breaker.failure(time.Now(), err) {
+			logWarnModule(TypeMQTT, "publish failed: %v", err)
+		}
+		return
+	}
+	h.breaker.success(time.Now())
+}
+
+func (h *MQTTOutputHandler) send(frame *OutputFrame) error {
+	png, err := frame.PNG()
+	if err != nil {
+		return err
+	}
+	var values []byte
+	if monitors := currentMonitorValues(); monitors != nil {
+		if values, err = json.Marshal(monitors); err != nil {
+			return err
+		}
+	}
+	if err := h.connect(); err != nil {
+		return err
+	}
+	if err := h.publishMessage(h.cfg.Topic, png); err != nil {
+		return err
+	}
+	if values != nil {
+		return h.publishMessage(h.cfg.ValuesTopic, values)
+	}
+	return nil
+}
+
+func (h *MQTTOutputHandler) connect() error {
+	// The broker drops a client silent for 1.5 keep-alive periods, so a
+	// connection idle that long, e.g. while the display was paused, is
+	// replaced rather than failing the next publish.
+	if h.conn != nil && time.Since(h.lastSent) < h.keepAlive {
+		return nil
+	}
+	h.closeConn()
+
+	address, useTLS, err := parseMQTTAddress(h.cfg.URL)
+	if err != nil {
+		return err
+	}
+	timeout := time.Duration(h.cfg.TimeoutMS) * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var conn net.Conn
+	if useTLS {
+		host, _, _ := net.SplitHostPort(address)
+		dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: timeout}, Config: &tls.Config{ServerName: host}}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		dialer := &net.Dialer{Timeout: timeout}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return err
+	}
+	h.conn = conn
+	h.reader = bufio.NewReader(conn)
+
+	if err := h.write(mqttConnectPacket(h.clientID, h.cfg.AuthUsername, h.cfg.AuthPassword, h.keepAlive)); err != nil {
+		h.closeConn()
+		return err
+	}
+	header, body, err := readMQTTPacket(h.reader)
+	if err != nil {
+		h.closeConn()
+		return err
+	}
+	if header != mqttPacketConnack || len(body) != 2 {
+		h.closeConn()
+		return fmt.Errorf("unexpected mqtt packet 0x%02x instead of CONNACK", header)
+	}
+	if err := mqttConnackError(body[1]); err != nil {
+		h.closeConn()
+		return err
+	}
+	logInfoModule(TypeMQTT, "Connected to %s as %s", address, h.clientID)
+	return nil
+}
+
+func (h *MQTTOutputHandler) publishMessage(topic string, payload []byte) error {
+	if len(topic)+len(payload)+4 > mqttMaxRemainingLength {
+		return fmt.Errorf("%s: payload of %d bytes is too large", topic, len(payload))
+	}
+	h.packetID++
+	if h.packetID == 0 {
+		h.packetID = 1
+	}
+	id := h.packetID
+	if err := h.write(mqttPublishPacket(topic, payload, h.cfg.QoS, h.cfg.Retain, id)); err != nil {
+		return err
+	}
+	switch h.cfg.QoS {
+	case 1:
+		return h.waitAck(mqttPacketPuback, id)
+	case 2:
+		if err := h.waitAck(mqttPacketPubrec, id); err != nil {
+			return err
+		}
+		if err := h.write(mqttPacket(mqttPacketPubrel, binary.BigEndian.AppendUint16(nil, id))); err != nil {
+			return err
+		}
+		return h.waitAck(mqttPacketPubcomp, id)
+	}
+	return nil
+}
+
+func (h *MQTTOutputHandler) write(packet []byte) error {
+	if err := h.conn.SetDeadline(time.Now().Add(time.Duration(h.cfg.TimeoutMS) * time.Millisecond)); err != nil {
+		return err
+	}
+	if _, err := h.conn.Write(packet); err != nil {
+		return err
+	}
+	h.lastSent = time.Now()
+	return nil
+}
+
+// waitAck reads until the acknowledgement of packet id arrives, skipping
+// anything else the broker sends, within the deadline of the last write.
+func (h *MQTTOutputHandler) waitAck(kind byte, id uint16) error {
+	for {
+		header, body, err := readMQTTPacket(h.reader)
+		if err != nil {
+			return err
+		}
+		if header&0xf0 == kind&0xf0 && len(body) >= 2 && binary.BigEndian.Uint16(body) == id {
+			return nil
+		}
+	}
+}
+
+func (h *MQTTOutputHandler) closeConn() {
+	if h.conn == nil {
+		return
+	}
+	_ = h.conn.Close()
+	h.conn = nil
+	h.reader = nil
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"image"
+	"net"
+	"testing"
+	"time"
+)
+
+type mqttTestMessage struct {
+	topic   string
+	payload []byte
+}
+
+// serveMQTTTestBroker accepts one client, checks its CONNECT, acknowledges
+// QoS 1 publishes and forwards them on messages until the client leaves.
+func serveMQTTTestBroker(t *testing.T, listener net.Listener, messages chan<- mqttTestMessage) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	header, body, err := readMQTTPacket(reader)
+	if err != nil || header != mqttPacketConnect {
+		t.Errorf("expected CONNECT, got 0x%02x %v", header, err)
+		return
+	}
+	// Protocol name, level, flags 0xc2 (user, password, clean session).
+	if !bytes.HasPrefix(body, []byte{0, 4, 'M', 'Q', 'T', 'T', 4, 0xc2}) {
+		t.Errorf("unexpected CONNECT header % x", body[:8])
+	}
+	if _, err := conn.Write([]byte{mqttPacketConnack, 2, 0, 0}); err != nil {
+		return
+	}
+	for {
+		header, body, err := readMQTTPacket(reader)
+		if err != nil || header == mqttPacketDisconnect {
+			close(messages)
+			return
+		}
+		if header&0xf0 != mqttPacketPublish || header&0x06 != 0x02 {
+			t.Errorf("expected a QoS 1 PUBLISH, got 0x%02x", header)
+			continue
+		}
+		topicLen := int(binary.BigEndian.Uint16(body))
+		topic := string(body[2 : 2+topicLen])
+		id := body[2+topicLen : 4+topicLen]
+		messages <- mqttTestMessage{topic: topic, payload: body[4+topicLen:]}
+		_, _ = conn.Write(append([]byte{mqttPacketPuback, 2}, id...))
+	}
+}
+
+func TestMQTTPublishesFrameAndValues(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	messages := make(chan mqttTestMessage, 4)
+	go serveMQTTTestBroker(t, listener, messages)
+
+	SetMonitorValuesSource(func() map[string]interface{} {
+		return map[string]interface{}{"go_native.cpu.usage": 12.5}
+	})
+	t.Cleanup(func() { SetMonitorValuesSource(nil) })
+
+	cfg, ok := normalizeSingleConfig(OutputConfig{
+		Type:         TypeMQTT,
+		URL:          listener.Addr().String(),
+		QoS:          1,
+		AuthUsername: "ha",
+		AuthPassword: "secret",
+	})
+	if !ok || cfg.Topic != defaultMQTTFrameTopic || cfg.MinIntervalMS != defaultMQTTIntervalMS {
+		t.Fatalf("unexpected normalized config %+v", cfg)
+	}
+	handler := NewMQTTOutputHandler(cfg, TypeMQTT)
+	if err := handler.OutputFrame(NewOutputFrame(image.NewRGBA(image.Rect(0, 0, 4, 2)))); err != nil {
+		t.Fatalf("output frame: %v", err)
+	}
+
+	var received []mqttTestMessage
+	timeout := time.After(5 * time.Second)
+	for len(received) < 2 {
+		select {
+		case msg := <-messages:
+			received = append(received, msg)
+		case <-timeout:
+			t.Fatalf("expected two publishes, got %d", len(received))
+		}
+	}
+	if received[0].topic != defaultMQTTFrameTopic || !bytes.HasPrefix(received[0].payload, []byte("\x89PNG")) {
+		t.Fatalf("expected a PNG on %s, got %q", defaultMQTTFrameTopic, received[0].topic)
+	}
+	var values map[string]float64
+	if received[1].topic != defaultMQTTValuesTopic || json.Unmarshal(received[1].payload, &values) != nil || values["go_native.cpu.usage"] != 12.5 {
+		t.Fatalf("unexpected values message %q %s", received[1].topic, received[1].payload)
+	}
+
+	if err := handler.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	select {
+	case _, open := <-messages:
+		if open {
+			t.Fatal("expected no further publishes")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected DISCONNECT on close")
+	}
+}
+
+func TestParseMQTTAddress(t *testing.T) {
+	cases := []struct {
+		url     string
+		address string
+		tls     bool
+	}{
+		{"homeassistant.local", "homeassistant.local:1883", false},
+		{"mqtt://10.0.0.2:1884", "10.0.0.2:1884", false},
+		{"mqtts://broker.example.com", "broker.example.com:8883", true},
+	}
+	for _, tc := range cases {
+		address, useTLS, err := parseMQTTAddress(tc.url)
+		if err != nil || address != tc.address || useTLS != tc.tls {
+			t.Fatalf("parseMQTTAddress(%q)=%q,%v,%v", tc.url, address, useTLS, err)
+		}
+	}
+	if _, _, err := parseMQTTAddress("http://broker"); err == nil {
+		t.Fatal("expected an unsupported scheme to be rejected")
+	}
+}
+
+func TestMQTTPacketLengthEncoding(t *testing.T) {
+	packet := mqttPacket(mqttPacketPublish, make([]byte, 321))
+	if !bytes.Equal(packet[:3], []byte{mqttPacketPublish, 0xc1, 0x02}) {
+		t.Fatalf("expected remaining length 321 as c1 02, got % x", packet[:3])
+	}
+	header, body, err := readMQTTPacket(bufio.NewReader(bytes.NewReader(packet)))
+	if err != nil || header != mqttPacketPublish || len(body) != 321 {
+		t.Fatalf("round trip failed: 0x%02x %d %v", header, len(body), err)
+	}
+}
@@ -13,6 +13,8 @@ type OutputHandlerRuntimeStats struct {
 	LastMS int64  `json:"last_ms"`
 	MaxMS  int64  `json:"max_ms"`
 	AvgMS  int64  `json:"avg_ms"`
+	// Unchanged counts frames held back as identical to the last one.
+	Unchanged int64 `json:"unchanged,omitempty"`
 }
 
 type OutputRuntimeStats struct {
@@ -53,11 +55,12 @@ type TCPPushAvailabilityStats struct {
 }
 
 type outputRuntimeAccumulator struct {
-	calls   int64
-	errors  int64
-	lastNS  int64
-	maxNS   int64
-	totalNS int64
+	calls     int64
+	errors    int64
+	lastNS    int64
+	maxNS     int64
+	totalNS   int64
+	unchanged int64
 }
 
 var (
@@ -106,6 +109,19 @@ func recordOutputRuntime(typeName string, duration time.Duration, err error) {
 	}
 }
 
+func recordOutputUnchanged(typeName string) {
+	typeName = normalizeTypeName(typeName)
+
+	outputRuntimeMu.Lock()
+	defer outputRuntimeMu.Unlock()
+	entry := outputRuntimeByType[typeName]
+	if entry == nil {
+		entry = &outputRuntimeAccumulator{}
+		outputRuntimeByType[typeName] = entry
+	}
+	entry.unchanged++
+}
+
 func normalizeTypeName(typeName string) string {
 	switch typeName {
 	case "":
@@ -151,6 +167,8 @@ func GetRuntimeStats() OutputRuntimeStats {
 			LastMS: toMillis(entry.lastNS),
 			MaxMS:  toMillis(entry.maxNS),
 			AvgMS:  avgMillis(entry.totalNS, entry.calls),
+
+			Unchanged: entry.unchanged,
 		}
 	}
 
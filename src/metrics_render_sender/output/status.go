@@ -0,0 +1,26 @@
+package output
+
+import "sync"
+
+var (
+	statusHookMu sync.RWMutex
+	statusHook   func(source string, err error)
+)
+
+// SetStatusHook registers fn to receive output health changes: a non-nil
+// error when a handler loses its device or gets disabled, nil once it works
+// again. fn must not block.
+func SetStatusHook(fn func(source string, err error)) {
+	statusHookMu.Lock()
+	statusHook = fn
+	statusHookMu.Unlock()
+}
+
+func reportStatus(source string, err error) {
+	statusHookMu.RLock()
+	fn := statusHook
+	statusHookMu.RUnlock()
+	if fn != nil {
+		fn(source, err)
+	}
+}
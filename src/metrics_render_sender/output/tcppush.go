@@ -58,6 +58,7 @@ type TCPPushOutputHandler struct {
 
 	lastErrorMu sync.Mutex
 	lastErrorAt time.Time
+	breaker     *outputBreaker
 
 	ackLogMu          sync.Mutex
 	lastAckStatusCode int
@@ -129,6 +130,7 @@ func NewTCPPushOutputHandler(cfg OutputConfig, typeName string) *TCPPushOutputHa
 		typeName: typeName,
 		stopCh:   make(chan struct{}),
 		frameCh:  make(chan *OutputFrame, 1),
+		breaker:  newOutputBreaker(typeName),
 	}
 	handler.loopWg.Add(1)
 	go handler.loop()
@@ -156,6 +158,12 @@ func (h *TCPPushOutputHandler) Close() error {
 	return nil
 }
 
+// Reset drops the connection, which the peer has usually timed out while
+// the system was suspended. The next frame dials again.
+func (h *TCPPushOutputHandler) Reset() {
+	h.closeConnWithReason("system resumed")
+}
+
 func (h *TCPPushOutputHandler) PingStatus() (string, error) {
 	response, err := h.doControlRequest(tcpPushOpcodePingStatus)
 	if err != nil {
@@ -183,6 +191,13 @@ func (h *TCPPushOutputHandler) loop() {
 	for {
 		select {
 		case <-h.stopCh:
+			// A frame queued right before Close, such as the shutdown
+			// screen, is still sent.
+			select {
+			case frame := <-h.frameCh:
+				h.push(frame)
+			default:
+			}
 			return
 		case frame := <-h.frameCh:
 			h.push(frame)
@@ -191,20 +206,26 @@ func (h *TCPPushOutputHandler) loop() {
 }
 
 func (h *TCPPushOutputHandler) push(frame *OutputFrame) {
-	if frame == nil {
+	if frame == nil || !h.breaker.allow(time.Now()) {
 		return
 	}
 
 	startedAt := time.Now()
 	err := h.doRequestFromFrame(frame)
 	recordTCPPushRuntime(h.typeName, time.Since(startedAt), err)
+	recordOutputHealth(h.typeName, err)
 	if err != nil {
+		if !h.breaker.failure(time.Now(), err) {
+			return
+		}
 		if isTimeoutError(err) {
 			h.logError("push timeout: %v", err)
 			return
 		}
 		h.logError("push failed: %v", err)
+		return
 	}
+	h.breaker.success(time.Now())
 }
 
 func (h *TCPPushOutputHandler) doRequestFromFrame(frame *OutputFrame) error {
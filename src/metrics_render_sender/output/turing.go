@@ -0,0 +1,336 @@
+package output
+
+import (
+	"errors"
+	"image"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Turing Smart Screen 3.5" (revision A) and the look-alike USB LCDs sold as
+// "UsbMonitor" enumerate as a USB serial port and take 6-byte commands
+// followed by raw RGB565 pixels.
+const (
+	defaultTuringBaud       = 115200
+	defaultTuringWidth      = 320
+	defaultTuringHeight     = 480
+	defaultTuringBrightness = 100
+	// turingMaxSize is the largest coordinate the 10-bit command fields
+	// can address.
+	turingMaxSize = 1024
+
+	TuringOrientationPortrait         = "portrait"
+	TuringOrientationReversePortrait  = "reverse_portrait"
+	TuringOrientationLandscape        = "landscape"
+	TuringOrientationReverseLandscape = "reverse_landscape"
+
+	turingCmdScreenOff     = 108
+	turingCmdScreenOn      = 109
+	turingCmdSetBrightness = 110
+	turingCmdSetOrient     = 121
+	turingCmdDisplayBitmap = 197
+)
+
+var (
+	serialPortOpenerMu sync.RWMutex
+	serialPortOpener   func(path string, baud int) (io.ReadWriteCloser, error)
+)
+
+// SetSerialPortOpener registers how serial ports are opened, so the turing
+// output shares the serial code of the custom serial collector.
+func SetSerialPortOpener(fn func(path string, baud int) (io.ReadWriteCloser, error)) {
+	serialPortOpenerMu.Lock()
+	serialPortOpener = fn
+	serialPortOpenerMu.Unlock()
+}
+
+func openSerialPort(path string, baud int) (io.ReadWriteCloser, error) {
+	serialPortOpenerMu.RLock()
+	fn := serialPortOpener
+	serialPortOpenerMu.RUnlock()
+	if fn == nil {
+		return nil, errors.New("serial ports are not available")
+	}
+	return fn(path, baud)
+}
+
+func normalizeTuringOrientation(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case TuringOrientationPortrait:
+		return TuringOrientationPortrait
+	case TuringOrientationReversePortrait:
+		return TuringOrientationReversePortrait
+	case TuringOrientationReverseLandscape:
+		return TuringOrientationReverseLandscape
+	default:
+		return TuringOrientationLandscape
+	}
+}
+
+func normalizeTuringConfig(cfg *OutputConfig, raw OutputConfig) {
+	cfg.Device = strings.TrimSpace(raw.Device)
+	if cfg.Device == "" {
+		cfg.Device = "/dev/ttyACM0"
+		if runtime.GOOS == "windows" {
+			cfg.Device = "COM3"
+		}
+	}
+	// Width and height are the native portrait size of the panel.
+	cfg.Width = raw.Width
+	if cfg.Width <= 0 || cfg.Width > turingMaxSize {
+		cfg.Width = defaultTuringWidth
+	}
+	cfg.Height = raw.Height
+	if cfg.Height <= 0 || cfg.Height > turingMaxSize {
+		cfg.Height = defaultTuringHeight
+	}
+	cfg.Baud = raw.Baud
+	if cfg.Baud <= 0 {
+		cfg.Baud = defaultTuringBaud
+	}
+	cfg.Brightness = raw.Brightness
+	if cfg.Brightness <= 0 || cfg.Brightness > 100 {
+		cfg.Brightness = defaultTuringBrightness
+	}
+	cfg.Orientation = normalizeTuringOrientation(raw.Orientation)
+	cfg.Fit = AX206FitContain
+	if raw.Fit != "" {
+		cfg.Fit = normalizeAX206FitMode(raw.Fit)
+	}
+	cfg.SkipUnchanged = cloneEnabledValue(raw.SkipUnchanged == nil || *raw.SkipUnchanged)
+}
+
+// turingFrameSize is the size frames are drawn at in the configured
+// orientation.
+func turingFrameSize(cfg OutputConfig) (int, int) {
+	switch cfg.Orientation {
+	case TuringOrientationLandscape, TuringOrientationReverseLandscape:
+		return cfg.Height, cfg.Width
+	default:
+		return cfg.Width, cfg.Height
+	}
+}
+
+func turingOrientationCode(orientation string) byte {
+	switch orientation {
+	case TuringOrientationReversePortrait:
+		return 1
+	case TuringOrientationLandscape:
+		return 2
+	case TuringOrientationReverseLandscape:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// turingCommand packs a rectangle and a command byte the way the panel
+// firmware expects: four 10-bit coordinates followed by the command.
+func turingCommand(cmd byte, x, y, ex, ey int) []byte {
+	return []byte{
+		byte(x >> 2),
+		byte((x&3)<<6 + y>>4),
+		byte((y&15)<<4 + ex>>6),
+		byte((ex&63)<<2 + ey>>8),
+		byte(ey),
+		cmd,
+	}
+}
+
+// turingBrightnessLevel maps a 0-100 % brightness to the panel scale, where
+// 0 is the brightest and 255 the darkest.
+func turingBrightnessLevel(percent int) int {
+	return 255 - percent*255/100
+}
+
+// turingChangedRegion returns the bounding box of the pixels that differ
+// between two RGB565 buffers of the given width, or false when none do.
+func turingChangedRegion(prev, next []byte, width int) (image.Rectangle, bool) {
+	if len(prev) != len(next) {
+		return image.Rect(0, 0, width, len(next)/2/width), true
+	}
+	region := image.Rectangle{}
+	found := false
+	rowBytes := width * 2
+	for offset := 0; offset+1 < len(next); offset += 2 {
+		if prev[offset] == next[offset] && prev[offset+1] == next[offset+1] {
+			continue
+		}
+		x := (offset % rowBytes) / 2
+		y := offset / rowBytes
+		pixel := image.Rect(x, y, x+1, y+1)
+		if !found {
+			region, found = pixel, true
+		} else {
+			region = region.Union(pixel)
+		}
+	}
+	return region, found
+}
+
+// packRGB565LE converts img to the little-endian RGB565 the panel reads,
+// leaving areas outside img black.
+func packRGB565LE(buf []byte, img image.Image, width, height int) []byte {
+	buf = packRGB565BE(buf, img, width, height)
+	for offset := 0; offset+1 < len(buf); offset += 2 {
+		buf[offset], buf[offset+1] = buf[offset+1], buf[offset]
+	}
+	return buf
+}
+
+// TuringOutputHandler sends frames to a Turing Smart Screen style USB serial
+// LCD, scaled to it by the fit mode. Only the rectangle that changed since
+// the previous frame is transferred, which keeps the slow serial link
+// usable. The port is opened with the first frame and reopened after a
+// failed write.
+type TuringOutputHandler struct {
+	typeName string
+	cfg      OutputConfig
+	open     func(path string, baud int) (io.ReadWriteCloser, error)
+	resetCh  chan struct{}
+
+	port       io.ReadWriteCloser
+	scaled     *image.RGBA
+	buf        []byte
+	prev       []byte
+	brightness int
+	screenOff  bool
+}
+
+func NewTuringOutputHandler(cfg OutputConfig, typeName string) *TuringOutputHandler {
+	return &TuringOutputHandler{typeName: typeName, cfg: cfg, open: openSerialPort, resetCh: make(chan struct{}, 1)}
+}
+
+func (h *TuringOutputHandler) GetType() string {
+	return h.typeName
+}
+
+func (h *TuringOutputHandler) OutputFrame(frame *OutputFrame) error {
+	if frame == nil || frame.Image == nil {
+		return nil
+	}
+	select {
+	case <-h.resetCh:
+		_ = h.Close()
+	default:
+	}
+	err := h.draw(frame.Image)
+	if err != nil && h.port != nil {
+		_ = h.port.Close()
+		h.port = nil
+	}
+	recordOutputHealth(h.typeName, err)
+	return err
+}
+
+func (h *TuringOutputHandler) draw(src image.Image) error {
+	width, height := turingFrameSize(h.cfg)
+	if h.port == nil {
+		port, err := h.open(h.cfg.Device, h.cfg.Baud)
+		if err != nil {
+			return err
+		}
+		logInfoModule(h.cfg.Type, "Connected %s %dx%d %s", h.cfg.Device, width, height, h.cfg.Orientation)
+		h.port = port
+		h.prev = nil
+		h.brightness = -1
+		h.screenOff = false
+		if err := h.setOrientation(); err != nil {
+			return err
+		}
+	}
+	if err := h.syncBrightness(); err != nil {
+		return err
+	}
+
+	var img image.Image
+	img, h.scaled = fitAX206Image(h.scaled, src, width, height, h.cfg.Fit)
+	h.buf = packRGB565LE(h.buf, img, width, height)
+	region, changed := turingChangedRegion(h.prev, h.buf, width)
+	if !changed {
+		return nil
+	}
+	if err := h.writeRegion(region, width); err != nil {
+		return err
+	}
+	h.prev, h.buf = h.buf, h.prev
+	return nil
+}
+
+func (h *TuringOutputHandler) setOrientation() error {
+	width, height := turingFrameSize(h.cfg)
+	cmd := make([]byte, 16)
+	copy(cmd, turingCommand(turingCmdSetOrient, 0, 0, 0, 0))
+	cmd[6] = turingOrientationCode(h.cfg.Orientation) + 100
+	cmd[7] = byte(width >> 8)
+	cmd[8] = byte(width)
+	cmd[9] = byte(height >> 8)
+	cmd[10] = byte(height)
+	_, err := h.port.Write(cmd)
+	return err
+}
+
+// syncBrightness applies the configured brightness scaled by the shared
+// backlight level, so display control, quiet hours and the shutdown blank
+// dim this panel like an AX206 frame. Level 0 turns the screen off.
+func (h *TuringOutputHandler) syncBrightness() error {
+	percent := h.cfg.Brightness * AX206Brightness() / MaxAX206Brightness
+	if percent == h.brightness {
+		return nil
+	}
+	if percent == 0 {
+		if _, err := h.port.Write(turingCommand(turingCmdScreenOff, 0, 0, 0, 0)); err != nil {
+			return err
+		}
+		h.screenOff = true
+		h.brightness = percent
+		return nil
+	}
+	if h.screenOff {
+		if _, err := h.port.Write(turingCommand(turingCmdScreenOn, 0, 0, 0, 0)); err != nil {
+			return err
+		}
+		h.screenOff = false
+	}
+	if _, err := h.port.Write(turingCommand(turingCmdSetBrightness, turingBrightnessLevel(percent), 0, 0, 0)); err != nil {
+		return err
+	}
+	h.brightness = percent
+	return nil
+}
+
+func (h *TuringOutputHandler) writeRegion(region image.Rectangle, width int) error {
+	cmd := turingCommand(turingCmdDisplayBitmap, region.Min.X, region.Min.Y, region.Max.X-1, region.Max.Y-1)
+	if _, err := h.port.Write(cmd); err != nil {
+		return err
+	}
+	rowBytes := region.Dx() * 2
+	pixels := make([]byte, 0, rowBytes*region.Dy())
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		start := (y*width + region.Min.X) * 2
+		pixels = append(pixels, h.buf[start:start+rowBytes]...)
+	}
+	_, err := h.port.Write(pixels)
+	return err
+}
+
+// Reset reopens the port after a resume, which may have reset the panel
+// to its boot orientation and brightness. The port is closed by the next
+// OutputFrame, since the brightness and bitmap writes run there.
+func (h *TuringOutputHandler) Reset() {
+	select {
+	case h.resetCh <- struct{}{}:
+	default:
+	}
+}
+
+func (h *TuringOutputHandler) Close() error {
+	if h.port == nil {
+		return nil
+	}
+	err := h.port.Close()
+	h.port = nil
+	return err
+}
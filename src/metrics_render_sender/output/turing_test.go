@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"io"
+	"testing"
+)
+
+type fakeSerialPort struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (p *fakeSerialPort) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestTuringCommandPacksCoordinates(t *testing.T) {
+	got := turingCommand(turingCmdDisplayBitmap, 0, 0, 479, 319)
+	want := []byte{0x00, 0x00, 0x07, 0x7d, 0x3f, turingCmdDisplayBitmap}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("expected % x, got % x", want, got)
+	}
+}
+
+func TestTuringChangedRegion(t *testing.T) {
+	prev := make([]byte, 4*3*2)
+	next := append([]byte(nil), prev...)
+	if _, changed := turingChangedRegion(prev, next, 4); changed {
+		t.Fatal("expected identical buffers to report no change")
+	}
+	next[(1*4+1)*2] = 1
+	next[(2*4+2)*2+1] = 1
+	region, changed := turingChangedRegion(prev, next, 4)
+	if !changed || region != image.Rect(1, 1, 3, 3) {
+		t.Fatalf("expected region (1,1)-(3,3), got %v %v", region, changed)
+	}
+	if region, _ := turingChangedRegion(nil, next, 4); region != image.Rect(0, 0, 4, 3) {
+		t.Fatalf("expected a full frame without a previous one, got %v", region)
+	}
+}
+
+func TestTuringHandlerSendsChangedRegion(t *testing.T) {
+	cfg, ok := normalizeSingleConfig(OutputConfig{Type: TypeTuring, Width: 4, Height: 6})
+	if !ok || cfg.Orientation != TuringOrientationLandscape || cfg.Baud != defaultTuringBaud {
+		t.Fatalf("unexpected normalized config %+v", cfg)
+	}
+	port := &fakeSerialPort{}
+	handler := NewTuringOutputHandler(cfg, TypeTuring)
+	handler.open = func(path string, baud int) (io.ReadWriteCloser, error) {
+		return port, nil
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 6, 4))
+	if err := handler.OutputFrame(&OutputFrame{Image: img}); err != nil {
+		t.Fatalf("first frame: %v", err)
+	}
+	// Orientation, brightness, then a full 6x4 landscape bitmap.
+	if port.Len() != 16+6+6+6*4*2 {
+		t.Fatalf("unexpected first frame size %d", port.Len())
+	}
+	if sent := port.Bytes(); sent[6] != 102 || sent[16+5] != turingCmdSetBrightness || sent[16+6+5] != turingCmdDisplayBitmap {
+		t.Fatalf("unexpected command sequence % x", sent[:28])
+	}
+
+	port.Reset()
+	img.Set(2, 1, color.RGBA{R: 255, A: 255})
+	if err := handler.OutputFrame(&OutputFrame{Image: img}); err != nil {
+		t.Fatalf("second frame: %v", err)
+	}
+	sent := port.Bytes()
+	if len(sent) != 6+2 || !bytes.Equal(sent[:6], turingCommand(turingCmdDisplayBitmap, 2, 1, 2, 1)) {
+		t.Fatalf("expected a single pixel update, got % x", sent)
+	}
+	if sent[6] != 0x00 || sent[7] != 0xf8 {
+		t.Fatalf("expected red as f800 little-endian, got %02x%02x", sent[6], sent[7])
+	}
+
+	// After a resume the panel gets its orientation and a full frame again.
+	handler.Reset()
+	port.Reset()
+	if err := handler.OutputFrame(&OutputFrame{Image: img}); err != nil {
+		t.Fatalf("frame after reset: %v", err)
+	}
+	if port.Len() != 16+6+6+6*4*2 {
+		t.Fatalf("expected a full refresh after Reset, got %d bytes", port.Len())
+	}
+
+	if err := handler.Close(); err != nil || !port.closed {
+		t.Fatalf("expected the port to be closed, err %v", err)
+	}
+}
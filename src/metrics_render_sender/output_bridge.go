@@ -2,6 +2,7 @@ package main
 
 import (
 	"image"
+	"io"
 	"metrics_render_sender/output"
 )
 
@@ -16,6 +17,8 @@ type OutputRuntimeStats = output.OutputRuntimeStats
 type OutputHandlerRuntimeStats = output.OutputHandlerRuntimeStats
 type AX206DeviceFrameRuntimeStats = output.AX206DeviceFrameRuntimeStats
 type TCPPushAvailabilityStats = output.TCPPushAvailabilityStats
+type OutputHealth = output.OutputHealth
+type OutputPin = output.OutputPin
 
 func NewOutputManager() *OutputManager {
 	return output.NewOutputManager()
@@ -60,3 +63,31 @@ func GetTCPPushRuntimeStats() map[string]OutputHandlerRuntimeStats {
 func GetTCPPushAvailabilityStats() map[string]TCPPushAvailabilityStats {
 	return output.GetTCPPushAvailabilityStats()
 }
+
+func SetAX206Brightness(level int) {
+	output.SetAX206Brightness(level)
+}
+
+func AX206Brightness() int {
+	return output.AX206Brightness()
+}
+
+func SetAX206PanelListener(fn func(width, height int)) {
+	output.SetAX206PanelListener(fn)
+}
+
+func SetOutputStatusHook(fn func(source string, err error)) {
+	output.SetStatusHook(fn)
+}
+
+func SetOutputPinOpener(fn func(chip string, line int) (OutputPin, error)) {
+	output.SetOutputPinOpener(fn)
+}
+
+func SetSerialPortOpener(fn func(path string, baud int) (io.ReadWriteCloser, error)) {
+	output.SetSerialPortOpener(fn)
+}
+
+func SetMonitorValuesSource(fn func() map[string]interface{}) {
+	output.SetMonitorValuesSource(fn)
+}
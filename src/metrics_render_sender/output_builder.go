@@ -5,10 +5,17 @@ import (
 )
 
 const (
-	outputTypeMemImg   = output.TypeMemImg
-	outputTypeAX206USB = output.TypeAX206USB
-	outputTypeHTTPPush = output.TypeHTTPPush
-	outputTypeTCPPush  = output.TypeTCPPush
+	outputTypeMemImg      = output.TypeMemImg
+	outputTypeAX206USB    = output.TypeAX206USB
+	outputTypeHTTPPush    = output.TypeHTTPPush
+	outputTypeTCPPush     = output.TypeTCPPush
+	outputTypeFile        = output.TypeFile
+	outputTypeHTTP        = output.TypeHTTP
+	outputTypeSSD1306     = output.TypeSSD1306
+	outputTypeST7789      = output.TypeST7789
+	outputTypeTuring      = output.TypeTuring
+	outputTypeFramebuffer = output.TypeFramebuffer
+	outputTypeMQTT        = output.TypeMQTT
 )
 
 var supportedOutputTypes = []string{
@@ -16,6 +23,13 @@ var supportedOutputTypes = []string{
 	outputTypeAX206USB,
 	outputTypeHTTPPush,
 	outputTypeTCPPush,
+	outputTypeFile,
+	outputTypeHTTP,
+	outputTypeSSD1306,
+	outputTypeST7789,
+	outputTypeTuring,
+	outputTypeFramebuffer,
+	outputTypeMQTT,
 }
 
 func getSupportedOutputTypes() []string {
@@ -0,0 +1,47 @@
+package main
+
+// watchPanelResolution lets a connecting AX206 panel pick the profile that
+// matches its size.
+func watchPanelResolution() {
+	SetAX206PanelListener(func(width, height int) {
+		// Switching profiles rebuilds the outputs, which waits for the
+		// connection goroutine this is called from.
+		go selectProfileForPanel(width, height)
+	})
+}
+
+// selectProfileForPanel switches to a profile laid out for the connected
+// panel when auto_resolution is on and the running config targets another
+// size. Without a matching profile the layout is kept and the AX206 output's
+// fit mode decides how frames are scaled.
+func selectProfileForPanel(width, height int) {
+	runtime := CurrentSharedWebAPI()
+	if runtime == nil {
+		return
+	}
+	cfg := runtime.CurrentConfig()
+	if cfg == nil || !cfg.AutoResolution || (cfg.Width == width && cfg.Height == height) {
+		return
+	}
+
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		logWarnModule("profile", "Resolution auto-select failed: %v", err)
+		return
+	}
+	profiles, err := GetProfileManagerWithPath(configPath)
+	if err != nil {
+		logWarnModule("profile", "Resolution auto-select failed: %v", err)
+		return
+	}
+	name, ok := profiles.FindByResolution(width, height)
+	if !ok {
+		logInfoModule("profile", "Panel is %dx%d but no profile matches, keeping %dx%d layout", width, height, cfg.Width, cfg.Height)
+		return
+	}
+	if err := switchActiveProfile(name); err != nil {
+		logWarnModule("profile", "Switch to profile %s for %dx%d panel failed: %v", name, width, height, err)
+		return
+	}
+	logInfoModule("profile", "Panel is %dx%d, switched to profile %s", width, height, name)
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyPauseSignal delivers SIGUSR1, which toggles the display pause.
+func notifyPauseSignal(ch chan<- os.Signal) {
+	signal.Notify(ch, syscall.SIGUSR1)
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// notifyPauseSignal is a no-op: Windows has no SIGUSR1, use the control API.
+func notifyPauseSignal(ch chan<- os.Signal) {}
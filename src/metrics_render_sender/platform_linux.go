@@ -0,0 +1,57 @@
+//go:build linux
+
+package main
+
+import gopsutilNet "github.com/shirou/gopsutil/v3/net"
+
+type linuxPlatform struct {
+	gopsutilPlatform
+}
+
+func newPlatformProvider() PlatformProvider {
+	return linuxPlatform{}
+}
+
+// CPUTemperatureSensors reads the labeled channels of the CPU hwmon chips,
+// which gopsutil only exposes as keys mixed with every other sensor.
+func (p linuxPlatform) CPUTemperatureSensors() []cpuTempSensor {
+	if found := readLinuxCPUTemperatureSensors(); len(found) > 0 {
+		return found
+	}
+	return p.gopsutilPlatform.CPUTemperatureSensors()
+}
+
+// DetectDisks prefers sysfs, which knows about whole disks and their
+// models, and falls back to gopsutil partitions.
+func (p linuxPlatform) DetectDisks() []*DiskInfo {
+	disks, err := detectDiskInfoBySysfs()
+	if err == nil {
+		return disks
+	}
+	logWarnModule("disk", "sysfs disk detection failed, fallback to gopsutil: %v", err)
+	return p.gopsutilPlatform.DetectDisks()
+}
+
+func (linuxPlatform) DiskCounters() (map[string]diskCounterSample, error) {
+	data, err := sensors.ReadFile("/proc/diskstats")
+	if err != nil {
+		return nil, err
+	}
+	return parseProcDiskstats(data), nil
+}
+
+func (linuxPlatform) DefaultNetworkInterface() string {
+	data, err := sensors.ReadFile("/proc/net/route")
+	if err != nil {
+		return ""
+	}
+	return parseProcNetRouteDefault(data)
+}
+
+func (linuxPlatform) NetworkCounters() ([]gopsutilNet.IOCountersStat, error) {
+	data, err := sensors.ReadFile("/proc/net/dev")
+	if err != nil {
+		return nil, err
+	}
+	return parseProcNetDev(data), nil
+}
@@ -1,6 +1,14 @@
 package main
 
-import "metrics_render_sender/platformops"
+import (
+	"flag"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"metrics_render_sender/platformops"
+)
 
 type ServiceInstallOptions = platformops.ServiceInstallOptions
 
@@ -9,10 +17,16 @@ func InstallService(options ServiceInstallOptions) error {
 	if err != nil {
 		return err
 	}
-	if result.UserMode {
+	switch {
+	case runtime.GOOS == "windows":
+		logInfo("Installed Windows service %s for %s", "metrics_render_sender", result.ServicePath)
+		if !result.Started {
+			logInfo("Start it with: sc start %s", "metrics_render_sender")
+		}
+	case result.UserMode:
 		logInfo("Installed user service: %s", result.ServicePath)
 		logInfo("Check status: systemctl --user status %s", "metrics_render_sender")
-	} else {
+	default:
 		logInfo("Installed system service: %s", result.ServicePath)
 		logInfo("Check status: systemctl status %s", "metrics_render_sender")
 	}
@@ -27,6 +41,52 @@ func UninstallService() error {
 	return nil
 }
 
+// runServiceCommand handles install-service [-no-autostart] [-restart
+// always|on-failure|no] [-restart-delay 5s] and uninstall-service. The
+// service runs headless, since neither a Windows service in session 0 nor
+// a systemd unit has a desktop for the tray, and gets the installing user's
+// config file and port.
+func runServiceCommand(command string, args []string, configLocation string, port int) error {
+	if command == "uninstall-service" {
+		return UninstallService()
+	}
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	noAutoStart := flags.Bool("no-autostart", false, "Register the service without starting it now or at boot")
+	restart := flags.String("restart", platformops.ServiceRestartAlways, "Restart policy: always, on-failure or no")
+	restartDelay := flags.Duration("restart-delay", 5*time.Second, "How long to wait before a restart")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	configArg := configLocation
+	if !isRemoteConfigLocation(configLocation) {
+		if err := resolveConfigLocation(configLocation, 0); err != nil {
+			return err
+		}
+		configPath, err := getUserConfigPath()
+		if err != nil {
+			return err
+		}
+		configArg = configPath
+	}
+	return InstallService(ServiceInstallOptions{
+		AutoStart:    !*noAutoStart,
+		Restart:      *restart,
+		RestartDelay: *restartDelay,
+		Args:         []string{"--headless", "--config", configArg, "--port", strconv.Itoa(port)},
+	})
+}
+
+// startServiceControl lets a Windows service stop like Ctrl+C; the returned
+// function reports the shutdown to the service manager.
+func startServiceControl(stop chan<- os.Signal) func() {
+	finished, err := platformops.StartServiceControl(stop)
+	if err != nil {
+		logWarnModule("service", "Service detection failed: %v", err)
+	}
+	return finished
+}
+
 func InstallAX206UdevRule() error {
 	result, err := platformops.InstallAX206UdevRule()
 	if err != nil {
@@ -0,0 +1,7 @@
+//go:build !linux && !windows
+
+package main
+
+func newPlatformProvider() PlatformProvider {
+	return gopsutilPlatform{}
+}
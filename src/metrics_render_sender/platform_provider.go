@@ -0,0 +1,100 @@
+package main
+
+import (
+	gopsutilDisk "github.com/shirou/gopsutil/v3/disk"
+	gopsutilNet "github.com/shirou/gopsutil/v3/net"
+)
+
+// PlatformProvider is everything the native collectors read from the
+// operating system. Each OS has one implementation selected by build tags;
+// tests swap currentPlatform for a fake.
+//
+// GPU and fan readings have no native path and come from the CoolerControl
+// and LibreHardwareMonitor collectors instead.
+type PlatformProvider interface {
+	// HasCPUTemperature reports whether CPUTemperature can ever succeed, so
+	// the CPU collector does not register an item that is always empty.
+	HasCPUTemperature() bool
+	CPUTemperature() (float64, bool)
+	// CPUTemperatureSensors lists every CPU temperature channel by label,
+	// e.g. Tctl and Tccd1 on AMD or Package id 0 and Core 0 on Intel.
+	CPUTemperatureSensors() []cpuTempSensor
+	CPUFrequency() (current, maxFreq float64, ok bool)
+
+	DetectDisks() []*DiskInfo
+	DiskCounters() (map[string]diskCounterSample, error)
+	DiskTemperatures(deviceNames []string) map[string]diskTemperatureSnapshot
+
+	// NetworkInterfaces lists active physical interfaces, sorted, with the
+	// preferred IPv4 and IPv6 address of each.
+	NetworkInterfaces() ([]string, map[string]networkAddresses)
+	// DefaultNetworkInterface is the interface carrying the default route,
+	// or "" when the OS does not say.
+	DefaultNetworkInterface() string
+	NetworkCounters() ([]gopsutilNet.IOCountersStat, error)
+}
+
+var currentPlatform PlatformProvider = newPlatformProvider()
+
+// gopsutilPlatform is the portable implementation the per-OS providers
+// embed and override where the OS has something better.
+type gopsutilPlatform struct{}
+
+func (gopsutilPlatform) HasCPUTemperature() bool {
+	return true
+}
+
+func (gopsutilPlatform) CPUTemperature() (float64, bool) {
+	temp := getTemperatureByKeywords([]string{"cpu", "package", "core", "tctl", "ccd"})
+	return temp, temp > 0
+}
+
+func (gopsutilPlatform) CPUTemperatureSensors() []cpuTempSensor {
+	return getTemperatureSensorsByKeywords([]string{"cpu", "package", "core", "tctl", "ccd"})
+}
+
+func (gopsutilPlatform) CPUFrequency() (float64, float64, bool) {
+	return getCPUFrequencyByGopsutil()
+}
+
+func (gopsutilPlatform) DetectDisks() []*DiskInfo {
+	return detectDiskInfoByGopsutil()
+}
+
+func (gopsutilPlatform) DiskCounters() (map[string]diskCounterSample, error) {
+	stats, err := gopsutilDisk.IOCounters()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]diskCounterSample, len(stats))
+	for name, stat := range stats {
+		result[name] = diskCounterSample{
+			Name:        stat.Name,
+			ReadBytes:   stat.ReadBytes,
+			WriteBytes:  stat.WriteBytes,
+			ReadCount:   stat.ReadCount,
+			WriteCount:  stat.WriteCount,
+			ReadTimeMS:  float64(stat.ReadTime),
+			WriteTimeMS: float64(stat.WriteTime),
+			BusyTimeMS:  float64(stat.IoTime),
+			QueueDepth:  float64(stat.IopsInProgress),
+		}
+	}
+	return result, nil
+}
+
+func (gopsutilPlatform) DiskTemperatures(deviceNames []string) map[string]diskTemperatureSnapshot {
+	return sharedDiskTempService.Read(deviceNames)
+}
+
+func (gopsutilPlatform) NetworkInterfaces() ([]string, map[string]networkAddresses) {
+	return getActiveNetworkInterfaces()
+}
+
+func (gopsutilPlatform) DefaultNetworkInterface() string {
+	return ""
+}
+
+func (gopsutilPlatform) NetworkCounters() ([]gopsutilNet.IOCountersStat, error) {
+	return gopsutilNet.IOCounters(true)
+}
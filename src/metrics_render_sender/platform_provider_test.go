@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+// fakePlatform answers disk and network queries from fixed data; anything
+// else falls through to the real implementation.
+type fakePlatform struct {
+	gopsutilPlatform
+	interfaces       []string
+	addresses        map[string]networkAddresses
+	defaultInterface string
+	counters         map[string]diskCounterSample
+}
+
+func (p *fakePlatform) DiskCounters() (map[string]diskCounterSample, error) {
+	return p.counters, nil
+}
+
+func (p *fakePlatform) NetworkInterfaces() ([]string, map[string]networkAddresses) {
+	return p.interfaces, p.addresses
+}
+
+func (p *fakePlatform) DefaultNetworkInterface() string {
+	return p.defaultInterface
+}
+
+func useFakePlatform(t *testing.T, platform PlatformProvider) {
+	t.Helper()
+	previous := currentPlatform
+	currentPlatform = platform
+	t.Cleanup(func() { currentPlatform = previous })
+}
+
+func TestDiskCounterSamplesMatchPartitionsToDisks(t *testing.T) {
+	useFakePlatform(t, &fakePlatform{counters: map[string]diskCounterSample{
+		"sda":     {Name: "sda", ReadBytes: 10},
+		"nvme0n1": {Name: "nvme0n1", WriteBytes: 20},
+	}})
+
+	samples := getDiskCounterSamples([]string{"/dev/sda1", "nvme0n1p3", "sdz"})
+	if samples["/dev/sda1"].ReadBytes != 10 || samples["nvme0n1p3"].WriteBytes != 20 {
+		t.Fatalf("unexpected samples %+v", samples)
+	}
+	if _, ok := samples["sdz"]; ok {
+		t.Fatal("expected no sample for an unknown disk")
+	}
+}
+
+func TestNetworkCollectorSlotsFollowPlatformInterfaces(t *testing.T) {
+	useFakePlatform(t, &fakePlatform{
+		interfaces: []string{"eth0", "wlan0"},
+		addresses:  map[string]networkAddresses{"eth0": {IPv4: "192.168.1.2"}},
+	})
+
+	items := NewGoNativeNetworkCollector(nil).GetAllItems()
+	if got := items["go_native.net.1.ip"]; got == nil || got.GetValue().Value != "192.168.1.2" || !got.IsAvailable() {
+		t.Fatalf("unexpected eth0 ip item %+v", got)
+	}
+	if got := items["go_native.net.2.interface"]; got == nil || got.GetValue().Value != "wlan0" {
+		t.Fatalf("unexpected wlan0 interface item %+v", got)
+	}
+	if got := items["go_native.net.2.ip"]; got == nil || got.IsAvailable() {
+		t.Fatal("expected wlan0 without an address to have no ip")
+	}
+}
@@ -0,0 +1,37 @@
+//go:build windows
+
+package main
+
+// windowsPlatform reads disks through device IOCTLs. CPU temperature needs
+// a kernel driver on Windows and is left to LibreHardwareMonitor.
+type windowsPlatform struct {
+	gopsutilPlatform
+}
+
+func newPlatformProvider() PlatformProvider {
+	return windowsPlatform{}
+}
+
+func (windowsPlatform) HasCPUTemperature() bool {
+	return false
+}
+
+func (windowsPlatform) CPUTemperature() (float64, bool) {
+	return 0, false
+}
+
+func (windowsPlatform) CPUTemperatureSensors() []cpuTempSensor {
+	return nil
+}
+
+func (windowsPlatform) DetectDisks() []*DiskInfo {
+	return detectDiskInfoByWindows()
+}
+
+func (windowsPlatform) DiskCounters() (map[string]diskCounterSample, error) {
+	return readWindowsDiskCounters()
+}
+
+func (windowsPlatform) DiskTemperatures(deviceNames []string) map[string]diskTemperatureSnapshot {
+	return readWindowsDiskTemperatures(deviceNames)
+}
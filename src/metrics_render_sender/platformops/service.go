@@ -5,137 +5,63 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"runtime"
+	"strings"
+	"time"
 )
 
 const serviceName = "metrics_render_sender"
 
+const (
+	ServiceRestartAlways    = "always"
+	ServiceRestartOnFailure = "on-failure"
+	ServiceRestartNever     = "no"
+
+	defaultServiceRestartDelay = 5 * time.Second
+)
+
+// ServiceInstallOptions configure the installed service. AutoStart starts
+// it at boot (or login for a systemd user service) and right away; Restart
+// is one of the ServiceRestart policies. Args are passed to the binary.
 type ServiceInstallOptions struct {
+	AutoStart    bool
+	Restart      string
+	RestartDelay time.Duration
+	Args         []string
 }
 
 type ServiceInstallResult struct {
 	ServicePath string
 	UserMode    bool
+	Started     bool
 }
 
-func InstallService(_ ServiceInstallOptions) (ServiceInstallResult, error) {
-	if runtime.GOOS != "linux" {
-		return ServiceInstallResult{}, fmt.Errorf("--install only supports Linux systemd")
-	}
-
-	execPath, err := os.Executable()
-	if err != nil {
-		return ServiceInstallResult{}, fmt.Errorf("failed to resolve executable path: %w", err)
-	}
-	execPath, err = filepath.EvalSymlinks(execPath)
-	if err != nil {
-		execPath = filepath.Clean(execPath)
-	}
-
-	rootMode, err := isRootUser()
-	if err != nil {
-		return ServiceInstallResult{}, err
-	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ServiceInstallResult{}, fmt.Errorf("failed to resolve home directory: %w", err)
-	}
-
-	targetBin, servicePath, wantedBy := resolveServicePaths(rootMode, homeDir)
-	if err := os.MkdirAll(filepath.Dir(targetBin), 0o755); err != nil {
-		return ServiceInstallResult{}, fmt.Errorf("failed to create binary directory: %w", err)
-	}
-	if err := copyExecutable(execPath, targetBin); err != nil {
-		return ServiceInstallResult{}, err
-	}
-
-	if err := os.MkdirAll(filepath.Dir(servicePath), 0o755); err != nil {
-		return ServiceInstallResult{}, fmt.Errorf("failed to create service directory: %w", err)
-	}
-
-	serviceContent := buildServiceContent(targetBin, homeDir, wantedBy)
-	if err := os.WriteFile(servicePath, []byte(serviceContent), 0o644); err != nil {
-		return ServiceInstallResult{}, fmt.Errorf("failed to write service file: %w", err)
-	}
-
-	if rootMode {
-		if err := runCommand("systemctl", "daemon-reload"); err != nil {
-			return ServiceInstallResult{}, err
-		}
-		if err := runCommand("systemctl", "enable", "--now", serviceName+".service"); err != nil {
-			return ServiceInstallResult{}, err
-		}
-	} else {
-		if err := runCommand("systemctl", "--user", "daemon-reload"); err != nil {
-			return ServiceInstallResult{}, err
-		}
-		if err := runCommand("systemctl", "--user", "enable", "--now", serviceName+".service"); err != nil {
-			return ServiceInstallResult{}, err
-		}
-	}
-
-	return ServiceInstallResult{ServicePath: servicePath, UserMode: !rootMode}, nil
+func normalizeServiceOptions(options ServiceInstallOptions) (ServiceInstallOptions, error) {
+	switch strings.ToLower(strings.TrimSpace(options.Restart)) {
+	case "", ServiceRestartAlways:
+		options.Restart = ServiceRestartAlways
+	case ServiceRestartOnFailure:
+		options.Restart = ServiceRestartOnFailure
+	case ServiceRestartNever, "never":
+		options.Restart = ServiceRestartNever
+	default:
+		return options, fmt.Errorf("unknown restart policy %q, use always, on-failure or no", options.Restart)
+	}
+	if options.RestartDelay <= 0 {
+		options.RestartDelay = defaultServiceRestartDelay
+	}
+	return options, nil
 }
 
-func UninstallService() error {
-	if runtime.GOOS != "linux" {
-		return fmt.Errorf("--uninstall only supports Linux systemd")
-	}
-
-	rootMode, err := isRootUser()
+func resolveExecutablePath() (string, error) {
+	execPath, err := os.Executable()
 	if err != nil {
-		return err
+		return "", fmt.Errorf("failed to resolve executable path: %w", err)
 	}
-
-	homeDir, err := os.UserHomeDir()
+	resolved, err := filepath.EvalSymlinks(execPath)
 	if err != nil {
-		return fmt.Errorf("failed to resolve home directory: %w", err)
-	}
-	targetBin, servicePath, _ := resolveServicePaths(rootMode, homeDir)
-
-	if rootMode {
-		_ = runCommand("systemctl", "disable", "--now", serviceName+".service")
-		_ = os.Remove(servicePath)
-		if err := runCommand("systemctl", "daemon-reload"); err != nil {
-			return err
-		}
-	} else {
-		_ = runCommand("systemctl", "--user", "disable", "--now", serviceName+".service")
-		_ = os.Remove(servicePath)
-		if err := runCommand("systemctl", "--user", "daemon-reload"); err != nil {
-			return err
-		}
-	}
-
-	_ = os.Remove(targetBin)
-	return nil
-}
-
-func resolveServicePaths(rootMode bool, homeDir string) (binPath, servicePath, wantedBy string) {
-	if rootMode {
-		return "/usr/local/bin/metrics_render_sender", "/etc/systemd/system/metrics_render_sender.service", "multi-user.target"
+		return filepath.Clean(execPath), nil
 	}
-	return filepath.Join(homeDir, ".local", "bin", "metrics_render_sender"),
-		filepath.Join(homeDir, ".config", "systemd", "user", "metrics_render_sender.service"),
-		"default.target"
-}
-
-func buildServiceContent(binaryPath, homeDir, wantedBy string) string {
-	return fmt.Sprintf(`[Unit]
-Description=MetricsRenderSender
-After=network.target
-
-[Service]
-Type=simple
-ExecStart=%s
-Restart=always
-RestartSec=5
-Environment=HOME=%s
-
-[Install]
-WantedBy=%s
-`, binaryPath, homeDir, wantedBy)
+	return resolved, nil
 }
 
 func copyExecutable(sourcePath, targetPath string) error {
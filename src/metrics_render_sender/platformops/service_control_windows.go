@@ -0,0 +1,76 @@
+//go:build windows
+
+package platformops
+
+import (
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+)
+
+// serviceStopTimeout bounds how long a stop request waits for shutdown
+// before the service is reported stopped anyway.
+const serviceStopTimeout = 20 * time.Second
+
+// StartServiceControl answers the service control manager when the process
+// was started as a service; otherwise it does nothing. A stop or shutdown
+// request is delivered on stop as SIGTERM, like Ctrl+C. The returned
+// function is called once the process has shut down, so the manager sees
+// the service stop only then.
+func StartServiceControl(stop chan<- os.Signal) (func(), error) {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return func() {}, err
+	}
+	handler := &serviceHandler{stop: stop, exited: make(chan struct{})}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		_ = svc.Run(serviceName, handler)
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(handler.exited) })
+		select {
+		case <-finished:
+		case <-time.After(5 * time.Second):
+		}
+	}, nil
+}
+
+type serviceHandler struct {
+	stop   chan<- os.Signal
+	exited chan struct{}
+}
+
+func (h *serviceHandler) Execute(_ []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	for {
+		select {
+		case <-h.exited:
+			// Shut down without a request: report an error exit so the
+			// restart policy applies.
+			return true, 1
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				status <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending, WaitHint: uint32(serviceStopTimeout / time.Millisecond)}
+				select {
+				case h.stop <- syscall.SIGTERM:
+				default:
+				}
+				select {
+				case <-h.exited:
+				case <-time.After(serviceStopTimeout):
+				}
+				return false, 0
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+//go:build !windows
+
+package platformops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+func InstallService(options ServiceInstallOptions) (ServiceInstallResult, error) {
+	if runtime.GOOS != "linux" {
+		return ServiceInstallResult{}, fmt.Errorf("install-service supports systemd on Linux and services on Windows")
+	}
+	options, err := normalizeServiceOptions(options)
+	if err != nil {
+		return ServiceInstallResult{}, err
+	}
+
+	execPath, err := resolveExecutablePath()
+	if err != nil {
+		return ServiceInstallResult{}, err
+	}
+
+	rootMode, err := isRootUser()
+	if err != nil {
+		return ServiceInstallResult{}, err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ServiceInstallResult{}, fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+
+	targetBin, servicePath, wantedBy := resolveServicePaths(rootMode, homeDir)
+	if err := os.MkdirAll(filepath.Dir(targetBin), 0o755); err != nil {
+		return ServiceInstallResult{}, fmt.Errorf("failed to create binary directory: %w", err)
+	}
+	if execPath != targetBin {
+		if err := copyExecutable(execPath, targetBin); err != nil {
+			return ServiceInstallResult{}, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(servicePath), 0o755); err != nil {
+		return ServiceInstallResult{}, fmt.Errorf("failed to create service directory: %w", err)
+	}
+
+	serviceContent := buildServiceContent(targetBin, homeDir, wantedBy, options)
+	if err := os.WriteFile(servicePath, []byte(serviceContent), 0o644); err != nil {
+		return ServiceInstallResult{}, fmt.Errorf("failed to write service file: %w", err)
+	}
+
+	if err := runSystemctl(rootMode, "daemon-reload"); err != nil {
+		return ServiceInstallResult{}, err
+	}
+	if options.AutoStart {
+		if err := runSystemctl(rootMode, "enable", "--now", serviceName+".service"); err != nil {
+			return ServiceInstallResult{}, err
+		}
+	}
+
+	return ServiceInstallResult{ServicePath: servicePath, UserMode: !rootMode, Started: options.AutoStart}, nil
+}
+
+func UninstallService() error {
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("uninstall-service supports systemd on Linux and services on Windows")
+	}
+
+	rootMode, err := isRootUser()
+	if err != nil {
+		return err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	targetBin, servicePath, _ := resolveServicePaths(rootMode, homeDir)
+	if _, err := os.Stat(servicePath); os.IsNotExist(err) {
+		return fmt.Errorf("service is not installed: %s", servicePath)
+	}
+
+	_ = runSystemctl(rootMode, "disable", "--now", serviceName+".service")
+	_ = os.Remove(servicePath)
+	if err := runSystemctl(rootMode, "daemon-reload"); err != nil {
+		return err
+	}
+
+	_ = os.Remove(targetBin)
+	return nil
+}
+
+func runSystemctl(rootMode bool, args ...string) error {
+	if !rootMode {
+		args = append([]string{"--user"}, args...)
+	}
+	return runCommand("systemctl", args...)
+}
+
+func resolveServicePaths(rootMode bool, homeDir string) (binPath, servicePath, wantedBy string) {
+	if rootMode {
+		return "/usr/local/bin/metrics_render_sender", "/etc/systemd/system/metrics_render_sender.service", "multi-user.target"
+	}
+	return filepath.Join(homeDir, ".local", "bin", "metrics_render_sender"),
+		filepath.Join(homeDir, ".config", "systemd", "user", "metrics_render_sender.service"),
+		"default.target"
+}
+
+// buildServiceContent writes the unit. HOME points the service at the
+// installing user's config directory. Environment= expands % specifiers but
+// not $ variables, so only % is doubled there.
+func buildServiceContent(binaryPath, homeDir, wantedBy string, options ServiceInstallOptions) string {
+	command := make([]string, 0, len(options.Args)+1)
+	for _, arg := range append([]string{binaryPath}, options.Args...) {
+		command = append(command, quoteSystemdArg(arg))
+	}
+	return fmt.Sprintf(`[Unit]
+Description=MetricsRenderSender
+After=network.target
+
+[Service]
+Type=simple
+ExecStart=%s
+Restart=%s
+RestartSec=%d
+Environment=%s
+
+[Install]
+WantedBy=%s
+`, strings.Join(command, " "), options.Restart, int(options.RestartDelay.Seconds()),
+		quoteSystemdValue("HOME="+strings.ReplaceAll(homeDir, "%", "%%")), wantedBy)
+}
+
+// quoteSystemdArg escapes arg for ExecStart=, where systemd expands %
+// specifiers and $ variables, and quotes it if it has spaces or quotes.
+func quoteSystemdArg(arg string) string {
+	arg = strings.NewReplacer("%", "%%", "$", "$$").Replace(arg)
+	if arg != "" && !strings.ContainsAny(arg, " \t\"'\\") {
+		return arg
+	}
+	return quoteSystemdValue(arg)
+}
+
+func quoteSystemdValue(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// StartServiceControl is only needed by Windows services; systemd stops the
+// service with SIGTERM, which is already handled.
+func StartServiceControl(_ chan<- os.Signal) (func(), error) {
+	return func() {}, nil
+}
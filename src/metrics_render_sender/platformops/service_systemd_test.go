@@ -0,0 +1,56 @@
+//go:build !windows
+
+package platformops
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildServiceContentAppliesOptions(t *testing.T) {
+	options, err := normalizeServiceOptions(ServiceInstallOptions{
+		Restart: "On-Failure",
+		Args:    []string{"--headless", "--config", "/home/me/My Configs/config.json"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.RestartDelay != defaultServiceRestartDelay {
+		t.Fatalf("expected the default restart delay, got %v", options.RestartDelay)
+	}
+	content := buildServiceContent("/usr/local/bin/metrics_render_sender", "/home/me", "default.target", options)
+	for _, want := range []string{
+		`ExecStart=/usr/local/bin/metrics_render_sender --headless --config "/home/me/My Configs/config.json"`,
+		"Restart=on-failure",
+		"RestartSec=5",
+		`Environment="HOME=/home/me"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in:\n%s", want, content)
+		}
+	}
+
+	if _, err := normalizeServiceOptions(ServiceInstallOptions{Restart: "sometimes"}); err == nil {
+		t.Fatal("expected an unknown restart policy to fail")
+	}
+	options, _ = normalizeServiceOptions(ServiceInstallOptions{Restart: "never", RestartDelay: 30 * time.Second})
+	if content := buildServiceContent("/bin/m", "/root", "multi-user.target", options); !strings.Contains(content, "Restart=no\nRestartSec=30") {
+		t.Fatalf("unexpected unit:\n%s", content)
+	}
+}
+
+func TestBuildServiceContentEscapesSpecifiers(t *testing.T) {
+	options, _ := normalizeServiceOptions(ServiceInstallOptions{
+		Args: []string{"--config", "/srv/100%/config.json", "--name", "$USER display"},
+	})
+	content := buildServiceContent("/opt/$app/metrics_render_sender", "/home/50% off", "default.target", options)
+	for _, want := range []string{
+		`ExecStart=/opt/$$app/metrics_render_sender --config /srv/100%%/config.json --name "$$USER display"`,
+		`Environment="HOME=/home/50%% off"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in:\n%s", want, content)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+//go:build windows
+
+package platformops
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+const (
+	serviceDisplayName = "MetricsRenderSender"
+	serviceDescription = "Renders system metrics to AX206 and other displays"
+
+	// serviceFailureResetPeriod clears the failure count after a day up.
+	serviceFailureResetPeriod = 24 * 60 * 60
+)
+
+// InstallService registers the running binary as a LocalSystem service.
+// Services run in session 0 without a desktop, so the caller passes the
+// headless flag and the config path in options.Args.
+func InstallService(options ServiceInstallOptions) (ServiceInstallResult, error) {
+	options, err := normalizeServiceOptions(options)
+	if err != nil {
+		return ServiceInstallResult{}, err
+	}
+	execPath, err := resolveExecutablePath()
+	if err != nil {
+		return ServiceInstallResult{}, err
+	}
+
+	manager, err := connectServiceManager()
+	if err != nil {
+		return ServiceInstallResult{}, err
+	}
+	defer manager.Disconnect()
+
+	if existing, err := manager.OpenService(serviceName); err == nil {
+		existing.Close()
+		return ServiceInstallResult{}, fmt.Errorf("service %s already exists, run uninstall-service first", serviceName)
+	}
+
+	startType := uint32(mgr.StartManual)
+	if options.AutoStart {
+		startType = mgr.StartAutomatic
+	}
+	service, err := manager.CreateService(serviceName, execPath, mgr.Config{
+		DisplayName: serviceDisplayName,
+		Description: serviceDescription,
+		StartType:   startType,
+		// USB devices and sensor drivers settle after the automatic services.
+		DelayedAutoStart: options.AutoStart,
+	}, options.Args...)
+	if err != nil {
+		return ServiceInstallResult{}, fmt.Errorf("failed to create service: %w", err)
+	}
+	defer service.Close()
+
+	if err := configureServiceRecovery(service, options); err != nil {
+		_ = service.Delete()
+		return ServiceInstallResult{}, err
+	}
+	if options.AutoStart {
+		if err := service.Start(); err != nil {
+			return ServiceInstallResult{}, fmt.Errorf("service installed but failed to start: %w", err)
+		}
+	}
+	return ServiceInstallResult{ServicePath: execPath, Started: options.AutoStart}, nil
+}
+
+// configureServiceRecovery maps the restart policy onto the service's
+// failure actions: a crash restarts with on-failure, and always also
+// restarts after the process exits with an error.
+func configureServiceRecovery(service *mgr.Service, options ServiceInstallOptions) error {
+	if options.Restart == ServiceRestartNever {
+		return nil
+	}
+	action := mgr.RecoveryAction{Type: mgr.ServiceRestart, Delay: options.RestartDelay}
+	actions := []mgr.RecoveryAction{action, action, action}
+	if err := service.SetRecoveryActions(actions, serviceFailureResetPeriod); err != nil {
+		return fmt.Errorf("failed to set restart policy: %w", err)
+	}
+	if options.Restart == ServiceRestartAlways {
+		if err := service.SetRecoveryActionsOnNonCrashFailures(true); err != nil {
+			return fmt.Errorf("failed to set restart policy: %w", err)
+		}
+	}
+	return nil
+}
+
+func UninstallService() error {
+	manager, err := connectServiceManager()
+	if err != nil {
+		return err
+	}
+	defer manager.Disconnect()
+
+	service, err := manager.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", serviceName)
+	}
+	defer service.Close()
+
+	if status, err := service.Query(); err == nil && status.State != svc.Stopped {
+		if _, err := service.Control(svc.Stop); err == nil {
+			waitServiceStopped(service, 10*time.Second)
+		}
+	}
+	if err := service.Delete(); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	return nil
+}
+
+func connectServiceManager() (*mgr.Mgr, error) {
+	manager, err := mgr.Connect()
+	if err != nil {
+		if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+			return nil, fmt.Errorf("managing services requires an elevated (Run as administrator) prompt")
+		}
+		return nil, fmt.Errorf("failed to connect to the service manager: %w", err)
+	}
+	return manager, nil
+}
+
+func waitServiceStopped(service *mgr.Service, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		status, err := service.Query()
+		if err != nil || status.State == svc.Stopped {
+			return
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	pomodoroPhaseWork       = "work"
+	pomodoroPhaseShortBreak = "short_break"
+	pomodoroPhaseLongBreak  = "long_break"
+
+	pomodoroStateStopped = "stopped"
+	pomodoroStateRunning = "running"
+	pomodoroStatePaused  = "paused"
+)
+
+type pomodoroSettings struct {
+	work           time.Duration
+	shortBreak     time.Duration
+	longBreak      time.Duration
+	longBreakEvery int
+	// autoStart runs the next phase as soon as one ends instead of waiting
+	// for timer_start.
+	autoStart bool
+}
+
+var defaultPomodoroSettings = pomodoroSettings{
+	work:           25 * time.Minute,
+	shortBreak:     5 * time.Minute,
+	longBreak:      15 * time.Minute,
+	longBreakEvery: 4,
+}
+
+// pomodoroSettingsFromConfig reads the timer collector options: work_minutes,
+// short_break_minutes, long_break_minutes, long_break_every and auto_start.
+func pomodoroSettingsFromConfig(cfg *MonitorConfig) pomodoroSettings {
+	settings := defaultPomodoroSettings
+	minutes := func(key string, fallback time.Duration) time.Duration {
+		value, err := strconv.ParseFloat(strings.TrimSpace(cfg.GetCollectorStringOption(collectorTimer, key, "")), 64)
+		if err != nil || value <= 0 || value > 24*60 {
+			return fallback
+		}
+		return time.Duration(value * float64(time.Minute)).Round(time.Second)
+	}
+	settings.work = minutes("work_minutes", settings.work)
+	settings.shortBreak = minutes("short_break_minutes", settings.shortBreak)
+	settings.longBreak = minutes("long_break_minutes", settings.longBreak)
+	if every, err := strconv.Atoi(strings.TrimSpace(cfg.GetCollectorStringOption(collectorTimer, "long_break_every", ""))); err == nil && every > 0 {
+		settings.longBreakEvery = every
+	}
+	settings.autoStart = strings.EqualFold(strings.TrimSpace(cfg.GetCollectorStringOption(collectorTimer, "auto_start", "")), "true")
+	return settings
+}
+
+// pomodoroStatus is what the timer monitors and the full_timer item show.
+type pomodoroStatus struct {
+	Phase     string
+	State     string
+	Remaining time.Duration
+	Duration  time.Duration
+	// Completed counts the work sessions finished since the last reset.
+	Completed int
+}
+
+// Progress is the elapsed share of the current phase in percent.
+func (s pomodoroStatus) Progress() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return clampFloat64(100-float64(s.Remaining)*100/float64(s.Duration), 0, 100)
+}
+
+// pomodoroTimer cycles work sessions and breaks. It only keeps deadlines and
+// catches up whenever it is read, so no goroutine ticks it.
+type pomodoroTimer struct {
+	mu        sync.Mutex
+	settings  pomodoroSettings
+	phase     string
+	state     string
+	remaining time.Duration
+	endsAt    time.Time
+	completed int
+}
+
+// sharedPomodoroTimer is driven by the display control actions, whatever
+// their source, and read by the timer collector.
+var sharedPomodoroTimer = newPomodoroTimer(defaultPomodoroSettings)
+
+func newPomodoroTimer(settings pomodoroSettings) *pomodoroTimer {
+	return &pomodoroTimer{
+		settings:  settings,
+		phase:     pomodoroPhaseWork,
+		state:     pomodoroStateStopped,
+		remaining: settings.work,
+	}
+}
+
+func (t *pomodoroTimer) phaseDuration(phase string) time.Duration {
+	switch phase {
+	case pomodoroPhaseShortBreak:
+		return t.settings.shortBreak
+	case pomodoroPhaseLongBreak:
+		return t.settings.longBreak
+	default:
+		return t.settings.work
+	}
+}
+
+// configure applies new settings. A stopped phase takes its new length at
+// once; a running or paused one keeps the time it has left.
+func (t *pomodoroTimer) configure(settings pomodoroSettings) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.settings == settings {
+		return
+	}
+	t.settings = settings
+	if t.state == pomodoroStateStopped {
+		t.remaining = t.phaseDuration(t.phase)
+	}
+}
+
+// advance moves past every phase that ended by now.
+func (t *pomodoroTimer) advance(now time.Time) {
+	for t.state == pomodoroStateRunning && !now.Before(t.endsAt) {
+		ended := t.endsAt
+		t.nextPhase()
+		if !t.settings.autoStart {
+			t.state = pomodoroStateStopped
+			t.remaining = t.phaseDuration(t.phase)
+			return
+		}
+		t.endsAt = ended.Add(t.phaseDuration(t.phase))
+	}
+}
+
+func (t *pomodoroTimer) nextPhase() {
+	if t.phase != pomodoroPhaseWork {
+		t.phase = pomodoroPhaseWork
+		return
+	}
+	t.completed++
+	if t.settings.longBreakEvery > 0 && t.completed%t.settings.longBreakEvery == 0 {
+		t.phase = pomodoroPhaseLongBreak
+	} else {
+		t.phase = pomodoroPhaseShortBreak
+	}
+}
+
+func (t *pomodoroTimer) status(now time.Time) pomodoroStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.advance(now)
+	remaining := t.remaining
+	if t.state == pomodoroStateRunning {
+		remaining = t.endsAt.Sub(now)
+	}
+	return pomodoroStatus{
+		Phase:     t.phase,
+		State:     t.state,
+		Remaining: remaining,
+		Duration:  t.phaseDuration(t.phase),
+		Completed: t.completed,
+	}
+}
+
+func (t *pomodoroTimer) start(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.advance(now)
+	if t.state == pomodoroStateRunning {
+		return
+	}
+	t.state = pomodoroStateRunning
+	t.endsAt = now.Add(t.remaining)
+}
+
+func (t *pomodoroTimer) pause(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.advance(now)
+	if t.state != pomodoroStateRunning {
+		return
+	}
+	t.state = pomodoroStatePaused
+	t.remaining = t.endsAt.Sub(now)
+}
+
+func (t *pomodoroTimer) toggle(now time.Time) {
+	if t.status(now).State == pomodoroStateRunning {
+		t.pause(now)
+		return
+	}
+	t.start(now)
+}
+
+// skip ends the current phase early; the next one starts right away when
+// the timer was running and auto_start is on.
+func (t *pomodoroTimer) skip(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.advance(now)
+	running := t.state == pomodoroStateRunning
+	t.nextPhase()
+	t.remaining = t.phaseDuration(t.phase)
+	t.state = pomodoroStateStopped
+	if running && t.settings.autoStart {
+		t.state = pomodoroStateRunning
+		t.endsAt = now.Add(t.remaining)
+	}
+}
+
+// reset stops the timer and starts the cycle over with a work session.
+func (t *pomodoroTimer) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phase = pomodoroPhaseWork
+	t.state = pomodoroStateStopped
+	t.remaining = t.settings.work
+	t.completed = 0
+}
+
+// apply runs one of the timer_* display actions and returns the new status.
+func (t *pomodoroTimer) apply(action string, now time.Time) pomodoroStatus {
+	switch action {
+	case displayActionTimerToggle:
+		t.toggle(now)
+	case displayActionTimerStart:
+		t.start(now)
+	case displayActionTimerPause:
+		t.pause(now)
+	case displayActionTimerReset:
+		t.reset()
+	case displayActionTimerSkip:
+		t.skip(now)
+	}
+	return t.status(now)
+}
+
+// formatPomodoroRemaining renders the time left as m:ss, rounding up so the
+// display reaches 0:00 only when the phase ends.
+func formatPomodoroRemaining(remaining time.Duration) string {
+	seconds := int(math.Ceil(remaining.Seconds()))
+	if seconds < 0 {
+		seconds = 0
+	}
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// describePomodoroStatus is the control API result for a timer action.
+func describePomodoroStatus(status pomodoroStatus) string {
+	return fmt.Sprintf("timer %s %s %s", strings.ReplaceAll(status.Phase, "_", " "), status.State, formatPomodoroRemaining(status.Remaining))
+}
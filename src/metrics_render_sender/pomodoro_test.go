@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPomodoroTimerCycles(t *testing.T) {
+	settings := pomodoroSettings{work: 10 * time.Second, shortBreak: 2 * time.Second, longBreak: 5 * time.Second, longBreakEvery: 2, autoStart: true}
+	timer := newPomodoroTimer(settings)
+	now := time.Unix(1000, 0)
+
+	timer.start(now)
+	status := timer.status(now.Add(4 * time.Second))
+	if status.Phase != pomodoroPhaseWork || status.Remaining != 6*time.Second || status.Progress() != 40 {
+		t.Fatalf("unexpected work status %+v", status)
+	}
+	// Work, short break, work, then the long break after the second session.
+	status = timer.status(now.Add(23 * time.Second))
+	if status.Phase != pomodoroPhaseLongBreak || status.State != pomodoroStateRunning || status.Completed != 2 || status.Remaining != 4*time.Second {
+		t.Fatalf("expected a running long break, got %+v", status)
+	}
+}
+
+func TestPomodoroTimerWaitsWithoutAutoStart(t *testing.T) {
+	timer := newPomodoroTimer(pomodoroSettings{work: 10 * time.Second, shortBreak: 2 * time.Second, longBreak: 5 * time.Second, longBreakEvery: 4})
+	now := time.Unix(1000, 0)
+
+	status := timer.apply(displayActionTimerToggle, now)
+	if status.State != pomodoroStateRunning {
+		t.Fatalf("expected toggle to start the timer, got %+v", status)
+	}
+	status = timer.apply(displayActionTimerToggle, now.Add(3*time.Second))
+	if status.State != pomodoroStatePaused || status.Remaining != 7*time.Second {
+		t.Fatalf("expected a paused timer with 7s left, got %+v", status)
+	}
+	if status = timer.status(now.Add(time.Hour)); status.Remaining != 7*time.Second {
+		t.Fatalf("expected a paused timer to hold, got %+v", status)
+	}
+
+	timer.start(now.Add(time.Hour))
+	status = timer.status(now.Add(time.Hour + 8*time.Second))
+	if status.Phase != pomodoroPhaseShortBreak || status.State != pomodoroStateStopped || status.Remaining != 2*time.Second {
+		t.Fatalf("expected a stopped short break, got %+v", status)
+	}
+
+	status = timer.apply(displayActionTimerSkip, now.Add(time.Hour+9*time.Second))
+	if status.Phase != pomodoroPhaseWork || status.State != pomodoroStateStopped || status.Completed != 1 {
+		t.Fatalf("expected skip to return to work, got %+v", status)
+	}
+	status = timer.apply(displayActionTimerReset, now.Add(time.Hour+10*time.Second))
+	if status.Completed != 0 || status.Remaining != 10*time.Second {
+		t.Fatalf("expected reset to clear the cycle, got %+v", status)
+	}
+}
+
+func TestPomodoroSettingsFromConfig(t *testing.T) {
+	cfg := &MonitorConfig{CollectorConfig: map[string]CollectorConfig{
+		collectorTimer: {Options: map[string]interface{}{
+			"work_minutes":     "50",
+			"long_break_every": "0",
+			"auto_start":       "true",
+		}},
+	}}
+	settings := pomodoroSettingsFromConfig(cfg)
+	if settings.work != 50*time.Minute || settings.shortBreak != 5*time.Minute || settings.longBreakEvery != 4 || !settings.autoStart {
+		t.Fatalf("unexpected settings %+v", settings)
+	}
+}
+
+func TestFormatPomodoroRemaining(t *testing.T) {
+	cases := map[time.Duration]string{
+		25 * time.Minute:                  "25:00",
+		59*time.Second + time.Millisecond: "1:00",
+		-time.Second:                      "0:00",
+		90 * time.Minute:                  "1:30:00",
+	}
+	for input, want := range cases {
+		if got := formatPomodoroRemaining(input); got != want {
+			t.Fatalf("formatPomodoroRemaining(%v)=%q want=%q", input, got, want)
+		}
+	}
+}
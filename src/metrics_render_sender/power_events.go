@@ -0,0 +1,58 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	powerWatchInterval = 2 * time.Second
+	// powerResumeThreshold is the least suspended time treated as a
+	// resume. Shorter gaps leave connections and rate baselines intact.
+	powerResumeThreshold = 2 * time.Second
+)
+
+var powerWatchOnce sync.Once
+
+// startPowerWatch resets rates and reconnects outputs when the system comes
+// back from suspend or hibernation. A resume shows up as growth of the time
+// the system spent suspended, read from the OS clocks, which needs neither
+// logind nor a window for power broadcasts.
+func startPowerWatch() {
+	powerWatchOnce.Do(func() {
+		if _, err := suspendedTime(); err != nil {
+			logInfoModule("power", "resume detection unavailable: %v", err)
+			return
+		}
+		ticker := time.NewTicker(powerWatchInterval)
+		go runPowerWatch(suspendedTime, ticker.C, handleSystemResume)
+	})
+}
+
+func runPowerWatch(read func() (time.Duration, error), ticks <-chan time.Time, onResume func(slept time.Duration)) {
+	last, err := read()
+	if err != nil {
+		return
+	}
+	for range ticks {
+		current, err := read()
+		if err != nil {
+			continue
+		}
+		if slept := current - last; slept >= powerResumeThreshold {
+			onResume(slept)
+		}
+		last = current
+	}
+}
+
+func handleSystemResume(slept time.Duration) {
+	logInfoModule("power", "System resumed after %v suspended, resetting rates and outputs", slept.Round(time.Second))
+	if runtime := CurrentSharedWebAPI(); runtime != nil {
+		runtime.resetAfterResume()
+		return
+	}
+	if manager := CurrentCollectorManager(); manager != nil {
+		manager.ResetRates("resume")
+	}
+}
@@ -0,0 +1,22 @@
+//go:build linux
+
+package main
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// suspendedTime is how long the system has been suspended since boot:
+// CLOCK_BOOTTIME keeps running during suspend, CLOCK_MONOTONIC does not.
+func suspendedTime() (time.Duration, error) {
+	var boot, mono unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_BOOTTIME, &boot); err != nil {
+		return 0, err
+	}
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &mono); err != nil {
+		return 0, err
+	}
+	return time.Duration(boot.Nano() - mono.Nano()), nil
+}
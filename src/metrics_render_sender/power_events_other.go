@@ -0,0 +1,12 @@
+//go:build !linux && !windows
+
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+func suspendedTime() (time.Duration, error) {
+	return 0, errors.New("suspended time is only available on Linux and Windows")
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunPowerWatchReportsSuspendedTime(t *testing.T) {
+	readings := []time.Duration{0, 0, 300 * time.Millisecond, 45 * time.Second, 45 * time.Second}
+	read := func() (time.Duration, error) {
+		value := readings[0]
+		readings = readings[1:]
+		return value, nil
+	}
+	ticks := make(chan time.Time, len(readings))
+	for range readings[1:] {
+		ticks <- time.Time{}
+	}
+	close(ticks)
+
+	var resumes []time.Duration
+	runPowerWatch(read, ticks, func(slept time.Duration) {
+		resumes = append(resumes, slept)
+	})
+	if len(resumes) != 1 || resumes[0] != 45*time.Second-300*time.Millisecond {
+		t.Fatalf("expected one resume after the suspend, got %v", resumes)
+	}
+}
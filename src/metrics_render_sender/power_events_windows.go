@@ -0,0 +1,31 @@
+//go:build windows
+
+package main
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	powerKernel32                  = windows.NewLazySystemDLL("kernel32.dll")
+	procGetTickCount64             = powerKernel32.NewProc("GetTickCount64")
+	procQueryUnbiasedInterruptTime = powerKernel32.NewProc("QueryUnbiasedInterruptTime")
+)
+
+// suspendedTime is how long the system has been asleep or hibernated since
+// boot: the tick count includes sleep, the unbiased interrupt time does not.
+func suspendedTime() (time.Duration, error) {
+	if err := procQueryUnbiasedInterruptTime.Find(); err != nil {
+		return 0, err
+	}
+	// Read the unbiased time first so the difference never goes negative.
+	var unbiased uint64
+	if ok, _, err := procQueryUnbiasedInterruptTime.Call(uintptr(unsafe.Pointer(&unbiased))); ok == 0 {
+		return 0, err
+	}
+	ticks, _, _ := procGetTickCount64.Call()
+	return time.Duration(ticks)*time.Millisecond - time.Duration(unbiased)*100, nil
+}
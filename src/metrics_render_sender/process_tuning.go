@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	processTuningMu      sync.Mutex
+	processTuningApplied *ProcessConfig
+)
+
+// setProcessTuning applies the process section when it changed. Nothing is
+// undone when the section is removed, since raising the priority again
+// needs privileges the monitor usually lacks; that takes a restart.
+func setProcessTuning(cfg *ProcessConfig) {
+	processTuningMu.Lock()
+	defer processTuningMu.Unlock()
+	if reflect.DeepEqual(processTuningApplied, cfg) {
+		return
+	}
+	if cfg == nil {
+		processTuningApplied = nil
+		return
+	}
+	copyCfg := *cfg
+	processTuningApplied = &copyCfg
+
+	if err := applyProcessPriority(copyCfg); err != nil {
+		logWarnModule("config", "process priority not applied: %v", err)
+	}
+	cores := strings.TrimSpace(copyCfg.Cores)
+	if cores == "" {
+		return
+	}
+	cpus, err := resolveProcessCores(cores)
+	if err == nil {
+		err = setProcessAffinity(cpus)
+	}
+	if err != nil {
+		logWarnModule("config", "process cores %q not applied: %v", cores, err)
+		return
+	}
+	logInfoModule("config", "Process pinned to cpus %s", formatCPUList(cpus))
+}
+
+func (cfg ProcessConfig) nice() int {
+	return max(-20, min(cfg.Nice, 19))
+}
+
+func resolveProcessCores(value string) ([]int, error) {
+	if !strings.EqualFold(value, "efficiency") {
+		return parseCPUList(value)
+	}
+	cpus, err := efficiencyCPUs()
+	if err != nil {
+		return nil, err
+	}
+	if len(cpus) == 0 {
+		return nil, errors.New("no efficiency cores found")
+	}
+	return cpus, nil
+}
+
+// parseCPUList reads the kernel's CPU list format, e.g. "0-3,6".
+func parseCPUList(value string) ([]int, error) {
+	seen := make(map[int]struct{})
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid cpu %q", part)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(last))
+			if err != nil || end < start {
+				return nil, fmt.Errorf("invalid cpu range %q", part)
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			seen[cpu] = struct{}{}
+		}
+	}
+	if len(seen) == 0 {
+		return nil, errors.New("empty cpu list")
+	}
+	cpus := make([]int, 0, len(seen))
+	for cpu := range seen {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
+
+// formatCPUList is the inverse of parseCPUList for sorted cpus.
+func formatCPUList(cpus []int) string {
+	parts := make([]string, 0, len(cpus))
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, strconv.Itoa(cpus[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
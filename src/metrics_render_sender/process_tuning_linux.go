@@ -0,0 +1,133 @@
+//go:build linux
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	ioprioWhoProcess      = 1
+	ioprioClassShift      = 13
+	ioprioClassBestEffort = 2
+	ioprioClassIdle       = 3
+	// ioprioLowestLevel is the lowest best-effort level.
+	ioprioLowestLevel = 7
+)
+
+// applyProcessPriority sets niceness and I/O class. Linux keeps both per
+// thread, so every thread of the process is changed; threads started later
+// inherit them from the thread that creates them.
+func applyProcessPriority(cfg ProcessConfig) error {
+	var ioprio int
+	switch strings.ToLower(strings.TrimSpace(cfg.IOClass)) {
+	case "":
+	case "idle":
+		ioprio = ioprioClassIdle << ioprioClassShift
+	case "best-effort", "best_effort":
+		ioprio = ioprioClassBestEffort<<ioprioClassShift | ioprioLowestLevel
+	default:
+		return fmt.Errorf("unknown io_class %q", cfg.IOClass)
+	}
+	nice := cfg.nice()
+	if nice == 0 && ioprio == 0 {
+		return nil
+	}
+	return forEachProcessThread(func(tid int) error {
+		if nice != 0 {
+			if err := unix.Setpriority(unix.PRIO_PROCESS, tid, nice); err != nil {
+				return fmt.Errorf("set nice %d: %w", nice, err)
+			}
+		}
+		if ioprio != 0 {
+			if _, _, errno := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(tid), uintptr(ioprio)); errno != 0 {
+				return fmt.Errorf("set io_class %s: %w", cfg.IOClass, errno)
+			}
+		}
+		return nil
+	})
+}
+
+func setProcessAffinity(cpus []int) error {
+	var set unix.CPUSet
+	for _, cpu := range cpus {
+		set.Set(cpu)
+	}
+	return forEachProcessThread(func(tid int) error {
+		return unix.SchedSetaffinity(tid, &set)
+	})
+}
+
+func forEachProcessThread(apply func(tid int) error) error {
+	entries, err := os.ReadDir("/proc/self/task")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		tid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		// A thread that exited meanwhile is not an error.
+		if err := apply(tid); err != nil && !errors.Is(err, unix.ESRCH) {
+			return err
+		}
+	}
+	return nil
+}
+
+func efficiencyCPUs() ([]int, error) {
+	return linuxEfficiencyCPUs("/sys/devices")
+}
+
+// linuxEfficiencyCPUs finds the small cores of a hybrid CPU: Intel lists
+// its E-cores as the cpu_atom PMU, ARM big.LITTLE SoCs give the small
+// cores the lowest cpu_capacity.
+func linuxEfficiencyCPUs(sysDevices string) ([]int, error) {
+	if data, err := os.ReadFile(filepath.Join(sysDevices, "cpu_atom", "cpus")); err == nil {
+		return parseCPUList(strings.TrimSpace(string(data)))
+	}
+	paths, _ := filepath.Glob(filepath.Join(sysDevices, "system", "cpu", "cpu[0-9]*", "cpu_capacity"))
+	capacities := make(map[int]int, len(paths))
+	lowest, highest := 0, 0
+	for _, path := range paths {
+		cpu, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(filepath.Dir(path)), "cpu"))
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		capacity, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			continue
+		}
+		if len(capacities) == 0 || capacity < lowest {
+			lowest = capacity
+		}
+		if len(capacities) == 0 || capacity > highest {
+			highest = capacity
+		}
+		capacities[cpu] = capacity
+	}
+	if len(capacities) == 0 || lowest == highest {
+		return nil, errors.New("CPU has no efficiency cores")
+	}
+	cpus := make([]int, 0, len(capacities))
+	for cpu, capacity := range capacities {
+		if capacity == lowest {
+			cpus = append(cpus, cpu)
+		}
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
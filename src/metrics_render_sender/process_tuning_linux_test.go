@@ -0,0 +1,46 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLinuxEfficiencyCPUs(t *testing.T) {
+	root := t.TempDir()
+	writeCapacity := func(cpu, capacity string) {
+		dir := filepath.Join(root, "system", "cpu", "cpu"+cpu)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "cpu_capacity"), []byte(capacity+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCapacity("0", "1024")
+	writeCapacity("1", "1024")
+	if _, err := linuxEfficiencyCPUs(root); err == nil {
+		t.Fatal("expected equal capacities to have no efficiency cores")
+	}
+
+	writeCapacity("2", "446")
+	writeCapacity("10", "446")
+	cpus, err := linuxEfficiencyCPUs(root)
+	if err != nil || !reflect.DeepEqual(cpus, []int{2, 10}) {
+		t.Fatalf("expected the low capacity cpus 2 and 10, got %v (%v)", cpus, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "cpu_atom"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "cpu_atom", "cpus"), []byte("16-19\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cpus, err = linuxEfficiencyCPUs(root)
+	if err != nil || !reflect.DeepEqual(cpus, []int{16, 17, 18, 19}) {
+		t.Fatalf("expected the cpu_atom cores, got %v (%v)", cpus, err)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build !linux && !windows
+
+package main
+
+import "errors"
+
+var errProcessTuningUnsupported = errors.New("not supported on this platform")
+
+func applyProcessPriority(cfg ProcessConfig) error {
+	if cfg == (ProcessConfig{Cores: cfg.Cores}) {
+		return nil
+	}
+	return errProcessTuningUnsupported
+}
+
+func setProcessAffinity(cpus []int) error {
+	return errProcessTuningUnsupported
+}
+
+func efficiencyCPUs() ([]int, error) {
+	return nil, errProcessTuningUnsupported
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCPUList(t *testing.T) {
+	cpus, err := parseCPUList(" 6, 0-3 ,2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{0, 1, 2, 3, 6}; !reflect.DeepEqual(cpus, want) {
+		t.Fatalf("expected %v, got %v", want, cpus)
+	}
+	if got := formatCPUList(cpus); got != "0-3,6" {
+		t.Fatalf("expected the list to format back as 0-3,6, got %q", got)
+	}
+	for _, invalid := range []string{"", "a", "3-1", "-2"} {
+		if _, err := parseCPUList(invalid); err == nil {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+//go:build windows
+
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	processKernel32                      = windows.NewLazySystemDLL("kernel32.dll")
+	procSetProcessAffinityMask           = processKernel32.NewProc("SetProcessAffinityMask")
+	procGetLogicalProcessorInformationEx = processKernel32.NewProc("GetLogicalProcessorInformationEx")
+)
+
+const relationProcessorCore = 0
+
+func applyProcessPriority(cfg ProcessConfig) error {
+	var class uint32
+	switch strings.ToLower(strings.TrimSpace(cfg.PriorityClass)) {
+	case "":
+		return nil
+	case "idle":
+		class = windows.IDLE_PRIORITY_CLASS
+	case "below_normal":
+		class = windows.BELOW_NORMAL_PRIORITY_CLASS
+	case "normal":
+		class = windows.NORMAL_PRIORITY_CLASS
+	default:
+		return fmt.Errorf("unknown priority_class %q", cfg.PriorityClass)
+	}
+	return windows.SetPriorityClass(windows.CurrentProcess(), class)
+}
+
+// setProcessAffinity pins the process within processor group 0, which holds
+// every CPU on machines with up to 64 of them.
+func setProcessAffinity(cpus []int) error {
+	var mask uintptr
+	for _, cpu := range cpus {
+		if cpu >= bits.UintSize {
+			return fmt.Errorf("cpu %d is outside processor group 0", cpu)
+		}
+		mask |= 1 << cpu
+	}
+	if ok, _, err := procSetProcessAffinityMask.Call(uintptr(windows.CurrentProcess()), mask); ok == 0 {
+		return err
+	}
+	return nil
+}
+
+// efficiencyCPUs lists the logical CPUs of the cores with the lowest
+// efficiency class. Windows 10 and later rank hybrid cores that way; on
+// other CPUs every core shares one class.
+func efficiencyCPUs() ([]int, error) {
+	if err := procGetLogicalProcessorInformationEx.Find(); err != nil {
+		return nil, err
+	}
+	var size uint32
+	procGetLogicalProcessorInformationEx.Call(relationProcessorCore, 0, uintptr(unsafe.Pointer(&size)))
+	if size == 0 {
+		return nil, errors.New("no processor information")
+	}
+	buf := make([]byte, size)
+	if ok, _, err := procGetLogicalProcessorInformationEx.Call(relationProcessorCore, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size))); ok == 0 {
+		return nil, err
+	}
+	return parseEfficiencyCores(buf[:size])
+}
+
+// parseEfficiencyCores walks SYSTEM_LOGICAL_PROCESSOR_INFORMATION_EX records
+// of processor cores: Relationship and Size, then Flags, EfficiencyClass,
+// 20 reserved bytes, GroupCount and the pointer-aligned GROUP_AFFINITY
+// masks, of which group 0 is used.
+func parseEfficiencyCores(buf []byte) ([]int, error) {
+	const (
+		efficiencyOffset = 9
+		groupCountOffset = 30
+		groupMaskOffset  = 32
+	)
+	maskSize := int(unsafe.Sizeof(uintptr(0)))
+	classes := make(map[uint8]uint64)
+	for offset := 0; offset+groupMaskOffset+maskSize+2 <= len(buf); {
+		record := buf[offset:]
+		recordSize := int(binary.LittleEndian.Uint32(record[4:8]))
+		if recordSize <= 0 || offset+recordSize > len(buf) {
+			break
+		}
+		if binary.LittleEndian.Uint32(record[0:4]) == relationProcessorCore && binary.LittleEndian.Uint16(record[groupCountOffset:]) > 0 {
+			var mask uint64
+			if maskSize == 8 {
+				mask = binary.LittleEndian.Uint64(record[groupMaskOffset:])
+			} else {
+				mask = uint64(binary.LittleEndian.Uint32(record[groupMaskOffset:]))
+			}
+			if group := binary.LittleEndian.Uint16(record[groupMaskOffset+maskSize:]); group == 0 {
+				classes[record[efficiencyOffset]] |= mask
+			}
+		}
+		offset += recordSize
+	}
+	if len(classes) < 2 {
+		return nil, errors.New("CPU has no efficiency cores")
+	}
+	lowest := uint8(255)
+	for class := range classes {
+		if class < lowest {
+			lowest = class
+		}
+	}
+	cpus := make([]int, 0, bits.OnesCount64(classes[lowest]))
+	for mask := classes[lowest]; mask != 0; mask &= mask - 1 {
+		cpus = append(cpus, bits.TrailingZeros64(mask))
+	}
+	return cpus, nil
+}
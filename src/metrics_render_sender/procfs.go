@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+
+	gopsutilNet "github.com/shirou/gopsutil/v3/net"
+)
+
+// procDiskstatsSectorSize is the unit of the sector columns in
+// /proc/diskstats, fixed by the kernel whatever the device's sector size.
+const procDiskstatsSectorSize = 512
+
+// parseProcDiskstats reads /proc/diskstats. Lines with too few columns are
+// skipped rather than failing the whole read.
+func parseProcDiskstats(data []byte) map[string]diskCounterSample {
+	result := make(map[string]diskCounterSample)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 14 {
+			continue
+		}
+		values, ok := parseProcUintFields(fields[3:14])
+		if !ok {
+			continue
+		}
+		name := fields[2]
+		result[name] = diskCounterSample{
+			Name:        name,
+			ReadCount:   values[0],
+			ReadBytes:   values[2] * procDiskstatsSectorSize,
+			ReadTimeMS:  float64(values[3]),
+			WriteCount:  values[4],
+			WriteBytes:  values[6] * procDiskstatsSectorSize,
+			WriteTimeMS: float64(values[7]),
+			QueueDepth:  float64(values[8]),
+			BusyTimeMS:  float64(values[9]),
+		}
+	}
+	return result
+}
+
+// parseProcNetDev reads /proc/net/dev, skipping its two header lines.
+func parseProcNetDev(data []byte) []gopsutilNet.IOCountersStat {
+	result := make([]gopsutilNet.IOCountersStat, 0, 8)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, rest, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		fields := strings.Fields(rest)
+		if name == "" || len(fields) < 16 {
+			continue
+		}
+		values, ok := parseProcUintFields(fields[:16])
+		if !ok {
+			continue
+		}
+		result = append(result, gopsutilNet.IOCountersStat{
+			Name:        name,
+			BytesRecv:   values[0],
+			PacketsRecv: values[1],
+			Errin:       values[2],
+			Dropin:      values[3],
+			Fifoin:      values[4],
+			BytesSent:   values[8],
+			PacketsSent: values[9],
+			Errout:      values[10],
+			Dropout:     values[11],
+			Fifoout:     values[12],
+		})
+	}
+	return result
+}
+
+// rtfUp is RTF_UP from the route flags column of /proc/net/route.
+const rtfUp = 0x1
+
+// parseProcNetRouteDefault returns the interface of the IPv4 default route
+// with the lowest metric, or "" when there is none.
+func parseProcNetRouteDefault(data []byte) string {
+	best := ""
+	bestMetric := uint64(0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[1] != "00000000" || fields[7] != "00000000" {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil || flags&rtfUp == 0 {
+			continue
+		}
+		metric, err := strconv.ParseUint(fields[6], 10, 64)
+		if err != nil {
+			continue
+		}
+		if best == "" || metric < bestMetric {
+			best = fields[0]
+			bestMetric = metric
+		}
+	}
+	return best
+}
+
+func parseProcUintFields(fields []string) ([]uint64, bool) {
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		values[i] = value
+	}
+	return values, true
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -28,6 +29,10 @@ type ProfileManager struct {
 
 var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
 
+// profileWatchInterval is how often the running process scans the profiles
+// directory for edits made outside it.
+const profileWatchInterval = 2 * time.Second
+
 var (
 	globalProfileManagerMu sync.Mutex
 	globalProfileManager   *ProfileManager
@@ -186,6 +191,33 @@ func (pm *ProfileManager) RenameProfile(oldName, newName string) (*MonitorConfig
 	return cfg, nil
 }
 
+// WatchActive scans the profiles directory every interval and re-applies
+// the active profile through apply whenever its file is edited outside the
+// web UI, e.g. by hand or by a sync tool. Saves made by the web UI are
+// recognised and not applied a second time.
+func (pm *ProfileManager) WatchActive(interval time.Duration, apply func(*MonitorConfig) error) (stop func()) {
+	return NewConfigManager(pm.profilesDir).Watch(interval, func(change ConfigDirChange) {
+		active := pm.ActiveName()
+		if !slices.Contains(change.Changed, active) {
+			return
+		}
+		cfg, err := pm.Switch(active)
+		if err != nil {
+			logWarnModule("config", "profile %s changed on disk but could not be loaded: %v", active, err)
+			return
+		}
+		if runningConfigEquals(cfg) {
+			return
+		}
+		UpdateRunningConfigStore(cfg)
+		if err := apply(cfg); err != nil {
+			logWarnModule("config", "profile %s changed on disk but could not be applied: %v", active, err)
+			return
+		}
+		logInfoModule("config", "reloaded profile %s after it changed on disk", active)
+	})
+}
+
 func (pm *ProfileManager) Switch(name string) (*MonitorConfig, error) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
@@ -203,6 +235,33 @@ func (pm *ProfileManager) Switch(name string) (*MonitorConfig, error) {
 	return cfg, nil
 }
 
+// FindByResolution returns the profile laid out for a width x height panel.
+// A profile named "<width>x<height>" wins; otherwise the first one in name
+// order whose canvas has that size.
+func (pm *ProfileManager) FindByResolution(width, height int) (string, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	preferred := fmt.Sprintf("%dx%d", width, height)
+	if pm.profileExistsUnsafe(preferred) {
+		return preferred, true
+	}
+	items, err := pm.listUnsafe()
+	if err != nil {
+		return "", false
+	}
+	for _, item := range items {
+		cfg, err := pm.loadProfileUnsafe(item.Name)
+		if err != nil {
+			continue
+		}
+		if cfg.Width == width && cfg.Height == height {
+			return item.Name, true
+		}
+	}
+	return "", false
+}
+
 func (pm *ProfileManager) DeleteProfile(name string) error {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
@@ -309,13 +368,13 @@ func (pm *ProfileManager) loadProfileUnsafe(name string) (*MonitorConfig, error)
 		return nil, fmt.Errorf("failed to read profile '%s': %w", name, err)
 	}
 
-	var cfg MonitorConfig
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	cfg, err := decodeMonitorConfig(data)
+	if err != nil {
 		return nil, fmt.Errorf("invalid profile '%s': %w", name, err)
 	}
-	normalizeMonitorConfig(&cfg)
+	normalizeMonitorConfig(cfg)
 	cfg.Name = name
-	return &cfg, nil
+	return cfg, nil
 }
 
 func (pm *ProfileManager) listUnsafe() ([]ProfileInfo, error) {
@@ -358,3 +417,65 @@ func validateProfileName(name string) error {
 	}
 	return nil
 }
+
+// switchActiveProfile makes name the active profile and applies it to the
+// running runtime and web config store.
+func switchActiveProfile(name string) error {
+	profileName := strings.TrimSpace(name)
+	if profileName == "" {
+		return fmt.Errorf("profile name is empty")
+	}
+
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return err
+	}
+	profiles, err := GetProfileManagerWithPath(configPath)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := profiles.Switch(profileName)
+	if err != nil {
+		return err
+	}
+	if err := ApplyConfigToSharedWebAPI(cfg); err != nil {
+		return err
+	}
+	UpdateRunningConfigStore(cfg)
+	return nil
+}
+
+// switchAdjacentProfile activates the profile step positions away from the
+// active one in name order, wrapping around, and returns its name.
+func switchAdjacentProfile(step int) (string, error) {
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return "", err
+	}
+	profiles, err := GetProfileManagerWithPath(configPath)
+	if err != nil {
+		return "", err
+	}
+	items, err := profiles.List()
+	if err != nil {
+		return "", err
+	}
+	if len(items) < 2 {
+		return "", fmt.Errorf("no other profile to switch to")
+	}
+	active := profiles.ActiveName()
+	current := 0
+	for idx, item := range items {
+		if item.Name == active {
+			current = idx
+			break
+		}
+	}
+	next := ((current+step)%len(items) + len(items)) % len(items)
+	name := items[next].Name
+	if err := switchActiveProfile(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	providerLiquidctl            = "liquidctl"
+	defaultLiquidctlCommand      = "liquidctl"
+	defaultLiquidctlInterval     = 2 * time.Second
+	defaultLiquidctlTimeout      = 5 * time.Second
+	liquidctlMinInterval         = 500 * time.Millisecond
+	liquidctlMaxStatusOutputSize = 1 << 20
+)
+
+func init() {
+	RegisterMonitorProvider(providerLiquidctl, newLiquidctlProvider)
+}
+
+// liquidctlDevice is one entry of `liquidctl status --json`.
+type liquidctlDevice struct {
+	Description string `json:"description"`
+	Status      []struct {
+		Key   string      `json:"key"`
+		Value interface{} `json:"value"`
+		Unit  string      `json:"unit"`
+	} `json:"status"`
+}
+
+type liquidctlReading struct {
+	descriptor MonitorDescriptor
+	value      float64
+}
+
+// liquidctlProvider polls `liquidctl status --json` for AIO coolers, pumps
+// and fan hubs. liquidctl takes a few hundred milliseconds per call, so the
+// output is cached for interval_ms and shared by Monitors and Read.
+//
+// Options: command (default "liquidctl"), match (passed as --match to pick
+// devices by description) and interval_ms (default 2000).
+type liquidctlProvider struct {
+	run      func(ctx context.Context) ([]byte, error)
+	interval time.Duration
+
+	mu       sync.Mutex
+	readAt   time.Time
+	readings []liquidctlReading
+	readErr  error
+}
+
+func newLiquidctlProvider(options map[string]interface{}) (MonitorProvider, error) {
+	command := strings.TrimSpace(providerStringOption(options, "command"))
+	if command == "" {
+		command = defaultLiquidctlCommand
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, fmt.Errorf("liquidctl not found: %w", err)
+	}
+	args := []string{"status", "--json"}
+	if match := strings.TrimSpace(providerStringOption(options, "match")); match != "" {
+		args = append(args, "--match", match)
+	}
+	interval := defaultLiquidctlInterval
+	if ms, err := strconv.Atoi(providerStringOption(options, "interval_ms")); err == nil && ms > 0 {
+		interval = time.Duration(ms) * time.Millisecond
+	}
+	if interval < liquidctlMinInterval {
+		interval = liquidctlMinInterval
+	}
+	return &liquidctlProvider{
+		run: func(ctx context.Context) ([]byte, error) {
+			return runProviderCommand(ctx, liquidctlMaxStatusOutputSize, path, args...)
+		},
+		interval: interval,
+	}, nil
+}
+
+func (p *liquidctlProvider) Monitors() ([]MonitorDescriptor, error) {
+	readings, err := p.status(context.Background())
+	descriptors := make([]MonitorDescriptor, 0, len(readings))
+	for _, reading := range readings {
+		descriptors = append(descriptors, reading.descriptor)
+	}
+	return descriptors, err
+}
+
+func (p *liquidctlProvider) Read() (map[string]interface{}, error) {
+	return p.ReadContext(context.Background())
+}
+
+// ReadContext stops a liquidctl run that outlives the collect timeout, e.g.
+// one stuck on a USB device that stopped answering.
+func (p *liquidctlProvider) ReadContext(ctx context.Context) (map[string]interface{}, error) {
+	readings, err := p.status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(readings))
+	for _, reading := range readings {
+		values[reading.descriptor.Name] = reading.value
+	}
+	return values, nil
+}
+
+func (p *liquidctlProvider) status(ctx context.Context) ([]liquidctlReading, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.readAt.IsZero() && time.Since(p.readAt) < p.interval {
+		return p.readings, p.readErr
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultLiquidctlTimeout)
+	defer cancel()
+	output, err := p.run(ctx)
+	var readings []liquidctlReading
+	if err == nil {
+		readings, err = parseLiquidctlStatus(output)
+	}
+	p.readAt = time.Now()
+	p.readings = readings
+	p.readErr = err
+	return readings, err
+}
+
+// parseLiquidctlStatus names each numeric status entry <device>.<key>, e.g.
+// nzxt_kraken_x.liquid_temperature, numbering devices that share a name.
+func parseLiquidctlStatus(data []byte) ([]liquidctlReading, error) {
+	var devices []liquidctlDevice
+	if err := json.Unmarshal(data, &devices); err != nil {
+		return nil, fmt.Errorf("invalid liquidctl status: %w", err)
+	}
+	readings := make([]liquidctlReading, 0)
+	seenDevices := make(map[string]int)
+	for idx, device := range devices {
+		deviceName := liquidctlDeviceSlug(device.Description)
+		if deviceName == "" {
+			deviceName = fmt.Sprintf("device%d", idx+1)
+		}
+		seenDevices[deviceName]++
+		if count := seenDevices[deviceName]; count > 1 {
+			deviceName = fmt.Sprintf("%s_%d", deviceName, count)
+		}
+		for _, entry := range device.Status {
+			value, ok := entry.Value.(float64)
+			if !ok {
+				continue
+			}
+			key := slugifyProviderName(entry.Key)
+			if key == "" {
+				continue
+			}
+			unit, precision, maxValue := liquidctlUnit(entry.Unit)
+			readings = append(readings, liquidctlReading{
+				descriptor: MonitorDescriptor{
+					Name:      deviceName + "." + key,
+					Label:     strings.TrimSpace(device.Description + " " + entry.Key),
+					Unit:      unit,
+					Max:       maxValue,
+					Precision: precision,
+				},
+				value: value,
+			})
+		}
+	}
+	return readings, nil
+}
+
+// liquidctlDeviceSlug keeps the model part of a description such as
+// "NZXT Kraken X (X53, X63 or X73)", which becomes "nzxt_kraken_x".
+func liquidctlDeviceSlug(description string) string {
+	if idx := strings.Index(description, "("); idx > 0 {
+		description = description[:idx]
+	}
+	return slugifyProviderName(description)
+}
+
+func liquidctlUnit(unit string) (string, int, float64) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "°c":
+		return "°C", 1, 60
+	case "rpm":
+		return "RPM", 0, 0
+	case "%":
+		return "%", 0, 100
+	case "v":
+		return "V", 2, 0
+	case "a":
+		return "A", 2, 0
+	case "w":
+		return "W", 1, 0
+	default:
+		return strings.TrimSpace(unit), 1, 0
+	}
+}
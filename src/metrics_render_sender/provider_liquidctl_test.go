@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+const liquidctlStatusSample = `[
+  {"bus": "hid", "address": "/dev/hidraw3", "description": "NZXT Kraken X (X53, X63 or X73)", "status": [
+    {"key": "Liquid temperature", "value": 31.2, "unit": "°C"},
+    {"key": "Pump speed", "value": 2484, "unit": "rpm"},
+    {"key": "Pump duty", "value": 74, "unit": "%"},
+    {"key": "Firmware version", "value": "6.0.2", "unit": ""}
+  ]},
+  {"bus": "hid", "address": "/dev/hidraw5", "description": "NZXT Kraken X (X53, X63 or X73)", "status": [
+    {"key": "Liquid temperature", "value": 29.8, "unit": "°C"}
+  ]},
+  {"bus": "hid", "address": "/dev/hidraw7", "description": "Corsair Commander Pro", "status": [
+    {"key": "Fan 1 speed", "value": 912, "unit": "rpm"},
+    {"key": "+12V rail", "value": 12.05, "unit": "V"}
+  ]}
+]`
+
+func TestParseLiquidctlStatus(t *testing.T) {
+	readings, err := parseLiquidctlStatus([]byte(liquidctlStatusSample))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := make(map[string]liquidctlReading, len(readings))
+	for _, reading := range readings {
+		got[reading.descriptor.Name] = reading
+	}
+	if len(got) != 6 {
+		t.Fatalf("expected 6 numeric readings, got %d: %v", len(got), got)
+	}
+	temp := got["nzxt_kraken_x.liquid_temperature"]
+	if temp.value != 31.2 || temp.descriptor.Unit != "°C" || temp.descriptor.Precision != 1 {
+		t.Fatalf("unexpected coolant reading: %+v", temp)
+	}
+	if pump := got["nzxt_kraken_x.pump_speed"]; pump.value != 2484 || pump.descriptor.Unit != "RPM" {
+		t.Fatalf("unexpected pump reading: %+v", pump)
+	}
+	if _, ok := got["nzxt_kraken_x_2.liquid_temperature"]; !ok {
+		t.Fatal("expected the second identical device to be numbered")
+	}
+	if fan := got["corsair_commander_pro.fan_1_speed"]; fan.value != 912 {
+		t.Fatalf("unexpected fan reading: %+v", fan)
+	}
+	if rail := got["corsair_commander_pro.12v_rail"]; rail.descriptor.Unit != "V" {
+		t.Fatalf("unexpected rail reading: %+v", rail)
+	}
+
+	if _, err := parseLiquidctlStatus([]byte("not json")); err == nil {
+		t.Fatal("expected an error for invalid output")
+	}
+}
+
+func TestLiquidctlProviderCachesStatus(t *testing.T) {
+	calls := 0
+	provider := &liquidctlProvider{
+		run: func(ctx context.Context) ([]byte, error) {
+			calls++
+			return []byte(liquidctlStatusSample), nil
+		},
+		interval: time.Hour,
+	}
+	monitors, err := provider.Monitors()
+	if err != nil || len(monitors) != 6 {
+		t.Fatalf("monitors: %v %v", monitors, err)
+	}
+	values, err := provider.Read()
+	if err != nil || values["nzxt_kraken_x.pump_duty"] != 74.0 {
+		t.Fatalf("read: %v %v", values, err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected Monitors and Read to share one liquidctl call, got %d", calls)
+	}
+}
+
+func TestRunProviderCommandStopsAtLimit(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	startedAt := time.Now()
+	_, err = runProviderCommand(context.Background(), 1024, sh, "-c", "exec yes")
+	if err == nil || !strings.Contains(err.Error(), "larger than 1024 bytes") {
+		t.Fatalf("expected the output limit to stop the command, got %v", err)
+	}
+	if elapsed := time.Since(startedAt); elapsed > 5*time.Second {
+		t.Fatalf("expected the command to be killed, took %v", elapsed)
+	}
+
+	output, err := runProviderCommand(context.Background(), 1024, sh, "-c", "echo ok")
+	if err != nil || strings.TrimSpace(string(output)) != "ok" {
+		t.Fatalf("unexpected output %q: %v", output, err)
+	}
+	if _, err := runProviderCommand(context.Background(), 1024, sh, "-c", "echo no device >&2; exit 2"); err == nil || !strings.Contains(err.Error(), "no device") {
+		t.Fatalf("expected stderr in the error, got %v", err)
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	providerSpeedtest        = "speedtest"
+	defaultSpeedtestInterval = 6 * time.Hour
+	speedtestMinInterval     = time.Hour
+	speedtestStartDelay      = time.Minute
+	speedtestTimeout         = 2 * time.Minute
+	speedtestMaxOutputSize   = 1 << 20
+	speedtestCloudflareURL   = "https://speed.cloudflare.com"
+	speedtestDownloadBytes   = 25 << 20
+	speedtestUploadBytes     = 10 << 20
+	speedtestPingSamples     = 5
+)
+
+func init() {
+	RegisterMonitorProvider(providerSpeedtest, newSpeedtestProvider)
+}
+
+type speedtestResult struct {
+	DownloadMbps float64
+	UploadMbps   float64
+	PingMS       float64
+}
+
+// speedtestProvider measures the internet connection every interval_hours
+// on its own goroutine. Read only returns the last result, so a test never
+// runs on the refresh path, and the first one waits a minute after start so
+// restarts while editing a layout do not each cost a test.
+//
+// Options: backend ("cloudflare", the default, "speedtest-cli" or "ookla"),
+// command (the binary for the two command backends) and interval_hours
+// (default 6, at least 1).
+type speedtestProvider struct {
+	run        func(ctx context.Context) (speedtestResult, error)
+	interval   time.Duration
+	startDelay time.Duration
+
+	mu     sync.Mutex
+	result speedtestResult
+	ok     bool
+
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+func newSpeedtestProvider(options map[string]interface{}) (MonitorProvider, error) {
+	run, err := newSpeedtestRunner(options)
+	if err != nil {
+		return nil, err
+	}
+	interval := defaultSpeedtestInterval
+	if hours, err := strconv.ParseFloat(strings.TrimSpace(providerStringOption(options, "interval_hours")), 64); err == nil && hours > 0 {
+		interval = time.Duration(hours * float64(time.Hour))
+	}
+	if interval < speedtestMinInterval {
+		interval = speedtestMinInterval
+	}
+	provider := &speedtestProvider{run: run, interval: interval, startDelay: speedtestStartDelay}
+	provider.start()
+	return provider, nil
+}
+
+func newSpeedtestRunner(options map[string]interface{}) (func(ctx context.Context) (speedtestResult, error), error) {
+	backend := strings.ToLower(strings.TrimSpace(providerStringOption(options, "backend")))
+	command := strings.TrimSpace(providerStringOption(options, "command"))
+	var args []string
+	switch backend {
+	case "", "cloudflare":
+		cf := speedtestCloudflare{
+			baseURL:       speedtestCloudflareURL,
+			client:        &http.Client{},
+			downloadBytes: speedtestDownloadBytes,
+			uploadBytes:   speedtestUploadBytes,
+		}
+		return cf.run, nil
+	case "speedtest-cli":
+		if command == "" {
+			command = "speedtest-cli"
+		}
+		args = []string{"--json"}
+	case "ookla":
+		if command == "" {
+			command = "speedtest"
+		}
+		args = []string{"--format=json", "--accept-license", "--accept-gdpr"}
+	default:
+		return nil, fmt.Errorf("unknown speedtest backend %q", backend)
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, fmt.Errorf("%s not found: %w", command, err)
+	}
+	return func(ctx context.Context) (speedtestResult, error) {
+		output, err := exec.CommandContext(ctx, path, args...).Output()
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return speedtestResult{}, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		if err != nil {
+			return speedtestResult{}, err
+		}
+		if len(output) > speedtestMaxOutputSize {
+			return speedtestResult{}, fmt.Errorf("output larger than %d bytes", speedtestMaxOutputSize)
+		}
+		return parseSpeedtestJSON(output)
+	}, nil
+}
+
+func (p *speedtestProvider) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.doneCh = make(chan struct{})
+	go p.loop(ctx)
+}
+
+func (p *speedtestProvider) loop(ctx context.Context) {
+	defer close(p.doneCh)
+	wait := p.startDelay
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		wait = p.interval
+		testCtx, cancel := context.WithTimeout(ctx, speedtestTimeout)
+		result, err := p.run(testCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			// Keep the previous result; a failed run says little about the
+			// line and the next one is hours away.
+			logWarnModule("collector", "speedtest failed: %v", err)
+			continue
+		}
+		logInfoModule("collector", "speedtest: down %.1f Mbit/s, up %.1f Mbit/s, ping %.0f ms", result.DownloadMbps, result.UploadMbps, result.PingMS)
+		p.mu.Lock()
+		p.result = result
+		p.ok = true
+		p.mu.Unlock()
+	}
+}
+
+// Close stops the schedule and cancels a test in progress.
+func (p *speedtestProvider) Close() error {
+	p.cancel()
+	<-p.doneCh
+	return nil
+}
+
+func (p *speedtestProvider) Monitors() ([]MonitorDescriptor, error) {
+	return []MonitorDescriptor{
+		{Name: "download", Label: "Speedtest download", Unit: "Mbit/s", Precision: 1},
+		{Name: "upload", Label: "Speedtest upload", Unit: "Mbit/s", Precision: 1},
+		{Name: "ping", Label: "Speedtest ping", Unit: "ms", Precision: 0},
+	}, nil
+}
+
+func (p *speedtestProvider) Read() (map[string]interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.ok {
+		return map[string]interface{}{}, nil
+	}
+	return map[string]interface{}{
+		"download": p.result.DownloadMbps,
+		"upload":   p.result.UploadMbps,
+		"ping":     p.result.PingMS,
+	}, nil
+}
+
+// parseSpeedtestJSON reads both speedtest-cli --json (bits per second at the
+// top level) and Ookla's --format=json (bytes per second under bandwidth).
+func parseSpeedtestJSON(data []byte) (speedtestResult, error) {
+	var report map[string]json.RawMessage
+	if err := json.Unmarshal(data, &report); err != nil {
+		return speedtestResult{}, fmt.Errorf("invalid speedtest output: %w", err)
+	}
+	var cli struct {
+		Download *float64 `json:"download"`
+		Upload   *float64 `json:"upload"`
+		Ping     *float64 `json:"ping"`
+	}
+	// Ookla's objects fail to decode into numbers, which is how the two are
+	// told apart.
+	if err := json.Unmarshal(data, &cli); err == nil && cli.Download != nil && cli.Upload != nil && cli.Ping != nil {
+		return speedtestResult{
+			DownloadMbps: *cli.Download / 1e6,
+			UploadMbps:   *cli.Upload / 1e6,
+			PingMS:       *cli.Ping,
+		}, nil
+	}
+	var ookla struct {
+		Ping *struct {
+			Latency float64 `json:"latency"`
+		} `json:"ping"`
+		Download *struct {
+			Bandwidth float64 `json:"bandwidth"`
+		} `json:"download"`
+		Upload *struct {
+			Bandwidth float64 `json:"bandwidth"`
+		} `json:"upload"`
+	}
+	if err := json.Unmarshal(data, &ookla); err == nil && ookla.Ping != nil && ookla.Download != nil && ookla.Upload != nil {
+		return speedtestResult{
+			DownloadMbps: ookla.Download.Bandwidth * 8 / 1e6,
+			UploadMbps:   ookla.Upload.Bandwidth * 8 / 1e6,
+			PingMS:       ookla.Ping.Latency,
+		}, nil
+	}
+	return speedtestResult{}, errors.New("speedtest output has no download, upload and ping")
+}
+
+// speedtestCloudflare measures against speed.cloudflare.com: ping is the
+// median time to first byte of empty downloads, then one download and one
+// upload of fixed size are timed.
+type speedtestCloudflare struct {
+	baseURL       string
+	client        *http.Client
+	downloadBytes int
+	uploadBytes   int
+}
+
+func (s speedtestCloudflare) run(ctx context.Context) (speedtestResult, error) {
+	pings := make([]float64, 0, speedtestPingSamples)
+	for i := 0; i < speedtestPingSamples; i++ {
+		_, firstByte, err := s.download(ctx, 0)
+		if err != nil {
+			return speedtestResult{}, fmt.Errorf("ping: %w", err)
+		}
+		pings = append(pings, float64(firstByte)/float64(time.Millisecond))
+	}
+	sort.Float64s(pings)
+
+	received, elapsed, err := s.downloadTimed(ctx)
+	if err != nil {
+		return speedtestResult{}, fmt.Errorf("download: %w", err)
+	}
+	sent, uploadElapsed, err := s.upload(ctx)
+	if err != nil {
+		return speedtestResult{}, fmt.Errorf("upload: %w", err)
+	}
+	return speedtestResult{
+		DownloadMbps: megabitsPerSecond(received, elapsed),
+		UploadMbps:   megabitsPerSecond(sent, uploadElapsed),
+		PingMS:       pings[len(pings)/2],
+	}, nil
+}
+
+func (s speedtestCloudflare) downloadTimed(ctx context.Context) (int64, time.Duration, error) {
+	startedAt := time.Now()
+	received, _, err := s.download(ctx, s.downloadBytes)
+	return received, time.Since(startedAt), err
+}
+
+// download fetches size bytes and returns how many arrived and the time to
+// the response headers.
+func (s speedtestCloudflare) download(ctx context.Context, size int) (int64, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/__down?bytes="+strconv.Itoa(size), nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	startedAt := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	firstByte := time.Since(startedAt)
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	received, err := io.Copy(io.Discard, resp.Body)
+	return received, firstByte, err
+}
+
+func (s speedtestCloudflare) upload(ctx context.Context) (int64, time.Duration, error) {
+	body := bytes.NewReader(make([]byte, s.uploadBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/__up", body)
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	startedAt := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, speedtestMaxOutputSize))
+	resp.Body.Close()
+	elapsed := time.Since(startedAt)
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return int64(s.uploadBytes), elapsed, nil
+}
+
+func megabitsPerSecond(transferred int64, elapsed time.Duration) float64 {
+	if transferred <= 0 || elapsed <= 0 {
+		return 0
+	}
+	return float64(transferred) * 8 / 1e6 / elapsed.Seconds()
+}
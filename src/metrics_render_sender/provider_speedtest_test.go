@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseSpeedtestJSON(t *testing.T) {
+	cli := `{"download": 93456789.5, "upload": 12000000.0, "ping": 14.2, "server": {"name": "Example"}}`
+	result, err := parseSpeedtestJSON([]byte(cli))
+	if err != nil || result.DownloadMbps != 93.4567895 || result.UploadMbps != 12 || result.PingMS != 14.2 {
+		t.Fatalf("unexpected speedtest-cli result: %+v %v", result, err)
+	}
+
+	ookla := `{"type": "result", "ping": {"jitter": 0.4, "latency": 9.8}, "download": {"bandwidth": 11750000, "bytes": 1}, "upload": {"bandwidth": 2500000}}`
+	result, err = parseSpeedtestJSON([]byte(ookla))
+	if err != nil || result.DownloadMbps != 94 || result.UploadMbps != 20 || result.PingMS != 9.8 {
+		t.Fatalf("unexpected ookla result: %+v %v", result, err)
+	}
+
+	for _, bad := range []string{"not json", `{"download": 1}`, `{"type": "log"}`} {
+		if _, err := parseSpeedtestJSON([]byte(bad)); err == nil {
+			t.Fatalf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestSpeedtestCloudflareMeasures(t *testing.T) {
+	var uploaded int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/__down":
+			size, _ := strconv.Atoi(r.URL.Query().Get("bytes"))
+			_, _ = w.Write(make([]byte, size))
+		case "/__up":
+			uploaded, _ = io.Copy(io.Discard, r.Body)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cf := speedtestCloudflare{baseURL: server.URL, client: server.Client(), downloadBytes: 1 << 20, uploadBytes: 512 << 10}
+	result, err := cf.run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DownloadMbps <= 0 || result.UploadMbps <= 0 || result.PingMS < 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if uploaded != 512<<10 {
+		t.Fatalf("expected the whole upload to arrive, got %d bytes", uploaded)
+	}
+
+	cf.baseURL = server.URL + "/missing"
+	if _, err := cf.run(context.Background()); err == nil {
+		t.Fatal("expected an error for a failing endpoint")
+	}
+}
+
+func TestSpeedtestProviderRunsOffTheReadPath(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	runs := make(chan struct{}, 4)
+	provider := &speedtestProvider{
+		run: func(ctx context.Context) (speedtestResult, error) {
+			runs <- struct{}{}
+			return speedtestResult{DownloadMbps: 500, UploadMbps: 50, PingMS: 8}, nil
+		},
+		interval:   time.Hour,
+		startDelay: 10 * time.Millisecond,
+	}
+	values, err := provider.Read()
+	if err != nil || len(values) != 0 {
+		t.Fatalf("expected no values before the first test, got %v %v", values, err)
+	}
+	provider.start()
+	defer provider.Close()
+
+	select {
+	case <-runs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the first test after the start delay")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if values, _ = provider.Read(); len(values) == 3 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if values["download"] != 500.0 || values["upload"] != 50.0 || values["ping"] != 8.0 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+	if len(runs) != 0 {
+		t.Fatal("expected no further test within the interval")
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gopsutilNet "github.com/shirou/gopsutil/v3/net"
+)
+
+const (
+	providerVPN                = "vpn"
+	defaultVPNWireGuardCommand = "wg"
+	defaultVPNInterval         = 5 * time.Second
+	defaultVPNTimeout          = 5 * time.Second
+	vpnMinInterval             = time.Second
+	vpnMaxDumpSize             = 1 << 20
+	// vpnHandshakeStaleAfter is when a WireGuard peer counts as gone:
+	// handshakes are renewed every two minutes while traffic flows, and
+	// keepalives keep idle tunnels within that.
+	vpnHandshakeStaleAfter = 3 * time.Minute
+)
+
+// vpnInterfacePrefixes are the names found by auto-detection besides the
+// interfaces `wg` reports: WireGuard, OpenVPN tun/tap, PPP and macOS utun.
+var vpnInterfacePrefixes = []string{"wg", "tun", "tap", "ppp", "utun"}
+
+func init() {
+	RegisterMonitorProvider(providerVPN, newVPNProvider)
+}
+
+// vpnTunnel is the state of one tunnel interface. Handshake and endpoint
+// come from its most recently active WireGuard peer.
+type vpnTunnel struct {
+	Name      string
+	LinkUp    bool
+	WireGuard bool
+	Peers     int
+	Handshake time.Time
+	Endpoint  string
+}
+
+// up is what the .up monitor shows: the link is up and, for WireGuard,
+// a peer answered recently. A WireGuard link stays up with the peer gone.
+func (t vpnTunnel) up(now time.Time) bool {
+	if !t.LinkUp {
+		return false
+	}
+	if !t.WireGuard || t.Peers == 0 {
+		return true
+	}
+	return !t.Handshake.IsZero() && now.Sub(t.Handshake) <= vpnHandshakeStaleAfter
+}
+
+// vpnProvider reports tunnel interfaces as vpn.<tunnel>.up, .handshake_age
+// and .endpoint, plus vpn.active with the number of tunnels up. Presence
+// works for any tunnel; handshake and endpoint need `wg show all dump`,
+// which has to run as root or with CAP_NET_ADMIN.
+//
+// Options: interfaces (comma separated names, default: every wg*, tun*,
+// tap*, ppp* and utun* interface plus those wg reports), command (default
+// "wg") and interval_ms (default 5000).
+type vpnProvider struct {
+	interfaces []string
+	links      func() (map[string]bool, error)
+	dump       func(ctx context.Context) ([]byte, error)
+	now        func() time.Time
+	interval   time.Duration
+
+	mu       sync.Mutex
+	readAt   time.Time
+	tunnels  []vpnTunnel
+	readErr  error
+	dumpFail string
+}
+
+func newVPNProvider(options map[string]interface{}) (MonitorProvider, error) {
+	provider := &vpnProvider{
+		interfaces: parseVPNInterfaceList(providerStringOption(options, "interfaces")),
+		links:      readVPNLinks,
+		now:        time.Now,
+		interval:   defaultVPNInterval,
+	}
+	if ms, err := strconv.Atoi(providerStringOption(options, "interval_ms")); err == nil && ms > 0 {
+		provider.interval = time.Duration(ms) * time.Millisecond
+	}
+	if provider.interval < vpnMinInterval {
+		provider.interval = vpnMinInterval
+	}
+	command := strings.TrimSpace(providerStringOption(options, "command"))
+	if command == "" {
+		command = defaultVPNWireGuardCommand
+	}
+	// Without wg the provider still reports which tunnels are up.
+	if path, err := exec.LookPath(command); err == nil {
+		provider.dump = func(ctx context.Context) ([]byte, error) {
+			return runProviderCommand(ctx, vpnMaxDumpSize, path, "show", "all", "dump")
+		}
+	}
+	return provider, nil
+}
+
+func (p *vpnProvider) Monitors() ([]MonitorDescriptor, error) {
+	tunnels, err := p.status()
+	descriptors := []MonitorDescriptor{{Name: "active", Label: "VPN tunnels up"}}
+	for _, tunnel := range tunnels {
+		slug := slugifyProviderName(tunnel.Name)
+		descriptors = append(descriptors, MonitorDescriptor{Name: slug + ".up", Label: "VPN " + tunnel.Name + " up", Max: 1})
+		if !tunnel.WireGuard {
+			continue
+		}
+		descriptors = append(descriptors,
+			MonitorDescriptor{Name: slug + ".handshake_age", Label: "VPN " + tunnel.Name + " handshake age", Unit: "s", Max: vpnHandshakeStaleAfter.Seconds()},
+			MonitorDescriptor{Name: slug + ".endpoint", Label: "VPN " + tunnel.Name + " endpoint"},
+		)
+	}
+	return descriptors, err
+}
+
+func (p *vpnProvider) Read() (map[string]interface{}, error) {
+	tunnels, err := p.status()
+	if err != nil {
+		return nil, err
+	}
+	now := p.now()
+	values := make(map[string]interface{}, len(tunnels)*3+1)
+	active := 0
+	for _, tunnel := range tunnels {
+		slug := slugifyProviderName(tunnel.Name)
+		up := 0.0
+		if tunnel.up(now) {
+			up = 1
+			active++
+		}
+		values[slug+".up"] = up
+		// The age is worked out on every read so it keeps counting between
+		// polls of wg.
+		if !tunnel.Handshake.IsZero() {
+			age := now.Sub(tunnel.Handshake)
+			if age < 0 {
+				age = 0
+			}
+			values[slug+".handshake_age"] = age.Seconds()
+		}
+		if tunnel.Endpoint != "" {
+			values[slug+".endpoint"] = tunnel.Endpoint
+		}
+	}
+	values["active"] = float64(active)
+	return values, nil
+}
+
+func (p *vpnProvider) status() ([]vpnTunnel, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	if !p.readAt.IsZero() && now.Sub(p.readAt) < p.interval {
+		return p.tunnels, p.readErr
+	}
+	p.readAt = now
+
+	links, err := p.links()
+	if err != nil {
+		p.tunnels, p.readErr = nil, err
+		return nil, err
+	}
+	var wireGuard map[string]vpnTunnel
+	if p.dump != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultVPNTimeout)
+		output, dumpErr := p.dump(ctx)
+		cancel()
+		if dumpErr == nil {
+			wireGuard = parseWireGuardDump(output)
+			p.dumpFail = ""
+		} else if message := dumpErr.Error(); message != p.dumpFail {
+			// Most likely missing privileges; keep reporting presence and
+			// log each distinct failure once.
+			p.dumpFail = message
+			logWarnModule("collector", "vpn: wg show failed, handshakes unavailable: %v", dumpErr)
+		}
+	}
+	p.tunnels, p.readErr = buildVPNTunnels(p.interfaces, links, wireGuard), nil
+	return p.tunnels, nil
+}
+
+// buildVPNTunnels lists the configured interfaces, or every tunnel-like
+// link and WireGuard interface when none are configured.
+func buildVPNTunnels(configured []string, links map[string]bool, wireGuard map[string]vpnTunnel) []vpnTunnel {
+	names := configured
+	if len(names) == 0 {
+		seen := make(map[string]struct{})
+		for name := range links {
+			if isVPNInterfaceName(name) {
+				seen[name] = struct{}{}
+			}
+		}
+		for name := range wireGuard {
+			seen[name] = struct{}{}
+		}
+		names = make([]string, 0, len(seen))
+		for name := range seen {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	tunnels := make([]vpnTunnel, 0, len(names))
+	for _, name := range names {
+		tunnel, ok := wireGuard[name]
+		if !ok {
+			tunnel = vpnTunnel{Name: name}
+		}
+		tunnel.LinkUp = links[name]
+		tunnels = append(tunnels, tunnel)
+	}
+	return tunnels
+}
+
+// parseWireGuardDump reads `wg show all dump`: per interface one line of
+// five fields (interface, private key, public key, port, fwmark) followed
+// by a line of nine per peer (interface, public key, preshared key,
+// endpoint, allowed ips, latest handshake, rx, tx, keepalive).
+func parseWireGuardDump(data []byte) map[string]vpnTunnel {
+	tunnels := make(map[string]vpnTunnel)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		switch len(fields) {
+		case 5:
+			name := strings.TrimSpace(fields[0])
+			if _, ok := tunnels[name]; !ok && name != "" {
+				tunnels[name] = vpnTunnel{Name: name, WireGuard: true}
+			}
+		case 9:
+			name := strings.TrimSpace(fields[0])
+			tunnel, ok := tunnels[name]
+			if !ok {
+				continue
+			}
+			tunnel.Peers++
+			seconds, err := strconv.ParseInt(fields[5], 10, 64)
+			if err == nil && seconds > 0 {
+				handshake := time.Unix(seconds, 0)
+				if handshake.After(tunnel.Handshake) {
+					tunnel.Handshake = handshake
+					tunnel.Endpoint = wireGuardEndpoint(fields[3])
+				}
+			}
+			if tunnel.Endpoint == "" && tunnel.Handshake.IsZero() {
+				tunnel.Endpoint = wireGuardEndpoint(fields[3])
+			}
+			tunnels[name] = tunnel
+		}
+	}
+	return tunnels
+}
+
+func wireGuardEndpoint(field string) string {
+	field = strings.TrimSpace(field)
+	if field == "(none)" {
+		return ""
+	}
+	return field
+}
+
+func isVPNInterfaceName(name string) bool {
+	for _, prefix := range vpnInterfacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseVPNInterfaceList(value string) []string {
+	names := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, part := range strings.Split(value, ",") {
+		name := strings.TrimSpace(part)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
+
+// readVPNLinks maps every interface name to whether it is up.
+func readVPNLinks() (map[string]bool, error) {
+	interfaces, err := gopsutilNet.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	links := make(map[string]bool, len(interfaces))
+	for _, iface := range interfaces {
+		name := strings.TrimSpace(iface.Name)
+		if name == "" {
+			continue
+		}
+		links[name] = false
+		for _, flag := range iface.Flags {
+			if flag == "up" {
+				links[name] = true
+				break
+			}
+		}
+	}
+	return links, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const wireGuardDumpSample = "wg0\tcHJpdmF0ZQ==\tcHVibGlj\t51820\toff\n" +
+	"wg0\tcGVlcjE=\t(none)\t203.0.113.7:51820\t10.8.0.0/24\t1767225500\t1024\t2048\t25\n" +
+	"wg0\tcGVlcjI=\t(none)\t198.51.100.9:51820\t10.9.0.0/24\t1767225590\t10\t20\toff\n" +
+	"wg1\tcHJpdmF0ZQ==\tcHVibGlj\t51821\toff\n" +
+	"wg1\tcGVlcjM=\t(none)\t(none)\t10.10.0.2/32\t0\t0\t0\toff\n"
+
+func TestParseWireGuardDump(t *testing.T) {
+	tunnels := parseWireGuardDump([]byte(wireGuardDumpSample))
+	wg0 := tunnels["wg0"]
+	if !wg0.WireGuard || wg0.Peers != 2 {
+		t.Fatalf("unexpected wg0: %+v", wg0)
+	}
+	if wg0.Handshake.Unix() != 1767225590 || wg0.Endpoint != "198.51.100.9:51820" {
+		t.Fatalf("expected the most recent peer, got %+v", wg0)
+	}
+	wg1 := tunnels["wg1"]
+	if wg1.Peers != 1 || !wg1.Handshake.IsZero() || wg1.Endpoint != "" {
+		t.Fatalf("unexpected wg1: %+v", wg1)
+	}
+}
+
+func TestVPNProviderReportsTunnels(t *testing.T) {
+	now := time.Unix(1767225600, 0)
+	dumps := 0
+	provider := &vpnProvider{
+		links: func() (map[string]bool, error) {
+			return map[string]bool{"eth0": true, "wg0": true, "wg1": true, "tun0": true, "tap1": false}, nil
+		},
+		dump: func(ctx context.Context) ([]byte, error) {
+			dumps++
+			return []byte(wireGuardDumpSample), nil
+		},
+		now:      func() time.Time { return now },
+		interval: time.Hour,
+	}
+	monitors, err := provider.Monitors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool, len(monitors))
+	for _, monitor := range monitors {
+		names[monitor.Name] = true
+	}
+	for _, want := range []string{"active", "wg0.up", "wg0.handshake_age", "wg0.endpoint", "wg1.up", "tun0.up", "tap1.up"} {
+		if !names[want] {
+			t.Errorf("missing monitor %s in %v", want, names)
+		}
+	}
+	if names["eth0.up"] || names["tun0.handshake_age"] {
+		t.Fatalf("unexpected monitors: %v", names)
+	}
+
+	values, err := provider.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["wg0.up"] != 1.0 || values["wg0.handshake_age"] != 10.0 || values["wg0.endpoint"] != "198.51.100.9:51820" {
+		t.Fatalf("unexpected wg0 values: %v", values)
+	}
+	// wg1 has a peer that never answered, tap1 is down.
+	if values["wg1.up"] != 0.0 || values["tun0.up"] != 1.0 || values["tap1.up"] != 0.0 || values["active"] != 2.0 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+	if _, ok := values["wg1.handshake_age"]; ok {
+		t.Fatal("expected no handshake age without a handshake")
+	}
+
+	// Between polls the age keeps counting and a silent peer goes stale.
+	now = now.Add(vpnHandshakeStaleAfter)
+	values, _ = provider.Read()
+	if values["wg0.up"] != 0.0 || values["wg0.handshake_age"] != 190.0 {
+		t.Fatalf("expected wg0 to go stale, got %v", values)
+	}
+	if dumps != 1 {
+		t.Fatalf("expected one wg call within the interval, got %d", dumps)
+	}
+}
+
+func TestVPNProviderWithoutWireGuardAccess(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	provider := &vpnProvider{
+		interfaces: parseVPNInterfaceList(" wg0, tun0 ,wg0"),
+		links: func() (map[string]bool, error) {
+			return map[string]bool{"wg0": true}, nil
+		},
+		dump: func(ctx context.Context) ([]byte, error) {
+			return nil, errors.New("Unable to access interface: Operation not permitted")
+		},
+		now:      time.Now,
+		interval: time.Minute,
+	}
+	values, err := provider.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["wg0.up"] != 1.0 || values["tun0.up"] != 0.0 || values["active"] != 1.0 {
+		t.Fatalf("expected presence from the links alone, got %v", values)
+	}
+	if len(values) != 3 {
+		t.Fatalf("expected only the configured tunnels, got %v", values)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// quietHoursSchedule is a parsed QuietHoursConfig. During quiet hours items
+// do not blink or pulse, the GPIO alert LED stays off, OpenRGB keeps its
+// band color and only warnings and errors are logged. Alerts are still
+// counted on the summary page.
+type quietHoursSchedule struct {
+	start int // minutes since midnight
+	end   int
+	days  map[time.Weekday]bool
+}
+
+func parseQuietHours(cfg *QuietHoursConfig) (*quietHoursSchedule, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	startHour, startMinute, ok := parseClockTime(cfg.Start)
+	if !ok {
+		return nil, fmt.Errorf("invalid start %q", cfg.Start)
+	}
+	endHour, endMinute, ok := parseClockTime(cfg.End)
+	if !ok {
+		return nil, fmt.Errorf("invalid end %q", cfg.End)
+	}
+	schedule := &quietHoursSchedule{start: startHour*60 + startMinute, end: endHour*60 + endMinute}
+	for _, name := range cfg.Days {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		day, ok := parseWeekdayName(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", name)
+		}
+		if schedule.days == nil {
+			schedule.days = make(map[time.Weekday]bool)
+		}
+		schedule.days[day] = true
+	}
+	return schedule, nil
+}
+
+// active reports whether now falls in quiet hours. A window that spans
+// midnight belongs to the day it starts on, so 23:00-07:00 on friday also
+// covers early saturday.
+func (s *quietHoursSchedule) active(now time.Time) bool {
+	if s == nil {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	day := now.Weekday()
+	switch {
+	case s.start == s.end:
+	case s.start < s.end:
+		if minute < s.start || minute >= s.end {
+			return false
+		}
+	case minute >= s.start:
+	case minute < s.end:
+		day = (day + 6) % 7
+	default:
+		return false
+	}
+	return s.days == nil || s.days[day]
+}
+
+var activeQuietHours atomic.Pointer[quietHoursSchedule]
+
+// setQuietHours installs the quiet_hours section. An invalid section is
+// logged and leaves alerts and logging on.
+func setQuietHours(cfg *QuietHoursConfig) {
+	schedule, err := parseQuietHours(cfg)
+	if err != nil {
+		logWarnModule("config", "quiet_hours ignored: %v", err)
+		schedule = nil
+	}
+	activeQuietHours.Store(schedule)
+}
+
+func quietHoursActive(now time.Time) bool {
+	return activeQuietHours.Load().active(now)
+}
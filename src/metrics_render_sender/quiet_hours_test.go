@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHoursActive(t *testing.T) {
+	// 2026-10-16 is a Friday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+	overnight, err := parseQuietHours(&QuietHoursConfig{Start: "23:00", End: "07:00", Days: []string{"fri"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		now  time.Time
+		want bool
+	}{
+		{at(16, 22, 59), false},
+		{at(16, 23, 0), true},
+		{at(17, 6, 59), true}, // early saturday belongs to friday night
+		{at(17, 7, 0), false},
+		{at(17, 23, 30), false},
+		{at(16, 6, 30), false}, // thursday night is not listed
+	}
+	for _, tc := range cases {
+		if got := overnight.active(tc.now); got != tc.want {
+			t.Fatalf("%v: expected %v, got %v", tc.now, tc.want, got)
+		}
+	}
+
+	daytime, err := parseQuietHours(&QuietHoursConfig{Start: "09:00", End: "17:30"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !daytime.active(at(14, 12, 0)) || daytime.active(at(14, 17, 30)) || daytime.active(at(14, 8, 59)) {
+		t.Fatal("expected 09:00-17:30 to cover the working day only")
+	}
+
+	weekend, err := parseQuietHours(&QuietHoursConfig{Start: "00:00", End: "00:00", Days: []string{"saturday", "sunday"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !weekend.active(at(17, 15, 0)) || weekend.active(at(16, 15, 0)) {
+		t.Fatal("expected equal times to cover the listed days whole")
+	}
+
+	if _, err := parseQuietHours(&QuietHoursConfig{Start: "7am", End: "08:00"}); err == nil {
+		t.Fatal("expected an invalid start to be rejected")
+	}
+	if (*quietHoursSchedule)(nil).active(at(16, 23, 0)) {
+		t.Fatal("expected no quiet hours without a schedule")
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// remoteConfigProfile is the profile a fetched config is stored as, so
+	// the usual profile machinery loads, saves and switches it.
+	remoteConfigProfile      = "remote"
+	remoteConfigCacheFile    = "remote-config.json"
+	remoteConfigETagFile     = "remote-config.etag"
+	remoteConfigFetchTimeout = 15 * time.Second
+	remoteConfigMaxBytes     = 8 << 20
+)
+
+// userConfigPathOverride replaces the default config path when -config
+// names a local file.
+var userConfigPathOverride string
+
+func isRemoteConfigLocation(location string) bool {
+	lower := strings.ToLower(strings.TrimSpace(location))
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// remoteConfigSource fetches a config over HTTP, keeping the last good copy
+// and its ETag next to the local config so unchanged configs cost a 304 and
+// an unreachable server falls back to the cached copy.
+type remoteConfigSource struct {
+	url      string
+	cacheDir string
+	client   *http.Client
+}
+
+func newRemoteConfigSource(url, cacheDir string) *remoteConfigSource {
+	return &remoteConfigSource{
+		url:      strings.TrimSpace(url),
+		cacheDir: cacheDir,
+		client:   &http.Client{Timeout: remoteConfigFetchTimeout},
+	}
+}
+
+// fetch returns the current config body and whether it differs from the
+// cached copy. When the server cannot be reached the cached copy is returned
+// along with the error; data is nil only when there is nothing to use.
+func (s *remoteConfigSource) fetch() ([]byte, bool, error) {
+	cached, _ := os.ReadFile(filepath.Join(s.cacheDir, remoteConfigCacheFile))
+	etag := ""
+	if len(cached) > 0 {
+		if raw, err := os.ReadFile(filepath.Join(s.cacheDir, remoteConfigETagFile)); err == nil {
+			etag = strings.TrimSpace(string(raw))
+		}
+	}
+
+	body, newETag, err := s.download(etag)
+	if err != nil {
+		if len(cached) == 0 {
+			return nil, false, err
+		}
+		return cached, false, err
+	}
+	if body == nil {
+		return cached, false, nil
+	}
+	if bytes.Equal(body, cached) {
+		return cached, false, s.writeETag(newETag)
+	}
+	if err := os.MkdirAll(s.cacheDir, 0o755); err != nil {
+		return body, true, fmt.Errorf("cache remote config: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.cacheDir, remoteConfigCacheFile), body, 0o644); err != nil {
+		return body, true, fmt.Errorf("cache remote config: %w", err)
+	}
+	return body, true, s.writeETag(newETag)
+}
+
+// download returns a nil body for 304 Not Modified.
+func (s *remoteConfigSource) download(etag string) ([]byte, string, error) {
+	request, err := http.NewRequest(http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "MetricsRenderSender/"+Version)
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotModified && etag != "":
+		return nil, etag, nil
+	case response.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("unexpected status %s", response.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, remoteConfigMaxBytes+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(body) > remoteConfigMaxBytes {
+		return nil, "", fmt.Errorf("config larger than %d bytes", remoteConfigMaxBytes)
+	}
+	var probe MonitorConfig
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return nil, "", fmt.Errorf("invalid config: %w", err)
+	}
+	return body, strings.TrimSpace(response.Header.Get("ETag")), nil
+}
+
+func (s *remoteConfigSource) writeETag(etag string) error {
+	path := filepath.Join(s.cacheDir, remoteConfigETagFile)
+	if etag == "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(etag+"\n"), 0o644)
+}
+
+// initRemoteConfig fetches the config at url and makes it the active
+// "remote" profile before the runtime starts.
+func initRemoteConfig(url string) (*remoteConfigSource, error) {
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	source := newRemoteConfigSource(url, filepath.Dir(configPath))
+	data, _, err := source.fetch()
+	if data == nil {
+		return nil, fmt.Errorf("fetch %s: %w", url, err)
+	}
+	if err != nil {
+		logWarnModule("config", "Remote config fetch failed, using cached copy: %v", err)
+	}
+
+	baseConfig, err := loadUserConfigOrDefault(configPath)
+	if err != nil {
+		return nil, err
+	}
+	profiles, _, err := InitializeGlobalProfileManager(configPath, baseConfig)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := storeRemoteConfigProfile(profiles, data); err != nil {
+		return nil, err
+	}
+	if _, err := profiles.Switch(remoteConfigProfile); err != nil {
+		return nil, err
+	}
+	logInfoModule("config", "Using remote config %s", url)
+	return source, nil
+}
+
+func storeRemoteConfigProfile(profiles *ProfileManager, data []byte) (*MonitorConfig, error) {
+	cfg, err := decodeMonitorConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote config: %w", err)
+	}
+	if err := profiles.SaveProfile(remoteConfigProfile, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// watchRemoteConfig refetches every interval. A changed config replaces the
+// "remote" profile and is applied if that profile is still active; a
+// profile switched to locally is left alone.
+func watchRemoteConfig(source *remoteConfigSource, interval time.Duration) {
+	if source == nil || interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		lastErr := ""
+		for range ticker.C {
+			data, changed, err := source.fetch()
+			if err != nil {
+				if err.Error() != lastErr {
+					logWarnModule("config", "Remote config refresh failed: %v", err)
+				}
+				lastErr = err.Error()
+			} else {
+				lastErr = ""
+			}
+			if !changed || data == nil {
+				continue
+			}
+			if err := applyRemoteConfigUpdate(data); err != nil {
+				logWarnModule("config", "Remote config update failed: %v", err)
+			}
+		}
+	}()
+}
+
+func applyRemoteConfigUpdate(data []byte) error {
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return err
+	}
+	profiles, err := GetProfileManagerWithPath(configPath)
+	if err != nil {
+		return err
+	}
+	if _, err := storeRemoteConfigProfile(profiles, data); err != nil {
+		return err
+	}
+	if profiles.ActiveName() != remoteConfigProfile {
+		logInfoModule("config", "Remote config updated, profile %s is active so it is not applied", profiles.ActiveName())
+		return nil
+	}
+	if err := switchActiveProfile(remoteConfigProfile); err != nil {
+		return err
+	}
+	logInfoModule("config", "Remote config updated and applied")
+	return nil
+}
+
+// resolveConfigLocation applies -config: a URL is fetched and watched, a
+// path replaces the default config file location.
+func resolveConfigLocation(location string, refresh time.Duration) error {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return nil
+	}
+	if !isRemoteConfigLocation(location) {
+		path, err := filepath.Abs(expandHomePath(location))
+		if err != nil {
+			return err
+		}
+		userConfigPathOverride = path
+		return nil
+	}
+	source, err := initRemoteConfig(location)
+	if err != nil {
+		return err
+	}
+	watchRemoteConfig(source, refresh)
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRemoteConfigSourceCachesWithETag(t *testing.T) {
+	body := `{"name":"fleet","width":480,"height":320}`
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(body))
+	}))
+
+	source := newRemoteConfigSource(server.URL, t.TempDir())
+	data, changed, err := source.fetch()
+	if err != nil || !changed || string(data) != body {
+		t.Fatalf("first fetch: changed=%v err=%v data=%q", changed, err, data)
+	}
+	data, changed, err = source.fetch()
+	if err != nil || changed || string(data) != body {
+		t.Fatalf("expected 304 to reuse the cached copy: changed=%v err=%v data=%q", changed, err, data)
+	}
+
+	server.Close()
+	data, changed, err = source.fetch()
+	if err == nil || changed || string(data) != body {
+		t.Fatalf("expected cached fallback with an error when the server is gone: changed=%v err=%v data=%q", changed, err, data)
+	}
+	if requests != 2 {
+		t.Fatalf("unexpected request count: %d", requests)
+	}
+}
+
+func TestRemoteConfigSourceRejectsInvalidConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html>login</html>"))
+	}))
+	defer server.Close()
+
+	data, _, err := newRemoteConfigSource(server.URL, t.TempDir()).fetch()
+	if err == nil || data != nil {
+		t.Fatalf("expected invalid config without a cache to fail: data=%q err=%v", data, err)
+	}
+}
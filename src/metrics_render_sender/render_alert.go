@@ -0,0 +1,201 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+)
+
+const (
+	alertEffectNone  = "none"
+	alertEffectBlink = "blink"
+	alertEffectPulse = "pulse"
+
+	alertBlinkMinPeriod = 500 * time.Millisecond
+	alertPulseMinPeriod = 2 * time.Second
+)
+
+type renderAlertRuntime struct {
+	effect string
+	color  string
+}
+
+func normalizeAlertEffect(effect string) string {
+	switch strings.ToLower(strings.TrimSpace(effect)) {
+	case alertEffectBlink, "flash":
+		return alertEffectBlink
+	case alertEffectPulse, "breath", "breathe":
+		return alertEffectPulse
+	default:
+		return alertEffectNone
+	}
+}
+
+func prepareRenderAlertRuntime(config *MonitorConfig, item *ItemConfig) renderAlertRuntime {
+	return renderAlertRuntime{
+		effect: normalizeAlertEffect(getItemAttrStringCfg(item, config, "alert_effect", alertEffectNone)),
+		color:  strings.TrimSpace(getItemAttrColorCfg(item, config, "alert_color", "")),
+	}
+}
+
+func resolveItemAlertRuntime(item *ItemConfig, config *MonitorConfig) renderAlertRuntime {
+	if item == nil {
+		return renderAlertRuntime{effect: alertEffectNone}
+	}
+	if item.runtime.prepared {
+		return item.runtime.alert
+	}
+	return prepareRenderAlertRuntime(config, item)
+}
+
+// thresholdWorstZoneColor reports whether the resolved range index is the
+// worst range of the group: the top, lower-bounded range by default, or the
+// bottom, upper-bounded range for lower_is_worse groups.
+func thresholdWorstZoneColor(group *ThresholdGroupConfig, idx int) (string, bool) {
+	if group == nil || len(group.Ranges) == 0 {
+		return "", false
+	}
+	if thresholdGroupLowerIsWorse(group) {
+		if idx != 0 || group.Ranges[0].Max == nil {
+			return "", false
+		}
+		return strings.TrimSpace(group.Ranges[0].Color), true
+	}
+	last := len(group.Ranges) - 1
+	if idx != last || group.Ranges[last].Min == nil {
+		return "", false
+	}
+	return strings.TrimSpace(group.Ranges[last].Color), true
+}
+
+// alertEffectAlpha returns the overlay opacity for the given frame time, or 0
+// when the effect is currently in its "off" phase.
+func alertEffectAlpha(effect string, now time.Time, tick time.Duration) float64 {
+	switch effect {
+	case alertEffectBlink:
+		period := tick
+		if period < alertBlinkMinPeriod {
+			period = alertBlinkMinPeriod
+		}
+		if (now.UnixNano()/int64(period))%2 == 0 {
+			return 0.85
+		}
+		return 0
+	case alertEffectPulse:
+		period := 4 * tick
+		if period < alertPulseMinPeriod {
+			period = alertPulseMinPeriod
+		}
+		phase := float64(now.UnixNano()%int64(period)) / float64(period)
+		return 0.2 + 0.6*(0.5-0.5*math.Cos(2*math.Pi*phase))
+	default:
+		return 0
+	}
+}
+
+// drawItemAlertEffect paints the blink/pulse background of an item whose
+// monitor value entered the worst threshold zone. Renderers call it right after
+// drawing their own background so the content stays readable on top.
+func drawItemAlertEffect(dc *gg.Context, item *ItemConfig, frame *RenderFrame, monitor *RenderMonitorSnapshot, config *MonitorConfig, radius float64) {
+	if dc == nil || item == nil || monitor == nil || monitor.value == nil {
+		return
+	}
+	alert := resolveItemAlertRuntime(item, config)
+	if alert.effect == alertEffectNone {
+		return
+	}
+	zoneColor, ok := itemAlertZoneColor(config, monitor)
+	if !ok {
+		return
+	}
+	now, tick := time.Now(), time.Second
+	if frame != nil {
+		now, tick = frame.renderedAt, frame.tick
+	}
+	if quietHoursActive(now) {
+		return
+	}
+	alpha := alertEffectAlpha(alert.effect, now, tick)
+	if config != nil && config.LowPower {
+		alpha = lowPowerAlertAlpha
+	}
+	if alpha <= 0 {
+		return
+	}
+	alertColor := alert.color
+	if alertColor == "" {
+		alertColor = zoneColor
+	}
+	if alertColor == "" {
+		alertColor = "#ef4444"
+	}
+	drawRoundedRectFill(dc, float64(item.X), float64(item.Y), float64(item.Width), float64(item.Height), radius, applyAlpha(alertColor, alpha))
+}
+
+// itemAlertZoneColor reports whether a monitor is alerting: a disk health
+// monitor reading FAILED, or a value in its threshold group's worst zone,
+// along with that zone's color.
+func itemAlertZoneColor(config *MonitorConfig, monitor *RenderMonitorSnapshot) (string, bool) {
+	if isDiskHealthMonitor(monitor.name) {
+		return "", monitor.value.Value == diskHealthFailed
+	}
+	numberValue, ok := tryGetFloat64(monitor.value.Value)
+	if !ok {
+		return "", false
+	}
+	group := findThresholdGroupForMonitor(config, monitor.name)
+	if group == nil {
+		return "", false
+	}
+	numberValue = thresholdGroupNumber(group, monitor.value, numberValue)
+	return thresholdWorstZoneColor(group, resolveThresholdGroupRangeIndex(group, monitor.name, numberValue))
+}
+
+// isDiskHealthMonitor matches go_native.disk.<N>.health.
+func isDiskHealthMonitor(name string) bool {
+	return strings.HasPrefix(name, "go_native.disk.") && strings.HasSuffix(name, ".health")
+}
+
+// configHasActiveAlert reports whether any threshold group monitor currently
+// sits in its group's worst zone. It drives alert outputs that are not tied
+// to a rendered item, such as a GPIO LED.
+func configHasActiveAlert(config *MonitorConfig, registry *CollectorManager) bool {
+	_, alerting := activeAlertZoneColor(config, registry)
+	return alerting
+}
+
+// activeAlertZoneColor reports whether any threshold group monitor is in its
+// worst zone, along with that zone's color. A disk failing its SMART health
+// check alerts too, in red.
+func activeAlertZoneColor(config *MonitorConfig, registry *CollectorManager) (string, bool) {
+	if config == nil || registry == nil {
+		return "", false
+	}
+	for idx := range config.ThresholdGroups {
+		group := &config.ThresholdGroups[idx]
+		for _, monitorName := range group.Monitors {
+			item := registry.Get(monitorName)
+			if item == nil || !item.IsAvailable() || !item.IsReady() {
+				continue
+			}
+			value := item.GetValue()
+			if value == nil {
+				continue
+			}
+			numberValue, ok := tryGetFloat64(value.Value)
+			if !ok {
+				continue
+			}
+			numberValue = thresholdGroupNumber(group, value, numberValue)
+			if zoneColor, worst := thresholdWorstZoneColor(group, resolveThresholdGroupRangeIndex(group, monitorName, numberValue)); worst {
+				return zoneColor, true
+			}
+		}
+	}
+	if sharedDiskHealthService.Failing() {
+		return "#ef4444", true
+	}
+	return "", false
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThresholdWorstZoneColorFollowsDirection(t *testing.T) {
+	group := &ThresholdGroupConfig{
+		Ranges: []ThresholdRangeConfig{
+			{Max: float64Ptr(70), Color: "#ok"},
+			{Min: float64Ptr(70), Max: float64Ptr(90), Color: "#warn"},
+			{Min: float64Ptr(90), Color: "#crit"},
+		},
+	}
+	if _, ok := thresholdWorstZoneColor(group, resolveThresholdRangeIndex(group.Ranges, 85)); ok {
+		t.Fatalf("expected 85 to stay outside the high zone")
+	}
+	color, ok := thresholdWorstZoneColor(group, resolveThresholdRangeIndex(group.Ranges, 95))
+	if !ok || color != "#crit" {
+		t.Fatalf("expected high zone color #crit, got %q ok=%v", color, ok)
+	}
+
+	group.Direction = thresholdDirectionLowerIsWorse
+	if _, ok := thresholdWorstZoneColor(group, resolveThresholdRangeIndex(group.Ranges, 95)); ok {
+		t.Fatalf("expected 95 to be healthy for lower_is_worse group")
+	}
+	color, ok = thresholdWorstZoneColor(group, resolveThresholdRangeIndex(group.Ranges, 10))
+	if !ok || color != "#ok" {
+		t.Fatalf("expected low zone color of first range, got %q ok=%v", color, ok)
+	}
+}
+
+func TestAlertEffectAlphaBlinkAlternatesPerTick(t *testing.T) {
+	tick := time.Second
+	on := time.Unix(0, 0)
+	off := on.Add(tick)
+	if alpha := alertEffectAlpha(alertEffectBlink, on, tick); alpha <= 0 {
+		t.Fatalf("expected blink on phase, got %v", alpha)
+	}
+	if alpha := alertEffectAlpha(alertEffectBlink, off, tick); alpha != 0 {
+		t.Fatalf("expected blink off phase, got %v", alpha)
+	}
+	if alpha := alertEffectAlpha(alertEffectNone, on, tick); alpha != 0 {
+		t.Fatalf("expected no effect alpha, got %v", alpha)
+	}
+}
+
+func TestNormalizeStyleValueAlertEffect(t *testing.T) {
+	if got := normalizeStyleValueByKey("alert_effect", "Pulse"); got != alertEffectPulse {
+		t.Fatalf("expected pulse, got %v", got)
+	}
+	if got := normalizeStyleValueByKey("alert_effect", "wobble"); got != alertEffectNone {
+		t.Fatalf("expected none for unknown effect, got %v", got)
+	}
+}
+
+func TestConfigHasActiveAlertChecksGroupMonitors(t *testing.T) {
+	config := &MonitorConfig{
+		ThresholdGroups: []ThresholdGroupConfig{{
+			Name:     "temp",
+			Monitors: []string{"cpu.temp"},
+			Ranges: []ThresholdRangeConfig{
+				{Max: float64Ptr(80), Color: "#ok"},
+				{Min: float64Ptr(80), Color: "#crit"},
+			},
+		}},
+	}
+	registry := NewCollectorManager()
+	item := NewCollectItem("cpu.temp", "CPU", "°C", 0, 100, 0)
+	registry.items["cpu.temp"] = item
+	item.SetValue(60.0)
+	item.SetAvailable(true)
+	if configHasActiveAlert(config, registry) {
+		t.Fatalf("expected no alert at 60")
+	}
+	item.SetValue(92.0)
+	if !configHasActiveAlert(config, registry) {
+		t.Fatalf("expected alert at 92")
+	}
+	item.SetAvailable(false)
+	if configHasActiveAlert(config, registry) {
+		t.Fatalf("expected unavailable monitor to be ignored")
+	}
+}
+
+func TestItemAlertZoneColorDiskHealth(t *testing.T) {
+	failed := &RenderMonitorSnapshot{name: "go_native.disk.2.health", value: &CollectValue{Value: diskHealthFailed}}
+	if _, ok := itemAlertZoneColor(&MonitorConfig{}, failed); !ok {
+		t.Fatal("expected a FAILED disk health monitor to alert")
+	}
+	passed := &RenderMonitorSnapshot{name: "go_native.disk.2.health", value: &CollectValue{Value: diskHealthPassed}}
+	if _, ok := itemAlertZoneColor(&MonitorConfig{}, passed); ok {
+		t.Fatal("expected a PASSED disk health monitor not to alert")
+	}
+}
@@ -43,15 +43,16 @@ func getItemAttr(item *ItemConfig, key string) (interface{}, bool) {
 }
 
 func getTypeDefaultAttr(config *MonitorConfig, itemType, key string) (interface{}, bool) {
-	if config == nil {
-		return nil, false
+	if config != nil {
+		if value, exists := config.GetTypeDefaults(itemType).RenderAttrsMap[key]; exists {
+			return value, true
+		}
 	}
-	defaults := config.GetTypeDefaults(itemType)
-	if len(defaults.RenderAttrsMap) == 0 {
-		return nil, false
+	if shared := currentLayoutDefaults(); shared != nil {
+		value, exists := shared.typeDefaults(itemType).RenderAttrsMap[key]
+		return value, exists
 	}
-	value, exists := defaults.RenderAttrsMap[key]
-	return value, exists
+	return nil, false
 }
 
 func getItemAttrWithDefaults(item *ItemConfig, config *MonitorConfig, key string) (interface{}, bool) {
@@ -241,3 +242,35 @@ func getItemAttrColorCfg(item *ItemConfig, config *MonitorConfig, key, fallback
 	}
 	return value
 }
+
+// parseMonitorListAttr reads a render attr holding monitor names, either as a
+// list or a comma separated string, and drops blanks and duplicates.
+func parseMonitorListAttr(raw interface{}) []string {
+	var names []string
+	switch value := raw.(type) {
+	case []interface{}:
+		for _, entry := range value {
+			if text, ok := entry.(string); ok {
+				names = append(names, text)
+			}
+		}
+	case []string:
+		names = append(names, value...)
+	case string:
+		names = strings.Split(value, ",")
+	}
+	out := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = normalizeMonitorAlias(name)
+		if name == "" {
+			continue
+		}
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+		out = append(out, name)
+	}
+	return out
+}
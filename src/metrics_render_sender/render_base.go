@@ -21,9 +21,10 @@ type BaseAlignH string
 type BaseAlignV string
 
 const (
-	AlignLeft   BaseAlignH = "left"
-	AlignCenter BaseAlignH = "center"
-	AlignRight  BaseAlignH = "right"
+	AlignLeft    BaseAlignH = "left"
+	AlignCenter  BaseAlignH = "center"
+	AlignRight   BaseAlignH = "right"
+	AlignJustify BaseAlignH = "justify"
 )
 
 const (
@@ -40,6 +41,11 @@ type BaseTextDrawOptions struct {
 	AlignV   BaseAlignV
 	PaddingX float64
 	PaddingY float64
+	// Wrap breaks long lines at the rect width, Ellipsis truncates lines that
+	// still overflow and LineSpacing scales the line height (1 when zero).
+	Wrap        bool
+	Ellipsis    bool
+	LineSpacing float64
 }
 
 func clampMinInt(value, minValue int) int {
@@ -93,7 +99,11 @@ func resolveRoleFontFace(
 	minSize int,
 ) (font.Face, int) {
 	size := resolveRoleFontSize(item, config, role, fallback, minSize)
-	return resolveFontFace(fontCache, size), size
+	face := resolveFontFace(fontCache, size)
+	if role == TextRoleValue && resolveItemTabularNumbers(item, config) {
+		face = fontCache.tabularFace(face)
+	}
+	return face, size
 }
 
 func drawBaseItemFrame(dc *gg.Context, item *ItemConfig, config *MonitorConfig) {
@@ -117,7 +127,7 @@ func drawBaseItemBorder(dc *gg.Context, item *ItemConfig, config *MonitorConfig,
 		radius = 0
 	}
 	dc.SetColor(parseColor(resolveItemBorderColor(item, config)))
-	dc.SetLineWidth(borderWidth)
+	setRenderLineWidth(dc, borderWidth)
 	if radius > 0 {
 		dc.DrawRoundedRectangle(float64(item.X), float64(item.Y), float64(item.Width), float64(item.Height), radius)
 	} else {
@@ -166,33 +176,59 @@ func drawTextInItemRect(
 		bottom = mid
 	}
 
-	anchorX := 0.0
-	textX := left
-	switch opts.AlignH {
-	case AlignRight:
-		textX = right
-		anchorX = 1
-	case AlignCenter:
-		textX = (left + right) / 2
-		anchorX = 0.5
-	default:
-		textX = left
-		anchorX = 0
-	}
-
 	metrics := baseMeasureText(face, text)
 	lineHeight := metrics.ascent + metrics.descent
 	if lineHeight <= 0 {
 		lineHeight = 1
 	}
-	centerY := (top + bottom) / 2
+	lineSpacing := opts.LineSpacing
+	if lineSpacing <= 0 {
+		lineSpacing = 1
+	}
+	lineStep := lineHeight * lineSpacing
+	maxLines := 0
+	if opts.Wrap {
+		maxLines = clampMinInt(int((bottom-top-lineHeight)/lineStep)+1, 1)
+	}
+	measure := newTextMeasureFunc(dc)
+	lines, paragraphEnds := layoutTextLines(measure, text, right-left, maxLines, opts.Wrap, opts.Ellipsis)
+	blockHeight := lineHeight + lineStep*float64(len(lines)-1)
+
+	centerY := (top+bottom)/2 - blockHeight/2 + lineHeight/2
 	switch opts.AlignV {
 	case AlignTop:
 		centerY = top + lineHeight/2
 	case AlignBottom:
-		centerY = bottom - lineHeight/2
+		centerY = bottom - blockHeight + lineHeight/2
+	}
+	for idx, line := range lines {
+		drawBaseAlignedTextLine(dc, face, measure, line, left, right, centerY, opts.AlignH, paragraphEnds[idx])
+		centerY += lineStep
+	}
+}
+
+func drawBaseAlignedTextLine(dc *gg.Context, face font.Face, measure textMeasureFunc, line string, left, right, centerY float64, alignH BaseAlignH, paragraphEnd bool) {
+	switch alignH {
+	case AlignRight:
+		drawBaseMetricAnchoredText(dc, face, line, right, centerY, 1)
+	case AlignCenter:
+		drawBaseMetricAnchoredText(dc, face, line, (left+right)/2, centerY, 0.5)
+	case AlignJustify:
+		words := strings.Fields(line)
+		positions := []float64(nil)
+		if !paragraphEnd {
+			positions = resolveJustifiedWordPositions(measure, words, right-left)
+		}
+		if positions == nil {
+			drawBaseMetricAnchoredText(dc, face, line, left, centerY, 0)
+			return
+		}
+		for idx, word := range words {
+			drawBaseMetricAnchoredText(dc, face, word, left+positions[idx], centerY, 0)
+		}
+	default:
+		drawBaseMetricAnchoredText(dc, face, line, left, centerY, 0)
 	}
-	drawBaseMetricAnchoredText(dc, face, text, textX, centerY, anchorX)
 }
 
 func resolveFontSizeByTextRole(item *ItemConfig, config *MonitorConfig, role BaseTextRole, fallback int) int {
@@ -253,6 +289,5 @@ func drawBaseMetricAnchoredText(dc *gg.Context, face font.Face, text string, x,
 		return
 	}
 	baseline := baseBaselineForCenteredText(face, text, centerY)
-	dc.SetFontFace(face)
-	dc.DrawStringAnchored(text, x, baseline, anchorX, 0)
+	drawStringAnchored(dc, face, text, x, baseline, anchorX, 0)
 }
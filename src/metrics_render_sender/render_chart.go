@@ -1,7 +1,10 @@
 package main
 
 import (
+	"fmt"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/fogleman/gg"
 )
@@ -30,25 +33,52 @@ func (c *LineChartRenderer) Render(dc *gg.Context, item *ItemConfig, frame *Rend
 
 	radius := resolveItemRadius(item, config, 0)
 	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), radius)
+	drawItemAlertEffect(dc, item, frame, monitor, config, radius)
 
+	lineColor := resolveMonitorColor(item, monitor, config)
+	stack, totals := resolveChartStackSeries(item, frame, lineColor, history)
 	minVal, maxVal := resolveEffectiveMinMax(item, value, history, val)
+	if stack != nil {
+		minVal, maxVal = resolveEffectiveMinMax(item, value, totals, totals[len(totals)-1])
+	}
 
-	lineColor := resolveMonitorColor(item, monitor, config)
-	lineWidth := item.runtime.simpleChart.lineWidth
-	enableThresholdColors := item.runtime.simpleChart.enableThresholdColors
+	chart := item.runtime.simpleChart
 	if !item.runtime.prepared {
-		lineWidth = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_width", 1.5), 1)
-		enableThresholdColors = getItemAttrBoolCfg(item, config, "enable_threshold_colors", false)
-	}
-	padding := 2.0
-	chartX := float64(item.X) + padding
-	chartY := float64(item.Y) + padding
-	chartWidth := float64(item.Width) - 2*padding
-	chartHeight := float64(item.Height) - 2*padding
+		chart.lineWidth = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_width", 1.5), 1)
+		chart.enableThresholdColors = getItemAttrBoolCfg(item, config, "enable_threshold_colors", false)
+		chart.showGridLines = getItemAttrBoolCfg(item, config, "show_grid_lines", false)
+		chart.gridLines = clampRenderInt(getItemAttrIntCfg(item, config, "grid_lines", 4), 2)
+		chart.gridLineColor = getItemAttrColorCfg(item, config, "grid_line_color", "#4755693c")
+		chart.showAxisLabels = getItemAttrBoolCfg(item, config, "show_axis_labels", false)
+		chart.showThresholdLines = getItemAttrBoolCfg(item, config, "show_threshold_lines", false)
+		chart.showTimeSpan = getItemAttrBoolCfg(item, config, "show_time_span", false)
+		chart.fillColor = getItemAttrColorCfg(item, config, "chart_fill_color", "rgba(0,0,0,0)")
+		chart.logScale = getItemAttrBoolCfg(item, config, "log_scale", false)
+	}
+	scale := newChartValueScale(minVal, maxVal, chart.logScale)
+	lineWidth := chart.lineWidth
+	paddingX, paddingY := resolveContentPaddingXY(item, config, 2, 2, 0, 0)
+	chartX := float64(item.X) + paddingX
+	chartY := float64(item.Y) + paddingY
+	chartWidth := float64(item.Width) - 2*paddingX
+	chartHeight := float64(item.Height) - 2*paddingY
 	if chartWidth <= 1 || chartHeight <= 1 {
 		drawBaseItemBorder(dc, item, config, radius)
 		return nil
 	}
+	area := fullRect{x: chartX, y: chartY, w: chartWidth, h: chartHeight}
+	if chart.showGridLines {
+		drawSimpleChartGridLines(dc, area, chart.gridLines, chart.gridLineColor)
+	}
+	if chart.showThresholdLines {
+		drawSimpleChartThresholdLines(dc, area, config, monitor.name, scale)
+	}
+	if stack != nil {
+		drawStackedChartSeries(dc, area, stack, scale, lineWidth)
+		drawSimpleChartAnnotations(dc, item, frame, fontCache, config, chart, area, value, minVal, maxVal, len(history))
+		drawBaseItemBorder(dc, item, config, radius)
+		return nil
+	}
 
 	type chartPoint struct {
 		x float64
@@ -64,16 +94,29 @@ func (c *LineChartRenderer) Render(dc *gg.Context, item *ItemConfig, frame *Rend
 		if len(history) > 1 {
 			x = chartX + float64(idx)*chartWidth/float64(len(history)-1)
 		}
-		y := chartY + chartHeight - (histValue-minVal)/(maxVal-minVal)*chartHeight
+		y := scale.y(area, histValue)
 		pointsOnChart = append(pointsOnChart, chartPoint{x: x, y: y, v: histValue})
 	}
 	if len(pointsOnChart) < 2 {
+		drawSimpleChartAnnotations(dc, item, frame, fontCache, config, chart, area, value, minVal, maxVal, len(history))
 		drawBaseItemBorder(dc, item, config, radius)
 		return nil
 	}
 
-	if enableThresholdColors {
-		dc.SetLineWidth(lineWidth)
+	if strings.TrimSpace(chart.fillColor) != "" && colorAlpha(chart.fillColor) > 0 {
+		bottomY := chartY + chartHeight
+		dc.MoveTo(pointsOnChart[0].x, bottomY)
+		for _, p := range pointsOnChart {
+			dc.LineTo(p.x, p.y)
+		}
+		dc.LineTo(pointsOnChart[len(pointsOnChart)-1].x, bottomY)
+		dc.ClosePath()
+		dc.SetColor(parseColor(chart.fillColor))
+		dc.Fill()
+	}
+
+	if chart.enableThresholdColors {
+		setRenderLineWidth(dc, lineWidth)
 		for idx := 1; idx < len(pointsOnChart); idx++ {
 			p0 := pointsOnChart[idx-1]
 			p1 := pointsOnChart[idx]
@@ -89,15 +132,90 @@ func (c *LineChartRenderer) Render(dc *gg.Context, item *ItemConfig, frame *Rend
 			dc.LineTo(p.x, p.y)
 		}
 		dc.SetColor(parseColor(lineColor))
-		dc.SetLineWidth(lineWidth)
+		setRenderLineWidth(dc, lineWidth)
 		dc.Stroke()
 	}
 
+	drawSimpleChartAnnotations(dc, item, frame, fontCache, config, chart, area, value, minVal, maxVal, len(history))
 	drawBaseItemBorder(dc, item, config, radius)
-	_ = fontCache
 	return nil
 }
 
+func drawSimpleChartGridLines(dc *gg.Context, area fullRect, gridLines int, lineColor string) {
+	setRenderLineWidth(dc, 1)
+	dc.SetColor(parseColor(lineColor))
+	for i := 0; i < gridLines; i++ {
+		y := area.y + float64(i)*(area.h/float64(gridLines-1))
+		dc.DrawLine(area.x, y, area.x+area.w, y)
+		dc.Stroke()
+	}
+}
+
+// drawSimpleChartThresholdLines marks every lower bound of the monitor's
+// threshold ranges that falls inside the visible value range with a dashed
+// line in that range's color.
+func drawSimpleChartThresholdLines(dc *gg.Context, area fullRect, config *MonitorConfig, monitorName string, scale chartValueScale) {
+	group := findThresholdGroupForMonitor(config, monitorName)
+	if group == nil || scale.max <= scale.min {
+		return
+	}
+	setRenderLineWidth(dc, 1)
+	setRenderDash(dc, 3, 3)
+	for _, entry := range group.Ranges {
+		if entry.Min == nil || *entry.Min <= scale.min || *entry.Min >= scale.max {
+			continue
+		}
+		y := scale.y(area, *entry.Min)
+		dc.SetColor(parseColor(applyAlpha(entry.Color, 0.6)))
+		dc.DrawLine(area.x, y, area.x+area.w, y)
+		dc.Stroke()
+	}
+	setRenderDash(dc)
+}
+
+// drawSimpleChartAnnotations draws the y-axis max/min labels in the left
+// corners and the covered time span in the bottom-right corner.
+func drawSimpleChartAnnotations(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig, chart renderSimpleChartRuntime, area fullRect, value *CollectValue, minVal, maxVal float64, points int) {
+	if !chart.showAxisLabels && !chart.showTimeSpan {
+		return
+	}
+	face, _ := resolveRoleFontFace(fontCache, item, config, TextRoleUnit, 14, 8)
+	metrics := baseMeasureText(face, "")
+	textColor := applyAlpha(resolveItemStaticColor(item, config), 0.75)
+	dc.SetColor(parseColor(textColor))
+	topY := area.y + metrics.ascent
+	bottomY := area.y + area.h - metrics.descent
+
+	if chart.showAxisLabels && value != nil {
+		unitOverride := resolveUnitOverride(item)
+		maxText := FormatCollectValue(&CollectValue{Value: maxVal, Unit: value.Unit, Precision: value.Precision}, true, unitOverride)
+		minText := FormatCollectValue(&CollectValue{Value: minVal, Unit: value.Unit, Precision: value.Precision}, true, unitOverride)
+		drawStringAnchored(dc, face, maxText, area.x+1, topY, 0, 0)
+		drawStringAnchored(dc, face, minText, area.x+1, bottomY, 0, 0)
+	}
+	if chart.showTimeSpan && points > 1 {
+		tick := time.Second
+		if frame != nil && frame.tick > 0 {
+			tick = frame.tick
+		}
+		if item.runtime.historyStride > 1 {
+			tick *= time.Duration(item.runtime.historyStride)
+		}
+		drawStringAnchored(dc, face, formatChartTimeSpan(time.Duration(points-1)*tick), area.x+area.w-1, bottomY, 1, 0)
+	}
+}
+
+func formatChartTimeSpan(span time.Duration) string {
+	switch {
+	case span < 2*time.Minute:
+		return fmt.Sprintf("%ds", int(span.Round(time.Second)/time.Second))
+	case span < 2*time.Hour:
+		return fmt.Sprintf("%dm", int(span.Round(time.Minute)/time.Minute))
+	default:
+		return fmt.Sprintf("%dh", int(span.Round(time.Hour)/time.Hour))
+	}
+}
+
 func isFiniteHistoryValue(value float64) bool {
 	return !math.IsNaN(value) && !math.IsInf(value, 0)
 }
@@ -0,0 +1,31 @@
+package main
+
+import "math"
+
+// chartValueScale maps history values onto the vertical axis of a chart. With
+// log enabled the offset from min is compressed with log1p so short bursts do
+// not flatten the rest of the history.
+type chartValueScale struct {
+	min float64
+	max float64
+	log bool
+}
+
+func newChartValueScale(minValue, maxValue float64, logScale bool) chartValueScale {
+	return chartValueScale{min: minValue, max: maxValue, log: logScale}
+}
+
+func (s chartValueScale) ratio(value float64) float64 {
+	span := s.max - s.min
+	if span <= 0 {
+		return 0
+	}
+	if !s.log {
+		return (value - s.min) / span
+	}
+	return math.Log1p(math.Max(value-s.min, 0)) / math.Log1p(span)
+}
+
+func (s chartValueScale) y(area fullRect, value float64) float64 {
+	return area.y + area.h - s.ratio(value)*area.h
+}
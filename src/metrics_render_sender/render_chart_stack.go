@@ -0,0 +1,145 @@
+package main
+
+import (
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// chartStackPalette colors the extra series of a stacked chart; the primary
+// series keeps the item's line color.
+var chartStackPalette = []string{"#f97316", "#a855f7", "#22c55e", "#eab308", "#ec4899"}
+
+type chartStackSeries struct {
+	color   string
+	history []float64
+}
+
+func isChartItemType(itemType string) bool {
+	return itemType == itemTypeSimpleChart || itemType == itemTypeFullChart
+}
+
+func resolveChartStackMonitors(item *ItemConfig) []string {
+	if item == nil || !isChartItemType(item.Type) {
+		return nil
+	}
+	if item.runtime.prepared {
+		return item.runtime.stackMonitors
+	}
+	return chartStackMonitorsAttr(item)
+}
+
+func chartStackMonitorsAttr(item *ItemConfig) []string {
+	raw, _ := getItemAttr(item, "stack_monitors")
+	return parseMonitorListAttr(raw)
+}
+
+// resolveChartStackSeries records the history of every stacked monitor of the
+// item and returns all series bottom-up together with the per-point totals.
+// It returns nil when the item has no stacked monitors.
+func resolveChartStackSeries(item *ItemConfig, frame *RenderFrame, primaryColor string, primaryHistory []float64) ([]chartStackSeries, []float64) {
+	monitors := resolveChartStackMonitors(item)
+	if len(monitors) == 0 {
+		return nil, nil
+	}
+	series := make([]chartStackSeries, 0, len(monitors)+1)
+	series = append(series, chartStackSeries{color: primaryColor, history: primaryHistory})
+	for idx, name := range monitors {
+		value := math.NaN()
+		if monitor := frame.ResolveMonitor(name); monitor != nil && monitor.available && monitor.value != nil {
+			if number, ok := tryGetFloat64(monitor.value.Value); ok {
+				value = number
+			}
+		}
+		history := []float64{value}
+		if frame != nil && frame.history != nil && item.runtime.historyKey != "" && item.runtime.historyPoints > 0 {
+			history = frame.history.append(item.runtime.historyKey+"|stack|"+name, value, item.runtime.historyPoints, item.runtime.historyStride)
+		}
+		series = append(series, chartStackSeries{
+			color:   chartStackPalette[idx%len(chartStackPalette)],
+			history: history,
+		})
+	}
+
+	totals := make([]float64, len(primaryHistory))
+	for idx := range totals {
+		if !isFiniteHistoryValue(primaryHistory[idx]) {
+			totals[idx] = math.NaN()
+			continue
+		}
+		for _, entry := range series {
+			if value, ok := chartStackValueAt(entry.history, len(totals), idx); ok {
+				totals[idx] += value
+			}
+		}
+	}
+	return series, totals
+}
+
+// chartStackValueAt reads point idx of a chart that is points wide from a
+// history aligned to the chart's right edge.
+func chartStackValueAt(history []float64, points, idx int) (float64, bool) {
+	idx -= points - len(history)
+	if idx < 0 || idx >= len(history) || !isFiniteHistoryValue(history[idx]) {
+		return 0, false
+	}
+	return history[idx], true
+}
+
+// drawStackedChartSeries fills each series as a band on top of the previous
+// ones and outlines the band's upper edge in the series color.
+func drawStackedChartSeries(dc *gg.Context, area fullRect, series []chartStackSeries, scale chartValueScale, lineWidth float64) {
+	if len(series) == 0 || scale.max <= scale.min {
+		return
+	}
+	points := len(series[0].history)
+	if points < 2 {
+		return
+	}
+	toY := func(value float64) float64 {
+		return clampFloat64(scale.y(area, value), area.y, area.y+area.h)
+	}
+	toX := func(idx int) float64 {
+		return area.x + area.w*float64(idx)/float64(points-1)
+	}
+
+	start := 0
+	for start < points && !isFiniteHistoryValue(series[0].history[start]) {
+		start++
+	}
+	if points-start < 2 {
+		return
+	}
+
+	base := make([]float64, points)
+	for _, entry := range series {
+		top := make([]float64, points)
+		for idx := range top {
+			top[idx] = base[idx]
+			if value, ok := chartStackValueAt(entry.history, points, idx); ok {
+				top[idx] += value
+			}
+		}
+
+		dc.MoveTo(toX(start), toY(top[start]))
+		for idx := start + 1; idx < points; idx++ {
+			dc.LineTo(toX(idx), toY(top[idx]))
+		}
+		for idx := points - 1; idx >= start; idx-- {
+			dc.LineTo(toX(idx), toY(base[idx]))
+		}
+		dc.ClosePath()
+		dc.SetColor(parseColor(applyAlpha(entry.color, 0.45)))
+		dc.Fill()
+
+		dc.MoveTo(toX(start), toY(top[start]))
+		for idx := start + 1; idx < points; idx++ {
+			dc.LineTo(toX(idx), toY(top[idx]))
+		}
+		dc.SetColor(parseColor(entry.color))
+		setRenderLineWidth(dc, lineWidth)
+		dc.Stroke()
+
+		base = top
+	}
+}
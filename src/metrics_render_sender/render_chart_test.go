@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestFormatChartTimeSpan(t *testing.T) {
+	cases := map[time.Duration]string{
+		59 * time.Second:  "59s",
+		90 * time.Second:  "90s",
+		150 * time.Second: "3m",
+		119 * time.Minute: "119m",
+		5 * time.Hour:     "5h",
+	}
+	for span, want := range cases {
+		if got := formatChartTimeSpan(span); got != want {
+			t.Fatalf("formatChartTimeSpan(%s) = %q, want %q", span, got, want)
+		}
+	}
+}
+
+func TestGetRequiredMonitorsIncludesChartStackMonitors(t *testing.T) {
+	config := &MonitorConfig{
+		Items: []ItemConfig{
+			{
+				Type:    itemTypeSimpleChart,
+				Monitor: "go_native.net.upload",
+				RenderAttrsMap: map[string]interface{}{
+					"stack_monitors": []interface{}{"go_native.net.download", "go_native.net.upload", " "},
+				},
+			},
+		},
+	}
+	required := getRequiredMonitors(config)
+	sort.Strings(required)
+	if len(required) != 2 || required[0] != "go_native.net.download" || required[1] != "go_native.net.upload" {
+		t.Fatalf("unexpected required monitors: %#v", required)
+	}
+}
+
+func TestResolveChartStackSeriesSumsTotals(t *testing.T) {
+	manager := NewCollectorManager()
+	extra := NewCollectItem("disk.write", "write", "B/s", 0, 0, 0)
+	extra.SetValue(5.0)
+	manager.items["disk.write"] = extra
+	item := &ItemConfig{
+		Type:           itemTypeSimpleChart,
+		Monitor:        "disk.read",
+		RenderAttrsMap: map[string]interface{}{"stack_monitors": "disk.write"},
+	}
+	frame := newRenderFrame(manager, nil, nil, nil)
+
+	series, totals := resolveChartStackSeries(item, frame, "#38bdf8", []float64{math.NaN(), 2})
+	if len(series) != 2 || series[1].color != chartStackPalette[0] {
+		t.Fatalf("unexpected series: %#v", series)
+	}
+	if !math.IsNaN(totals[0]) || totals[1] != 7 {
+		t.Fatalf("unexpected totals: %#v", totals)
+	}
+}
+
+func TestChartValueScaleLogKeepsSmallValuesVisible(t *testing.T) {
+	linear := newChartValueScale(0, 1000, false)
+	logScale := newChartValueScale(0, 1000, true)
+	if got := linear.ratio(10); math.Abs(got-0.01) > 1e-9 {
+		t.Fatalf("linear ratio = %v, want 0.01", got)
+	}
+	if got := logScale.ratio(10); got < 0.3 {
+		t.Fatalf("log ratio for 10/1000 = %v, want a visible share", got)
+	}
+	if got := logScale.ratio(1000); math.Abs(got-1) > 1e-9 {
+		t.Fatalf("log ratio at max = %v, want 1", got)
+	}
+	if got := logScale.ratio(-5); got != 0 {
+		t.Fatalf("log ratio below min = %v, want 0", got)
+	}
+}
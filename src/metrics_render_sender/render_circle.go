@@ -16,6 +16,10 @@ func (r *CircleRenderer) RequiresMonitor() bool {
 	return false
 }
 
+func (r *CircleRenderer) StaticContent() bool {
+	return true
+}
+
 func (r *CircleRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig) error {
 	_ = frame
 	_ = fontCache
@@ -35,7 +39,7 @@ func (r *CircleRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderF
 	borderWidth := resolveItemBorderWidth(item, config)
 	if borderWidth > 0 {
 		dc.SetColor(parseColor(resolveItemBorderColor(item, config)))
-		dc.SetLineWidth(borderWidth)
+		setRenderLineWidth(dc, borderWidth)
 		dc.DrawEllipse(cx, cy, rx, ry)
 		dc.Stroke()
 	}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"image/color"
+	"math"
 	"strconv"
 	"strings"
 
@@ -74,6 +75,17 @@ func parseColor(hexColor string) color.Color {
 	return color.RGBA{uint8(r), uint8(g), uint8(b), a}
 }
 
+// parseColorNRGBA is parseColor for code that changes the alpha: parseColor
+// keeps the channels as written rather than premultiplied, so they are
+// reinterpreted here instead of converted.
+func parseColorNRGBA(hexColor string) color.NRGBA {
+	parsed := parseColor(hexColor)
+	if rgba, ok := parsed.(color.RGBA); ok {
+		return color.NRGBA(rgba)
+	}
+	return color.NRGBAModel.Convert(parsed).(color.NRGBA)
+}
+
 func clampFloat64(value, minValue, maxValue float64) float64 {
 	if value < minValue {
 		return minValue
@@ -155,20 +167,16 @@ func resolveFontFace(fontCache *FontCache, fontSize int) font.Face {
 	return font
 }
 
-func drawCenteredValueWithUnit(dc *gg.Context, valueText, unitText string, x, y, width, height int, valueFontSize int, valueColor string, unitFontSize int, unitColor string, fontCache *FontCache) {
+func drawCenteredValueWithUnit(dc *gg.Context, valueText, unitText string, rect fullRect, valueFace font.Face, valueColor string, unitFace font.Face, unitColor string) {
 	if strings.TrimSpace(valueText) == "" && strings.TrimSpace(unitText) == "" {
 		return
 	}
 	if strings.TrimSpace(unitText) == "" {
-		valueFace := resolveFontFace(fontCache, valueFontSize)
 		dc.SetColor(parseColor(valueColor))
-		drawMetricAnchoredText(dc, valueFace, valueText, float64(x)+float64(width)/2, float64(y)+float64(height)/2, 0.5)
+		drawMetricAnchoredText(dc, valueFace, valueText, rect.x+rect.w/2, rect.y+rect.h/2, 0.5)
 		return
 	}
 
-	valueFace := resolveFontFace(fontCache, valueFontSize)
-	unitFace := resolveFontFace(fontCache, unitFontSize)
-
 	dc.SetFontFace(valueFace)
 	valueWidth, _ := dc.MeasureString(valueText)
 
@@ -181,8 +189,8 @@ func drawCenteredValueWithUnit(dc *gg.Context, valueText, unitText string, x, y,
 	}
 
 	totalWidth := valueWidth + gap + unitWidth
-	startX := float64(x) + (float64(width)-totalWidth)/2
-	centerY := float64(y) + float64(height)/2
+	startX := rect.x + (rect.w-totalWidth)/2
+	centerY := rect.y + rect.h/2
 
 	if strings.TrimSpace(valueText) != "" {
 		dc.SetColor(parseColor(valueColor))
@@ -194,6 +202,68 @@ func drawCenteredValueWithUnit(dc *gg.Context, valueText, unitText string, x, y,
 	drawMetricAnchoredText(dc, unitFace, unitText, startX, centerY, 0)
 }
 
+// drawAlignedValueWithUnit lays out value and unit as one run inside the rect
+// using the item's text layout. Justify has no word gaps to spread across for
+// a single value, so it is drawn centered.
+func drawAlignedValueWithUnit(dc *gg.Context, valueText, unitText string, rect fullRect, valueFace font.Face, valueColor string, unitFace font.Face, unitColor string, layout renderTextLayoutRuntime) {
+	if strings.TrimSpace(valueText) == "" && strings.TrimSpace(unitText) == "" {
+		return
+	}
+	const paddingX, paddingY = 4.0, 2.0
+	left := rect.x + paddingX
+	right := rect.x + rect.w - paddingX
+	top := rect.y + paddingY
+	bottom := rect.y + rect.h - paddingY
+
+	dc.SetFontFace(unitFace)
+	unitWidth := 0.0
+	if strings.TrimSpace(unitText) != "" {
+		unitWidth, _ = dc.MeasureString(unitText)
+	}
+	gap := 0.0
+	if strings.TrimSpace(valueText) != "" && unitWidth > 0 {
+		gap = 2.0
+	}
+	dc.SetFontFace(valueFace)
+	if layout.ellipsis {
+		valueText = truncateTextWithEllipsis(newTextMeasureFunc(dc), valueText, right-left-gap-unitWidth)
+	}
+	valueWidth, _ := dc.MeasureString(valueText)
+
+	totalWidth := valueWidth + gap + unitWidth
+	startX := rect.x + (rect.w-totalWidth)/2
+	switch layout.alignH {
+	case AlignLeft:
+		startX = left
+	case AlignRight:
+		startX = right - totalWidth
+	}
+
+	valueMetrics := baseMeasureText(valueFace, valueText)
+	lineHeight := valueMetrics.ascent + valueMetrics.descent
+	if unitWidth > 0 {
+		unitMetrics := baseMeasureText(unitFace, unitText)
+		lineHeight = math.Max(lineHeight, unitMetrics.ascent+unitMetrics.descent)
+	}
+	centerY := rect.y + rect.h/2
+	switch layout.alignV {
+	case AlignTop:
+		centerY = top + lineHeight/2
+	case AlignBottom:
+		centerY = bottom - lineHeight/2
+	}
+
+	if strings.TrimSpace(valueText) != "" {
+		dc.SetColor(parseColor(valueColor))
+		drawMetricAnchoredText(dc, valueFace, valueText, startX, centerY, 0)
+		startX += valueWidth + gap
+	}
+	if unitWidth > 0 {
+		dc.SetColor(parseColor(unitColor))
+		drawMetricAnchoredText(dc, unitFace, unitText, startX, centerY, 0)
+	}
+}
+
 func canUseItemCustomStyle(item *ItemConfig, config *MonitorConfig) bool {
 	if item == nil || config == nil {
 		return false
@@ -303,11 +373,10 @@ func resolveMonitorValueColor(item *ItemConfig, monitorName string, value *Colle
 		return color
 	}
 	if group := findThresholdGroupForMonitor(config, monitorName); group != nil {
-		if color := resolveThresholdRangeColor(group, numberValue); color != "" {
+		if color := resolveThresholdRangeColor(group, monitorName, thresholdGroupNumber(group, value, numberValue)); color != "" {
 			return color
 		}
 	}
-	_ = value
 	return resolveSystemDefaultValueColor(config)
 }
 
@@ -324,14 +393,54 @@ func resolveMonitorUnitColor(item *ItemConfig, monitorName string, value *Collec
 		return color
 	}
 	if group := findThresholdGroupForMonitor(config, monitorName); group != nil {
-		if color := resolveThresholdRangeColor(group, numberValue); color != "" {
+		if color := resolveThresholdRangeColor(group, monitorName, thresholdGroupNumber(group, value, numberValue)); color != "" {
 			return color
 		}
 	}
-	_ = value
 	return resolveSystemDefaultValueColor(config)
 }
 
+func resolveItemTabularNumbers(item *ItemConfig, config *MonitorConfig) bool {
+	if item == nil {
+		return false
+	}
+	if item.runtime.prepared {
+		return item.runtime.tabularNumbers
+	}
+	return getItemAttrBoolCfg(item, config, "tabular_numbers", false)
+}
+
+func resolveItemShowLevelLabel(item *ItemConfig, config *MonitorConfig) bool {
+	if item == nil {
+		return false
+	}
+	if item.runtime.prepared {
+		return item.runtime.showLevelLabel
+	}
+	return getItemAttrBoolCfg(item, config, "show_level_label", false)
+}
+
+// appendThresholdLevelLabel appends the label of the matching threshold range
+// (e.g. "WARN") to the unit text so it is drawn next to the value.
+func appendThresholdLevelLabel(unitText string, monitorName string, value *CollectValue, config *MonitorConfig) string {
+	if value == nil {
+		return unitText
+	}
+	numberValue, ok := tryGetFloat64(value.Value)
+	if !ok {
+		return unitText
+	}
+	group := findThresholdGroupForMonitor(config, monitorName)
+	label := resolveThresholdRangeLabel(group, monitorName, thresholdGroupNumber(group, value, numberValue))
+	if label == "" {
+		return unitText
+	}
+	if strings.TrimSpace(unitText) == "" {
+		return label
+	}
+	return unitText + " " + label
+}
+
 func resolveMonitorColor(item *ItemConfig, monitor *RenderMonitorSnapshot, config *MonitorConfig) string {
 	if monitor == nil || monitor.value == nil {
 		if color := resolveExplicitItemStaticColor(item, config); color != "" {
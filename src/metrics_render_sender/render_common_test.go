@@ -95,3 +95,29 @@ func TestResolveMonitorValueColorFallsBackToSystemDefault(t *testing.T) {
 		t.Fatalf("expected system default color, got %q", color)
 	}
 }
+
+func TestResolveSimpleLineEndpointsAndDashes(t *testing.T) {
+	item := &ItemConfig{X: 10, Y: 20, Width: 100, Height: 40}
+	cases := map[string][4]float64{
+		"horizontal":                {10, 40, 110, 40},
+		"vertical":                  {60, 20, 60, 60},
+		lineOrientationDiagonalDown: {10, 20, 110, 60},
+		lineOrientationDiagonalUp:   {10, 60, 110, 20},
+	}
+	for orientation, want := range cases {
+		x1, y1, x2, y2 := resolveSimpleLineEndpoints(item, orientation)
+		if got := [4]float64{x1, y1, x2, y2}; got != want {
+			t.Fatalf("orientation %s: got %v want %v", orientation, got, want)
+		}
+	}
+
+	if dashes := resolveSimpleLineDashes(renderSimpleLineRuntime{dash: lineDashSolid, lineWidth: 2}); len(dashes) != 0 {
+		t.Fatalf("expected no dashes for solid line, got %v", dashes)
+	}
+	if dashes := resolveSimpleLineDashes(renderSimpleLineRuntime{dash: lineDashDashed, dashLength: 5}); len(dashes) != 2 || dashes[0] != 5 {
+		t.Fatalf("unexpected dashed pattern %v", dashes)
+	}
+	if got := normalizeStyleValueByKey("line_dash", "DOTTED"); got != lineDashDotted {
+		t.Fatalf("expected dotted, got %v", got)
+	}
+}
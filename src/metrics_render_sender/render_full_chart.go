@@ -29,6 +29,7 @@ func (r *FullChartRenderer) Render(dc *gg.Context, item *ItemConfig, frame *Rend
 
 	cardRadius := resolveItemCardRadius(item, config)
 	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), cardRadius)
+	drawItemAlertEffect(dc, item, frame, monitor, config, cardRadius)
 
 	labelText, valueText, unitText := fullResolveTextParts(item, monitor, value, config)
 	displayValue := strings.TrimSpace(valueText + " " + unitText)
@@ -55,19 +56,28 @@ func (r *FullChartRenderer) drawBody(dc *gg.Context, item *ItemConfig, frame *Re
 	}
 
 	minValue, maxValue := resolveEffectiveMinMax(item, value, history, numberValue)
+	stack, totals := resolveChartStackSeries(item, frame, lineColor, history)
+	if stack != nil {
+		minValue, maxValue = resolveEffectiveMinMax(item, value, totals, totals[len(totals)-1])
+	}
 
 	chartAreaBg := item.runtime.fullChart.chartAreaBg
 	chartAreaBorder := item.runtime.fullChart.chartAreaBorder
+	chartAreaRadius := item.runtime.fullChart.chartAreaRadius
+	gridLineColor := item.runtime.fullChart.gridLineColor
 	chartFillColor := item.runtime.fullChart.fillColor
 	showSegmentLines := item.runtime.fullChart.showSegmentLines
 	gridLines := item.runtime.fullChart.gridLines
 	lineWidth := item.runtime.fullChart.lineWidth
 	enableThresholdColors := item.runtime.fullChart.enableThresholdColors
 	showAvgLine := item.runtime.fullChart.showAvgLine
+	logScale := item.runtime.fullChart.logScale
 	if !item.runtime.prepared {
 		chartFillColor = getItemAttrColorCfg(item, config, "chart_fill_color", "rgba(0,0,0,0)")
 		chartAreaBg = getItemAttrColorCfg(item, config, "chart_area_bg", "")
 		chartAreaBorder = getItemAttrColorCfg(item, config, "chart_area_border_color", "")
+		chartAreaRadius = clampMinFloat(getItemAttrFloatCfg(item, config, "chart_area_radius", 4), 0)
+		gridLineColor = getItemAttrColorCfg(item, config, "grid_line_color", "#4755693c")
 		showSegmentLines = getItemAttrBoolCfg(
 			item,
 			config,
@@ -78,26 +88,32 @@ func (r *FullChartRenderer) drawBody(dc *gg.Context, item *ItemConfig, frame *Re
 		lineWidth = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_width", 2), 1)
 		enableThresholdColors = getItemAttrBoolCfg(item, config, "enable_threshold_colors", false)
 		showAvgLine = getItemAttrBoolCfg(item, config, "show_avg_line", true)
+		logScale = getItemAttrBoolCfg(item, config, "log_scale", false)
 	}
+	scale := newChartValueScale(minValue, maxValue, logScale)
 	if chartAreaBg != "" {
-		drawRoundedRectFill(dc, body.x, body.y, body.w, body.h, 4, chartAreaBg)
+		drawRoundedRectFill(dc, body.x, body.y, body.w, body.h, chartAreaRadius, chartAreaBg)
 	}
 	if chartAreaBorder != "" {
-		dc.SetLineWidth(1)
+		setRenderLineWidth(dc, 1)
 		dc.SetColor(parseColor(chartAreaBorder))
-		dc.DrawRoundedRectangle(body.x, body.y, body.w, body.h, 4)
+		dc.DrawRoundedRectangle(body.x, body.y, body.w, body.h, chartAreaRadius)
 		dc.Stroke()
 	}
 
 	if showSegmentLines {
-		dc.SetLineWidth(1)
-		dc.SetColor(parseColor("#4755693c"))
+		setRenderLineWidth(dc, 1)
+		dc.SetColor(parseColor(gridLineColor))
 		for i := 0; i < gridLines; i++ {
 			y := body.y + float64(i)*(body.h/float64(gridLines-1))
 			dc.DrawLine(body.x, y, body.x+body.w, y)
 			dc.Stroke()
 		}
 	}
+	if stack != nil {
+		drawStackedChartSeries(dc, body, stack, scale, lineWidth)
+		return
+	}
 
 	type chartPoint struct {
 		x float64
@@ -113,7 +129,7 @@ func (r *FullChartRenderer) drawBody(dc *gg.Context, item *ItemConfig, frame *Re
 		if len(history) > 1 {
 			x = body.x + body.w*float64(idx)/float64(len(history)-1)
 		}
-		y := body.y + body.h - ((histValue-minValue)/(maxValue-minValue))*body.h
+		y := scale.y(body, histValue)
 		y = clampFloat64(y, body.y, body.y+body.h)
 		pointsOnChart = append(pointsOnChart, chartPoint{x: x, y: y, v: histValue})
 	}
@@ -121,7 +137,7 @@ func (r *FullChartRenderer) drawBody(dc *gg.Context, item *ItemConfig, frame *Re
 		return
 	}
 
-	if strings.TrimSpace(chartFillColor) != "" {
+	if strings.TrimSpace(chartFillColor) != "" && colorAlpha(chartFillColor) > 0 {
 		bottomY := body.y + body.h
 		dc.MoveTo(pointsOnChart[0].x, bottomY)
 		dc.LineTo(pointsOnChart[0].x, pointsOnChart[0].y)
@@ -137,7 +153,7 @@ func (r *FullChartRenderer) drawBody(dc *gg.Context, item *ItemConfig, frame *Re
 	}
 
 	if enableThresholdColors {
-		dc.SetLineWidth(lineWidth)
+		setRenderLineWidth(dc, lineWidth)
 		for idx := 1; idx < len(pointsOnChart); idx++ {
 			p0 := pointsOnChart[idx-1]
 			p1 := pointsOnChart[idx]
@@ -152,20 +168,20 @@ func (r *FullChartRenderer) drawBody(dc *gg.Context, item *ItemConfig, frame *Re
 			p := pointsOnChart[idx]
 			dc.LineTo(p.x, p.y)
 		}
-		dc.SetLineWidth(lineWidth)
+		setRenderLineWidth(dc, lineWidth)
 		dc.SetColor(parseColor(lineColor))
 		dc.Stroke()
 	}
 
 	if showAvgLine {
 		avg := historyAverage(history)
-		y := body.y + body.h - ((avg-minValue)/(maxValue-minValue))*body.h
+		y := scale.y(body, avg)
 		y = clampFloat64(y, body.y, body.y+body.h)
 		dc.SetColor(parseColor(applyAlpha(lineColor, 0.7)))
-		dc.SetDash(4, 4)
-		dc.SetLineWidth(1)
+		setRenderDash(dc, 4, 4)
+		setRenderLineWidth(dc, 1)
 		dc.DrawLine(body.x, y, body.x+body.w, y)
 		dc.Stroke()
-		dc.SetDash()
+		setRenderDash(dc)
 	}
 }
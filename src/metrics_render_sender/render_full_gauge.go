@@ -29,6 +29,7 @@ func (r *FullGaugeRenderer) Render(dc *gg.Context, item *ItemConfig, frame *Rend
 
 	cardRadius := resolveItemCardRadius(item, config)
 	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), cardRadius)
+	drawItemAlertEffect(dc, item, frame, monitor, config, cardRadius)
 
 	contentPaddingX, contentPaddingY := resolveContentPaddingXY(item, config, 1, 1, 0, 0)
 	body := fullRect{
@@ -110,7 +111,7 @@ func (r *FullGaugeRenderer) drawBody(
 	start := math.Pi/2 + gapRad/2
 	end := start + sweep
 
-	dc.SetLineWidth(thickness)
+	setRenderLineWidth(dc, thickness)
 	dc.SetColor(parseColor(trackColor))
 	dc.DrawArc(cx, cy, radius, start, end)
 	dc.Stroke()
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+const heatmapMinFontSize = 7
+
+type renderFullHeatmapRuntime struct {
+	monitors        []string
+	colCount        int
+	lowColor        string
+	highColor       string
+	cellGap         float64
+	cellRadius      float64
+	showValues      bool
+	thresholdColors bool
+}
+
+type fullHeatmapCell struct {
+	monitor *RenderMonitorSnapshot
+	value   float64
+	ok      bool
+}
+
+type FullHeatmapRenderer struct{}
+
+func NewFullHeatmapRenderer() *FullHeatmapRenderer {
+	return &FullHeatmapRenderer{}
+}
+
+func (r *FullHeatmapRenderer) GetType() string {
+	return itemTypeFullHeatmap
+}
+
+func (r *FullHeatmapRenderer) RequiresMonitor() bool {
+	return false
+}
+
+func (r *FullHeatmapRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig) error {
+	if dc == nil || item == nil || fontCache == nil {
+		return nil
+	}
+
+	cardRadius := resolveItemCardRadius(item, config)
+	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), cardRadius)
+
+	contentPaddingX, contentPaddingY := resolveContentPaddingXY(item, config, 1, 1, 0, 0)
+	body := fullRect{
+		x: float64(item.X) + contentPaddingX,
+		y: float64(item.Y) + contentPaddingY,
+		w: float64(item.Width) - contentPaddingX*2,
+		h: float64(item.Height) - contentPaddingY*2,
+	}
+	if title := resolveItemTitleText(item, config); title != "" {
+		headerRect, nextBody, labelFace, valueFace := fullBuildHeaderAndBody(item, config, fontCache, title, "", contentPaddingX, contentPaddingY, 4)
+		textColor := resolveItemStaticColor(item, config)
+		drawFullHeader(dc, item, config, headerRect, labelFace, valueFace, title, "", textColor, textColor)
+		body = nextBody
+	}
+	if body.w < 1 || body.h < 1 {
+		drawBaseItemBorder(dc, item, config, cardRadius)
+		return nil
+	}
+
+	heatmap := resolveFullHeatmapRuntime(item, config)
+	cells := resolveFullHeatmapCells(heatmap.monitors, frame)
+	if len(cells) == 0 {
+		drawBaseItemBorder(dc, item, config, cardRadius)
+		return nil
+	}
+	minValue, maxValue := resolveFullHeatmapRange(item, cells)
+	cols, rows := resolveFullHeatmapGrid(len(cells), heatmap.colCount, body.w, body.h)
+
+	cellW := (body.w - heatmap.cellGap*float64(cols-1)) / float64(cols)
+	cellH := (body.h - heatmap.cellGap*float64(rows-1)) / float64(rows)
+	if cellW < 1 || cellH < 1 {
+		drawBaseItemBorder(dc, item, config, cardRadius)
+		return nil
+	}
+
+	_, valueFontSize := resolveRoleFontFace(fontCache, item, config, TextRoleValue, 14, 8)
+	fontSize := int(math.Min(float64(valueFontSize), math.Floor(cellH*0.6)))
+	showValues := heatmap.showValues && fontSize >= heatmapMinFontSize
+
+	for idx, cell := range cells {
+		x := body.x + float64(idx%cols)*(cellW+heatmap.cellGap)
+		y := body.y + float64(idx/cols)*(cellH+heatmap.cellGap)
+		if !cell.ok {
+			drawRoundedRectFill(dc, x, y, cellW, cellH, heatmap.cellRadius, applyAlpha(heatmap.lowColor, 0.25))
+			continue
+		}
+
+		cellColor := ""
+		if heatmap.thresholdColors {
+			if group := findThresholdGroupForMonitor(config, cell.monitor.name); group != nil {
+				cellColor = resolveThresholdRangeColor(group, cell.monitor.name, thresholdGroupNumber(group, cell.monitor.value, cell.value))
+			}
+		}
+		if cellColor == "" {
+			cellColor = mixHeatmapColor(heatmap.lowColor, heatmap.highColor, normalizeRatio(cell.value, minValue, maxValue))
+		}
+		drawRoundedRectFill(dc, x, y, cellW, cellH, heatmap.cellRadius, cellColor)
+
+		if !showValues {
+			continue
+		}
+		valueText, _ := FormatCollectValueParts(cell.monitor.value, resolveUnitOverride(item))
+		if valueFace := fitHeatmapValueFace(dc, fontCache, valueText, fontSize, cellW-2); valueFace != nil {
+			dc.SetColor(parseColor(heatmapTextColor(cellColor)))
+			drawMetricAnchoredText(dc, valueFace, valueText, x+cellW/2, y+cellH/2, 0.5)
+		}
+	}
+
+	drawBaseItemBorder(dc, item, config, cardRadius)
+	return nil
+}
+
+// fitHeatmapValueFace shrinks the value font until the text fits the cell
+// width, giving up below heatmapMinFontSize.
+func fitHeatmapValueFace(dc *gg.Context, fontCache *FontCache, text string, fontSize int, maxWidth float64) font.Face {
+	for size := fontSize; size >= heatmapMinFontSize; size-- {
+		face := resolveFontFace(fontCache, size)
+		dc.SetFontFace(face)
+		if width, _ := dc.MeasureString(text); width <= maxWidth {
+			return face
+		}
+	}
+	return nil
+}
+
+func prepareRenderFullHeatmapRuntime(item *ItemConfig, config *MonitorConfig) renderFullHeatmapRuntime {
+	return renderFullHeatmapRuntime{
+		monitors:        fullHeatmapMonitorsAttr(item),
+		colCount:        getItemAttrIntCfg(item, config, "col_count", 0),
+		lowColor:        getItemAttrColorCfg(item, config, "heatmap_low_color", "#1e3a8a"),
+		highColor:       getItemAttrColorCfg(item, config, "heatmap_high_color", "#ef4444"),
+		cellGap:         clampMinFloat(getItemAttrFloatCfg(item, config, "heatmap_cell_gap", 2), 0),
+		cellRadius:      clampMinFloat(getItemAttrFloatCfg(item, config, "heatmap_cell_radius", 2), 0),
+		showValues:      getItemAttrBoolCfg(item, config, "heatmap_show_values", true),
+		thresholdColors: getItemAttrBoolCfg(item, config, "enable_threshold_colors", false),
+	}
+}
+
+func resolveFullHeatmapRuntime(item *ItemConfig, config *MonitorConfig) renderFullHeatmapRuntime {
+	if item.runtime.prepared {
+		return item.runtime.fullHeatmap
+	}
+	return prepareRenderFullHeatmapRuntime(item, config)
+}
+
+func fullHeatmapMonitorsAttr(item *ItemConfig) []string {
+	raw, _ := getItemAttr(item, "monitors")
+	return parseMonitorListAttr(raw)
+}
+
+func resolveFullHeatmapCells(monitors []string, frame *RenderFrame) []fullHeatmapCell {
+	cells := make([]fullHeatmapCell, 0, len(monitors))
+	for _, name := range monitors {
+		cell := fullHeatmapCell{value: math.NaN()}
+		if frame != nil {
+			cell.monitor = frame.ResolveMonitor(name)
+		}
+		if cell.monitor != nil && cell.monitor.available && cell.monitor.value != nil {
+			cell.value, cell.ok = tryGetFloat64(cell.monitor.value.Value)
+		}
+		cells = append(cells, cell)
+	}
+	return cells
+}
+
+// resolveFullHeatmapRange picks one color scale for all cells: the item's
+// min/max when set, otherwise the unit profile or the peak of the current
+// values, the same way single-value range items resolve theirs.
+func resolveFullHeatmapRange(item *ItemConfig, cells []fullHeatmapCell) (float64, float64) {
+	values := make([]float64, 0, len(cells))
+	var sample *CollectValue
+	for _, cell := range cells {
+		if !cell.ok {
+			continue
+		}
+		values = append(values, cell.value)
+		if sample == nil {
+			sample = cell.monitor.value
+		}
+	}
+	current := math.NaN()
+	if len(values) > 0 {
+		current = values[len(values)-1]
+	}
+	return resolveEffectiveMinMax(item, sample, values, current)
+}
+
+// resolveFullHeatmapGrid returns the column and row count for count cells.
+// Without an explicit column count the grid is chosen so the cells come out
+// roughly square in the given area.
+func resolveFullHeatmapGrid(count, colCount int, width, height float64) (int, int) {
+	if count < 1 {
+		return 1, 1
+	}
+	cols := colCount
+	if cols < 1 {
+		cols = 1
+		if width > 0 && height > 0 {
+			cols = int(math.Ceil(math.Sqrt(float64(count) * width / height)))
+		}
+	}
+	if cols > count {
+		cols = count
+	}
+	if cols < 1 {
+		cols = 1
+	}
+	return cols, (count + cols - 1) / cols
+}
+
+func mixHeatmapColor(lowColor, highColor string, ratio float64) string {
+	low := parseColorNRGBA(lowColor)
+	high := parseColorNRGBA(highColor)
+	ratio = clampFloat64(ratio, 0, 1)
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*ratio))
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", mix(low.R, high.R), mix(low.G, high.G), mix(low.B, high.B), mix(low.A, high.A))
+}
+
+// heatmapTextColor picks dark or light text depending on the cell luminance.
+func heatmapTextColor(cellColor string) string {
+	c := parseColorNRGBA(cellColor)
+	luminance := 0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)
+	if luminance > 150 {
+		return "#0f172a"
+	}
+	return "#f8fafc"
+}
+
+func normalizeFullHeatmapItemAttrs(item *ItemConfig) {
+	if item == nil {
+		return
+	}
+	if item.RenderAttrsMap == nil {
+		item.RenderAttrsMap = map[string]interface{}{}
+	}
+	if monitors := fullHeatmapMonitorsAttr(item); len(monitors) > 0 {
+		item.RenderAttrsMap["monitors"] = monitors
+	} else {
+		delete(item.RenderAttrsMap, "monitors")
+	}
+	if colCount := getItemAttrInt(item, "col_count", 0); colCount > 0 {
+		item.RenderAttrsMap["col_count"] = colCount
+	} else {
+		delete(item.RenderAttrsMap, "col_count")
+	}
+}
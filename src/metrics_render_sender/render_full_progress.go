@@ -35,6 +35,7 @@ func (r *FullProgressRenderer) Render(dc *gg.Context, item *ItemConfig, frame *R
 
 	cardRadius := resolveItemCardRadius(item, config)
 	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), cardRadius)
+	drawItemAlertEffect(dc, item, frame, monitor, config, cardRadius)
 
 	labelText, valueText, unitText := fullResolveTextParts(item, monitor, value, config)
 	displayValue := strings.TrimSpace(valueText + " " + unitText)
@@ -100,8 +101,8 @@ func (r *FullProgressRenderer) drawVertical(
 	progress, style, barRadius, barWidth, trackColor, segments, segmentGap := resolveFullProgressLayout(item, frame, value, numberValue, config)
 	contentPaddingX, contentPaddingY := resolveContentPaddingXY(item, config, 2, 2, 0, 0)
 
-	valueFace, valueFontSize := resolveRoleFontFace(fontCache, item, config, TextRoleValue, 18, 8)
-	_, unitFontSize := resolveRoleFontFace(fontCache, item, config, TextRoleUnit, 14, 8)
+	valueFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleValue, 18, 8)
+	unitFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleUnit, 14, 8)
 	textFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleText, 16, 8)
 	displayValue := strings.TrimSpace(valueText + " " + unitText)
 
@@ -150,15 +151,11 @@ func (r *FullProgressRenderer) drawVertical(
 		dc,
 		valueText,
 		unitText,
-		int(math.Round(valueRect.x)),
-		int(math.Round(valueRect.y)),
-		int(math.Round(valueRect.w)),
-		int(math.Round(valueRect.h)),
-		valueFontSize,
+		valueRect,
+		valueFace,
 		valueColor,
-		unitFontSize,
+		unitFace,
 		unitColor,
-		fontCache,
 	)
 
 	if barWidth <= 0 || barWidth > barRect.w {
@@ -250,7 +247,7 @@ func drawFullProgressFillHorizontal(
 		dc.SetColor(parseColor(applyAlpha("#ffffff", 0.2)))
 		for lineX := x - barHeight; lineX < x+fillWidth+barHeight; lineX += 8 {
 			dc.DrawLine(lineX, y+barHeight, lineX+barHeight, y)
-			dc.SetLineWidth(2)
+			setRenderLineWidth(dc, 2)
 			dc.Stroke()
 		}
 		dc.ResetClip()
@@ -308,7 +305,7 @@ func drawFullProgressFillVertical(
 		dc.SetColor(parseColor(applyAlpha("#ffffff", 0.2)))
 		for lineY := y + fillHeight + barWidth; lineY > y-barWidth; lineY -= 8 {
 			dc.DrawLine(x, lineY, x+barWidth, lineY-barWidth)
-			dc.SetLineWidth(2)
+			setRenderLineWidth(dc, 2)
 			dc.Stroke()
 		}
 		dc.ResetClip()
@@ -482,7 +482,7 @@ func drawFullTableGrid(
 		return
 	}
 	dc.SetColor(parseColor(borderColor))
-	dc.SetLineWidth(borderWidth)
+	setRenderLineWidth(dc, borderWidth)
 
 	gridWidth := cellWidth*float64(columns) + columnGap*float64(max(0, columns-1))
 	gridHeight := rowHeight*float64(rowCount) + rowGap*float64(max(0, rowCount-1))
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// fullTimerMonitors are the timer collector monitors a full_timer item
+// reads; the item has no monitor of its own.
+var fullTimerMonitors = []string{"timer.remaining_text", "timer.progress", "timer.phase", "timer.state"}
+
+type renderFullTimerRuntime struct {
+	workColor      string
+	breakColor     string
+	longBreakColor string
+	idleColor      string
+	trackColor     string
+	barHeight      float64
+}
+
+// FullTimerRenderer shows the pomodoro timer: the phase, the time left and
+// a bar of the elapsed share, colored by phase and grayed while the timer
+// is paused or waiting for timer_start.
+type FullTimerRenderer struct{}
+
+func NewFullTimerRenderer() *FullTimerRenderer {
+	return &FullTimerRenderer{}
+}
+
+func (r *FullTimerRenderer) GetType() string {
+	return itemTypeFullTimer
+}
+
+func (r *FullTimerRenderer) RequiresMonitor() bool {
+	return false
+}
+
+func (r *FullTimerRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig) error {
+	if dc == nil || item == nil || fontCache == nil {
+		return nil
+	}
+
+	cardRadius := resolveItemCardRadius(item, config)
+	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), cardRadius)
+
+	contentPaddingX, contentPaddingY := resolveContentPaddingXY(item, config, 4, 4, 0, 0)
+	body := fullRect{
+		x: float64(item.X) + contentPaddingX,
+		y: float64(item.Y) + contentPaddingY,
+		w: float64(item.Width) - contentPaddingX*2,
+		h: float64(item.Height) - contentPaddingY*2,
+	}
+	if body.w < 1 || body.h < 1 {
+		drawBaseItemBorder(dc, item, config, cardRadius)
+		return nil
+	}
+
+	timer := resolveFullTimerRuntime(item, config)
+	remaining := fullTimerMonitorText(frame, "timer.remaining_text")
+	if remaining == "" {
+		remaining = "--:--"
+	}
+	phase := fullTimerMonitorText(frame, "timer.phase")
+	state := fullTimerMonitorText(frame, "timer.state")
+	progress, _ := fullTimerMonitorValue(frame, "timer.progress")
+	accent := timer.phaseColor(phase, state)
+
+	label := resolveItemTitleText(item, config)
+	if label == "" {
+		label = fullTimerPhaseLabel(phase, state)
+	}
+	textFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleText, 14, 8)
+	valueFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleValue, 32, 8)
+	labelMetrics := baseMeasureText(textFace, label)
+	valueMetrics := baseMeasureText(valueFace, remaining)
+	labelHeight := labelMetrics.ascent + labelMetrics.descent
+	valueHeight := valueMetrics.ascent + valueMetrics.descent
+	barHeight := math.Min(timer.barHeight, body.h/4)
+	gap := 4.0
+	total := labelHeight + gap + valueHeight + gap + barHeight
+	top := body.y + math.Max(0, (body.h-total)/2)
+
+	cx := body.x + body.w/2
+	dc.SetColor(parseColor(resolveItemStaticColor(item, config)))
+	drawBaseMetricAnchoredText(dc, textFace, label, cx, top+labelHeight/2, 0.5)
+	dc.SetColor(parseColor(accent))
+	drawBaseMetricAnchoredText(dc, valueFace, remaining, cx, top+labelHeight+gap+valueHeight/2, 0.5)
+
+	if barHeight >= 1 {
+		barY := math.Min(top+labelHeight+gap+valueHeight+gap, body.y+body.h-barHeight)
+		drawRoundedRectFill(dc, body.x, barY, body.w, barHeight, barHeight/2, timer.trackColor)
+		if filled := body.w * clampFloat64(progress, 0, 100) / 100; filled >= 1 {
+			drawRoundedRectFill(dc, body.x, barY, filled, barHeight, barHeight/2, accent)
+		}
+	}
+
+	drawBaseItemBorder(dc, item, config, cardRadius)
+	return nil
+}
+
+func (t renderFullTimerRuntime) phaseColor(phase, state string) string {
+	if state != pomodoroStateRunning {
+		return t.idleColor
+	}
+	switch phase {
+	case pomodoroPhaseShortBreak:
+		return t.breakColor
+	case pomodoroPhaseLongBreak:
+		return t.longBreakColor
+	default:
+		return t.workColor
+	}
+}
+
+func fullTimerPhaseLabel(phase, state string) string {
+	label := "FOCUS"
+	switch phase {
+	case pomodoroPhaseShortBreak:
+		label = "BREAK"
+	case pomodoroPhaseLongBreak:
+		label = "LONG BREAK"
+	}
+	switch state {
+	case pomodoroStatePaused:
+		return label + " · PAUSED"
+	case pomodoroStateStopped:
+		return label + " · READY"
+	}
+	return label
+}
+
+func fullTimerMonitorText(frame *RenderFrame, name string) string {
+	if frame == nil {
+		return ""
+	}
+	monitor := frame.ResolveMonitor(name)
+	if monitor == nil || !monitor.available || monitor.value == nil {
+		return ""
+	}
+	text, _ := monitor.value.Value.(string)
+	return strings.TrimSpace(text)
+}
+
+func fullTimerMonitorValue(frame *RenderFrame, name string) (float64, bool) {
+	if frame == nil {
+		return 0, false
+	}
+	monitor := frame.ResolveMonitor(name)
+	if monitor == nil || !monitor.available || monitor.value == nil {
+		return 0, false
+	}
+	return tryGetFloat64(monitor.value.Value)
+}
+
+func prepareRenderFullTimerRuntime(item *ItemConfig, config *MonitorConfig) renderFullTimerRuntime {
+	return renderFullTimerRuntime{
+		workColor:      getItemAttrColorCfg(item, config, "timer_work_color", "#ef4444"),
+		breakColor:     getItemAttrColorCfg(item, config, "timer_break_color", "#22c55e"),
+		longBreakColor: getItemAttrColorCfg(item, config, "timer_long_break_color", "#3b82f6"),
+		idleColor:      getItemAttrColorCfg(item, config, "timer_idle_color", "#9ca3af"),
+		trackColor:     getItemAttrColorCfg(item, config, "track_color", "#1f2937"),
+		barHeight:      clampMinFloat(getItemAttrFloatCfg(item, config, "bar_height", 4), 0),
+	}
+}
+
+func resolveFullTimerRuntime(item *ItemConfig, config *MonitorConfig) renderFullTimerRuntime {
+	if item.runtime.prepared {
+		return item.runtime.fullTimer
+	}
+	return prepareRenderFullTimerRuntime(item, config)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+type renderFullVUMeterRuntime struct {
+	monitors   []string
+	vertical   bool
+	segments   int
+	segmentGap float64
+	offColor   string
+	lowColor   string
+	midColor   string
+	highColor  string
+	midAt      float64
+	highAt     float64
+}
+
+// FullVUMeterRenderer draws one LED-style segmented bar per monitor, green
+// up to vu_mid_at percent of the range, yellow up to vu_high_at and red
+// above, the look of a hardware level meter. With the audio collector,
+// audio.left and audio.right make a stereo meter.
+type FullVUMeterRenderer struct{}
+
+func NewFullVUMeterRenderer() *FullVUMeterRenderer {
+	return &FullVUMeterRenderer{}
+}
+
+func (r *FullVUMeterRenderer) GetType() string {
+	return itemTypeFullVUMeter
+}
+
+func (r *FullVUMeterRenderer) RequiresMonitor() bool {
+	return false
+}
+
+func (r *FullVUMeterRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig) error {
+	if dc == nil || item == nil || fontCache == nil {
+		return nil
+	}
+
+	cardRadius := resolveItemCardRadius(item, config)
+	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), cardRadius)
+
+	contentPaddingX, contentPaddingY := resolveContentPaddingXY(item, config, 1, 1, 0, 0)
+	body := fullRect{
+		x: float64(item.X) + contentPaddingX,
+		y: float64(item.Y) + contentPaddingY,
+		w: float64(item.Width) - contentPaddingX*2,
+		h: float64(item.Height) - contentPaddingY*2,
+	}
+	if title := resolveItemTitleText(item, config); title != "" {
+		headerRect, nextBody, labelFace, valueFace := fullBuildHeaderAndBody(item, config, fontCache, title, "", contentPaddingX, contentPaddingY, 4)
+		textColor := resolveItemStaticColor(item, config)
+		drawFullHeader(dc, item, config, headerRect, labelFace, valueFace, title, "", textColor, textColor)
+		body = nextBody
+	}
+
+	meter := resolveFullVUMeterRuntime(item, config)
+	// The bars reuse the heatmap cells: one value per monitor.
+	bars := resolveFullHeatmapCells(meter.monitors, frame)
+	if body.w < 1 || body.h < 1 || len(bars) == 0 {
+		drawBaseItemBorder(dc, item, config, cardRadius)
+		return nil
+	}
+	minValue, maxValue := resolveFullHeatmapRange(item, bars)
+
+	barGap := meter.segmentGap * 2
+	across, along := body.h, body.w
+	if meter.vertical {
+		across, along = body.w, body.h
+	}
+	barSize := (across - barGap*float64(len(bars)-1)) / float64(len(bars))
+	segmentSize := (along - meter.segmentGap*float64(meter.segments-1)) / float64(meter.segments)
+	if barSize < 1 || segmentSize < 1 {
+		drawBaseItemBorder(dc, item, config, cardRadius)
+		return nil
+	}
+
+	for idx, bar := range bars {
+		lit := 0
+		if bar.ok {
+			lit = fullVUMeterLitSegments(normalizeRatio(bar.value, minValue, maxValue), meter.segments)
+		}
+		barOffset := float64(idx) * (barSize + barGap)
+		for segment := 0; segment < meter.segments; segment++ {
+			segmentColor := meter.offColor
+			if segment < lit {
+				segmentColor = meter.segmentColor(segment)
+			}
+			segmentOffset := float64(segment) * (segmentSize + meter.segmentGap)
+			if meter.vertical {
+				// Vertical meters fill from the bottom.
+				x := body.x + barOffset
+				y := body.y + body.h - segmentOffset - segmentSize
+				drawRoundedRectFill(dc, x, y, barSize, segmentSize, 0, segmentColor)
+			} else {
+				drawRoundedRectFill(dc, body.x+segmentOffset, body.y+barOffset, segmentSize, barSize, 0, segmentColor)
+			}
+		}
+	}
+
+	drawBaseItemBorder(dc, item, config, cardRadius)
+	return nil
+}
+
+// fullVUMeterLitSegments rounds up, so any signal above the floor lights the
+// first segment.
+func fullVUMeterLitSegments(ratio float64, segments int) int {
+	if ratio <= 0 || math.IsNaN(ratio) {
+		return 0
+	}
+	lit := int(math.Ceil(ratio * float64(segments)))
+	if lit > segments {
+		return segments
+	}
+	return lit
+}
+
+// segmentColor picks the zone color of a segment by where its top edge sits
+// in the range.
+func (m renderFullVUMeterRuntime) segmentColor(segment int) string {
+	position := float64(segment+1) / float64(m.segments) * 100
+	switch {
+	case position > m.highAt:
+		return m.highColor
+	case position > m.midAt:
+		return m.midColor
+	default:
+		return m.lowColor
+	}
+}
+
+func prepareRenderFullVUMeterRuntime(item *ItemConfig, config *MonitorConfig) renderFullVUMeterRuntime {
+	midAt := clampFloat64(getItemAttrFloatCfg(item, config, "vu_mid_at", 70), 0, 100)
+	return renderFullVUMeterRuntime{
+		monitors:   fullVUMeterMonitorsAttr(item),
+		vertical:   getItemAttrStringCfg(item, config, "progress_orientation", "horizontal") == "vertical",
+		segments:   clampRenderInt(getItemAttrIntCfg(item, config, "segments", 20), 4),
+		segmentGap: clampMinFloat(getItemAttrFloatCfg(item, config, "segment_gap", 1), 0),
+		offColor:   getItemAttrColorCfg(item, config, "track_color", "#1f2937"),
+		lowColor:   getItemAttrColorCfg(item, config, "vu_low_color", "#22c55e"),
+		midColor:   getItemAttrColorCfg(item, config, "vu_mid_color", "#eab308"),
+		highColor:  getItemAttrColorCfg(item, config, "vu_high_color", "#ef4444"),
+		midAt:      midAt,
+		highAt:     clampFloat64(getItemAttrFloatCfg(item, config, "vu_high_at", 90), midAt, 100),
+	}
+}
+
+func resolveFullVUMeterRuntime(item *ItemConfig, config *MonitorConfig) renderFullVUMeterRuntime {
+	if item.runtime.prepared {
+		return item.runtime.fullVUMeter
+	}
+	return prepareRenderFullVUMeterRuntime(item, config)
+}
+
+func fullVUMeterMonitorsAttr(item *ItemConfig) []string {
+	raw, _ := getItemAttr(item, "monitors")
+	return parseMonitorListAttr(raw)
+}
+
+func normalizeFullVUMeterItemAttrs(item *ItemConfig) {
+	if item == nil {
+		return
+	}
+	if item.RenderAttrsMap == nil {
+		item.RenderAttrsMap = map[string]interface{}{}
+	}
+	if monitors := fullVUMeterMonitorsAttr(item); len(monitors) > 0 {
+		item.RenderAttrsMap["monitors"] = monitors
+	} else {
+		delete(item.RenderAttrsMap, "monitors")
+	}
+}
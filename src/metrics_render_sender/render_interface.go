@@ -5,6 +5,7 @@ import (
 	"image"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/fogleman/gg"
 )
@@ -19,10 +20,11 @@ type monitorBoundRenderer interface {
 }
 
 type RenderManager struct {
-	renderers map[string]RenderItem
-	fontCache *FontCache
-	registry  *CollectorManager
-	history   *renderHistoryStore
+	renderers   map[string]RenderItem
+	fontCache   *FontCache
+	registry    *CollectorManager
+	history     *renderHistoryStore
+	staticLayer renderStaticLayer
 }
 
 type renderFullCardRuntime struct {
@@ -37,6 +39,14 @@ type renderFullCardRuntime struct {
 type renderSimpleChartRuntime struct {
 	lineWidth             float64
 	enableThresholdColors bool
+	showGridLines         bool
+	gridLines             int
+	gridLineColor         string
+	showAxisLabels        bool
+	showThresholdLines    bool
+	showTimeSpan          bool
+	fillColor             string
+	logScale              bool
 	thresholdPercents     []float64
 	levelColors           []string
 }
@@ -46,11 +56,14 @@ type renderFullChartRuntime struct {
 	fillColor             string
 	chartAreaBg           string
 	chartAreaBorder       string
+	chartAreaRadius       float64
 	showSegmentLines      bool
 	gridLines             int
+	gridLineColor         string
 	lineWidth             float64
 	enableThresholdColors bool
 	showAvgLine           bool
+	logScale              bool
 	thresholdPercents     []float64
 	levelColors           []string
 }
@@ -77,6 +90,18 @@ type renderFullProgressRuntime struct {
 	segmentGap float64
 }
 
+type renderSimpleProgressRuntime struct {
+	vertical      bool
+	style         string
+	roundCaps     bool
+	trackColor    string
+	segments      int
+	segmentGap    float64
+	tickCount     int
+	tickColor     string
+	valuePosition string
+}
+
 type renderFullGaugeRuntime struct {
 	thickness  float64
 	gapDegrees float64
@@ -87,6 +112,8 @@ type renderFullGaugeRuntime struct {
 type renderSimpleLineRuntime struct {
 	orientation string
 	lineWidth   float64
+	dash        string
+	dashLength  float64
 }
 
 type renderSpecialFormatRuntime struct {
@@ -94,12 +121,14 @@ type renderSpecialFormatRuntime struct {
 	kind            string
 	timeLayout      string
 	displayTemplate string
+	valueFormat     string
 }
 
 type renderItemRuntime struct {
 	prepared            bool
 	historyKey          string
 	historyPoints       int
+	historyStride       int
 	background          string
 	staticColor         string
 	explicitStaticColor string
@@ -121,37 +150,65 @@ type renderItemRuntime struct {
 	text                string
 	fullCard            renderFullCardRuntime
 	simpleChart         renderSimpleChartRuntime
+	simpleProgress      renderSimpleProgressRuntime
 	fullChart           renderFullChartRuntime
 	fullTable           renderFullTableRuntime
 	fullProgress        renderFullProgressRuntime
 	fullGauge           renderFullGaugeRuntime
+	fullHeatmap         renderFullHeatmapRuntime
+	fullVUMeter         renderFullVUMeterRuntime
+	fullTimer           renderFullTimerRuntime
 	simpleLine          renderSimpleLineRuntime
+	textLayout          renderTextLayoutRuntime
 	specialFormat       renderSpecialFormatRuntime
+	alert               renderAlertRuntime
+	shadow              renderShadowRuntime
+	showLevelLabel      bool
+	tabularNumbers      bool
+	stackMonitors       []string
 }
 
+// monitorWarmupPlaceholder stands in for the value of a monitor that has
+// not produced a sample yet.
+const monitorWarmupPlaceholder = "--"
+
 type RenderMonitorSnapshot struct {
 	name      string
 	label     string
 	available bool
 	value     *CollectValue
+	updatedAt time.Time
+	stale     bool
 }
 
 type renderItemState struct {
 	monitor *RenderMonitorSnapshot
+	// stale is set when the stale section marks the monitor unavailable or
+	// out of date; the item then gets the stale style over it.
+	stale bool
 }
 
 type RenderFrame struct {
-	registry *CollectorManager
-	monitors map[string]*RenderMonitorSnapshot
-	items    map[*ItemConfig]renderItemState
-	history  *renderHistoryStore
+	registry   *CollectorManager
+	monitors   map[string]*RenderMonitorSnapshot
+	items      map[*ItemConfig]renderItemState
+	history    *renderHistoryStore
+	renderedAt time.Time
+	tick       time.Duration
+	stale      staleSettings
 }
 
 func newRenderFrame(registry *CollectorManager, history *renderHistoryStore, renderers map[string]RenderItem, config *MonitorConfig) *RenderFrame {
 	frame := &RenderFrame{
-		registry: registry,
-		monitors: make(map[string]*RenderMonitorSnapshot),
-		history:  history,
+		registry:   registry,
+		monitors:   make(map[string]*RenderMonitorSnapshot),
+		history:    history,
+		renderedAt: time.Now(),
+		tick:       time.Second,
+	}
+	if config != nil {
+		frame.tick = config.GetCollectTickDuration()
+		frame.stale = resolveStaleSettings(config.Stale)
 	}
 	if registry == nil || config == nil || len(config.Items) == 0 {
 		frame.items = make(map[*ItemConfig]renderItemState)
@@ -165,6 +222,7 @@ func newRenderFrame(registry *CollectorManager, history *renderHistoryStore, ren
 		state := renderItemState{}
 		if rendererRequiresMonitor(renderer) {
 			state.monitor = resolveRenderMonitorSnapshot(frame.monitors, registry, item.Monitor)
+			state.monitor, state.stale = frame.stale.apply(state.monitor, frame.renderedAt)
 		}
 		frame.items[item] = state
 	}
@@ -184,12 +242,21 @@ func resolveRenderMonitorSnapshot(cache map[string]*RenderMonitorSnapshot, regis
 		cache[name] = nil
 		return nil
 	}
-	_, available, value := collectItem.SnapshotState()
+	_, available, ready, value := collectItem.SnapshotState()
+	if available && !ready {
+		// Until the first sample the value is a placeholder, as text so
+		// gauges, charts and alerts leave it alone.
+		value = &CollectValue{Value: monitorWarmupPlaceholder, ValueKind: ValueKindText}
+	}
 	monitor := &RenderMonitorSnapshot{
 		name:      collectItem.GetName(),
 		label:     collectItem.GetLabel(),
 		available: available,
 		value:     value,
+		updatedAt: collectItem.UpdatedAt(),
+	}
+	if available && ready {
+		_, monitor.stale = registry.ItemStaleness(collectItem, time.Now())
 	}
 	cache[name] = monitor
 	return monitor
@@ -211,7 +278,10 @@ func (f *RenderFrame) AvailableItemValue(item *ItemConfig) (*RenderMonitorSnapsh
 		return nil, nil, false
 	}
 	state, exists := f.items[item]
-	if !exists || state.monitor == nil || !state.monitor.available || state.monitor.value == nil {
+	if !exists || state.monitor == nil || state.monitor.value == nil {
+		return nil, nil, false
+	}
+	if !state.monitor.available && !state.stale {
 		return nil, nil, false
 	}
 	return state.monitor, state.monitor.value, true
@@ -279,6 +349,9 @@ func NewRenderManagerWithHistory(fontCache *FontCache, registry *CollectorManage
 	rm.RegisterRenderer(NewFullProgressRenderer(itemTypeFullProgressH, false))
 	rm.RegisterRenderer(NewFullProgressRenderer(itemTypeFullProgressV, true))
 	rm.RegisterRenderer(NewFullGaugeRenderer())
+	rm.RegisterRenderer(NewFullHeatmapRenderer())
+	rm.RegisterRenderer(NewFullVUMeterRenderer())
+	rm.RegisterRenderer(NewFullTimerRenderer())
 
 	return rm
 }
@@ -288,23 +361,66 @@ func (rm *RenderManager) RegisterRenderer(renderer RenderItem) {
 }
 
 func (rm *RenderManager) Render(config *MonitorConfig) (*RenderResult, error) {
-	dc := gg.NewContext(config.Width, config.Height)
-	dc.SetColor(parseColor(config.GetDefaultBackgroundColor()))
-	dc.Clear()
 	frame := newRenderFrame(rm.registry, rm.history, rm.renderers, config)
+	groupOffsets := resolveItemGroupOffsets(config)
+	order := resolveItemRenderOrder(config.Items)
 
-	for idx := range config.Items {
-		item := &config.Items[idx]
-		renderer, exists := rm.renderers[item.Type]
-		if !exists {
-			continue
-		}
-		if err := rm.renderItemSafely(renderer, dc, item, frame, config); err != nil {
-			logWarnModule("render", "skip item idx=%d type=%s monitor=%s: %v", idx, item.Type, strings.TrimSpace(item.Monitor), err)
-		}
+	scale := config.GetRenderScale()
+
+	base, staticCount := rm.resolveStaticLayer(config, order, frame, groupOffsets)
+	img := cloneRGBA(base)
+	dc := gg.NewContextForRGBA(img)
+	dc.Scale(float64(scale), float64(scale))
+	for _, idx := range order[staticCount:] {
+		rm.drawItem(dc, idx, frame, config, groupOffsets)
+	}
+
+	return NewRenderResult(downscaleRGBA(img, scale)), nil
+}
+
+// resolveStaticLayer returns the background with the leading static items
+// already drawn, rebuilding it when the config changed since the last frame.
+func (rm *RenderManager) resolveStaticLayer(config *MonitorConfig, order []int, frame *RenderFrame, groupOffsets map[string]ItemGroupConfig) (*image.RGBA, int) {
+	layer := &rm.staticLayer
+	layer.mu.Lock()
+	defer layer.mu.Unlock()
+	if layer.matches(config) {
+		return layer.image, layer.staticCount
 	}
 
-	return NewRenderResult(dc.Image()), nil
+	scale := config.GetRenderScale()
+	dc := gg.NewContext(config.Width*scale, config.Height*scale)
+	dc.SetColor(parseColor(config.GetDefaultBackgroundColor()))
+	dc.Clear()
+	dc.Scale(float64(scale), float64(scale))
+	staticCount := countLeadingStaticItems(rm.renderers, config.Items, order)
+	for _, idx := range order[:staticCount] {
+		rm.drawItem(dc, idx, frame, config, groupOffsets)
+	}
+	img, _ := dc.Image().(*image.RGBA)
+	layer.store(config, img, staticCount)
+	return img, staticCount
+}
+
+func (rm *RenderManager) drawItem(dc *gg.Context, idx int, frame *RenderFrame, config *MonitorConfig, groupOffsets map[string]ItemGroupConfig) {
+	item := &config.Items[idx]
+	renderer, exists := rm.renderers[item.Type]
+	if !exists || lowPowerSkipsItem(config, item) {
+		return
+	}
+	offset, shifted := groupOffsets[item.Group]
+	if shifted {
+		dc.Push()
+		dc.Translate(float64(offset.X), float64(offset.Y))
+	}
+	drawItemShadow(dc, item, config)
+	if err := rm.renderItemSafely(renderer, dc, item, frame, config); err != nil {
+		logWarnModule("render", "skip item idx=%d type=%s monitor=%s: %v", idx, item.Type, strings.TrimSpace(item.Monitor), err)
+	}
+	drawItemStaleStyle(dc, item, frame, config)
+	if shifted {
+		dc.Pop()
+	}
 }
 
 func (rm *RenderManager) renderItemSafely(renderer RenderItem, dc *gg.Context, item *ItemConfig, frame *RenderFrame, config *MonitorConfig) (err error) {
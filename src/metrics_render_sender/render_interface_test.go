@@ -1,6 +1,9 @@
 package main
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestNewRenderManagerWithHistoryReusesExistingStore(t *testing.T) {
 	history := newRenderHistoryStore()
@@ -22,3 +25,53 @@ func TestNewRenderManagerWithHistoryCreatesStoreWhenNil(t *testing.T) {
 		t.Fatalf("expected render manager to create history store")
 	}
 }
+
+func TestRenderHistoryStoreAveragesStridedSamples(t *testing.T) {
+	history := newRenderHistoryStore()
+	var got []float64
+	for _, value := range []float64{1, 3, 5, 7, 9} {
+		got = history.append("cpu", value, 10, 2)
+	}
+	if len(got) != 10 {
+		t.Fatalf("expected 10 points, got %d", len(got))
+	}
+	want := []float64{2, 6, 9}
+	tail := got[len(got)-len(want):]
+	for idx := range want {
+		if tail[idx] != want[idx] {
+			t.Fatalf("expected tail %v, got %v", want, tail)
+		}
+	}
+	if !math.IsNaN(got[len(got)-len(want)-1]) {
+		t.Fatalf("expected NaN padding before strided points, got %v", got)
+	}
+
+	got = history.append("cpu", 11, 10, 2)
+	if got[len(got)-1] != 10 || got[len(got)-2] != 6 {
+		t.Fatalf("expected completed bucket to replace pending point, got %v", got)
+	}
+}
+
+func TestResolveRenderMonitorSnapshotShowsPlaceholderUntilReady(t *testing.T) {
+	registry := NewCollectorManager()
+	item := NewCollectItem("go_native.cpu.usage", "CPU usage", "%", 0, 100, 1)
+	item.SetAvailable(true)
+	registry.items[item.GetName()] = item
+
+	monitor := resolveRenderMonitorSnapshot(map[string]*RenderMonitorSnapshot{}, registry, item.GetName())
+	if monitor == nil || !monitor.available {
+		t.Fatalf("expected an available snapshot, got %+v", monitor)
+	}
+	if text := FormatCollectValue(monitor.value, true, ""); text != monitorWarmupPlaceholder {
+		t.Fatalf("expected %q before the first sample, got %q", monitorWarmupPlaceholder, text)
+	}
+	if _, ok := tryGetFloat64(monitor.value.Value); ok {
+		t.Fatal("expected the placeholder not to read as a number")
+	}
+
+	item.SetValue(12.5)
+	monitor = resolveRenderMonitorSnapshot(map[string]*RenderMonitorSnapshot{}, registry, item.GetName())
+	if number, ok := tryGetFloat64(monitor.value.Value); !ok || number != 12.5 {
+		t.Fatalf("expected the sample once ready, got %v", monitor.value.Value)
+	}
+}
@@ -16,17 +16,25 @@ func (r *LabelRenderer) RequiresMonitor() bool {
 	return false
 }
 
+func (r *LabelRenderer) StaticContent() bool {
+	return true
+}
+
 func (r *LabelRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig) error {
 	_ = frame
 	if item == nil || item.Text == "" {
 		return nil
 	}
 	drawBaseItemFrame(dc, item, config)
+	layout := resolveItemTextLayoutRuntime(item, config)
 	drawTextInItemRect(dc, fontCache, item, config, item.Text, item.X, item.Y, item.Width, item.Height, BaseTextDrawOptions{
-		Role:     TextRoleText,
-		AlignH:   AlignLeft,
-		AlignV:   AlignMiddle,
-		PaddingX: 4,
+		Role:        TextRoleText,
+		AlignH:      layout.alignH,
+		AlignV:      layout.alignV,
+		PaddingX:    4,
+		Wrap:        layout.wrap,
+		Ellipsis:    layout.ellipsis,
+		LineSpacing: layout.lineSpacing,
 	})
 	return nil
 }
@@ -1,9 +1,18 @@
 package main
 
 import (
+	"math"
 	"strings"
 
 	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+const (
+	labelPositionLeft   = "left"
+	labelPositionAbove  = "above"
+	labelPositionBelow  = "below"
+	labelPositionInline = "inline"
 )
 
 type LabelTextRenderer struct {
@@ -38,6 +47,7 @@ func (r *LabelTextRenderer) Render(dc *gg.Context, item *ItemConfig, frame *Rend
 
 	radius := resolveItemRadius(item, config, 0)
 	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), radius)
+	drawItemAlertEffect(dc, item, frame, monitor, config, radius)
 
 	r.renderLabelText1(dc, item, fontCache, config, monitor, textText, valueText, unitText)
 
@@ -73,27 +83,115 @@ func (r *LabelTextRenderer) renderLabelText1(
 		textHeight = 1
 		textTop = float64(item.Y)
 	}
-	centerY := textTop + textHeight/2
+	layout := resolveItemTextLayoutRuntime(item, config)
+	left := float64(item.X) + paddingX
+	right := float64(item.X+item.Width) - paddingX
+	bottom := textTop + textHeight
 
-	dc.SetColor(parseColor(textColor))
-	drawMetricAnchoredText(dc, textFace, textText, float64(item.X)+paddingX, centerY, 0)
+	dc.SetFontFace(valueFace)
+	valueWidth, _ := dc.MeasureString(valueText)
+	unitWidth := 0.0
+	gap := 0.0
+	if strings.TrimSpace(unitText) != "" {
+		dc.SetFontFace(unitFace)
+		unitWidth, _ = dc.MeasureString(unitText)
+		gap = 2.0
+	}
+	runWidth := valueWidth + gap + unitWidth
+	textLineHeight := resolveFaceLineHeight(textFace)
+	valueLineHeight := math.Max(resolveFaceLineHeight(valueFace), resolveFaceLineHeight(unitFace))
+	dc.SetFontFace(textFace)
+	measureText := newTextMeasureFunc(dc)
 
-	rightX := float64(item.X+item.Width) - paddingX
-	if strings.TrimSpace(unitText) == "" {
+	drawValueRun := func(startX, centerY float64) {
 		dc.SetColor(parseColor(valueColor))
-		drawMetricAnchoredText(dc, valueFace, valueText, rightX, centerY, 1)
+		drawMetricAnchoredText(dc, valueFace, valueText, startX, centerY, 0)
+		if unitWidth > 0 {
+			dc.SetColor(parseColor(unitColor))
+			drawMetricAnchoredText(dc, unitFace, unitText, startX+valueWidth+gap, centerY, 0)
+		}
+	}
+
+	switch layout.labelPosition {
+	case labelPositionAbove, labelPositionBelow:
+		blockHeight := textLineHeight + layout.labelGap + valueLineHeight
+		blockTop := textTop + (textHeight-blockHeight)/2
+		switch layout.alignV {
+		case AlignTop:
+			blockTop = textTop
+		case AlignBottom:
+			blockTop = bottom - blockHeight
+		}
+		textCenterY := blockTop + textLineHeight/2
+		valueCenterY := blockTop + textLineHeight + layout.labelGap + valueLineHeight/2
+		if layout.labelPosition == labelPositionBelow {
+			valueCenterY = blockTop + valueLineHeight/2
+			textCenterY = blockTop + valueLineHeight + layout.labelGap + textLineHeight/2
+		}
+		if layout.ellipsis {
+			textText = truncateTextWithEllipsis(measureText, textText, right-left)
+		}
+		dc.SetColor(parseColor(textColor))
+		drawMetricAnchoredText(dc, textFace, textText, resolveAlignedStartX(layout.alignH, left, right, measureText(textText)), textCenterY, 0)
+		drawValueRun(resolveAlignedStartX(layout.alignH, left, right, runWidth), valueCenterY)
+		return
+	case labelPositionInline:
+		centerY := resolveAlignedCenterY(layout.alignV, textTop, bottom, math.Max(textLineHeight, valueLineHeight))
+		if layout.ellipsis {
+			textText = truncateTextWithEllipsis(measureText, textText, right-left-layout.labelGap-runWidth)
+		}
+		textWidth := measureText(textText)
+		startX := resolveAlignedStartX(layout.alignH, left, right, textWidth+layout.labelGap+runWidth)
+		dc.SetColor(parseColor(textColor))
+		drawMetricAnchoredText(dc, textFace, textText, startX, centerY, 0)
+		drawValueRun(startX+textWidth+layout.labelGap, centerY)
 		return
 	}
 
-	dc.SetFontFace(valueFace)
-	valueWidth, _ := dc.MeasureString(valueText)
-	dc.SetFontFace(unitFace)
-	unitWidth, _ := dc.MeasureString(unitText)
-	gap := 2.0
-	startX := rightX - (valueWidth + gap + unitWidth)
-
-	dc.SetColor(parseColor(valueColor))
-	drawMetricAnchoredText(dc, valueFace, valueText, startX, centerY, 0)
-	dc.SetColor(parseColor(unitColor))
-	drawMetricAnchoredText(dc, unitFace, unitText, startX+valueWidth+gap, centerY, 0)
+	// Left placement pins the label to the left edge and the value to the
+	// right edge, so text_align does not apply here.
+	centerY := resolveAlignedCenterY(layout.alignV, textTop, bottom, math.Max(textLineHeight, valueLineHeight))
+	startX := right - runWidth
+	if layout.ellipsis {
+		textText = truncateTextWithEllipsis(measureText, textText, startX-layout.labelGap-left)
+	}
+	dc.SetColor(parseColor(textColor))
+	drawMetricAnchoredText(dc, textFace, textText, left, centerY, 0)
+	drawValueRun(startX, centerY)
+}
+
+func normalizeLabelPosition(value string) string {
+	switch value = strings.ToLower(strings.TrimSpace(value)); value {
+	case labelPositionAbove, labelPositionBelow, labelPositionInline:
+		return value
+	default:
+		return labelPositionLeft
+	}
+}
+
+func resolveFaceLineHeight(face font.Face) float64 {
+	metrics := baseMeasureText(face, "")
+	return metrics.ascent + metrics.descent
+}
+
+func resolveAlignedStartX(alignH BaseAlignH, left, right, width float64) float64 {
+	switch alignH {
+	case AlignLeft:
+		return left
+	case AlignRight:
+		return right - width
+	default:
+		return (left + right - width) / 2
+	}
+}
+
+func resolveAlignedCenterY(alignV BaseAlignV, top, bottom, lineHeight float64) float64 {
+	switch alignV {
+	case AlignTop:
+		return top + lineHeight/2
+	case AlignBottom:
+		return bottom - lineHeight/2
+	default:
+		return (top + bottom) / 2
+	}
 }
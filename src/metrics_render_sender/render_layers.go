@@ -0,0 +1,56 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// resolveItemRenderOrder returns item indexes from the lowest to the highest
+// layer. Items on the same layer keep their list order, so configs without z
+// values render exactly as before.
+func resolveItemRenderOrder(items []ItemConfig) []int {
+	order := make([]int, len(items))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return items[order[i]].Z < items[order[j]].Z
+	})
+	return order
+}
+
+// resolveItemGroupOffsets maps group names to their offset, leaving out
+// groups that do not move their items.
+func resolveItemGroupOffsets(config *MonitorConfig) map[string]ItemGroupConfig {
+	if config == nil || len(config.Groups) == 0 {
+		return nil
+	}
+	offsets := make(map[string]ItemGroupConfig, len(config.Groups))
+	for _, group := range config.Groups {
+		if group.Name == "" || (group.X == 0 && group.Y == 0) {
+			continue
+		}
+		offsets[group.Name] = group
+	}
+	return offsets
+}
+
+func normalizeItemGroups(groups []ItemGroupConfig) []ItemGroupConfig {
+	if len(groups) == 0 {
+		return nil
+	}
+	out := make([]ItemGroupConfig, 0, len(groups))
+	seen := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		group.Name = strings.TrimSpace(group.Name)
+		if group.Name == "" {
+			continue
+		}
+		if _, exists := seen[group.Name]; exists {
+			continue
+		}
+		seen[group.Name] = struct{}{}
+		out = append(out, group)
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/fogleman/gg"
+)
+
+func TestResolveItemRenderOrderIsStableByZ(t *testing.T) {
+	items := []ItemConfig{
+		{ID: "a", Z: 1},
+		{ID: "b"},
+		{ID: "c", Z: -1},
+		{ID: "d"},
+		{ID: "e", Z: 1},
+	}
+	got := resolveItemRenderOrder(items)
+	want := []int{2, 1, 3, 0, 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected render order: got=%v want=%v", got, want)
+	}
+}
+
+func TestNormalizeItemGroups(t *testing.T) {
+	groups := normalizeItemGroups([]ItemGroupConfig{
+		{Name: " cpu ", X: 10},
+		{Name: ""},
+		{Name: "cpu", X: 99},
+		{Name: "gpu", Y: -5},
+	})
+	want := []ItemGroupConfig{{Name: "cpu", X: 10}, {Name: "gpu", Y: -5}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Fatalf("unexpected groups: got=%v want=%v", groups, want)
+	}
+
+	offsets := resolveItemGroupOffsets(&MonitorConfig{Groups: append(groups, ItemGroupConfig{Name: "static"})})
+	if len(offsets) != 2 || offsets["cpu"].X != 10 || offsets["gpu"].Y != -5 {
+		t.Fatalf("unexpected group offsets: %v", offsets)
+	}
+}
+
+type countingStaticRenderer struct {
+	itemType string
+	static   bool
+	calls    int
+}
+
+func (r *countingStaticRenderer) GetType() string       { return r.itemType }
+func (r *countingStaticRenderer) RequiresMonitor() bool { return false }
+func (r *countingStaticRenderer) StaticContent() bool   { return r.static }
+
+func (r *countingStaticRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig) error {
+	r.calls++
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(float64(item.X), float64(item.Y), float64(item.Width), float64(item.Height))
+	dc.Fill()
+	return nil
+}
+
+func TestRenderCachesLeadingStaticItems(t *testing.T) {
+	static := &countingStaticRenderer{itemType: "test_static", static: true}
+	dynamic := &countingStaticRenderer{itemType: "test_dynamic"}
+	rm := &RenderManager{renderers: map[string]RenderItem{static.itemType: static, dynamic.itemType: dynamic}}
+	config := &MonitorConfig{
+		Width:  20,
+		Height: 20,
+		Items: []ItemConfig{
+			{Type: static.itemType, Width: 4, Height: 4},
+			{Type: dynamic.itemType, X: 5, Width: 4, Height: 4, Z: 1},
+			{Type: static.itemType, X: 10, Width: 4, Height: 4, Z: 2},
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		result, err := rm.Render(config)
+		if err != nil {
+			t.Fatalf("render: %v", err)
+		}
+		if _, _, _, a := result.Image.At(1, 1).RGBA(); a == 0 {
+			t.Fatalf("expected cached static item to be composited")
+		}
+	}
+	// The bottom static item is cached; the one above the dynamic item is not.
+	if static.calls != 1+3 {
+		t.Fatalf("unexpected static render calls: %d", static.calls)
+	}
+	if dynamic.calls != 3 {
+		t.Fatalf("unexpected dynamic render calls: %d", dynamic.calls)
+	}
+
+	reloaded := *config
+	reloaded.Items = append([]ItemConfig(nil), config.Items...)
+	if _, err := rm.Render(&reloaded); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if static.calls != 4+2 {
+		t.Fatalf("expected static layer rebuild after config change, calls=%d", static.calls)
+	}
+}
+
+func TestRenderSupersampledFrameKeepsLayoutSize(t *testing.T) {
+	rm := &RenderManager{renderers: map[string]RenderItem{}}
+	config := &MonitorConfig{Width: 6, Height: 4, Scale: 2}
+	result, err := rm.Render(config)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if bounds := result.Image.Bounds(); bounds.Dx() != 6 || bounds.Dy() != 4 {
+		t.Fatalf("unexpected frame size: %v", bounds)
+	}
+}
+
+func TestDownscaleRGBAAveragesBlocks(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 2; x++ {
+		src.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	dst := downscaleRGBA(src, 2)
+	if bounds := dst.Bounds(); bounds.Dx() != 2 || bounds.Dy() != 1 {
+		t.Fatalf("unexpected bounds: %v", bounds)
+	}
+	if got := dst.RGBAAt(0, 0); got != (color.RGBA{R: 128, A: 128}) {
+		t.Fatalf("unexpected averaged pixel: %v", got)
+	}
+	if got := dst.RGBAAt(1, 0); got != (color.RGBA{}) {
+		t.Fatalf("unexpected empty pixel: %v", got)
+	}
+}
+
+func TestContextScaleAndRenderScaleClamp(t *testing.T) {
+	dc := gg.NewContext(8, 8)
+	dc.Scale(3, 3)
+	if scale := contextScale(dc); scale != 3 {
+		t.Fatalf("unexpected context scale: %v", scale)
+	}
+	if scaled := (&MonitorConfig{Scale: 9}).GetRenderScale(); scaled != maxRenderScale {
+		t.Fatalf("expected scale clamp, got %d", scaled)
+	}
+}
@@ -1,7 +1,17 @@
 package main
 
 import (
+	"math"
+	"strings"
+
 	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+const (
+	progressValueCenter = "center"
+	progressValueInside = "inside"
+	progressValueNone   = "none"
 )
 
 type ProgressRenderer struct{}
@@ -34,30 +44,174 @@ func (p *ProgressRenderer) Render(dc *gg.Context, item *ItemConfig, frame *Rende
 	}
 
 	radius := resolveItemRadius(item, config, 0)
+	style := resolveSimpleProgressRuntime(item, config)
 
 	bgColor := resolveItemBackground(item, config)
 	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, bgColor, radius)
+	drawItemAlertEffect(dc, item, frame, monitor, config, radius)
+
+	paddingX, paddingY := resolveContentPaddingXY(item, config, 0, 0, 0, 0)
+	bar := fullRect{
+		x: float64(item.X) + paddingX,
+		y: float64(item.Y) + paddingY,
+		w: math.Max(1, float64(item.Width)-paddingX*2),
+		h: math.Max(1, float64(item.Height)-paddingY*2),
+	}
+	x, y, width, height := bar.x, bar.y, bar.w, bar.h
+	barRadius := radius
+	if style.roundCaps {
+		barRadius = math.Min(width, height) / 2
+	}
+	if style.trackColor != "" {
+		drawRoundedRectFill(dc, x, y, width, height, barRadius, style.trackColor)
+	}
 
 	percentage := (val - minValue) / (maxValue - minValue)
-	fillWidth := float64(item.Width) * percentage
-	if fillWidth > 0 {
-		itemColor := resolveMonitorColor(item, monitor, config)
-		dc.SetColor(parseColor(itemColor))
-		if radius > 0 {
-			dc.DrawRoundedRectangle(float64(item.X), float64(item.Y), fillWidth, float64(item.Height), radius)
+	itemColor := resolveMonitorColor(item, monitor, config)
+	fill := fullRect{x: x, y: y, w: width * percentage, h: height}
+	if style.vertical {
+		fill = fullRect{x: x, y: y + height*(1-percentage), w: width, h: height * percentage}
+	}
+	if style.style == "segmented" || fill.w > 0 && fill.h > 0 {
+		if style.vertical {
+			drawFullProgressFillVertical(dc, style.style, fill.x, fill.y, fill.w, fill.h, height, barRadius, itemColor, style.segments, style.segmentGap)
 		} else {
-			dc.DrawRectangle(float64(item.X), float64(item.Y), fillWidth, float64(item.Height))
+			drawFullProgressFillHorizontal(dc, style.style, fill.x, fill.y, fill.w, width, fill.h, barRadius, itemColor, style.segments, style.segmentGap)
 		}
-		dc.Fill()
 	}
+	drawSimpleProgressTicks(dc, item, config, bar, style)
 
-	valueText, unitText := resolveItemDisplayValueParts(item, monitor, value, config)
-	_, fontSize := resolveRoleFontFace(fontCache, item, config, TextRoleValue, 18, 8)
-	_, unitFontSize := resolveRoleFontFace(fontCache, item, config, TextRoleUnit, 14, 8)
-	textColor := resolveMonitorColor(item, monitor, config)
-	unitColor := resolveMonitorUnitColor(item, monitor.name, value, val, config)
-	drawCenteredValueWithUnit(dc, valueText, unitText, item.X, item.Y, item.Width, item.Height, fontSize, textColor, unitFontSize, unitColor, fontCache)
+	if style.valuePosition != progressValueNone {
+		valueText, unitText := resolveItemDisplayValueParts(item, monitor, value, config)
+		valueFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleValue, 18, 8)
+		unitFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleUnit, 14, 8)
+		textColor := resolveMonitorColor(item, monitor, config)
+		unitColor := resolveMonitorUnitColor(item, monitor.name, value, val, config)
+		labelRect := bar
+		if style.valuePosition == progressValueInside {
+			var onFill bool
+			labelRect, onFill = resolveSimpleProgressLabelRect(dc, bar, fill, style.vertical, valueText, unitText, valueFace, unitFace)
+			if onFill {
+				// Knock the text out of the fill so it stays readable on the bar color.
+				textColor = resolveItemBackground(item, config)
+				if textColor == "" && config != nil {
+					textColor = config.GetDefaultBackgroundColor()
+				}
+				unitColor = textColor
+			}
+		}
+		drawCenteredValueWithUnit(
+			dc,
+			valueText,
+			unitText,
+			labelRect,
+			valueFace,
+			textColor,
+			unitFace,
+			unitColor,
+		)
+	}
 
 	drawBaseItemBorder(dc, item, config, radius)
 	return nil
 }
+
+func normalizeProgressValuePosition(position string) string {
+	switch strings.ToLower(strings.TrimSpace(position)) {
+	case progressValueInside, "in", "bar":
+		return progressValueInside
+	case progressValueNone, "hidden", "off":
+		return progressValueNone
+	default:
+		return progressValueCenter
+	}
+}
+
+func prepareRenderSimpleProgressRuntime(config *MonitorConfig, item *ItemConfig) renderSimpleProgressRuntime {
+	return renderSimpleProgressRuntime{
+		vertical:      getItemAttrStringCfg(item, config, "progress_orientation", "horizontal") == "vertical",
+		style:         normalizeFullProgressStyle(getItemAttrStringCfg(item, config, "progress_style", "solid")),
+		roundCaps:     getItemAttrBoolCfg(item, config, "round_caps", false),
+		trackColor:    strings.TrimSpace(getItemAttrColorCfg(item, config, "track_color", "")),
+		segments:      clampRenderInt(getItemAttrIntCfg(item, config, "segments", 12), 4),
+		segmentGap:    getItemAttrFloatCfg(item, config, "segment_gap", 2),
+		tickCount:     getItemAttrIntCfg(item, config, "tick_count", 0),
+		tickColor:     strings.TrimSpace(getItemAttrColorCfg(item, config, "tick_color", "")),
+		valuePosition: normalizeProgressValuePosition(getItemAttrStringCfg(item, config, "value_position", progressValueCenter)),
+	}
+}
+
+func resolveSimpleProgressRuntime(item *ItemConfig, config *MonitorConfig) renderSimpleProgressRuntime {
+	if item.runtime.prepared {
+		return item.runtime.simpleProgress
+	}
+	return prepareRenderSimpleProgressRuntime(config, item)
+}
+
+// drawSimpleProgressTicks splits the bar into tick_count equal parts and marks
+// each inner boundary with short notches on both long edges.
+func drawSimpleProgressTicks(dc *gg.Context, item *ItemConfig, config *MonitorConfig, bar fullRect, style renderSimpleProgressRuntime) {
+	if style.tickCount < 2 {
+		return
+	}
+	tickColor := style.tickColor
+	if tickColor == "" {
+		tickColor = applyAlpha(resolveItemStaticColor(item, config), 0.5)
+	}
+	x, y, width, height := bar.x, bar.y, bar.w, bar.h
+
+	dc.SetColor(parseColor(tickColor))
+	setRenderLineWidth(dc, 1)
+	for i := 1; i < style.tickCount; i++ {
+		ratio := float64(i) / float64(style.tickCount)
+		if style.vertical {
+			tickLen := math.Max(2, width*0.25)
+			tickY := math.Round(y+height*(1-ratio)) + 0.5
+			dc.DrawLine(x, tickY, x+tickLen, tickY)
+			dc.DrawLine(x+width-tickLen, tickY, x+width, tickY)
+		} else {
+			tickLen := math.Max(2, height*0.25)
+			tickX := math.Round(x+width*ratio) + 0.5
+			dc.DrawLine(tickX, y, tickX, y+tickLen)
+			dc.DrawLine(tickX, y+height-tickLen, tickX, y+height)
+		}
+	}
+	dc.Stroke()
+}
+
+// resolveSimpleProgressLabelRect places the value at the leading edge of the
+// filled part, moving it just past the fill when the fill is too short to hold
+// the text. The bool reports whether the label ended up on the fill.
+func resolveSimpleProgressLabelRect(dc *gg.Context, bar fullRect, fill fullRect, vertical bool, valueText, unitText string, valueFace, unitFace font.Face) (fullRect, bool) {
+	x, y, width, height := bar.x, bar.y, bar.w, bar.h
+	const pad = 3.0
+
+	valueMetrics := baseMeasureText(valueFace, valueText)
+	dc.SetFontFace(valueFace)
+	textWidth, _ := dc.MeasureString(valueText)
+	textHeight := valueMetrics.ascent + valueMetrics.descent
+	if strings.TrimSpace(unitText) != "" {
+		unitMetrics := baseMeasureText(unitFace, unitText)
+		dc.SetFontFace(unitFace)
+		unitWidth, _ := dc.MeasureString(unitText)
+		textWidth += unitWidth + 2
+		textHeight = math.Max(textHeight, unitMetrics.ascent+unitMetrics.descent)
+	}
+
+	if vertical {
+		onFill := fill.h >= textHeight+pad*2
+		labelY := fill.y + pad
+		if !onFill {
+			labelY = fill.y - textHeight - pad
+		}
+		labelY = math.Max(y, math.Min(labelY, y+height-textHeight))
+		return fullRect{x: x, y: labelY, w: width, h: textHeight}, onFill
+	}
+	onFill := fill.w >= textWidth+pad*2
+	labelX := fill.x + fill.w - textWidth - pad
+	if !onFill {
+		labelX = fill.x + fill.w + pad
+	}
+	labelX = math.Max(x, math.Min(labelX, x+width-textWidth))
+	return fullRect{x: labelX, y: y, w: textWidth, h: height}, onFill
+}
@@ -16,6 +16,10 @@ func (r *RectRenderer) RequiresMonitor() bool {
 	return false
 }
 
+func (r *RectRenderer) StaticContent() bool {
+	return true
+}
+
 func (r *RectRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig) error {
 	_ = frame
 	_ = fontCache
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"image"
+	"math"
+	"sync"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+const maxRenderScale = 4
+
+// contextScale returns the uniform scale of the context transform. Renderers
+// draw in layout pixels; when the frame is supersampled the context carries a
+// scale so paths land on the larger canvas.
+func contextScale(dc *gg.Context) float64 {
+	x0, y0 := dc.TransformPoint(0, 0)
+	x1, y1 := dc.TransformPoint(1, 0)
+	scale := math.Hypot(x1-x0, y1-y0)
+	if scale <= 0 {
+		return 1
+	}
+	return scale
+}
+
+// gg strokes in device pixels, so widths and dash lengths are scaled here to
+// keep them proportional to the layout.
+func setRenderLineWidth(dc *gg.Context, width float64) {
+	dc.SetLineWidth(width * contextScale(dc))
+}
+
+func setRenderDash(dc *gg.Context, dashes ...float64) {
+	if len(dashes) == 0 {
+		dc.SetDash()
+		return
+	}
+	scale := contextScale(dc)
+	scaled := make([]float64, len(dashes))
+	for idx, dash := range dashes {
+		scaled[idx] = dash * scale
+	}
+	dc.SetDash(scaled...)
+}
+
+// drawStringAnchored draws text like dc.DrawStringAnchored. On a scaled
+// context gg would resample the glyph bitmaps through the transform, which
+// blurs them, so the text is drawn in device space with a face rasterized at
+// the scaled size instead.
+func drawStringAnchored(dc *gg.Context, face font.Face, text string, x, y, ax, ay float64) {
+	scale := contextScale(dc)
+	if math.Abs(scale-1) < 1e-6 {
+		dc.SetFontFace(face)
+		dc.DrawStringAnchored(text, x, y, ax, ay)
+		return
+	}
+	scaledFace := resolveScaledFontFace(face, scale)
+	if scaledFace == nil {
+		dc.SetFontFace(face)
+		dc.DrawStringAnchored(text, x, y, ax, ay)
+		return
+	}
+	deviceX, deviceY := dc.TransformPoint(x, y)
+	dc.Push()
+	dc.Identity()
+	dc.SetFontFace(scaledFace)
+	dc.DrawStringAnchored(text, deviceX, deviceY, ax, ay)
+	dc.Pop()
+	dc.SetFontFace(face)
+}
+
+type fontFaceSource struct {
+	path string
+	size float64
+}
+
+type scaledFontFaceKey struct {
+	face  font.Face
+	scale float64
+}
+
+var (
+	fontFaceSources sync.Map
+	scaledFontFaces sync.Map
+)
+
+func registerFontFaceSource(face font.Face, path string, size float64) {
+	if isNilFontFace(face) || path == "" {
+		return
+	}
+	fontFaceSources.Store(face, fontFaceSource{path: path, size: size})
+}
+
+// resolveScaledFontFace returns face loaded at scale times its size, or nil
+// when the face was not loaded from a font file (e.g. the built-in fallback).
+func resolveScaledFontFace(face font.Face, scale float64) font.Face {
+	if isNilFontFace(face) {
+		return nil
+	}
+	key := scaledFontFaceKey{face: face, scale: scale}
+	if cached, ok := scaledFontFaces.Load(key); ok {
+		return cached.(font.Face)
+	}
+	var scaled font.Face
+	if tabular, ok := face.(*tabularDigitsFace); ok {
+		inner := resolveScaledFontFace(tabular.Face, scale)
+		if inner == nil {
+			return nil
+		}
+		scaled = newTabularDigitsFace(inner)
+	} else {
+		raw, ok := fontFaceSources.Load(face)
+		if !ok {
+			return nil
+		}
+		source := raw.(fontFaceSource)
+		loaded, err := gg.LoadFontFace(source.path, source.size*scale)
+		if err != nil || isNilFontFace(loaded) {
+			return nil
+		}
+		scaled = loaded
+	}
+	actual, _ := scaledFontFaces.LoadOrStore(key, scaled)
+	return actual.(font.Face)
+}
+
+// downscaleRGBA box-filters a frame rendered at an integer scale back to the
+// layout size, averaging each scale x scale block into one pixel.
+func downscaleRGBA(src *image.RGBA, scale int) *image.RGBA {
+	if scale <= 1 {
+		return src
+	}
+	bounds := src.Bounds()
+	width := bounds.Dx() / scale
+	height := bounds.Dy() / scale
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	area := uint32(scale * scale)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var r, g, b, a uint32
+			for sy := 0; sy < scale; sy++ {
+				offset := src.PixOffset(bounds.Min.X+x*scale, bounds.Min.Y+y*scale+sy)
+				for sx := 0; sx < scale; sx++ {
+					pixel := src.Pix[offset : offset+4 : offset+4]
+					r += uint32(pixel[0])
+					g += uint32(pixel[1])
+					b += uint32(pixel[2])
+					a += uint32(pixel[3])
+					offset += 4
+				}
+			}
+			out := dst.PixOffset(x, y)
+			dst.Pix[out] = uint8((r + area/2) / area)
+			dst.Pix[out+1] = uint8((g + area/2) / area)
+			dst.Pix[out+2] = uint8((b + area/2) / area)
+			dst.Pix[out+3] = uint8((a + area/2) / area)
+		}
+	}
+	return dst
+}
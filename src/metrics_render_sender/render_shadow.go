@@ -0,0 +1,70 @@
+package main
+
+import (
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+type renderShadowRuntime struct {
+	color   string
+	offsetX float64
+	offsetY float64
+	blur    float64
+}
+
+func prepareRenderShadowRuntime(config *MonitorConfig, item *ItemConfig) renderShadowRuntime {
+	return renderShadowRuntime{
+		color:   strings.TrimSpace(getItemAttrColorCfg(item, config, "shadow_color", "")),
+		offsetX: getItemAttrFloatCfg(item, config, "shadow_offset_x", 2),
+		offsetY: getItemAttrFloatCfg(item, config, "shadow_offset_y", 2),
+		blur:    clampFloat64(getItemAttrFloatCfg(item, config, "shadow_blur", 4), 0, 32),
+	}
+}
+
+func resolveItemShadowRuntime(item *ItemConfig, config *MonitorConfig) renderShadowRuntime {
+	if item.runtime.prepared {
+		return item.runtime.shadow
+	}
+	return prepareRenderShadowRuntime(config, item)
+}
+
+// resolveItemShapeRadius returns the corner radius of the item's outer box:
+// the card radius for full items and the plain radius for everything else.
+func resolveItemShapeRadius(item *ItemConfig, config *MonitorConfig) float64 {
+	if isFullItemType(item.Type) {
+		return resolveItemCardRadius(item, config)
+	}
+	return resolveItemRadius(item, config, 0)
+}
+
+// drawItemShadow paints the drop shadow below an item before its renderer
+// runs. The blur is approximated by stacking translucent copies of the item
+// shape, each grown by one pixel, which keeps the cost linear in the radius.
+func drawItemShadow(dc *gg.Context, item *ItemConfig, config *MonitorConfig) {
+	if dc == nil || item == nil || item.Type == itemTypeSimpleLine {
+		return
+	}
+	shadow := resolveItemShadowRuntime(item, config)
+	if shadow.color == "" || item.Width <= 0 || item.Height <= 0 {
+		return
+	}
+	x := float64(item.X) + shadow.offsetX
+	y := float64(item.Y) + shadow.offsetY
+	width, height := float64(item.Width), float64(item.Height)
+	radius := resolveItemShapeRadius(item, config)
+
+	steps := int(math.Ceil(shadow.blur))
+	alpha := colorAlpha(shadow.color)
+	layerColor := applyAlpha(shadow.color, alpha/float64(steps+1))
+	for grow := float64(steps); grow >= 0; grow-- {
+		if item.Type == itemTypeSimpleCircle {
+			dc.SetColor(parseColor(layerColor))
+			dc.DrawEllipse(x+width/2, y+height/2, width/2+grow, height/2+grow)
+			dc.Fill()
+			continue
+		}
+		drawRoundedRectFill(dc, x-grow, y-grow, width+grow*2, height+grow*2, radius+grow, layerColor)
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"image/color"
 	"math"
 	"strings"
 	"sync"
@@ -20,6 +19,13 @@ type renderHistorySeries struct {
 	values []float64
 	next   int
 	size   int
+
+	// stride > 1 averages that many samples into one stored point; the
+	// bucket in progress is shown as the newest point until it completes.
+	stride       int
+	pendingSum   float64
+	pendingCount int
+	pendingSeen  int
 }
 
 func newRenderHistoryStore() *renderHistoryStore {
@@ -28,10 +34,13 @@ func newRenderHistoryStore() *renderHistoryStore {
 	}
 }
 
-func (s *renderHistoryStore) append(key string, value float64, maxLen int) []float64 {
+func (s *renderHistoryStore) append(key string, value float64, maxLen int, stride int) []float64 {
 	if maxLen < 10 {
 		maxLen = 10
 	}
+	if stride < 1 {
+		stride = 1
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	series := s.history[key]
@@ -39,8 +48,15 @@ func (s *renderHistoryStore) append(key string, value float64, maxLen int) []flo
 		series = resizeRenderHistorySeries(series, maxLen)
 		s.history[key] = series
 	}
-	series.append(value)
-	return series.snapshot()
+	if series.stride != stride {
+		series.stride = stride
+		series.resetPending()
+	}
+	if stride == 1 {
+		series.append(value)
+		return series.snapshot()
+	}
+	return series.appendStrided(value)
 }
 
 func newRenderHistorySeries(size int) *renderHistorySeries {
@@ -89,6 +105,39 @@ func (s *renderHistorySeries) append(value float64) {
 	}
 }
 
+func (s *renderHistorySeries) resetPending() {
+	s.pendingSum = 0
+	s.pendingCount = 0
+	s.pendingSeen = 0
+}
+
+func (s *renderHistorySeries) pendingValue() float64 {
+	if s.pendingCount == 0 {
+		return math.NaN()
+	}
+	return s.pendingSum / float64(s.pendingCount)
+}
+
+func (s *renderHistorySeries) appendStrided(value float64) []float64 {
+	s.pendingSeen++
+	if isFiniteHistoryValue(value) {
+		s.pendingSum += value
+		s.pendingCount++
+	}
+	if s.pendingSeen >= s.stride {
+		s.append(s.pendingValue())
+		s.resetPending()
+		return s.snapshot()
+	}
+	current := s.snapshot()
+	if len(current) == 0 {
+		return current
+	}
+	copy(current, current[1:])
+	current[len(current)-1] = s.pendingValue()
+	return current
+}
+
 func (s *renderHistorySeries) snapshot() []float64 {
 	if s == nil || len(s.values) == 0 {
 		return nil
@@ -176,6 +225,10 @@ func prepareRenderItemRuntime(config *MonitorConfig, item *ItemConfig) {
 	item.runtime.labelText = strings.TrimSpace(getItemAttrStringCfg(item, config, "label", ""))
 	item.runtime.text = strings.TrimSpace(item.Text)
 	item.runtime.specialFormat = prepareRenderSpecialFormatRuntime(item)
+	item.runtime.alert = prepareRenderAlertRuntime(config, item)
+	item.runtime.shadow = prepareRenderShadowRuntime(config, item)
+	item.runtime.showLevelLabel = getItemAttrBoolCfg(item, config, "show_level_label", false)
+	item.runtime.tabularNumbers = getItemAttrBoolCfg(item, config, "tabular_numbers", false)
 	prepareRenderTypeRuntime(config, item)
 	item.runtime.prepared = true
 	defaultPoints := defaultRenderHistoryPoints(item.Type)
@@ -184,6 +237,7 @@ func prepareRenderItemRuntime(config *MonitorConfig, item *ItemConfig) {
 	}
 	item.runtime.historyKey = buildRenderHistoryKey(item)
 	item.runtime.historyPoints = resolveItemHistoryPoints(item, config, defaultPoints)
+	item.runtime.historyStride = clampRenderInt(getItemAttrIntCfg(item, config, "history_stride", 1), 1)
 }
 
 func appendRenderHistory(store *renderHistoryStore, item *ItemConfig, value float64) []float64 {
@@ -193,7 +247,7 @@ func appendRenderHistory(store *renderHistoryStore, item *ItemConfig, value floa
 	if item.runtime.historyKey == "" || item.runtime.historyPoints <= 0 {
 		return []float64{value}
 	}
-	return store.append(item.runtime.historyKey, value, item.runtime.historyPoints)
+	return store.append(item.runtime.historyKey, value, item.runtime.historyPoints, item.runtime.historyStride)
 }
 
 func appendFrameRenderHistory(frame *RenderFrame, item *ItemConfig, value float64) []float64 {
@@ -241,12 +295,25 @@ func prepareRenderTypeRuntime(config *MonitorConfig, item *ItemConfig) {
 	case itemTypeSimpleChart:
 		item.runtime.simpleChart.lineWidth = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_width", 1.5), 1)
 		item.runtime.simpleChart.enableThresholdColors = getItemAttrBoolCfg(item, config, "enable_threshold_colors", false)
+		item.runtime.simpleChart.showGridLines = getItemAttrBoolCfg(item, config, "show_grid_lines", false)
+		item.runtime.simpleChart.gridLines = clampRenderInt(getItemAttrIntCfg(item, config, "grid_lines", 4), 2)
+		item.runtime.simpleChart.showAxisLabels = getItemAttrBoolCfg(item, config, "show_axis_labels", false)
+		item.runtime.simpleChart.gridLineColor = getItemAttrColorCfg(item, config, "grid_line_color", "#4755693c")
+		item.runtime.simpleChart.showThresholdLines = getItemAttrBoolCfg(item, config, "show_threshold_lines", false)
+		item.runtime.simpleChart.showTimeSpan = getItemAttrBoolCfg(item, config, "show_time_span", false)
+		item.runtime.simpleChart.fillColor = getItemAttrColorCfg(item, config, "chart_fill_color", "rgba(0,0,0,0)")
+		item.runtime.simpleChart.logScale = getItemAttrBoolCfg(item, config, "log_scale", false)
+		item.runtime.stackMonitors = chartStackMonitorsAttr(item)
+	case itemTypeSimpleProgress:
+		item.runtime.simpleProgress = prepareRenderSimpleProgressRuntime(config, item)
 	case itemTypeFullChart:
 		item.runtime.fullCard = prepareRenderFullCardRuntime(config, item, 4)
 		item.runtime.fullChart.lineColor = resolveFullChartLineColor(item, config)
 		item.runtime.fullChart.fillColor = getItemAttrColorCfg(item, config, "chart_fill_color", "rgba(0,0,0,0)")
 		item.runtime.fullChart.chartAreaBg = getItemAttrColorCfg(item, config, "chart_area_bg", "")
 		item.runtime.fullChart.chartAreaBorder = getItemAttrColorCfg(item, config, "chart_area_border_color", "")
+		item.runtime.fullChart.chartAreaRadius = clampMinFloat(getItemAttrFloatCfg(item, config, "chart_area_radius", 4), 0)
+		item.runtime.fullChart.gridLineColor = getItemAttrColorCfg(item, config, "grid_line_color", "#4755693c")
 		item.runtime.fullChart.showSegmentLines = getItemAttrBoolCfg(
 			item,
 			config,
@@ -257,6 +324,8 @@ func prepareRenderTypeRuntime(config *MonitorConfig, item *ItemConfig) {
 		item.runtime.fullChart.lineWidth = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_width", 2), 1)
 		item.runtime.fullChart.enableThresholdColors = getItemAttrBoolCfg(item, config, "enable_threshold_colors", false)
 		item.runtime.fullChart.showAvgLine = getItemAttrBoolCfg(item, config, "show_avg_line", true)
+		item.runtime.fullChart.logScale = getItemAttrBoolCfg(item, config, "log_scale", false)
+		item.runtime.stackMonitors = chartStackMonitorsAttr(item)
 	case itemTypeFullTable:
 		item.runtime.fullCard = prepareRenderFullCardRuntime(config, item, 4)
 		item.runtime.fullTable = prepareRenderFullTableRuntime(item, config)
@@ -279,9 +348,21 @@ func prepareRenderTypeRuntime(config *MonitorConfig, item *ItemConfig) {
 		item.runtime.fullGauge.gapDegrees = getItemAttrFloatCfg(item, config, "gauge_gap_degrees", 76)
 		item.runtime.fullGauge.trackColor = getItemAttrColorCfg(item, config, "track_color", "#1f2937")
 		item.runtime.fullGauge.textGap = getItemAttrFloatCfg(item, config, "gauge_text_gap", 1)
+	case itemTypeFullHeatmap:
+		item.runtime.fullCard = prepareRenderFullCardRuntime(config, item, 4)
+		item.runtime.fullHeatmap = prepareRenderFullHeatmapRuntime(item, config)
+	case itemTypeFullVUMeter:
+		item.runtime.fullCard = prepareRenderFullCardRuntime(config, item, 4)
+		item.runtime.fullVUMeter = prepareRenderFullVUMeterRuntime(item, config)
+	case itemTypeFullTimer:
+		item.runtime.fullTimer = prepareRenderFullTimerRuntime(item, config)
+	case itemTypeSimpleLabel, itemTypeSimpleValue, itemTypeLabelText:
+		item.runtime.textLayout = prepareRenderTextLayoutRuntime(config, item)
 	case itemTypeSimpleLine:
 		item.runtime.simpleLine.orientation = normalizeSimpleLineOrientation(getItemAttrStringCfg(item, config, "line_orientation", "horizontal"))
 		item.runtime.simpleLine.lineWidth = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_width", 1), 1)
+		item.runtime.simpleLine.dash = normalizeSimpleLineDash(getItemAttrStringCfg(item, config, "line_dash", lineDashSolid))
+		item.runtime.simpleLine.dashLength = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_dash_length", 6), 1)
 	}
 }
 
@@ -327,12 +408,22 @@ func normalizeFullProgressStyle(style string) string {
 
 func normalizeSimpleLineOrientation(orientation string) string {
 	orientation = strings.ToLower(strings.TrimSpace(orientation))
-	if orientation == "vertical" {
+	switch orientation {
+	case "vertical", lineOrientationDiagonalDown, lineOrientationDiagonalUp:
 		return orientation
 	}
 	return "horizontal"
 }
 
+func normalizeSimpleLineDash(dash string) string {
+	dash = strings.ToLower(strings.TrimSpace(dash))
+	switch dash {
+	case lineDashDashed, lineDashDotted:
+		return dash
+	}
+	return lineDashSolid
+}
+
 func clampRenderFloat(value float64, minValue float64) float64 {
 	if value < minValue {
 		return minValue
@@ -504,7 +595,7 @@ func drawFullHeader(
 	if divider {
 		dc.SetColor(parseColor(resolveFullCardHeaderDividerColor(item, config)))
 		dividerWidth := resolveFullCardHeaderDividerWidth(item, config)
-		dc.SetLineWidth(dividerWidth)
+		setRenderLineWidth(dc, dividerWidth)
 		dividerOffset := resolveFullCardHeaderDividerOffset(item, config)
 		y := rect.y + rect.h + dividerOffset
 		dc.DrawLine(rect.x, y, rect.x+rect.w, y)
@@ -674,7 +765,7 @@ func historyAverage(values []float64) float64 {
 }
 
 func applyAlpha(colorText string, alpha float64) string {
-	parsed := color.RGBAModel.Convert(parseColor(colorText)).(color.RGBA)
+	parsed := parseColorNRGBA(colorText)
 	if alpha < 0 {
 		alpha = 0
 	}
@@ -684,6 +775,10 @@ func applyAlpha(colorText string, alpha float64) string {
 	return fmt.Sprintf("#%02x%02x%02x%02x", parsed.R, parsed.G, parsed.B, uint8(alpha*255))
 }
 
+func colorAlpha(colorText string) float64 {
+	return float64(parseColorNRGBA(colorText).A) / 255
+}
+
 func drawRoundedRectFill(dc *gg.Context, x, y, width, height, radius float64, colorText string) {
 	if width <= 0 || height <= 0 {
 		return
@@ -2,6 +2,15 @@ package main
 
 import "github.com/fogleman/gg"
 
+const (
+	lineOrientationDiagonalDown = "diagonal_down"
+	lineOrientationDiagonalUp   = "diagonal_up"
+
+	lineDashSolid  = "solid"
+	lineDashDashed = "dashed"
+	lineDashDotted = "dotted"
+)
+
 type SimpleLineRenderer struct{}
 
 func NewSimpleLineRenderer() *SimpleLineRenderer {
@@ -16,6 +25,10 @@ func (r *SimpleLineRenderer) RequiresMonitor() bool {
 	return false
 }
 
+func (r *SimpleLineRenderer) StaticContent() bool {
+	return true
+}
+
 func (r *SimpleLineRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFrame, fontCache *FontCache, config *MonitorConfig) error {
 	_ = frame
 	_ = fontCache
@@ -23,25 +36,55 @@ func (r *SimpleLineRenderer) Render(dc *gg.Context, item *ItemConfig, frame *Ren
 		return nil
 	}
 
-	orientation := item.runtime.simpleLine.orientation
-	lineWidth := item.runtime.simpleLine.lineWidth
+	line := item.runtime.simpleLine
 	if !item.runtime.prepared {
-		orientation = normalizeSimpleLineOrientation(getItemAttrStringCfg(item, config, "line_orientation", "horizontal"))
-		lineWidth = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_width", 1), 1)
+		line.orientation = normalizeSimpleLineOrientation(getItemAttrStringCfg(item, config, "line_orientation", "horizontal"))
+		line.lineWidth = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_width", 1), 1)
+		line.dash = normalizeSimpleLineDash(getItemAttrStringCfg(item, config, "line_dash", lineDashSolid))
+		line.dashLength = clampRenderFloat(getItemAttrFloatCfg(item, config, "line_dash_length", 6), 1)
 	}
 
 	dc.SetColor(parseColor(resolveItemStaticColor(item, config)))
-	dc.SetLineWidth(lineWidth)
-
-	centerX := float64(item.X) + float64(item.Width)/2
-	centerY := float64(item.Y) + float64(item.Height)/2
-	if orientation == "vertical" {
-		dc.DrawLine(centerX, float64(item.Y), centerX, float64(item.Y+item.Height))
-		dc.Stroke()
-		return nil
+	setRenderLineWidth(dc, line.lineWidth)
+	if dashes := resolveSimpleLineDashes(line); len(dashes) > 0 {
+		setRenderDash(dc, dashes...)
+		defer setRenderDash(dc)
 	}
 
-	dc.DrawLine(float64(item.X), centerY, float64(item.X+item.Width), centerY)
+	x1, y1, x2, y2 := resolveSimpleLineEndpoints(item, line.orientation)
+	dc.DrawLine(x1, y1, x2, y2)
 	dc.Stroke()
 	return nil
 }
+
+func resolveSimpleLineEndpoints(item *ItemConfig, orientation string) (float64, float64, float64, float64) {
+	left := float64(item.X)
+	top := float64(item.Y)
+	right := float64(item.X + item.Width)
+	bottom := float64(item.Y + item.Height)
+	switch orientation {
+	case "vertical":
+		centerX := left + float64(item.Width)/2
+		return centerX, top, centerX, bottom
+	case lineOrientationDiagonalDown:
+		return left, top, right, bottom
+	case lineOrientationDiagonalUp:
+		return left, bottom, right, top
+	default:
+		centerY := top + float64(item.Height)/2
+		return left, centerY, right, centerY
+	}
+}
+
+// Dotted lines use a dash equal to the stroke width so the dots stay round-ish
+// regardless of thickness; dashed lines use the configured segment length.
+func resolveSimpleLineDashes(line renderSimpleLineRuntime) []float64 {
+	switch line.dash {
+	case lineDashDashed:
+		return []float64{line.dashLength, line.dashLength}
+	case lineDashDotted:
+		return []float64{line.lineWidth, line.lineWidth * 2}
+	default:
+		return nil
+	}
+}
@@ -11,6 +11,7 @@ const (
 	renderSpecialFormatDisplay    = "display"
 	renderSpecialFormatResolution = "resolution"
 	renderSpecialFormatRefresh    = "refresh"
+	renderSpecialFormatValue      = "value"
 )
 
 func resolveItemDisplayValueParts(item *ItemConfig, monitor *RenderMonitorSnapshot, value *CollectValue, config *MonitorConfig) (string, string) {
@@ -26,8 +27,16 @@ func resolveItemDisplayValueParts(item *ItemConfig, monitor *RenderMonitorSnapsh
 			return fallbackValue, fallbackUnit
 		}
 		return formatDisplayTemplate(format.displayTemplate, resolution, refresh), ""
+	case renderSpecialFormatValue:
+		valueText, unitText := formatItemValueWithPattern(format.valueFormat, item, value)
+		if unitText != "" && resolveItemShowLevelLabel(item, config) && monitor != nil {
+			unitText = appendThresholdLevelLabel(unitText, monitor.name, value, config)
+		}
+		return valueText, unitText
 	default:
-		_ = config
+		if resolveItemShowLevelLabel(item, config) && monitor != nil {
+			fallbackUnit = appendThresholdLevelLabel(fallbackUnit, monitor.name, value, config)
+		}
 		return fallbackValue, fallbackUnit
 	}
 }
@@ -44,6 +53,11 @@ func prepareRenderSpecialFormatRuntime(item *ItemConfig) renderSpecialFormatRunt
 		runtime.timeLayout = normalizeTimeLayout(rawFormat)
 	case renderSpecialFormatDisplay, renderSpecialFormatResolution, renderSpecialFormatRefresh:
 		runtime.displayTemplate = resolveDisplayTemplate(rawFormat, runtime.kind)
+	default:
+		runtime.valueFormat = rawFormat
+		if rawFormat != "" {
+			runtime.kind = renderSpecialFormatValue
+		}
 	}
 	return runtime
 }
@@ -51,7 +65,7 @@ func prepareRenderSpecialFormatRuntime(item *ItemConfig) renderSpecialFormatRunt
 func resolveRenderSpecialFormat(item *ItemConfig, monitor *RenderMonitorSnapshot) renderSpecialFormatRuntime {
 	if item != nil && item.runtime.prepared {
 		runtime := item.runtime.specialFormat
-		if runtime.kind != renderSpecialFormatNone {
+		if runtime.kind != renderSpecialFormatNone && runtime.kind != renderSpecialFormatValue {
 			return runtime
 		}
 		if monitor == nil {
@@ -70,12 +84,16 @@ func resolveRenderSpecialFormat(item *ItemConfig, monitor *RenderMonitorSnapshot
 			}
 		case renderSpecialFormatDisplay, renderSpecialFormatResolution, renderSpecialFormatRefresh:
 			runtime.displayTemplate = resolveDisplayTemplate("", runtime.kind)
+		default:
+			if runtime.valueFormat != "" {
+				runtime.kind = renderSpecialFormatValue
+			}
 		}
 		return runtime
 	}
 
 	runtime := prepareRenderSpecialFormatRuntime(item)
-	if runtime.kind != renderSpecialFormatNone || monitor == nil {
+	if (runtime.kind != renderSpecialFormatNone && runtime.kind != renderSpecialFormatValue) || monitor == nil {
 		return runtime
 	}
 	monitorKey := normalizeRenderMonitorKey(monitor.name)
@@ -89,6 +107,10 @@ func resolveRenderSpecialFormat(item *ItemConfig, monitor *RenderMonitorSnapshot
 		runtime.timeLayout = normalizeTimeLayout(strings.TrimSpace(getItemAttrString(item, "format", "")))
 	case renderSpecialFormatDisplay, renderSpecialFormatResolution, renderSpecialFormatRefresh:
 		runtime.displayTemplate = resolveDisplayTemplate(strings.TrimSpace(getItemAttrString(item, "format", "")), runtime.kind)
+	default:
+		if runtime.valueFormat != "" {
+			runtime.kind = renderSpecialFormatValue
+		}
 	}
 	return runtime
 }
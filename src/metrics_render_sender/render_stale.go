@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+)
+
+const (
+	defaultStalePlaceholder = "N/A"
+
+	staleStyleDim    = "dim"
+	staleStyleStrike = "strike"
+	staleStyleNone   = "none"
+)
+
+// staleSettings is a StaleConfig with defaults applied. Without a stale
+// section items of unavailable monitors are skipped as before.
+type staleSettings struct {
+	enabled     bool
+	placeholder string
+	style       string
+	maxAge      time.Duration
+}
+
+func resolveStaleSettings(cfg *StaleConfig) staleSettings {
+	if cfg == nil {
+		return staleSettings{}
+	}
+	settings := staleSettings{
+		enabled:     true,
+		placeholder: defaultStalePlaceholder,
+		style:       staleStyleDim,
+	}
+	if placeholder := strings.TrimSpace(cfg.Placeholder); placeholder != "" {
+		settings.placeholder = placeholder
+	}
+	switch style := strings.ToLower(strings.TrimSpace(cfg.Style)); style {
+	case "", staleStyleDim:
+	case staleStyleStrike, "strikethrough", "strike-through":
+		settings.style = staleStyleStrike
+	case staleStyleNone:
+		settings.style = staleStyleNone
+	default:
+		logWarnModule("config", "unknown stale style %q, using %s", cfg.Style, staleStyleDim)
+	}
+	if cfg.MaxAgeSec > 0 {
+		settings.maxAge = time.Duration(cfg.MaxAgeSec * float64(time.Second))
+	}
+	return settings
+}
+
+// apply returns the snapshot an item should draw and whether it is stale.
+// An unavailable monitor becomes a copy holding the placeholder as text, so
+// the shared snapshot other items read is left alone. Without max_age_sec
+// the registry's stale_after_cycles decides. Only numeric values age: text
+// such as the host name is set once and stays current.
+func (s staleSettings) apply(monitor *RenderMonitorSnapshot, now time.Time) (*RenderMonitorSnapshot, bool) {
+	if !s.enabled || monitor == nil {
+		return monitor, false
+	}
+	if !monitor.available {
+		placeholder := *monitor
+		placeholder.value = &CollectValue{Value: s.placeholder, ValueKind: ValueKindText}
+		return &placeholder, true
+	}
+	if s.maxAge <= 0 {
+		return monitor, monitor.stale
+	}
+	if monitor.updatedAt.IsZero() || monitor.value == nil {
+		return monitor, false
+	}
+	if _, ok := tryGetFloat64(monitor.value.Value); !ok {
+		return monitor, false
+	}
+	return monitor, now.Sub(monitor.updatedAt) > s.maxAge
+}
+
+// drawItemStaleStyle dims or strikes through an item whose monitor is
+// unavailable or out of date, after the renderer has drawn it.
+func drawItemStaleStyle(dc *gg.Context, item *ItemConfig, frame *RenderFrame, config *MonitorConfig) {
+	if dc == nil || item == nil || frame == nil || !frame.items[item].stale {
+		return
+	}
+	x, y := float64(item.X), float64(item.Y)
+	w, h := float64(item.Width), float64(item.Height)
+	switch frame.stale.style {
+	case staleStyleDim:
+		background := parseColor(config.GetDefaultBackgroundColor())
+		r, g, b, _ := background.RGBA()
+		dc.SetRGBA(float64(r)/0xffff, float64(g)/0xffff, float64(b)/0xffff, 0.6)
+		radius := resolveItemRadius(item, config, 0)
+		if radius > 0 {
+			dc.DrawRoundedRectangle(x, y, w, h, radius)
+		} else {
+			dc.DrawRectangle(x, y, w, h)
+		}
+		dc.Fill()
+	case staleStyleStrike:
+		inset := min(4, item.Width/8)
+		dc.SetColor(parseColor("#94a3b8"))
+		setRenderLineWidth(dc, 1.5)
+		dc.DrawLine(x+float64(inset), y+h/2, x+w-float64(inset), y+h/2)
+		dc.Stroke()
+	}
+}
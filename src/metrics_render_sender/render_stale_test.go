@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStaleSettingsApply(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	settings := resolveStaleSettings(&StaleConfig{MaxAgeSec: 30})
+	if settings.placeholder != defaultStalePlaceholder || settings.style != staleStyleDim {
+		t.Fatalf("expected the default placeholder and style, got %+v", settings)
+	}
+
+	gone := &RenderMonitorSnapshot{name: "go_native.gpu.temp", value: &CollectValue{Value: 61.0}}
+	shown, stale := settings.apply(gone, now)
+	if !stale || shown == gone || shown.value.Value != defaultStalePlaceholder {
+		t.Fatalf("expected a stale placeholder copy, got %+v stale=%v", shown, stale)
+	}
+	if gone.value.Value != 61.0 {
+		t.Fatal("expected the shared snapshot to keep its value")
+	}
+
+	old := &RenderMonitorSnapshot{available: true, value: &CollectValue{Value: 61.0}, updatedAt: now.Add(-time.Minute)}
+	if _, stale := settings.apply(old, now); !stale {
+		t.Fatal("expected a value older than max_age_sec to be stale")
+	}
+	fresh := &RenderMonitorSnapshot{available: true, value: &CollectValue{Value: 61.0}, updatedAt: now.Add(-10 * time.Second)}
+	if _, stale := settings.apply(fresh, now); stale {
+		t.Fatal("expected a recent value to be fresh")
+	}
+	text := &RenderMonitorSnapshot{available: true, value: &CollectValue{Value: "host"}, updatedAt: now.Add(-time.Hour)}
+	if _, stale := settings.apply(text, now); stale {
+		t.Fatal("expected text values never to age")
+	}
+
+	flagged := &RenderMonitorSnapshot{available: true, value: &CollectValue{Value: 61.0}, updatedAt: now.Add(-time.Minute), stale: true}
+	if _, stale := resolveStaleSettings(&StaleConfig{}).apply(flagged, now); !stale {
+		t.Fatal("expected the registry's staleness without max_age_sec")
+	}
+	if _, stale := resolveStaleSettings(&StaleConfig{}).apply(old, now); stale {
+		t.Fatal("expected only the registry to decide without max_age_sec")
+	}
+
+	if shown, stale := resolveStaleSettings(nil).apply(gone, now); stale || shown != gone {
+		t.Fatal("expected no change without a stale section")
+	}
+	if got := resolveStaleSettings(&StaleConfig{Style: "Strike-Through", Placeholder: "offline"}); got.style != staleStyleStrike || got.placeholder != "offline" {
+		t.Fatalf("unexpected settings %+v", got)
+	}
+}
+
+func TestRenderFrameShowsPlaceholderForUnavailableMonitor(t *testing.T) {
+	registry := NewCollectorManager()
+	temp := NewCollectItem("go_native.gpu.temp", "GPU temperature", "°C", 0, 100, 0)
+	temp.SetValue(61.0)
+	temp.SetAvailable(false)
+	registry.items[temp.GetName()] = temp
+	renderers := map[string]RenderItem{itemTypeSimpleValue: NewValueRenderer()}
+	config := &MonitorConfig{Items: []ItemConfig{{Type: itemTypeSimpleValue, Monitor: temp.GetName(), Width: 40, Height: 20}}}
+	item := &config.Items[0]
+
+	if _, _, ok := newRenderFrame(registry, nil, renderers, config).AvailableItemValue(item); ok {
+		t.Fatal("expected an unavailable monitor to be skipped without a stale section")
+	}
+
+	config.Stale = &StaleConfig{Placeholder: "offline"}
+	frame := newRenderFrame(registry, nil, renderers, config)
+	_, value, ok := frame.AvailableItemValue(item)
+	if !ok || value.Value != "offline" {
+		t.Fatalf("expected the placeholder, got %+v ok=%v", value, ok)
+	}
+	if !frame.items[item].stale {
+		t.Fatal("expected the item to get the stale style")
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"image"
+	"sync"
+)
+
+// staticContentRenderer is implemented by renderers whose output depends only
+// on the item config, so their pixels can be cached across frames.
+type staticContentRenderer interface {
+	StaticContent() bool
+}
+
+func rendererIsStatic(renderer RenderItem) bool {
+	static, ok := renderer.(staticContentRenderer)
+	return ok && static.StaticContent()
+}
+
+// renderStaticLayer holds the background plus the leading run of static items
+// in render order. Only that run can be cached: a static item above a dynamic
+// one still has to be drawn every frame to keep the z order.
+type renderStaticLayer struct {
+	mu sync.Mutex
+
+	config    *MonitorConfig
+	items     *ItemConfig
+	itemCount int
+	width     int
+	height    int
+	scale     int
+
+	image       *image.RGBA
+	staticCount int
+}
+
+func countLeadingStaticItems(renderers map[string]RenderItem, items []ItemConfig, order []int) int {
+	count := 0
+	for _, idx := range order {
+		if !rendererIsStatic(renderers[items[idx].Type]) {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// matches reports whether the cached layer was built for this config. Configs
+// are replaced rather than edited in place on reload, so identity is enough.
+func (l *renderStaticLayer) matches(config *MonitorConfig) bool {
+	if l.image == nil || l.config != config || l.itemCount != len(config.Items) {
+		return false
+	}
+	if l.width != config.Width || l.height != config.Height || l.scale != config.GetRenderScale() {
+		return false
+	}
+	return len(config.Items) == 0 || l.items == &config.Items[0]
+}
+
+func (l *renderStaticLayer) store(config *MonitorConfig, img *image.RGBA, staticCount int) {
+	l.config = config
+	l.items = nil
+	if len(config.Items) > 0 {
+		l.items = &config.Items[0]
+	}
+	l.itemCount = len(config.Items)
+	l.width = config.Width
+	l.height = config.Height
+	l.scale = config.GetRenderScale()
+	l.image = img
+	l.staticCount = staticCount
+}
+
+func cloneRGBA(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(src.Rect)
+	copy(dst.Pix, src.Pix)
+	return dst
+}
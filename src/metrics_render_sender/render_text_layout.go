@@ -0,0 +1,188 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+const textEllipsis = "…"
+
+type renderTextLayoutRuntime struct {
+	alignH      BaseAlignH
+	alignV      BaseAlignV
+	wrap        bool
+	ellipsis    bool
+	lineSpacing float64
+
+	labelPosition string
+	labelGap      float64
+}
+
+func prepareRenderTextLayoutRuntime(config *MonitorConfig, item *ItemConfig) renderTextLayoutRuntime {
+	fallbackH := string(AlignCenter)
+	if item != nil && item.Type == itemTypeSimpleLabel {
+		fallbackH = string(AlignLeft)
+	}
+	return renderTextLayoutRuntime{
+		alignH:      normalizeTextAlignH(getItemAttrStringCfg(item, config, "text_align", fallbackH)),
+		alignV:      normalizeTextAlignV(getItemAttrStringCfg(item, config, "text_valign", string(AlignMiddle))),
+		wrap:        getItemAttrBoolCfg(item, config, "text_wrap", false),
+		ellipsis:    getItemAttrBoolCfg(item, config, "text_ellipsis", false),
+		lineSpacing: clampFloat64(getItemAttrFloatCfg(item, config, "text_line_spacing", 1.2), 0.8, 3),
+
+		labelPosition: normalizeLabelPosition(getItemAttrStringCfg(item, config, "label_position", labelPositionLeft)),
+		labelGap:      clampMinFloat(getItemAttrFloatCfg(item, config, "label_gap", 4), 0),
+	}
+}
+
+func resolveItemTextLayoutRuntime(item *ItemConfig, config *MonitorConfig) renderTextLayoutRuntime {
+	if item != nil && item.runtime.prepared {
+		return item.runtime.textLayout
+	}
+	return prepareRenderTextLayoutRuntime(config, item)
+}
+
+func normalizeTextAlignH(value string) BaseAlignH {
+	switch BaseAlignH(strings.ToLower(strings.TrimSpace(value))) {
+	case AlignLeft:
+		return AlignLeft
+	case AlignRight:
+		return AlignRight
+	case AlignJustify:
+		return AlignJustify
+	default:
+		return AlignCenter
+	}
+}
+
+func normalizeTextAlignV(value string) BaseAlignV {
+	switch BaseAlignV(strings.ToLower(strings.TrimSpace(value))) {
+	case AlignTop:
+		return AlignTop
+	case AlignBottom:
+		return AlignBottom
+	default:
+		return AlignMiddle
+	}
+}
+
+type textMeasureFunc func(text string) float64
+
+func newTextMeasureFunc(dc *gg.Context) textMeasureFunc {
+	return func(text string) float64 {
+		width, _ := dc.MeasureString(text)
+		return width
+	}
+}
+
+// layoutTextLines splits text on explicit newlines, optionally word-wraps each
+// paragraph to maxWidth and keeps at most maxLines lines. paragraphEnds marks
+// the last line of every paragraph so justified text leaves it ragged.
+func layoutTextLines(measure textMeasureFunc, text string, maxWidth float64, maxLines int, wrap bool, ellipsis bool) (lines []string, paragraphEnds []bool) {
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		wrapped := []string{paragraph}
+		if wrap {
+			wrapped = wrapTextParagraph(measure, paragraph, maxWidth)
+		}
+		for idx, line := range wrapped {
+			lines = append(lines, line)
+			paragraphEnds = append(paragraphEnds, idx == len(wrapped)-1)
+		}
+	}
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[:maxLines]
+		paragraphEnds = paragraphEnds[:maxLines]
+		if ellipsis {
+			last := strings.TrimRight(lines[maxLines-1], " ")
+			lines[maxLines-1] = truncateTextWithEllipsis(measure, last+textEllipsis, maxWidth)
+		}
+		paragraphEnds[maxLines-1] = true
+	}
+	if ellipsis {
+		for idx := range lines {
+			lines[idx] = truncateTextWithEllipsis(measure, lines[idx], maxWidth)
+		}
+	}
+	return lines, paragraphEnds
+}
+
+// wrapTextParagraph breaks on spaces first and falls back to per-rune breaks
+// for words wider than the line, which also covers CJK text without spaces.
+func wrapTextParagraph(measure textMeasureFunc, paragraph string, maxWidth float64) []string {
+	words := strings.Fields(paragraph)
+	if len(words) == 0 || maxWidth <= 0 {
+		return []string{strings.TrimSpace(paragraph)}
+	}
+	lines := make([]string, 0, 2)
+	current := ""
+	for _, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if measure(candidate) <= maxWidth {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+			current = ""
+		}
+		for _, r := range word {
+			next := current + string(r)
+			if current != "" && measure(next) > maxWidth {
+				lines = append(lines, current)
+				next = string(r)
+			}
+			current = next
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func truncateTextWithEllipsis(measure textMeasureFunc, text string, maxWidth float64) string {
+	if measure(text) <= maxWidth {
+		return text
+	}
+	runes := []rune(strings.TrimSuffix(text, textEllipsis))
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimRight(string(runes), " ") + textEllipsis
+		if measure(candidate) <= maxWidth {
+			return candidate
+		}
+	}
+	if measure(textEllipsis) <= maxWidth {
+		return textEllipsis
+	}
+	return ""
+}
+
+// resolveJustifiedWordPositions returns the x offset of every word so the line
+// spans exactly maxWidth. It returns nil when the line cannot be justified.
+func resolveJustifiedWordPositions(measure textMeasureFunc, words []string, maxWidth float64) []float64 {
+	if len(words) < 2 {
+		return nil
+	}
+	total := 0.0
+	widths := make([]float64, len(words))
+	for idx, word := range words {
+		widths[idx] = measure(word)
+		total += widths[idx]
+	}
+	gap := (maxWidth - total) / float64(len(words)-1)
+	if gap <= 0 {
+		return nil
+	}
+	positions := make([]float64, len(words))
+	x := 0.0
+	for idx := range words {
+		positions[idx] = x
+		x += widths[idx] + gap
+	}
+	return positions
+}
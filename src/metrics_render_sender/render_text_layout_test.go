@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func runeWidthMeasure(text string) float64 {
+	return float64(utf8.RuneCountInString(text))
+}
+
+func TestLayoutTextLinesWrapsWordsAndLongRuns(t *testing.T) {
+	lines, ends := layoutTextLines(runeWidthMeasure, "alpha beta gamma\n温度传感器读数", 10, 0, true, false)
+	expectedLines := []string{"alpha beta", "gamma", "温度传感器读数"}
+	if !reflect.DeepEqual(lines, expectedLines) {
+		t.Fatalf("unexpected lines: %q", lines)
+	}
+	if !reflect.DeepEqual(ends, []bool{false, true, true}) {
+		t.Fatalf("unexpected paragraph ends: %v", ends)
+	}
+
+	lines, _ = layoutTextLines(runeWidthMeasure, "abcdefghijklmnop", 6, 0, true, false)
+	if !reflect.DeepEqual(lines, []string{"abcdef", "ghijkl", "mnop"}) {
+		t.Fatalf("expected long word to break per rune, got %q", lines)
+	}
+}
+
+func TestLayoutTextLinesLimitsLinesWithEllipsis(t *testing.T) {
+	lines, ends := layoutTextLines(runeWidthMeasure, "one two three four five", 9, 2, true, true)
+	if !reflect.DeepEqual(lines, []string{"one two", "three…"}) {
+		t.Fatalf("unexpected truncated lines: %q", lines)
+	}
+	if !ends[len(ends)-1] {
+		t.Fatalf("expected last visible line to end the paragraph")
+	}
+}
+
+func TestTruncateTextWithEllipsis(t *testing.T) {
+	if got := truncateTextWithEllipsis(runeWidthMeasure, "short", 10); got != "short" {
+		t.Fatalf("expected untouched text, got %q", got)
+	}
+	if got := truncateTextWithEllipsis(runeWidthMeasure, "Processor Package", 8); got != "Process…" {
+		t.Fatalf("unexpected truncation %q", got)
+	}
+	if got := truncateTextWithEllipsis(runeWidthMeasure, "abc", 0.5); got != "" {
+		t.Fatalf("expected empty text when not even the ellipsis fits, got %q", got)
+	}
+}
+
+func TestResolveJustifiedWordPositions(t *testing.T) {
+	positions := resolveJustifiedWordPositions(runeWidthMeasure, []string{"ab", "cd", "ef"}, 12)
+	if !reflect.DeepEqual(positions, []float64{0, 5, 10}) {
+		t.Fatalf("unexpected justified positions: %v", positions)
+	}
+	if positions := resolveJustifiedWordPositions(runeWidthMeasure, []string{"single"}, 12); positions != nil {
+		t.Fatalf("expected single word to fall back, got %v", positions)
+	}
+}
+
+func TestLabelPositionNormalizationAndAlignment(t *testing.T) {
+	if got := normalizeStyleValueByKey("label_position", " Above "); got != labelPositionAbove {
+		t.Fatalf("expected above, got %v", got)
+	}
+	if got := normalizeLabelPosition("diagonal"); got != labelPositionLeft {
+		t.Fatalf("expected left fallback, got %v", got)
+	}
+	if value, _ := styleCodeDefault(itemTypeLabelText, "label_gap"); value != 4.0 {
+		t.Fatalf("expected default label gap 4, got %v", value)
+	}
+	cases := map[BaseAlignH]float64{AlignLeft: 10, AlignCenter: 40, AlignRight: 70, AlignJustify: 40}
+	for align, want := range cases {
+		if got := resolveAlignedStartX(align, 10, 100, 30); got != want {
+			t.Fatalf("align %s: got %v want %v", align, got, want)
+		}
+	}
+}
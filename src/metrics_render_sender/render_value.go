@@ -20,15 +20,16 @@ func (v *ValueRenderer) Render(dc *gg.Context, item *ItemConfig, frame *RenderFr
 
 	radius := resolveItemRadius(item, config, 0)
 	drawRoundedBackground(dc, item.X, item.Y, item.Width, item.Height, resolveItemBackground(item, config), radius)
+	drawItemAlertEffect(dc, item, frame, monitor, config, radius)
 
 	valueText, unitText := resolveItemDisplayValueParts(item, monitor, value, config)
-	_, fontSize := resolveRoleFontFace(fontCache, item, config, TextRoleValue, 18, 8)
-	_, unitFontSize := resolveRoleFontFace(fontCache, item, config, TextRoleUnit, 14, 8)
+	valueFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleValue, 18, 8)
+	unitFace, _ := resolveRoleFontFace(fontCache, item, config, TextRoleUnit, 14, 8)
 
 	itemColor := resolveMonitorColor(item, monitor, config)
 	numberValue, _ := tryGetFloat64(value.Value)
 	unitColor := resolveMonitorUnitColor(item, monitor.name, value, numberValue, config)
-	drawCenteredValueWithUnit(dc, valueText, unitText, item.X, item.Y, item.Width, item.Height, fontSize, itemColor, unitFontSize, unitColor, fontCache)
+	drawAlignedValueWithUnit(dc, valueText, unitText, fullRect{x: float64(item.X), y: float64(item.Y), w: float64(item.Width), h: float64(item.Height)}, valueFace, itemColor, unitFace, unitColor, resolveItemTextLayoutRuntime(item, config))
 	drawBaseItemBorder(dc, item, config, radius)
 
 	return nil
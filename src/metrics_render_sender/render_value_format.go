@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+var (
+	valueFormatPlaceholderPattern = regexp.MustCompile(`\{(value|unit|min|max|percent|raw)(?::([^}]*))?\}`)
+	valueFormatVerbPattern        = regexp.MustCompile(`%[-+# 0]*\d*(?:\.\d+)?([a-zA-Z%])`)
+)
+
+// formatItemValueWithPattern applies a per-item "format" to a monitor value.
+// A pattern without placeholders is a printf spec for the number and keeps
+// the unit separate; a template such as "{value:%5.1f}/{max}" renders the
+// whole text itself, so the unit is only shown where {unit} appears.
+func formatItemValueWithPattern(pattern string, item *ItemConfig, value *CollectValue) (string, string) {
+	unitOverride := resolveUnitOverride(item)
+	number, unit, precision, ok := scaleCollectNumber(value, unitOverride)
+	if !ok {
+		text, unitText := FormatCollectValueParts(value, unitOverride)
+		if !strings.Contains(pattern, "{") {
+			return text, unitText
+		}
+		return valueFormatPlaceholderPattern.ReplaceAllStringFunc(pattern, func(token string) string {
+			switch valueFormatPlaceholderPattern.FindStringSubmatch(token)[1] {
+			case "value", "raw":
+				return text
+			case "unit":
+				return unitText
+			}
+			return token
+		}), ""
+	}
+
+	defaultSpec := "%." + itoa(max(0, precision)) + "f"
+	if !strings.Contains(pattern, "{") {
+		return formatNumberWithSpec(pattern, number, defaultSpec), unit
+	}
+
+	raw := getFloat64Value(value.Value)
+	scale := 1.0
+	if raw != 0 {
+		scale = number / raw
+	}
+	minValue, maxValue := resolveEffectiveMinMax(item, value, nil, raw)
+	percent := 0.0
+	if maxValue > minValue {
+		percent = clampFloat64((raw-minValue)/(maxValue-minValue)*100, 0, 100)
+	}
+
+	out := valueFormatPlaceholderPattern.ReplaceAllStringFunc(pattern, func(token string) string {
+		match := valueFormatPlaceholderPattern.FindStringSubmatch(token)
+		spec := match[2]
+		switch match[1] {
+		case "value":
+			return formatNumberWithSpec(spec, number, defaultSpec)
+		case "raw":
+			return formatNumberWithSpec(spec, raw, "%."+itoa(max(0, value.Precision))+"f")
+		case "min":
+			return formatNumberWithSpec(spec, minValue*scale, defaultSpec)
+		case "max":
+			return formatNumberWithSpec(spec, maxValue*scale, defaultSpec)
+		case "percent":
+			return formatNumberWithSpec(spec, percent, "%.0f")
+		case "unit":
+			return unit
+		}
+		return token
+	})
+	return out, ""
+}
+
+// formatNumberWithSpec formats number with a single-verb printf spec. Integer
+// verbs receive the rounded value; specs with zero or several verbs, or with
+// verbs that make no sense for numbers, fall back to defaultSpec.
+func formatNumberWithSpec(spec string, number float64, defaultSpec string) string {
+	if strings.TrimSpace(spec) == "" {
+		return fmt.Sprintf(defaultSpec, number)
+	}
+	if !strings.Contains(spec, "%") {
+		spec = "%" + spec
+	}
+	verb := byte(0)
+	for _, match := range valueFormatVerbPattern.FindAllStringSubmatch(spec, -1) {
+		if match[1] == "%" {
+			continue
+		}
+		if verb != 0 {
+			return fmt.Sprintf(defaultSpec, number)
+		}
+		verb = match[1][0]
+	}
+	switch verb {
+	case 'd', 'x', 'X', 'o', 'b':
+		return fmt.Sprintf(spec, int64(math.Round(number)))
+	case 'e', 'E', 'f', 'F', 'g', 'G', 'v':
+		return fmt.Sprintf(spec, number)
+	default:
+		return fmt.Sprintf(defaultSpec, number)
+	}
+}
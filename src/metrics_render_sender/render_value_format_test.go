@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestFormatItemValueWithPrintfSpecKeepsUnit(t *testing.T) {
+	item := &ItemConfig{}
+	value := &CollectValue{Value: 7.34, Unit: "%", Precision: 0}
+	text, unit := formatItemValueWithPattern("%5.1f", item, value)
+	if text != "  7.3" {
+		t.Fatalf("unexpected fixed-width text %q", text)
+	}
+	if unit != "%" {
+		t.Fatalf("expected unit to be kept, got %q", unit)
+	}
+	if text, _ := formatItemValueWithPattern("%03d", item, value); text != "007" {
+		t.Fatalf("expected integer verb to round, got %q", text)
+	}
+	if text, _ := formatItemValueWithPattern("%s %s", item, value); text != "7" {
+		t.Fatalf("expected invalid spec to fall back to precision, got %q", text)
+	}
+}
+
+func TestFormatItemValueWithTemplate(t *testing.T) {
+	maxValue := 200.0
+	item := &ItemConfig{MaxValue: &maxValue}
+	value := &CollectValue{Value: 50.0, Unit: "W", Precision: 0}
+	text, unit := formatItemValueWithPattern("{value:%4.0f}/{max}{unit} ({percent}%)", item, value)
+	if text != "  50/200W (25%)" {
+		t.Fatalf("unexpected template text %q", text)
+	}
+	if unit != "" {
+		t.Fatalf("expected template to own the unit, got %q", unit)
+	}
+
+	scaled := &CollectValue{Value: 2048.0, Unit: "KB/s", Precision: 1}
+	if text, _ := formatItemValueWithPattern("{value:.2f} {unit} = {raw:.0f}", &ItemConfig{}, scaled); text != "2.00 MB/s = 2048" {
+		t.Fatalf("unexpected scaled template text %q", text)
+	}
+}
+
+func TestResolveItemDisplayValuePartsUsesItemFormat(t *testing.T) {
+	item := &ItemConfig{Monitor: "cpu.usage", RenderAttrsMap: map[string]interface{}{"format": "%.2f"}}
+	monitor := &RenderMonitorSnapshot{name: "cpu.usage"}
+	value := &CollectValue{Value: 12.0, Unit: "%", Precision: 0}
+	prepareRenderItemRuntime(&MonitorConfig{}, item)
+	text, unit := resolveItemDisplayValueParts(item, monitor, value, &MonitorConfig{})
+	if text != "12.00" || unit != "%" {
+		t.Fatalf("unexpected display parts %q %q", text, unit)
+	}
+}
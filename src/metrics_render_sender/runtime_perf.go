@@ -5,6 +5,17 @@ import (
 	"time"
 )
 
+// frameRuntimeStats counts frames from render to output. Skipped frames were
+// rendered but never shipped: replaced in the output queue by a newer frame,
+// or held back by the max_fps limiter.
+type frameRuntimeStats struct {
+	Rendered     int64
+	Shipped      int64
+	SkippedQueue int64
+	SkippedLimit int64
+	SkippedTotal int64
+}
+
 type renderRuntimeStats struct {
 	Calls  int64
 	LastMS int64
@@ -17,6 +28,11 @@ var (
 	renderRuntimeLastNS  int64
 	renderRuntimeMaxNS   int64
 	renderRuntimeTotalNS int64
+
+	frameRuntimeRendered     int64
+	frameRuntimeShipped      int64
+	frameRuntimeSkippedQueue int64
+	frameRuntimeSkippedLimit int64
 )
 
 func recordRenderDuration(duration time.Duration) {
@@ -54,3 +70,76 @@ func renderRuntimeSnapshot() renderRuntimeStats {
 		AvgMS:  avgNS / int64(time.Millisecond),
 	}
 }
+
+func recordFrameRendered() {
+	atomic.AddInt64(&frameRuntimeRendered, 1)
+}
+
+func recordFrameShipped() {
+	atomic.AddInt64(&frameRuntimeShipped, 1)
+}
+
+func recordFrameSkippedQueue(count int) {
+	if count > 0 {
+		atomic.AddInt64(&frameRuntimeSkippedQueue, int64(count))
+	}
+}
+
+func recordFrameSkippedLimit(count int) {
+	if count > 0 {
+		atomic.AddInt64(&frameRuntimeSkippedLimit, int64(count))
+	}
+}
+
+func frameRuntimeSnapshot() frameRuntimeStats {
+	skippedQueue := atomic.LoadInt64(&frameRuntimeSkippedQueue)
+	skippedLimit := atomic.LoadInt64(&frameRuntimeSkippedLimit)
+	return frameRuntimeStats{
+		Rendered:     atomic.LoadInt64(&frameRuntimeRendered),
+		Shipped:      atomic.LoadInt64(&frameRuntimeShipped),
+		SkippedQueue: skippedQueue,
+		SkippedLimit: skippedLimit,
+		SkippedTotal: skippedQueue + skippedLimit,
+	}
+}
+
+// frameStatsLogger writes one line per interval with the frame counts of that
+// window, so skipped frames show up without debug logging.
+type frameStatsLogger struct {
+	interval time.Duration
+	lastAt   time.Time
+	last     frameRuntimeStats
+}
+
+func (l *frameStatsLogger) maybeLog(now time.Time) {
+	if l.lastAt.IsZero() {
+		l.lastAt = now
+		l.last = frameRuntimeSnapshot()
+		return
+	}
+	if now.Sub(l.lastAt) < l.interval {
+		return
+	}
+	current := frameRuntimeSnapshot()
+	rendered := current.Rendered - l.last.Rendered
+	shipped := current.Shipped - l.last.Shipped
+	skippedQueue := current.SkippedQueue - l.last.SkippedQueue
+	skippedLimit := current.SkippedLimit - l.last.SkippedLimit
+	window := now.Sub(l.lastAt).Round(time.Second)
+	l.lastAt = now
+	l.last = current
+	if skippedQueue+skippedLimit == 0 {
+		logDebugModule("output", "frames in %v: rendered=%d shipped=%d", window, rendered, shipped)
+		return
+	}
+	logInfoModule(
+		"output",
+		"frames in %v: rendered=%d shipped=%d skipped=%d (queue=%d max_fps=%d)",
+		window,
+		rendered,
+		shipped,
+		skippedQueue+skippedLimit,
+		skippedQueue,
+		skippedLimit,
+	)
+}
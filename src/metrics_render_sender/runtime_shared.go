@@ -67,3 +67,9 @@ func ApplyConfigToSharedWebAPI(cfg *MonitorConfig) error {
 	}
 	return runtime.ApplyConfig(cfg)
 }
+
+func CurrentSharedWebAPI() *WebAPI {
+	sharedWebAPIMu.Lock()
+	defer sharedWebAPIMu.Unlock()
+	return sharedWebAPI
+}
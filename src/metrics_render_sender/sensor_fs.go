@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// sysfsAttrMaxBytes is the kernel's page-size cap on a sysfs attribute.
+	sysfsAttrMaxBytes = 4096
+	// procFileMaxBytes covers the largest /proc table read, mountinfo on a
+	// busy container host; anything bigger is treated as a broken interface.
+	procFileMaxBytes = 1 << 20
+	// defaultSensorReadTimeout bounds a single read. Some sysfs attributes
+	// block while the device behind them wakes up.
+	defaultSensorReadTimeout = 2 * time.Second
+)
+
+var (
+	errSensorFileTooLarge = errors.New("sensor file exceeds read limit")
+	errSensorFileBinary   = errors.New("sensor attribute is not text")
+	errSensorReadTimeout  = errors.New("sensor read timed out")
+	errSensorReadPending  = errors.New("previous sensor read still pending")
+)
+
+// pendingSensorReads holds paths whose read timed out and has not returned
+// yet, so a stuck attribute costs one goroutine rather than one per update.
+var pendingSensorReads = struct {
+	mu    sync.Mutex
+	paths map[string]struct{}
+}{paths: make(map[string]struct{})}
+
+// sensorEnv is the machine as sensor readers see it: the filesystem that
+// holds /sys and /proc, and the clock. Readers take absolute paths; tests
+// replace sensors with an fstest.MapFS whose keys drop the leading slash.
+type sensorEnv struct {
+	FS          fs.FS
+	Now         func() time.Time
+	ReadTimeout time.Duration
+}
+
+var sensors = sensorEnv{FS: newHostFS("/"), Now: time.Now, ReadTimeout: defaultSensorReadTimeout}
+
+// hostFS is os.DirFS plus symlink resolution, which sysfs uses to tie block
+// devices to the bus they sit on.
+type hostFS struct {
+	fs.FS
+	root string
+}
+
+func newHostFS(root string) hostFS {
+	return hostFS{FS: os.DirFS(root), root: root}
+}
+
+func (h hostFS) EvalSymlinks(name string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(filepath.Join(h.root, filepath.FromSlash(name)))
+	if err != nil {
+		return "", err
+	}
+	root, err := filepath.EvalSymlinks(h.root)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fs.ErrNotExist
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// sensorPath turns an absolute path into the unrooted form io/fs expects.
+func sensorPath(name string) string {
+	cleaned := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+	if cleaned == "" {
+		return "."
+	}
+	return cleaned
+}
+
+// ReadFile reads a /proc table or other multi-line sensor file, up to
+// procFileMaxBytes.
+func (e sensorEnv) ReadFile(name string) ([]byte, error) {
+	return e.readBounded(name, procFileMaxBytes)
+}
+
+// ReadAttr reads a single sysfs attribute as trimmed text. Attributes over a
+// page or containing NUL bytes are rejected.
+func (e sensorEnv) ReadAttr(name string) (string, error) {
+	data, err := e.readBounded(name, sysfsAttrMaxBytes)
+	if err != nil {
+		return "", err
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", fmt.Errorf("%s: %w", name, errSensorFileBinary)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// ReadAttrUint reads an attribute holding exactly one unsigned decimal.
+func (e sensorEnv) ReadAttrUint(name string) (uint64, error) {
+	text, err := e.ReadAttr(name)
+	if err != nil {
+		return 0, err
+	}
+	return parseSensorUint(name, text)
+}
+
+// ReadAttrInt reads an attribute holding exactly one signed decimal.
+func (e sensorEnv) ReadAttrInt(name string) (int64, error) {
+	text, err := e.ReadAttr(name)
+	if err != nil {
+		return 0, err
+	}
+	if text == "" {
+		return 0, fmt.Errorf("empty sensor value: %s", name)
+	}
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sensor value %s: %w", name, err)
+	}
+	return value, nil
+}
+
+func parseSensorUint(name, text string) (uint64, error) {
+	if text == "" {
+		return 0, fmt.Errorf("empty sensor value: %s", name)
+	}
+	value, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sensor value %s: %w", name, err)
+	}
+	return value, nil
+}
+
+func (e sensorEnv) readBounded(name string, limit int64) ([]byte, error) {
+	key := sensorPath(name)
+	pendingSensorReads.mu.Lock()
+	if _, pending := pendingSensorReads.paths[key]; pending {
+		pendingSensorReads.mu.Unlock()
+		return nil, fmt.Errorf("%s: %w", name, errSensorReadPending)
+	}
+	pendingSensorReads.mu.Unlock()
+
+	type readResult struct {
+		data []byte
+		err  error
+	}
+	done := make(chan readResult, 1)
+	go func() {
+		data, err := e.readLimited(key, limit)
+		done <- readResult{data: data, err: err}
+	}()
+	timer := time.NewTimer(e.ReadTimeout)
+	defer timer.Stop()
+	select {
+	case result := <-done:
+		if errors.Is(result.err, errSensorFileTooLarge) {
+			return nil, fmt.Errorf("%s: %w", name, result.err)
+		}
+		return result.data, result.err
+	case <-timer.C:
+	}
+
+	pendingSensorReads.mu.Lock()
+	pendingSensorReads.paths[key] = struct{}{}
+	pendingSensorReads.mu.Unlock()
+	go func() {
+		<-done
+		pendingSensorReads.mu.Lock()
+		delete(pendingSensorReads.paths, key)
+		pendingSensorReads.mu.Unlock()
+	}()
+	return nil, fmt.Errorf("%s: %w", name, errSensorReadTimeout)
+}
+
+func (e sensorEnv) readLimited(key string, limit int64) ([]byte, error) {
+	file, err := e.FS.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, errSensorFileTooLarge
+	}
+	return data, nil
+}
+
+func (e sensorEnv) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(e.FS, sensorPath(name))
+}
+
+func (e sensorEnv) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(e.FS, sensorPath(name))
+}
+
+// EvalSymlinks resolves name when the filesystem knows about links. A
+// filesystem without them, like fstest.MapFS, returns the path unchanged.
+func (e sensorEnv) EvalSymlinks(name string) (string, error) {
+	linker, ok := e.FS.(interface {
+		EvalSymlinks(name string) (string, error)
+	})
+	if !ok {
+		if _, err := e.Stat(name); err != nil {
+			return "", err
+		}
+		return "/" + sensorPath(name), nil
+	}
+	resolved, err := linker.EvalSymlinks(sensorPath(name))
+	if err != nil {
+		return "", err
+	}
+	return "/" + resolved, nil
+}
+
+func (e sensorEnv) IsDir(name string) bool {
+	info, err := e.Stat(name)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func useSensorFS(t *testing.T, fsys fs.FS) {
+	t.Helper()
+	previous := sensors
+	sensors = sensorEnv{FS: fsys, Now: previous.Now, ReadTimeout: previous.ReadTimeout}
+	t.Cleanup(func() { sensors = previous })
+}
+
+const procDiskstatsFixture = `   8       0 sda 1200 30 96000 400 800 20 64000 900 2 1500 1300 0 0 0 0 50 20
+   8       1 sda1 1100 30 88000 380 790 20 63000 880 0 1400 1260 0 0 0 0 0 0
+ 259       0 nvme0n1 5000 0 2048000 1000 3000 0 1024000 600 0 2000 1600
+   7       0 loop0 bad 0 0 0 0 0 0 0 0 0 0
+`
+
+const procNetDevFixture = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:  123456     100    0    0    0     0          0         0   123456     100    0    0    0     0       0          0
+  eth0: 987654321  654321    1    2    0     0          0        10 123456789  54321    3    4    0     0       0          0
+wlan0:0 0 0 0 0 0 0 0 5 0 0 0 0 0 0 0
+`
+
+func TestParseProcDiskstats(t *testing.T) {
+	stats := parseProcDiskstats([]byte(procDiskstatsFixture))
+	sda, ok := stats["sda"]
+	if !ok {
+		t.Fatal("expected sda")
+	}
+	want := diskCounterSample{
+		Name:        "sda",
+		ReadCount:   1200,
+		ReadBytes:   96000 * 512,
+		ReadTimeMS:  400,
+		WriteCount:  800,
+		WriteBytes:  64000 * 512,
+		WriteTimeMS: 900,
+		QueueDepth:  2,
+		BusyTimeMS:  1500,
+	}
+	if sda != want {
+		t.Fatalf("unexpected sda %+v", sda)
+	}
+	if nvme := stats["nvme0n1"]; nvme.ReadBytes != 2048000*512 || nvme.BusyTimeMS != 2000 {
+		t.Fatalf("unexpected pre-4.18 line parse %+v", nvme)
+	}
+	if _, ok := stats["loop0"]; ok {
+		t.Fatal("expected a malformed line to be skipped")
+	}
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 devices, got %d", len(stats))
+	}
+}
+
+func TestParseProcNetDev(t *testing.T) {
+	stats := parseProcNetDev([]byte(procNetDevFixture))
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 interfaces, got %+v", stats)
+	}
+	eth0 := stats[1]
+	if eth0.Name != "eth0" || eth0.BytesRecv != 987654321 || eth0.BytesSent != 123456789 ||
+		eth0.PacketsRecv != 654321 || eth0.Errin != 1 || eth0.Dropout != 4 {
+		t.Fatalf("unexpected eth0 %+v", eth0)
+	}
+	if wlan := stats[2]; wlan.Name != "wlan0" || wlan.BytesSent != 5 {
+		t.Fatalf("expected a line without padding to parse, got %+v", wlan)
+	}
+}
+
+func TestSysfsDiskDetectionFromFixture(t *testing.T) {
+	useSensorFS(t, fstest.MapFS{
+		"sys/block/sdq/size":                 {Data: []byte("1953525168\n")},
+		"sys/block/sdq/device/model":         {Data: []byte("WDC WD10EZEX  \n")},
+		"sys/block/nvme7n1/size":             {Data: []byte("976773168\n")},
+		"sys/block/nvme7n1/device/serial":    {Data: []byte("S4EWNX0R\n")},
+		"sys/block/nvme7n1/wwid":             {Data: []byte("eui.0025388b91b1d1a4\n")},
+		"sys/block/sdq/device/wwid":          {Data: []byte("naa.5002538e40a2b3c4\n")},
+		"sys/block/loop3/size":               {Data: []byte("8\n")},
+		"sys/block/zram0/device/placeholder": {Data: []byte("")},
+		"sys/block/dm-0/size":                {Data: []byte("100\n")},
+	})
+
+	disks, err := detectDiskInfoBySysfs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disks) != 2 {
+		t.Fatalf("expected two physical disks, got %d", len(disks))
+	}
+	if disks[0].Name != "nvme7n1" || disks[0].Model != "S4EWNX0R" || disks[0].Size != 465 ||
+		disks[0].Serial != "S4EWNX0R" || disks[0].WWN != "eui.0025388b91b1d1a4" {
+		t.Fatalf("unexpected nvme disk %+v", disks[0])
+	}
+	if disks[1].Name != "sdq" || disks[1].Model != "WDC WD10EZEX" || disks[1].Size != 931 ||
+		disks[1].Serial != "" || disks[1].WWN != "naa.5002538e40a2b3c4" {
+		t.Fatalf("unexpected sata disk %+v", disks[1])
+	}
+}
+
+func TestDiskTempProbesFromFixture(t *testing.T) {
+	useSensorFS(t, fstest.MapFS{
+		"sys/block/sda/device/hwmon/hwmon1/name":        {Data: []byte("drivetemp\n")},
+		"sys/block/sda/device/hwmon/hwmon1/temp1_input": {Data: []byte("36000\n")},
+		"sys/block/sdb/device/hwmon/hwmon2/name":        {Data: []byte("drivetemp\n")},
+		"sys/block/sdb/device/hwmon/hwmon2/temp1_input": {Data: []byte("garbage\n")},
+		"sys/class/nvme/nvme0/hwmon4/name":              {Data: []byte("nvme\n")},
+		"sys/class/nvme/nvme0/hwmon4/temp1_label":       {Data: []byte("Composite\n")},
+		"sys/class/nvme/nvme0/hwmon4/temp1_input":       {Data: []byte("45850\n")},
+	})
+
+	service := newDiskTempService(platformDiskTempProbes())
+	service.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	temps := service.Read([]string{"sda", "sdb", "nvme0n1"})
+	if len(platformDiskTempProbes()) == 0 {
+		if len(temps) != 0 {
+			t.Fatalf("expected no sysfs readings on this platform, got %+v", temps)
+		}
+		return
+	}
+	if got := temps["sda"]; !got.OK || got.Temperature != 36 {
+		t.Fatalf("unexpected sda %+v", got)
+	}
+	if got := temps["nvme0n1"]; !got.OK || got.Temperature != 45.85 {
+		t.Fatalf("unexpected nvme0n1 %+v", got)
+	}
+	if _, ok := temps["sdb"]; ok {
+		t.Fatal("expected an unparsable sensor to be ignored")
+	}
+}
+
+// blockingFS hangs every Open until release is closed, like a sysfs
+// attribute whose device is waking from sleep.
+type blockingFS struct {
+	fstest.MapFS
+	release chan struct{}
+}
+
+func (b blockingFS) Open(name string) (fs.File, error) {
+	<-b.release
+	return b.MapFS.Open(name)
+}
+
+func TestSensorReadsAreBounded(t *testing.T) {
+	useSensorFS(t, fstest.MapFS{
+		"sys/a/huge":   {Data: make([]byte, sysfsAttrMaxBytes+1)},
+		"sys/a/binary": {Data: []byte("12\x00\xff")},
+		"sys/a/number": {Data: []byte(" 42\n")},
+		"sys/a/suffix": {Data: []byte("42 kB\n")},
+	})
+
+	if _, err := sensors.ReadAttr("/sys/a/huge"); !errors.Is(err, errSensorFileTooLarge) {
+		t.Fatalf("expected an oversized attribute to be rejected, got %v", err)
+	}
+	if _, err := sensors.ReadFile("/sys/a/huge"); err != nil {
+		t.Fatalf("expected the table limit to allow the file, got %v", err)
+	}
+	if _, err := sensors.ReadAttr("/sys/a/binary"); !errors.Is(err, errSensorFileBinary) {
+		t.Fatalf("expected a binary attribute to be rejected, got %v", err)
+	}
+	if value, err := sensors.ReadAttrUint("/sys/a/number"); err != nil || value != 42 {
+		t.Fatalf("unexpected number %d, %v", value, err)
+	}
+	if _, err := sensors.ReadAttrUint("/sys/a/suffix"); err == nil {
+		t.Fatal("expected trailing text to fail strict parsing")
+	}
+}
+
+func TestSensorReadTimesOutOnceWhileStuck(t *testing.T) {
+	release := make(chan struct{})
+	useSensorFS(t, blockingFS{MapFS: fstest.MapFS{"sys/slow": {Data: []byte("1\n")}}, release: release})
+	sensors.ReadTimeout = 10 * time.Millisecond
+
+	if _, err := sensors.ReadAttr("/sys/slow"); !errors.Is(err, errSensorReadTimeout) {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	if _, err := sensors.ReadAttr("/sys/slow"); !errors.Is(err, errSensorReadPending) {
+		t.Fatalf("expected the stuck read to block new ones, got %v", err)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		value, err := sensors.ReadAttr("/sys/slow")
+		if err == nil {
+			if value != "1" {
+				t.Fatalf("unexpected value %q", value)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("read did not recover after release: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestParseProcNetRouteDefault(t *testing.T) {
+	const route = `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+wlan0	00000000	0101A8C0	0003	0	0	600	00000000	0	0	0
+eth0	00000000	0100000A	0003	0	0	100	00000000	0	0	0
+eth0	0000000A	00000000	0001	0	0	100	000000FF	0	0	0
+tun0	00000000	00000000	0000	0	0	1	00000000	0	0	0
+`
+	if got := parseProcNetRouteDefault([]byte(route)); got != "eth0" {
+		t.Fatalf("expected the lowest metric default route, got %q", got)
+	}
+	if got := parseProcNetRouteDefault([]byte("Iface\tDestination\n")); got != "" {
+		t.Fatalf("expected no default route, got %q", got)
+	}
+}
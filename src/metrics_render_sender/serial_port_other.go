@@ -0,0 +1,12 @@
+//go:build !linux && !windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+func openSerialPort(path string, baud int) (io.ReadWriteCloser, error) {
+	return nil, fmt.Errorf("serial ports are not supported on this platform")
+}
@@ -0,0 +1,53 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const serialDCBBinary = 0x00000001
+
+// openSerialPort opens a COM port in 8N1 mode. Reads return after at most
+// half a second without data so the reader can notice it was stopped.
+func openSerialPort(path string, baud int) (io.ReadWriteCloser, error) {
+	if baud <= 0 {
+		return nil, fmt.Errorf("unsupported baud rate %d", baud)
+	}
+	name := path
+	if !strings.HasPrefix(name, `\\.\`) {
+		name = `\\.\` + name
+	}
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	handle, err := windows.CreateFile(namePtr, windows.GENERIC_READ|windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	dcb := windows.DCB{
+		BaudRate: uint32(baud),
+		Flags:    serialDCBBinary,
+		ByteSize: 8,
+		Parity:   windows.NOPARITY,
+		StopBits: windows.ONESTOPBIT,
+	}
+	dcb.DCBlength = uint32(unsafe.Sizeof(dcb))
+	if err := windows.SetCommState(handle, &dcb); err != nil {
+		windows.CloseHandle(handle)
+		return nil, fmt.Errorf("configure %s: %w", path, err)
+	}
+	timeouts := windows.CommTimeouts{ReadIntervalTimeout: 50, ReadTotalTimeoutConstant: 500}
+	if err := windows.SetCommTimeouts(handle, &timeouts); err != nil {
+		windows.CloseHandle(handle)
+		return nil, fmt.Errorf("configure %s timeouts: %w", path, err)
+	}
+	return os.NewFile(uintptr(handle), path), nil
+}
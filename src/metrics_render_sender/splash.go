@@ -0,0 +1,133 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
+)
+
+const (
+	defaultSplashTitle    = "MetricsRenderSender"
+	defaultSplashDuration = 3 * time.Second
+	defaultShutdownText   = "System off"
+
+	shutdownScreenMessage = "message"
+	shutdownScreenBlank   = "blank"
+	shutdownScreenNone    = "none"
+)
+
+// splashSettings is a SplashConfig with defaults applied. A config without
+// a splash section gets the splash and the shutdown message.
+type splashSettings struct {
+	title        string
+	duration     time.Duration
+	shutdown     string
+	shutdownText string
+}
+
+func resolveSplashSettings(cfg *SplashConfig) splashSettings {
+	settings := splashSettings{
+		title:        defaultSplashTitle,
+		duration:     defaultSplashDuration,
+		shutdown:     shutdownScreenMessage,
+		shutdownText: defaultShutdownText,
+	}
+	if cfg == nil {
+		return settings
+	}
+	if title := strings.TrimSpace(cfg.Title); title != "" {
+		settings.title = title
+	}
+	if cfg.DurationSec != 0 {
+		settings.duration = time.Duration(cfg.DurationSec) * time.Second
+	}
+	switch shutdown := strings.ToLower(strings.TrimSpace(cfg.Shutdown)); shutdown {
+	case "", shutdownScreenMessage:
+	case shutdownScreenBlank, "off":
+		settings.shutdown = shutdownScreenBlank
+	case shutdownScreenNone:
+		settings.shutdown = shutdownScreenNone
+	default:
+		logWarnModule("config", "unknown splash shutdown %q, using %s", cfg.Shutdown, shutdownScreenMessage)
+	}
+	if text := strings.TrimSpace(cfg.ShutdownText); text != "" {
+		settings.shutdownText = text
+	}
+	return settings
+}
+
+func newBlankFrame(width, height int) *image.RGBA {
+	blank := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(blank, blank.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	return blank
+}
+
+// splashHostInfo returns the host name and the address of the default
+// network interface, IPv4 when it has one.
+func splashHostInfo(preferredInterface string) (string, string) {
+	hostname, _ := os.Hostname()
+	state := readNetworkInterfaceState(preferredInterface)
+	addresses := state.Addresses[state.Default]
+	address := addresses.IPv4
+	if address == "" {
+		address = addresses.IPv6
+	}
+	return strings.TrimSpace(hostname), address
+}
+
+func splashVersionText(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" || version == "unknown" {
+		return "development build"
+	}
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
+// renderSplashScreen draws the title with the host name, version and IP
+// address below it, centered on black.
+func renderSplashScreen(settings splashSettings, width, height int, fontCache *FontCache, hostname, version, address string) image.Image {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	dc := gg.NewContext(width, height)
+	dc.SetColor(color.Black)
+	dc.Clear()
+
+	lines := make([]string, 0, 3)
+	for _, line := range []string{hostname, splashVersionText(version), address} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	titleSize := max(12, height/8)
+	// Long titles shrink to fit narrow panels.
+	for titleSize > 10 && font.MeasureString(resolveFontFace(fontCache, titleSize), settings.title).Ceil() > width*9/10 {
+		titleSize--
+	}
+	lineSize := max(10, height/16)
+	lineHeight := lineSize * 3 / 2
+	top := (height - titleSize*2 - lineHeight*len(lines)) / 2
+	drawCenteredText(dc, settings.title, 0, top, width, titleSize*2, titleSize, "#f8fafc", fontCache)
+	for idx, line := range lines {
+		drawCenteredText(dc, line, 0, top+titleSize*2+lineHeight*idx, width, lineHeight, lineSize, "#94a3b8", fontCache)
+	}
+	return dc.Image()
+}
+
+// renderShutdownScreen draws text centered on black.
+func renderShutdownScreen(text string, width, height int, fontCache *FontCache) image.Image {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	dc := gg.NewContext(width, height)
+	dc.SetColor(color.Black)
+	dc.Clear()
+	drawCenteredText(dc, text, 0, 0, width, height, max(12, height/10), "#64748b", fontCache)
+	return dc.Image()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestResolveSplashSettings(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	defaults := resolveSplashSettings(nil)
+	if defaults.duration != defaultSplashDuration || defaults.shutdown != shutdownScreenMessage || defaults.shutdownText != defaultShutdownText {
+		t.Fatalf("unexpected defaults %+v", defaults)
+	}
+	custom := resolveSplashSettings(&SplashConfig{Title: "Rack 2", DurationSec: -1, Shutdown: "Blank"})
+	if custom.title != "Rack 2" || custom.duration > 0 || custom.shutdown != shutdownScreenBlank {
+		t.Fatalf("unexpected settings %+v", custom)
+	}
+	if got := splashVersionText("unknown"); got != "development build" {
+		t.Fatalf("unexpected version text %q", got)
+	}
+	if got := splashVersionText("v1.2.3"); got != "v1.2.3" {
+		t.Fatalf("unexpected version text %q", got)
+	}
+}
+
+func TestWebAPISplashHoldsRendering(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	runtime := &WebAPI{
+		config:      &MonitorConfig{Width: 32, Height: 24, Splash: &SplashConfig{DurationSec: 60}},
+		outputQueue: newWebFrameQueue(),
+	}
+	runtime.showSplash()
+	frame, ok := runtime.outputQueue.takeLatest()
+	if !ok {
+		t.Fatal("expected the splash to be queued")
+	}
+	if bounds := frame.result.Image.Bounds(); bounds.Dx() != 32 || bounds.Dy() != 24 {
+		t.Fatalf("unexpected splash size %v", bounds)
+	}
+	if rendered, err := runtime.renderOnce(true); rendered || err != nil {
+		t.Fatalf("expected rendering to wait for the splash, rendered=%v err=%v", rendered, err)
+	}
+	if !runtime.splashUntil.After(time.Now().Add(50 * time.Second)) {
+		t.Fatalf("expected the splash to stay up for its duration, until %v", runtime.splashUntil)
+	}
+}
+
+func TestWebAPIShutdownScreen(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	defer SetAX206Brightness(AX206Brightness())
+
+	runtime := &WebAPI{
+		config:      &MonitorConfig{Width: 4, Height: 2, Splash: &SplashConfig{Shutdown: "blank"}},
+		outputQueue: newWebFrameQueue(),
+	}
+	runtime.showShutdownScreen()
+	frame, ok := runtime.outputQueue.takeLatest()
+	if !ok {
+		t.Fatal("expected the shutdown frame to be queued")
+	}
+	if got := color.RGBAModel.Convert(frame.result.Image.At(3, 1)).(color.RGBA); got != (color.RGBA{A: 255}) {
+		t.Fatalf("expected a black frame, got %+v", got)
+	}
+	if AX206Brightness() != 0 {
+		t.Fatalf("expected the backlight off, got %d", AX206Brightness())
+	}
+	if rendered, err := runtime.renderOnce(true); rendered || err != nil {
+		t.Fatalf("expected no rendering after shutdown, rendered=%v err=%v", rendered, err)
+	}
+
+	runtime = &WebAPI{
+		config:      &MonitorConfig{Width: 4, Height: 2, Splash: &SplashConfig{Shutdown: "none"}},
+		outputQueue: newWebFrameQueue(),
+	}
+	runtime.showShutdownScreen()
+	if _, ok := runtime.outputQueue.takeLatest(); ok {
+		t.Fatal("expected the last frame to be kept with shutdown none")
+	}
+}
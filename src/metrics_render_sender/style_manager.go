@@ -46,33 +46,58 @@ var styleMetaList = []StyleKeyMeta{
 	{Key: "border_width", Label: "边框宽度", Kind: "float", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}},
 	{Key: "border_color", Label: "边框颜色", Kind: "color", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}},
 	{Key: "radius", Label: "圆角", Kind: "int", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}},
+	{Key: "shadow_color", Label: "阴影颜色", Kind: "color", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}},
+	{Key: "shadow_offset_x", Label: "阴影偏移X", Kind: "float", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}},
+	{Key: "shadow_offset_y", Label: "阴影偏移Y", Kind: "float", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}},
+	{Key: "shadow_blur", Label: "阴影模糊", Kind: "float", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}},
 	{Key: "history_points", Label: "历史点数", Kind: "int", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart}},
-	{Key: "content_padding_x", Label: "左右边距", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeLabelText, itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}},
-	{Key: "content_padding_y", Label: "上下边距", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeLabelText, itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}},
-	{Key: "body_gap", Label: "标题间距", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH}},
-	{Key: "header_height", Label: "标题栏高度", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH}},
-	{Key: "header_divider", Label: "标题分隔线", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH}},
-	{Key: "header_divider_width", Label: "分隔线宽", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH}},
-	{Key: "header_divider_offset", Label: "分隔线偏移", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH}},
-	{Key: "header_divider_color", Label: "分隔线颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH}},
+	{Key: "history_stride", Label: "采样步长", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart}},
+	{Key: "text_align", Label: "水平对齐", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLabel, itemTypeSimpleValue, itemTypeLabelText}, Options: []StyleOption{{Label: "左对齐", Value: string(AlignLeft)}, {Label: "居中", Value: string(AlignCenter)}, {Label: "右对齐", Value: string(AlignRight)}, {Label: "两端对齐", Value: string(AlignJustify)}}},
+	{Key: "text_valign", Label: "垂直对齐", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLabel, itemTypeSimpleValue, itemTypeLabelText}, Options: []StyleOption{{Label: "顶部", Value: string(AlignTop)}, {Label: "居中", Value: string(AlignMiddle)}, {Label: "底部", Value: string(AlignBottom)}}},
+	{Key: "text_wrap", Label: "自动换行", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLabel}},
+	{Key: "text_line_spacing", Label: "行距倍数", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLabel}},
+	{Key: "text_ellipsis", Label: "超长省略", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLabel, itemTypeSimpleValue, itemTypeLabelText}},
+	{Key: "label_position", Label: "标签位置", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeLabelText}, Options: []StyleOption{{Label: "左侧", Value: labelPositionLeft}, {Label: "上方", Value: labelPositionAbove}, {Label: "下方", Value: labelPositionBelow}, {Label: "同行", Value: labelPositionInline}}},
+	{Key: "label_gap", Label: "标签间距", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeLabelText}},
+	{Key: "content_padding_x", Label: "左右边距", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress, itemTypeSimpleChart, itemTypeLabelText, itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullTimer, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}},
+	{Key: "content_padding_y", Label: "上下边距", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress, itemTypeSimpleChart, itemTypeLabelText, itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullTimer, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}},
+	{Key: "body_gap", Label: "标题间距", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullProgressH}},
+	{Key: "header_height", Label: "标题栏高度", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullProgressH}},
+	{Key: "header_divider", Label: "标题分隔线", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullProgressH}},
+	{Key: "header_divider_width", Label: "分隔线宽", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullProgressH}},
+	{Key: "header_divider_offset", Label: "分隔线偏移", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullProgressH}},
+	{Key: "header_divider_color", Label: "分隔线颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullProgressH}},
 	{Key: "show_segment_lines", Label: "分段线", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
-	{Key: "show_grid_lines", Label: "网格线", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
-	{Key: "grid_lines", Label: "网格线数量", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
-	{Key: "enable_threshold_colors", Label: "阈值分段颜色", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart}},
+	{Key: "show_grid_lines", Label: "网格线", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart}},
+	{Key: "grid_lines", Label: "网格线数量", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart}},
+	{Key: "show_axis_labels", Label: "最值标签", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart}},
+	{Key: "show_threshold_lines", Label: "阈值参考线", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart}},
+	{Key: "show_time_span", Label: "时间跨度", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart}},
+	{Key: "enable_threshold_colors", Label: "阈值分段颜色", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart, itemTypeFullHeatmap}},
 	{Key: "line_width", Label: "线宽", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeSimpleLine, itemTypeFullChart}},
-	{Key: "line_orientation", Label: "线方向", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLine}, Options: []StyleOption{{Label: "横向", Value: "horizontal"}, {Label: "竖向", Value: "vertical"}}},
+	{Key: "line_orientation", Label: "线方向", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLine}, Options: []StyleOption{{Label: "横向", Value: "horizontal"}, {Label: "竖向", Value: "vertical"}, {Label: "左上到右下", Value: lineOrientationDiagonalDown}, {Label: "左下到右上", Value: lineOrientationDiagonalUp}}},
+	{Key: "line_dash", Label: "线型", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLine}, Options: []StyleOption{{Label: "实线", Value: lineDashSolid}, {Label: "虚线", Value: lineDashDashed}, {Label: "点线", Value: lineDashDotted}}},
+	{Key: "line_dash_length", Label: "虚线段长", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleLine}},
+	{Key: "log_scale", Label: "对数刻度", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart}},
 	{Key: "show_avg_line", Label: "均线", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
 	{Key: "chart_color", Label: "折线颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
-	{Key: "chart_fill_color", Label: "折线区域颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
+	{Key: "chart_fill_color", Label: "折线区域颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart}},
 	{Key: "chart_area_bg", Label: "图表区背景", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
 	{Key: "chart_area_border_color", Label: "图表区边框", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
-	{Key: "progress_style", Label: "进度样式", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullProgressH, itemTypeFullProgressV}, Options: []StyleOption{{Label: "gradient", Value: "gradient"}, {Label: "solid", Value: "solid"}, {Label: "segmented", Value: "segmented"}, {Label: "stripes", Value: "stripes"}}},
-	{Key: "bar_height", Label: "条高度", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullProgressH, itemTypeFullProgressV}},
+	{Key: "chart_area_radius", Label: "图表区圆角", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart}},
+	{Key: "grid_line_color", Label: "网格线颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleChart, itemTypeFullChart}},
+	{Key: "progress_style", Label: "进度样式", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress, itemTypeFullProgressH, itemTypeFullProgressV}, Options: []StyleOption{{Label: "gradient", Value: "gradient"}, {Label: "solid", Value: "solid"}, {Label: "segmented", Value: "segmented"}, {Label: "stripes", Value: "stripes"}}},
+	{Key: "bar_height", Label: "条高度", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullTimer}},
 	{Key: "bar_radius", Label: "条圆角", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullProgressH, itemTypeFullProgressV}},
-	{Key: "track_color", Label: "轨道颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}},
-	{Key: "segments", Label: "分段数量", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullProgressH, itemTypeFullProgressV}},
-	{Key: "segment_gap", Label: "分段间隔", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullProgressH, itemTypeFullProgressV}},
-	{Key: "card_radius", Label: "外框圆角", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}},
+	{Key: "track_color", Label: "轨道颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge, itemTypeFullVUMeter, itemTypeFullTimer}},
+	{Key: "segments", Label: "分段数量", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullVUMeter}},
+	{Key: "segment_gap", Label: "分段间隔", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullVUMeter}},
+	{Key: "progress_orientation", Label: "进度方向", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress, itemTypeFullVUMeter}, Options: []StyleOption{{Label: "横向", Value: "horizontal"}, {Label: "竖向", Value: "vertical"}}},
+	{Key: "round_caps", Label: "圆头", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress}},
+	{Key: "tick_count", Label: "刻度数量", Kind: "int", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress}},
+	{Key: "tick_color", Label: "刻度颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress}},
+	{Key: "value_position", Label: "数值位置", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeSimpleProgress}, Options: []StyleOption{{Label: "居中", Value: progressValueCenter}, {Label: "条内", Value: progressValueInside}, {Label: "隐藏", Value: progressValueNone}}},
+	{Key: "card_radius", Label: "外框圆角", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullChart, itemTypeFullTable, itemTypeFullHeatmap, itemTypeFullVUMeter, itemTypeFullTimer, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}},
 	{Key: "table_row_gap", Label: "行间距", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTable}},
 	{Key: "table_row_radius", Label: "行圆角", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTable}},
 	{Key: "table_row_bg", Label: "行背景", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTable}},
@@ -80,11 +105,33 @@ var styleMetaList = []StyleKeyMeta{
 	{Key: "table_column_gap", Label: "列间距", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTable}},
 	{Key: "table_label_width_ratio", Label: "标签列比例", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTable}},
 	{Key: "table_show_units", Label: "显示单位", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTable}},
+	{Key: "heatmap_low_color", Label: "低值颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullHeatmap}},
+	{Key: "heatmap_high_color", Label: "高值颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullHeatmap}},
+	{Key: "heatmap_cell_gap", Label: "单元间距", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullHeatmap}},
+	{Key: "heatmap_cell_radius", Label: "单元圆角", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullHeatmap}},
+	{Key: "heatmap_show_values", Label: "单元数值", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullHeatmap}},
+	{Key: "vu_low_color", Label: "低电平颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullVUMeter}},
+	{Key: "vu_mid_color", Label: "中电平颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullVUMeter}},
+	{Key: "vu_high_color", Label: "高电平颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullVUMeter}},
+	{Key: "vu_mid_at", Label: "中电平起点(%)", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullVUMeter}},
+	{Key: "vu_high_at", Label: "高电平起点(%)", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullVUMeter}},
+	{Key: "timer_work_color", Label: "专注颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTimer}},
+	{Key: "timer_break_color", Label: "短休息颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTimer}},
+	{Key: "timer_long_break_color", Label: "长休息颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTimer}},
+	{Key: "timer_idle_color", Label: "暂停颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullTimer}},
 	{Key: "gauge_thickness", Label: "仪表盘厚度", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullGauge}},
 	{Key: "gauge_gap_degrees", Label: "底部缺口角度", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullGauge}},
 	{Key: "gauge_text_gap", Label: "文字间距", Kind: "float", Scopes: []string{styleScopeType, styleScopeItem}, Types: []string{itemTypeFullGauge}},
+	{Key: "show_level_label", Label: "显示阈值标签", Kind: "bool", Scopes: []string{styleScopeType, styleScopeItem}, Types: alertEffectItemTypes},
+	{Key: "tabular_numbers", Label: "等宽数字", Kind: "bool", Scopes: []string{styleScopeBase, styleScopeType, styleScopeItem}, Types: tabularNumberItemTypes},
+	{Key: "alert_effect", Label: "越限动画", Kind: "select", Scopes: []string{styleScopeType, styleScopeItem}, Types: alertEffectItemTypes, Options: []StyleOption{{Label: "无", Value: alertEffectNone}, {Label: "闪烁", Value: alertEffectBlink}, {Label: "呼吸", Value: alertEffectPulse}}},
+	{Key: "alert_color", Label: "越限动画颜色", Kind: "color", Scopes: []string{styleScopeType, styleScopeItem}, Types: alertEffectItemTypes},
 }
 
+var alertEffectItemTypes = []string{itemTypeSimpleValue, itemTypeSimpleProgress, itemTypeSimpleChart, itemTypeLabelText, itemTypeFullChart, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}
+
+var tabularNumberItemTypes = []string{itemTypeSimpleValue, itemTypeSimpleProgress, itemTypeLabelText, itemTypeFullChart, itemTypeFullTable, itemTypeFullProgressH, itemTypeFullProgressV, itemTypeFullGauge}
+
 var styleMetaByKey = buildStyleMetaByKey()
 
 func buildStyleMetaByKey() map[string]styleMetaEntry {
@@ -214,7 +261,7 @@ func normalizeStyleMap(input map[string]interface{}, scope string, itemType stri
 
 func normalizeStyleValueByKey(key string, value interface{}) interface{} {
 	switch key {
-	case "text_font_size", "unit_font_size", "value_font_size", "header_height", "history_points", "grid_lines", "segments", "content_padding_x", "content_padding_y", "body_gap", "radius":
+	case "text_font_size", "unit_font_size", "value_font_size", "header_height", "history_points", "history_stride", "grid_lines", "segments", "tick_count", "content_padding_x", "content_padding_y", "body_gap", "radius":
 		n, ok := toStyleNumber(value)
 		if !ok {
 			return 0
@@ -229,7 +276,7 @@ func normalizeStyleValueByKey(key string, value interface{}) interface{} {
 			n = 4
 		}
 		return int(n)
-	case "border_width", "line_width", "bar_height", "bar_radius", "segment_gap", "card_radius", "gauge_thickness", "gauge_gap_degrees", "gauge_text_gap", "header_divider_width", "header_divider_offset":
+	case "border_width", "line_width", "bar_height", "bar_radius", "segment_gap", "card_radius", "gauge_thickness", "gauge_gap_degrees", "gauge_text_gap", "header_divider_width", "header_divider_offset", "heatmap_cell_gap", "heatmap_cell_radius", "vu_mid_at", "vu_high_at", "shadow_blur", "chart_area_radius", "line_dash_length", "text_line_spacing", "label_gap":
 		n, ok := toStyleNumber(value)
 		if !ok {
 			return 0.0
@@ -238,14 +285,34 @@ func normalizeStyleValueByKey(key string, value interface{}) interface{} {
 			n = 0
 		}
 		return n
-	case "header_divider", "show_segment_lines", "show_grid_lines", "enable_threshold_colors", "show_avg_line":
+	case "shadow_offset_x", "shadow_offset_y":
+		n, ok := toStyleNumber(value)
+		if !ok {
+			return 0.0
+		}
+		return n
+	case "header_divider", "show_segment_lines", "show_grid_lines", "enable_threshold_colors", "show_avg_line", "show_level_label", "round_caps", "show_axis_labels", "show_threshold_lines", "show_time_span", "log_scale", "heatmap_show_values", "text_wrap", "text_ellipsis", "tabular_numbers":
 		return toStyleBool(value)
 	case "line_orientation":
+		return normalizeSimpleLineOrientation(fmt.Sprintf("%v", value))
+	case "line_dash":
+		return normalizeSimpleLineDash(fmt.Sprintf("%v", value))
+	case "text_align":
+		return string(normalizeTextAlignH(fmt.Sprintf("%v", value)))
+	case "label_position":
+		return normalizeLabelPosition(fmt.Sprintf("%v", value))
+	case "text_valign":
+		return string(normalizeTextAlignV(fmt.Sprintf("%v", value)))
+	case "progress_orientation":
 		text := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", value)))
 		if text != "vertical" {
 			return "horizontal"
 		}
 		return text
+	case "alert_effect":
+		return normalizeAlertEffect(fmt.Sprintf("%v", value))
+	case "value_position":
+		return normalizeProgressValuePosition(fmt.Sprintf("%v", value))
 	case "progress_style":
 		text := strings.ToLower(strings.TrimSpace(fmt.Sprintf("%v", value)))
 		switch text {
@@ -356,11 +423,25 @@ func styleCodeDefault(itemType, key string) (interface{}, bool) {
 		return 0, true
 	case "history_points":
 		return 150, true
+	case "history_stride":
+		return 1, true
 	case "content_padding_x", "content_padding_y":
 		if itemType == itemTypeLabelText || strings.HasPrefix(itemType, "full_") {
 			return 1, true
 		}
+		if itemType == itemTypeSimpleChart {
+			return 2, true
+		}
+		if itemType == itemTypeSimpleProgress {
+			return 0, true
+		}
 		return 3, true
+	case "shadow_color":
+		return "", true
+	case "shadow_offset_x", "shadow_offset_y":
+		return 2.0, true
+	case "shadow_blur":
+		return 4.0, true
 	case "body_gap":
 		if itemType == itemTypeFullChart {
 			return 4, true
@@ -382,12 +463,33 @@ func styleCodeDefault(itemType, key string) (interface{}, bool) {
 		return false, true
 	case "grid_lines":
 		return 4, true
+	case "show_axis_labels", "show_threshold_lines", "show_time_span", "log_scale":
+		return false, true
 	case "enable_threshold_colors":
 		return false, true
 	case "line_width":
 		return 1.0, true
 	case "line_orientation":
 		return "horizontal", true
+	case "line_dash":
+		return lineDashSolid, true
+	case "text_align":
+		if itemType == itemTypeSimpleLabel {
+			return string(AlignLeft), true
+		}
+		return string(AlignCenter), true
+	case "text_valign":
+		return string(AlignMiddle), true
+	case "text_wrap", "text_ellipsis":
+		return false, true
+	case "text_line_spacing":
+		return 1.2, true
+	case "label_position":
+		return labelPositionLeft, true
+	case "label_gap":
+		return 4.0, true
+	case "line_dash_length":
+		return 6.0, true
 	case "show_avg_line":
 		return false, true
 	case "chart_color":
@@ -401,6 +503,10 @@ func styleCodeDefault(itemType, key string) (interface{}, bool) {
 		return "", true
 	case "chart_area_border_color":
 		return "", true
+	case "chart_area_radius":
+		return 4.0, true
+	case "grid_line_color":
+		return "#4755693c", true
 	case "table_row_gap":
 		return 0.0, true
 	case "table_row_radius":
@@ -415,18 +521,69 @@ func styleCodeDefault(itemType, key string) (interface{}, bool) {
 		return 0.46, true
 	case "table_show_units":
 		return true, true
+	case "heatmap_low_color":
+		return "#1e3a8a", true
+	case "heatmap_high_color":
+		return "#ef4444", true
+	case "heatmap_cell_gap", "heatmap_cell_radius":
+		return 2.0, true
+	case "heatmap_show_values":
+		return true, true
+	case "vu_low_color":
+		return "#22c55e", true
+	case "vu_mid_color":
+		return "#eab308", true
+	case "vu_high_color":
+		return "#ef4444", true
+	case "vu_mid_at":
+		return 70.0, true
+	case "vu_high_at":
+		return 90.0, true
+	case "timer_work_color":
+		return "#ef4444", true
+	case "timer_break_color":
+		return "#22c55e", true
+	case "timer_long_break_color":
+		return "#3b82f6", true
+	case "timer_idle_color":
+		return "#9ca3af", true
 	case "progress_style":
+		if itemType == itemTypeSimpleProgress {
+			return "solid", true
+		}
 		return "gradient", true
 	case "bar_height":
+		if itemType == itemTypeFullTimer {
+			return 4.0, true
+		}
 		return 0.0, true
 	case "bar_radius":
 		return 0.0, true
 	case "track_color":
+		if itemType == itemTypeSimpleProgress {
+			return "", true
+		}
 		return "#1f2937", true
 	case "segments":
+		if itemType == itemTypeFullVUMeter {
+			return 20, true
+		}
 		return 12, true
 	case "segment_gap":
+		if itemType == itemTypeFullVUMeter {
+			return 1.0, true
+		}
 		return 2.0, true
+	case "progress_orientation":
+		return "horizontal", true
+	case "round_caps":
+		return false, true
+	case "tick_count":
+		return 0, true
+	case "tick_color":
+		return "", true
+	case "value_position":
+		return progressValueCenter, true
 	case "card_radius":
 		return 0.0, true
 	case "gauge_thickness":
@@ -435,6 +592,12 @@ func styleCodeDefault(itemType, key string) (interface{}, bool) {
 		return 76.0, true
 	case "gauge_text_gap":
 		return 1.0, true
+	case "show_level_label", "tabular_numbers":
+		return false, true
+	case "alert_effect":
+		return alertEffectNone, true
+	case "alert_color":
+		return "", true
 	}
 	return nil, false
 }
@@ -468,6 +631,16 @@ func resolveStyleRaw(item *ItemConfig, config *MonitorConfig, key string) (inter
 			return value, true
 		}
 	}
+	if shared := currentLayoutDefaults(); shared != nil {
+		if hasItemType {
+			if value, ok := readStyleMapValue(shared.typeDefaults(itemType).Style, normalizedKey); ok {
+				return value, true
+			}
+		}
+		if value, ok := readStyleMapValue(shared.StyleBase, normalizedKey); ok {
+			return value, true
+		}
+	}
 
 	if value, ok := styleCodeDefault(itemType, normalizedKey); ok {
 		return value, true
@@ -558,6 +731,11 @@ func resolveStyleOverrideRaw(item *ItemConfig, config *MonitorConfig, key string
 			return value, true
 		}
 	}
+	if shared := currentLayoutDefaults(); shared != nil && itemType != "" {
+		if value, ok := readStyleMapValue(shared.typeDefaults(itemType).Style, normalizedKey); ok {
+			return value, true
+		}
+	}
 	return nil, false
 }
 
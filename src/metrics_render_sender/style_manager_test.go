@@ -21,7 +21,7 @@ func TestWebStyleKeyMetaIncludesChartFillColor(t *testing.T) {
 		if meta.Label != "折线区域颜色" {
 			t.Fatalf("unexpected label: %s", meta.Label)
 		}
-		if len(meta.Types) != 1 || meta.Types[0] != itemTypeFullChart {
+		if len(meta.Types) != 2 || meta.Types[0] != itemTypeSimpleChart || meta.Types[1] != itemTypeFullChart {
 			t.Fatalf("unexpected types: %#v", meta.Types)
 		}
 		if meta.Default != "rgba(0,0,0,0)" {
@@ -31,3 +31,33 @@ func TestWebStyleKeyMetaIncludesChartFillColor(t *testing.T) {
 	}
 	t.Fatalf("chart_fill_color meta not found")
 }
+
+func TestStyleCodeDefaultSimpleProgressKeepsFlatBar(t *testing.T) {
+	if value, _ := styleCodeDefault(itemTypeSimpleProgress, "progress_style"); value != "solid" {
+		t.Fatalf("expected solid progress_style for simple_progress, got %v", value)
+	}
+	if value, _ := styleCodeDefault(itemTypeFullProgressH, "progress_style"); value != "gradient" {
+		t.Fatalf("expected gradient progress_style for full_progress_h, got %v", value)
+	}
+	if value, _ := styleCodeDefault(itemTypeSimpleProgress, "track_color"); value != "" {
+		t.Fatalf("expected no track for simple_progress, got %v", value)
+	}
+	if got := normalizeStyleValueByKey("value_position", "INSIDE"); got != progressValueInside {
+		t.Fatalf("expected inside value_position, got %v", got)
+	}
+	if got := normalizeStyleValueByKey("progress_orientation", "diagonal"); got != "horizontal" {
+		t.Fatalf("expected horizontal fallback, got %v", got)
+	}
+}
+
+func TestNormalizeShadowStyleValues(t *testing.T) {
+	if got := normalizeStyleValueByKey("shadow_offset_x", -3); got != -3.0 {
+		t.Fatalf("expected negative shadow offset to be kept, got %v", got)
+	}
+	if got := normalizeStyleValueByKey("shadow_blur", -4); got != 0.0 {
+		t.Fatalf("expected negative shadow_blur clamped to 0, got %v", got)
+	}
+	if value, _ := styleCodeDefault(itemTypeSimpleValue, "shadow_color"); value != "" {
+		t.Fatalf("expected shadow disabled by default, got %v", value)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Collectors report every epoch; a single failed epoch is common for
+	// remote backends and not worth showing on the panel.
+	subsystemStatusCollectorFailures = 3
+	// Sources that stop reporting are dropped after this long, so a removed
+	// collector or output does not leave its last error behind.
+	subsystemStatusExpiry = 5 * time.Minute
+	subsystemStatusMaxLen = 64
+)
+
+type subsystemStatusEntry struct {
+	message  string
+	since    time.Time
+	lastAt   time.Time
+	failures int
+}
+
+var (
+	subsystemStatusMu sync.Mutex
+	subsystemStatuses = make(map[string]*subsystemStatusEntry)
+)
+
+// reportSubsystemStatus records the outcome of the latest attempt by source,
+// e.g. a collector update or an output write. A nil error clears it.
+func reportSubsystemStatus(source string, err error) {
+	reportSubsystemStatusAt(source, err, 1, time.Now())
+}
+
+func reportCollectorStatus(name string, err error) {
+	reportSubsystemStatusAt(name, err, subsystemStatusCollectorFailures, time.Now())
+}
+
+func reportSubsystemStatusAt(source string, err error, minFailures int, now time.Time) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return
+	}
+	subsystemStatusMu.Lock()
+	defer subsystemStatusMu.Unlock()
+	if err == nil {
+		delete(subsystemStatuses, source)
+		return
+	}
+	entry := subsystemStatuses[source]
+	if entry == nil {
+		entry = &subsystemStatusEntry{since: now}
+		subsystemStatuses[source] = entry
+	}
+	entry.failures++
+	entry.lastAt = now
+	if entry.failures >= minFailures {
+		entry.message = strings.TrimSpace(err.Error())
+	}
+}
+
+// currentSubsystemStatus returns the most recent active error as
+// "source: message" and the number of failing sources.
+func currentSubsystemStatus(now time.Time) (string, int) {
+	subsystemStatusMu.Lock()
+	defer subsystemStatusMu.Unlock()
+	latestSource := ""
+	var latest *subsystemStatusEntry
+	count := 0
+	for source, entry := range subsystemStatuses {
+		if now.Sub(entry.lastAt) > subsystemStatusExpiry {
+			delete(subsystemStatuses, source)
+			continue
+		}
+		if entry.message == "" {
+			continue
+		}
+		count++
+		if latest == nil || entry.since.After(latest.since) || (entry.since.Equal(latest.since) && source < latestSource) {
+			latestSource = source
+			latest = entry
+		}
+	}
+	if latest == nil {
+		return "", 0
+	}
+	return truncateSubsystemStatus(latestSource + ": " + latest.message), count
+}
+
+func truncateSubsystemStatus(text string) string {
+	runes := []rune(text)
+	if len(runes) <= subsystemStatusMaxLen {
+		return text
+	}
+	return string(runes[:subsystemStatusMaxLen-1]) + "…"
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSubsystemStatusReportsLatestError(t *testing.T) {
+	subsystemStatusMu.Lock()
+	subsystemStatuses = make(map[string]*subsystemStatusEntry)
+	subsystemStatusMu.Unlock()
+
+	now := time.Unix(1000, 0)
+	reportSubsystemStatusAt("librehardwaremonitor", errors.New("connection refused"), subsystemStatusCollectorFailures, now)
+	if status, count := currentSubsystemStatus(now); status != "" || count != 0 {
+		t.Fatalf("expected a single collector failure to stay hidden, got %q (%d)", status, count)
+	}
+	for idx := 1; idx < subsystemStatusCollectorFailures; idx++ {
+		reportSubsystemStatusAt("librehardwaremonitor", errors.New("connection refused"), subsystemStatusCollectorFailures, now)
+	}
+	reportSubsystemStatusAt("ax206usb", errors.New("no device"), 1, now.Add(time.Second))
+
+	status, count := currentSubsystemStatus(now.Add(time.Second))
+	if status != "ax206usb: no device" || count != 2 {
+		t.Fatalf("expected newest failure first, got %q (%d)", status, count)
+	}
+
+	reportSubsystemStatusAt("ax206usb", nil, 1, now.Add(2*time.Second))
+	if status, _ := currentSubsystemStatus(now.Add(2 * time.Second)); status != "librehardwaremonitor: connection refused" {
+		t.Fatalf("expected cleared source to fall back to the remaining error, got %q", status)
+	}
+	if status, count := currentSubsystemStatus(now.Add(subsystemStatusExpiry + time.Minute)); status != "" || count != 0 {
+		t.Fatalf("expected stale errors to expire, got %q (%d)", status, count)
+	}
+
+	reportSubsystemStatusAt("httppush", errors.New(strings.Repeat("x", 100)), 1, now)
+	if status, _ := currentSubsystemStatus(now); len([]rune(status)) != subsystemStatusMaxLen {
+		t.Fatalf("expected long errors to be truncated, got %d runes", len([]rune(status)))
+	}
+}
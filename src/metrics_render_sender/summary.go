@@ -0,0 +1,479 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+)
+
+const (
+	summaryScheduleDaily  = "daily"
+	summaryScheduleWeekly = "weekly"
+
+	defaultSummaryAt       = "23:59"
+	defaultSummaryDuration = time.Minute
+	defaultSummaryTemp     = "go_native.cpu.temp"
+	defaultSummaryLoad     = "go_native.cpu.usage"
+
+	summaryUploadMonitor   = "go_native.net.default.upload"
+	summaryDownloadMonitor = "go_native.net.default.download"
+
+	// summaryMaxSampleGap bounds the time one traffic sample is credited
+	// with, so a pause or a suspend adds no traffic.
+	summaryMaxSampleGap = 30 * time.Second
+)
+
+// summarySettings is a SummaryConfig with defaults applied.
+type summarySettings struct {
+	weekly     bool
+	weekday    time.Weekday
+	hour       int
+	minute     int
+	duration   time.Duration
+	temps      []string
+	load       string
+	archiveDir string
+}
+
+func resolveSummarySettings(cfg *SummaryConfig) summarySettings {
+	settings := summarySettings{
+		weekday:  time.Sunday,
+		hour:     23,
+		minute:   59,
+		duration: defaultSummaryDuration,
+		temps:    []string{defaultSummaryTemp},
+		load:     defaultSummaryLoad,
+	}
+	if cfg == nil {
+		return settings
+	}
+	switch schedule := strings.ToLower(strings.TrimSpace(cfg.Schedule)); schedule {
+	case "", summaryScheduleDaily:
+	case summaryScheduleWeekly:
+		settings.weekly = true
+	default:
+		logWarnModule("summary", "unknown schedule %q, using daily", cfg.Schedule)
+	}
+	if at := strings.TrimSpace(cfg.At); at != "" {
+		if hour, minute, ok := parseClockTime(at); ok {
+			settings.hour, settings.minute = hour, minute
+		} else {
+			logWarnModule("summary", "invalid time %q, using %s", cfg.At, defaultSummaryAt)
+		}
+	}
+	if weekday := strings.TrimSpace(cfg.Weekday); weekday != "" {
+		if day, ok := parseWeekdayName(weekday); ok {
+			settings.weekday = day
+		} else {
+			logWarnModule("summary", "invalid weekday %q, using sunday", cfg.Weekday)
+		}
+	}
+	if cfg.DurationSec != 0 {
+		settings.duration = time.Duration(cfg.DurationSec) * time.Second
+	}
+	if temps := normalizeSummaryMonitors(cfg.Temps); len(temps) > 0 {
+		settings.temps = temps
+	}
+	if load := normalizeMonitorAlias(cfg.Load); load != "" {
+		settings.load = load
+	}
+	settings.archiveDir = strings.TrimSpace(cfg.ArchiveDir)
+	return settings
+}
+
+func cloneSummaryConfig(cfg *SummaryConfig) *SummaryConfig {
+	if cfg == nil {
+		return nil
+	}
+	copyCfg := *cfg
+	copyCfg.Temps = append([]string(nil), cfg.Temps...)
+	return &copyCfg
+}
+
+func summaryConfigsEqual(left, right *SummaryConfig) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	return reflect.DeepEqual(*left, *right)
+}
+
+func normalizeSummaryMonitors(names []string) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = normalizeMonitorAlias(name); name != "" {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// summaryMonitorRefs lists the monitors a summary reads, so they are
+// collected even when no item shows them.
+func summaryMonitorRefs(cfg *SummaryConfig) []string {
+	settings := resolveSummarySettings(cfg)
+	refs := append([]string(nil), settings.temps...)
+	return append(refs, settings.load, summaryUploadMonitor, summaryDownloadMonitor)
+}
+
+func parseClockTime(value string) (int, int, bool) {
+	hourText, minuteText, found := strings.Cut(value, ":")
+	if !found {
+		return 0, 0, false
+	}
+	hour, err := strconv.Atoi(strings.TrimSpace(hourText))
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, false
+	}
+	minute, err := strconv.Atoi(strings.TrimSpace(minuteText))
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+func parseWeekdayName(value string) (time.Weekday, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if value == name || value == name[:3] {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// nextSummaryTime is the first scheduled time after now, in now's location.
+func nextSummaryTime(settings summarySettings, now time.Time) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), settings.hour, settings.minute, 0, 0, now.Location())
+	if settings.weekly {
+		offset := (int(settings.weekday) - int(next.Weekday()) + 7) % 7
+		next = next.AddDate(0, 0, offset)
+	}
+	for !next.After(now) {
+		if settings.weekly {
+			next = next.AddDate(0, 0, 7)
+		} else {
+			next = next.AddDate(0, 0, 1)
+		}
+	}
+	return next
+}
+
+// statsSummary is what one summary page shows.
+type statsSummary struct {
+	Weekly        bool
+	Start         time.Time
+	End           time.Time
+	Temps         []summaryPeak
+	Load          summaryPeak
+	UploadBytes   float64
+	DownloadBytes float64
+	TrafficOK     bool
+	// Alerts counts the times any monitor entered its worst zone,
+	// QuietAlerts the ones that were muted by quiet hours.
+	Alerts      int
+	QuietAlerts int
+}
+
+// summaryPeak is the highest temperature, or for the load the average, a
+// monitor reported in the period.
+type summaryPeak struct {
+	Label string
+	Unit  string
+	Value float64
+	OK    bool
+}
+
+// summaryAccumulator folds the monitor values of each rendered frame into
+// the running period.
+type summaryAccumulator struct {
+	start     time.Time
+	lastAt    time.Time
+	temps     map[string]summaryPeak
+	load      summaryPeak
+	loadSum   float64
+	loadCount int
+	upload    float64
+	download  float64
+	traffic   bool
+
+	alerting    bool
+	alerts      int
+	quietAlerts int
+}
+
+// reset starts a new period. An alert still in progress is not counted
+// again.
+func (a *summaryAccumulator) reset(now time.Time) {
+	*a = summaryAccumulator{start: now, temps: make(map[string]summaryPeak), alerting: a.alerting}
+}
+
+func (a *summaryAccumulator) sample(now time.Time, config *MonitorConfig, registry *CollectorManager, settings summarySettings) {
+	if registry == nil {
+		return
+	}
+	alerting := configHasActiveAlert(config, registry)
+	if alerting && !a.alerting {
+		a.alerts++
+		if quietHoursActive(now) {
+			a.quietAlerts++
+		}
+	}
+	a.alerting = alerting
+	for _, name := range settings.temps {
+		value, unit, label, ok := summaryMonitorNumber(registry, name)
+		if !ok {
+			continue
+		}
+		if peak := a.temps[name]; !peak.OK || value > peak.Value {
+			a.temps[name] = summaryPeak{Label: label, Unit: unit, Value: value, OK: true}
+		}
+	}
+	if value, unit, label, ok := summaryMonitorNumber(registry, settings.load); ok {
+		a.loadSum += value
+		a.loadCount++
+		a.load = summaryPeak{Label: label, Unit: unit, OK: true}
+	}
+	if gap := now.Sub(a.lastAt); !a.lastAt.IsZero() && gap > 0 && gap <= summaryMaxSampleGap {
+		if rate, ok := summaryByteRate(registry, summaryUploadMonitor); ok {
+			a.upload += rate * gap.Seconds()
+			a.traffic = true
+		}
+		if rate, ok := summaryByteRate(registry, summaryDownloadMonitor); ok {
+			a.download += rate * gap.Seconds()
+			a.traffic = true
+		}
+	}
+	a.lastAt = now
+}
+
+func (a *summaryAccumulator) summarize(now time.Time, settings summarySettings) statsSummary {
+	summary := statsSummary{
+		Weekly:        settings.weekly,
+		Start:         a.start,
+		End:           now,
+		Load:          a.load,
+		UploadBytes:   a.upload,
+		DownloadBytes: a.download,
+		TrafficOK:     a.traffic,
+		Alerts:        a.alerts,
+		QuietAlerts:   a.quietAlerts,
+	}
+	for _, name := range settings.temps {
+		peak, ok := a.temps[name]
+		if !ok {
+			peak = summaryPeak{Label: name}
+		}
+		summary.Temps = append(summary.Temps, peak)
+	}
+	if a.loadCount > 0 {
+		summary.Load.Value = a.loadSum / float64(a.loadCount)
+	} else {
+		summary.Load = summaryPeak{Label: settings.load}
+	}
+	return summary
+}
+
+func summaryMonitorNumber(registry *CollectorManager, name string) (float64, string, string, bool) {
+	item := registry.Get(name)
+	if item == nil || !item.IsAvailable() || !item.IsReady() {
+		return 0, "", "", false
+	}
+	value := item.GetValue()
+	number, ok := value.Float64()
+	if !ok {
+		return 0, "", "", false
+	}
+	label := item.GetLabel()
+	if label == "" {
+		label = name
+	}
+	return number, strings.TrimSpace(value.Unit), label, true
+}
+
+func summaryByteRate(registry *CollectorManager, name string) (float64, bool) {
+	number, unit, _, ok := summaryMonitorNumber(registry, name)
+	if !ok {
+		return 0, false
+	}
+	return convertUnitValue(number, unit, "B/s")
+}
+
+// summaryScheduler shows the summary page once per period. It is driven by
+// the render loop and, like it, not safe for concurrent use.
+type summaryScheduler struct {
+	settings  summarySettings
+	fontCache *FontCache
+	acc       summaryAccumulator
+	next      time.Time
+	until     time.Time
+	image     image.Image
+}
+
+func newSummaryScheduler(fontCache *FontCache, now time.Time) *summaryScheduler {
+	scheduler := &summaryScheduler{fontCache: fontCache}
+	scheduler.acc.reset(now)
+	return scheduler
+}
+
+// configure applies a changed summary section. The stats gathered so far
+// are kept.
+func (s *summaryScheduler) configure(cfg *SummaryConfig, now time.Time) {
+	s.settings = resolveSummarySettings(cfg)
+	s.next = nextSummaryTime(s.settings, now)
+	logInfoModule("summary", "next summary at %s", s.next.Format("2006-01-02 15:04"))
+}
+
+func (s *summaryScheduler) sample(now time.Time, config *MonitorConfig, registry *CollectorManager) {
+	s.acc.sample(now, config, registry, s.settings)
+}
+
+// frame returns the summary page while it is on screen, and whether it was
+// produced just now. The page is rendered and archived when the scheduled
+// time is reached.
+func (s *summaryScheduler) frame(now time.Time, width, height int) (image.Image, bool) {
+	if !now.Before(s.next) {
+		summary := s.acc.summarize(now, s.settings)
+		s.acc.reset(now)
+		s.next = nextSummaryTime(s.settings, now)
+		s.image = renderStatsSummary(summary, width, height, s.fontCache)
+		s.until = now.Add(s.settings.duration)
+		if dir := s.settings.archiveDir; dir != "" && s.image != nil {
+			go func(img image.Image) {
+				path, err := archiveSummaryImage(dir, now, img)
+				if err != nil {
+					logWarnModule("summary", "archive failed: %v", err)
+					return
+				}
+				logInfoModule("summary", "saved %s", path)
+			}(s.image)
+		}
+		if s.image != nil && s.settings.duration > 0 {
+			return s.image, true
+		}
+	}
+	if s.image == nil || !now.Before(s.until) {
+		s.image = nil
+		return nil, false
+	}
+	return s.image, false
+}
+
+// archiveSummaryImage writes img to summary-YYYY-MM-DD.png in dir, which is
+// relative to the config directory unless absolute.
+func archiveSummaryImage(dir string, day time.Time, img image.Image) (string, error) {
+	dir = expandHomePath(dir)
+	if !filepath.IsAbs(dir) {
+		configDir, err := getUserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(configDir, dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "summary-"+day.Format("2006-01-02")+".png")
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := png.Encode(file, img); err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	return path, file.Close()
+}
+
+type summaryRow struct {
+	label string
+	value string
+}
+
+func summaryRows(summary statsSummary) []summaryRow {
+	rows := make([]summaryRow, 0, len(summary.Temps)+4)
+	for _, peak := range summary.Temps {
+		rows = append(rows, summaryRow{label: peak.Label + " max", value: formatSummaryPeak(peak)})
+	}
+	rows = append(rows, summaryRow{label: summary.Load.Label + " avg", value: formatSummaryPeak(summary.Load)})
+	download, upload := "-", "-"
+	if summary.TrafficOK {
+		download = formatSummaryBytes(summary.DownloadBytes)
+		upload = formatSummaryBytes(summary.UploadBytes)
+	}
+	alerts := strconv.Itoa(summary.Alerts)
+	if summary.QuietAlerts > 0 {
+		alerts += fmt.Sprintf(" (%d quiet)", summary.QuietAlerts)
+	}
+	return append(rows,
+		summaryRow{label: "Network download", value: download},
+		summaryRow{label: "Network upload", value: upload},
+		summaryRow{label: "Alerts", value: alerts},
+	)
+}
+
+func formatSummaryPeak(peak summaryPeak) string {
+	if !peak.OK {
+		return "-"
+	}
+	return strconv.FormatFloat(peak.Value, 'f', 1, 64) + peak.Unit
+}
+
+func formatSummaryBytes(size float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	unitIdx := 0
+	for size >= 1024 && unitIdx < len(units)-1 {
+		size /= 1024
+		unitIdx++
+	}
+	if unitIdx == 0 {
+		return fmt.Sprintf("%.0f %s", size, units[unitIdx])
+	}
+	return fmt.Sprintf("%.1f %s", size, units[unitIdx])
+}
+
+// renderStatsSummary draws the summary page: a title with the period, then
+// one row per figure with the label on the left and the value on the right.
+func renderStatsSummary(summary statsSummary, width, height int, fontCache *FontCache) image.Image {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	dc := gg.NewContext(width, height)
+	dc.SetColor(color.Black)
+	dc.Clear()
+
+	titleFace := resolveFontFace(fontCache, max(12, height/9))
+	rowFace := resolveFontFace(fontCache, max(10, height/14))
+	padding := float64(max(4, width/32))
+	header := float64(height) / 4
+
+	title := "Daily summary"
+	if summary.Weekly {
+		title = "Weekly summary"
+	}
+	dc.SetColor(parseColor("#f8fafc"))
+	drawMetricAnchoredText(dc, titleFace, title, padding, header*0.4, 0)
+	dc.SetColor(parseColor("#94a3b8"))
+	period := summary.Start.Format("Jan 2 15:04") + " - " + summary.End.Format("Jan 2 15:04")
+	drawMetricAnchoredText(dc, rowFace, period, padding, header*0.8, 0)
+
+	rows := summaryRows(summary)
+	rowHeight := (float64(height) - header - padding) / float64(len(rows))
+	for idx, row := range rows {
+		centerY := header + rowHeight*(float64(idx)+0.5)
+		dc.SetColor(parseColor("#94a3b8"))
+		drawMetricAnchoredText(dc, rowFace, row.label, padding, centerY, 0)
+		dc.SetColor(parseColor("#f8fafc"))
+		drawMetricAnchoredText(dc, rowFace, row.value, float64(width)-padding, centerY, 1)
+	}
+	return dc.Image()
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNextSummaryTime(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	// 2026-10-14 is a Wednesday.
+	now := time.Date(2026, 10, 14, 22, 0, 0, 0, time.UTC)
+
+	daily := resolveSummarySettings(&SummaryConfig{At: "21:30"})
+	if got := nextSummaryTime(daily, now); !got.Equal(time.Date(2026, 10, 15, 21, 30, 0, 0, time.UTC)) {
+		t.Fatalf("expected tomorrow's run once today's has passed, got %v", got)
+	}
+	if got := nextSummaryTime(resolveSummarySettings(nil), now); !got.Equal(time.Date(2026, 10, 14, 23, 59, 0, 0, time.UTC)) {
+		t.Fatalf("expected today at the default 23:59, got %v", got)
+	}
+
+	weekly := resolveSummarySettings(&SummaryConfig{Schedule: "weekly", Weekday: "Mon", At: "08:00"})
+	if got := nextSummaryTime(weekly, now); !got.Equal(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected next Monday, got %v", got)
+	}
+	onTheDay := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	if got := nextSummaryTime(weekly, onTheDay); !got.Equal(onTheDay.AddDate(0, 0, 7)) {
+		t.Fatalf("expected the following week once the run is due, got %v", got)
+	}
+
+	invalid := resolveSummarySettings(&SummaryConfig{At: "25:00", Weekday: "someday"})
+	if invalid.hour != 23 || invalid.minute != 59 || invalid.weekday != time.Sunday {
+		t.Fatalf("expected defaults for invalid values, got %+v", invalid)
+	}
+}
+
+func TestSummaryAccumulatorFoldsFrames(t *testing.T) {
+	registry := NewCollectorManager()
+	temp := NewCollectItem("go_native.cpu.temp", "CPU temperature", "°C", 0, 120, 0)
+	load := NewCollectItem("go_native.cpu.usage", "CPU usage", "%", 0, 100, 0)
+	download := NewCollectItem(summaryDownloadMonitor, "Default net download", " MiB/s", 0, 0, 2)
+	for _, item := range []*CollectItem{temp, load, download} {
+		registry.items[item.GetName()] = item
+	}
+	settings := resolveSummarySettings(&SummaryConfig{})
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	var acc summaryAccumulator
+	acc.reset(start)
+	for idx, sample := range []struct{ temp, load float64 }{{50, 10}, {72, 30}, {61, 20}} {
+		temp.SetValue(sample.temp)
+		load.SetValue(sample.load)
+		download.SetValue(1.0)
+		acc.sample(start.Add(time.Duration(idx)*10*time.Second), nil, registry, settings)
+	}
+	// A gap such as a suspend credits no traffic.
+	acc.sample(start.Add(time.Hour), nil, registry, settings)
+
+	summary := acc.summarize(start.Add(2*time.Hour), settings)
+	if len(summary.Temps) != 1 || summary.Temps[0].Value != 72 || summary.Temps[0].Unit != "°C" {
+		t.Fatalf("expected a 72°C peak, got %+v", summary.Temps)
+	}
+	if !summary.Load.OK || summary.Load.Value != 20 {
+		t.Fatalf("expected a 20%% average load over the four frames, got %+v", summary.Load)
+	}
+	if want := 20.0 * 1024 * 1024; summary.DownloadBytes != want {
+		t.Fatalf("expected %v bytes downloaded, got %v", want, summary.DownloadBytes)
+	}
+
+	rows := summaryRows(summary)
+	want := []summaryRow{
+		{label: "CPU temperature max", value: "72.0°C"},
+		{label: "CPU usage avg", value: "20.0%"},
+		{label: "Network download", value: "20.0 MiB"},
+		{label: "Network upload", value: "0 B"},
+		{label: "Alerts", value: "0"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), rows)
+	}
+	for idx := range want {
+		if rows[idx] != want[idx] {
+			t.Fatalf("row %d: expected %+v, got %+v", idx, want[idx], rows[idx])
+		}
+	}
+}
+
+func TestSummaryAccumulatorCountsAlerts(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	config := &MonitorConfig{
+		ThresholdGroups: []ThresholdGroupConfig{{
+			Monitors: []string{"go_native.cpu.temp"},
+			Ranges: []ThresholdRangeConfig{
+				{Max: float64Ptr(80), Color: "#00ff00"},
+				{Min: float64Ptr(80), Color: "#ff0000"},
+			},
+		}},
+	}
+	registry := NewCollectorManager()
+	temp := NewCollectItem("go_native.cpu.temp", "CPU temperature", "°C", 0, 120, 0)
+	registry.items[temp.GetName()] = temp
+	settings := resolveSummarySettings(&SummaryConfig{})
+
+	setQuietHours(&QuietHoursConfig{Start: "23:00", End: "07:00"})
+	t.Cleanup(func() { setQuietHours(nil) })
+
+	start := time.Date(2026, 10, 14, 20, 0, 0, 0, time.UTC)
+	var acc summaryAccumulator
+	acc.reset(start)
+	for idx, value := range []float64{70, 85, 90, 70, 85} {
+		temp.SetValue(value)
+		acc.sample(start.Add(time.Duration(idx)*2*time.Hour), config, registry, settings)
+	}
+	summary := acc.summarize(start.Add(10*time.Hour), settings)
+	if summary.Alerts != 2 || summary.QuietAlerts != 1 {
+		t.Fatalf("expected 2 alerts with 1 during quiet hours, got %d and %d", summary.Alerts, summary.QuietAlerts)
+	}
+	if rows := summaryRows(summary); rows[len(rows)-1].value != "2 (1 quiet)" {
+		t.Fatalf("unexpected alerts row %+v", rows[len(rows)-1])
+	}
+}
+
+func TestSummarySchedulerShowsPageForDuration(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	now := time.Date(2026, 10, 14, 23, 58, 0, 0, time.UTC)
+	scheduler := newSummaryScheduler(nil, now)
+	scheduler.configure(&SummaryConfig{DurationSec: 30}, now)
+
+	if frame, _ := scheduler.frame(now, 64, 48); frame != nil {
+		t.Fatal("expected no summary page before the scheduled time")
+	}
+	due := now.Add(time.Minute)
+	frame, fresh := scheduler.frame(due, 64, 48)
+	if frame == nil || !fresh {
+		t.Fatal("expected a fresh summary page at the scheduled time")
+	}
+	if bounds := frame.Bounds(); bounds.Dx() != 64 || bounds.Dy() != 48 {
+		t.Fatalf("expected a 64x48 page, got %v", bounds)
+	}
+	if frame, fresh := scheduler.frame(due.Add(10*time.Second), 64, 48); frame == nil || fresh {
+		t.Fatal("expected the page to stay up without being pushed again")
+	}
+	if frame, _ := scheduler.frame(due.Add(30*time.Second), 64, 48); frame != nil {
+		t.Fatal("expected the layout back once the duration is over")
+	}
+	if !scheduler.next.Equal(due.AddDate(0, 0, 1)) {
+		t.Fatalf("expected the next summary a day later, got %v", scheduler.next)
+	}
+}
+
+func TestArchiveSummaryImage(t *testing.T) {
+	dir := t.TempDir()
+	img := renderStatsSummary(statsSummary{}, 32, 24, nil)
+	path, err := archiveSummaryImage(dir, time.Date(2026, 10, 14, 23, 59, 0, 0, time.UTC), img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "summary-2026-10-14.png") {
+		t.Fatalf("unexpected archive path %s", path)
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		t.Fatalf("expected a PNG at %s: %v", path, err)
+	}
+}
@@ -4,8 +4,21 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync"
 )
 
+// thresholdRangeStates remembers the last range index per group and monitor so
+// groups with hysteresis only switch once the value clearly left the range.
+var thresholdRangeStates = &thresholdRangeStateStore{last: make(map[string]int)}
+
+type thresholdRangeStateStore struct {
+	mu   sync.Mutex
+	last map[string]int
+	// readOnly is set while the editor previews a draft config, so its
+	// renders leave nothing behind for the config that gets applied next.
+	readOnly bool
+}
+
 func normalizeThresholdGroups(groups []ThresholdGroupConfig) []ThresholdGroupConfig {
 	normalized := make([]ThresholdGroupConfig, 0, len(groups))
 	seen := make(map[string]struct{}, len(groups))
@@ -22,6 +35,11 @@ func normalizeThresholdGroups(groups []ThresholdGroupConfig) []ThresholdGroupCon
 			Monitors: normalizeThresholdGroupMonitors(group.Monitors),
 			Ranges:   normalizeThresholdRanges(group.Ranges),
 		}
+		if group.Hysteresis > 0 && !math.IsInf(group.Hysteresis, 0) {
+			entry.Hysteresis = group.Hysteresis
+		}
+		entry.Direction = normalizeThresholdDirection(group.Direction)
+		entry.Unit = strings.TrimSpace(group.Unit)
 		if len(entry.Ranges) == 0 {
 			continue
 		}
@@ -32,6 +50,23 @@ func normalizeThresholdGroups(groups []ThresholdGroupConfig) []ThresholdGroupCon
 	return normalized
 }
 
+const thresholdDirectionLowerIsWorse = "lower_is_worse"
+
+// normalizeThresholdDirection keeps the default higher-is-worse direction as
+// the empty string so existing configs serialize unchanged.
+func normalizeThresholdDirection(direction string) string {
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case thresholdDirectionLowerIsWorse, "lower", "low", "descending":
+		return thresholdDirectionLowerIsWorse
+	default:
+		return ""
+	}
+}
+
+func thresholdGroupLowerIsWorse(group *ThresholdGroupConfig) bool {
+	return group != nil && group.Direction == thresholdDirectionLowerIsWorse
+}
+
 func normalizeThresholdGroupMonitors(monitors []string) []string {
 	if len(monitors) == 0 {
 		return nil
@@ -63,7 +98,7 @@ func normalizeThresholdRanges(ranges []ThresholdRangeConfig) []ThresholdRangeCon
 		if color == "" {
 			continue
 		}
-		entry := ThresholdRangeConfig{Color: color}
+		entry := ThresholdRangeConfig{Color: color, Label: strings.TrimSpace(raw.Label)}
 		if raw.Min != nil && !math.IsNaN(*raw.Min) && !math.IsInf(*raw.Min, 0) {
 			minValue := *raw.Min
 			entry.Min = &minValue
@@ -137,33 +172,116 @@ func findThresholdGroupForMonitor(config *MonitorConfig, monitorName string) *Th
 	return nil
 }
 
-func resolveThresholdRangeColor(group *ThresholdGroupConfig, value float64) string {
+// thresholdGroupNumber converts number, read from value, into the group's
+// unit. Numbers whose unit cannot be converted are compared as they are.
+func thresholdGroupNumber(group *ThresholdGroupConfig, value *CollectValue, number float64) float64 {
+	if group == nil || group.Unit == "" || value == nil {
+		return number
+	}
+	if converted, ok := convertUnitValue(number, value.Unit, group.Unit); ok {
+		return converted
+	}
+	return number
+}
+
+func resolveThresholdRangeColor(group *ThresholdGroupConfig, monitorName string, value float64) string {
 	if group == nil {
 		return ""
 	}
-	return resolveThresholdRangesColor(group.Ranges, value)
+	idx := resolveThresholdGroupRangeIndex(group, monitorName, value)
+	if idx < 0 {
+		return ""
+	}
+	return strings.TrimSpace(group.Ranges[idx].Color)
 }
 
 func resolveThresholdRangesColor(ranges []ThresholdRangeConfig, value float64) string {
-	if len(ranges) == 0 {
+	idx := resolveThresholdRangeIndex(ranges, value)
+	if idx < 0 {
+		return ""
+	}
+	return strings.TrimSpace(ranges[idx].Color)
+}
+
+func resolveThresholdRangeLabel(group *ThresholdGroupConfig, monitorName string, value float64) string {
+	if group == nil {
 		return ""
 	}
-	firstColor := strings.TrimSpace(ranges[0].Color)
-	lastColor := strings.TrimSpace(ranges[len(ranges)-1].Color)
-	for _, thresholdRange := range ranges {
+	idx := resolveThresholdGroupRangeIndex(group, monitorName, value)
+	if idx < 0 {
+		return ""
+	}
+	return strings.TrimSpace(group.Ranges[idx].Label)
+}
+
+// resolveThresholdRangeIndex returns the range that value falls into, clamping
+// values below the first or above the last range to the nearest end, or -1.
+func resolveThresholdRangeIndex(ranges []ThresholdRangeConfig, value float64) int {
+	if len(ranges) == 0 {
+		return -1
+	}
+	for idx, thresholdRange := range ranges {
 		if thresholdRange.Min != nil && value < *thresholdRange.Min {
 			continue
 		}
 		if thresholdRange.Max != nil && value > *thresholdRange.Max {
 			continue
 		}
-		return strings.TrimSpace(thresholdRange.Color)
+		return idx
 	}
 	if first := ranges[0]; first.Min != nil && value < *first.Min {
-		return firstColor
+		return 0
 	}
 	if last := ranges[len(ranges)-1]; last.Max != nil && value > *last.Max {
-		return lastColor
+		return len(ranges) - 1
+	}
+	return -1
+}
+
+// resolveThresholdGroupRangeIndex is resolveThresholdRangeIndex with the group's
+// hysteresis applied: the previous range is kept while value stays within its
+// bounds widened by the hysteresis band.
+func resolveThresholdGroupRangeIndex(group *ThresholdGroupConfig, monitorName string, value float64) int {
+	if group == nil {
+		return -1
+	}
+	idx := resolveThresholdRangeIndex(group.Ranges, value)
+	if group.Hysteresis <= 0 || idx < 0 {
+		return idx
+	}
+	key := group.Name + "|" + normalizeMonitorNameInput(monitorName)
+	return thresholdRangeStates.apply(key, group.Ranges, group.Hysteresis, idx, value)
+}
+
+func (s *thresholdRangeStateStore) apply(key string, ranges []ThresholdRangeConfig, hysteresis float64, idx int, value float64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if prev, ok := s.last[key]; ok && prev != idx && prev >= 0 && prev < len(ranges) {
+		if thresholdRangeContainsWithin(ranges[prev], value, hysteresis) {
+			idx = prev
+		}
+	}
+	if !s.readOnly {
+		s.last[key] = idx
+	}
+	return idx
+}
+
+// reset forgets every remembered range, as groups may have been renamed or
+// their ranges changed, and sets whether new ones are recorded.
+func (s *thresholdRangeStateStore) reset(readOnly bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = make(map[string]int)
+	s.readOnly = readOnly
+}
+
+func thresholdRangeContainsWithin(thresholdRange ThresholdRangeConfig, value float64, margin float64) bool {
+	if thresholdRange.Min != nil && value < *thresholdRange.Min-margin {
+		return false
+	}
+	if thresholdRange.Max != nil && value > *thresholdRange.Max+margin {
+		return false
 	}
-	return ""
+	return true
 }
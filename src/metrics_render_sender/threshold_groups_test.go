@@ -19,3 +19,140 @@ func TestResolveThresholdRangesColorClampsToLastRange(t *testing.T) {
 		t.Fatalf("expected last range color for overflow value, got %q", color)
 	}
 }
+
+func TestResolveThresholdRangeLabelSupportsMoreThanThreeLevels(t *testing.T) {
+	group := &ThresholdGroupConfig{
+		Name: "cpu_temp",
+		Ranges: normalizeThresholdRanges([]ThresholdRangeConfig{
+			{Max: float64Ptr(50), Color: "#22c55e", Label: " OK "},
+			{Min: float64Ptr(50), Max: float64Ptr(70), Color: "#eab308", Label: "WARM"},
+			{Min: float64Ptr(70), Max: float64Ptr(85), Color: "#f97316", Label: "WARN"},
+			{Min: float64Ptr(85), Max: float64Ptr(95), Color: "#ef4444", Label: "CRIT"},
+			{Min: float64Ptr(95), Color: "#a21caf"},
+		}),
+	}
+	cases := map[float64]string{10: "OK", 60: "WARM", 80: "WARN", 90: "CRIT", 99: ""}
+	for value, want := range cases {
+		if got := resolveThresholdRangeLabel(group, "", value); got != want {
+			t.Fatalf("value %v: expected label %q, got %q", value, want, got)
+		}
+	}
+}
+
+func TestAppendThresholdLevelLabel(t *testing.T) {
+	config := &MonitorConfig{
+		ThresholdGroups: []ThresholdGroupConfig{
+			{
+				Name:     "cpu_usage",
+				Monitors: []string{"go_native.cpu.usage"},
+				Ranges: []ThresholdRangeConfig{
+					{Max: float64Ptr(80), Color: "#22c55e", Label: "OK"},
+					{Min: float64Ptr(80), Color: "#ef4444", Label: "CRIT"},
+				},
+			},
+		},
+	}
+	got := appendThresholdLevelLabel("%", "go_native.cpu.usage", &CollectValue{Value: 92.0}, config)
+	if got != "% CRIT" {
+		t.Fatalf("expected unit with level label, got %q", got)
+	}
+	if got := appendThresholdLevelLabel("", "go_native.cpu.usage", &CollectValue{Value: 10.0}, config); got != "OK" {
+		t.Fatalf("expected bare level label, got %q", got)
+	}
+}
+
+func TestResolveThresholdGroupRangeIndexAppliesHysteresis(t *testing.T) {
+	group := &ThresholdGroupConfig{
+		Name:       "hysteresis_test",
+		Hysteresis: 2,
+		Ranges: []ThresholdRangeConfig{
+			{Max: float64Ptr(80), Color: "#ok"},
+			{Min: float64Ptr(80), Color: "#hot"},
+		},
+	}
+	steps := []struct {
+		value float64
+		want  int
+	}{
+		{79, 0},
+		{81, 0},
+		{82.5, 1},
+		{79, 1},
+		{77.5, 0},
+		{81, 0},
+	}
+	for idx, step := range steps {
+		if got := resolveThresholdGroupRangeIndex(group, "go_native.cpu.temp", step.value); got != step.want {
+			t.Fatalf("step %d value %v: expected range %d, got %d", idx, step.value, step.want, got)
+		}
+	}
+	if got := resolveThresholdGroupRangeIndex(group, "go_native.gpu.temp", 79); got != 0 {
+		t.Fatalf("expected independent state per monitor, got %d", got)
+	}
+
+	// A preview drops the remembered ranges and records none of its own.
+	thresholdRangeStates.reset(true)
+	t.Cleanup(func() { thresholdRangeStates.reset(false) })
+	if got := resolveThresholdGroupRangeIndex(group, "go_native.cpu.temp", 81); got != 1 {
+		t.Fatalf("expected the plain range after a reset, got %d", got)
+	}
+	if got := resolveThresholdGroupRangeIndex(group, "go_native.cpu.temp", 79); got != 0 {
+		t.Fatalf("expected a preview render not to be remembered, got %d", got)
+	}
+}
+
+func TestNormalizeThresholdGroupsDirection(t *testing.T) {
+	groups := normalizeThresholdGroups([]ThresholdGroupConfig{
+		{Name: "battery", Direction: " Lower_Is_Worse ", Ranges: []ThresholdRangeConfig{{Color: "#ef4444"}}},
+		{Name: "cpu", Direction: "higher_is_worse", Ranges: []ThresholdRangeConfig{{Color: "#ef4444"}}},
+	})
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].Direction != thresholdDirectionLowerIsWorse {
+		t.Fatalf("expected lower_is_worse direction, got %q", groups[0].Direction)
+	}
+	if groups[1].Direction != "" {
+		t.Fatalf("expected default direction to normalize to empty, got %q", groups[1].Direction)
+	}
+}
+
+func TestThresholdGroupUnitComparesAcrossPrefixes(t *testing.T) {
+	config := &MonitorConfig{ThresholdGroups: normalizeThresholdGroups([]ThresholdGroupConfig{{
+		Name:     "throughput",
+		Unit:     " B/s ",
+		Monitors: []string{"go_native.net.download", "go_native.disk.total_read"},
+		Ranges: []ThresholdRangeConfig{
+			{Max: float64Ptr(1 << 20), Color: "#low"},
+			{Min: float64Ptr(1 << 20), Color: "#high"},
+		},
+	}})}
+	if config.ThresholdGroups[0].Unit != "B/s" {
+		t.Fatalf("expected the unit to be trimmed, got %q", config.ThresholdGroups[0].Unit)
+	}
+	cases := []struct {
+		monitor string
+		value   *CollectValue
+		want    string
+	}{
+		{"go_native.net.download", &CollectValue{Value: 512.0, Unit: "KiB/s"}, "#low"},
+		{"go_native.net.download", &CollectValue{Value: 2048.0, Unit: "KiB/s"}, "#high"},
+		{"go_native.disk.total_read", &CollectValue{Value: 0.5, Unit: "MiB/s"}, "#low"},
+		{"go_native.disk.total_read", &CollectValue{Value: 3.0, Unit: "MiB/s"}, "#high"},
+	}
+	for _, tc := range cases {
+		number, _ := tc.value.Float64()
+		if got := resolveMonitorValueColor(nil, tc.monitor, tc.value, number, config); got != tc.want {
+			t.Errorf("%s %v %s: expected %s, got %s", tc.monitor, tc.value.Value, tc.value.Unit, tc.want, got)
+		}
+	}
+
+	group := &config.ThresholdGroups[0]
+	if got := thresholdGroupNumber(group, &CollectValue{Unit: "°C"}, 70); got != 70 {
+		t.Fatalf("expected unconvertible units to compare as they are, got %v", got)
+	}
+	group.Unit = ""
+	if got := thresholdGroupNumber(group, &CollectValue{Unit: "MiB/s"}, 3); got != 3 {
+		t.Fatalf("expected groups without a unit to compare raw numbers, got %v", got)
+	}
+}
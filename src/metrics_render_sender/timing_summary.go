@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timingSummaryInterval is how often the latency summary is logged.
+const timingSummaryInterval = 5 * time.Minute
+
+// timingSlowestCollectors is how many collectors the summary names.
+const timingSlowestCollectors = 3
+
+// timingBucketBounds are the upper bounds of the latency buckets. Durations
+// past the last bound fall into one overflow bucket.
+var timingBucketBounds = [...]time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+type latencyHistogram struct {
+	counts [len(timingBucketBounds) + 1]int64
+	count  int64
+	max    time.Duration
+}
+
+func (h *latencyHistogram) observe(duration time.Duration) {
+	if duration < 0 {
+		duration = 0
+	}
+	bucket := sort.Search(len(timingBucketBounds), func(i int) bool {
+		return duration <= timingBucketBounds[i]
+	})
+	h.counts[bucket]++
+	h.count++
+	if duration > h.max {
+		h.max = duration
+	}
+}
+
+// percentile returns the upper bound of the bucket holding quantile q,
+// capped at the largest duration seen.
+func (h *latencyHistogram) percentile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := int64(q*float64(h.count) + 0.999999)
+	if rank < 1 {
+		rank = 1
+	}
+	seen := int64(0)
+	for bucket, count := range h.counts {
+		seen += count
+		if seen < rank {
+			continue
+		}
+		if bucket < len(timingBucketBounds) && timingBucketBounds[bucket] < h.max {
+			return timingBucketBounds[bucket]
+		}
+		break
+	}
+	return h.max
+}
+
+func (h *latencyHistogram) String() string {
+	return fmt.Sprintf(
+		"n=%d p50<=%v p95<=%v p99<=%v max=%v",
+		h.count,
+		h.percentile(0.50),
+		h.percentile(0.95),
+		h.percentile(0.99),
+		h.max.Round(time.Millisecond),
+	)
+}
+
+// timingStage names the steps of a frame the summary reports on.
+type timingStage int
+
+const (
+	timingWait timingStage = iota
+	timingRender
+	timingQueue
+	timingOutput
+	timingUpdate
+	timingStageCount
+)
+
+var timingStageNames = [timingStageCount]string{"wait", "render", "queue", "output", "update"}
+
+// timingSummary collects latency histograms over one window and logs them as
+// a few info lines when the window ends, in place of per-frame debug lines.
+type timingSummary struct {
+	mu         sync.Mutex
+	interval   time.Duration
+	startedAt  time.Time
+	stages     [timingStageCount]latencyHistogram
+	collectors map[string]*latencyHistogram
+}
+
+var runtimeTimings = newTimingSummary(timingSummaryInterval)
+
+func newTimingSummary(interval time.Duration) *timingSummary {
+	return &timingSummary{interval: interval, collectors: make(map[string]*latencyHistogram)}
+}
+
+func (s *timingSummary) observe(stage timingStage, duration time.Duration) {
+	s.mu.Lock()
+	s.stages[stage].observe(duration)
+	s.mu.Unlock()
+}
+
+// observeCollector records one collector update, both in the update stage
+// and under the collector's own name.
+func (s *timingSummary) observeCollector(name string, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stages[timingUpdate].observe(duration)
+	histogram := s.collectors[name]
+	if histogram == nil {
+		histogram = &latencyHistogram{}
+		s.collectors[name] = histogram
+	}
+	histogram.observe(duration)
+}
+
+// maybeLog writes the summary once the window has run for the interval and
+// starts a new one.
+func (s *timingSummary) maybeLog(now time.Time) {
+	s.mu.Lock()
+	if s.startedAt.IsZero() {
+		s.startedAt = now
+	}
+	window := now.Sub(s.startedAt)
+	if window < s.interval {
+		s.mu.Unlock()
+		return
+	}
+	lines := s.summaryLinesLocked(window.Round(time.Second))
+	s.stages = [timingStageCount]latencyHistogram{}
+	s.collectors = make(map[string]*latencyHistogram)
+	s.startedAt = now
+	s.mu.Unlock()
+
+	for _, line := range lines {
+		logInfoModule("timing", "%s", line)
+	}
+}
+
+func (s *timingSummary) summaryLinesLocked(window time.Duration) []string {
+	lines := make([]string, 0, timingStageCount+1)
+	for stage, histogram := range s.stages {
+		if histogram.count == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s in %v: %s", timingStageNames[stage], window, histogram.String()))
+	}
+
+	names := make([]string, 0, len(s.collectors))
+	for name := range s.collectors {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		left, right := s.collectors[names[i]], s.collectors[names[j]]
+		if p95i, p95j := left.percentile(0.95), right.percentile(0.95); p95i != p95j {
+			return p95i > p95j
+		}
+		if left.max != right.max {
+			return left.max > right.max
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > timingSlowestCollectors {
+		names = names[:timingSlowestCollectors]
+	}
+	if len(names) > 0 {
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			histogram := s.collectors[name]
+			parts = append(parts, fmt.Sprintf("%s p95<=%v max=%v", name, histogram.percentile(0.95), histogram.max.Round(time.Millisecond)))
+		}
+		lines = append(lines, "slowest collectors: "+strings.Join(parts, ", "))
+	}
+	return lines
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyHistogramPercentiles(t *testing.T) {
+	var histogram latencyHistogram
+	for i := 0; i < 98; i++ {
+		histogram.observe(3 * time.Millisecond)
+	}
+	histogram.observe(40 * time.Millisecond)
+	histogram.observe(700 * time.Millisecond)
+
+	if got := histogram.percentile(0.50); got != 5*time.Millisecond {
+		t.Fatalf("expected p50 in the 5ms bucket, got %v", got)
+	}
+	if got := histogram.percentile(0.99); got != 50*time.Millisecond {
+		t.Fatalf("expected p99 in the 50ms bucket, got %v", got)
+	}
+	if got := histogram.percentile(1); got != 700*time.Millisecond {
+		t.Fatalf("expected p100 capped at the max, got %v", got)
+	}
+}
+
+func TestTimingSummaryLogsOncePerInterval(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+	summary := newTimingSummary(time.Minute)
+	startedAt := time.Now()
+	summary.maybeLog(startedAt)
+	summary.observe(timingRender, 8*time.Millisecond)
+	summary.observeCollector("go_native.cpu", 2*time.Millisecond)
+	summary.observeCollector("coolercontrol", 300*time.Millisecond)
+
+	summary.maybeLog(startedAt.Add(30 * time.Second))
+	if summary.stages[timingRender].count != 1 {
+		t.Fatal("expected the window to stay open before the interval")
+	}
+	lines := summary.summaryLinesLocked(time.Minute)
+	if len(lines) != 3 || lines[2] != "slowest collectors: coolercontrol p95<=300ms max=300ms, go_native.cpu p95<=2ms max=2ms" {
+		t.Fatalf("unexpected summary: %q", lines)
+	}
+
+	summary.maybeLog(startedAt.Add(time.Minute))
+	if summary.stages[timingRender].count != 0 || len(summary.collectors) != 0 {
+		t.Fatal("expected the window to reset after logging")
+	}
+}
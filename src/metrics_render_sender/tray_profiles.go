@@ -153,28 +153,9 @@ func (s *trayProfileMenuState) handleProfileClicks(entry *trayProfileMenuEntry)
 }
 
 func switchTrayProfile(name string) error {
-	profileName := strings.TrimSpace(name)
-	if profileName == "" {
-		return fmt.Errorf("profile name is empty")
-	}
-
-	configPath, err := getUserConfigPath()
-	if err != nil {
-		return err
-	}
-	profiles, err := GetProfileManagerWithPath(configPath)
-	if err != nil {
-		return err
-	}
-
-	cfg, err := profiles.Switch(profileName)
-	if err != nil {
-		return err
-	}
-	if err := ApplyConfigToSharedWebAPI(cfg); err != nil {
+	if err := switchActiveProfile(name); err != nil {
 		return err
 	}
-	UpdateRunningConfigStore(cfg)
-	logInfoModule("tray", "active profile switched to %s", profileName)
+	logInfoModule("tray", "active profile switched to %s", strings.TrimSpace(name))
 	return nil
 }
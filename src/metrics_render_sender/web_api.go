@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"image"
+	"math"
 	"metrics_render_sender/rtsssource"
 	"sort"
 	"strings"
@@ -19,6 +21,9 @@ type WebMonitorSnapshotItem struct {
 	Label     string `json:"label,omitempty"`
 	Text      string `json:"text"`
 	Unit      string `json:"unit,omitempty"`
+	// Stale marks a value that stopped updating, StaleSec seconds ago.
+	Stale    bool  `json:"stale,omitempty"`
+	StaleSec int64 `json:"stale_sec,omitempty"`
 }
 
 type WebSnapshotResponse struct {
@@ -56,16 +61,31 @@ type WebAPI struct {
 	lastProbeLHM  string
 	lastProbeRTSS bool
 
+	// Guarded by applyMu.
+	gpio       *gpioController
+	gpioConfig *GPIOConfig
+
+	openRGB       *openRGBSync
+	openRGBConfig *OpenRGBConfig
+
+	// Guarded by renderMu.
+	summary       *summaryScheduler
+	summaryConfig *SummaryConfig
+	splashUntil   time.Time
+	closed        bool
+
 	activityMu   sync.RWMutex
 	lastActivity time.Time
 	modeFull     bool
 	updatedAt    time.Time
 	realtimeConn int32
+	paused       atomic.Bool
 	lastEpoch    int64
+	lastTickAt   atomic.Int64
 	frameStats   webFrameRuntimeStats
 
-	outputChan chan webOutputFrame
-	outputWg   sync.WaitGroup
+	outputQueue *webFrameQueue
+	outputWg    sync.WaitGroup
 
 	stopOnce sync.Once
 	stopCh   chan struct{}
@@ -134,7 +154,7 @@ func NewWebAPI(cfg *MonitorConfig) (*WebAPI, error) {
 	runtime := &WebAPI{
 		fontCache:     fontCache,
 		previewOutput: NewMemImgOutputHandler(),
-		outputChan:    make(chan webOutputFrame, 1),
+		outputQueue:   newWebFrameQueue(),
 		valueCache:    make(map[*CollectItem]webSnapshotValueCache),
 		stopCh:        make(chan struct{}),
 		stopped:       make(chan struct{}),
@@ -143,6 +163,9 @@ func NewWebAPI(cfg *MonitorConfig) (*WebAPI, error) {
 	if err := runtime.applyConfigInternal(cfg, false); err != nil {
 		return nil, err
 	}
+	logConfigLint(runtime.config, runtime.registry)
+	SetMonitorValuesSource(runtime.monitorValues)
+	runtime.showSplash()
 
 	runtime.outputWg.Add(1)
 	go runtime.outputLoop()
@@ -182,10 +205,17 @@ func (r *WebAPI) SetRealtimeConnectionCount(count int) {
 
 func (r *WebAPI) outputLoop() {
 	defer r.outputWg.Done()
-	for frame := range r.outputChan {
+	statsLogger := frameStatsLogger{interval: time.Minute}
+	var lastShippedAt time.Time
+	for {
+		frame, ok := r.outputQueue.pop()
+		if !ok {
+			return
+		}
 		if frame.result == nil {
 			continue
 		}
+		frame = r.waitOutputFrameSlot(frame, lastShippedAt)
 		outputStart := time.Now()
 		outputFrame := frame.result.OutputFrame()
 		if outputFrame == nil {
@@ -203,20 +233,51 @@ func (r *WebAPI) outputLoop() {
 		if outputManager == nil {
 			continue
 		}
+		lastShippedAt = outputStart
+		recordFrameShipped()
+		statsLogger.maybeLog(outputStart)
 		if err := outputManager.OutputFrame(outputFrame); err != nil {
 			logDebugModule("web", "runtime output failed: %v", err)
 			continue
 		}
-		queueDelay := outputStart.Sub(frame.enqueuedAt)
-		outputDuration := time.Since(outputStart)
-		logDebugModule("web", "output=%v queue=%v", outputDuration, queueDelay)
+		runtimeTimings.observe(timingQueue, outputStart.Sub(frame.enqueuedAt))
+		runtimeTimings.observe(timingOutput, time.Since(outputStart))
+	}
+}
+
+// waitOutputFrameSlot holds frame back until max_fps allows the next one to
+// be shipped. A frame rendered in the meantime replaces it, so outputs always
+// get the newest frame.
+func (r *WebAPI) waitOutputFrameSlot(frame webOutputFrame, lastShippedAt time.Time) webOutputFrame {
+	cfg, _, _, _, _, _ := r.getRuntimeRefs()
+	if cfg == nil || lastShippedAt.IsZero() {
+		return frame
+	}
+	wait := cfg.GetOutputFrameInterval() - time.Since(lastShippedAt)
+	if wait <= 0 {
+		return frame
+	}
+	select {
+	case <-r.stopCh:
+		return frame
+	case <-time.After(wait):
+	}
+	newer, ok := r.outputQueue.takeLatest()
+	if !ok || newer.result == nil {
+		return frame
 	}
+	recordFrameSkippedLimit(1)
+	return newer
 }
 
 func (r *WebAPI) renderOnce(forceFull bool) (bool, error) {
 	r.renderMu.Lock()
 	defer r.renderMu.Unlock()
 
+	if r.paused.Load() || r.closed || time.Now().Before(r.splashUntil) {
+		return false, nil
+	}
+
 	modeFull := forceFull || r.isFullMode()
 	r.setMode(modeFull)
 	noteRenderAccess()
@@ -229,35 +290,156 @@ func (r *WebAPI) renderOnce(forceFull bool) (bool, error) {
 	waitMax := cfg.GetRenderWaitMaxDuration()
 	currentEpoch := registry.CurrentEpoch()
 	if currentEpoch > r.lastEpoch {
-		waitComplete, waitDuration := registry.WaitForEpoch(currentEpoch, waitMax)
-		logDebugModule("web", "epoch=%d wait=%v complete=%v", currentEpoch, waitDuration, waitComplete)
+		_, waitDuration := registry.WaitForEpoch(currentEpoch, waitMax)
+		runtimeTimings.observe(timingWait, waitDuration)
 		r.lastEpoch = currentEpoch
+		if r.summary != nil {
+			r.summary.sample(time.Now(), cfg, registry)
+		}
 	} else if !forceFull {
 		return false, nil
 	}
+	recordMonitorFrame(registry, time.Now())
+
+	if r.summary != nil {
+		if frame, fresh := r.summary.frame(time.Now(), cfg.Width, cfg.Height); frame != nil {
+			// The summary page stays up until its duration is over; it is
+			// only pushed again when a forced render would replace it.
+			if !fresh && !forceFull {
+				return false, nil
+			}
+			r.outputQueue.push(webOutputFrame{
+				result:     NewRenderResult(frame),
+				enqueuedAt: time.Now(),
+				modeFull:   modeFull,
+			})
+			r.setUpdatedAt(time.Now())
+			return true, nil
+		}
+	}
 
 	renderStartedAt := time.Now()
 	result, err := renderManager.Render(cfg)
 	if err != nil {
 		return false, err
 	}
-	recordRenderDuration(time.Since(renderStartedAt))
+	renderDuration := time.Since(renderStartedAt)
+	recordRenderDuration(renderDuration)
+	runtimeTimings.observe(timingRender, renderDuration)
+	recordFrameRendered()
 
-	replaced, ok := enqueueLatestWebFrame(r.outputChan, webOutputFrame{
+	dropped := r.outputQueue.push(webOutputFrame{
 		result:     result,
 		enqueuedAt: time.Now(),
 		modeFull:   modeFull,
 	})
-	if !ok {
-		logDebugModule("web", "output queue busy, skip frame")
-	} else if replaced {
-		logDebugModule("web", "output queue replaced stale frame")
-	}
+	recordFrameSkippedQueue(dropped)
 
 	r.setUpdatedAt(time.Now())
 	return true, nil
 }
 
+// SetPaused stops collection and rendering and blanks every output, or resumes
+// both with a fresh frame.
+func (r *WebAPI) SetPaused(paused bool) {
+	if r.paused.Swap(paused) == paused {
+		return
+	}
+	if registry := CurrentCollectorManager(); registry != nil {
+		registry.SetPaused(paused)
+	}
+	if !paused {
+		logInfoModule("web", "display resumed")
+		_, _ = r.renderOnce(true)
+		return
+	}
+	logInfoModule("web", "display paused")
+	cfg, _, _, _, _, _ := r.getRuntimeRefs()
+	if cfg == nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return
+	}
+	r.renderMu.Lock()
+	defer r.renderMu.Unlock()
+	r.outputQueue.push(webOutputFrame{
+		result:     NewRenderResult(newBlankFrame(cfg.Width, cfg.Height)),
+		enqueuedAt: time.Now(),
+	})
+}
+
+// resetAfterResume restarts counter based rates and output connections after
+// a system suspend, then pushes a full frame for panels that were power
+// cycled while asleep.
+func (r *WebAPI) resetAfterResume() {
+	_, _, registry, _, outputManager, _ := r.getRuntimeRefs()
+	if registry != nil {
+		registry.ResetRates("resume")
+	}
+	if outputManager != nil {
+		outputManager.Reset()
+	}
+	_, _ = r.renderOnce(true)
+}
+
+// showSplash queues the splash screen and holds rendering back while it is
+// up, so the first frames the panel shows are not the zeros of collectors
+// that have not sampled yet.
+func (r *WebAPI) showSplash() {
+	cfg, _, _, _, _, _ := r.getRuntimeRefs()
+	if cfg == nil {
+		return
+	}
+	settings := resolveSplashSettings(cfg.Splash)
+	if settings.duration <= 0 {
+		return
+	}
+	hostname, address := splashHostInfo(cfg.GetNetworkInterface())
+	frame := renderSplashScreen(settings, cfg.Width, cfg.Height, r.fontCache, hostname, Version, address)
+	if frame == nil {
+		return
+	}
+
+	r.renderMu.Lock()
+	defer r.renderMu.Unlock()
+	r.outputQueue.push(webOutputFrame{
+		result:     NewRenderResult(frame),
+		enqueuedAt: time.Now(),
+	})
+	r.splashUntil = time.Now().Add(settings.duration)
+}
+
+// showShutdownScreen replaces the last frame with the configured shutdown
+// screen and stops rendering for good. Close calls it before draining the
+// output queue, so the screen reaches the outputs before they close.
+func (r *WebAPI) showShutdownScreen() {
+	r.renderMu.Lock()
+	defer r.renderMu.Unlock()
+	r.closed = true
+
+	cfg, _, _, _, _, _ := r.getRuntimeRefs()
+	if cfg == nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return
+	}
+	settings := resolveSplashSettings(cfg.Splash)
+	var frame image.Image
+	switch settings.shutdown {
+	case shutdownScreenNone:
+		return
+	case shutdownScreenBlank:
+		SetAX206Brightness(0)
+		frame = newBlankFrame(cfg.Width, cfg.Height)
+	default:
+		frame = renderShutdownScreen(settings.shutdownText, cfg.Width, cfg.Height, r.fontCache)
+	}
+	r.outputQueue.push(webOutputFrame{
+		result:     NewRenderResult(frame),
+		enqueuedAt: time.Now(),
+	})
+}
+
+func (r *WebAPI) IsPaused() bool {
+	return r.paused.Load()
+}
+
 func (r *WebAPI) applyConfigInternal(cfg *MonitorConfig, forceMemImg bool) error {
 	r.applyMu.Lock()
 	defer r.applyMu.Unlock()
@@ -267,10 +449,15 @@ func (r *WebAPI) applyConfigInternal(cfg *MonitorConfig, forceMemImg bool) error
 	defer r.renderMu.Unlock()
 
 	configCopy := cloneMonitorConfig(cfg)
+	applyConfigOverrides(configCopy)
+	expandWidgetTemplates(configCopy)
 	normalizeMonitorConfig(configCopy)
+	applyLowPowerOutputs(configCopy)
+	thresholdRangeStates.reset(forceMemImg)
 
 	SetGlobalCollectorConfig(configCopy)
 	initializeCache()
+	reloadLayoutDefaults()
 
 	required := getRequiredMonitors(configCopy)
 	registry := GetCollectorManagerWithConfig(required, configCopy.GetNetworkInterface())
@@ -317,30 +504,67 @@ func (r *WebAPI) applyConfigInternal(cfg *MonitorConfig, forceMemImg bool) error
 	if oldOutputManager != nil && oldOutputManager != outputManager {
 		oldOutputManager.Close()
 	}
+	r.applyGPIOLocked(configCopy.GPIO)
+	r.applyOpenRGBLocked(configCopy.OpenRGB)
+	setQuietHours(configCopy.QuietHours)
+	setProcessTuning(configCopy.Process)
+	r.applySummaryLocked(configCopy.Summary)
 	r.maybeProbeDataSources(configCopy)
 	return nil
 }
 
-func enqueueLatestWebFrame(ch chan webOutputFrame, frame webOutputFrame) (bool, bool) {
-	select {
-	case ch <- frame:
-		return false, true
-	default:
+// applyGPIOLocked restarts the GPIO controller when the gpio section changed.
+// Callers must hold applyMu.
+func (r *WebAPI) applyGPIOLocked(cfg *GPIOConfig) {
+	if gpioConfigsEqual(r.gpioConfig, cfg) {
+		return
 	}
+	r.gpio.stop()
+	r.gpioConfig = cloneGPIOConfig(cfg)
+	r.gpio = startGPIOController(cfg, r.hasActiveAlert)
+}
 
-	replaced := false
-	select {
-	case <-ch:
-		replaced = true
-	default:
+// applyOpenRGBLocked restarts the OpenRGB sync when the openrgb section
+// changed. Callers must hold applyMu.
+func (r *WebAPI) applyOpenRGBLocked(cfg *OpenRGBConfig) {
+	if openRGBConfigsEqual(r.openRGBConfig, cfg) {
+		return
 	}
+	r.openRGB.stop()
+	r.openRGBConfig = cloneOpenRGBConfig(cfg)
+	r.openRGB = startOpenRGBSync(cfg, r.openRGBColor)
+}
 
-	select {
-	case ch <- frame:
-		return replaced, true
-	default:
-		return replaced, false
+// applySummaryLocked reschedules the summary page when the summary section
+// changed. Callers must hold renderMu.
+func (r *WebAPI) applySummaryLocked(cfg *SummaryConfig) {
+	if cfg == nil {
+		r.summary = nil
+		r.summaryConfig = nil
+		return
+	}
+	if r.summary != nil && summaryConfigsEqual(r.summaryConfig, cfg) {
+		return
+	}
+	now := time.Now()
+	if r.summary == nil {
+		r.summary = newSummaryScheduler(r.fontCache, now)
+	}
+	r.summaryConfig = cloneSummaryConfig(cfg)
+	r.summary.configure(r.summaryConfig, now)
+}
+
+func (r *WebAPI) openRGBColor(cfg *OpenRGBConfig) string {
+	config, _, registry, _, _, _ := r.getRuntimeRefs()
+	return resolveOpenRGBColor(cfg, config, registry)
+}
+
+func (r *WebAPI) hasActiveAlert() bool {
+	if quietHoursActive(time.Now()) {
+		return false
 	}
+	config, _, registry, _, _, _ := r.getRuntimeRefs()
+	return configHasActiveAlert(config, registry)
 }
 
 func (r *WebAPI) maybeProbeDataSources(cfg *MonitorConfig) {
@@ -429,6 +653,8 @@ func (r *WebAPI) loop() {
 			return
 		case <-ticker.C:
 		}
+		r.lastTickAt.Store(time.Now().UnixNano())
+		runtimeTimings.maybeLog(time.Now())
 
 		modeFull := r.isFullMode()
 		if modeFull != lastModeFull {
@@ -483,11 +709,16 @@ func (r *WebAPI) Snapshot() WebSnapshotResponse {
 	monitorStats := registry.Stats()
 	layout := r.snapshotLayout(registry, modeFull, required)
 	values := make(map[string]WebMonitorSnapshotItem, len(layout.entries))
+	now := time.Now()
 	for _, entry := range layout.entries {
 		if entry.monitor == nil {
 			continue
 		}
 		item := r.snapshotValueItem(entry.monitor, entry.baseLabel)
+		if age, stale := registry.ItemStaleness(entry.monitor, now); stale && item.Available {
+			item.Stale = true
+			item.StaleSec = int64(age / time.Second)
+		}
 		values[entry.name] = item
 	}
 	applyDynamicWebSnapshotLabels(values, layout.entries)
@@ -631,11 +862,12 @@ func (r *WebAPI) Close() {
 		close(r.stopCh)
 		<-r.stopped
 	})
+	r.showShutdownScreen()
+	r.outputQueue.close()
+	r.outputWg.Wait()
 
 	r.mu.Lock()
 	oldOutputManager := r.outputManager
-	outputChan := r.outputChan
-	r.outputChan = nil
 	r.outputManager = nil
 	r.outputConfigs = nil
 	r.outputTypes = nil
@@ -649,13 +881,42 @@ func (r *WebAPI) Close() {
 	r.outputHasMem = false
 	r.mu.Unlock()
 
-	if outputChan != nil {
-		close(outputChan)
-	}
-	r.outputWg.Wait()
 	if oldOutputManager != nil {
 		oldOutputManager.Close()
 	}
+
+	r.applyMu.Lock()
+	r.applyGPIOLocked(nil)
+	r.applyOpenRGBLocked(nil)
+	r.applyMu.Unlock()
+}
+
+// monitorValues is what mqtt outputs publish: every monitor with a sample,
+// numbers as numbers and text as text.
+func (r *WebAPI) monitorValues() map[string]interface{} {
+	_, _, registry, _, _, _ := r.getRuntimeRefs()
+	if registry == nil {
+		return nil
+	}
+	items := registry.GetAll()
+	values := make(map[string]interface{}, len(items))
+	for name, item := range items {
+		if item == nil || !item.IsAvailable() || !item.IsReady() {
+			continue
+		}
+		value := item.GetValue()
+		if value == nil {
+			continue
+		}
+		if number, ok := numericValue(value.Value); ok {
+			if !math.IsNaN(number) && !math.IsInf(number, 0) {
+				values[name] = number
+			}
+		} else if text, ok := value.Value.(string); ok {
+			values[name] = text
+		}
+	}
+	return values
 }
 
 func (r *WebAPI) getRuntimeRefs() (*MonitorConfig, []string, *CollectorManager, *RenderManager, *OutputManager, bool) {
@@ -820,7 +1081,7 @@ func buildStaticWebSnapshotLabel(name string, monitor *CollectItem) string {
 }
 
 func (r *WebAPI) snapshotValueItem(monitor *CollectItem, baseLabel string) WebMonitorSnapshotItem {
-	version, available, value := monitor.SnapshotState()
+	version, available, ready, value := monitor.SnapshotState()
 
 	r.mu.RLock()
 	cache, ok := r.valueCache[monitor]
@@ -839,7 +1100,9 @@ func (r *WebAPI) snapshotValueItem(monitor *CollectItem, baseLabel string) WebMo
 		Label:     baseLabel,
 		Text:      "-",
 	}
-	if available && value != nil {
+	if available && !ready {
+		item.Text = monitorWarmupPlaceholder
+	} else if available && value != nil {
 		item.Text = FormatCollectValue(value, true, "")
 		item.Unit = value.Unit
 	}
@@ -879,6 +1142,8 @@ func applyDynamicWebSnapshotLabels(values map[string]WebMonitorSnapshotItem, ent
 				item.Label = "Net " + iface + " download speed"
 			case "ip":
 				item.Label = "Net " + iface + " ip"
+			case "ip6":
+				item.Label = "Net " + iface + " ipv6"
 			case "interface":
 				item.Label = "Net " + iface + " interface"
 			}
@@ -901,6 +1166,8 @@ func applyDynamicWebSnapshotLabels(values map[string]WebMonitorSnapshotItem, ent
 				item.Label = "Disk " + diskName + " usage"
 			case "temp":
 				item.Label = "Disk " + diskName + " temperature"
+			case "health":
+				item.Label = "Disk " + diskName + " health"
 			case "busy":
 				item.Label = "Disk " + diskName + " busy"
 			case "read":
@@ -940,6 +1207,9 @@ var explicitWebSnapshotLabels = map[string]string{
 	"go_native.disk.max_busy":                  "Disk max busy",
 	"go_native.disk.max_latency":               "Disk max latency",
 	"go_native.disk.max_temp":                  "Disk max temperature",
+	"go_native.disk.total_size":                "Disks total size",
+	"go_native.disk.total_used":                "Disks total used",
+	"go_native.disk.total_free":                "Disks total free",
 	"go_native.btrfs_root.device_size":         "Btrfs root device size",
 	"go_native.btrfs_root.allocated":           "Btrfs root allocated",
 	"go_native.btrfs_root.allocated_used":      "Btrfs root allocated used",
@@ -982,6 +1252,11 @@ var explicitWebSnapshotLabels = map[string]string{
 	"go_native.system.render.avg_ms":           "Render avg ms",
 	"go_native.system.output.max_ms":           "Output max ms",
 	"go_native.system.output.avg_ms":           "Output avg ms",
+	"go_native.system.frames.rendered":         "Frames rendered",
+	"go_native.system.frames.shipped":          "Frames shipped",
+	"go_native.system.frames.skipped":          "Frames skipped",
+	"go_native.system.status":                  "Status",
+	"go_native.system.error_count":             "Failing subsystems",
 	"go_native.system.output.memimg.last_ms":   "Output memimg last ms",
 	"go_native.system.output.memimg.max_ms":    "Output memimg max ms",
 	"go_native.system.output.memimg.avg_ms":    "Output memimg avg ms",
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetOutputFrameInterval(t *testing.T) {
+	cases := []struct {
+		fps  int
+		want time.Duration
+	}{
+		{0, 0},
+		{-5, 0},
+		{10, 100 * time.Millisecond},
+		{500, time.Second / maxOutputFPS},
+	}
+	for _, tc := range cases {
+		cfg := &MonitorConfig{MaxFPS: tc.fps}
+		if got := cfg.GetOutputFrameInterval(); got != tc.want {
+			t.Fatalf("max_fps=%d: got %v want %v", tc.fps, got, tc.want)
+		}
+	}
+}
+
+func TestWaitOutputFrameSlotShipsNewestFrame(t *testing.T) {
+	runtime := &WebAPI{
+		config:      &MonitorConfig{MaxFPS: 20},
+		outputQueue: newWebFrameQueue(),
+		stopCh:      make(chan struct{}),
+	}
+	older := webOutputFrame{result: &RenderResult{}}
+	newer := webOutputFrame{result: &RenderResult{}, modeFull: true}
+	runtime.outputQueue.push(newer)
+
+	skippedBefore := frameRuntimeSnapshot().SkippedLimit
+	startedAt := time.Now()
+	got := runtime.waitOutputFrameSlot(older, startedAt)
+	if elapsed := time.Since(startedAt); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected limiter to hold the frame, waited %v", elapsed)
+	}
+	if !got.modeFull {
+		t.Fatalf("expected the newer frame to replace the held one")
+	}
+	if skipped := frameRuntimeSnapshot().SkippedLimit - skippedBefore; skipped != 1 {
+		t.Fatalf("expected the held frame to be skipped, got %d", skipped)
+	}
+
+	got = runtime.waitOutputFrameSlot(older, time.Now().Add(-time.Second))
+	if got.modeFull {
+		t.Fatalf("expected frame past its slot to ship immediately")
+	}
+}
+
+func TestWebFrameQueueKeepsNewestFrame(t *testing.T) {
+	queue := newWebFrameQueue()
+	dropped := 0
+	for sec := int64(1); sec <= 3; sec++ {
+		dropped += queue.push(webOutputFrame{enqueuedAt: time.Unix(sec, 0)})
+	}
+	if dropped != 2 {
+		t.Fatalf("expected the two older frames to be replaced, got %d", dropped)
+	}
+	if frame, ok := queue.pop(); !ok || frame.enqueuedAt.Unix() != 3 {
+		t.Fatalf("expected the newest frame, got %v", frame.enqueuedAt)
+	}
+	if _, ok := queue.takeLatest(); ok {
+		t.Fatalf("expected the mailbox to be empty after pop")
+	}
+
+	queue.close()
+	if _, ok := queue.pop(); ok {
+		t.Fatalf("expected closed queue to stop the consumer")
+	}
+	if dropped := queue.push(webOutputFrame{}); dropped != 1 {
+		t.Fatalf("expected closed queue to drop new frames")
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestWebAPISetPausedBlanksOutputAndSkipsRender(t *testing.T) {
+	initNormalizeOutputConfigTestDeps()
+
+	runtime := &WebAPI{
+		config:      &MonitorConfig{Width: 4, Height: 2},
+		outputQueue: newWebFrameQueue(),
+	}
+	runtime.SetPaused(true)
+	defer runtime.SetPaused(false)
+
+	if !runtime.IsPaused() {
+		t.Fatalf("expected runtime to be paused")
+	}
+	if frame, ok := runtime.outputQueue.takeLatest(); ok {
+		img := frame.result.Image
+		if bounds := img.Bounds(); bounds.Dx() != 4 || bounds.Dy() != 2 {
+			t.Fatalf("unexpected blank frame size: %v", bounds)
+		}
+		if got := color.RGBAModel.Convert(img.At(3, 1)).(color.RGBA); got != (color.RGBA{A: 255}) {
+			t.Fatalf("expected opaque black frame, got %+v", got)
+		}
+	} else {
+		t.Fatalf("expected a blank frame to be queued")
+	}
+	if rendered, err := runtime.renderOnce(true); rendered || err != nil {
+		t.Fatalf("expected paused runtime to skip rendering, rendered=%v err=%v", rendered, err)
+	}
+}
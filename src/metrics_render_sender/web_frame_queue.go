@@ -0,0 +1,80 @@
+package main
+
+import "sync"
+
+// webFrameQueue is a single-slot mailbox between rendering and the output
+// loop. A frame pushed while the previous one still waits replaces it, so
+// after a stalled output (e.g. a slow USB transfer) the newest frame gets
+// shown rather than a stale one.
+type webFrameQueue struct {
+	mu      sync.Mutex
+	frame   webOutputFrame
+	pending bool
+	closed  bool
+	ready   chan struct{}
+}
+
+func newWebFrameQueue() *webFrameQueue {
+	return &webFrameQueue{ready: make(chan struct{}, 1)}
+}
+
+// push stores frame and returns how many frames it dropped: the one still
+// waiting, or frame itself once the queue is closed.
+func (q *webFrameQueue) push(frame webOutputFrame) int {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return 1
+	}
+	dropped := 0
+	if q.pending {
+		dropped = 1
+	}
+	q.frame, q.pending = frame, true
+	q.mu.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return dropped
+}
+
+// pop blocks until a frame is waiting and takes it. It returns false once
+// the queue is closed and empty.
+func (q *webFrameQueue) pop() (webOutputFrame, bool) {
+	for {
+		if frame, ok := q.takeLatest(); ok {
+			return frame, true
+		}
+		q.mu.Lock()
+		closed := q.closed
+		q.mu.Unlock()
+		if closed {
+			return webOutputFrame{}, false
+		}
+		<-q.ready
+	}
+}
+
+// takeLatest takes the waiting frame without blocking.
+func (q *webFrameQueue) takeLatest() (webOutputFrame, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.pending {
+		return webOutputFrame{}, false
+	}
+	frame := q.frame
+	q.frame, q.pending = webOutputFrame{}, false
+	return frame, true
+}
+
+func (q *webFrameQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"net/http"
+	"time"
+)
+
+// The render loop ticks every webTickerInterval but a tick can block for up
+// to render_wait_max_ms (at most 10s) waiting on collectors.
+const webHealthStaleAfter = 15 * time.Second
+
+type WebHealthResponse struct {
+	Status       string         `json:"status"`
+	LoopAlive    bool           `json:"loop_alive"`
+	Paused       bool           `json:"paused"`
+	LastTickAt   string         `json:"last_tick_at,omitempty"`
+	LastRenderAt string         `json:"last_render_at,omitempty"`
+	LastOutputAt string         `json:"last_output_at,omitempty"`
+	Outputs      []OutputHealth `json:"outputs"`
+}
+
+// Health reports render loop liveness and per-output delivery state. Status
+// is "down" when the loop has stopped ticking, "degraded" when any output is
+// failing or disabled, and "ok" otherwise.
+func (r *WebAPI) Health(now time.Time) WebHealthResponse {
+	_, _, _, _, outputManager, _ := r.getRuntimeRefs()
+	resp := WebHealthResponse{
+		Paused:  r.IsPaused(),
+		Outputs: outputManager.Health(),
+	}
+	if tick := r.lastTickAt.Load(); tick > 0 {
+		tickAt := time.Unix(0, tick)
+		resp.LastTickAt = tickAt.Format(time.RFC3339)
+		resp.LoopAlive = now.Sub(tickAt) <= webHealthStaleAfter
+	}
+	if updatedAt := r.getUpdatedAt(); !updatedAt.IsZero() {
+		resp.LastRenderAt = updatedAt.Format(time.RFC3339)
+	}
+
+	var lastOutput time.Time
+	degraded := false
+	for _, item := range resp.Outputs {
+		if !item.Healthy {
+			degraded = true
+		}
+		if at, err := time.Parse(time.RFC3339, item.LastSuccessAt); err == nil && at.After(lastOutput) {
+			lastOutput = at
+		}
+	}
+	if !lastOutput.IsZero() {
+		resp.LastOutputAt = lastOutput.Format(time.RFC3339)
+	}
+
+	switch {
+	case !resp.LoopAlive:
+		resp.Status = "down"
+	case degraded && !resp.Paused:
+		resp.Status = "degraded"
+	default:
+		resp.Status = "ok"
+	}
+	return resp
+}
+
+// healthStatusCode fails the check only when the daemon itself is stuck. A
+// missing panel or unreachable push target is reported as degraded but keeps
+// 200, so a supervisor does not restart the process over it.
+func healthStatusCode(resp WebHealthResponse) int {
+	if resp.Status == "down" {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+func (s *ConfigStore) health() WebHealthResponse {
+	if s.runtime == nil {
+		return WebHealthResponse{Status: "down", Outputs: []OutputHealth{}}
+	}
+	return s.runtime.Health(time.Now())
+}
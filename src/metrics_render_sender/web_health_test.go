@@ -0,0 +1,28 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWebHealthLoopLiveness(t *testing.T) {
+	runtime := &WebAPI{}
+	now := time.Now()
+
+	resp := runtime.Health(now)
+	if resp.Status != "down" || healthStatusCode(resp) != http.StatusServiceUnavailable {
+		t.Fatalf("expected a loop that never ticked to be down: %+v", resp)
+	}
+
+	runtime.lastTickAt.Store(now.Add(-time.Second).UnixNano())
+	resp = runtime.Health(now)
+	if resp.Status != "ok" || !resp.LoopAlive || healthStatusCode(resp) != http.StatusOK {
+		t.Fatalf("expected a ticking loop without outputs to be ok: %+v", resp)
+	}
+
+	resp = runtime.Health(now.Add(webHealthStaleAfter + time.Second))
+	if resp.Status != "down" || resp.LoopAlive {
+		t.Fatalf("expected a stalled loop to be down: %+v", resp)
+	}
+}
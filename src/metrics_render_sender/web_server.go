@@ -112,6 +112,20 @@ func UpdateRunningConfigStore(cfg *MonitorConfig) {
 	store.setConfig(cfg)
 }
 
+// runningConfigEquals reports whether the running web server already holds
+// cfg, e.g. because it has just saved it.
+func runningConfigEquals(cfg *MonitorConfig) bool {
+	runningConfigMu.RLock()
+	store := runningConfigStore
+	runningConfigMu.RUnlock()
+	if store == nil || cfg == nil {
+		return false
+	}
+	current, _ := json.Marshal(store.getConfig())
+	next, _ := json.Marshal(cfg)
+	return string(current) == string(next)
+}
+
 func stopRunningWebServer(timeout time.Duration) error {
 	runningWebServerMu.Lock()
 	server := runningWebServer
@@ -312,6 +326,12 @@ func RunWebServer(options WebServerOptions) error {
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
 
+	// Health probes are not user activity, so this does not touch the runtime.
+	e.GET("/healthz", func(c echo.Context) error {
+		resp := store.health()
+		return c.JSON(healthStatusCode(resp), resp)
+	})
+
 	e.GET("/api/meta", func(c echo.Context) error {
 		store.touchRuntime()
 		return c.JSON(http.StatusOK, buildWebMetaResponse(store))
@@ -591,10 +611,37 @@ func RunWebServer(options WebServerOptions) error {
 		})
 	})
 
+	e.POST("/api/control", func(c echo.Context) error {
+		var payload struct {
+			Action string `json:"action"`
+		}
+		if err := c.Bind(&payload); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("invalid payload: %v", err)})
+		}
+		if normalizeDisplayAction(payload.Action) == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown action %q", payload.Action)})
+		}
+		store.touchRuntime()
+		result, err := runDisplayAction(payload.Action)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"ok":     true,
+			"result": result,
+		})
+	})
+
 	e.GET("/api/snapshot", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, store.snapshot())
 	})
 
+	// Other instances list this one as an "agent" host and poll it here.
+	e.GET("/api/host", func(c echo.Context) error {
+		poller := c.RealIP() + "/" + c.QueryParam("poller")
+		return c.JSON(http.StatusOK, currentLocalHostSummary(poller))
+	})
+
 	e.GET("/api/ws", func(c echo.Context) error {
 		return serveWebSocket(c, store)
 	})
@@ -726,7 +773,7 @@ func buildWebMetaResponse(store *ConfigStore) WebMetaResponse {
 		OutputTypes:          getSupportedOutputTypes(),
 		FontFamilies:         fontFamilies,
 		NetworkInterfaces:    listNetworkInterfaces(),
-		CustomMonitorTypes:   []string{"file", "mixed", "coolercontrol", "librehardwaremonitor"},
+		CustomMonitorTypes:   []string{"file", "message", "serial", "dns", "http", "ssh", "prometheus", "energy", "mixed", "coolercontrol", "librehardwaremonitor"},
 		CustomAggregateTypes: []string{"max", "min", "avg", "sum"},
 		MonitorAliasLabels:   monitorAliasLabels(),
 		ActiveProfile:        store.profiles.ActiveName(),
@@ -863,6 +910,9 @@ func collectFontFamilies(config *MonitorConfig) []string {
 }
 
 func getUserConfigPath() (string, error) {
+	if userConfigPathOverride != "" {
+		return userConfigPathOverride, nil
+	}
 	configDir, err := getUserConfigDir()
 	if err != nil {
 		return "", err
@@ -883,15 +933,16 @@ func getUserConfigDir() (string, error) {
 
 func loadUserConfigOrDefault(path string) (*MonitorConfig, error) {
 	if data, err := os.ReadFile(path); err == nil {
-		var cfg MonitorConfig
-		if err := json.Unmarshal(data, &cfg); err != nil {
+		cfg, err := decodeMonitorConfig(data)
+		if err != nil {
 			return nil, fmt.Errorf("invalid user config %s: %w", path, err)
 		}
-		normalizeMonitorConfig(&cfg)
-		return &cfg, nil
+		normalizeMonitorConfig(cfg)
+		return cfg, nil
 	}
 
 	cfg := &MonitorConfig{
+		SchemaVersion:           currentConfigSchemaVersion,
 		Name:                    "web",
 		Width:                   480,
 		Height:                  320,
@@ -919,6 +970,9 @@ func loadUserConfigOrDefault(path string) (*MonitorConfig, error) {
 			collectorCoolerControl:        {Enabled: boolPtr(false), Options: map[string]interface{}{"url": defaultCoolerControlURL}},
 			collectorLibreHardwareMonitor: {Enabled: boolPtr(false), Options: map[string]interface{}{"url": defaultLibreHardwareMonitorURL}},
 			collectorRTSS:                 {Enabled: boolPtr(false), Options: map[string]interface{}{}},
+			collectorBLE:                  {Enabled: boolPtr(false), Options: map[string]interface{}{}},
+			collectorFrameTime:            {Enabled: boolPtr(false), Options: map[string]interface{}{}},
+			collectorAudio:                {Enabled: boolPtr(false), Options: map[string]interface{}{}},
 		},
 		Items: []ItemConfig{},
 	}
@@ -971,6 +1025,9 @@ func normalizeMonitorConfig(cfg *MonitorConfig) {
 	ensureCollectorConfigDefault(cfg, collectorCustomAll, true)
 	ensureCollectorConfigDefault(cfg, collectorCoolerControl, false)
 	ensureCollectorConfigDefault(cfg, collectorLibreHardwareMonitor, false)
+	ensureCollectorConfigDefault(cfg, collectorBLE, false)
+	ensureCollectorConfigDefault(cfg, collectorFrameTime, false)
+	ensureCollectorConfigDefault(cfg, collectorAudio, false)
 	defaultRTSS := defaultRTSSCollectorEnabledForPlatform(goruntime.GOOS)
 	ensureCollectorConfigDefault(cfg, collectorRTSS, defaultRTSS)
 	if goruntime.GOOS == "windows" && configNeedsRTSS(cfg) {
@@ -1051,6 +1108,12 @@ func normalizeMonitorConfig(cfg *MonitorConfig) {
 	if cfg.RenderWaitMaxMS > cfg.RefreshInterval {
 		cfg.RenderWaitMaxMS = cfg.RefreshInterval
 	}
+	if cfg.Scale < 0 {
+		cfg.Scale = 0
+	}
+	if cfg.Scale > maxRenderScale {
+		cfg.Scale = maxRenderScale
+	}
 	if cfg.MonitorUpdateWorkers < 0 {
 		cfg.MonitorUpdateWorkers = 0
 	}
@@ -1096,6 +1159,7 @@ func normalizeMonitorConfig(cfg *MonitorConfig) {
 	}
 	ensureTypeDefaults(cfg)
 	cfg.ThresholdGroups = normalizeThresholdGroups(cfg.ThresholdGroups)
+	cfg.Groups = normalizeItemGroups(cfg.Groups)
 	normalizeStyleConfiguration(cfg)
 	setCollectorOptionDefault(cfg, collectorCoolerControl, "url", defaultCoolerControlURL)
 	setCollectorOptionDefault(cfg, collectorLibreHardwareMonitor, "url", defaultLibreHardwareMonitorURL)
@@ -1122,6 +1186,7 @@ func normalizeMonitorConfig(cfg *MonitorConfig) {
 		item.Type = itemType
 		item.Monitor = normalizeMonitorAlias(item.Monitor)
 		item.EditUIName = defaultEditUIName(item.EditUIName, idx, item)
+		item.Group = strings.TrimSpace(item.Group)
 		if !cfg.AllowCustomStyle {
 			item.CustomStyle = false
 		}
@@ -1136,6 +1201,23 @@ func normalizeMonitorConfig(cfg *MonitorConfig) {
 			item.MinValue = nil
 			item.MaxValue = nil
 			normalizeFullTableItemAttrs(item)
+		} else if item.Type == itemTypeFullHeatmap {
+			if strings.TrimSpace(item.Unit) == "" {
+				item.Unit = "auto"
+			}
+			item.Monitor = ""
+			normalizeFullHeatmapItemAttrs(item)
+		} else if item.Type == itemTypeFullVUMeter {
+			if strings.TrimSpace(item.Unit) == "" {
+				item.Unit = "auto"
+			}
+			item.Monitor = ""
+			normalizeFullVUMeterItemAttrs(item)
+		} else if item.Type == itemTypeFullTimer {
+			item.Monitor = ""
+			item.Unit = ""
+			item.MinValue = nil
+			item.MaxValue = nil
 		} else if isCollectorItemType(item.Type) {
 			if strings.TrimSpace(item.Unit) == "" {
 				item.Unit = "auto"
@@ -192,11 +192,11 @@ func readZramDeviceSnapshot(path string) (zramDeviceSnapshot, error) {
 }
 
 func readZramMMStat(path string) (zramDeviceSnapshot, error) {
-	data, err := os.ReadFile(path)
+	data, err := sensors.ReadAttr(path)
 	if err != nil {
 		return zramDeviceSnapshot{}, err
 	}
-	fields := strings.Fields(string(data))
+	fields := strings.Fields(data)
 	if len(fields) < 8 {
 		return zramDeviceSnapshot{}, fmt.Errorf("invalid zram mm_stat field count: %s", path)
 	}
@@ -224,17 +224,5 @@ func readZramMMStat(path string) (zramDeviceSnapshot, error) {
 }
 
 func readRequiredZramUint(path string) (uint64, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return 0, err
-	}
-	trimmed := strings.TrimSpace(string(data))
-	if trimmed == "" {
-		return 0, fmt.Errorf("empty zram sysfs value: %s", path)
-	}
-	value, err := strconv.ParseUint(trimmed, 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid zram sysfs value %s: %w", path, err)
-	}
-	return value, nil
+	return sensors.ReadAttrUint(path)
 }